@@ -2,11 +2,13 @@ package main
 
 import (
 	"avacado/internal/command/registry"
+	"avacado/internal/config"
 	"avacado/internal/executor"
 	"avacado/internal/observability"
 	"avacado/internal/protocol/resp"
 	"avacado/internal/server"
 	"avacado/internal/storage"
+	"avacado/internal/storage/keyspace"
 	"context"
 	"flag"
 	"fmt"
@@ -16,14 +18,35 @@ import (
 
 func main() {
 	port := 6379
+	storageConfig := storage.DefaultConfig()
+	maxMemory := "0"
+	maxMemoryPolicy := string(keyspace.NoEviction)
 	flag.IntVar(&port, "port", 6379, "--port")
+	flag.IntVar(&storageConfig.Databases, "databases", storage.DefaultDatabaseCount, "--databases")
+	flag.StringVar(&maxMemory, "maxmemory", maxMemory, "--maxmemory, e.g. 100mb")
+	flag.StringVar(&maxMemoryPolicy, "maxmemory-policy", maxMemoryPolicy, "--maxmemory-policy")
+	flag.IntVar(&storageConfig.Sets.MaxIntsetEntries, "set-max-intset-entries", storageConfig.Sets.MaxIntsetEntries, "--set-max-intset-entries")
+	flag.IntVar(&storageConfig.Sets.MaxListpackEntries, "set-max-listpack-entries", storageConfig.Sets.MaxListpackEntries, "--set-max-listpack-entries")
+	flag.IntVar(&storageConfig.Sets.MaxListpackValue, "set-max-listpack-value", storageConfig.Sets.MaxListpackValue, "--set-max-listpack-value")
+	flag.IntVar(&storageConfig.ZSets.MaxListpackEntries, "zset-max-listpack-entries", storageConfig.ZSets.MaxListpackEntries, "--zset-max-listpack-entries")
+	flag.IntVar(&storageConfig.ZSets.MaxListpackValue, "zset-max-listpack-value", storageConfig.ZSets.MaxListpackValue, "--zset-max-listpack-value")
+	flag.IntVar(&storageConfig.Streams.NodeMaxBytes, "stream-node-max-bytes", storageConfig.Streams.NodeMaxBytes, "--stream-node-max-bytes")
+	flag.IntVar(&storageConfig.Streams.NodeMaxEntries, "stream-node-max-entries", storageConfig.Streams.NodeMaxEntries, "--stream-node-max-entries")
 	flag.Parse()
 	logger := observability.NewLogger(observability.LoggerConfig{
 		Level:  0,
 		Format: "json",
 	})
-	store := storage.NewDefaultStorage()
-	exec := executor.New(store)
+	var err error
+	if storageConfig.MaxMemory, err = config.ParseMemory(maxMemory); err != nil {
+		logger.Error("invalid maxmemory", "error", err.Error())
+		os.Exit(1)
+	}
+	if storageConfig.MaxMemoryPolicy, err = keyspace.ParseEvictionPolicy(maxMemoryPolicy); err != nil {
+		logger.Error("invalid maxmemory-policy", "error", err.Error())
+		os.Exit(1)
+	}
+	exec := executor.New(storage.NewDefaultDatabases(storageConfig))
 	go exec.Run(context.Background())
 	s := server.NewServer(
 		resp.NewRespProtocol(),
@@ -0,0 +1,144 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestType_ReportsEveryType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "type:string", "v", 0)
+	testClient.RPush(ctx, "type:list", "v")
+	testClient.HSet(ctx, "type:hash", "f", "v")
+	testClient.SAdd(ctx, "type:set", "v")
+	testClient.Do(ctx, "ZADD", "type:zset", "1", "v")
+	testClient.Do(ctx, "XADD", "type:stream", "*", "f", "v")
+
+	for key, expected := range map[string]string{
+		"type:string":  "string",
+		"type:list":    "list",
+		"type:hash":    "hash",
+		"type:set":     "set",
+		"type:zset":    "zset",
+		"type:stream":  "stream",
+		"type:missing": "none",
+	} {
+		keyType, err := testClient.Type(ctx, key).Result()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, keyType, key)
+	}
+}
+
+func TestKeys_MatchesPatternAcrossTypes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "keys:a", "v", 0)
+	testClient.RPush(ctx, "keys:b", "v")
+	testClient.HSet(ctx, "keys:c", "f", "v")
+	testClient.Set(ctx, "keys:expiring", "v", time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	keys, err := testClient.Keys(ctx, "keys:*").Result()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"keys:a", "keys:b", "keys:c"}, keys)
+
+	keys, err = testClient.Keys(ctx, "keys:[ab]").Result()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"keys:a", "keys:b"}, keys)
+}
+
+func TestScan_WalksTheKeyspace(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	expected := make([]string, 0)
+	for _, key := range []string{"scan:1", "scan:2", "scan:3"} {
+		testClient.Set(ctx, key, "v", 0)
+		expected = append(expected, key)
+	}
+	testClient.RPush(ctx, "scan:list", "v")
+	expected = append(expected, "scan:list")
+
+	var keys []string
+	iter := testClient.Scan(ctx, 0, "scan:*", 2).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	assert.NoError(t, iter.Err())
+	assert.ElementsMatch(t, expected, keys)
+
+	keys = nil
+	typeIter := testClient.ScanType(ctx, 0, "scan:*", 2, "list").Iterator()
+	for typeIter.Next(ctx) {
+		keys = append(keys, typeIter.Val())
+	}
+	assert.NoError(t, typeIter.Err())
+	assert.Equal(t, []string{"scan:list"}, keys)
+
+	err := testClient.Do(ctx, "SCAN", "notacursor").Err()
+	assert.EqualError(t, err, "ERR invalid cursor")
+}
+
+func TestRandomKey_ReturnsAnExistingKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "randomkey:1", "v", 0)
+
+	key, err := testClient.RandomKey(ctx).Result()
+	assert.NoError(t, err)
+	count, err := testClient.Exists(ctx, key).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+// TestDBSize_CountsLiveKeys is not parallel so no other test changes the keyspace while it runs.
+func TestDBSize_CountsLiveKeys(t *testing.T) {
+	ctx := context.Background()
+	before, err := testClient.DBSize(ctx).Result()
+	assert.NoError(t, err)
+
+	testClient.Set(ctx, "dbsize:string", "v", 0)
+	testClient.RPush(ctx, "dbsize:list", "v")
+	testClient.HSet(ctx, "dbsize:hash", "f", "v")
+	testClient.Set(ctx, "dbsize:expiring", "v", time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	after, err := testClient.DBSize(ctx).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, before+3, after)
+}
+
+func TestScan_PagesThroughALargeKeyspace(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	expected := make([]string, 0, 500)
+	for i := 0; i < 500; i++ {
+		key := fmt.Sprintf("scanpage:%d", i)
+		testClient.Set(ctx, key, "v", 0)
+		expected = append(expected, key)
+	}
+
+	seen := make(map[string]bool)
+	pages := 0
+	cursor := uint64(0)
+	for {
+		keys, next, err := testClient.Scan(ctx, cursor, "scanpage:*", 20).Result()
+		assert.NoError(t, err)
+		for _, key := range keys {
+			seen[key] = true
+		}
+		pages++
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	assert.Greater(t, pages, 1)
+	for _, key := range expected {
+		assert.True(t, seen[key], key)
+	}
+}
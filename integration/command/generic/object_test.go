@@ -0,0 +1,48 @@
+package generic
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObject_EncodingFollowsTheValue(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "object:int", "12345", 0)
+	testClient.Set(ctx, "object:raw", "hello", 0)
+	testClient.RPush(ctx, "object:list", "v")
+	testClient.HSet(ctx, "object:small", "f", "v")
+	testClient.HSet(ctx, "object:large", "f", strings.Repeat("v", 1024))
+
+	for key, expected := range map[string]string{
+		"object:int":   "int",
+		"object:raw":   "raw",
+		"object:list":  "quicklist",
+		"object:small": "listpack",
+		"object:large": "hashtable",
+	} {
+		encoding, err := testClient.ObjectEncoding(ctx, key).Result()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, encoding, key)
+	}
+	assert.Equal(t, redis.Nil, testClient.ObjectEncoding(ctx, "object:missing").Err())
+}
+
+func TestObject_IdleTimeFreqAndRefCount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "object:used", "v", 0)
+	for i := 0; i < 10; i++ {
+		testClient.Get(ctx, "object:used")
+	}
+
+	assert.Equal(t, int64(0), int64(testClient.ObjectIdleTime(ctx, "object:used").Val().Seconds()))
+	assert.Equal(t, int64(1), testClient.ObjectRefCount(ctx, "object:used").Val())
+	freq, err := testClient.ObjectFreq(ctx, "object:used").Result()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, freq, int64(5))
+}
@@ -0,0 +1,109 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRename_MovesEveryTypeWithItsTTL(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "rename:string", "v", time.Hour)
+	testClient.RPush(ctx, "rename:list", "a", "b")
+	testClient.HSet(ctx, "rename:hash", "f", "v")
+
+	for _, key := range []string{"rename:string", "rename:list", "rename:hash"} {
+		err := testClient.Rename(ctx, key, key+":new").Err()
+		assert.NoError(t, err)
+		count, _ := testClient.Exists(ctx, key).Result()
+		assert.Equal(t, int64(0), count)
+	}
+
+	val, _ := testClient.Get(ctx, "rename:string:new").Result()
+	assert.Equal(t, "v", val)
+	ttl, _ := testClient.TTL(ctx, "rename:string:new").Result()
+	assert.Greater(t, ttl, 59*time.Minute)
+	list, _ := testClient.LRange(ctx, "rename:list:new", 0, -1).Result()
+	assert.Equal(t, []string{"a", "b"}, list)
+	hash, _ := testClient.HGetAll(ctx, "rename:hash:new").Result()
+	assert.Equal(t, map[string]string{"f": "v"}, hash)
+
+	err := testClient.Rename(ctx, "rename:missing", "rename:other").Err()
+	assert.EqualError(t, err, "ERR no such key")
+}
+
+func TestRenameNX_DoesNotOverwrite(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "renamenx:a", "a", 0)
+	testClient.RPush(ctx, "renamenx:b", "b")
+
+	ok, err := testClient.RenameNX(ctx, "renamenx:a", "renamenx:b").Result()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = testClient.RenameNX(ctx, "renamenx:a", "renamenx:c").Result()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	val, _ := testClient.Get(ctx, "renamenx:c").Result()
+	assert.Equal(t, "a", val)
+}
+
+func TestCopy_IsIndependentOfTheSource(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.RPush(ctx, "copy:list", "a", "b", "c")
+	testClient.Expire(ctx, "copy:list", time.Hour)
+	testClient.HSet(ctx, "copy:hash", "f", "v")
+	bigHash := make([]any, 0, 400)
+	for i := 0; i < 200; i++ {
+		bigHash = append(bigHash, fmt.Sprintf("f%d", i), "v")
+	}
+	testClient.HSet(ctx, "copy:bighash", bigHash...)
+
+	for _, key := range []string{"copy:list", "copy:hash", "copy:bighash"} {
+		copied, err := testClient.Copy(ctx, key, key+":copy", 0, false).Result()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), copied)
+	}
+
+	ttl, _ := testClient.TTL(ctx, "copy:list:copy").Result()
+	assert.Greater(t, ttl, 59*time.Minute)
+
+	testClient.LPop(ctx, "copy:list:copy")
+	testClient.HSet(ctx, "copy:hash:copy", "f", "changed")
+	testClient.HDel(ctx, "copy:bighash:copy", "f0")
+
+	list, _ := testClient.LRange(ctx, "copy:list", 0, -1).Result()
+	assert.Equal(t, []string{"a", "b", "c"}, list)
+	val, _ := testClient.HGet(ctx, "copy:hash", "f").Result()
+	assert.Equal(t, "v", val)
+	all, _ := testClient.HGetAll(ctx, "copy:bighash").Result()
+	assert.Len(t, all, 200)
+	all, _ = testClient.HGetAll(ctx, "copy:bighash:copy").Result()
+	assert.Len(t, all, 199)
+}
+
+func TestCopy_ReplaceAndErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "copyreplace:src", "new", 0)
+	testClient.Set(ctx, "copyreplace:dst", "old", 0)
+
+	copied, err := testClient.Copy(ctx, "copyreplace:src", "copyreplace:dst", 0, false).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), copied)
+
+	copied, err = testClient.Copy(ctx, "copyreplace:src", "copyreplace:dst", 0, true).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), copied)
+	val, _ := testClient.Get(ctx, "copyreplace:dst").Result()
+	assert.Equal(t, "new", val)
+
+	err = testClient.Copy(ctx, "copyreplace:src", "copyreplace:src", 0, false).Err()
+	assert.EqualError(t, err, "ERR source and destination objects are the same")
+}
@@ -0,0 +1,156 @@
+package generic
+
+import (
+	"avacado/integration"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var testClient *redis.Client
+
+func TestMain(m *testing.M) {
+	shutdown, err := integration.StartNewServer(6004)
+	if err != nil {
+		panic(err)
+	}
+
+	testClient = redis.NewClient(&redis.Options{
+		Addr:     "localhost:6004",
+		Password: "",
+		DB:       0,
+	})
+
+	code := m.Run()
+
+	if err := testClient.Close(); err != nil {
+		panic(err)
+	}
+	shutdown()
+	os.Exit(code)
+}
+
+const wrongTypeError = "WRONGTYPE Operation against a key holding the wrong kind of value"
+
+func TestWrongType_ListCommandOnString(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.Set(ctx, "wt_string", "x", 0)
+
+	err := testClient.LPush(ctx, "wt_string", "y").Err()
+	assert.EqualError(t, err, wrongTypeError)
+
+	err = testClient.LIndex(ctx, "wt_string", 0).Err()
+	assert.EqualError(t, err, wrongTypeError)
+
+	val, err := testClient.Get(ctx, "wt_string").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "x", val)
+}
+
+func TestWrongType_BlockingListPopOnString(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.Set(ctx, "wt_blocking", "x", 0)
+
+	err := testClient.BLPop(ctx, time.Second, "wt_blocking").Err()
+	assert.EqualError(t, err, wrongTypeError)
+
+	err = testClient.BRPop(ctx, time.Second, "wt_blocking").Err()
+	assert.EqualError(t, err, wrongTypeError)
+}
+
+func TestWrongType_StringCommandOnList(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.RPush(ctx, "wt_list", "a")
+
+	err := testClient.Get(ctx, "wt_list").Err()
+	assert.EqualError(t, err, wrongTypeError)
+
+	err = testClient.Incr(ctx, "wt_list").Err()
+	assert.EqualError(t, err, wrongTypeError)
+
+	err = testClient.HSet(ctx, "wt_list", "f", "v").Err()
+	assert.EqualError(t, err, wrongTypeError)
+}
+
+func TestWrongType_HashCommandsOnString(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.Set(ctx, "wt_hash_string", "x", 0)
+
+	err := testClient.HGet(ctx, "wt_hash_string", "f").Err()
+	assert.EqualError(t, err, wrongTypeError)
+
+	err = testClient.HGetAll(ctx, "wt_hash_string").Err()
+	assert.EqualError(t, err, wrongTypeError)
+
+	err = testClient.HMGet(ctx, "wt_hash_string", "f").Err()
+	assert.EqualError(t, err, wrongTypeError)
+}
+
+func TestWrongType_SetOverwritesAnyType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "wt_overwrite", "f", "v")
+
+	err := testClient.SetArgs(ctx, "wt_overwrite", "x", redis.SetArgs{Get: true}).Err()
+	assert.EqualError(t, err, wrongTypeError)
+
+	err = testClient.Set(ctx, "wt_overwrite", "x", 0).Err()
+	assert.NoError(t, err)
+
+	val, err := testClient.Get(ctx, "wt_overwrite").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "x", val)
+}
+
+func TestDelAndExists_WorkForEveryType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.Set(ctx, "de_string", "x", 0)
+	testClient.RPush(ctx, "de_list", "a")
+	testClient.HSet(ctx, "de_hash", "f", "v")
+
+	count, err := testClient.Exists(ctx, "de_string", "de_list", "de_hash", "de_missing").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = testClient.Del(ctx, "de_string", "de_list", "de_hash").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = testClient.Exists(ctx, "de_string", "de_list", "de_hash").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	length, err := testClient.LLen(ctx, "de_list").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), length)
+}
+
+func TestExists_EmptiedListIsRemoved(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.RPush(ctx, "de_emptied", "a")
+	testClient.LPop(ctx, "de_emptied")
+
+	count, err := testClient.Exists(ctx, "de_emptied").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	err = testClient.Set(ctx, "de_emptied", "x", 0).Err()
+	assert.NoError(t, err)
+}
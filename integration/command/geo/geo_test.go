@@ -0,0 +1,155 @@
+package geo
+
+import (
+	"avacado/integration"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testClient *redis.Client
+
+func TestMain(m *testing.M) {
+	shutdown, err := integration.StartNewServer(6013)
+	if err != nil {
+		panic(err)
+	}
+
+	testClient = redis.NewClient(&redis.Options{
+		Addr:     "localhost:6013",
+		Password: "",
+		DB:       0,
+	})
+
+	code := m.Run()
+
+	if err := testClient.Close(); err != nil {
+		panic(err)
+	}
+	shutdown()
+	os.Exit(code)
+}
+
+// addSicily adds the locations of the examples of the Redis documentation to key.
+func addSicily(t *testing.T, key string) {
+	added, err := testClient.GeoAdd(context.Background(), key,
+		&redis.GeoLocation{Name: "Palermo", Longitude: 13.361389, Latitude: 38.115556},
+		&redis.GeoLocation{Name: "Catania", Longitude: 15.087269, Latitude: 37.502669},
+		&redis.GeoLocation{Name: "edge1", Longitude: 12.758489, Latitude: 38.788135},
+		&redis.GeoLocation{Name: "edge2", Longitude: 17.241510, Latitude: 38.788135},
+	).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(4), added)
+}
+
+func TestGeoAddScoresWithGeohash(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addSicily(t, "geo:scores")
+
+	score, err := testClient.ZScore(ctx, "geo:scores", "Palermo").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, float64(3479099956230698), score)
+
+	changed, err := testClient.Do(ctx, "GEOADD", "geo:scores", "XX", "CH", "13.5", "38.1", "Palermo", "1", "1", "Rome").Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), changed)
+	_, err = testClient.ZScore(ctx, "geo:scores", "Rome").Result()
+	assert.ErrorIs(t, err, redis.Nil)
+
+	err = testClient.GeoAdd(ctx, "geo:scores", &redis.GeoLocation{Name: "pole", Longitude: 0, Latitude: 89}).Err()
+	assert.EqualError(t, err, "ERR invalid longitude,latitude pair 0.000000,89.000000")
+}
+
+func TestGeoPosDistAndHash(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addSicily(t, "geo:sicily")
+
+	positions, err := testClient.GeoPos(ctx, "geo:sicily", "Palermo", "missing").Result()
+	assert.NoError(t, err)
+	require.Len(t, positions, 2)
+	assert.Equal(t, 13.36138933897018433, positions[0].Longitude)
+	assert.Equal(t, 38.11555639549629859, positions[0].Latitude)
+	assert.Nil(t, positions[1])
+
+	distance, err := testClient.GeoDist(ctx, "geo:sicily", "Palermo", "Catania", "km").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 166.2742, distance)
+	_, err = testClient.GeoDist(ctx, "geo:sicily", "Palermo", "missing", "").Result()
+	assert.ErrorIs(t, err, redis.Nil)
+
+	hashes, err := testClient.GeoHash(ctx, "geo:sicily", "Palermo", "Catania").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sqc8b49rny0", "sqdtr74hyu0"}, hashes)
+}
+
+func TestGeoSearch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addSicily(t, "geo:search")
+
+	names, err := testClient.GeoSearch(ctx, "geo:search", &redis.GeoSearchQuery{
+		Longitude: 15, Latitude: 37, Radius: 200, RadiusUnit: "km", Sort: "ASC",
+	}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Catania", "Palermo"}, names)
+
+	locations, err := testClient.GeoSearchLocation(ctx, "geo:search", &redis.GeoSearchLocationQuery{
+		GeoSearchQuery: redis.GeoSearchQuery{
+			Longitude: 15, Latitude: 37, BoxWidth: 400, BoxHeight: 400, BoxUnit: "km", Sort: "ASC",
+		},
+		WithCoord: true, WithDist: true, WithHash: true,
+	}).Result()
+	assert.NoError(t, err)
+	require.Len(t, locations, 4)
+	assert.Equal(t, redis.GeoLocation{
+		Name: "Catania", Longitude: 15.08726745843887329, Latitude: 37.50266842333162032,
+		Dist: 56.4413, GeoHash: 3479447370796909,
+	}, locations[0])
+	assert.Equal(t, "edge1", locations[3].Name)
+	assert.Equal(t, 279.7405, locations[3].Dist)
+
+	names, err = testClient.GeoSearch(ctx, "geo:search", &redis.GeoSearchQuery{
+		Member: "Palermo", Radius: 100, RadiusUnit: "km", Sort: "DESC", Count: 1,
+	}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"edge1"}, names)
+
+	err = testClient.GeoSearch(ctx, "geo:search", &redis.GeoSearchQuery{Member: "Rome", Radius: 1}).Err()
+	assert.EqualError(t, err, "ERR could not decode requested zset member")
+	names, err = testClient.GeoSearch(ctx, "geo:missing", &redis.GeoSearchQuery{Member: "Rome", Radius: 1}).Result()
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestGeoSearchStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addSicily(t, "geo:source")
+	require.NoError(t, testClient.Set(ctx, "geo:destination", "string", 0).Err())
+
+	stored, err := testClient.GeoSearchStore(ctx, "geo:source", "geo:destination", &redis.GeoSearchStoreQuery{
+		GeoSearchQuery: redis.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 200, RadiusUnit: "km"},
+		StoreDist:      true,
+	}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), stored)
+	members, err := testClient.ZRangeWithScores(ctx, "geo:destination", 0, -1).Result()
+	assert.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, "Catania", members[0].Member)
+	assert.InDelta(t, 56.4413, members[0].Score, 0.0001)
+
+	stored, err = testClient.GeoSearchStore(ctx, "geo:source", "geo:destination", &redis.GeoSearchStoreQuery{
+		GeoSearchQuery: redis.GeoSearchQuery{Longitude: 0, Latitude: 0, Radius: 1, RadiusUnit: "km"},
+	}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), stored)
+	exists, _ := testClient.Exists(ctx, "geo:destination").Result()
+	assert.Equal(t, int64(0), exists)
+}
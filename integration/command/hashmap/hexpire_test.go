@@ -0,0 +1,85 @@
+package hashmap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHExpire_SetsFieldExpiry verifies that HEXPIRE replies per field and that the field expires.
+func TestHExpire_SetsFieldExpiry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "hexpire_hash1", "f1", "v1", "f2", "v2")
+
+	result, err := testClient.HPExpire(ctx, "hexpire_hash1", 100*time.Millisecond, "f1", "missing").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, -2}, result)
+
+	assert.Eventually(t, func() bool {
+		all, _ := testClient.HGetAll(ctx, "hexpire_hash1").Result()
+		return len(all) == 1 && all["f2"] == "v2"
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+// TestHExpire_Conditions verifies NX, XX, GT and LT.
+func TestHExpire_Conditions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "hexpire_hash2", "f1", "v1")
+
+	result, err := testClient.HExpireWithArgs(ctx, "hexpire_hash2", time.Hour, redis.HExpireArgs{XX: true}, "f1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{0}, result)
+	result, _ = testClient.HExpireWithArgs(ctx, "hexpire_hash2", time.Hour, redis.HExpireArgs{NX: true}, "f1").Result()
+	assert.Equal(t, []int64{1}, result)
+	result, _ = testClient.HExpireWithArgs(ctx, "hexpire_hash2", 2*time.Hour, redis.HExpireArgs{LT: true}, "f1").Result()
+	assert.Equal(t, []int64{0}, result)
+	result, _ = testClient.HExpireWithArgs(ctx, "hexpire_hash2", 2*time.Hour, redis.HExpireArgs{GT: true}, "f1").Result()
+	assert.Equal(t, []int64{1}, result)
+}
+
+// TestHExpire_PastTimeDeletesFields verifies that an expiry in the past deletes the fields and the emptied key.
+func TestHExpire_PastTimeDeletesFields(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "hexpire_hash3", "f1", "v1")
+
+	result, err := testClient.HExpireAt(ctx, "hexpire_hash3", time.Now().Add(-time.Hour), "f1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2}, result)
+	assert.Equal(t, int64(0), testClient.Exists(ctx, "hexpire_hash3").Val())
+}
+
+// TestHExpire_Errors verifies the errors replied for invalid arguments.
+func TestHExpire_Errors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	err := testClient.Do(ctx, "HEXPIRE", "hexpire_hash4", "-1", "FIELDS", "1", "f1").Err()
+	assert.EqualError(t, err, "ERR invalid expire time, must be >= 0")
+	err = testClient.Do(ctx, "HEXPIRE", "hexpire_hash4", "10", "FIELDS", "2", "f1").Err()
+	assert.EqualError(t, err, "ERR The `numfields` parameter must match the number of arguments")
+	err = testClient.Do(ctx, "HEXPIRE", "hexpire_hash4", "10", "NUMFIELDS", "1", "f1").Err()
+	assert.EqualError(t, err, "ERR Mandatory argument FIELDS is missing or not at the right position")
+}
+
+// TestHExpire_ActiveExpiry verifies that expired fields are reclaimed without being accessed.
+func TestHExpire_ActiveExpiry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "hexpire_hash5", "f1", "v1")
+	testClient.HPExpire(ctx, "hexpire_hash5", 50*time.Millisecond, "f1")
+
+	assert.Eventually(t, func() bool {
+		keys, _ := testClient.Keys(ctx, "hexpire_hash5").Result()
+		return len(keys) == 0
+	}, 3*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,34 @@
+package hashmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHGetDel_ReturnsAndDeletesFields verifies that HGETDEL replies the values and deletes the fields.
+func TestHGetDel_ReturnsAndDeletesFields(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "hgetdel_hash1", "f1", "v1", "f2", "v2")
+
+	vals, err := testClient.Do(ctx, "HGETDEL", "hgetdel_hash1", "FIELDS", "2", "f1", "missing").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"v1", nil}, vals)
+	assert.Equal(t, map[string]string{"f2": "v2"}, testClient.HGetAll(ctx, "hgetdel_hash1").Val())
+}
+
+// TestHGetDel_RemovesEmptiedKey verifies that deleting the last field removes the key.
+func TestHGetDel_RemovesEmptiedKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "hgetdel_hash2", "f1", "v1")
+
+	vals, err := testClient.HGetDel(ctx, "hgetdel_hash2", "f1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1"}, vals)
+	assert.Equal(t, int64(0), testClient.Exists(ctx, "hgetdel_hash2").Val())
+}
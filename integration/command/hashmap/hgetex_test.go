@@ -0,0 +1,43 @@
+package hashmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHGetEx_SetsAndRemovesExpiry verifies that HGETEX replies the values and changes the expiry.
+func TestHGetEx_SetsAndRemovesExpiry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "hgetex_hash1", "f1", "v1", "f2", "v2")
+
+	vals, err := testClient.HGetEXWithArgs(ctx, "hgetex_hash1", &redis.HGetEXOptions{ExpirationType: redis.HGetEXExpirationEX, ExpirationVal: 100}, "f1", "f2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1", "v2"}, vals)
+	assert.Equal(t, []int64{100, 100}, testClient.HTTL(ctx, "hgetex_hash1", "f1", "f2").Val())
+
+	vals, err = testClient.HGetEX(ctx, "hgetex_hash1", "f1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1"}, vals)
+	assert.Equal(t, []int64{100}, testClient.HTTL(ctx, "hgetex_hash1", "f1").Val())
+
+	_, err = testClient.HGetEXWithArgs(ctx, "hgetex_hash1", &redis.HGetEXOptions{ExpirationType: redis.HGetEXExpirationPERSIST}, "f1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{-1, 100}, testClient.HTTL(ctx, "hgetex_hash1", "f1", "f2").Val())
+}
+
+// TestHGetEx_MissingFields verifies that missing fields are replied as nil.
+func TestHGetEx_MissingFields(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "hgetex_hash2", "f1", "v1")
+
+	vals, err := testClient.Do(ctx, "HGETEX", "hgetex_hash2", "PX", "100000", "FIELDS", "2", "f1", "missing").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"v1", nil}, vals)
+}
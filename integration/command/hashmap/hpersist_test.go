@@ -0,0 +1,37 @@
+package hashmap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHPersist_RemovesFieldExpiry verifies HPERSIST replies per field and removes the expiry.
+func TestHPersist_RemovesFieldExpiry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "hpersist_hash1", "f1", "v1", "f2", "v2")
+	testClient.HExpire(ctx, "hpersist_hash1", time.Hour, "f1")
+
+	result, err := testClient.HPersist(ctx, "hpersist_hash1", "f1", "f2", "missing").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, -1, -2}, result)
+	assert.Equal(t, []int64{-1}, testClient.HTTL(ctx, "hpersist_hash1", "f1").Val())
+}
+
+// TestHSet_RemovesFieldExpiry verifies that overwriting a field with HSET removes its expiry
+// while HINCRBY keeps it.
+func TestHSet_RemovesFieldExpiry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "hpersist_hash2", "f1", "v1", "counter", "1")
+	testClient.HExpire(ctx, "hpersist_hash2", time.Hour, "f1", "counter")
+	testClient.HSet(ctx, "hpersist_hash2", "f1", "changed")
+	testClient.HIncrBy(ctx, "hpersist_hash2", "counter", 1)
+
+	assert.Equal(t, []int64{-1, 3600}, testClient.HTTL(ctx, "hpersist_hash2", "f1", "counter").Val())
+}
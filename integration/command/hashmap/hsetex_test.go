@@ -0,0 +1,48 @@
+package hashmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHSetEx_SetsFieldsWithExpiry verifies that HSETEX sets the fields along with their expiry.
+func TestHSetEx_SetsFieldsWithExpiry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	n, err := testClient.HSetEXWithArgs(ctx, "hsetex_hash1", &redis.HSetEXOptions{ExpirationType: redis.HSetEXExpirationEX, ExpirationVal: 100}, "f1", "v1", "f2", "v2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, map[string]string{"f1": "v1", "f2": "v2"}, testClient.HGetAll(ctx, "hsetex_hash1").Val())
+	assert.Equal(t, []int64{100, 100}, testClient.HTTL(ctx, "hsetex_hash1", "f1", "f2").Val())
+
+	n, err = testClient.HSetEXWithArgs(ctx, "hsetex_hash1", &redis.HSetEXOptions{ExpirationType: redis.HSetEXExpirationKEEPTTL}, "f1", "changed").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, []int64{100}, testClient.HTTL(ctx, "hsetex_hash1", "f1").Val())
+
+	n, err = testClient.HSetEX(ctx, "hsetex_hash1", "f2", "changed").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, []int64{-1}, testClient.HTTL(ctx, "hsetex_hash1", "f2").Val())
+}
+
+// TestHSetEx_Conditions verifies that FNX and FXX set either every field or none.
+func TestHSetEx_Conditions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	n, err := testClient.HSetEXWithArgs(ctx, "hsetex_hash2", &redis.HSetEXOptions{Condition: redis.HSetEXFXX}, "f1", "v1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	assert.Equal(t, int64(0), testClient.Exists(ctx, "hsetex_hash2").Val())
+
+	n, _ = testClient.HSetEXWithArgs(ctx, "hsetex_hash2", &redis.HSetEXOptions{Condition: redis.HSetEXFNX}, "f1", "v1").Result()
+	assert.Equal(t, int64(1), n)
+	n, _ = testClient.HSetEXWithArgs(ctx, "hsetex_hash2", &redis.HSetEXOptions{Condition: redis.HSetEXFNX}, "f1", "v2", "f2", "v2").Result()
+	assert.Equal(t, int64(0), n)
+	assert.Equal(t, map[string]string{"f1": "v1"}, testClient.HGetAll(ctx, "hsetex_hash2").Val())
+}
@@ -0,0 +1,53 @@
+package hashmap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHTTL_RepliesPerField verifies HTTL and HPTTL for fields with, without and missing an expiry.
+func TestHTTL_RepliesPerField(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.HSet(ctx, "httl_hash1", "f1", "v1", "f2", "v2")
+	testClient.HExpire(ctx, "httl_hash1", 100*time.Second, "f1")
+
+	ttls, err := testClient.HTTL(ctx, "httl_hash1", "f1", "f2", "missing").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{100, -1, -2}, ttls)
+
+	ttls, err = testClient.HPTTL(ctx, "httl_hash1", "f1").Result()
+	assert.NoError(t, err)
+	assert.InDelta(t, 100000, ttls[0], 1000)
+}
+
+// TestHExpireTime_RepliesUnixTime verifies HEXPIRETIME and HPEXPIRETIME.
+func TestHExpireTime_RepliesUnixTime(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	at := time.UnixMilli(4_102_444_800_123)
+
+	testClient.HSet(ctx, "httl_hash2", "f1", "v1")
+	testClient.HPExpireAt(ctx, "httl_hash2", at, "f1")
+
+	times, err := testClient.HPExpireTime(ctx, "httl_hash2", "f1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{4_102_444_800_123}, times)
+	times, err = testClient.HExpireTime(ctx, "httl_hash2", "f1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{4_102_444_801}, times)
+}
+
+// TestHTTL_KeyNotFound verifies that every field of a missing key is reported missing.
+func TestHTTL_KeyNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ttls, err := testClient.HTTL(ctx, "httl_nonexistent", "f1", "f2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{-2, -2}, ttls)
+}
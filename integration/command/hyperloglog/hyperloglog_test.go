@@ -0,0 +1,178 @@
+package hyperloglog
+
+import (
+	"avacado/integration"
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testClient *redis.Client
+
+func TestMain(m *testing.M) {
+	shutdown, err := integration.StartNewServer(6012)
+	if err != nil {
+		panic(err)
+	}
+
+	testClient = redis.NewClient(&redis.Options{
+		Addr:     "localhost:6012",
+		Password: "",
+		DB:       0,
+	})
+
+	code := m.Run()
+
+	if err := testClient.Close(); err != nil {
+		panic(err)
+	}
+	shutdown()
+	os.Exit(code)
+}
+
+func elements(prefix string, n int) []interface{} {
+	values := make([]interface{}, n)
+	for i := range values {
+		values[i] = prefix + strconv.Itoa(i)
+	}
+	return values
+}
+
+func TestPFAddAndPFCount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	added, err := testClient.PFAdd(ctx, "visitors", "alice", "bob", "carol").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), added)
+	added, _ = testClient.PFAdd(ctx, "visitors", "alice").Result()
+	assert.Equal(t, int64(0), added)
+	added, _ = testClient.PFAdd(ctx, "visitors:empty").Result()
+	assert.Equal(t, int64(1), added)
+
+	count, err := testClient.PFCount(ctx, "visitors").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	count, _ = testClient.PFCount(ctx, "visitors:empty", "visitors:missing").Result()
+	assert.Equal(t, int64(0), count)
+
+	_, _ = testClient.PFAdd(ctx, "visitors:other", "carol", "dave").Result()
+	count, err = testClient.PFCount(ctx, "visitors", "visitors:other").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+}
+
+func TestPFCount_EstimatesLargeCardinalities(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	_, err := testClient.PFAdd(ctx, "large", elements("e", 10000)...).Result()
+	require.NoError(t, err)
+	count, err := testClient.PFCount(ctx, "large").Result()
+	assert.NoError(t, err)
+	assert.InDelta(t, 10000, count, 10000*0.02)
+	encoding, _ := testClient.Do(ctx, "PFDEBUG", "ENCODING", "large").Result()
+	assert.Equal(t, "dense", encoding)
+}
+
+func TestPFMerge(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	_, _ = testClient.PFAdd(ctx, "merge:first", "a", "b", "c").Result()
+	_, _ = testClient.PFAdd(ctx, "merge:second", "c", "d").Result()
+
+	status, err := testClient.PFMerge(ctx, "merge:dest", "merge:first", "merge:second").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "OK", status)
+	count, _ := testClient.PFCount(ctx, "merge:dest").Result()
+	assert.Equal(t, int64(4), count)
+
+	_, _ = testClient.PFMerge(ctx, "merge:first", "merge:missing").Result()
+	count, _ = testClient.PFCount(ctx, "merge:first").Result()
+	assert.Equal(t, int64(3), count)
+	status, _ = testClient.PFMerge(ctx, "merge:created").Result()
+	assert.Equal(t, "OK", status)
+	count, _ = testClient.PFCount(ctx, "merge:created").Result()
+	assert.Equal(t, int64(0), count)
+}
+
+func TestPFDebug(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	_, _ = testClient.PFAdd(ctx, "debug").Result()
+
+	encoding, err := testClient.Do(ctx, "PFDEBUG", "ENCODING", "debug").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "sparse", encoding)
+	decoded, err := testClient.Do(ctx, "PFDEBUG", "DECODE", "debug").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "Z:16384", decoded)
+
+	converted, err := testClient.Do(ctx, "PFDEBUG", "TODENSE", "debug").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), converted)
+	converted, _ = testClient.Do(ctx, "PFDEBUG", "TODENSE", "debug").Result()
+	assert.Equal(t, int64(0), converted)
+	_, err = testClient.Do(ctx, "PFDEBUG", "DECODE", "debug").Result()
+	assert.EqualError(t, err, "ERR HLL encoding is not sparse")
+
+	registers, err := testClient.Do(ctx, "PFDEBUG", "GETREG", "debug").Slice()
+	assert.NoError(t, err)
+	assert.Len(t, registers, 16384)
+
+	_, err = testClient.Do(ctx, "PFDEBUG", "ENCODING", "debug:missing").Result()
+	assert.EqualError(t, err, "ERR The specified key does not exist")
+	_, err = testClient.Do(ctx, "PFDEBUG", "FOO", "debug").Result()
+	assert.EqualError(t, err, "ERR Unknown PFDEBUG subcommand 'FOO'")
+}
+
+// TestHyperLogLog_IsAString checks the value is the string Redis would hold, so that it can be
+// copied to and from Redis with GET and SET.
+func TestHyperLogLog_IsAString(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	_, _ = testClient.PFAdd(ctx, "string", "a", "b").Result()
+
+	kind, _ := testClient.Type(ctx, "string").Result()
+	assert.Equal(t, "string", kind)
+	data, err := testClient.Get(ctx, "string").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "HYLL", data[:4])
+
+	// The sparse sketch of "a" and "b" as Redis encodes it, its cardinality not cached yet.
+	redisData := "HYLL\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x80" + data[16:]
+	_, _ = testClient.Set(ctx, "string:copy", redisData, 0).Result()
+	count, err := testClient.PFCount(ctx, "string:copy").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestHyperLogLog_WrongType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	_, _ = testClient.Set(ctx, "wrongtype:string", "value", 0).Result()
+	_, _ = testClient.LPush(ctx, "wrongtype:list", "value").Result()
+
+	_, err := testClient.PFAdd(ctx, "wrongtype:string", "a").Result()
+	assert.EqualError(t, err, "WRONGTYPE Key is not a valid HyperLogLog string value.")
+	_, err = testClient.PFCount(ctx, "wrongtype:string").Result()
+	assert.EqualError(t, err, "WRONGTYPE Key is not a valid HyperLogLog string value.")
+	_, err = testClient.PFAdd(ctx, "wrongtype:list", "a").Result()
+	assert.EqualError(t, err, "WRONGTYPE Operation against a key holding the wrong kind of value")
+	_, err = testClient.PFMerge(ctx, "wrongtype:dest", "wrongtype:list").Result()
+	assert.EqualError(t, err, "WRONGTYPE Operation against a key holding the wrong kind of value")
+}
+
+func TestHyperLogLog_Corrupted(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	_, _ = testClient.Set(ctx, "corrupted", "HYLL\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x80\x7f", 0).Result()
+
+	_, err := testClient.PFCount(ctx, "corrupted").Result()
+	assert.EqualError(t, err, "INVALIDOBJ Corrupted HLL object detected")
+}
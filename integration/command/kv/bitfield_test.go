@@ -0,0 +1,69 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitField(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	values, err := testClient.BitField(ctx, "bitfield", "INCRBY", "i5", 100, 1, "GET", "u4", 0).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 0}, values)
+
+	values, err = testClient.BitField(ctx, "bitfield", "SET", "i8", "#0", -100, "GET", "u8", 0, "GET", "i8", 0).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{0, 156, -100}, values)
+	val, _ := testClient.GetRange(ctx, "bitfield", 0, 0).Result()
+	assert.Equal(t, "\x9c", val)
+}
+
+func TestBitField_Overflow(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var got [][]int64
+	for i := 0; i < 4; i++ {
+		values, err := testClient.BitField(ctx, "bitfield_overflow", "INCRBY", "u2", 100, 1, "OVERFLOW", "SAT", "INCRBY", "u2", 102, 1).Result()
+		assert.NoError(t, err)
+		got = append(got, values)
+	}
+	assert.Equal(t, [][]int64{{1, 1}, {2, 2}, {3, 3}, {0, 3}}, got)
+
+	result, err := testClient.Do(ctx, "BITFIELD", "bitfield_overflow", "OVERFLOW", "FAIL", "INCRBY", "u2", 102, 1).Slice()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{nil}, result)
+}
+
+func TestBitFieldRO(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "bitfield_ro", "\x01\x02", 0)
+
+	values, err := testClient.BitFieldRO(ctx, "bitfield_ro", "u8", 0, "u8", "#1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, values)
+	values, _ = testClient.BitFieldRO(ctx, "bitfield_ro_missing", "i16", 0).Result()
+	assert.Equal(t, []int64{0}, values)
+	exists, _ := testClient.Exists(ctx, "bitfield_ro_missing").Result()
+	assert.Equal(t, int64(0), exists)
+
+	_, err = testClient.Do(ctx, "BITFIELD_RO", "bitfield_ro", "SET", "u8", 0, 1).Result()
+	assert.EqualError(t, err, "ERR BITFIELD_RO only supports the GET subcommand")
+}
+
+func TestBitField_InvalidArguments(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	_, err := testClient.BitField(ctx, "bitfield_invalid", "GET", "u64", 0).Result()
+	assert.EqualError(t, err, "ERR Invalid bitfield type. Use something like i16 u8. Note that u64 is not supported but i64 is.")
+	_, err = testClient.BitField(ctx, "bitfield_invalid", "OVERFLOW", "NONE").Result()
+	assert.EqualError(t, err, "ERR Invalid OVERFLOW type specified")
+	_, err = testClient.BitField(ctx, "bitfield_invalid", "SET", "u8", -1, 1).Result()
+	assert.EqualError(t, err, "ERR bit offset is not an integer or out of range")
+}
@@ -0,0 +1,145 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetBitAndGetBit(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	old, err := testClient.SetBit(ctx, "bitmap_setbit", 7, 1).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), old)
+	old, _ = testClient.SetBit(ctx, "bitmap_setbit", 7, 0).Result()
+	assert.Equal(t, int64(1), old)
+	_, _ = testClient.SetBit(ctx, "bitmap_setbit", 17, 1).Result()
+
+	val, _ := testClient.Get(ctx, "bitmap_setbit").Result()
+	assert.Equal(t, "\x00\x00\x40", val)
+	bit, err := testClient.GetBit(ctx, "bitmap_setbit", 17).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), bit)
+	bit, _ = testClient.GetBit(ctx, "bitmap_setbit", 100).Result()
+	assert.Equal(t, int64(0), bit)
+
+	_, err = testClient.SetBit(ctx, "bitmap_setbit", -1, 1).Result()
+	assert.EqualError(t, err, "ERR bit offset is not an integer or out of range")
+	_, err = testClient.SetBit(ctx, "bitmap_setbit", 0, 2).Result()
+	assert.EqualError(t, err, "ERR bit is not an integer or out of range")
+}
+
+func TestSetBit_OnInteger(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "bitmap_integer", 1, 0)
+
+	_, err := testClient.SetBit(ctx, "bitmap_integer", 6, 1).Result()
+	assert.NoError(t, err)
+	val, _ := testClient.Get(ctx, "bitmap_integer").Result()
+	assert.Equal(t, "3", val)
+	encoding, _ := testClient.ObjectEncoding(ctx, "bitmap_integer").Result()
+	assert.Equal(t, "raw", encoding)
+	n, err := testClient.Incr(ctx, "bitmap_integer").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+}
+
+func TestBitCount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "bitmap_bitcount", "foobar", 0)
+
+	n, err := testClient.BitCount(ctx, "bitmap_bitcount", nil).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(26), n)
+	n, _ = testClient.BitCount(ctx, "bitmap_bitcount", &redis.BitCount{Start: 1, End: 1}).Result()
+	assert.Equal(t, int64(6), n)
+	n, _ = testClient.BitCount(ctx, "bitmap_bitcount", &redis.BitCount{Start: 5, End: 30, Unit: redis.BitCountIndexBit}).Result()
+	assert.Equal(t, int64(17), n)
+	n, _ = testClient.BitCount(ctx, "bitmap_missing", nil).Result()
+	assert.Equal(t, int64(0), n)
+
+	_, err = testClient.Do(ctx, "BITCOUNT", "bitmap_bitcount", "1").Result()
+	assert.EqualError(t, err, "ERR syntax error")
+}
+
+func TestBitPos(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "bitmap_bitpos", "\xff\xf0\x00", 0)
+	testClient.Set(ctx, "bitmap_bitpos_full", "\xff\xff", 0)
+
+	pos, err := testClient.BitPos(ctx, "bitmap_bitpos", 0).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), pos)
+	pos, _ = testClient.BitPos(ctx, "bitmap_bitpos", 1, 2).Result()
+	assert.Equal(t, int64(-1), pos)
+	pos, _ = testClient.BitPosSpan(ctx, "bitmap_bitpos", 1, 7, 15, "bit").Result()
+	assert.Equal(t, int64(7), pos)
+	pos, _ = testClient.BitPos(ctx, "bitmap_bitpos_full", 0).Result()
+	assert.Equal(t, int64(16), pos)
+	pos, _ = testClient.BitPos(ctx, "bitmap_bitpos_full", 0, 0, -1).Result()
+	assert.Equal(t, int64(-1), pos)
+	pos, _ = testClient.BitPos(ctx, "bitmap_missing", 0).Result()
+	assert.Equal(t, int64(0), pos)
+
+	_, err = testClient.BitPos(ctx, "bitmap_bitpos", 2).Result()
+	assert.EqualError(t, err, "ERR The bit argument must be 1 or 0.")
+}
+
+func TestBitOp(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "bitmap_bitop_a", "\x0c\xff", 0)
+	testClient.Set(ctx, "bitmap_bitop_b", "\x0a", 0)
+	testClient.Set(ctx, "bitmap_bitop_c", "\x06", 0)
+
+	n, err := testClient.BitOpAnd(ctx, "bitmap_bitop_dest", "bitmap_bitop_a", "bitmap_bitop_b").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	val, _ := testClient.Get(ctx, "bitmap_bitop_dest").Result()
+	assert.Equal(t, "\x08\x00", val)
+
+	_, _ = testClient.BitOpNot(ctx, "bitmap_bitop_dest", "bitmap_bitop_b").Result()
+	val, _ = testClient.Get(ctx, "bitmap_bitop_dest").Result()
+	assert.Equal(t, "\xf5", val)
+
+	_, _ = testClient.Do(ctx, "BITOP", "DIFF", "bitmap_bitop_dest", "bitmap_bitop_a", "bitmap_bitop_b", "bitmap_bitop_c").Result()
+	val, _ = testClient.Get(ctx, "bitmap_bitop_dest").Result()
+	assert.Equal(t, "\x00\xff", val)
+	_, _ = testClient.Do(ctx, "BITOP", "ANDOR", "bitmap_bitop_dest", "bitmap_bitop_a", "bitmap_bitop_b", "bitmap_bitop_c").Result()
+	val, _ = testClient.Get(ctx, "bitmap_bitop_dest").Result()
+	assert.Equal(t, "\x0c\x00", val)
+	_, _ = testClient.Do(ctx, "BITOP", "ONE", "bitmap_bitop_dest", "bitmap_bitop_b", "bitmap_bitop_c").Result()
+	val, _ = testClient.Get(ctx, "bitmap_bitop_dest").Result()
+	assert.Equal(t, "\x0c", val)
+
+	n, err = testClient.BitOpOr(ctx, "bitmap_bitop_dest", "bitmap_missing").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	exists, _ := testClient.Exists(ctx, "bitmap_bitop_dest").Result()
+	assert.Equal(t, int64(0), exists)
+
+	_, err = testClient.Do(ctx, "BITOP", "NOT", "bitmap_bitop_dest", "bitmap_bitop_a", "bitmap_bitop_b").Result()
+	assert.EqualError(t, err, "ERR BITOP NOT must be called with a single source key.")
+	_, err = testClient.Do(ctx, "BITOP", "DIFF", "bitmap_bitop_dest", "bitmap_bitop_a").Result()
+	assert.EqualError(t, err, "ERR BITOP DIFF must be called with at least two source keys.")
+}
+
+func TestBitmap_WrongType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.LPush(ctx, "bitmap_list", "value")
+
+	_, err := testClient.SetBit(ctx, "bitmap_list", 0, 1).Result()
+	assert.EqualError(t, err, "WRONGTYPE Operation against a key holding the wrong kind of value")
+	_, err = testClient.BitCount(ctx, "bitmap_list", nil).Result()
+	assert.EqualError(t, err, "WRONGTYPE Operation against a key holding the wrong kind of value")
+	_, err = testClient.BitOpAnd(ctx, "bitmap_wrongtype_dest", "bitmap_list").Result()
+	assert.EqualError(t, err, "WRONGTYPE Operation against a key holding the wrong kind of value")
+}
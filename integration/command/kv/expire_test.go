@@ -0,0 +1,125 @@
+package kv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpire_ExpiresKeysOfEveryType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.Set(ctx, "expire_string", "value", 0)
+	testClient.RPush(ctx, "expire_list", "a", "b")
+	testClient.HSet(ctx, "expire_hash", "field", "value")
+
+	for _, key := range []string{"expire_string", "expire_list", "expire_hash"} {
+		ok, err := testClient.PExpire(ctx, key, 100*time.Millisecond).Result()
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		ttl, err := testClient.PTTL(ctx, key).Result()
+		assert.NoError(t, err)
+		assert.Greater(t, ttl, time.Duration(0))
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	count, err := testClient.Exists(ctx, "expire_string", "expire_list", "expire_hash").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	length, err := testClient.LLen(ctx, "expire_list").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), length)
+}
+
+func TestExpire_MissingKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ok, err := testClient.Expire(ctx, "expire_missing", time.Minute).Result()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ttl, err := testClient.Do(ctx, "TTL", "expire_missing").Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-2), ttl)
+}
+
+func TestExpire_Conditions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.RPush(ctx, "expire_conditions", "a")
+
+	ok, _ := testClient.ExpireXX(ctx, "expire_conditions", time.Minute).Result()
+	assert.False(t, ok)
+	ok, _ = testClient.ExpireGT(ctx, "expire_conditions", time.Minute).Result()
+	assert.False(t, ok)
+	ok, _ = testClient.ExpireNX(ctx, "expire_conditions", time.Minute).Result()
+	assert.True(t, ok)
+	ok, _ = testClient.ExpireNX(ctx, "expire_conditions", time.Hour).Result()
+	assert.False(t, ok)
+	ok, _ = testClient.ExpireGT(ctx, "expire_conditions", time.Hour).Result()
+	assert.True(t, ok)
+	ok, _ = testClient.ExpireLT(ctx, "expire_conditions", 2*time.Hour).Result()
+	assert.False(t, ok)
+	ok, _ = testClient.ExpireLT(ctx, "expire_conditions", time.Minute).Result()
+	assert.True(t, ok)
+
+	ttl, err := testClient.TTL(ctx, "expire_conditions").Result()
+	assert.NoError(t, err)
+	assert.InDelta(t, time.Minute.Seconds(), ttl.Seconds(), 1)
+
+	err = testClient.Do(ctx, "EXPIRE", "expire_conditions", "10", "NX", "XX").Err()
+	assert.EqualError(t, err, "ERR NX and XX, GT or LT options at the same time are not compatible")
+	err = testClient.Do(ctx, "EXPIRE", "expire_conditions", "10", "GT", "LT").Err()
+	assert.EqualError(t, err, "ERR GT and LT options at the same time are not compatible")
+}
+
+func TestExpireAt_ExpireTimeAndPersist(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.HSet(ctx, "expireat_hash", "field", "value")
+
+	expireTime, err := testClient.ExpireTime(ctx, "expireat_hash").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(-1), expireTime)
+
+	at := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	ok, err := testClient.PExpireAt(ctx, "expireat_hash", at).Result()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	pExpireTime, err := testClient.Do(ctx, "PEXPIRETIME", "expireat_hash").Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, at.UnixMilli(), pExpireTime)
+	seconds, err := testClient.Do(ctx, "EXPIRETIME", "expireat_hash").Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, at.Unix(), seconds)
+
+	ok, err = testClient.Persist(ctx, "expireat_hash").Result()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = testClient.Persist(ctx, "expireat_hash").Result()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ttl, err := testClient.Do(ctx, "TTL", "expireat_hash").Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), ttl)
+}
+
+func TestExpireAt_PastTimeDeletesKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.RPush(ctx, "expireat_past", "a")
+
+	ok, err := testClient.ExpireAt(ctx, "expireat_past", time.Now().Add(-time.Hour)).Result()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	count, err := testClient.Exists(ctx, "expireat_past").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
@@ -142,3 +142,103 @@ func TestSet_WithIFEQIntegerValues(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "456", val)
 }
+
+func TestSet_WithPXExpiresInMilliseconds(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	err := testClient.Set(ctx, "set_px", "value", 150*time.Millisecond).Err()
+	assert.NoError(t, err)
+
+	ttl, err := testClient.PTTL(ctx, "set_px").Result()
+	assert.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0))
+	assert.LessOrEqual(t, ttl, 150*time.Millisecond)
+
+	time.Sleep(250 * time.Millisecond)
+	_, err = testClient.Get(ctx, "set_px").Result()
+	assert.Equal(t, redis.Nil, err)
+}
+
+func TestSet_WithEXATAndPXAT(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	at := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+
+	err := testClient.Do(ctx, "SET", "set_pxat", "value", "PXAT", at.UnixMilli()).Err()
+	assert.NoError(t, err)
+	expireTime, err := testClient.Do(ctx, "PEXPIRETIME", "set_pxat").Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, at.UnixMilli(), expireTime)
+
+	err = testClient.Do(ctx, "SET", "set_exat", "value", "EXAT", at.Unix()).Err()
+	assert.NoError(t, err)
+	expireTime, err = testClient.Do(ctx, "EXPIRETIME", "set_exat").Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, at.Unix(), expireTime)
+
+	err = testClient.Do(ctx, "SET", "set_exat_past", "value", "EXAT", time.Now().Add(-time.Hour).Unix()).Err()
+	assert.NoError(t, err)
+	count, err := testClient.Exists(ctx, "set_exat_past").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestSet_WithKeepTTL(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.Set(ctx, "set_keepttl", "v1", time.Hour)
+	err := testClient.SetArgs(ctx, "set_keepttl", "v2", redis.SetArgs{KeepTTL: true}).Err()
+	assert.NoError(t, err)
+	ttl, err := testClient.TTL(ctx, "set_keepttl").Result()
+	assert.NoError(t, err)
+	assert.Greater(t, ttl, 59*time.Minute)
+
+	err = testClient.Set(ctx, "set_keepttl", "v3", 0).Err()
+	assert.NoError(t, err)
+	ttl, err = testClient.TTL(ctx, "set_keepttl").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(-1), ttl)
+}
+
+func TestSet_NXAndXXWithGet(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	val, err := testClient.Do(ctx, "SET", "set_nxget", "v1", "NX", "GET").Result()
+	assert.Equal(t, redis.Nil, err)
+	assert.Nil(t, val)
+
+	val, err = testClient.Do(ctx, "SET", "set_nxget", "v2", "NX", "GET").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", val)
+	current, _ := testClient.Get(ctx, "set_nxget").Result()
+	assert.Equal(t, "v1", current)
+
+	_, err = testClient.Do(ctx, "SET", "set_xxget", "v1", "XX", "GET").Result()
+	assert.Equal(t, redis.Nil, err)
+	count, _ := testClient.Exists(ctx, "set_xxget").Result()
+	assert.Equal(t, int64(0), count)
+}
+
+func TestSet_SyntaxErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	for _, args := range [][]any{
+		{"SET", "set_syntax", "v", "NX", "XX"},
+		{"SET", "set_syntax", "v", "EX", "10", "PX", "100"},
+		{"SET", "set_syntax", "v", "KEEPTTL", "EX", "10"},
+		{"SET", "set_syntax", "v", "BOGUS"},
+		{"SET", "set_syntax", "v", "EX"},
+	} {
+		err := testClient.Do(ctx, args...).Err()
+		assert.EqualError(t, err, "ERR syntax error", args)
+	}
+	err := testClient.Do(ctx, "SET", "set_syntax", "v", "PX", "0").Err()
+	assert.EqualError(t, err, "ERR invalid expire time in 'set' command")
+
+	count, _ := testClient.Exists(ctx, "set_syntax").Result()
+	assert.Equal(t, int64(0), count)
+}
@@ -275,6 +275,21 @@ func TestBLPop_BlocksUntilPush(t *testing.T) {
 	assert.Equal(t, []string{"blpop2", "hello"}, result)
 }
 
+func TestBLPop_BlocksUntilLMove(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.RPush(ctx, "blpop_lmove_src", "moved")
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		testClient.LMove(ctx, "blpop_lmove_src", "blpop_lmove", "LEFT", "RIGHT")
+	}()
+
+	result, err := testClient.BLPop(ctx, 2*time.Second, "blpop_lmove").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"blpop_lmove", "moved"}, result)
+}
+
 func TestBLPop_Timeout(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
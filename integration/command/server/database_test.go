@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDBClient(t *testing.T, db int) *redis.Client {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6006", DB: db})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestDatabases_AreIsolated(t *testing.T) {
+	ctx := context.Background()
+	first, second := newDBClient(t, 1), newDBClient(t, 2)
+
+	assert.NoError(t, first.Set(ctx, "db_isolated", "one", 0).Err())
+	assert.NoError(t, second.Set(ctx, "db_isolated", "two", 0).Err())
+
+	assert.Equal(t, "one", first.Get(ctx, "db_isolated").Val())
+	assert.Equal(t, "two", second.Get(ctx, "db_isolated").Val())
+	assert.Equal(t, int64(0), testClient.Exists(ctx, "db_isolated").Val())
+}
+
+func TestSelect_OutOfRange(t *testing.T) {
+	ctx := context.Background()
+	// A client of its own, so the connection left on DB 15 never returns to the pool of testClient.
+	conn := newDBClient(t, 0).Conn()
+	defer conn.Close()
+
+	assert.EqualError(t, conn.Select(ctx, 16).Err(), "ERR DB index is out of range")
+	assert.NoError(t, conn.Select(ctx, 15).Err())
+	assert.NoError(t, conn.Set(ctx, "db_select", "fifteen", 0).Err())
+	assert.Equal(t, "fifteen", newDBClient(t, 15).Get(ctx, "db_select").Val())
+}
+
+func TestMove_KeepsExpiry(t *testing.T) {
+	ctx := context.Background()
+	source, destination := newDBClient(t, 3), newDBClient(t, 4)
+
+	source.Set(ctx, "db_move", "value", time.Hour)
+	destination.Set(ctx, "db_move_taken", "kept", 0)
+	source.Set(ctx, "db_move_taken", "other", 0)
+
+	assert.Equal(t, true, source.Move(ctx, "db_move", 4).Val())
+	assert.Equal(t, false, source.Move(ctx, "db_move", 4).Val())
+	assert.Equal(t, false, source.Move(ctx, "db_move_taken", 4).Val())
+	assert.EqualError(t, source.Move(ctx, "db_move_taken", 3).Err(), "ERR source and destination objects are the same")
+	assert.EqualError(t, source.Move(ctx, "db_move_taken", 99).Err(), "ERR DB index is out of range")
+
+	assert.Equal(t, "value", destination.Get(ctx, "db_move").Val())
+	assert.Greater(t, destination.TTL(ctx, "db_move").Val(), 59*time.Minute)
+	assert.Equal(t, "kept", destination.Get(ctx, "db_move_taken").Val())
+}
+
+func TestMove_ServesBlockedClients(t *testing.T) {
+	ctx := context.Background()
+	blocked := newDBClient(t, 1)
+
+	result := make(chan []string, 1)
+	go func() {
+		result <- blocked.BLPop(ctx, 5*time.Second, "db_move_list").Val()
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	testClient.RPush(ctx, "db_move_list", "value")
+	assert.Equal(t, true, testClient.Move(ctx, "db_move_list", 1).Val())
+	select {
+	case values := <-result:
+		assert.Equal(t, []string{"db_move_list", "value"}, values)
+	case <-time.After(2 * time.Second):
+		t.Fatal("client blocked on db 1 was not served after MOVE")
+	}
+}
+
+func TestCopy_ToAnotherDB(t *testing.T) {
+	ctx := context.Background()
+	source, destination := newDBClient(t, 5), newDBClient(t, 6)
+
+	source.RPush(ctx, "db_copy", "a", "b")
+	assert.Equal(t, int64(1), source.Copy(ctx, "db_copy", "db_copy", 6, false).Val())
+	assert.Equal(t, int64(0), source.Copy(ctx, "db_copy", "db_copy", 6, false).Val())
+	assert.EqualError(t, source.Copy(ctx, "db_copy", "db_copy", 5, false).Err(), "ERR source and destination objects are the same")
+
+	destination.RPush(ctx, "db_copy", "c")
+	assert.Equal(t, []string{"a", "b"}, source.LRange(ctx, "db_copy", 0, -1).Val())
+	assert.Equal(t, []string{"a", "b", "c"}, destination.LRange(ctx, "db_copy", 0, -1).Val())
+}
+
+func TestCopy_ServesBlockedClients(t *testing.T) {
+	ctx := context.Background()
+	blocked := newDBClient(t, 1)
+
+	result := make(chan []string, 1)
+	go func() {
+		result <- blocked.BLPop(ctx, 5*time.Second, "db_copy_list").Val()
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	testClient.RPush(ctx, "db_copy_list", "value")
+	assert.Equal(t, int64(1), testClient.Copy(ctx, "db_copy_list", "db_copy_list", 1, false).Val())
+	select {
+	case values := <-result:
+		assert.Equal(t, []string{"db_copy_list", "value"}, values)
+	case <-time.After(2 * time.Second):
+		t.Fatal("client blocked on db 1 was not served after COPY")
+	}
+	assert.Equal(t, []string{"value"}, testClient.LRange(ctx, "db_copy_list", 0, -1).Val())
+}
+
+func TestSwapDB_ServesBlockedClients(t *testing.T) {
+	ctx := context.Background()
+	blocked, other := newDBClient(t, 7), newDBClient(t, 8)
+
+	result := make(chan []string, 1)
+	go func() {
+		result <- blocked.BLPop(ctx, 5*time.Second, "db_swap_list").Val()
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	// A push to the same key of another database does not serve the client.
+	other.RPush(ctx, "db_swap_list", "value")
+	select {
+	case <-result:
+		t.Fatal("client blocked on db 7 was served by a push to db 8")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	assert.NoError(t, testClient.Do(ctx, "SWAPDB", 7, 8).Err())
+	select {
+	case values := <-result:
+		assert.Equal(t, []string{"db_swap_list", "value"}, values)
+	case <-time.After(2 * time.Second):
+		t.Fatal("client blocked on db 7 was not served after SWAPDB")
+	}
+	assert.EqualError(t, testClient.Do(ctx, "SWAPDB", 0, 16).Err(), "ERR DB index is out of range")
+}
+
+func TestFlushDB_FlushAll(t *testing.T) {
+	ctx := context.Background()
+	first, second := newDBClient(t, 9), newDBClient(t, 10)
+
+	first.Set(ctx, "db_flush", "value", 0)
+	second.Set(ctx, "db_flush", "value", 0)
+
+	assert.NoError(t, first.Do(ctx, "FLUSHDB", "ASYNC").Err())
+	assert.Equal(t, int64(0), first.DBSize(ctx).Val())
+	assert.Equal(t, int64(1), second.DBSize(ctx).Val())
+	assert.EqualError(t, first.Do(ctx, "FLUSHDB", "NOW").Err(), "ERR syntax error")
+
+	first.Set(ctx, "db_flush", "value", 0)
+	assert.NoError(t, second.FlushAll(ctx).Err())
+	assert.Equal(t, int64(0), first.DBSize(ctx).Val())
+	assert.Equal(t, int64(0), second.DBSize(ctx).Val())
+}
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugObject_ReportsQuicklistNodes(t *testing.T) {
+	ctx := context.Background()
+	testClient.RPush(ctx, "debug_small", "v")
+	for i := 0; i < 20; i++ {
+		testClient.RPush(ctx, "debug_large", strings.Repeat("v", 1000))
+	}
+
+	assert.Contains(t, testClient.DebugObject(ctx, "debug_small").Val(), "encoding:quicklist")
+	assert.Contains(t, testClient.DebugObject(ctx, "debug_small").Val(), "ql_nodes:1")
+	assert.Regexp(t, `ql_nodes:([2-9]|[1-9][0-9]+)$`, testClient.DebugObject(ctx, "debug_large").Val())
+
+	testClient.Set(ctx, "debug_string", "12", 0)
+	assert.NotContains(t, testClient.DebugObject(ctx, "debug_string").Val(), "ql_nodes")
+	assert.EqualError(t, testClient.DebugObject(ctx, "debug_missing").Err(), "ERR no such key")
+}
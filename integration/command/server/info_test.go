@@ -0,0 +1,61 @@
+package server
+
+import (
+	"avacado/integration"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var testClient *redis.Client
+
+func TestMain(m *testing.M) {
+	shutdown, err := integration.StartNewServer(6006)
+	if err != nil {
+		panic(err)
+	}
+
+	testClient = redis.NewClient(&redis.Options{
+		Addr:     "localhost:6006",
+		Password: "",
+		DB:       0,
+	})
+
+	code := m.Run()
+
+	if err := testClient.Close(); err != nil {
+		panic(err)
+	}
+	shutdown()
+	os.Exit(code)
+}
+
+func infoField(t *testing.T, ctx context.Context, section, name string) string {
+	info, err := testClient.Info(ctx, section).Result()
+	assert.NoError(t, err)
+	for _, line := range strings.Split(info, "\r\n") {
+		if value, found := strings.CutPrefix(line, name+":"); found {
+			return value
+		}
+	}
+	t.Fatalf("field %s not found in INFO %s", name, section)
+	return ""
+}
+
+func TestInfo_ActiveExpireCycleReclaimsUntouchedKeys(t *testing.T) {
+	ctx := context.Background()
+
+	for _, key := range []string{"info_exp1", "info_exp2", "info_exp3"} {
+		testClient.SetArgs(ctx, key, "value", redis.SetArgs{TTL: 1 * time.Second})
+	}
+
+	// The keys are never read again: only the active expire cycle can reclaim them.
+	assert.Eventually(t, func() bool {
+		return infoField(t, ctx, "stats", "expired_keys") == "3"
+	}, 3*time.Second, 100*time.Millisecond)
+}
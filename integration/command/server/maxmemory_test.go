@@ -0,0 +1,70 @@
+package server
+
+import (
+	"avacado/integration"
+	"avacado/internal/storage"
+	"avacado/internal/storage/keyspace"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLimitedServer(t *testing.T, port int64, policy keyspace.EvictionPolicy) *redis.Client {
+	config := storage.DefaultConfig()
+	config.MaxMemory = 64 * 1024
+	config.MaxMemoryPolicy = policy
+	shutdown, err := integration.StartNewServerWithConfig(port, config)
+	assert.NoError(t, err)
+	client := redis.NewClient(&redis.Options{Addr: fmt.Sprintf("localhost:%d", port)})
+	t.Cleanup(func() {
+		_ = client.Close()
+		shutdown()
+	})
+	return client
+}
+
+func TestMaxMemory_AllKeysLRUEvicts(t *testing.T) {
+	ctx := context.Background()
+	client := newLimitedServer(t, 6007, keyspace.AllKeysLRU)
+	value := strings.Repeat("x", 1024)
+
+	for i := 0; i < 200; i++ {
+		assert.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), value, 0).Err())
+	}
+
+	size := client.DBSize(ctx).Val()
+	assert.Less(t, size, int64(200))
+	assert.Greater(t, size, int64(0))
+	assert.Equal(t, value, client.Get(ctx, "key199").Val())
+
+	info := client.Info(ctx, "stats").Val()
+	for _, line := range strings.Split(info, "\r\n") {
+		if evicted, found := strings.CutPrefix(line, "evicted_keys:"); found {
+			count, err := strconv.ParseInt(evicted, 10, 64)
+			assert.NoError(t, err)
+			assert.Equal(t, 200-size, count)
+		}
+	}
+}
+
+func TestMaxMemory_NoEvictionRefusesWrites(t *testing.T) {
+	ctx := context.Background()
+	client := newLimitedServer(t, 6008, keyspace.NoEviction)
+	value := strings.Repeat("x", 1024)
+
+	var err error
+	for i := 0; i < 200 && err == nil; i++ {
+		err = client.Set(ctx, fmt.Sprintf("key%d", i), value, 0).Err()
+	}
+	assert.EqualError(t, err, "OOM command not allowed when used memory > 'maxmemory'.")
+
+	// Reads and deletions are still served, and free memory for new writes.
+	assert.Equal(t, value, client.Get(ctx, "key0").Val())
+	assert.NoError(t, client.Del(ctx, "key0", "key1", "key2").Err())
+	assert.NoError(t, client.Set(ctx, "key0", value, 0).Err())
+}
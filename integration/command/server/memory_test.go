@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryUsage_GrowsWithTheValue(t *testing.T) {
+	ctx := context.Background()
+	testClient.Set(ctx, "memory_usage", "small", 0)
+	small := testClient.MemoryUsage(ctx, "memory_usage").Val()
+	testClient.Append(ctx, "memory_usage", strings.Repeat("x", 4096))
+	large := testClient.MemoryUsage(ctx, "memory_usage", 5).Val()
+
+	assert.Greater(t, small, int64(0))
+	assert.Greater(t, large, small+4096-1)
+	assert.Equal(t, redis.Nil, testClient.MemoryUsage(ctx, "memory_missing").Err())
+}
+
+func TestMemory_UsedMemoryFollowsTheKeys(t *testing.T) {
+	ctx := context.Background()
+	used := func() int64 {
+		value, err := strconv.ParseInt(infoField(t, ctx, "memory", "used_memory"), 10, 64)
+		assert.NoError(t, err)
+		return value
+	}
+	before := used()
+
+	testClient.RPush(ctx, "memory_list", strings.Repeat("x", 8192), strings.Repeat("y", 8192))
+	testClient.HSet(ctx, "memory_hash", "field", strings.Repeat("z", 8192))
+	assert.Greater(t, used(), before+3*8192)
+
+	testClient.Del(ctx, "memory_list", "memory_hash")
+	assert.Equal(t, before, used())
+}
+
+func TestMemory_StatsAndDoctor(t *testing.T) {
+	ctx := context.Background()
+	testClient.Set(ctx, "memory_stats", "value", 0)
+
+	reply, err := testClient.Do(ctx, "MEMORY", "STATS").Result()
+	assert.NoError(t, err)
+	stats, ok := reply.(map[interface{}]interface{})
+	assert.True(t, ok)
+	assert.Contains(t, stats, "total.allocated")
+	assert.Contains(t, stats, "keys.count")
+	assert.Contains(t, stats, "db.0")
+
+	doctor, err := testClient.Do(ctx, "MEMORY", "DOCTOR").Text()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, doctor)
+}
@@ -0,0 +1,107 @@
+package set
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSInter_AndSInterCard(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.SAdd(ctx, "sinter:1", "a", "b", "c", "d")
+	testClient.SAdd(ctx, "sinter:2", "b", "c", "d", "e")
+	testClient.SAdd(ctx, "sinter:3", "c", "d")
+
+	members, err := testClient.SInter(ctx, "sinter:1", "sinter:2", "sinter:3").Result()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"c", "d"}, members)
+
+	members, err = testClient.SInter(ctx, "sinter:1", "sinter:missing").Result()
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+
+	card, err := testClient.SInterCard(ctx, 0, "sinter:1", "sinter:2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), card)
+
+	card, err = testClient.SInterCard(ctx, 2, "sinter:1", "sinter:2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), card)
+
+	err = testClient.Do(ctx, "SINTERCARD", "0", "sinter:1").Err()
+	assert.EqualError(t, err, "ERR numkeys should be greater than 0")
+
+	err = testClient.Do(ctx, "SINTERCARD", "1", "sinter:1", "LIMIT", "-1").Err()
+	assert.EqualError(t, err, "ERR LIMIT can't be negative")
+}
+
+func TestSUnion_AndSDiff(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.SAdd(ctx, "sunion:1", "a", "b")
+	testClient.SAdd(ctx, "sunion:2", "b", "1")
+
+	members, err := testClient.SUnion(ctx, "sunion:1", "sunion:2", "sunion:missing").Result()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "1"}, members)
+
+	members, err = testClient.SDiff(ctx, "sunion:1", "sunion:2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, members)
+
+	members, err = testClient.SDiff(ctx, "sunion:missing", "sunion:1").Result()
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+}
+
+func TestStoreVariants_ReplaceTheDestination(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.SAdd(ctx, "store:1", "1", "2", "3", "a")
+	testClient.SAdd(ctx, "store:2", "1", "2", "b")
+	testClient.Set(ctx, "store:dst", "x", 0)
+
+	n, err := testClient.SInterStore(ctx, "store:dst", "store:1", "store:2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	// Although both sources are listpacks, the result only holds integers.
+	encoding, err := testClient.ObjectEncoding(ctx, "store:dst").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "intset", encoding)
+
+	n, err = testClient.SUnionStore(ctx, "store:dst", "store:1", "store:2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+
+	n, err = testClient.SDiffStore(ctx, "store:dst", "store:1", "store:2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	members, err := testClient.SMembers(ctx, "store:dst").Result()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"3", "a"}, members)
+
+	n, err = testClient.SInterStore(ctx, "store:dst", "store:1", "store:missing").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	err = testClient.Get(ctx, "store:dst").Err()
+	assert.ErrorIs(t, err, redis.Nil)
+}
+
+func TestSetAlgebra_WrongType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.SAdd(ctx, "algebra-wt:set", "a")
+	testClient.Set(ctx, "algebra-wt:string", "x", 0)
+
+	assert.EqualError(t, testClient.SInter(ctx, "algebra-wt:missing", "algebra-wt:string").Err(), wrongTypeError)
+	assert.EqualError(t, testClient.SUnion(ctx, "algebra-wt:set", "algebra-wt:string").Err(), wrongTypeError)
+	assert.EqualError(t, testClient.SDiffStore(ctx, "algebra-wt:dst", "algebra-wt:set", "algebra-wt:string").Err(), wrongTypeError)
+}
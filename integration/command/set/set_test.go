@@ -0,0 +1,203 @@
+package set
+
+import (
+	"avacado/integration"
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var testClient *redis.Client
+
+func TestMain(m *testing.M) {
+	shutdown, err := integration.StartNewServer(6009)
+	if err != nil {
+		panic(err)
+	}
+
+	testClient = redis.NewClient(&redis.Options{
+		Addr:     "localhost:6009",
+		Password: "",
+		DB:       0,
+	})
+
+	code := m.Run()
+
+	if err := testClient.Close(); err != nil {
+		panic(err)
+	}
+	shutdown()
+	os.Exit(code)
+}
+
+const wrongTypeError = "WRONGTYPE Operation against a key holding the wrong kind of value"
+
+func TestSAdd_CountsNewMembers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	n, err := testClient.SAdd(ctx, "sadd", "a", "b", "a").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	n, err = testClient.SAdd(ctx, "sadd", "b", "c").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	members, err := testClient.SMembers(ctx, "sadd").Result()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, members)
+
+	card, err := testClient.SCard(ctx, "sadd").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), card)
+}
+
+func TestSRem_RemovesTheKeyWithItsLastMember(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.SAdd(ctx, "srem", "a", "b")
+
+	n, err := testClient.SRem(ctx, "srem", "a", "missing").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	n, err = testClient.SRem(ctx, "srem", "b").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	exists, err := testClient.Exists(ctx, "srem").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), exists)
+}
+
+func TestSIsMember_AndSMIsMember(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.SAdd(ctx, "sismember", "a", "1")
+
+	isMember, err := testClient.SIsMember(ctx, "sismember", "a").Result()
+	assert.NoError(t, err)
+	assert.True(t, isMember)
+
+	isMember, err = testClient.SIsMember(ctx, "sismember", "b").Result()
+	assert.NoError(t, err)
+	assert.False(t, isMember)
+
+	areMembers, err := testClient.SMIsMember(ctx, "sismember", "a", "b", "1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, areMembers)
+}
+
+func TestSPop_RemovesMembers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.SAdd(ctx, "spop", "a", "b", "c")
+
+	member, err := testClient.SPop(ctx, "spop").Result()
+	assert.NoError(t, err)
+	assert.Contains(t, []string{"a", "b", "c"}, member)
+
+	members, err := testClient.SPopN(ctx, "spop", 5).Result()
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.NotContains(t, members, member)
+
+	err = testClient.SPop(ctx, "spop").Err()
+	assert.ErrorIs(t, err, redis.Nil)
+}
+
+func TestSRandMember_LeavesTheSetUntouched(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.SAdd(ctx, "srandmember", "a", "b", "c")
+
+	members, err := testClient.SRandMemberN(ctx, "srandmember", 2).Result()
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.NotEqual(t, members[0], members[1])
+
+	members, err = testClient.SRandMemberN(ctx, "srandmember", -6).Result()
+	assert.NoError(t, err)
+	assert.Len(t, members, 6)
+
+	card, err := testClient.SCard(ctx, "srandmember").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), card)
+
+	err = testClient.SRandMember(ctx, "srandmember:missing").Err()
+	assert.ErrorIs(t, err, redis.Nil)
+}
+
+func TestSMove_MovesAMemberBetweenSets(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.SAdd(ctx, "smove:src", "a")
+
+	moved, err := testClient.SMove(ctx, "smove:src", "smove:dst", "a").Result()
+	assert.NoError(t, err)
+	assert.True(t, moved)
+
+	moved, err = testClient.SMove(ctx, "smove:src", "smove:dst", "a").Result()
+	assert.NoError(t, err)
+	assert.False(t, moved)
+
+	exists, err := testClient.Exists(ctx, "smove:src").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), exists)
+
+	members, err := testClient.SMembers(ctx, "smove:dst").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, members)
+}
+
+func TestSets_ConvertToLargerEncodings(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.SAdd(ctx, "encoding", "1", "2", "3")
+	encoding, err := testClient.ObjectEncoding(ctx, "encoding").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "intset", encoding)
+
+	testClient.SAdd(ctx, "encoding", "a")
+	encoding, err = testClient.ObjectEncoding(ctx, "encoding").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "listpack", encoding)
+
+	testClient.SAdd(ctx, "encoding", strings.Repeat("x", 65))
+	encoding, err = testClient.ObjectEncoding(ctx, "encoding").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "hashtable", encoding)
+
+	members := make([]interface{}, 600)
+	for i := range members {
+		members[i] = strconv.Itoa(i)
+	}
+	testClient.SAdd(ctx, "encoding:large-intset", members...)
+	encoding, err = testClient.ObjectEncoding(ctx, "encoding:large-intset").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "hashtable", encoding)
+}
+
+func TestSets_WrongType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.Set(ctx, "wrongtype", "x", 0)
+
+	assert.EqualError(t, testClient.SAdd(ctx, "wrongtype", "a").Err(), wrongTypeError)
+	assert.EqualError(t, testClient.SMembers(ctx, "wrongtype").Err(), wrongTypeError)
+	assert.EqualError(t, testClient.SCard(ctx, "wrongtype").Err(), wrongTypeError)
+	assert.EqualError(t, testClient.SMove(ctx, "wrongtype", "wrongtype:dst", "a").Err(), wrongTypeError)
+}
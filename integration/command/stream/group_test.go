@@ -0,0 +1,228 @@
+package stream
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addEntries adds an entry at i-0 for each i in [1, n] to the stream at key.
+func addEntries(t *testing.T, key string, n int) {
+	for i := 1; i <= n; i++ {
+		_, err := testClient.XAdd(context.Background(), &redis.XAddArgs{Stream: key, ID: strconv.Itoa(i), Values: []string{"f", "v"}}).Result()
+		require.NoError(t, err)
+	}
+}
+
+// readGroup reads from the stream at key as consumer of group, without blocking.
+func readGroup(key, group, consumer, id string, count int64) ([]redis.XStream, error) {
+	return testClient.XReadGroup(context.Background(), &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{key, id},
+		Count:    count,
+		Block:    -1,
+	}).Result()
+}
+
+func TestXGroup_CreateAndManage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	_, err := testClient.XGroupCreate(ctx, "xgroup", "group", "$").Result()
+	assert.EqualError(t, err, "ERR The XGROUP subcommand requires the key to exist. Note that for CREATE you may want to use the MKSTREAM option to create an empty stream automatically.")
+	status, err := testClient.XGroupCreateMkStream(ctx, "xgroup", "group", "$").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "OK", status)
+	_, err = testClient.XGroupCreate(ctx, "xgroup", "group", "0").Result()
+	assert.EqualError(t, err, "BUSYGROUP Consumer Group name already exists")
+
+	created, err := testClient.XGroupCreateConsumer(ctx, "xgroup", "group", "alice").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), created)
+	_, err = testClient.XGroupCreateConsumer(ctx, "xgroup", "missing", "alice").Result()
+	assert.EqualError(t, err, "NOGROUP No such consumer group 'missing' for key name 'xgroup'")
+	deleted, err := testClient.XGroupDelConsumer(ctx, "xgroup", "group", "alice").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	_, err = testClient.Do(ctx, "XGROUP", "SETID", "xgroup", "group", "5", "ENTRIESREAD", "-2").Result()
+	assert.EqualError(t, err, "ERR value for ENTRIESREAD must be positive or -1")
+	status, err = testClient.XGroupSetID(ctx, "xgroup", "group", "5").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "OK", status)
+
+	destroyed, err := testClient.XGroupDestroy(ctx, "xgroup", "group").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), destroyed)
+	destroyed, _ = testClient.XGroupDestroy(ctx, "xgroup", "group").Result()
+	assert.Equal(t, int64(0), destroyed)
+}
+
+func TestXReadGroup_DeliversAndTracksPendingEntries(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addEntries(t, "xreadgroup", 3)
+	require.NoError(t, testClient.XGroupCreate(ctx, "xreadgroup", "group", "0").Err())
+
+	read, err := readGroup("xreadgroup", "group", "alice", ">", 2)
+	assert.NoError(t, err)
+	require.Len(t, read, 1)
+	assert.Equal(t, "xreadgroup", read[0].Stream)
+	assert.Equal(t, []string{"1-0", "2-0"}, ids(read[0].Messages))
+	read, _ = readGroup("xreadgroup", "group", "bob", ">", 0)
+	assert.Equal(t, []string{"3-0"}, ids(read[0].Messages))
+	_, err = readGroup("xreadgroup", "group", "bob", ">", 0)
+	assert.Equal(t, redis.Nil, err)
+
+	_, _ = testClient.XDel(ctx, "xreadgroup", "1-0").Result()
+	read, err = readGroup("xreadgroup", "group", "alice", "0", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.XMessage{{ID: "1-0"}, {ID: "2-0", Values: map[string]interface{}{"f": "v"}}}, read[0].Messages)
+
+	pending, err := testClient.XPending(ctx, "xreadgroup", "group").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, &redis.XPending{Count: 3, Lower: "1-0", Higher: "3-0", Consumers: map[string]int64{"alice": 2, "bob": 1}}, pending)
+
+	extended, err := testClient.XPendingExt(ctx, &redis.XPendingExtArgs{Stream: "xreadgroup", Group: "group", Start: "-", End: "+", Count: 10, Consumer: "alice"}).Result()
+	assert.NoError(t, err)
+	require.Len(t, extended, 2)
+	assert.Equal(t, "alice", extended[1].Consumer)
+	assert.Equal(t, int64(2), extended[1].RetryCount)
+
+	acknowledged, err := testClient.XAck(ctx, "xreadgroup", "group", "1-0", "3-0", "9-0").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), acknowledged)
+	pending, _ = testClient.XPending(ctx, "xreadgroup", "group").Result()
+	assert.Equal(t, int64(1), pending.Count)
+
+	_, err = readGroup("xreadgroup", "missing", "alice", ">", 0)
+	assert.EqualError(t, err, "NOGROUP No such key 'xreadgroup' or consumer group 'missing' in XREADGROUP with GROUP option")
+	_, err = testClient.XPending(ctx, "xreadgroup", "missing").Result()
+	assert.EqualError(t, err, "NOGROUP No such key 'xreadgroup' or consumer group 'missing'")
+}
+
+func TestXReadGroup_NoAck(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addEntries(t, "xreadgroup:noack", 2)
+	require.NoError(t, testClient.XGroupCreate(ctx, "xreadgroup:noack", "group", "0").Err())
+
+	read, err := testClient.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "group", Consumer: "alice", Streams: []string{"xreadgroup:noack", ">"}, Block: -1, NoAck: true}).Result()
+	assert.NoError(t, err)
+	assert.Len(t, read[0].Messages, 2)
+	pending, _ := testClient.XPending(ctx, "xreadgroup:noack", "group").Result()
+	assert.Equal(t, int64(0), pending.Count)
+}
+
+func TestXClaimAndXAutoClaim(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addEntries(t, "xclaim", 4)
+	require.NoError(t, testClient.XGroupCreate(ctx, "xclaim", "group", "0").Err())
+	_, err := readGroup("xclaim", "group", "alice", ">", 0)
+	require.NoError(t, err)
+
+	claimed, err := testClient.XClaim(ctx, &redis.XClaimArgs{Stream: "xclaim", Group: "group", Consumer: "bob", MinIdle: time.Hour, Messages: []string{"1-0"}}).Result()
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+	claimed, err = testClient.XClaim(ctx, &redis.XClaimArgs{Stream: "xclaim", Group: "group", Consumer: "bob", Messages: []string{"1-0"}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.XMessage{{ID: "1-0", Values: map[string]interface{}{"f": "v"}}}, claimed)
+	claimedIDs, err := testClient.XClaimJustID(ctx, &redis.XClaimArgs{Stream: "xclaim", Group: "group", Consumer: "carol", Messages: []string{"2-0"}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2-0"}, claimedIDs)
+	_, err = testClient.Do(ctx, "XCLAIM", "xclaim", "group", "bob", "0", "1-0", "BOGUS").Result()
+	assert.EqualError(t, err, "ERR Unrecognized XCLAIM option 'BOGUS'")
+
+	_, _ = testClient.XDel(ctx, "xclaim", "3-0").Result()
+	messages, next, err := testClient.XAutoClaim(ctx, &redis.XAutoClaimArgs{Stream: "xclaim", Group: "group", Consumer: "dave", Start: "-", Count: 3}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1-0", "2-0"}, ids(messages))
+	assert.Equal(t, "4-0", next)
+	reply, err := testClient.Do(ctx, "XAUTOCLAIM", "xclaim", "group", "dave", "0", next, "JUSTID").Slice()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"0-0", []interface{}{"4-0"}, []interface{}{}}, reply)
+	_, err = testClient.Do(ctx, "XAUTOCLAIM", "xclaim", "group", "dave", "0", "0", "COUNT", "0").Result()
+	assert.EqualError(t, err, "ERR COUNT must be > 0")
+
+	pending, _ := testClient.XPending(ctx, "xclaim", "group").Result()
+	assert.Equal(t, map[string]int64{"dave": 3}, pending.Consumers)
+}
+
+func TestXInfoGroupsAndConsumers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addEntries(t, "xinfo:groups", 3)
+	require.NoError(t, testClient.XGroupCreate(ctx, "xinfo:groups", "group", "0").Err())
+	_, err := readGroup("xinfo:groups", "group", "alice", ">", 1)
+	require.NoError(t, err)
+
+	groups, err := testClient.XInfoGroups(ctx, "xinfo:groups").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.XInfoGroup{{Name: "group", Consumers: 1, Pending: 1, LastDeliveredID: "1-0", EntriesRead: 1, Lag: 2}}, groups)
+	info, _ := testClient.XInfoStream(ctx, "xinfo:groups").Result()
+	assert.Equal(t, int64(1), info.Groups)
+
+	consumers, err := testClient.XInfoConsumers(ctx, "xinfo:groups", "group").Result()
+	assert.NoError(t, err)
+	require.Len(t, consumers, 1)
+	assert.Equal(t, "alice", consumers[0].Name)
+	assert.Equal(t, int64(1), consumers[0].Pending)
+	_, err = testClient.XInfoConsumers(ctx, "xinfo:groups", "missing").Result()
+	assert.EqualError(t, err, "NOGROUP No such consumer group 'missing' for key name 'xinfo:groups'")
+}
+
+func TestXInfoStreamFull(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addEntries(t, "xinfo:full", 3)
+	require.NoError(t, testClient.XGroupCreate(ctx, "xinfo:full", "group", "0").Err())
+	_, err := readGroup("xinfo:full", "group", "alice", ">", 2)
+	require.NoError(t, err)
+
+	info, err := testClient.XInfoStreamFull(ctx, "xinfo:full", 1).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), info.Length)
+	assert.Equal(t, []redis.XMessage{{ID: "1-0", Values: map[string]interface{}{"f": "v"}}}, info.Entries)
+	require.Len(t, info.Groups, 1)
+	group := info.Groups[0]
+	assert.Equal(t, "group", group.Name)
+	assert.Equal(t, "2-0", group.LastDeliveredID)
+	assert.Equal(t, int64(2), group.PelCount)
+	require.Len(t, group.Pending, 1)
+	assert.Equal(t, "alice", group.Pending[0].Consumer)
+	assert.WithinDuration(t, time.Now(), group.Pending[0].DeliveryTime, time.Minute)
+	require.Len(t, group.Consumers, 1)
+	assert.Equal(t, "alice", group.Consumers[0].Name)
+	assert.Equal(t, int64(2), group.Consumers[0].PelCount)
+	assert.Len(t, group.Consumers[0].Pending, 1)
+
+	info, err = testClient.XInfoStreamFull(ctx, "xinfo:full", 0).Result()
+	require.NoError(t, err)
+	assert.Len(t, info.Entries, 3)
+	assert.Len(t, info.Groups[0].Pending, 2)
+
+	_, err = testClient.Do(ctx, "XINFO", "STREAM", "xinfo:full", "EXTRA").Result()
+	assert.EqualError(t, err, "ERR syntax error")
+}
+
+func TestStreamGroups_WrongType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "xgroup:string", "value", 0)
+
+	_, err := testClient.XGroupCreate(ctx, "xgroup:string", "group", "0").Result()
+	assert.EqualError(t, err, wrongTypeError)
+	_, err = testClient.XAck(ctx, "xgroup:string", "group", "1-0").Result()
+	assert.EqualError(t, err, wrongTypeError)
+	_, err = testClient.XPending(ctx, "xgroup:string", "group").Result()
+	assert.EqualError(t, err, wrongTypeError)
+	_, err = readGroup("xgroup:string", "group", "alice", ">", 0)
+	assert.EqualError(t, err, wrongTypeError)
+}
@@ -0,0 +1,97 @@
+package stream
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXRead(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addEntries(t, "xread", 3)
+
+	read, err := testClient.XRead(ctx, &redis.XReadArgs{Streams: []string{"xread", "xread:missing", "1", "0"}, Count: 1, Block: -1}).Result()
+	assert.NoError(t, err)
+	require.Len(t, read, 1)
+	assert.Equal(t, "xread", read[0].Stream)
+	assert.Equal(t, []redis.XMessage{{ID: "2-0", Values: map[string]interface{}{"f": "v"}}}, read[0].Messages)
+	_, err = testClient.XRead(ctx, &redis.XReadArgs{Streams: []string{"xread", "$"}, Block: -1}).Result()
+	assert.Equal(t, redis.Nil, err)
+
+	_, err = testClient.Do(ctx, "XREAD", "STREAMS", "xread", ">").Result()
+	assert.EqualError(t, err, "ERR The > ID can be specified only when calling XREADGROUP using the GROUP <group> <consumer> option.")
+	_, err = testClient.Do(ctx, "XREAD", "STREAMS", "xread", "xread:missing", "0").Result()
+	assert.EqualError(t, err, "ERR Unbalanced 'xread' list of streams: for each stream key an ID or '$' must be specified.")
+	testClient.Set(ctx, "xread:string", "value", 0)
+	_, err = testClient.XRead(ctx, &redis.XReadArgs{Streams: []string{"xread:string", "0"}, Block: -1}).Result()
+	assert.EqualError(t, err, wrongTypeError)
+}
+
+func TestXRead_BlockWakesEveryReader(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	addEntries(t, "xread:block", 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			read, err := testClient.XRead(ctx, &redis.XReadArgs{Streams: []string{"xread:block:other", "xread:block", "$", "$"}, Block: 2 * time.Second}).Result()
+			assert.NoError(t, err)
+			if assert.Len(t, read, 1) {
+				assert.Equal(t, "xread:block", read[0].Stream)
+				assert.Equal(t, []string{"2-0"}, ids(read[0].Messages))
+			}
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xread:block", ID: "2-0", Values: []string{"f", "v"}})
+	wg.Wait()
+}
+
+func TestXRead_BlockTimesOut(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	_, err := testClient.XRead(ctx, &redis.XReadArgs{Streams: []string{"xread:timeout", "$"}, Block: 200 * time.Millisecond}).Result()
+	assert.Equal(t, redis.Nil, err)
+}
+
+func TestXReadGroup_BlocksUntilXAdd(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	require.NoError(t, testClient.XGroupCreateMkStream(ctx, "xreadgroup:block", "group", "$").Err())
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xreadgroup:block", ID: "1-0", Values: []string{"f", "v"}})
+	}()
+
+	read, err := testClient.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "group", Consumer: "alice", Streams: []string{"xreadgroup:block", ">"}, Block: 2 * time.Second}).Result()
+	assert.NoError(t, err)
+	require.Len(t, read, 1)
+	assert.Equal(t, []string{"1-0"}, ids(read[0].Messages))
+	pending, _ := testClient.XPending(ctx, "xreadgroup:block", "group").Result()
+	assert.Equal(t, map[string]int64{"alice": 1}, pending.Consumers)
+}
+
+func TestXReadGroup_BlockedUntilGroupDestroyed(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	require.NoError(t, testClient.XGroupCreateMkStream(ctx, "xreadgroup:destroy", "group", "$").Err())
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		testClient.XGroupDestroy(ctx, "xreadgroup:destroy", "group")
+	}()
+
+	_, err := testClient.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "group", Consumer: "alice", Streams: []string{"xreadgroup:destroy", ">"}, Block: 2 * time.Second}).Result()
+	assert.EqualError(t, err, "NOGROUP the consumer group this client was blocked on no longer exists")
+}
@@ -0,0 +1,210 @@
+package stream
+
+import (
+	"avacado/integration"
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var testClient *redis.Client
+
+func TestMain(m *testing.M) {
+	shutdown, err := integration.StartNewServer(6011)
+	if err != nil {
+		panic(err)
+	}
+
+	testClient = redis.NewClient(&redis.Options{
+		Addr:     "localhost:6011",
+		Password: "",
+		DB:       0,
+	})
+
+	code := m.Run()
+
+	if err := testClient.Close(); err != nil {
+		panic(err)
+	}
+	shutdown()
+	os.Exit(code)
+}
+
+const wrongTypeError = "WRONGTYPE Operation against a key holding the wrong kind of value"
+
+func ids(messages []redis.XMessage) []string {
+	var ids []string
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+	return ids
+}
+
+func TestXAdd_GeneratesAndValidatesIDs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	id, err := testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xadd", ID: "5-*", Values: []string{"f", "v"}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "5-0", id)
+	id, err = testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xadd", ID: "5-*", Values: []string{"f", "v"}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "5-1", id)
+	id, err = testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xadd", ID: "7", Values: []string{"f", "v"}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "7-0", id)
+	id, err = testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xadd", Values: []string{"f", "v"}}).Result()
+	assert.NoError(t, err)
+	assert.NotEqual(t, "7-1", id)
+
+	_, err = testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xadd", ID: "7-0", Values: []string{"f", "v"}}).Result()
+	assert.EqualError(t, err, "ERR The ID specified in XADD is equal or smaller than the target stream top item")
+	_, err = testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xadd:zero", ID: "0-0", Values: []string{"f", "v"}}).Result()
+	assert.EqualError(t, err, "ERR The ID specified in XADD must be greater than 0-0")
+	_, err = testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xadd", ID: "x-1", Values: []string{"f", "v"}}).Result()
+	assert.EqualError(t, err, "ERR Invalid stream ID specified as stream command argument")
+	_, err = testClient.Do(ctx, "XADD", "xadd", "*", "f", "v", "g").Result()
+	assert.EqualError(t, err, "ERR wrong number of arguments for 'xadd' command")
+
+	n, err := testClient.XLen(ctx, "xadd").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+	exists, _ := testClient.Exists(ctx, "xadd:zero").Result()
+	assert.Equal(t, int64(0), exists)
+}
+
+func TestXAdd_NoMkStream(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	_, err := testClient.XAdd(ctx, &redis.XAddArgs{Stream: "nomkstream", NoMkStream: true, Values: []string{"f", "v"}}).Result()
+	assert.Equal(t, redis.Nil, err)
+	exists, _ := testClient.Exists(ctx, "nomkstream").Result()
+	assert.Equal(t, int64(0), exists)
+}
+
+func TestXAdd_Trims(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xadd:maxlen", ID: strconv.Itoa(i), MaxLen: 3, Values: []string{"f", "v"}})
+		testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xadd:minid", ID: strconv.Itoa(i), MinID: "3", Values: []string{"f", "v"}})
+		testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xadd:approx", ID: strconv.Itoa(i), MaxLen: 3, Approx: true, Values: []string{"f", "v"}})
+	}
+
+	messages, err := testClient.XRange(ctx, "xadd:maxlen", "-", "+").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3-0", "4-0", "5-0"}, ids(messages))
+	messages, err = testClient.XRange(ctx, "xadd:minid", "-", "+").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3-0", "4-0", "5-0"}, ids(messages))
+	n, err := testClient.XLen(ctx, "xadd:approx").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), n, "~ only evicts whole nodes")
+}
+
+func TestXRange_RangesAndCounts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xrange", ID: strconv.Itoa(i) + "-1", Values: []string{"f", strconv.Itoa(i)}})
+	}
+
+	messages, err := testClient.XRange(ctx, "xrange", "2", "4").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.XMessage{
+		{ID: "2-1", Values: map[string]interface{}{"f": "2"}},
+		{ID: "3-1", Values: map[string]interface{}{"f": "3"}},
+		{ID: "4-1", Values: map[string]interface{}{"f": "4"}},
+	}, messages)
+
+	messages, err = testClient.XRangeN(ctx, "xrange", "(2-1", "+", 2).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3-1", "4-1"}, ids(messages))
+
+	messages, err = testClient.XRevRangeN(ctx, "xrange", "+", "-", 2).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"5-1", "4-1"}, ids(messages))
+
+	messages, err = testClient.XRevRange(ctx, "xrange", "(4-1", "2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3-1", "2-1"}, ids(messages))
+
+	messages, err = testClient.XRange(ctx, "missing", "-", "+").Result()
+	assert.NoError(t, err)
+	assert.Empty(t, messages)
+
+	_, err = testClient.XRange(ctx, "xrange", "(18446744073709551615-18446744073709551615", "+").Result()
+	assert.EqualError(t, err, "ERR invalid start ID for the interval")
+}
+
+func TestXDelAndXTrim(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	for i := 1; i <= 6; i++ {
+		testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xdel", ID: strconv.Itoa(i), Values: []string{"f", "v"}})
+	}
+
+	n, err := testClient.XDel(ctx, "xdel", "2-0", "2-0", "9-0").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	n, err = testClient.XTrimMaxLen(ctx, "xdel", 3).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	messages, _ := testClient.XRange(ctx, "xdel", "-", "+").Result()
+	assert.Equal(t, []string{"4-0", "5-0", "6-0"}, ids(messages))
+
+	n, err = testClient.XTrimMinID(ctx, "xdel", "6").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	n, err = testClient.XTrimMaxLen(ctx, "xdel", 0).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	exists, _ := testClient.Exists(ctx, "xdel").Result()
+	assert.Equal(t, int64(1), exists, "an empty stream stays")
+
+	_, err = testClient.Do(ctx, "XTRIM", "xdel", "MAXLEN", "1", "LIMIT", "10").Result()
+	assert.EqualError(t, err, "ERR syntax error, LIMIT cannot be used without the special ~ option")
+}
+
+func TestXInfoStream(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	for i := 1; i <= 3; i++ {
+		testClient.XAdd(ctx, &redis.XAddArgs{Stream: "xinfo", ID: strconv.Itoa(i), Values: []string{"f", strconv.Itoa(i)}})
+	}
+	testClient.XDel(ctx, "xinfo", "1-0")
+
+	info, err := testClient.XInfoStream(ctx, "xinfo").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), info.Length)
+	assert.Equal(t, int64(1), info.RadixTreeKeys)
+	assert.Equal(t, "3-0", info.LastGeneratedID)
+	assert.Equal(t, "1-0", info.MaxDeletedEntryID)
+	assert.Equal(t, int64(3), info.EntriesAdded)
+	assert.Equal(t, "2-0", info.RecordedFirstEntryID)
+	assert.Equal(t, redis.XMessage{ID: "2-0", Values: map[string]interface{}{"f": "2"}}, info.FirstEntry)
+	assert.Equal(t, redis.XMessage{ID: "3-0", Values: map[string]interface{}{"f": "3"}}, info.LastEntry)
+
+	_, err = testClient.XInfoStream(ctx, "missing").Result()
+	assert.EqualError(t, err, "ERR no such key")
+}
+
+func TestStreams_WrongType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "stream:string", "v", 0)
+
+	_, err := testClient.XAdd(ctx, &redis.XAddArgs{Stream: "stream:string", Values: []string{"f", "v"}}).Result()
+	assert.EqualError(t, err, wrongTypeError)
+	_, err = testClient.XLen(ctx, "stream:string").Result()
+	assert.EqualError(t, err, wrongTypeError)
+	_, err = testClient.XRange(ctx, "stream:string", "-", "+").Result()
+	assert.EqualError(t, err, wrongTypeError)
+}
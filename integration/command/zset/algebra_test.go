@@ -0,0 +1,82 @@
+package zset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZUnion_WeightsAndAggregates(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.ZAdd(ctx, "zunion:1", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"})
+	testClient.ZAdd(ctx, "zunion:2", redis.Z{Score: 3, Member: "b"}, redis.Z{Score: 4, Member: "c"})
+	testClient.SAdd(ctx, "zunion:set", "c", "d")
+
+	scored, err := testClient.ZUnionWithScores(ctx, redis.ZStore{Keys: []string{"zunion:1", "zunion:2"}, Weights: []float64{2, 1}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.Z{{Score: 2, Member: "a"}, {Score: 4, Member: "c"}, {Score: 7, Member: "b"}}, scored)
+
+	members, err := testClient.ZUnion(ctx, redis.ZStore{Keys: []string{"zunion:2", "zunion:set"}, Aggregate: "MIN"}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "d", "b"}, members)
+
+	n, err := testClient.ZUnionStore(ctx, "zunion:dst", &redis.ZStore{Keys: []string{"zunion:1", "zunion:set"}, Aggregate: "MAX"}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+	scored, err = testClient.ZRangeWithScores(ctx, "zunion:dst", 0, -1).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.Z{{Score: 1, Member: "a"}, {Score: 1, Member: "c"}, {Score: 1, Member: "d"}, {Score: 2, Member: "b"}}, scored)
+}
+
+func TestZInter_IntersectsAndCounts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.ZAdd(ctx, "zinter:1", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"}, redis.Z{Score: 3, Member: "c"})
+	testClient.ZAdd(ctx, "zinter:2", redis.Z{Score: 10, Member: "b"}, redis.Z{Score: 20, Member: "c"})
+
+	scored, err := testClient.ZInterWithScores(ctx, &redis.ZStore{Keys: []string{"zinter:1", "zinter:2"}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.Z{{Score: 12, Member: "b"}, {Score: 23, Member: "c"}}, scored)
+
+	n, err := testClient.ZInterStore(ctx, "zinter:dst", &redis.ZStore{Keys: []string{"zinter:1", "zinter:missing"}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	n, err = testClient.ZInterCard(ctx, 0, "zinter:1", "zinter:2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	n, err = testClient.ZInterCard(ctx, 1, "zinter:1", "zinter:2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
+
+func TestZDiff_KeepsTheScoresOfTheFirstKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.ZAdd(ctx, "zdiff:1", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"}, redis.Z{Score: 3, Member: "c"})
+	testClient.SAdd(ctx, "zdiff:set", "b")
+
+	scored, err := testClient.ZDiffWithScores(ctx, "zdiff:1", "zdiff:set").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.Z{{Score: 1, Member: "a"}, {Score: 3, Member: "c"}}, scored)
+
+	n, err := testClient.ZDiffStore(ctx, "zdiff:dst", "zdiff:1", "zdiff:set").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+}
+
+func TestZUnion_Errors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.Set(ctx, "zalgebra:string", "v", 0)
+
+	err := testClient.ZUnion(ctx, redis.ZStore{Keys: []string{"zalgebra:string"}}).Err()
+	assert.EqualError(t, err, wrongTypeError)
+	err = testClient.Do(ctx, "ZUNION", "0", "k").Err()
+	assert.EqualError(t, err, "ERR at least 1 input key is needed for 'zunion' command")
+	err = testClient.Do(ctx, "ZINTER", "1", "k", "WEIGHTS", "x").Err()
+	assert.EqualError(t, err, "ERR weight value is not a float")
+}
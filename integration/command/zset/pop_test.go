@@ -0,0 +1,128 @@
+package zset
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZPop_PopsLowestAndHighest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.ZAdd(ctx, "zpop", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"}, redis.Z{Score: 3, Member: "c"}, redis.Z{Score: 4, Member: "d"})
+
+	popped, err := testClient.ZPopMin(ctx, "zpop").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.Z{{Score: 1, Member: "a"}}, popped)
+
+	popped, err = testClient.ZPopMax(ctx, "zpop", 2).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.Z{{Score: 4, Member: "d"}, {Score: 3, Member: "c"}}, popped)
+
+	popped, err = testClient.ZPopMin(ctx, "zpop:missing").Result()
+	assert.NoError(t, err)
+	assert.Empty(t, popped)
+}
+
+func TestZMPop_PopsFromTheFirstNonEmptyKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.ZAdd(ctx, "zmpop:2", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2.5, Member: "b"})
+
+	key, popped, err := testClient.ZMPop(ctx, "max", 5, "zmpop:1", "zmpop:2").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "zmpop:2", key)
+	assert.Equal(t, []redis.Z{{Score: 2.5, Member: "b"}, {Score: 1, Member: "a"}}, popped)
+
+	_, _, err = testClient.ZMPop(ctx, "min", 1, "zmpop:1", "zmpop:2").Result()
+	assert.Equal(t, redis.Nil, err)
+}
+
+func TestBZPopMin_BlocksUntilZAdd(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		testClient.ZAdd(ctx, "bzpopmin", redis.Z{Score: 2, Member: "b"}, redis.Z{Score: 1, Member: "a"})
+	}()
+
+	result, err := testClient.BZPopMin(ctx, 2*time.Second, "bzpopmin:other", "bzpopmin").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, &redis.ZWithKey{Key: "bzpopmin", Z: redis.Z{Score: 1, Member: "a"}}, result)
+}
+
+func TestBZPopMax_Timeout(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	_, err := testClient.BZPopMax(ctx, 200*time.Millisecond, "bzpopmax:empty").Result()
+	assert.Equal(t, redis.Nil, err)
+}
+
+func TestBZMPop_BlocksUntilZUnionStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.ZAdd(ctx, "bzmpop:source", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"})
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		testClient.ZUnionStore(ctx, "bzmpop", &redis.ZStore{Keys: []string{"bzmpop:source"}})
+	}()
+
+	key, popped, err := testClient.BZMPop(ctx, 2*time.Second, "max", 5, "bzmpop").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "bzmpop", key)
+	assert.Equal(t, []redis.Z{{Score: 2, Member: "b"}, {Score: 1, Member: "a"}}, popped)
+}
+
+func TestBZPopMin_OneWriteServesSeveralClients(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	members := make(chan string, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := testClient.BZPopMin(ctx, 2*time.Second, "bzpopmin:many").Result()
+			assert.NoError(t, err)
+			if err == nil {
+				members <- result.Member.(string)
+			}
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	testClient.ZAdd(ctx, "bzpopmin:many", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"})
+	wg.Wait()
+	close(members)
+
+	var served []string
+	for member := range members {
+		served = append(served, member)
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, served)
+}
+
+func TestBZPopMin_IgnoresKeysOfAnotherType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		testClient.RPush(ctx, "bzpopmin:list", "v")
+		testClient.Rename(ctx, "bzpopmin:list", "bzpopmin:typed")
+		testClient.ZAdd(ctx, "bzpopmin:typed:zset", redis.Z{Score: 1, Member: "a"})
+		testClient.Do(ctx, "COPY", "bzpopmin:typed:zset", "bzpopmin:typed", "REPLACE")
+	}()
+
+	result, err := testClient.BZPopMin(ctx, 2*time.Second, "bzpopmin:typed").Result()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "a", result.Member)
+	}
+}
@@ -0,0 +1,127 @@
+package zset
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZRange_ByRankScoreAndLex(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.ZAdd(ctx, "zrange", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2.5, Member: "b"}, redis.Z{Score: 2.5, Member: "c"}, redis.Z{Score: 3, Member: "d"})
+
+	members, err := testClient.ZRange(ctx, "zrange", 1, -2).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, members)
+
+	members, err = testClient.ZRangeArgs(ctx, redis.ZRangeArgs{Key: "zrange", Start: 0, Stop: 0, Rev: true}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"d"}, members)
+
+	scored, err := testClient.ZRangeArgsWithScores(ctx, redis.ZRangeArgs{Key: "zrange", Start: "(1", Stop: "+inf", ByScore: true, Count: 2}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.Z{{Score: 2.5, Member: "b"}, {Score: 2.5, Member: "c"}}, scored)
+
+	members, err = testClient.ZRangeArgs(ctx, redis.ZRangeArgs{Key: "zrange", Start: "-inf", Stop: "3", ByScore: true, Rev: true, Offset: 1, Count: 2}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "b"}, members)
+
+	members, err = testClient.ZRangeArgs(ctx, redis.ZRangeArgs{Key: "zrange", Start: "[b", Stop: "+", ByLex: true}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c", "d"}, members)
+
+	members, err = testClient.ZRange(ctx, "zrange:missing", 0, -1).Result()
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+
+	err = testClient.Do(ctx, "ZRANGE", "zrange", "0", "1", "LIMIT", "0", "1").Err()
+	assert.EqualError(t, err, "ERR syntax error, LIMIT is only supported in combination with either BYSCORE or BYLEX")
+	err = testClient.Do(ctx, "ZRANGE", "zrange", "a", "1", "BYSCORE").Err()
+	assert.EqualError(t, err, "ERR min or max is not a float")
+}
+
+func TestZRange_RangesASkiplist(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	members := make([]redis.Z, 0, 200)
+	for i := 0; i < 200; i++ {
+		members = append(members, redis.Z{Score: float64(i), Member: strconv.Itoa(i)})
+	}
+	testClient.ZAdd(ctx, "zrange:skiplist", members...)
+
+	result, err := testClient.ZRangeArgs(ctx, redis.ZRangeArgs{Key: "zrange:skiplist", Start: "(100", Stop: "150", ByScore: true, Offset: 10, Count: 3}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"111", "112", "113"}, result)
+
+	result, err = testClient.ZRangeArgs(ctx, redis.ZRangeArgs{Key: "zrange:skiplist", Start: 0, Stop: 1, Rev: true}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"199", "198"}, result)
+
+	count, err := testClient.ZCount(ctx, "zrange:skiplist", "10", "(20").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), count)
+}
+
+func TestZRangeStore_StoresTheRange(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.ZAdd(ctx, "zrangestore:src", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"}, redis.Z{Score: 3, Member: "c"})
+	testClient.Set(ctx, "zrangestore:dst", "value", 0)
+
+	n, err := testClient.ZRangeStore(ctx, "zrangestore:dst", redis.ZRangeArgs{Key: "zrangestore:src", Start: 2, Stop: "+inf", ByScore: true}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	scored, err := testClient.ZRangeWithScores(ctx, "zrangestore:dst", 0, -1).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []redis.Z{{Score: 2, Member: "b"}, {Score: 3, Member: "c"}}, scored)
+
+	n, err = testClient.ZRangeStore(ctx, "zrangestore:dst", redis.ZRangeArgs{Key: "zrangestore:src", Start: 5, Stop: 10}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	exists, err := testClient.Exists(ctx, "zrangestore:dst").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), exists)
+}
+
+func TestZCount_CountsScoreAndLexRanges(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.ZAdd(ctx, "zcount", redis.Z{Score: 0, Member: "a"}, redis.Z{Score: 0, Member: "b"}, redis.Z{Score: 0, Member: "c"})
+
+	count, err := testClient.ZCount(ctx, "zcount", "(0", "+inf").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	count, err = testClient.ZLexCount(ctx, "zcount", "(a", "+").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	err = testClient.ZLexCount(ctx, "zcount", "a", "+").Err()
+	assert.EqualError(t, err, "ERR min or max not valid string range item")
+}
+
+func TestZRemRange_RemovesRanges(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testClient.ZAdd(ctx, "zremrange", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"}, redis.Z{Score: 3, Member: "c"}, redis.Z{Score: 4, Member: "d"}, redis.Z{Score: 5, Member: "e"})
+
+	n, err := testClient.ZRemRangeByRank(ctx, "zremrange", -1, -1).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	n, err = testClient.ZRemRangeByScore(ctx, "zremrange", "(1", "3").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	n, err = testClient.ZRemRangeByLex(ctx, "zremrange", "-", "+").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	exists, err := testClient.Exists(ctx, "zremrange").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), exists)
+}
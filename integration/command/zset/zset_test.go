@@ -0,0 +1,209 @@
+package zset
+
+import (
+	"avacado/integration"
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var testClient *redis.Client
+
+func TestMain(m *testing.M) {
+	shutdown, err := integration.StartNewServer(6010)
+	if err != nil {
+		panic(err)
+	}
+
+	testClient = redis.NewClient(&redis.Options{
+		Addr:     "localhost:6010",
+		Password: "",
+		DB:       0,
+	})
+
+	code := m.Run()
+
+	if err := testClient.Close(); err != nil {
+		panic(err)
+	}
+	shutdown()
+	os.Exit(code)
+}
+
+const wrongTypeError = "WRONGTYPE Operation against a key holding the wrong kind of value"
+
+func TestZAdd_AddsAndUpdatesMembers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	n, err := testClient.ZAdd(ctx, "zadd", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	n, err = testClient.ZAdd(ctx, "zadd", redis.Z{Score: 3, Member: "a"}, redis.Z{Score: 4, Member: "c"}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	card, err := testClient.ZCard(ctx, "zadd").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), card)
+
+	score, err := testClient.ZScore(ctx, "zadd", "a").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, score)
+}
+
+func TestZAdd_Options(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.ZAdd(ctx, "zadd:options", redis.Z{Score: 5, Member: "a"})
+
+	n, err := testClient.ZAddArgs(ctx, "zadd:options", redis.ZAddArgs{GT: true, Ch: true, Members: []redis.Z{{Score: 4, Member: "a"}, {Score: 1, Member: "b"}}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	n, err = testClient.ZAddArgs(ctx, "zadd:options", redis.ZAddArgs{XX: true, Ch: true, Members: []redis.Z{{Score: 6, Member: "a"}, {Score: 1, Member: "c"}}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	n, err = testClient.ZAddArgs(ctx, "zadd:options", redis.ZAddArgs{NX: true, Members: []redis.Z{{Score: 0, Member: "a"}, {Score: 1, Member: "c"}}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	score, err := testClient.ZAddArgsIncr(ctx, "zadd:options", redis.ZAddArgs{Members: []redis.Z{{Score: 0.5, Member: "a"}}}).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 6.5, score)
+
+	err = testClient.ZAddArgsIncr(ctx, "zadd:options", redis.ZAddArgs{NX: true, Members: []redis.Z{{Score: 1, Member: "a"}}}).Err()
+	assert.ErrorIs(t, err, redis.Nil)
+
+	err = testClient.Do(ctx, "ZADD", "zadd:options", "NX", "XX", "1", "a").Err()
+	assert.EqualError(t, err, "ERR XX and NX options at the same time are not compatible")
+
+	err = testClient.Do(ctx, "ZADD", "zadd:options", "nan", "a").Err()
+	assert.EqualError(t, err, "ERR value is not a valid float")
+}
+
+func TestZIncrBy_IncrementsScores(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	score, err := testClient.ZIncrBy(ctx, "zincrby", 1.5, "a").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, score)
+
+	score, err = testClient.ZIncrBy(ctx, "zincrby", -4, "a").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, -2.5, score)
+
+	testClient.Do(ctx, "ZADD", "zincrby", "-inf", "b")
+	err = testClient.Do(ctx, "ZINCRBY", "zincrby", "+inf", "b").Err()
+	assert.EqualError(t, err, "ERR resulting score is not a number (NaN)")
+}
+
+func TestZScore_FormatsScoresLikeRedis(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.Do(ctx, "ZADD", "zscore", "1e21", "big", "0.1", "small", "+inf", "inf", "10", "ten")
+
+	for member, expected := range map[string]string{"big": "1e+21", "small": "0.1", "inf": "inf", "ten": "10"} {
+		score, err := testClient.Do(ctx, "ZSCORE", "zscore", member).Text()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, score, member)
+	}
+
+	err := testClient.ZScore(ctx, "zscore", "missing").Err()
+	assert.ErrorIs(t, err, redis.Nil)
+
+	scores, err := testClient.ZMScore(ctx, "zscore", "ten", "missing").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{10, 0}, scores)
+}
+
+func TestZRank_RanksMembers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.ZAdd(ctx, "zrank", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"}, redis.Z{Score: 3, Member: "c"})
+
+	rank, err := testClient.ZRank(ctx, "zrank", "b").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rank)
+
+	rank, err = testClient.ZRevRank(ctx, "zrank", "a").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rank)
+
+	rankWithScore, err := testClient.ZRankWithScore(ctx, "zrank", "c").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, redis.RankScore{Rank: 2, Score: 3}, rankWithScore)
+
+	err = testClient.ZRank(ctx, "zrank", "missing").Err()
+	assert.ErrorIs(t, err, redis.Nil)
+}
+
+func TestZRem_RemovesTheKeyWithItsLastMember(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.ZAdd(ctx, "zrem", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"})
+
+	n, err := testClient.ZRem(ctx, "zrem", "a", "missing").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	n, err = testClient.ZRem(ctx, "zrem", "b").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	exists, err := testClient.Exists(ctx, "zrem").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), exists)
+}
+
+func TestZSets_ConvertToSkiplist(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.ZAdd(ctx, "encoding", redis.Z{Score: 1, Member: "a"})
+	encoding, err := testClient.ObjectEncoding(ctx, "encoding").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "listpack", encoding)
+
+	testClient.ZAdd(ctx, "encoding", redis.Z{Score: 2, Member: strings.Repeat("x", 65)})
+	encoding, err = testClient.ObjectEncoding(ctx, "encoding").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "skiplist", encoding)
+
+	members := make([]redis.Z, 200)
+	for i := range members {
+		members[i] = redis.Z{Score: float64(200 - i), Member: "m" + strconv.Itoa(i)}
+	}
+	testClient.ZAdd(ctx, "encoding:large", members...)
+	encoding, err = testClient.ObjectEncoding(ctx, "encoding:large").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "skiplist", encoding)
+
+	rank, err := testClient.ZRank(ctx, "encoding:large", "m0").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(199), rank)
+}
+
+func TestZSets_WrongType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testClient.Set(ctx, "wrongtype", "x", 0)
+
+	assert.EqualError(t, testClient.ZAdd(ctx, "wrongtype", redis.Z{Score: 1, Member: "a"}).Err(), wrongTypeError)
+	assert.EqualError(t, testClient.ZScore(ctx, "wrongtype", "a").Err(), wrongTypeError)
+	assert.EqualError(t, testClient.ZCard(ctx, "wrongtype").Err(), wrongTypeError)
+	assert.EqualError(t, testClient.ZRank(ctx, "wrongtype", "a").Err(), wrongTypeError)
+}
@@ -13,9 +13,12 @@ import (
 )
 
 func StartNewServer(port int64) (func(), error) {
+	return StartNewServerWithConfig(port, storage.DefaultConfig())
+}
+
+func StartNewServerWithConfig(port int64, config storage.Config) (func(), error) {
 	logger := observability.NewNoOutLogger()
-	store := storage.NewDefaultStorage()
-	exec := executor.New(store)
+	exec := executor.New(storage.NewDefaultDatabases(config))
 	go exec.Run(context.Background())
 	s := server.NewServer(
 		resp.NewRespProtocol(),
@@ -4,13 +4,103 @@ import (
 	"avacado/internal/protocol"
 	"avacado/internal/storage"
 	"context"
+	"errors"
 	"fmt"
+	"time"
+)
+
+var (
+	// ErrSyntax is returned when a command is given options it does not understand or that cannot be combined
+	ErrSyntax = errors.New("ERR syntax error")
+	// ErrNotInteger is returned when an argument expected to be an integer is not one or is out of range
+	ErrNotInteger = errors.New("ERR value is not an integer or out of range")
+	// ErrDBIndexOutOfRange is returned when a command designates a database that does not exist
+	ErrDBIndexOutOfRange = errors.New("ERR DB index is out of range")
+	// ErrNoDatabases is returned when a command needing every database is run outside the executor
+	ErrNoDatabases = errors.New("ERR databases are not available")
+	// ErrNumKeys is returned when the numkeys argument of a command taking a variable number of keys is not positive
+	ErrNumKeys = errors.New("ERR numkeys should be greater than 0")
+	// ErrTooManyKeys is returned when numkeys exceeds the number of arguments left for keys
+	ErrTooManyKeys = errors.New("ERR Number of keys can't be greater than number of args")
+	// ErrNegativeLimit is returned when the LIMIT of a command counting members is negative or not an integer
+	ErrNegativeLimit = errors.New("ERR LIMIT can't be negative")
+	// ErrOOM is returned for a DenyOOM command when the memory used exceeds maxmemory and nothing can be evicted
+	ErrOOM = errors.New("OOM command not allowed when used memory > 'maxmemory'.")
 )
 
 // BlockRegistry is implemented by the executor and injected via context so blocking
 // commands can register without importing the executor package.
 type BlockRegistry interface {
-	RegisterBlockedClient(keys []string, direction string) (<-chan *protocol.Response, context.CancelFunc)
+	RegisterBlockedClient(ctx context.Context, keys []string, unblocker Unblocker) (<-chan *protocol.Response, context.CancelFunc)
+}
+
+// Unblocker is implemented by the blocking commands to serve their client once a key it is
+// blocked on receives data it can take.
+type Unblocker interface {
+	// Ready reports whether key holds data the client can take.
+	Ready(ctx context.Context, storage storage.Storage, key string) bool
+	// Unblock takes the data from key, which is Ready, and returns the reply to the client.
+	Unblock(ctx context.Context, storage storage.Storage, key string) *protocol.Response
+}
+
+// KeyWriter is implemented by the commands that may add data to keys clients can be blocked on.
+// Once such a command ran, the executor serves the clients blocked on the keys it wrote.
+type KeyWriter interface {
+	WrittenKeys() []string
+}
+
+// DBKey is a key of the database at index DB.
+type DBKey struct {
+	DB  int
+	Key string
+}
+
+// DBKeyWriter is implemented by the commands that may add data to keys of a database other than
+// the selected one, such as MOVE and COPY with DB. Once such a command ran, the executor serves
+// the clients blocked on the keys it wrote there.
+type DBKeyWriter interface {
+	WrittenDBKeys() []DBKey
+}
+
+// DBSwapper is implemented by SWAPDB. Once it ran, the executor serves the clients blocked on
+// keys of the two swapped databases.
+type DBSwapper interface {
+	SwappedDBs() (int, int)
+}
+
+// Block blocks the client on keys until unblocker can serve it, until timeout seconds passed
+// unless timeout is 0, or until ctx is done as the client disconnects, and returns the response
+// whose BlockCh delivers the reply.
+func Block(ctx context.Context, keys []string, unblocker Unblocker, timeout float64) *protocol.Response {
+	registry, ok := BlockRegistryFromContext(ctx)
+	if !ok {
+		return protocol.NewNullBulkStringResponse()
+	}
+	blockCh, cancelFn := registry.RegisterBlockedClient(ctx, keys, unblocker)
+
+	// The goroutine relays the reply, so that it ends as soon as the client is served.
+	// For timeout=0 the client waits indefinitely until data arrives.
+	replyCh := make(chan *protocol.Response, 1)
+	go func() {
+		var expired <-chan time.Time
+		if timeout > 0 {
+			timer := time.NewTimer(time.Duration(timeout * float64(time.Second)))
+			defer timer.Stop()
+			expired = timer.C
+		}
+		select {
+		case reply := <-blockCh:
+			replyCh <- reply
+			return
+		case <-expired:
+		case <-ctx.Done():
+		}
+		// Whether the cancellation or the executor won, exactly one reply is delivered.
+		cancelFn()
+		replyCh <- <-blockCh
+	}()
+
+	return &protocol.Response{BlockCh: replyCh}
 }
 
 type blockRegistryKey struct{}
@@ -26,6 +116,28 @@ func ContextWithBlockRegistry(ctx context.Context, r BlockRegistry) context.Cont
 	return context.WithValue(ctx, blockRegistryKey{}, r)
 }
 
+type databasesKey struct{}
+
+// DatabasesFromContext extracts the databases injected by the executor, for the commands
+// working on databases other than the selected one.
+func DatabasesFromContext(ctx context.Context) (storage.Databases, bool) {
+	d, ok := ctx.Value(databasesKey{}).(storage.Databases)
+	return d, ok
+}
+
+// ContextWithDatabases returns a context that carries the given databases.
+func ContextWithDatabases(ctx context.Context, d storage.Databases) context.Context {
+	return context.WithValue(ctx, databasesKey{}, d)
+}
+
+// CheckDBIndex returns ErrDBIndexOutOfRange unless index designates one of the databases.
+func CheckDBIndex(databases storage.Databases, index int) error {
+	if index < 0 || index >= databases.Count() {
+		return ErrDBIndexOutOfRange
+	}
+	return nil
+}
+
 // Command represent a redis command.
 //
 //go:generate sh -c "rm -f mock/command.go && mockgen -source=command.go -destination=mock/command.go -package=mockcommand"
@@ -33,6 +145,12 @@ type Command interface {
 	Execute(ctx context.Context, storage storage.Storage) *protocol.Response
 }
 
+// DenyOOM is implemented by the commands that may add data. The executor refuses them with
+// ErrOOM when the memory used exceeds maxmemory and eviction cannot bring it back under.
+type DenyOOM interface {
+	DenyOOM()
+}
+
 // Parser parses a raw message to a redis command
 type Parser interface {
 	Parse(msg *protocol.Message) (Command, error)
@@ -55,6 +173,12 @@ func NewInvalidArgumentsCount(name string, expectedCount int, actualCount int) e
 	return fmt.Errorf("%s parse error, expected count <%d> actual count <%d>", name, expectedCount, actualCount)
 }
 
+// NewUnknownSubcommandError create a new error to be return when a container command such as MEMORY
+// or OBJECT gets an unknown subcommand, or a known one with the wrong number of arguments
+func NewUnknownSubcommandError(name string, subcommand string) error {
+	return fmt.Errorf("ERR unknown subcommand or wrong number of arguments for '%s'. Try %s HELP.", subcommand, name)
+}
+
 // NewInvalidTypeError create a new error to be return when there is incorrect type of argument
 func NewInvalidTypeError(name string, field string) error {
 	return fmt.Errorf("%s parse error, incorrect option type %s", name, field)
@@ -0,0 +1,53 @@
+package connection
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/config"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"errors"
+	"strconv"
+)
+
+// Select changes the database the following commands of the connection run against
+type Select struct {
+	DB int
+}
+
+func (s *Select) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	databases, ok := command.DatabasesFromContext(ctx)
+	if !ok {
+		return protocol.NewErrorResponse(command.ErrNoDatabases)
+	}
+	if err := command.CheckDBIndex(databases, s.DB); err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	cc, ok := ctx.Value(config.ClientConfigKey).(*config.ClientConfig)
+	if !ok {
+		return protocol.NewErrorResponse(errors.New("internal server error"))
+	}
+	cc.DB = s.DB
+	return protocol.NewSimpleStringResponse("OK")
+}
+
+type SelectParser struct{}
+
+func NewSelectParser() *SelectParser {
+	return &SelectParser{}
+}
+
+func (s *SelectParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 1 {
+		return nil, command.NewInvalidArgumentsCount(s.Name(), 1, len(msg.Args))
+	}
+	db, err := strconv.Atoi(msg.Args[0])
+	if err != nil {
+		return nil, command.ErrNotInteger
+	}
+	return &Select{DB: db}, nil
+}
+
+func (s *SelectParser) Name() string {
+	return "SELECT"
+}
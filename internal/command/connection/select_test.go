@@ -0,0 +1,44 @@
+package connection
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/config"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSelect_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	databases := mocksstorage.NewMockDatabases(controller)
+	databases.EXPECT().Count().Return(16).AnyTimes()
+	cc := config.DefaultClientConfig()
+	ctx := command.ContextWithDatabases(context.WithValue(context.Background(), config.ClientConfigKey, cc), databases)
+
+	response := (&Select{DB: 9}).Execute(ctx, mocksstorage.NewMockStorage(controller))
+	assert.Equal(t, protocol.NewSimpleStringResponse("OK"), response)
+	assert.Equal(t, 9, cc.DB)
+
+	response = (&Select{DB: 16}).Execute(ctx, mocksstorage.NewMockStorage(controller))
+	assert.EqualError(t, response.Err, "ERR DB index is out of range")
+	response = (&Select{DB: -1}).Execute(ctx, mocksstorage.NewMockStorage(controller))
+	assert.EqualError(t, response.Err, "ERR DB index is out of range")
+	assert.Equal(t, 9, cc.DB)
+}
+
+func TestSelectParser_Parse(t *testing.T) {
+	parser := NewSelectParser()
+
+	cmd, err := parser.Parse(&protocol.Message{Command: "SELECT", Args: []string{"3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Select{DB: 3}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SELECT", Args: []string{"one"}})
+	assert.EqualError(t, err, "ERR value is not an integer or out of range")
+	_, err = parser.Parse(&protocol.Message{Command: "SELECT", Args: []string{}})
+	assert.Error(t, err)
+}
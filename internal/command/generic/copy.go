@@ -0,0 +1,102 @@
+package generic
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/config"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Copy copies the value and expiry of a key to another key, replacing it only with REPLACE.
+// The destination key lives in the database DB when set, in the selected database otherwise.
+type Copy struct {
+	Source      string
+	Destination string
+	DB          *int
+	Replace     bool
+	copied      bool
+}
+
+func (c *Copy) DenyOOM() {}
+
+// WrittenKeys returns the destination unless the command names a database, which may not be
+// the selected one. WrittenDBKeys reports it then.
+func (c *Copy) WrittenKeys() []string {
+	if c.DB != nil {
+		return nil
+	}
+	return []string{c.Destination}
+}
+
+// WrittenDBKeys returns the destination in the database the command names once it was copied there.
+func (c *Copy) WrittenDBKeys() []command.DBKey {
+	if c.DB == nil || !c.copied {
+		return nil
+	}
+	return []command.DBKey{{DB: *c.DB, Key: c.Destination}}
+}
+
+func (c *Copy) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	copied, err := c.copy(ctx, storage)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if copied {
+		c.copied = true
+		return protocol.NewNumberResponse(1)
+	}
+	return protocol.NewNumberResponse(0)
+}
+
+func (c *Copy) copy(ctx context.Context, storage storage.Storage) (bool, error) {
+	if c.DB == nil {
+		return storage.Keyspace().Copy(ctx, c.Source, c.Destination, c.Replace)
+	}
+	databases, ok := command.DatabasesFromContext(ctx)
+	if !ok {
+		return false, command.ErrNoDatabases
+	}
+	if err := command.CheckDBIndex(databases, *c.DB); err != nil {
+		return false, err
+	}
+	return databases.Copy(ctx, c.Source, config.SelectedDB(ctx), c.Destination, *c.DB, c.Replace)
+}
+
+type CopyParser struct{}
+
+func NewCopyParser() *CopyParser {
+	return &CopyParser{}
+}
+
+func (c *CopyParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(c.Name(), 2, len(msg.Args))
+	}
+	cmd := &Copy{Source: msg.Args[0], Destination: msg.Args[1]}
+	for i := 2; i < len(msg.Args); i++ {
+		switch strings.ToUpper(msg.Args[i]) {
+		case "REPLACE":
+			cmd.Replace = true
+		case "DB":
+			if i+1 >= len(msg.Args) {
+				return nil, command.ErrSyntax
+			}
+			i++
+			db, err := strconv.Atoi(msg.Args[i])
+			if err != nil {
+				return nil, command.ErrNotInteger
+			}
+			cmd.DB = &db
+		default:
+			return nil, command.ErrSyntax
+		}
+	}
+	return cmd, nil
+}
+
+func (c *CopyParser) Name() string {
+	return "COPY"
+}
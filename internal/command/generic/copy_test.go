@@ -0,0 +1,77 @@
+package generic
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/config"
+	"avacado/internal/protocol"
+	"avacado/internal/storage/keyspace"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCopy_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks).AnyTimes()
+	ks.EXPECT().Copy(gomock.Any(), "a", "b", true).Return(true, nil)
+	ks.EXPECT().Copy(gomock.Any(), "a", "taken", false).Return(false, nil)
+	ks.EXPECT().Copy(gomock.Any(), "a", "a", false).Return(false, keyspace.ErrSameObject)
+
+	response := (&Copy{Source: "a", Destination: "b", Replace: true}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(1), response)
+
+	response = (&Copy{Source: "a", Destination: "taken"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(0), response)
+
+	response = (&Copy{Source: "a", Destination: "a"}).Execute(context.Background(), storage)
+	assert.EqualError(t, response.Err, "ERR source and destination objects are the same")
+
+}
+
+func TestCopy_ExecuteToAnotherDB(t *testing.T) {
+	controller := gomock.NewController(t)
+	databases := mocksstorage.NewMockDatabases(controller)
+	databases.EXPECT().Count().Return(16).AnyTimes()
+	databases.EXPECT().Copy(gomock.Any(), "a", 2, "b", 5, false).Return(true, nil)
+	ctx := command.ContextWithDatabases(
+		context.WithValue(context.Background(), config.ClientConfigKey, &config.ClientConfig{DB: 2}),
+		databases,
+	)
+
+	db := 5
+	cmd := &Copy{Source: "a", Destination: "b", DB: &db}
+	assert.Equal(t, protocol.NewNumberResponse(1), cmd.Execute(ctx, mocksstorage.NewMockStorage(controller)))
+	assert.Empty(t, cmd.WrittenKeys())
+	assert.Equal(t, []command.DBKey{{DB: 5, Key: "b"}}, cmd.WrittenDBKeys())
+
+	outOfRange := 16
+	cmd = &Copy{Source: "a", Destination: "b", DB: &outOfRange}
+	assert.EqualError(t, cmd.Execute(ctx, mocksstorage.NewMockStorage(controller)).Err, "ERR DB index is out of range")
+	assert.Empty(t, cmd.WrittenDBKeys())
+}
+
+func TestCopyParser_Parse(t *testing.T) {
+	parser := NewCopyParser()
+
+	cmd, err := parser.Parse(&protocol.Message{Command: "COPY", Args: []string{"a", "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Copy{Source: "a", Destination: "b"}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "COPY", Args: []string{"a", "b", "db", "0", "REPLACE"}})
+	assert.NoError(t, err)
+	db := 0
+	assert.Equal(t, &Copy{Source: "a", Destination: "b", DB: &db, Replace: true}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "COPY", Args: []string{"a", "b", "DB"}})
+	assert.EqualError(t, err, "ERR syntax error")
+	_, err = parser.Parse(&protocol.Message{Command: "COPY", Args: []string{"a", "b", "DB", "x"}})
+	assert.EqualError(t, err, "ERR value is not an integer or out of range")
+	_, err = parser.Parse(&protocol.Message{Command: "COPY", Args: []string{"a", "b", "FORCE"}})
+	assert.EqualError(t, err, "ERR syntax error")
+}
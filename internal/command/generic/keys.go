@@ -0,0 +1,47 @@
+package generic
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// Keys returns all the keys matching a glob-style pattern
+type Keys struct {
+	Pattern string
+}
+
+func (k *Keys) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	keys, err := storage.Keyspace().Keys(ctx, k.Pattern)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewSuccessResponse(keysValue(keys))
+}
+
+// keysValue converts key names to an array of bulk strings, as keys may hold any byte.
+func keysValue(keys []string) protocol.Value {
+	values := make([]protocol.Value, len(keys))
+	for i, key := range keys {
+		values[i] = protocol.NewBulkStringProtocolValue([]byte(key))
+	}
+	return protocol.NewArrayProtocolValue(values)
+}
+
+type KeysParser struct{}
+
+func NewKeysParser() *KeysParser {
+	return &KeysParser{}
+}
+
+func (k *KeysParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 1 {
+		return nil, command.NewInvalidArgumentsCount(k.Name(), 1, len(msg.Args))
+	}
+	return &Keys{Pattern: msg.Args[0]}, nil
+}
+
+func (k *KeysParser) Name() string {
+	return "KEYS"
+}
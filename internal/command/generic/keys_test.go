@@ -0,0 +1,33 @@
+package generic
+
+import (
+	"avacado/internal/protocol"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestKeys_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks)
+	ks.EXPECT().Keys(gomock.Any(), "user:*").Return([]string{"user:1", "user:2"}, nil)
+
+	response := (&Keys{Pattern: "user:*"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewArrayResponse([][]byte{[]byte("user:1"), []byte("user:2")}), response)
+}
+
+func TestKeysParser_Parse(t *testing.T) {
+	parser := NewKeysParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "KEYS", Args: []string{"*"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Keys{Pattern: "*"}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "KEYS", Args: []string{}})
+	assert.Error(t, err)
+}
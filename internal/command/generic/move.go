@@ -0,0 +1,71 @@
+package generic
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/config"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/keyspace"
+	"context"
+	"strconv"
+)
+
+// Move moves a key with its expiry from the selected database to another one,
+// unless the key does not exist or the destination database already holds it
+type Move struct {
+	Key   string
+	DB    int
+	moved bool
+}
+
+// WrittenDBKeys returns the key in the destination database once it was moved there.
+func (m *Move) WrittenDBKeys() []command.DBKey {
+	if !m.moved {
+		return nil
+	}
+	return []command.DBKey{{DB: m.DB, Key: m.Key}}
+}
+
+func (m *Move) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	databases, ok := command.DatabasesFromContext(ctx)
+	if !ok {
+		return protocol.NewErrorResponse(command.ErrNoDatabases)
+	}
+	if err := command.CheckDBIndex(databases, m.DB); err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	from := config.SelectedDB(ctx)
+	if from == m.DB {
+		return protocol.NewErrorResponse(keyspace.ErrSameObject)
+	}
+	moved, err := databases.Move(ctx, m.Key, from, m.DB)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if moved {
+		m.moved = true
+		return protocol.NewNumberResponse(1)
+	}
+	return protocol.NewNumberResponse(0)
+}
+
+type MoveParser struct{}
+
+func NewMoveParser() *MoveParser {
+	return &MoveParser{}
+}
+
+func (m *MoveParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 2 {
+		return nil, command.NewInvalidArgumentsCount(m.Name(), 2, len(msg.Args))
+	}
+	db, err := strconv.Atoi(msg.Args[1])
+	if err != nil {
+		return nil, command.ErrNotInteger
+	}
+	return &Move{Key: msg.Args[0], DB: db}, nil
+}
+
+func (m *MoveParser) Name() string {
+	return "MOVE"
+}
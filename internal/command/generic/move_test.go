@@ -0,0 +1,53 @@
+package generic
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/config"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMove_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	databases := mocksstorage.NewMockDatabases(controller)
+	storage := mocksstorage.NewMockStorage(controller)
+	databases.EXPECT().Count().Return(16).AnyTimes()
+	databases.EXPECT().Move(gomock.Any(), "key", 1, 3).Return(true, nil)
+	databases.EXPECT().Move(gomock.Any(), "taken", 1, 3).Return(false, nil)
+	ctx := command.ContextWithDatabases(
+		context.WithValue(context.Background(), config.ClientConfigKey, &config.ClientConfig{DB: 1}),
+		databases,
+	)
+
+	move := &Move{Key: "key", DB: 3}
+	assert.Equal(t, protocol.NewNumberResponse(1), move.Execute(ctx, storage))
+	assert.Equal(t, []command.DBKey{{DB: 3, Key: "key"}}, move.WrittenDBKeys())
+
+	move = &Move{Key: "taken", DB: 3}
+	assert.Equal(t, protocol.NewNumberResponse(0), move.Execute(ctx, storage))
+	assert.Empty(t, move.WrittenDBKeys())
+
+	response := (&Move{Key: "key", DB: 1}).Execute(ctx, storage)
+	assert.EqualError(t, response.Err, "ERR source and destination objects are the same")
+
+	response = (&Move{Key: "key", DB: 16}).Execute(ctx, storage)
+	assert.EqualError(t, response.Err, "ERR DB index is out of range")
+}
+
+func TestMoveParser_Parse(t *testing.T) {
+	parser := NewMoveParser()
+
+	cmd, err := parser.Parse(&protocol.Message{Command: "MOVE", Args: []string{"key", "2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Move{Key: "key", DB: 2}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "MOVE", Args: []string{"key", "two"}})
+	assert.EqualError(t, err, "ERR value is not an integer or out of range")
+	_, err = parser.Parse(&protocol.Message{Command: "MOVE", Args: []string{"key"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package generic
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"fmt"
+	"strings"
+)
+
+var objectHelp = []string{
+	"OBJECT <subcommand> [<arg> [value] [opt] ...]. Subcommands are:",
+	"ENCODING <key>",
+	"    Return the kind of internal representation used in order to store the value",
+	"    associated with a <key>.",
+	"FREQ <key>",
+	"    Return the access frequency index of the <key>. The returned integer is",
+	"    proportional to the logarithm of the recent access frequency of the key.",
+	"IDLETIME <key>",
+	"    Return the idle time of the <key>, that is the approximated number of",
+	"    seconds elapsed since the last access to the key.",
+	"REFCOUNT <key>",
+	"    Return the number of references of the value associated with the specified",
+	"    <key>.",
+	"HELP",
+	"    Print this help.",
+}
+
+// Object inspects how the value of a key is stored and used. Subcommand is one of ENCODING,
+// IDLETIME, FREQ and REFCOUNT; values are never shared between keys, so REFCOUNT is always 1.
+type Object struct {
+	Subcommand string
+	Key        string
+}
+
+func (o *Object) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	object, ok, err := storage.Keyspace().Object(ctx, o.Key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if !ok {
+		return protocol.NewNullBulkStringResponse()
+	}
+	switch o.Subcommand {
+	case "ENCODING":
+		return protocol.NewBulkStringResponse([]byte(object.Encoding))
+	case "IDLETIME":
+		return protocol.NewNumberResponse(int64(object.Idle.Seconds()))
+	case "FREQ":
+		return protocol.NewNumberResponse(object.Frequency)
+	default:
+		return protocol.NewNumberResponse(1)
+	}
+}
+
+// ObjectHelp lists the OBJECT subcommands.
+type ObjectHelp struct{}
+
+func (o *ObjectHelp) Execute(_ context.Context, _ storage.Storage) *protocol.Response {
+	return protocol.NewArrayResponse(objectHelp)
+}
+
+type ObjectParser struct{}
+
+func NewObjectParser() *ObjectParser {
+	return &ObjectParser{}
+}
+
+func (p *ObjectParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) == 0 {
+		return nil, fmt.Errorf("ERR wrong number of arguments for 'object' command")
+	}
+	subcommand := strings.ToUpper(msg.Args[0])
+	switch {
+	case subcommand == "HELP" && len(msg.Args) == 1:
+		return &ObjectHelp{}, nil
+	case len(msg.Args) == 2 && (subcommand == "ENCODING" || subcommand == "IDLETIME" || subcommand == "FREQ" || subcommand == "REFCOUNT"):
+		return &Object{Subcommand: subcommand, Key: msg.Args[1]}, nil
+	}
+	return nil, command.NewUnknownSubcommandError(p.Name(), msg.Args[0])
+}
+
+func (p *ObjectParser) Name() string {
+	return "OBJECT"
+}
@@ -0,0 +1,59 @@
+package generic
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/keyspace"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestObject_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks).AnyTimes()
+	ks.EXPECT().Object(gomock.Any(), "key").
+		Return(keyspace.Object{Encoding: "listpack", Idle: 90 * time.Second, Frequency: 7}, true, nil).AnyTimes()
+	ks.EXPECT().Object(gomock.Any(), "missing").Return(keyspace.Object{}, false, nil)
+
+	response := (&Object{Subcommand: "ENCODING", Key: "key"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewBulkStringResponse([]byte("listpack")), response)
+
+	response = (&Object{Subcommand: "IDLETIME", Key: "key"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(90), response)
+
+	response = (&Object{Subcommand: "FREQ", Key: "key"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(7), response)
+
+	response = (&Object{Subcommand: "REFCOUNT", Key: "key"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(1), response)
+
+	response = (&Object{Subcommand: "ENCODING", Key: "missing"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNullBulkStringResponse(), response)
+}
+
+func TestObjectParser_Parse(t *testing.T) {
+	parser := NewObjectParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "OBJECT", Args: []string{"encoding", "key"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Object{Subcommand: "ENCODING", Key: "key"}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "OBJECT", Args: []string{"HELP"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ObjectHelp{}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "OBJECT", Args: []string{}})
+	assert.EqualError(t, err, "ERR wrong number of arguments for 'object' command")
+
+	_, err = parser.Parse(&protocol.Message{Command: "OBJECT", Args: []string{"ENCODING"}})
+	assert.EqualError(t, err, "ERR unknown subcommand or wrong number of arguments for 'ENCODING'. Try OBJECT HELP.")
+
+	_, err = parser.Parse(&protocol.Message{Command: "OBJECT", Args: []string{"size", "key"}})
+	assert.EqualError(t, err, "ERR unknown subcommand or wrong number of arguments for 'size'. Try OBJECT HELP.")
+}
@@ -0,0 +1,39 @@
+package generic
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// RandomKey returns a random key of the keyspace, or nil when it is empty
+type RandomKey struct{}
+
+func (r *RandomKey) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	key, found, err := storage.Keyspace().RandomKey(ctx)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if !found {
+		return protocol.NewNullBulkStringResponse()
+	}
+	return protocol.NewBulkStringResponse([]byte(key))
+}
+
+type RandomKeyParser struct{}
+
+func NewRandomKeyParser() *RandomKeyParser {
+	return &RandomKeyParser{}
+}
+
+func (r *RandomKeyParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 0 {
+		return nil, command.NewInvalidArgumentsCount(r.Name(), 0, len(msg.Args))
+	}
+	return &RandomKey{}, nil
+}
+
+func (r *RandomKeyParser) Name() string {
+	return "RANDOMKEY"
+}
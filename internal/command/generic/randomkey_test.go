@@ -0,0 +1,38 @@
+package generic
+
+import (
+	"avacado/internal/protocol"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRandomKey_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks).AnyTimes()
+	gomock.InOrder(
+		ks.EXPECT().RandomKey(gomock.Any()).Return("key", true, nil),
+		ks.EXPECT().RandomKey(gomock.Any()).Return("", false, nil),
+	)
+
+	response := (&RandomKey{}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewBulkStringResponse([]byte("key")), response)
+
+	response = (&RandomKey{}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNullBulkStringResponse(), response)
+}
+
+func TestRandomKeyParser_Parse(t *testing.T) {
+	parser := NewRandomKeyParser()
+	_, err := parser.Parse(&protocol.Message{Command: "RANDOMKEY", Args: []string{}})
+	assert.NoError(t, err)
+
+	_, err = parser.Parse(&protocol.Message{Command: "RANDOMKEY", Args: []string{"extra"}})
+	assert.Error(t, err)
+}
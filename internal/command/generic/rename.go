@@ -0,0 +1,66 @@
+package generic
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// Rename renames a key along with its expiry. With NX the rename only happens if the new key does not exist.
+type Rename struct {
+	Key    string
+	NewKey string
+	NX     bool
+}
+
+func (r *Rename) WrittenKeys() []string { return []string{r.NewKey} }
+
+func (r *Rename) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	renamed, err := storage.Keyspace().Rename(ctx, r.Key, r.NewKey, r.NX)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if !r.NX {
+		return protocol.NewSimpleStringResponse("OK")
+	}
+	if renamed {
+		return protocol.NewNumberResponse(1)
+	}
+	return protocol.NewNumberResponse(0)
+}
+
+func parseRename(name string, nx bool, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 2 {
+		return nil, command.NewInvalidArgumentsCount(name, 2, len(msg.Args))
+	}
+	return &Rename{Key: msg.Args[0], NewKey: msg.Args[1], NX: nx}, nil
+}
+
+type RenameParser struct{}
+
+func NewRenameParser() *RenameParser {
+	return &RenameParser{}
+}
+
+func (r *RenameParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseRename(r.Name(), false, msg)
+}
+
+func (r *RenameParser) Name() string {
+	return "RENAME"
+}
+
+type RenameNXParser struct{}
+
+func NewRenameNXParser() *RenameNXParser {
+	return &RenameNXParser{}
+}
+
+func (r *RenameNXParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseRename(r.Name(), true, msg)
+}
+
+func (r *RenameNXParser) Name() string {
+	return "RENAMENX"
+}
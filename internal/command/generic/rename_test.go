@@ -0,0 +1,56 @@
+package generic
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/keyspace"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRename_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks).AnyTimes()
+	ks.EXPECT().Rename(gomock.Any(), "a", "b", false).Return(true, nil)
+	ks.EXPECT().Rename(gomock.Any(), "missing", "b", false).Return(false, keyspace.ErrNoSuchKey)
+
+	response := (&Rename{Key: "a", NewKey: "b"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewSimpleStringResponse("OK"), response)
+
+	response = (&Rename{Key: "missing", NewKey: "b"}).Execute(context.Background(), storage)
+	assert.EqualError(t, response.Err, "ERR no such key")
+}
+
+func TestRename_ExecuteNX(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks).AnyTimes()
+	ks.EXPECT().Rename(gomock.Any(), "a", "b", true).Return(true, nil)
+	ks.EXPECT().Rename(gomock.Any(), "a", "taken", true).Return(false, nil)
+
+	response := (&Rename{Key: "a", NewKey: "b", NX: true}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(1), response)
+
+	response = (&Rename{Key: "a", NewKey: "taken", NX: true}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(0), response)
+}
+
+func TestRenameParsers_Parse(t *testing.T) {
+	cmd, err := NewRenameParser().Parse(&protocol.Message{Command: "RENAME", Args: []string{"a", "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Rename{Key: "a", NewKey: "b"}, cmd)
+
+	cmd, err = NewRenameNXParser().Parse(&protocol.Message{Command: "RENAMENX", Args: []string{"a", "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Rename{Key: "a", NewKey: "b", NX: true}, cmd)
+
+	_, err = NewRenameParser().Parse(&protocol.Message{Command: "RENAME", Args: []string{"a"}})
+	assert.Error(t, err)
+}
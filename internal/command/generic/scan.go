@@ -0,0 +1,79 @@
+package generic
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/keyspace"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// defaultScanCount is the number of keys SCAN visits per call when COUNT is not given
+const defaultScanCount = 10
+
+var errInvalidCursor = errors.New("ERR invalid cursor")
+
+// Scan incrementally iterates the keyspace, replying with the next cursor and a batch of keys
+type Scan struct {
+	Cursor  uint64
+	Options keyspace.ScanOptions
+}
+
+func (s *Scan) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	next, keys, err := storage.Keyspace().Scan(ctx, s.Cursor, s.Options)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewBulkStringProtocolValue([]byte(strconv.FormatUint(next, 10))),
+		keysValue(keys),
+	}))
+}
+
+type ScanParser struct{}
+
+func NewScanParser() *ScanParser {
+	return &ScanParser{}
+}
+
+func (s *ScanParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 {
+		return nil, command.NewInvalidArgumentsCount(s.Name(), 1, len(msg.Args))
+	}
+	cursor, err := strconv.ParseUint(msg.Args[0], 10, 64)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	options := keyspace.ScanOptions{Count: defaultScanCount}
+	for i := 1; i < len(msg.Args); i += 2 {
+		if i+1 >= len(msg.Args) {
+			return nil, command.ErrSyntax
+		}
+		value := msg.Args[i+1]
+		switch strings.ToUpper(msg.Args[i]) {
+		case "MATCH":
+			options.Match = value
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, command.ErrNotInteger
+			}
+			if count < 1 {
+				return nil, command.ErrSyntax
+			}
+			options.Count = count
+		case "TYPE":
+			options.Type = keyspace.Type(strings.ToLower(value))
+		default:
+			return nil, command.ErrSyntax
+		}
+	}
+	return &Scan{Cursor: cursor, Options: options}, nil
+}
+
+func (s *ScanParser) Name() string {
+	return "SCAN"
+}
@@ -0,0 +1,66 @@
+package generic
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/keyspace"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScan_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks)
+	options := keyspace.ScanOptions{Match: "k*", Count: 10}
+	ks.EXPECT().Scan(gomock.Any(), uint64(12), options).Return(uint64(7), []string{"k1"}, nil)
+
+	response := (&Scan{Cursor: 12, Options: options}).Execute(context.Background(), storage)
+	expected := protocol.NewSuccessResponse(protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewBulkStringProtocolValue([]byte("7")),
+		protocol.NewArrayProtocolValue([]protocol.Value{protocol.NewBulkStringProtocolValue([]byte("k1"))}),
+	}))
+	assert.Equal(t, expected, response)
+}
+
+func TestScanParser_Parse(t *testing.T) {
+	parser := NewScanParser()
+
+	cmd, err := parser.Parse(&protocol.Message{Command: "SCAN", Args: []string{"0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Scan{Cursor: 0, Options: keyspace.ScanOptions{Count: defaultScanCount}}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{
+		Command: "SCAN",
+		Args:    []string{"42", "match", "user:*", "COUNT", "100", "TYPE", "HASH"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &Scan{
+		Cursor:  42,
+		Options: keyspace.ScanOptions{Match: "user:*", Count: 100, Type: keyspace.TypeHash},
+	}, cmd)
+}
+
+func TestScanParser_ParseErrors(t *testing.T) {
+	parser := NewScanParser()
+	tests := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"-1"}, "ERR invalid cursor"},
+		{[]string{"abc"}, "ERR invalid cursor"},
+		{[]string{"0", "COUNT"}, "ERR syntax error"},
+		{[]string{"0", "COUNT", "0"}, "ERR syntax error"},
+		{[]string{"0", "COUNT", "many"}, "ERR value is not an integer or out of range"},
+		{[]string{"0", "LIMIT", "10"}, "ERR syntax error"},
+	}
+	for _, tt := range tests {
+		_, err := parser.Parse(&protocol.Message{Command: "SCAN", Args: tt.args})
+		assert.EqualError(t, err, tt.err, tt.args)
+	}
+}
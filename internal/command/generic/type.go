@@ -0,0 +1,38 @@
+package generic
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// Type returns the type of the value stored at a key, or none if the key does not exist
+type Type struct {
+	Key string
+}
+
+func (t *Type) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	keyType, err := storage.Keyspace().Type(ctx, t.Key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewSimpleStringResponse(string(keyType))
+}
+
+type TypeParser struct{}
+
+func NewTypeParser() *TypeParser {
+	return &TypeParser{}
+}
+
+func (t *TypeParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 1 {
+		return nil, command.NewInvalidArgumentsCount(t.Name(), 1, len(msg.Args))
+	}
+	return &Type{Key: msg.Args[0]}, nil
+}
+
+func (t *TypeParser) Name() string {
+	return "TYPE"
+}
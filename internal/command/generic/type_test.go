@@ -0,0 +1,38 @@
+package generic
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/keyspace"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestType_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks).AnyTimes()
+	ks.EXPECT().Type(gomock.Any(), "list").Return(keyspace.TypeList, nil)
+	ks.EXPECT().Type(gomock.Any(), "missing").Return(keyspace.TypeNone, nil)
+
+	response := (&Type{Key: "list"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewSimpleStringResponse("list"), response)
+
+	response = (&Type{Key: "missing"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewSimpleStringResponse("none"), response)
+}
+
+func TestTypeParser_Parse(t *testing.T) {
+	parser := NewTypeParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "TYPE", Args: []string{"key"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Type{Key: "key"}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "TYPE", Args: []string{}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package geo
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/geo"
+	"avacado/internal/storage/zsets"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errUnsupportedUnit = errors.New("ERR unsupported unit provided. please use M, KM, FT, MI")
+
+// parseLocation parses a longitude and a latitude, which must be in the range scores can encode.
+func parseLocation(longitude, latitude string) (float64, float64, error) {
+	lon, err := zsets.ParseScore(longitude)
+	if err != nil {
+		return 0, 0, err
+	}
+	lat, err := zsets.ParseScore(latitude)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !geo.ValidCoordinates(lon, lat) {
+		return 0, 0, fmt.Errorf("ERR invalid longitude,latitude pair %f,%f", lon, lat)
+	}
+	return lon, lat, nil
+}
+
+// parseUnit returns the number of meters in a unit of distance.
+func parseUnit(unit string) (float64, error) {
+	switch strings.ToLower(unit) {
+	case "m":
+		return 1, nil
+	case "km":
+		return 1000, nil
+	case "ft":
+		return 0.3048, nil
+	case "mi":
+		return 1609.34, nil
+	}
+	return 0, errUnsupportedUnit
+}
+
+// distanceValue replies a distance with 4 decimals, as Redis does.
+func distanceValue(distance float64) protocol.Value {
+	return protocol.NewBulkStringProtocolValue([]byte(strconv.FormatFloat(distance, 'f', 4, 64)))
+}
+
+// coordinatesValue replies a location as its longitude and latitude, each with up to 17 decimals
+// as Redis does, trailing zeros removed.
+func coordinatesValue(longitude, latitude float64) protocol.Value {
+	return protocol.NewArrayProtocolValue([]protocol.Value{coordinateValue(longitude), coordinateValue(latitude)})
+}
+
+func coordinateValue(coordinate float64) protocol.Value {
+	s := strconv.FormatFloat(coordinate, 'f', 17, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		s = "0"
+	}
+	return protocol.NewBulkStringProtocolValue([]byte(s))
+}
@@ -0,0 +1,76 @@
+package geo
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/geo"
+	"avacado/internal/storage/zsets"
+	"context"
+	"strings"
+)
+
+// GeoAdd adds members to the sorted set at a key, each scored with the geohash of its location,
+// and replies how many were added, or added and moved with CH.
+type GeoAdd struct {
+	key     string
+	members []zsets.ScoredMember
+	options zsets.AddOptions
+}
+
+func (g *GeoAdd) DenyOOM() {}
+
+func (g *GeoAdd) WrittenKeys() []string { return []string{g.key} }
+
+func (g *GeoAdd) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	n, err := storage.ZSets().ZAdd(ctx, g.key, g.members, g.options)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(n))
+}
+
+type GeoAddParser struct{}
+
+func NewGeoAddParser() *GeoAddParser {
+	return &GeoAddParser{}
+}
+
+// Parse parses GEOADD key [NX | XX] [CH] longitude latitude member [longitude latitude member ...].
+func (p *GeoAddParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 4 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 4, len(msg.Args))
+	}
+	cmd := &GeoAdd{key: msg.Args[0]}
+	args := msg.Args[1:]
+flags:
+	for len(args) > 0 {
+		switch strings.ToUpper(args[0]) {
+		case "NX":
+			cmd.options.NX = true
+		case "XX":
+			cmd.options.XX = true
+		case "CH":
+			cmd.options.CH = true
+		default:
+			break flags
+		}
+		args = args[1:]
+	}
+	if len(args) == 0 || len(args)%3 != 0 || (cmd.options.NX && cmd.options.XX) {
+		return nil, command.ErrSyntax
+	}
+	cmd.members = make([]zsets.ScoredMember, 0, len(args)/3)
+	for i := 0; i < len(args); i += 3 {
+		longitude, latitude, err := parseLocation(args[i], args[i+1])
+		if err != nil {
+			return nil, err
+		}
+		cmd.members = append(cmd.members, zsets.ScoredMember{Member: args[i+2], Score: geo.Encode(longitude, latitude)})
+	}
+	return cmd, nil
+}
+
+func (p *GeoAddParser) Name() string {
+	return "GEOADD"
+}
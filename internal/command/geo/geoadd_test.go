@@ -0,0 +1,62 @@
+package geo
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/zsets"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeoAddCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	members := []zsets.ScoredMember{{Member: "Palermo", Score: 3479099956230698}}
+	cmd := GeoAdd{key: "Sicily", members: members, options: zsets.AddOptions{CH: true}}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs)
+	zs.EXPECT().ZAdd(ctx, "Sicily", members, zsets.AddOptions{CH: true}).Return(1, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(1), response.Value.Number)
+}
+
+func TestGeoAddParser_Parse(t *testing.T) {
+	parser := NewGeoAddParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "GEOADD", Args: []string{
+		"Sicily", "xx", "CH", "13.361389", "38.115556", "Palermo", "15.087269", "37.502669", "Catania",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, &GeoAdd{
+		key: "Sicily",
+		members: []zsets.ScoredMember{
+			{Member: "Palermo", Score: 3479099956230698},
+			{Member: "Catania", Score: 3479447370796909},
+		},
+		options: zsets.AddOptions{XX: true, CH: true},
+	}, cmd)
+
+	for _, tc := range []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"Sicily", "1", "2", "a", "3"}, command.ErrSyntax.Error()},
+		{[]string{"Sicily", "NX", "XX", "1", "2", "a"}, command.ErrSyntax.Error()},
+		{[]string{"Sicily", "GT", "1", "2", "a"}, command.ErrSyntax.Error()},
+		{[]string{"Sicily", "one", "2", "a"}, zsets.ErrNotFloat.Error()},
+		{[]string{"Sicily", "200", "100", "a"}, "ERR invalid longitude,latitude pair 200.000000,100.000000"},
+		{[]string{"Sicily", "1", "-85.06", "a"}, "ERR invalid longitude,latitude pair 1.000000,-85.060000"},
+	} {
+		_, err := parser.Parse(&protocol.Message{Command: "GEOADD", Args: tc.args})
+		assert.EqualError(t, err, tc.err, tc.args)
+	}
+
+	_, err = parser.Parse(&protocol.Message{Command: "GEOADD", Args: []string{"Sicily", "1", "2"}})
+	assert.Error(t, err)
+}
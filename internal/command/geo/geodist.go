@@ -0,0 +1,59 @@
+package geo
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/geo"
+	"context"
+)
+
+// GeoDist replies the distance between two members in the unit given, meters by default, or nil
+// if either is missing.
+type GeoDist struct {
+	key              string
+	member1, member2 string
+	unit             float64
+}
+
+func (g *GeoDist) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	scores, err := storage.ZSets().ZMScore(ctx, g.key, []string{g.member1, g.member2})
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if scores[0] == nil || scores[1] == nil {
+		return protocol.NewNullBulkStringResponse()
+	}
+	lon1, lat1 := geo.Decode(*scores[0])
+	lon2, lat2 := geo.Decode(*scores[1])
+	return protocol.NewSuccessResponse(distanceValue(geo.Distance(lon1, lat1, lon2, lat2) / g.unit))
+}
+
+type GeoDistParser struct{}
+
+func NewGeoDistParser() *GeoDistParser {
+	return &GeoDistParser{}
+}
+
+// Parse parses GEODIST key member1 member2 [M | KM | FT | MI].
+func (p *GeoDistParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 3 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 3, len(msg.Args))
+	}
+	if len(msg.Args) > 4 {
+		return nil, command.ErrSyntax
+	}
+	cmd := &GeoDist{key: msg.Args[0], member1: msg.Args[1], member2: msg.Args[2], unit: 1}
+	if len(msg.Args) == 4 {
+		unit, err := parseUnit(msg.Args[3])
+		if err != nil {
+			return nil, err
+		}
+		cmd.unit = unit
+	}
+	return cmd, nil
+}
+
+func (p *GeoDistParser) Name() string {
+	return "GEODIST"
+}
@@ -0,0 +1,51 @@
+package geo
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeoDistCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs).Times(3)
+	palermo, catania := float64(3479099956230698), float64(3479447370796909)
+	zs.EXPECT().ZMScore(ctx, "Sicily", []string{"Palermo", "Catania"}).Return([]*float64{&palermo, &catania}, nil).Times(2)
+	zs.EXPECT().ZMScore(ctx, "Sicily", []string{"Palermo", "missing"}).Return([]*float64{&palermo, nil}, nil)
+
+	response := (&GeoDist{key: "Sicily", member1: "Palermo", member2: "Catania", unit: 1}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("166274.1516"), response.Value.Bytes)
+
+	response = (&GeoDist{key: "Sicily", member1: "Palermo", member2: "Catania", unit: 1609.34}).Execute(ctx, storage)
+	assert.Equal(t, []byte("103.3182"), response.Value.Bytes)
+
+	response = (&GeoDist{key: "Sicily", member1: "Palermo", member2: "missing", unit: 1}).Execute(ctx, storage)
+	assert.True(t, response.Value.Null)
+}
+
+func TestGeoDistParser_Parse(t *testing.T) {
+	parser := NewGeoDistParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "GEODIST", Args: []string{"Sicily", "Palermo", "Catania"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &GeoDist{key: "Sicily", member1: "Palermo", member2: "Catania", unit: 1}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "GEODIST", Args: []string{"Sicily", "Palermo", "Catania", "KM"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1000.0, cmd.(*GeoDist).unit)
+
+	_, err = parser.Parse(&protocol.Message{Command: "GEODIST", Args: []string{"Sicily", "Palermo", "Catania", "yd"}})
+	assert.Equal(t, errUnsupportedUnit, err)
+	_, err = parser.Parse(&protocol.Message{Command: "GEODIST", Args: []string{"Sicily", "Palermo", "Catania", "m", "km"}})
+	assert.EqualError(t, err, "ERR syntax error")
+	_, err = parser.Parse(&protocol.Message{Command: "GEODIST", Args: []string{"Sicily", "Palermo"}})
+	assert.Error(t, err)
+}
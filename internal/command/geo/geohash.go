@@ -0,0 +1,49 @@
+package geo
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/geo"
+	"context"
+)
+
+// GeoHash replies the standard geohash string of the location of each member, nil for the ones
+// missing.
+type GeoHash struct {
+	key     string
+	members []string
+}
+
+func (g *GeoHash) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	scores, err := storage.ZSets().ZMScore(ctx, g.key, g.members)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	values := make([]protocol.Value, len(scores))
+	for i, score := range scores {
+		if score == nil {
+			values[i] = protocol.NewNullBulkStringProtocolValue()
+		} else {
+			values[i] = protocol.NewBulkStringProtocolValue([]byte(geo.String(*score)))
+		}
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue(values))
+}
+
+type GeoHashParser struct{}
+
+func NewGeoHashParser() *GeoHashParser {
+	return &GeoHashParser{}
+}
+
+func (p *GeoHashParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	return &GeoHash{key: msg.Args[0], members: msg.Args[1:]}, nil
+}
+
+func (p *GeoHashParser) Name() string {
+	return "GEOHASH"
+}
@@ -0,0 +1,38 @@
+package geo
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeoHashCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := GeoHash{key: "Sicily", members: []string{"Palermo", "missing", "Catania"}}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs)
+	palermo, catania := float64(3479099956230698), float64(3479447370796909)
+	zs.EXPECT().ZMScore(ctx, "Sicily", cmd.members).Return([]*float64{&palermo, nil, &catania}, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("sqc8b49rny0"), response.Value.Array[0].Bytes)
+	assert.True(t, response.Value.Array[1].Null)
+	assert.Equal(t, []byte("sqdtr74hyu0"), response.Value.Array[2].Bytes)
+}
+
+func TestGeoHashParser_Parse(t *testing.T) {
+	parser := NewGeoHashParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "GEOHASH", Args: []string{"Sicily", "Palermo", "Catania"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &GeoHash{key: "Sicily", members: []string{"Palermo", "Catania"}}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "GEOHASH"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package geo
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/geo"
+	"context"
+)
+
+// GeoPos replies the location of each member, nil for the ones missing.
+type GeoPos struct {
+	key     string
+	members []string
+}
+
+func (g *GeoPos) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	scores, err := storage.ZSets().ZMScore(ctx, g.key, g.members)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	values := make([]protocol.Value, len(scores))
+	for i, score := range scores {
+		if score == nil {
+			values[i] = protocol.NewNullBulkStringProtocolValue()
+		} else {
+			values[i] = coordinatesValue(geo.Decode(*score))
+		}
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue(values))
+}
+
+type GeoPosParser struct{}
+
+func NewGeoPosParser() *GeoPosParser {
+	return &GeoPosParser{}
+}
+
+func (p *GeoPosParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	return &GeoPos{key: msg.Args[0], members: msg.Args[1:]}, nil
+}
+
+func (p *GeoPosParser) Name() string {
+	return "GEOPOS"
+}
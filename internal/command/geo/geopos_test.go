@@ -0,0 +1,50 @@
+package geo
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeoPosCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := GeoPos{key: "Sicily", members: []string{"Palermo", "missing"}}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs)
+	score := float64(3479099956230698)
+	zs.EXPECT().ZMScore(ctx, "Sicily", []string{"Palermo", "missing"}).Return([]*float64{&score, nil}, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Len(t, response.Value.Array, 2)
+	position := response.Value.Array[0].Array
+	assert.Equal(t, []byte("13.36138933897018433"), position[0].Bytes)
+	assert.Equal(t, []byte("38.11555639549629859"), position[1].Bytes)
+	assert.True(t, response.Value.Array[1].Null)
+}
+
+func TestGeoPosParser_Parse(t *testing.T) {
+	parser := NewGeoPosParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "GEOPOS", Args: []string{"Sicily", "Palermo"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &GeoPos{key: "Sicily", members: []string{"Palermo"}}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "GEOPOS", Args: []string{"Sicily"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &GeoPos{key: "Sicily", members: []string{}}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "GEOPOS"})
+	assert.Error(t, err)
+}
+
+func TestCoordinateValue(t *testing.T) {
+	assert.Equal(t, []byte("15"), coordinateValue(15).Bytes)
+	assert.Equal(t, []byte("-0.5"), coordinateValue(-0.5).Bytes)
+	assert.Equal(t, []byte("0"), coordinateValue(-1e-20).Bytes)
+}
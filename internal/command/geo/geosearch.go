@@ -0,0 +1,354 @@
+package geo
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/geo"
+	"avacado/internal/storage/zsets"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	errMemberNotFound   = errors.New("ERR could not decode requested zset member")
+	errRadius           = errors.New("ERR need numeric radius")
+	errNegativeRadius   = errors.New("ERR radius cannot be negative")
+	errWidth            = errors.New("ERR need numeric width")
+	errHeight           = errors.New("ERR need numeric height")
+	errNegativeBox      = errors.New("ERR height or width cannot be negative")
+	errCount            = errors.New("ERR COUNT must be > 0")
+	errAnyWithoutCount  = errors.New("ERR the ANY argument requires COUNT argument")
+	errStoreWithOptions = errors.New("ERR GEOSEARCHSTORE is not compatible with WITHDIST, WITHHASH and WITHCOORD options")
+)
+
+type order int
+
+const (
+	unsorted order = iota
+	ascending
+	descending
+)
+
+// searchQuery is a search of the members of a sorted set inside a shape, centered on a location
+// or, when fromMember is set, on the location of member.
+type searchQuery struct {
+	key                    string
+	fromMember, fromLonLat bool
+	member                 string
+	byRadius               bool
+	shape                  geo.Shape
+	// unit is the number of meters in the unit of the shape, the one distances are replied in.
+	unit float64
+	// order is the order of the results by distance, unsorted leaving them in the order found.
+	order order
+	// count limits the results to the nearest ones unless 0, or with any to the first ones found.
+	count int
+	any   bool
+}
+
+// point is a member found by a search.
+type point struct {
+	member              string
+	score               float64
+	longitude, latitude float64
+	// distance is the distance to the center in the unit of the search.
+	distance float64
+}
+
+// search returns the members inside the shape in the order asked for, scanning the areas covering
+// it the way Redis does so that the order of unsorted results matches.
+func (q *searchQuery) search(ctx context.Context, storage storage.Storage) ([]point, error) {
+	card, err := storage.ZSets().ZCard(ctx, q.key)
+	if err != nil || card == 0 {
+		return nil, err
+	}
+	shape := q.shape
+	if q.fromMember {
+		score, found, err := storage.ZSets().ZScore(ctx, q.key, q.member)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, errMemberNotFound
+		}
+		shape.Longitude, shape.Latitude = geo.Decode(score)
+	}
+
+	var points []point
+scan:
+	for _, r := range shape.SearchRanges() {
+		spec := zsets.RangeSpec{By: zsets.ByScore, Score: zsets.ScoreRange{Min: r.Min, Max: r.Max, MaxExclusive: true}, Count: -1}
+		members, err := storage.ZSets().ZRange(ctx, q.key, spec)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			longitude, latitude := geo.Decode(member.Score)
+			distance, ok := shape.Contains(longitude, latitude)
+			if !ok {
+				continue
+			}
+			points = append(points, point{member.Member, member.Score, longitude, latitude, distance / q.unit})
+			if q.any && len(points) >= q.count {
+				break scan
+			}
+		}
+	}
+
+	switch q.order {
+	case ascending:
+		sort.SliceStable(points, func(i, j int) bool { return points[i].distance < points[j].distance })
+	case descending:
+		sort.SliceStable(points, func(i, j int) bool { return points[i].distance > points[j].distance })
+	}
+	if q.count > 0 && len(points) > q.count {
+		points = points[:q.count]
+	}
+	return points, nil
+}
+
+// GeoSearch replies the members inside a shape, each along with its distance to the center,
+// its score and its location as asked.
+type GeoSearch struct {
+	query                         searchQuery
+	withDist, withHash, withCoord bool
+}
+
+func (g *GeoSearch) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	points, err := g.query.search(ctx, storage)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	values := make([]protocol.Value, len(points))
+	for i, p := range points {
+		member := protocol.NewBulkStringProtocolValue([]byte(p.member))
+		if !g.withDist && !g.withHash && !g.withCoord {
+			values[i] = member
+			continue
+		}
+		item := []protocol.Value{member}
+		if g.withDist {
+			item = append(item, distanceValue(p.distance))
+		}
+		if g.withHash {
+			item = append(item, protocol.NewNumberProtocolValue(int64(p.score)))
+		}
+		if g.withCoord {
+			item = append(item, coordinatesValue(p.longitude, p.latitude))
+		}
+		values[i] = protocol.NewArrayProtocolValue(item)
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue(values))
+}
+
+// GeoSearchStore replaces destination with the members inside a shape, scored by their geohash
+// or with storeDist by their distance to the center, or removes it if there are none, and
+// replies how many were stored.
+type GeoSearchStore struct {
+	destination string
+	query       searchQuery
+	storeDist   bool
+}
+
+func (g *GeoSearchStore) DenyOOM() {}
+
+func (g *GeoSearchStore) WrittenKeys() []string { return []string{g.destination} }
+
+func (g *GeoSearchStore) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	points, err := g.query.search(ctx, storage)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if _, err := storage.Keyspace().Del(ctx, g.destination); err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if len(points) == 0 {
+		return protocol.NewNumberResponse(0)
+	}
+	members := make([]zsets.ScoredMember, len(points))
+	for i, p := range points {
+		members[i] = zsets.ScoredMember{Member: p.member, Score: p.score}
+		if g.storeDist {
+			members[i].Score = p.distance
+		}
+	}
+	if _, err := storage.ZSets().ZAdd(ctx, g.destination, members, zsets.AddOptions{}); err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(len(points)))
+}
+
+type GeoSearchParser struct{}
+
+func NewGeoSearchParser() *GeoSearchParser {
+	return &GeoSearchParser{}
+}
+
+// Parse parses GEOSEARCH key FROMMEMBER member | FROMLONLAT longitude latitude
+// BYRADIUS radius M | KM | FT | MI | BYBOX width height M | KM | FT | MI [ASC | DESC]
+// [COUNT count [ANY]] [WITHCOORD] [WITHDIST] [WITHHASH].
+func (p *GeoSearchParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	cmd := &GeoSearch{query: searchQuery{key: msg.Args[0]}}
+	args := msg.Args[1:]
+	for i := 0; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "WITHDIST":
+			cmd.withDist = true
+		case "WITHHASH":
+			cmd.withHash = true
+		case "WITHCOORD":
+			cmd.withCoord = true
+		default:
+			n, err := parseSearchArg(&cmd.query, args[i:])
+			if err != nil {
+				return nil, err
+			}
+			i += n
+		}
+	}
+	if err := checkSearchQuery(&cmd.query, msg.Command); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func (p *GeoSearchParser) Name() string {
+	return "GEOSEARCH"
+}
+
+type GeoSearchStoreParser struct{}
+
+func NewGeoSearchStoreParser() *GeoSearchStoreParser {
+	return &GeoSearchStoreParser{}
+}
+
+// Parse parses GEOSEARCHSTORE destination source, followed by the arguments of GEOSEARCH but
+// WITHCOORD, WITHDIST and WITHHASH, and [STOREDIST].
+func (p *GeoSearchStoreParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	cmd := &GeoSearchStore{destination: msg.Args[0], query: searchQuery{key: msg.Args[1]}}
+	withOptions := false
+	args := msg.Args[2:]
+	for i := 0; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "WITHDIST", "WITHHASH", "WITHCOORD":
+			withOptions = true
+		case "STOREDIST":
+			cmd.storeDist = true
+		default:
+			n, err := parseSearchArg(&cmd.query, args[i:])
+			if err != nil {
+				return nil, err
+			}
+			i += n
+		}
+	}
+	if withOptions {
+		return nil, errStoreWithOptions
+	}
+	if err := checkSearchQuery(&cmd.query, msg.Command); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func (p *GeoSearchStoreParser) Name() string {
+	return "GEOSEARCHSTORE"
+}
+
+// parseSearchArg parses the search argument args starts with into q, and returns the number of
+// values following it that it took.
+func parseSearchArg(q *searchQuery, args []string) (int, error) {
+	switch arg := strings.ToUpper(args[0]); {
+	case arg == "ANY":
+		q.any = true
+		return 0, nil
+	case arg == "ASC":
+		q.order = ascending
+		return 0, nil
+	case arg == "DESC":
+		q.order = descending
+		return 0, nil
+	case arg == "COUNT" && len(args) > 1:
+		count, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return 0, command.ErrNotInteger
+		}
+		if count <= 0 {
+			return 0, errCount
+		}
+		q.count = int(count)
+		return 1, nil
+	case arg == "FROMMEMBER" && len(args) > 1 && !q.fromLonLat:
+		q.fromMember, q.member = true, args[1]
+		return 1, nil
+	case arg == "FROMLONLAT" && len(args) > 2 && !q.fromMember:
+		longitude, latitude, err := parseLocation(args[1], args[2])
+		if err != nil {
+			return 0, err
+		}
+		q.fromLonLat, q.shape.Longitude, q.shape.Latitude = true, longitude, latitude
+		return 2, nil
+	case arg == "BYRADIUS" && len(args) > 2 && !q.shape.Box:
+		radius, err := zsets.ParseScore(args[1])
+		if err != nil {
+			return 0, errRadius
+		}
+		if radius < 0 {
+			return 0, errNegativeRadius
+		}
+		unit, err := parseUnit(args[2])
+		if err != nil {
+			return 0, err
+		}
+		q.byRadius, q.shape.Radius, q.unit = true, radius*unit, unit
+		return 2, nil
+	case arg == "BYBOX" && len(args) > 3 && !q.byRadius:
+		width, err := zsets.ParseScore(args[1])
+		if err != nil {
+			return 0, errWidth
+		}
+		height, err := zsets.ParseScore(args[2])
+		if err != nil {
+			return 0, errHeight
+		}
+		if width < 0 || height < 0 {
+			return 0, errNegativeBox
+		}
+		unit, err := parseUnit(args[3])
+		if err != nil {
+			return 0, err
+		}
+		q.shape.Box, q.shape.Width, q.shape.Height, q.unit = true, width*unit, height*unit, unit
+		return 3, nil
+	}
+	return 0, command.ErrSyntax
+}
+
+// checkSearchQuery checks that q has a center and a shape, and orders the results by ascending
+// distance when limited to the nearest ones without an order.
+func checkSearchQuery(q *searchQuery, name string) error {
+	if !q.fromMember && !q.fromLonLat {
+		return fmt.Errorf("ERR exactly one of FROMMEMBER or FROMLONLAT can be specified for %s", name)
+	}
+	if !q.byRadius && !q.shape.Box {
+		return fmt.Errorf("ERR exactly one of BYRADIUS and BYBOX can be specified for %s", name)
+	}
+	if q.any && q.count == 0 {
+		return errAnyWithoutCount
+	}
+	if q.count > 0 && q.order == unsorted && !q.any {
+		q.order = ascending
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package geo
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage/geo"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/zsets"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// sicily returns a ZSets mock holding the locations of the examples of the Redis documentation.
+func sicily(controller *gomock.Controller) *mockzsets.MockZSets {
+	members := []zsets.ScoredMember{
+		{Member: "Palermo", Score: geo.Encode(13.361389, 38.115556)},
+		{Member: "Catania", Score: geo.Encode(15.087269, 37.502669)},
+		{Member: "edge1", Score: geo.Encode(12.758489, 38.788135)},
+		{Member: "edge2", Score: geo.Encode(17.241510, 38.788135)},
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Score < members[j].Score })
+	zs := mockzsets.NewMockZSets(controller)
+	zs.EXPECT().ZCard(gomock.Any(), "Sicily").Return(len(members), nil).AnyTimes()
+	zs.EXPECT().ZScore(gomock.Any(), "Sicily", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, member string) (float64, bool, error) {
+			for _, m := range members {
+				if m.Member == member {
+					return m.Score, true, nil
+				}
+			}
+			return 0, false, nil
+		}).AnyTimes()
+	zs.EXPECT().ZRange(gomock.Any(), "Sicily", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, spec zsets.RangeSpec) ([]zsets.ScoredMember, error) {
+			var selected []zsets.ScoredMember
+			for _, m := range members {
+				if spec.Score.AboveMin(m.Score) && spec.Score.BelowMax(m.Score) {
+					selected = append(selected, m)
+				}
+			}
+			return selected, nil
+		}).AnyTimes()
+	zs.EXPECT().ZCard(gomock.Any(), "missing").Return(0, nil).AnyTimes()
+	return zs
+}
+
+func members(response *protocol.Response) []string {
+	var names []string
+	for _, value := range response.Value.Array {
+		if value.Type == protocol.TypeArray {
+			value = value.Array[0]
+		}
+		names = append(names, string(value.Bytes))
+	}
+	return names
+}
+
+func TestGeoSearchCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	storage.EXPECT().ZSets().Return(sicily(controller)).AnyTimes()
+	search := func(args ...string) *protocol.Response {
+		cmd, err := NewGeoSearchParser().Parse(&protocol.Message{Command: "GEOSEARCH", Args: append([]string{"Sicily"}, args...)})
+		assert.NoError(t, err)
+		return cmd.Execute(ctx, storage)
+	}
+
+	response := search("FROMLONLAT", "15", "37", "BYRADIUS", "200", "km", "ASC")
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []string{"Catania", "Palermo"}, members(response))
+	assert.Equal(t, []string{"Palermo", "Catania"}, members(search("FROMLONLAT", "15", "37", "BYRADIUS", "200", "km")))
+	assert.Equal(t, []string{"Palermo", "Catania"}, members(search("FROMLONLAT", "15", "37", "BYRADIUS", "200", "km", "DESC")))
+	assert.Equal(t, []string{"Catania"}, members(search("FROMLONLAT", "15", "37", "BYRADIUS", "200", "km", "COUNT", "1")))
+	assert.Equal(t, []string{"Palermo"}, members(search("FROMLONLAT", "15", "37", "BYRADIUS", "200", "km", "COUNT", "1", "ANY")))
+	assert.Equal(t, []string{"Palermo", "edge1"}, members(search("FROMMEMBER", "Palermo", "BYRADIUS", "100", "km", "ASC")))
+
+	response = search("FROMLONLAT", "15", "37", "BYBOX", "400", "400", "km", "ASC", "WITHCOORD", "WITHDIST", "WITHHASH")
+	assert.Equal(t, []string{"Catania", "Palermo", "edge2", "edge1"}, members(response))
+	catania := response.Value.Array[0].Array
+	assert.Len(t, catania, 4)
+	assert.Equal(t, []byte("56.4413"), catania[1].Bytes)
+	assert.Equal(t, int64(3479447370796909), catania[2].Number)
+	assert.Equal(t, []byte("15.08726745843887329"), catania[3].Array[0].Bytes)
+	assert.Equal(t, []byte("37.50266842333162032"), catania[3].Array[1].Bytes)
+	edge1 := response.Value.Array[3].Array
+	assert.Equal(t, []byte("279.7405"), edge1[1].Bytes)
+	assert.Equal(t, []byte("12.7584877610206604"), edge1[3].Array[0].Bytes)
+
+	response = search("FROMLONLAT", "15", "37", "BYRADIUS", "200", "km", "ASC", "WITHDIST")
+	assert.Equal(t, []byte("190.4424"), response.Value.Array[1].Array[1].Bytes)
+
+	assert.Empty(t, search("FROMLONLAT", "0", "0", "BYRADIUS", "200", "km").Value.Array)
+	assert.Equal(t, errMemberNotFound, search("FROMMEMBER", "Rome", "BYRADIUS", "200", "km").Err)
+
+	cmd := &GeoSearch{query: searchQuery{key: "missing", fromMember: true, member: "Rome", byRadius: true, unit: 1}}
+	response = cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Empty(t, response.Value.Array)
+}
+
+func TestGeoSearchStoreCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := sicily(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().ZSets().Return(zs).AnyTimes()
+	storage.EXPECT().Keyspace().Return(ks).AnyTimes()
+	ks.EXPECT().Del(ctx, "destination").Return(int64(1), nil).Times(3)
+	zs.EXPECT().ZAdd(ctx, "destination", []zsets.ScoredMember{
+		{Member: "Catania", Score: geo.Encode(15.087269, 37.502669)},
+		{Member: "Palermo", Score: geo.Encode(13.361389, 38.115556)},
+	}, zsets.AddOptions{}).Return(2, nil)
+	zs.EXPECT().ZAdd(ctx, "destination", gomock.Any(), zsets.AddOptions{}).DoAndReturn(
+		func(_ context.Context, _ string, members []zsets.ScoredMember, _ zsets.AddOptions) (int, error) {
+			assert.Len(t, members, 1)
+			assert.Equal(t, "Catania", members[0].Member)
+			assert.InDelta(t, 56.4413, members[0].Score, 0.0001)
+			return 1, nil
+		})
+	store := func(args ...string) *protocol.Response {
+		cmd, err := NewGeoSearchStoreParser().Parse(&protocol.Message{Command: "GEOSEARCHSTORE", Args: append([]string{"destination"}, args...)})
+		assert.NoError(t, err)
+		return cmd.Execute(ctx, storage)
+	}
+
+	assert.Equal(t, int64(2), store("Sicily", "FROMLONLAT", "15", "37", "BYRADIUS", "200", "km", "ASC").Value.Number)
+	assert.Equal(t, int64(1), store("Sicily", "FROMLONLAT", "15", "37", "BYRADIUS", "200", "km", "COUNT", "1", "STOREDIST").Value.Number)
+	// An empty result removes destination.
+	assert.Equal(t, int64(0), store("missing", "FROMLONLAT", "15", "37", "BYRADIUS", "200", "km").Value.Number)
+}
+
+func TestGeoSearchParser_Parse(t *testing.T) {
+	parser := NewGeoSearchParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "GEOSEARCH", Args: []string{
+		"Sicily", "frommember", "Palermo", "bybox", "4", "2", "km", "count", "3", "any", "withhash",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, &GeoSearch{
+		query: searchQuery{
+			key: "Sicily", fromMember: true, member: "Palermo",
+			shape: geo.Shape{Box: true, Width: 4000, Height: 2000}, unit: 1000, count: 3, any: true,
+		},
+		withHash: true,
+	}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "GEOSEARCH", Args: []string{
+		"Sicily", "FROMLONLAT", "15", "37", "BYRADIUS", "10", "mi", "COUNT", "2",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, searchQuery{
+		key: "Sicily", fromLonLat: true, byRadius: true,
+		shape: geo.Shape{Longitude: 15, Latitude: 37, Radius: 16093.4}, unit: 1609.34, count: 2, order: ascending,
+	}, cmd.(*GeoSearch).query)
+
+	for _, tc := range []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"BYRADIUS", "1", "m"}, "ERR exactly one of FROMMEMBER or FROMLONLAT can be specified for geosearch"},
+		{[]string{"FROMMEMBER", "a"}, "ERR exactly one of BYRADIUS and BYBOX can be specified for geosearch"},
+		{[]string{"FROMMEMBER", "a", "FROMLONLAT", "1", "2", "BYRADIUS", "1", "m"}, command.ErrSyntax.Error()},
+		{[]string{"FROMMEMBER", "a", "BYRADIUS", "1", "m", "BYBOX", "1", "1", "m"}, command.ErrSyntax.Error()},
+		{[]string{"FROMMEMBER", "a", "BYRADIUS", "1"}, command.ErrSyntax.Error()},
+		{[]string{"FROMMEMBER", "a", "BYRADIUS", "1", "m", "STOREDIST"}, command.ErrSyntax.Error()},
+		{[]string{"FROMLONLAT", "1", "91", "BYRADIUS", "1", "m"}, "ERR invalid longitude,latitude pair 1.000000,91.000000"},
+		{[]string{"FROMMEMBER", "a", "BYRADIUS", "x", "m"}, "ERR need numeric radius"},
+		{[]string{"FROMMEMBER", "a", "BYRADIUS", "-1", "m"}, "ERR radius cannot be negative"},
+		{[]string{"FROMMEMBER", "a", "BYRADIUS", "1", "yd"}, errUnsupportedUnit.Error()},
+		{[]string{"FROMMEMBER", "a", "BYBOX", "1", "x", "m"}, "ERR need numeric height"},
+		{[]string{"FROMMEMBER", "a", "BYBOX", "-1", "1", "m"}, "ERR height or width cannot be negative"},
+		{[]string{"FROMMEMBER", "a", "BYRADIUS", "1", "m", "COUNT", "0"}, "ERR COUNT must be > 0"},
+		{[]string{"FROMMEMBER", "a", "BYRADIUS", "1", "m", "COUNT", "x"}, command.ErrNotInteger.Error()},
+		{[]string{"FROMMEMBER", "a", "BYRADIUS", "1", "m", "ANY"}, "ERR the ANY argument requires COUNT argument"},
+	} {
+		_, err := parser.Parse(&protocol.Message{Command: "geosearch", Args: append([]string{"Sicily"}, tc.args...)})
+		assert.EqualError(t, err, tc.err, tc.args)
+	}
+}
+
+func TestGeoSearchStoreParser_Parse(t *testing.T) {
+	parser := NewGeoSearchStoreParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "GEOSEARCHSTORE", Args: []string{
+		"destination", "Sicily", "FROMMEMBER", "Palermo", "BYRADIUS", "5", "m", "DESC", "STOREDIST",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, &GeoSearchStore{
+		destination: "destination",
+		query: searchQuery{
+			key: "Sicily", fromMember: true, member: "Palermo", byRadius: true,
+			shape: geo.Shape{Radius: 5}, unit: 1, order: descending,
+		},
+		storeDist: true,
+	}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "GEOSEARCHSTORE", Args: []string{
+		"destination", "Sicily", "FROMMEMBER", "Palermo", "BYRADIUS", "5", "m", "WITHDIST",
+	}})
+	assert.Equal(t, errStoreWithOptions, err)
+	_, err = parser.Parse(&protocol.Message{Command: "GEOSEARCHSTORE", Args: []string{"destination"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package hashmap
+
+import (
+	"avacado/internal/command"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxFieldExpiry is the latest Unix time in milliseconds a field can expire at, the one Redis allows.
+const maxFieldExpiry = 1<<48 - 1
+
+var (
+	errFieldsMissing     = errors.New("ERR Mandatory argument FIELDS is missing or not at the right position")
+	errNumFields         = errors.New("ERR Parameter `numFields` should be greater than 0")
+	errNumFieldsMismatch = errors.New("ERR The `numfields` parameter must match the number of arguments")
+	errNegativeExpiry    = errors.New("ERR invalid expire time, must be >= 0")
+)
+
+// parseFields parses FIELDS numfields followed by the fields, which must end the arguments.
+// Each field comes with valuesPerField values, itself included, and the values are returned.
+func parseFields(args []string, valuesPerField int) ([]string, error) {
+	if len(args) < 2 || !strings.EqualFold(args[0], "FIELDS") {
+		return nil, errFieldsMissing
+	}
+	numFields, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || numFields <= 0 {
+		return nil, errNumFields
+	}
+	values := args[2:]
+	if len(values)%valuesPerField != 0 || numFields != int64(len(values)/valuesPerField) {
+		return nil, errNumFieldsMismatch
+	}
+	return values, nil
+}
+
+// expiryTime is an expiry given to fields, either relative to now or as an absolute Unix time.
+type expiryTime struct {
+	amount   int64
+	unit     time.Duration
+	absolute bool
+}
+
+// parseExpiryOption parses the value of an EX, PX, EXAT or PXAT option.
+func parseExpiryOption(option, value string) (*expiryTime, error) {
+	amount, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, command.ErrNotInteger
+	}
+	expiry := &expiryTime{amount: amount, unit: time.Second, absolute: option == "EXAT" || option == "PXAT"}
+	if option == "PX" || option == "PXAT" {
+		expiry.unit = time.Millisecond
+	}
+	return expiry, nil
+}
+
+// at converts the expiry to an absolute time. Like Redis, it fails for a negative amount or a time
+// past maxFieldExpiry, naming the command in the error.
+func (e *expiryTime) at(name string) (time.Time, error) {
+	if e.amount < 0 {
+		return time.Time{}, errNegativeExpiry
+	}
+	invalid := fmt.Errorf("ERR invalid expire time in '%s' command", strings.ToLower(name))
+	factor := int64(e.unit / time.Millisecond)
+	if e.amount > math.MaxInt64/factor || e.amount*factor > maxFieldExpiry {
+		return time.Time{}, invalid
+	}
+	millis := e.amount * factor
+	if !e.absolute {
+		now := time.Now().UnixMilli()
+		if millis > maxFieldExpiry-now {
+			return time.Time{}, invalid
+		}
+		millis += now
+	}
+	return time.UnixMilli(millis), nil
+}
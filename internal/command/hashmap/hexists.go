@@ -13,7 +13,10 @@ type HExists struct {
 }
 
 func (h *HExists) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
-	result := storage.Maps().HExists(ctx, h.key, h.field)
+	result, err := storage.Maps().HExists(ctx, h.key, h.field)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
 	return protocol.NewNumberResponse(int64(result))
 }
 
@@ -18,7 +18,7 @@ func TestHExistsCommand_ExecuteFieldExists(t *testing.T) {
 	storage := mocksstorage.NewMockStorage(controller)
 	maps := mockhashmaps.NewMockHashMaps(controller)
 	storage.EXPECT().Maps().Return(maps)
-	maps.EXPECT().HExists(ctx, "myhash", "field1").Return(1)
+	maps.EXPECT().HExists(ctx, "myhash", "field1").Return(1, nil)
 	response := cmd.Execute(ctx, storage)
 	assert.Nil(t, response.Err)
 	assert.Equal(t, int64(1), response.Value.Number)
@@ -31,7 +31,7 @@ func TestHExistsCommand_ExecuteFieldNotExists(t *testing.T) {
 	storage := mocksstorage.NewMockStorage(controller)
 	maps := mockhashmaps.NewMockHashMaps(controller)
 	storage.EXPECT().Maps().Return(maps)
-	maps.EXPECT().HExists(ctx, "myhash", "missing").Return(0)
+	maps.EXPECT().HExists(ctx, "myhash", "missing").Return(0, nil)
 	response := cmd.Execute(ctx, storage)
 	assert.Nil(t, response.Err)
 	assert.Equal(t, int64(0), response.Value.Number)
@@ -44,7 +44,7 @@ func TestHExistsCommand_ExecuteKeyNotExists(t *testing.T) {
 	storage := mocksstorage.NewMockStorage(controller)
 	maps := mockhashmaps.NewMockHashMaps(controller)
 	storage.EXPECT().Maps().Return(maps)
-	maps.EXPECT().HExists(ctx, "nonexistent", "field1").Return(0)
+	maps.EXPECT().HExists(ctx, "nonexistent", "field1").Return(0, nil)
 	response := cmd.Execute(ctx, storage)
 	assert.Nil(t, response.Err)
 	assert.Equal(t, int64(0), response.Value.Number)
@@ -0,0 +1,121 @@
+package hashmap
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/keyspace"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HExpire sets the expiry of fields of a hash, either relative to now (HEXPIRE, HPEXPIRE) or as an
+// absolute Unix time (HEXPIREAT, HPEXPIREAT). It replies for each field -2 if it does not exist,
+// 0 if the condition prevented the change, 1 if the expiry was set and 2 if the field was deleted
+// because the time is not in the future.
+type HExpire struct {
+	name      string
+	key       string
+	expiry    expiryTime
+	condition keyspace.ExpireCondition
+	fields    []string
+}
+
+func (h *HExpire) DenyOOM() {}
+
+func (h *HExpire) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	at, err := h.expiry.at(h.name)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	result, err := storage.Maps().HExpire(ctx, h.key, at, h.condition, h.fields)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewArrayResponse(result)
+}
+
+// parseHExpire parses key time [NX | XX | GT | LT] FIELDS numfields field [field ...].
+func parseHExpire(name string, unit time.Duration, absolute bool, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 5 {
+		return nil, command.NewInvalidArgumentsCount(name, 5, len(msg.Args))
+	}
+	amount, err := strconv.ParseInt(msg.Args[1], 10, 64)
+	if err != nil {
+		return nil, command.ErrNotInteger
+	}
+	cmd := &HExpire{name: name, key: msg.Args[0], expiry: expiryTime{amount: amount, unit: unit, absolute: absolute}}
+	args := msg.Args[2:]
+	switch strings.ToUpper(args[0]) {
+	case "NX":
+		cmd.condition, args = keyspace.ExpireNX, args[1:]
+	case "XX":
+		cmd.condition, args = keyspace.ExpireXX, args[1:]
+	case "GT":
+		cmd.condition, args = keyspace.ExpireGT, args[1:]
+	case "LT":
+		cmd.condition, args = keyspace.ExpireLT, args[1:]
+	}
+	if cmd.fields, err = parseFields(args, 1); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+type HExpireParser struct{}
+
+func NewHExpireParser() *HExpireParser {
+	return &HExpireParser{}
+}
+
+func (p *HExpireParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseHExpire(p.Name(), time.Second, false, msg)
+}
+
+func (p *HExpireParser) Name() string {
+	return "HEXPIRE"
+}
+
+type HPExpireParser struct{}
+
+func NewHPExpireParser() *HPExpireParser {
+	return &HPExpireParser{}
+}
+
+func (p *HPExpireParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseHExpire(p.Name(), time.Millisecond, false, msg)
+}
+
+func (p *HPExpireParser) Name() string {
+	return "HPEXPIRE"
+}
+
+type HExpireAtParser struct{}
+
+func NewHExpireAtParser() *HExpireAtParser {
+	return &HExpireAtParser{}
+}
+
+func (p *HExpireAtParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseHExpire(p.Name(), time.Second, true, msg)
+}
+
+func (p *HExpireAtParser) Name() string {
+	return "HEXPIREAT"
+}
+
+type HPExpireAtParser struct{}
+
+func NewHPExpireAtParser() *HPExpireAtParser {
+	return &HPExpireAtParser{}
+}
+
+func (p *HPExpireAtParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseHExpire(p.Name(), time.Millisecond, true, msg)
+}
+
+func (p *HPExpireAtParser) Name() string {
+	return "HPEXPIREAT"
+}
@@ -0,0 +1,101 @@
+package hashmap
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mockhashmaps "avacado/internal/storage/hashmaps/mock"
+	"avacado/internal/storage/keyspace"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHExpire_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	store := mocksstorage.NewMockStorage(controller)
+	maps := mockhashmaps.NewMockHashMaps(controller)
+	store.EXPECT().Maps().Return(maps)
+	at := time.UnixMilli(1_700_000_000_000)
+	maps.EXPECT().HExpire(ctx, "myhash", at, keyspace.ExpireGT, []string{"f1", "f2"}).Return([]int64{1, -2}, nil)
+
+	cmd := &HExpire{name: "HPEXPIREAT", key: "myhash", expiry: expiryTime{amount: 1_700_000_000_000, unit: time.Millisecond, absolute: true}, condition: keyspace.ExpireGT, fields: []string{"f1", "f2"}}
+	response := cmd.Execute(ctx, store)
+
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(1), response.Value.Array[0].Number)
+	assert.Equal(t, int64(-2), response.Value.Array[1].Number)
+}
+
+func TestHExpire_ExecuteRejectsInvalidTimes(t *testing.T) {
+	controller := gomock.NewController(t)
+	store := mocksstorage.NewMockStorage(controller)
+
+	cmd := &HExpire{name: "HEXPIRE", key: "myhash", expiry: expiryTime{amount: -1, unit: time.Second}, fields: []string{"f"}}
+	assert.EqualError(t, cmd.Execute(context.Background(), store).Err, "ERR invalid expire time, must be >= 0")
+
+	cmd = &HExpire{name: "HEXPIRE", key: "myhash", expiry: expiryTime{amount: maxFieldExpiry / 1000, unit: time.Second}, fields: []string{"f"}}
+	assert.EqualError(t, cmd.Execute(context.Background(), store).Err, "ERR invalid expire time in 'hexpire' command")
+
+	cmd = &HExpire{name: "HPEXPIREAT", key: "myhash", expiry: expiryTime{amount: maxFieldExpiry + 1, unit: time.Millisecond, absolute: true}, fields: []string{"f"}}
+	assert.EqualError(t, cmd.Execute(context.Background(), store).Err, "ERR invalid expire time in 'hpexpireat' command")
+}
+
+func TestExpiryTime_At(t *testing.T) {
+	before := time.Now()
+	at, err := (&expiryTime{amount: 10, unit: time.Second}).at("HEXPIRE")
+	assert.NoError(t, err)
+	assert.WithinDuration(t, before.Add(10*time.Second), at, time.Second)
+
+	at, err = (&expiryTime{amount: 1_700_000_000, unit: time.Second, absolute: true}).at("HEXPIREAT")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1_700_000_000_000), at.UnixMilli())
+}
+
+func TestHExpireParser_Parse(t *testing.T) {
+	cmd, err := NewHExpireParser().Parse(&protocol.Message{Command: "HEXPIRE", Args: []string{"myhash", "10", "xx", "FIELDS", "2", "f1", "f2"}})
+	assert.NoError(t, err)
+	hexpire := cmd.(*HExpire)
+	assert.Equal(t, "myhash", hexpire.key)
+	assert.Equal(t, expiryTime{amount: 10, unit: time.Second}, hexpire.expiry)
+	assert.Equal(t, keyspace.ExpireXX, hexpire.condition)
+	assert.Equal(t, []string{"f1", "f2"}, hexpire.fields)
+
+	cmd, err = NewHPExpireAtParser().Parse(&protocol.Message{Command: "HPEXPIREAT", Args: []string{"myhash", "1000", "fields", "1", "f1"}})
+	assert.NoError(t, err)
+	hexpire = cmd.(*HExpire)
+	assert.Equal(t, expiryTime{amount: 1000, unit: time.Millisecond, absolute: true}, hexpire.expiry)
+	assert.Equal(t, keyspace.ExpireAlways, hexpire.condition)
+	assert.Equal(t, []string{"f1"}, hexpire.fields)
+}
+
+func TestHExpireParser_ParseErrors(t *testing.T) {
+	parser := NewHExpireParser()
+	tests := []struct {
+		args []string
+		err  error
+	}{
+		{[]string{"myhash", "10", "FIELDS", "1"}, command.NewInvalidArgumentsCount("HEXPIRE", 5, 4)},
+		{[]string{"myhash", "ten", "FIELDS", "1", "f"}, command.ErrNotInteger},
+		{[]string{"myhash", "10", "NX", "XX", "FIELDS", "1", "f"}, errFieldsMissing},
+		{[]string{"myhash", "10", "FIELD", "1", "f"}, errFieldsMissing},
+		{[]string{"myhash", "10", "FIELDS", "0", "f"}, errNumFields},
+		{[]string{"myhash", "10", "FIELDS", "one", "f"}, errNumFields},
+		{[]string{"myhash", "10", "FIELDS", "2", "f"}, errNumFieldsMismatch},
+	}
+	for _, test := range tests {
+		_, err := parser.Parse(&protocol.Message{Command: "HEXPIRE", Args: test.args})
+		assert.Equal(t, test.err, err, "%v", test.args)
+	}
+}
+
+func TestHExpireParsers_Name(t *testing.T) {
+	assert.Equal(t, "HEXPIRE", NewHExpireParser().Name())
+	assert.Equal(t, "HPEXPIRE", NewHPExpireParser().Name())
+	assert.Equal(t, "HEXPIREAT", NewHExpireAtParser().Name())
+	assert.Equal(t, "HPEXPIREAT", NewHPExpireAtParser().Name())
+}
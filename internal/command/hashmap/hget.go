@@ -4,7 +4,9 @@ import (
 	"avacado/internal/command"
 	"avacado/internal/protocol"
 	"avacado/internal/storage"
+	"avacado/internal/storage/keyspace"
 	"context"
+	"errors"
 )
 
 type hGet struct {
@@ -14,6 +16,9 @@ type hGet struct {
 
 func (h *hGet) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	value, err := storage.Maps().HGet(ctx, h.name, h.field)
+	if errors.Is(err, keyspace.ErrWrongType) {
+		return protocol.NewErrorResponse(err)
+	}
 	if err != nil {
 		return protocol.NewNullBulkStringResponse()
 	}
@@ -0,0 +1,44 @@
+package hashmap
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// HGetDel replies the values of fields of a hash, nil for the missing ones, and deletes the fields.
+type HGetDel struct {
+	key    string
+	fields []string
+}
+
+func (h *HGetDel) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	values, err := storage.Maps().HGetDel(ctx, h.key, h.fields)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewArrayResponse(values)
+}
+
+type HGetDelParser struct{}
+
+func NewHGetDelParser() *HGetDelParser {
+	return &HGetDelParser{}
+}
+
+// Parse parses HGETDEL key FIELDS numfields field [field ...].
+func (p *HGetDelParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 4 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 4, len(msg.Args))
+	}
+	fields, err := parseFields(msg.Args[1:], 1)
+	if err != nil {
+		return nil, err
+	}
+	return &HGetDel{key: msg.Args[0], fields: fields}, nil
+}
+
+func (p *HGetDelParser) Name() string {
+	return "HGETDEL"
+}
@@ -0,0 +1,38 @@
+package hashmap
+
+import (
+	"avacado/internal/protocol"
+	mockhashmaps "avacado/internal/storage/hashmaps/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHGetDel_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	store := mocksstorage.NewMockStorage(controller)
+	maps := mockhashmaps.NewMockHashMaps(controller)
+	store.EXPECT().Maps().Return(maps)
+	maps.EXPECT().HGetDel(ctx, "myhash", []string{"f1", "missing"}).Return([]any{[]byte("v1"), nil}, nil)
+
+	response := (&HGetDel{key: "myhash", fields: []string{"f1", "missing"}}).Execute(ctx, store)
+
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("v1"), response.Value.Array[0].Bytes)
+	assert.True(t, response.Value.Array[1].Null)
+}
+
+func TestHGetDelParser_Parse(t *testing.T) {
+	parser := NewHGetDelParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "HGETDEL", Args: []string{"myhash", "FIELDS", "2", "f1", "f2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &HGetDel{key: "myhash", fields: []string{"f1", "f2"}}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "HGETDEL", Args: []string{"myhash", "FIELDS", "-1", "f1"}})
+	assert.Equal(t, errNumFields, err)
+	assert.Equal(t, "HGETDEL", parser.Name())
+}
@@ -0,0 +1,83 @@
+package hashmap
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/hashmaps"
+	"context"
+	"strings"
+)
+
+// HGetEx replies the values of fields of a hash, nil for the missing ones, and sets or with
+// persist removes the expiry of the existing ones. Without either their expiry is left alone.
+type HGetEx struct {
+	key     string
+	fields  []string
+	expiry  *expiryTime
+	persist bool
+}
+
+func (h *HGetEx) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	option := hashmaps.FieldExpiry{Keep: h.expiry == nil && !h.persist}
+	if h.expiry != nil {
+		at, err := h.expiry.at("HGETEX")
+		if err != nil {
+			return protocol.NewErrorResponse(err)
+		}
+		option.At = &at
+	}
+	values, err := storage.Maps().HGetEx(ctx, h.key, h.fields, option)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewArrayResponse(values)
+}
+
+type HGetExParser struct{}
+
+func NewHGetExParser() *HGetExParser {
+	return &HGetExParser{}
+}
+
+// Parse parses HGETEX key [EX seconds | PX milliseconds | EXAT unix-time-seconds |
+// PXAT unix-time-milliseconds | PERSIST] FIELDS numfields field [field ...].
+func (p *HGetExParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 4 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 4, len(msg.Args))
+	}
+	cmd := &HGetEx{key: msg.Args[0]}
+	args := msg.Args[1:]
+	for len(args) > 0 && !strings.EqualFold(args[0], "FIELDS") {
+		switch option := strings.ToUpper(args[0]); option {
+		case "PERSIST":
+			if cmd.expiry != nil || cmd.persist {
+				return nil, command.ErrSyntax
+			}
+			cmd.persist = true
+			args = args[1:]
+		case "EX", "PX", "EXAT", "PXAT":
+			if cmd.expiry != nil || cmd.persist || len(args) < 2 {
+				return nil, command.ErrSyntax
+			}
+			expiry, err := parseExpiryOption(option, args[1])
+			if err != nil {
+				return nil, err
+			}
+			cmd.expiry = expiry
+			args = args[2:]
+		default:
+			return nil, command.ErrSyntax
+		}
+	}
+	fields, err := parseFields(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	cmd.fields = fields
+	return cmd, nil
+}
+
+func (p *HGetExParser) Name() string {
+	return "HGETEX"
+}
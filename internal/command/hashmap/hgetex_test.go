@@ -0,0 +1,74 @@
+package hashmap
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage/hashmaps"
+	mockhashmaps "avacado/internal/storage/hashmaps/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHGetEx_Execute(t *testing.T) {
+	ctx := context.Background()
+	at := time.UnixMilli(1_700_000_000_000)
+	tests := []struct {
+		name   string
+		cmd    *HGetEx
+		expiry hashmaps.FieldExpiry
+	}{
+		{"keeps the expiry by default", &HGetEx{key: "myhash", fields: []string{"f1"}}, hashmaps.FieldExpiry{Keep: true}},
+		{"removes it with persist", &HGetEx{key: "myhash", fields: []string{"f1"}, persist: true}, hashmaps.FieldExpiry{}},
+		{"sets the one given", &HGetEx{key: "myhash", fields: []string{"f1"}, expiry: &expiryTime{amount: 1_700_000_000, unit: time.Second, absolute: true}}, hashmaps.FieldExpiry{At: &at}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			store := mocksstorage.NewMockStorage(controller)
+			maps := mockhashmaps.NewMockHashMaps(controller)
+			store.EXPECT().Maps().Return(maps)
+			maps.EXPECT().HGetEx(ctx, "myhash", []string{"f1"}, test.expiry).Return([]any{[]byte("v1")}, nil)
+
+			response := test.cmd.Execute(ctx, store)
+
+			assert.Nil(t, response.Err)
+			assert.Equal(t, []byte("v1"), response.Value.Array[0].Bytes)
+		})
+	}
+}
+
+func TestHGetExParser_Parse(t *testing.T) {
+	parser := NewHGetExParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "HGETEX", Args: []string{"myhash", "px", "100", "FIELDS", "1", "f1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &HGetEx{key: "myhash", fields: []string{"f1"}, expiry: &expiryTime{amount: 100, unit: time.Millisecond}}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "HGETEX", Args: []string{"myhash", "PERSIST", "FIELDS", "2", "f1", "f2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &HGetEx{key: "myhash", fields: []string{"f1", "f2"}, persist: true}, cmd)
+	assert.Equal(t, "HGETEX", parser.Name())
+}
+
+func TestHGetExParser_ParseErrors(t *testing.T) {
+	parser := NewHGetExParser()
+	tests := []struct {
+		args []string
+		err  error
+	}{
+		{[]string{"myhash", "EX", "10", "PERSIST", "FIELDS", "1", "f1"}, command.ErrSyntax},
+		{[]string{"myhash", "EX", "10", "PX", "10", "FIELDS", "1", "f1"}, command.ErrSyntax},
+		{[]string{"myhash", "EX", "ten", "FIELDS", "1", "f1"}, command.ErrNotInteger},
+		{[]string{"myhash", "KEEPTTL", "FIELDS", "1", "f1"}, command.ErrSyntax},
+		{[]string{"myhash", "EX", "10", "1", "f1"}, command.ErrSyntax},
+		{[]string{"myhash", "FIELDS", "2", "f1"}, errNumFieldsMismatch},
+	}
+	for _, test := range tests {
+		_, err := parser.Parse(&protocol.Message{Command: "HGETEX", Args: test.args})
+		assert.Equal(t, test.err, err, "%v", test.args)
+	}
+}
@@ -14,6 +14,8 @@ type HIncrBy struct {
 	increment int64
 }
 
+func (h *HIncrBy) DenyOOM() {}
+
 func (h *HIncrBy) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	value, err := storage.Maps().HIncrBy(ctx, h.key, h.field, h.increment)
 	if err != nil {
@@ -13,7 +13,10 @@ type hMGet struct {
 }
 
 func (h *hMGet) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
-	values := storage.Maps().HMGet(ctx, h.key, h.fields)
+	values, err := storage.Maps().HMGet(ctx, h.key, h.fields)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
 	return protocol.NewArrayResponse(values)
 }
 
@@ -18,7 +18,7 @@ func TestHMGetCommand_Execute_AllFound(t *testing.T) {
 	store := mocksstorage.NewMockStorage(controller)
 	maps := mockhashmaps.NewMockHashMaps(controller)
 	store.EXPECT().Maps().Return(maps)
-	maps.EXPECT().HMGet(ctx, "myhash", []string{"f1", "f2"}).Return([]any{[]byte("v1"), []byte("v2")})
+	maps.EXPECT().HMGet(ctx, "myhash", []string{"f1", "f2"}).Return([]any{[]byte("v1"), []byte("v2")}, nil)
 	response := cmd.Execute(ctx, store)
 	assert.Nil(t, response.Err)
 	assert.Equal(t, protocol.ValueType(protocol.TypeArray), response.Value.Type)
@@ -34,7 +34,7 @@ func TestHMGetCommand_Execute_SomeNotFound(t *testing.T) {
 	store := mocksstorage.NewMockStorage(controller)
 	maps := mockhashmaps.NewMockHashMaps(controller)
 	store.EXPECT().Maps().Return(maps)
-	maps.EXPECT().HMGet(ctx, "myhash", []string{"f1", "missing"}).Return([]any{[]byte("v1"), nil})
+	maps.EXPECT().HMGet(ctx, "myhash", []string{"f1", "missing"}).Return([]any{[]byte("v1"), nil}, nil)
 	response := cmd.Execute(ctx, store)
 	assert.Nil(t, response.Err)
 	assert.Equal(t, protocol.ValueType(protocol.TypeArray), response.Value.Type)
@@ -51,7 +51,7 @@ func TestHMGetCommand_Execute_KeyNotFound(t *testing.T) {
 	store := mocksstorage.NewMockStorage(controller)
 	maps := mockhashmaps.NewMockHashMaps(controller)
 	store.EXPECT().Maps().Return(maps)
-	maps.EXPECT().HMGet(ctx, "nonexistent", []string{"f1", "f2"}).Return([]any{nil, nil})
+	maps.EXPECT().HMGet(ctx, "nonexistent", []string{"f1", "f2"}).Return([]any{nil, nil}, nil)
 	response := cmd.Execute(ctx, store)
 	assert.Nil(t, response.Err)
 	assert.Equal(t, protocol.ValueType(protocol.TypeArray), response.Value.Type)
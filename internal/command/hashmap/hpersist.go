@@ -0,0 +1,45 @@
+package hashmap
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// HPersist removes the expiry of fields of a hash, replying for each field 1 if it had one,
+// -1 if it had none and -2 if it does not exist.
+type HPersist struct {
+	key    string
+	fields []string
+}
+
+func (h *HPersist) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	result, err := storage.Maps().HPersist(ctx, h.key, h.fields)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewArrayResponse(result)
+}
+
+type HPersistParser struct{}
+
+func NewHPersistParser() *HPersistParser {
+	return &HPersistParser{}
+}
+
+// Parse parses HPERSIST key FIELDS numfields field [field ...].
+func (p *HPersistParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 4 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 4, len(msg.Args))
+	}
+	fields, err := parseFields(msg.Args[1:], 1)
+	if err != nil {
+		return nil, err
+	}
+	return &HPersist{key: msg.Args[0], fields: fields}, nil
+}
+
+func (p *HPersistParser) Name() string {
+	return "HPERSIST"
+}
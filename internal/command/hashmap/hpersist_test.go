@@ -0,0 +1,38 @@
+package hashmap
+
+import (
+	"avacado/internal/protocol"
+	mockhashmaps "avacado/internal/storage/hashmaps/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHPersist_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	store := mocksstorage.NewMockStorage(controller)
+	maps := mockhashmaps.NewMockHashMaps(controller)
+	store.EXPECT().Maps().Return(maps)
+	maps.EXPECT().HPersist(ctx, "myhash", []string{"f1", "f2"}).Return([]int64{1, -1}, nil)
+
+	response := (&HPersist{key: "myhash", fields: []string{"f1", "f2"}}).Execute(ctx, store)
+
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(1), response.Value.Array[0].Number)
+	assert.Equal(t, int64(-1), response.Value.Array[1].Number)
+}
+
+func TestHPersistParser_Parse(t *testing.T) {
+	parser := NewHPersistParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "HPERSIST", Args: []string{"myhash", "FIELDS", "1", "f1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &HPersist{key: "myhash", fields: []string{"f1"}}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "HPERSIST", Args: []string{"myhash", "f1", "1", "f1"}})
+	assert.Equal(t, errFieldsMissing, err)
+	assert.Equal(t, "HPERSIST", parser.Name())
+}
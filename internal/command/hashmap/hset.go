@@ -12,8 +12,13 @@ type HSet struct {
 	keyValues []string
 }
 
+func (h *HSet) DenyOOM() {}
+
 func (h *HSet) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
-	n := storage.Maps().HSet(ctx, h.name, h.keyValues)
+	n, err := storage.Maps().HSet(ctx, h.name, h.keyValues)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
 	return protocol.NewNumberResponse(int64(n))
 }
 
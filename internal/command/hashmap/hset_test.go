@@ -18,7 +18,7 @@ func TestHSetCommand_Execute(t *testing.T) {
 	storage := mocksstorage.NewMockStorage(controller)
 	maps := mockhashmaps.NewMockHashMaps(controller)
 	storage.EXPECT().Maps().Return(maps)
-	maps.EXPECT().HSet(ctx, "myhash", []string{"field1", "value1", "field2", "value2"}).Return(2)
+	maps.EXPECT().HSet(ctx, "myhash", []string{"field1", "value1", "field2", "value2"}).Return(2, nil)
 	response := cmd.Execute(ctx, storage)
 	assert.Nil(t, response.Err)
 	assert.Equal(t, int64(2), response.Value.Number)
@@ -31,7 +31,7 @@ func TestHSetCommand_ExecuteSingleField(t *testing.T) {
 	storage := mocksstorage.NewMockStorage(controller)
 	maps := mockhashmaps.NewMockHashMaps(controller)
 	storage.EXPECT().Maps().Return(maps)
-	maps.EXPECT().HSet(ctx, "myhash", []string{"field1", "value1"}).Return(1)
+	maps.EXPECT().HSet(ctx, "myhash", []string{"field1", "value1"}).Return(1, nil)
 	response := cmd.Execute(ctx, storage)
 	assert.Nil(t, response.Err)
 	assert.Equal(t, int64(1), response.Value.Number)
@@ -0,0 +1,95 @@
+package hashmap
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/hashmaps"
+	"context"
+	"strings"
+)
+
+// HSetEx sets fields of a hash unless FNX or FXX prevent it, and replies 1 if they were set and
+// 0 otherwise. The fields get the expiry given, keep theirs with KEEPTTL and lose it otherwise.
+type HSetEx struct {
+	key       string
+	keyValues []string
+	fnx, fxx  bool
+	expiry    *expiryTime
+	keepTTL   bool
+}
+
+func (h *HSetEx) DenyOOM() {}
+
+func (h *HSetEx) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	options := hashmaps.SetExOptions{FNX: h.fnx, FXX: h.fxx, Expiry: hashmaps.FieldExpiry{Keep: h.keepTTL}}
+	if h.expiry != nil {
+		at, err := h.expiry.at("HSETEX")
+		if err != nil {
+			return protocol.NewErrorResponse(err)
+		}
+		options.Expiry.At = &at
+	}
+	set, err := storage.Maps().HSetEx(ctx, h.key, h.keyValues, options)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if set {
+		return protocol.NewNumberResponse(1)
+	}
+	return protocol.NewNumberResponse(0)
+}
+
+type HSetExParser struct{}
+
+func NewHSetExParser() *HSetExParser {
+	return &HSetExParser{}
+}
+
+// Parse parses HSETEX key [FNX | FXX] [EX seconds | PX milliseconds | EXAT unix-time-seconds |
+// PXAT unix-time-milliseconds | KEEPTTL] FIELDS numfields field value [field value ...].
+func (p *HSetExParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 5 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 5, len(msg.Args))
+	}
+	cmd := &HSetEx{key: msg.Args[0]}
+	args := msg.Args[1:]
+	for len(args) > 0 && !strings.EqualFold(args[0], "FIELDS") {
+		switch option := strings.ToUpper(args[0]); option {
+		case "FNX", "FXX":
+			if cmd.fnx || cmd.fxx {
+				return nil, command.ErrSyntax
+			}
+			cmd.fnx, cmd.fxx = option == "FNX", option == "FXX"
+			args = args[1:]
+		case "KEEPTTL":
+			if cmd.expiry != nil || cmd.keepTTL {
+				return nil, command.ErrSyntax
+			}
+			cmd.keepTTL = true
+			args = args[1:]
+		case "EX", "PX", "EXAT", "PXAT":
+			if cmd.expiry != nil || cmd.keepTTL || len(args) < 2 {
+				return nil, command.ErrSyntax
+			}
+			expiry, err := parseExpiryOption(option, args[1])
+			if err != nil {
+				return nil, err
+			}
+			cmd.expiry = expiry
+			args = args[2:]
+		default:
+			return nil, command.ErrSyntax
+		}
+	}
+	keyValues, err := parseFields(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	cmd.keyValues = keyValues
+	return cmd, nil
+}
+
+func (p *HSetExParser) Name() string {
+	return "HSETEX"
+}
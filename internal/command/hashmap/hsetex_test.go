@@ -0,0 +1,67 @@
+package hashmap
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage/hashmaps"
+	mockhashmaps "avacado/internal/storage/hashmaps/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHSetEx_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	store := mocksstorage.NewMockStorage(controller)
+	maps := mockhashmaps.NewMockHashMaps(controller)
+	store.EXPECT().Maps().Return(maps).Times(2)
+	at := time.UnixMilli(1_700_000_000_000)
+	maps.EXPECT().HSetEx(ctx, "myhash", []string{"f1", "v1"}, hashmaps.SetExOptions{FNX: true, Expiry: hashmaps.FieldExpiry{At: &at}}).Return(true, nil)
+	maps.EXPECT().HSetEx(ctx, "myhash", []string{"f1", "v1"}, hashmaps.SetExOptions{FXX: true, Expiry: hashmaps.FieldExpiry{Keep: true}}).Return(false, nil)
+
+	cmd := &HSetEx{key: "myhash", keyValues: []string{"f1", "v1"}, fnx: true, expiry: &expiryTime{amount: 1_700_000_000_000, unit: time.Millisecond, absolute: true}}
+	response := cmd.Execute(ctx, store)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(1), response.Value.Number)
+
+	cmd = &HSetEx{key: "myhash", keyValues: []string{"f1", "v1"}, fxx: true, keepTTL: true}
+	response = cmd.Execute(ctx, store)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(0), response.Value.Number)
+}
+
+func TestHSetExParser_Parse(t *testing.T) {
+	parser := NewHSetExParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "HSETEX", Args: []string{"myhash", "FXX", "EX", "10", "FIELDS", "2", "f1", "v1", "f2", "v2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &HSetEx{key: "myhash", keyValues: []string{"f1", "v1", "f2", "v2"}, fxx: true, expiry: &expiryTime{amount: 10, unit: time.Second}}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "HSETEX", Args: []string{"myhash", "keepttl", "fields", "1", "f1", "v1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &HSetEx{key: "myhash", keyValues: []string{"f1", "v1"}, keepTTL: true}, cmd)
+	assert.Equal(t, "HSETEX", parser.Name())
+}
+
+func TestHSetExParser_ParseErrors(t *testing.T) {
+	parser := NewHSetExParser()
+	tests := []struct {
+		args []string
+		err  error
+	}{
+		{[]string{"myhash", "FIELDS", "1", "f1"}, command.NewInvalidArgumentsCount("HSETEX", 5, 4)},
+		{[]string{"myhash", "FNX", "FXX", "FIELDS", "1", "f1", "v1"}, command.ErrSyntax},
+		{[]string{"myhash", "EX", "10", "KEEPTTL", "FIELDS", "1", "f1", "v1"}, command.ErrSyntax},
+		{[]string{"myhash", "PERSIST", "FIELDS", "1", "f1", "v1"}, command.ErrSyntax},
+		{[]string{"myhash", "FIELDS", "1", "f1", "v1", "f2"}, errNumFieldsMismatch},
+		{[]string{"myhash", "FIELDS", "2", "f1", "v1"}, errNumFieldsMismatch},
+	}
+	for _, test := range tests {
+		_, err := parser.Parse(&protocol.Message{Command: "HSETEX", Args: test.args})
+		assert.Equal(t, test.err, err, "%v", test.args)
+	}
+}
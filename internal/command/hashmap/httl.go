@@ -0,0 +1,108 @@
+package hashmap
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"time"
+)
+
+// HTTL replies for each field of a hash its time to live (HTTL, HPTTL) or the Unix time it expires
+// at (HEXPIRETIME, HPEXPIRETIME), -1 if it has no expiry and -2 if it does not exist. Like Redis,
+// times in seconds are rounded up.
+type HTTL struct {
+	key      string
+	fields   []string
+	unit     time.Duration
+	absolute bool
+}
+
+func (h *HTTL) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	var result []int64
+	var err error
+	if h.absolute {
+		result, err = storage.Maps().HExpireTime(ctx, h.key, h.fields)
+	} else {
+		result, err = storage.Maps().HTTL(ctx, h.key, h.fields)
+	}
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if h.unit == time.Second {
+		for i, millis := range result {
+			if millis >= 0 {
+				result[i] = (millis + 999) / 1000
+			}
+		}
+	}
+	return protocol.NewArrayResponse(result)
+}
+
+// parseHTTL parses key FIELDS numfields field [field ...].
+func parseHTTL(name string, unit time.Duration, absolute bool, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 4 {
+		return nil, command.NewInvalidArgumentsCount(name, 4, len(msg.Args))
+	}
+	fields, err := parseFields(msg.Args[1:], 1)
+	if err != nil {
+		return nil, err
+	}
+	return &HTTL{key: msg.Args[0], fields: fields, unit: unit, absolute: absolute}, nil
+}
+
+type HTTLParser struct{}
+
+func NewHTTLParser() *HTTLParser {
+	return &HTTLParser{}
+}
+
+func (p *HTTLParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseHTTL(p.Name(), time.Second, false, msg)
+}
+
+func (p *HTTLParser) Name() string {
+	return "HTTL"
+}
+
+type HPTTLParser struct{}
+
+func NewHPTTLParser() *HPTTLParser {
+	return &HPTTLParser{}
+}
+
+func (p *HPTTLParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseHTTL(p.Name(), time.Millisecond, false, msg)
+}
+
+func (p *HPTTLParser) Name() string {
+	return "HPTTL"
+}
+
+type HExpireTimeParser struct{}
+
+func NewHExpireTimeParser() *HExpireTimeParser {
+	return &HExpireTimeParser{}
+}
+
+func (p *HExpireTimeParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseHTTL(p.Name(), time.Second, true, msg)
+}
+
+func (p *HExpireTimeParser) Name() string {
+	return "HEXPIRETIME"
+}
+
+type HPExpireTimeParser struct{}
+
+func NewHPExpireTimeParser() *HPExpireTimeParser {
+	return &HPExpireTimeParser{}
+}
+
+func (p *HPExpireTimeParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseHTTL(p.Name(), time.Millisecond, true, msg)
+}
+
+func (p *HPExpireTimeParser) Name() string {
+	return "HPEXPIRETIME"
+}
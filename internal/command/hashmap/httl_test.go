@@ -0,0 +1,67 @@
+package hashmap
+
+import (
+	"avacado/internal/protocol"
+	mockhashmaps "avacado/internal/storage/hashmaps/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHTTL_ExecuteRoundsSecondsUp(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	store := mocksstorage.NewMockStorage(controller)
+	maps := mockhashmaps.NewMockHashMaps(controller)
+	store.EXPECT().Maps().Return(maps)
+	maps.EXPECT().HTTL(ctx, "myhash", []string{"f1", "f2", "f3"}).Return([]int64{1500, -1, -2}, nil)
+
+	cmd := &HTTL{key: "myhash", fields: []string{"f1", "f2", "f3"}, unit: time.Second}
+	response := cmd.Execute(ctx, store)
+
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(2), response.Value.Array[0].Number)
+	assert.Equal(t, int64(-1), response.Value.Array[1].Number)
+	assert.Equal(t, int64(-2), response.Value.Array[2].Number)
+}
+
+func TestHTTL_ExecuteExpireTimeInMilliseconds(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	store := mocksstorage.NewMockStorage(controller)
+	maps := mockhashmaps.NewMockHashMaps(controller)
+	store.EXPECT().Maps().Return(maps)
+	maps.EXPECT().HExpireTime(ctx, "myhash", []string{"f1"}).Return([]int64{1_700_000_000_123}, nil)
+
+	cmd := &HTTL{key: "myhash", fields: []string{"f1"}, unit: time.Millisecond, absolute: true}
+	response := cmd.Execute(ctx, store)
+
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(1_700_000_000_123), response.Value.Array[0].Number)
+}
+
+func TestHTTLParsers_Parse(t *testing.T) {
+	cmd, err := NewHPTTLParser().Parse(&protocol.Message{Command: "HPTTL", Args: []string{"myhash", "FIELDS", "2", "f1", "f2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &HTTL{key: "myhash", fields: []string{"f1", "f2"}, unit: time.Millisecond}, cmd)
+
+	cmd, err = NewHExpireTimeParser().Parse(&protocol.Message{Command: "HEXPIRETIME", Args: []string{"myhash", "FIELDS", "1", "f1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &HTTL{key: "myhash", fields: []string{"f1"}, unit: time.Second, absolute: true}, cmd)
+
+	_, err = NewHTTLParser().Parse(&protocol.Message{Command: "HTTL", Args: []string{"myhash", "FIELDS", "1"}})
+	assert.Error(t, err)
+	_, err = NewHTTLParser().Parse(&protocol.Message{Command: "HTTL", Args: []string{"myhash", "FIELDS", "1", "f1", "f2"}})
+	assert.Equal(t, errNumFieldsMismatch, err)
+}
+
+func TestHTTLParsers_Name(t *testing.T) {
+	assert.Equal(t, "HTTL", NewHTTLParser().Name())
+	assert.Equal(t, "HPTTL", NewHPTTLParser().Name())
+	assert.Equal(t, "HEXPIRETIME", NewHExpireTimeParser().Name())
+	assert.Equal(t, "HPEXPIRETIME", NewHPExpireTimeParser().Name())
+}
@@ -0,0 +1,45 @@
+package hyperloglog
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// PFAdd adds elements to the HyperLogLog at a key, creating it if needed, and replies 1 if the
+// estimated cardinality may have changed, or 0.
+type PFAdd struct {
+	key      string
+	elements []string
+}
+
+func (p *PFAdd) DenyOOM() {}
+
+func (p *PFAdd) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	updated, err := storage.KV().PFAdd(ctx, p.key, p.elements)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if updated {
+		return protocol.NewNumberResponse(1)
+	}
+	return protocol.NewNumberResponse(0)
+}
+
+type PFAddParser struct{}
+
+func NewPFAddParser() *PFAddParser {
+	return &PFAddParser{}
+}
+
+func (p *PFAddParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	return &PFAdd{key: msg.Args[0], elements: msg.Args[1:]}, nil
+}
+
+func (p *PFAddParser) Name() string {
+	return "PFADD"
+}
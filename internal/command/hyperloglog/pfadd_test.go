@@ -0,0 +1,41 @@
+package hyperloglog
+
+import (
+	"avacado/internal/protocol"
+	mockkv "avacado/internal/storage/kv/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPFAddParser_Parse(t *testing.T) {
+	p := NewPFAddParser()
+	cmd, err := p.Parse(&protocol.Message{Command: "PFADD", Args: []string{"hll", "a", "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &PFAdd{key: "hll", elements: []string{"a", "b"}}, cmd)
+
+	cmd, err = p.Parse(&protocol.Message{Command: "PFADD", Args: []string{"hll"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &PFAdd{key: "hll", elements: []string{}}, cmd)
+
+	_, err = p.Parse(&protocol.Message{Command: "PFADD"})
+	assert.Error(t, err)
+}
+
+func TestPFAdd_Execute(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+	store := mockkv.NewMockStore(ctr)
+	storage.EXPECT().KV().Return(store).Times(3)
+	store.EXPECT().PFAdd(gomock.Any(), "hll", []string{"a"}).Return(true, nil)
+	store.EXPECT().PFAdd(gomock.Any(), "hll", []string{"a"}).Return(false, nil)
+	store.EXPECT().PFAdd(gomock.Any(), "hll", []string{"a"}).Return(false, assert.AnError)
+
+	cmd := &PFAdd{key: "hll", elements: []string{"a"}}
+	assert.Equal(t, protocol.NewNumberResponse(1), cmd.Execute(context.TODO(), storage))
+	assert.Equal(t, protocol.NewNumberResponse(0), cmd.Execute(context.TODO(), storage))
+	assert.Equal(t, protocol.NewErrorResponse(assert.AnError), cmd.Execute(context.TODO(), storage))
+}
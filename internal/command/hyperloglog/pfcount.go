@@ -0,0 +1,39 @@
+package hyperloglog
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// PFCount replies the estimated cardinality of the HyperLogLog at a key, or of the union of those
+// at several keys.
+type PFCount struct {
+	keys []string
+}
+
+func (p *PFCount) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	card, err := storage.KV().PFCount(ctx, p.keys)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(card))
+}
+
+type PFCountParser struct{}
+
+func NewPFCountParser() *PFCountParser {
+	return &PFCountParser{}
+}
+
+func (p *PFCountParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	return &PFCount{keys: msg.Args}, nil
+}
+
+func (p *PFCountParser) Name() string {
+	return "PFCOUNT"
+}
@@ -0,0 +1,35 @@
+package hyperloglog
+
+import (
+	"avacado/internal/protocol"
+	mockkv "avacado/internal/storage/kv/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPFCountParser_Parse(t *testing.T) {
+	p := NewPFCountParser()
+	cmd, err := p.Parse(&protocol.Message{Command: "PFCOUNT", Args: []string{"first", "second"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &PFCount{keys: []string{"first", "second"}}, cmd)
+
+	_, err = p.Parse(&protocol.Message{Command: "PFCOUNT"})
+	assert.Error(t, err)
+}
+
+func TestPFCount_Execute(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+	store := mockkv.NewMockStore(ctr)
+	storage.EXPECT().KV().Return(store).Times(2)
+	store.EXPECT().PFCount(gomock.Any(), []string{"first", "second"}).Return(uint64(42), nil)
+	store.EXPECT().PFCount(gomock.Any(), []string{"first", "second"}).Return(uint64(0), assert.AnError)
+
+	cmd := &PFCount{keys: []string{"first", "second"}}
+	assert.Equal(t, protocol.NewNumberResponse(42), cmd.Execute(context.TODO(), storage))
+	assert.Equal(t, protocol.NewErrorResponse(assert.AnError), cmd.Execute(context.TODO(), storage))
+}
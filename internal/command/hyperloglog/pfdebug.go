@@ -0,0 +1,90 @@
+package hyperloglog
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	errNoSuchKey = errors.New("ERR The specified key does not exist")
+	errNotSparse = errors.New("ERR HLL encoding is not sparse")
+)
+
+// PFDebug inspects the HyperLogLog at a key:
+//   - GETREG converts it to dense and replies the value of every register.
+//   - DECODE replies the opcodes of a sparse one.
+//   - ENCODING replies "sparse" or "dense".
+//   - TODENSE converts it to dense and replies 1, or 0 if it already was.
+type PFDebug struct {
+	subcommand string
+	key        string
+}
+
+func (p *PFDebug) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	toDense := p.subcommand == "GETREG" || p.subcommand == "TODENSE"
+	hll, converted, err := storage.KV().PFDebug(ctx, p.key, toDense)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if hll == nil {
+		return protocol.NewErrorResponse(errNoSuchKey)
+	}
+	switch p.subcommand {
+	case "GETREG":
+		registers := hll.Registers()
+		values := make([]int, len(registers))
+		for i, value := range registers {
+			values[i] = int(value)
+		}
+		return protocol.NewArrayResponse(values)
+	case "DECODE":
+		if hll.Dense() {
+			return protocol.NewErrorResponse(errNotSparse)
+		}
+		decoded, err := hll.Decode()
+		if err != nil {
+			return protocol.NewErrorResponse(err)
+		}
+		return protocol.NewBulkStringResponse([]byte(decoded))
+	case "ENCODING":
+		return protocol.NewSimpleStringResponse(hll.Encoding())
+	default:
+		if converted {
+			return protocol.NewNumberResponse(1)
+		}
+		return protocol.NewNumberResponse(0)
+	}
+}
+
+type PFDebugParser struct{}
+
+func NewPFDebugParser() *PFDebugParser {
+	return &PFDebugParser{}
+}
+
+// Parse follows Redis in letting DECODE take extra arguments, which it ignores.
+func (p *PFDebugParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	subcommand := strings.ToUpper(msg.Args[0])
+	switch subcommand {
+	case "GETREG", "ENCODING", "TODENSE":
+		if len(msg.Args) != 2 {
+			return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+		}
+	case "DECODE":
+	default:
+		return nil, fmt.Errorf("ERR Unknown PFDEBUG subcommand '%s'", msg.Args[0])
+	}
+	return &PFDebug{subcommand: subcommand, key: msg.Args[1]}, nil
+}
+
+func (p *PFDebugParser) Name() string {
+	return "PFDEBUG"
+}
@@ -0,0 +1,70 @@
+package hyperloglog
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/hyperloglog"
+	mockkv "avacado/internal/storage/kv/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPFDebugParser_Parse(t *testing.T) {
+	p := NewPFDebugParser()
+	cmd, err := p.Parse(&protocol.Message{Command: "PFDEBUG", Args: []string{"getreg", "hll"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &PFDebug{subcommand: "GETREG", key: "hll"}, cmd)
+
+	cmd, err = p.Parse(&protocol.Message{Command: "PFDEBUG", Args: []string{"DECODE", "hll", "extra"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &PFDebug{subcommand: "DECODE", key: "hll"}, cmd)
+
+	_, err = p.Parse(&protocol.Message{Command: "PFDEBUG", Args: []string{"ENCODING", "hll", "extra"}})
+	assert.Error(t, err)
+	_, err = p.Parse(&protocol.Message{Command: "PFDEBUG", Args: []string{"TODENSE"}})
+	assert.Error(t, err)
+	_, err = p.Parse(&protocol.Message{Command: "PFDEBUG", Args: []string{"foo", "hll"}})
+	assert.EqualError(t, err, "ERR Unknown PFDEBUG subcommand 'foo'")
+}
+
+func TestPFDebug_Execute(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+	store := mockkv.NewMockStore(ctr)
+	storage.EXPECT().KV().Return(store).AnyTimes()
+	sparse := hyperloglog.New()
+	_, _ = sparse.Add([]byte("a"), hyperloglog.DefaultSparseMaxBytes)
+	dense := hyperloglog.New()
+	_, _ = dense.ToDense()
+
+	store.EXPECT().PFDebug(gomock.Any(), "sparse", false).Return(sparse, false, nil).Times(2)
+	decoded, _ := sparse.Decode()
+	resp := (&PFDebug{subcommand: "DECODE", key: "sparse"}).Execute(context.TODO(), storage)
+	assert.Equal(t, protocol.NewBulkStringResponse([]byte(decoded)), resp)
+	resp = (&PFDebug{subcommand: "ENCODING", key: "sparse"}).Execute(context.TODO(), storage)
+	assert.Equal(t, protocol.NewSimpleStringResponse("sparse"), resp)
+
+	store.EXPECT().PFDebug(gomock.Any(), "dense", false).Return(dense, false, nil)
+	resp = (&PFDebug{subcommand: "DECODE", key: "dense"}).Execute(context.TODO(), storage)
+	assert.Equal(t, protocol.NewErrorResponse(errNotSparse), resp)
+
+	store.EXPECT().PFDebug(gomock.Any(), "dense", true).Return(dense, true, nil)
+	resp = (&PFDebug{subcommand: "TODENSE", key: "dense"}).Execute(context.TODO(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(1), resp)
+
+	store.EXPECT().PFDebug(gomock.Any(), "dense", true).Return(dense, false, nil)
+	resp = (&PFDebug{subcommand: "GETREG", key: "dense"}).Execute(context.TODO(), storage)
+	assert.Len(t, resp.Value.Array, hyperloglog.Registers)
+	assert.Equal(t, int64(0), resp.Value.Array[0].Number)
+
+	store.EXPECT().PFDebug(gomock.Any(), "missing", false).Return(nil, false, nil)
+	resp = (&PFDebug{subcommand: "ENCODING", key: "missing"}).Execute(context.TODO(), storage)
+	assert.Equal(t, protocol.NewErrorResponse(errNoSuchKey), resp)
+
+	store.EXPECT().PFDebug(gomock.Any(), "string", false).Return(nil, false, hyperloglog.ErrNotHLL)
+	resp = (&PFDebug{subcommand: "ENCODING", key: "string"}).Execute(context.TODO(), storage)
+	assert.Equal(t, protocol.NewErrorResponse(hyperloglog.ErrNotHLL), resp)
+}
@@ -0,0 +1,41 @@
+package hyperloglog
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// PFMerge stores the union of the HyperLogLogs at the source keys and at the destination in the
+// destination.
+type PFMerge struct {
+	destination string
+	sources     []string
+}
+
+func (p *PFMerge) DenyOOM() {}
+
+func (p *PFMerge) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	if err := storage.KV().PFMerge(ctx, p.destination, p.sources); err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewSimpleStringResponse("OK")
+}
+
+type PFMergeParser struct{}
+
+func NewPFMergeParser() *PFMergeParser {
+	return &PFMergeParser{}
+}
+
+func (p *PFMergeParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	return &PFMerge{destination: msg.Args[0], sources: msg.Args[1:]}, nil
+}
+
+func (p *PFMergeParser) Name() string {
+	return "PFMERGE"
+}
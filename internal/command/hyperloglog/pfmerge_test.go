@@ -0,0 +1,35 @@
+package hyperloglog
+
+import (
+	"avacado/internal/protocol"
+	mockkv "avacado/internal/storage/kv/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPFMergeParser_Parse(t *testing.T) {
+	p := NewPFMergeParser()
+	cmd, err := p.Parse(&protocol.Message{Command: "PFMERGE", Args: []string{"dest", "first", "second"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &PFMerge{destination: "dest", sources: []string{"first", "second"}}, cmd)
+
+	_, err = p.Parse(&protocol.Message{Command: "PFMERGE"})
+	assert.Error(t, err)
+}
+
+func TestPFMerge_Execute(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+	store := mockkv.NewMockStore(ctr)
+	storage.EXPECT().KV().Return(store).Times(2)
+	store.EXPECT().PFMerge(gomock.Any(), "dest", []string{"first"}).Return(nil)
+	store.EXPECT().PFMerge(gomock.Any(), "dest", []string{"first"}).Return(assert.AnError)
+
+	cmd := &PFMerge{destination: "dest", sources: []string{"first"}}
+	assert.Equal(t, protocol.NewSimpleStringResponse("OK"), cmd.Execute(context.TODO(), storage))
+	assert.Equal(t, protocol.NewErrorResponse(assert.AnError), cmd.Execute(context.TODO(), storage))
+}
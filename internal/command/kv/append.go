@@ -12,6 +12,8 @@ type Append struct {
 	Value []byte
 }
 
+func (a *Append) DenyOOM() {}
+
 func (a *Append) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	length, err := storage.KV().Append(ctx, a.Key, a.Value)
 	if err != nil {
@@ -0,0 +1,76 @@
+package kv
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/kv"
+	"context"
+	"strconv"
+	"strings"
+)
+
+// parseBitRange parses the start and end of a range of a string value, followed by the BYTE or
+// BIT unit they are in if unit is given.
+func parseBitRange(start, end string, unit ...string) (kv.BitRange, error) {
+	var r kv.BitRange
+	var err error
+	if r.Start, err = strconv.ParseInt(start, 10, 64); err != nil {
+		return r, command.ErrNotInteger
+	}
+	if r.End, err = strconv.ParseInt(end, 10, 64); err != nil {
+		return r, command.ErrNotInteger
+	}
+	if len(unit) > 0 {
+		switch strings.ToUpper(unit[0]) {
+		case "BIT":
+			r.Bits = true
+		case "BYTE":
+		default:
+			return r, command.ErrSyntax
+		}
+	}
+	return r, nil
+}
+
+// BitCount replies how many bits of a string value are set, within a range of bytes or bits.
+type BitCount struct {
+	Key   string
+	Range kv.BitRange
+}
+
+func (b *BitCount) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	count, err := storage.KV().BitCount(ctx, b.Key, b.Range)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(count)
+}
+
+type BitCountParser struct{}
+
+func NewBitCountParser() *BitCountParser {
+	return &BitCountParser{}
+}
+
+// Parse accepts BITCOUNT key [start end [BYTE|BIT]], counting the whole value without a range.
+func (p *BitCountParser) Parse(msg *protocol.Message) (command.Command, error) {
+	args := msg.Args
+	switch {
+	case len(args) == 0:
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, 0)
+	case len(args) == 1:
+		return &BitCount{Key: args[0], Range: kv.BitRange{Start: 0, End: -1}}, nil
+	case len(args) == 2 || len(args) > 4:
+		return nil, command.ErrSyntax
+	}
+	r, err := parseBitRange(args[1], args[2], args[3:]...)
+	if err != nil {
+		return nil, err
+	}
+	return &BitCount{Key: args[0], Range: r}, nil
+}
+
+func (p *BitCountParser) Name() string {
+	return "BITCOUNT"
+}
@@ -0,0 +1,62 @@
+package kv
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage/kv"
+	mockkv "avacado/internal/storage/kv/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBitCountParser_Parse(t *testing.T) {
+	p := NewBitCountParser()
+	for _, tc := range []struct {
+		args []string
+		r    kv.BitRange
+	}{
+		{[]string{"mykey"}, kv.BitRange{Start: 0, End: -1}},
+		{[]string{"mykey", "1", "-2"}, kv.BitRange{Start: 1, End: -2}},
+		{[]string{"mykey", "1", "-2", "byte"}, kv.BitRange{Start: 1, End: -2}},
+		{[]string{"mykey", "5", "30", "BIT"}, kv.BitRange{Start: 5, End: 30, Bits: true}},
+	} {
+		cmd, err := p.Parse(&protocol.Message{Command: "BITCOUNT", Args: tc.args})
+		assert.NoError(t, err)
+		assert.Equal(t, &BitCount{Key: "mykey", Range: tc.r}, cmd, tc.args)
+	}
+}
+
+func TestBitCountParser_ParseInvalidArguments(t *testing.T) {
+	p := NewBitCountParser()
+	for _, tc := range []struct {
+		args []string
+		err  error
+	}{
+		{[]string{"mykey", "1"}, command.ErrSyntax},
+		{[]string{"mykey", "1", "2", "BIT", "x"}, command.ErrSyntax},
+		{[]string{"mykey", "1", "2", "WORD"}, command.ErrSyntax},
+		{[]string{"mykey", "x", "2"}, command.ErrNotInteger},
+		{[]string{"mykey", "1", "x"}, command.ErrNotInteger},
+	} {
+		_, err := p.Parse(&protocol.Message{Command: "BITCOUNT", Args: tc.args})
+		assert.Equal(t, tc.err, err, tc.args)
+	}
+}
+
+func TestBitCount_Execute(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+	store := mockkv.NewMockStore(ctr)
+	storage.EXPECT().KV().Return(store).Times(2)
+	r := kv.BitRange{Start: 0, End: -1}
+	store.EXPECT().BitCount(gomock.Any(), "mykey", r).Return(int64(26), nil)
+	store.EXPECT().BitCount(gomock.Any(), "mykey", r).Return(int64(0), assert.AnError)
+
+	cmd := &BitCount{Key: "mykey", Range: r}
+	assert.Equal(t, protocol.NewNumberResponse(26), cmd.Execute(context.TODO(), storage))
+	assert.Equal(t, protocol.NewErrorResponse(assert.AnError), cmd.Execute(context.TODO(), storage))
+}
@@ -0,0 +1,188 @@
+package kv
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/kv"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	errBitFieldType     = errors.New("ERR Invalid bitfield type. Use something like i16 u8. Note that u64 is not supported but i64 is.")
+	errBitFieldOverflow = errors.New("ERR Invalid OVERFLOW type specified")
+	errBitFieldReadOnly = errors.New("ERR BITFIELD_RO only supports the GET subcommand")
+)
+
+// BitField performs GET, SET and INCRBY operations on integer fields of a string value and
+// replies the result of each, nil where OVERFLOW FAIL left the field unchanged.
+type BitField struct {
+	Key string
+	Ops []kv.BitFieldOp
+}
+
+func (b *BitField) DenyOOM() {}
+
+func (b *BitField) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	return executeBitField(ctx, storage, b.Key, b.Ops)
+}
+
+// BitFieldRO is BITFIELD limited to GET operations.
+type BitFieldRO struct {
+	Key string
+	Ops []kv.BitFieldOp
+}
+
+func (b *BitFieldRO) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	return executeBitField(ctx, storage, b.Key, b.Ops)
+}
+
+func executeBitField(ctx context.Context, storage storage.Storage, key string, ops []kv.BitFieldOp) *protocol.Response {
+	results, err := storage.KV().BitField(ctx, key, ops)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	values := make([]protocol.Value, len(results))
+	for i, result := range results {
+		if result == nil {
+			values[i] = protocol.NewNullBulkStringProtocolValue()
+		} else {
+			values[i] = protocol.NewNumberProtocolValue(*result)
+		}
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue(values))
+}
+
+type BitFieldParser struct{}
+
+func NewBitFieldParser() *BitFieldParser {
+	return &BitFieldParser{}
+}
+
+// Parse accepts BITFIELD key [GET encoding offset | [OVERFLOW WRAP|SAT|FAIL] SET encoding offset
+// value | INCRBY encoding offset increment] ..., OVERFLOW applying to the operations after it.
+func (p *BitFieldParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	ops, err := parseBitFieldOps(msg.Args[1:])
+	if err != nil {
+		return nil, err
+	}
+	return &BitField{Key: msg.Args[0], Ops: ops}, nil
+}
+
+func (p *BitFieldParser) Name() string {
+	return "BITFIELD"
+}
+
+type BitFieldROParser struct{}
+
+func NewBitFieldROParser() *BitFieldROParser {
+	return &BitFieldROParser{}
+}
+
+func (p *BitFieldROParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	ops, err := parseBitFieldOps(msg.Args[1:])
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		if op.Kind != kv.BitFieldGet {
+			return nil, errBitFieldReadOnly
+		}
+	}
+	return &BitFieldRO{Key: msg.Args[0], Ops: ops}, nil
+}
+
+func (p *BitFieldROParser) Name() string {
+	return "BITFIELD_RO"
+}
+
+func parseBitFieldOps(args []string) ([]kv.BitFieldOp, error) {
+	var ops []kv.BitFieldOp
+	overflow := kv.BitFieldWrap
+	for i := 0; i < len(args); {
+		op := kv.BitFieldOp{Overflow: overflow}
+		argc := 3
+		switch subcommand := strings.ToUpper(args[i]); {
+		case subcommand == "GET" && len(args)-i > 2:
+			op.Kind, argc = kv.BitFieldGet, 2
+		case subcommand == "SET" && len(args)-i > 3:
+			op.Kind = kv.BitFieldSet
+		case subcommand == "INCRBY" && len(args)-i > 3:
+			op.Kind = kv.BitFieldIncrBy
+		case subcommand == "OVERFLOW" && len(args)-i > 1:
+			switch strings.ToUpper(args[i+1]) {
+			case "WRAP":
+				overflow = kv.BitFieldWrap
+			case "SAT":
+				overflow = kv.BitFieldSat
+			case "FAIL":
+				overflow = kv.BitFieldFail
+			default:
+				return nil, errBitFieldOverflow
+			}
+			i += 2
+			continue
+		default:
+			return nil, command.ErrSyntax
+		}
+		var err error
+		if op.Signed, op.Bits, err = parseBitFieldType(args[i+1]); err != nil {
+			return nil, err
+		}
+		if op.Offset, err = parseBitFieldOffset(args[i+2], op.Bits); err != nil {
+			return nil, err
+		}
+		if op.Kind != kv.BitFieldGet {
+			if op.Value, err = strconv.ParseInt(args[i+3], 10, 64); err != nil {
+				return nil, command.ErrNotInteger
+			}
+		}
+		ops = append(ops, op)
+		i += argc + 1
+	}
+	return ops, nil
+}
+
+// parseBitFieldType parses a field type such as i16 or u8: a signed integer of up to 64 bits or
+// an unsigned one of up to 63, so that it fits an int64.
+func parseBitFieldType(arg string) (bool, int, error) {
+	if len(arg) < 2 {
+		return false, 0, errBitFieldType
+	}
+	signed := false
+	switch arg[0] {
+	case 'i', 'I':
+		signed = true
+	case 'u', 'U':
+	default:
+		return false, 0, errBitFieldType
+	}
+	bits, err := strconv.Atoi(arg[1:])
+	if err != nil || bits < 1 || (signed && bits > 64) || (!signed && bits > 63) {
+		return false, 0, errBitFieldType
+	}
+	return signed, bits, nil
+}
+
+// parseBitFieldOffset parses the bit offset of a field, or with a # prefix its index in an array
+// of fields of bits bits.
+func parseBitFieldOffset(arg string, bits int) (int64, error) {
+	index, ok := strings.CutPrefix(arg, "#")
+	offset, err := parseBitOffset(index)
+	if err != nil || !ok {
+		return offset, err
+	}
+	if offset >= maxBitOffset/int64(bits) {
+		return 0, errBitOffset
+	}
+	return offset * int64(bits), nil
+}
@@ -0,0 +1,90 @@
+package kv
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage/kv"
+	mockkv "avacado/internal/storage/kv/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBitFieldParser_Parse(t *testing.T) {
+	p := NewBitFieldParser()
+	cmd, err := p.Parse(&protocol.Message{Command: "BITFIELD", Args: []string{
+		"mykey", "get", "u4", "0", "SET", "i8", "#2", "-100", "OVERFLOW", "sat", "INCRBY", "i64", "3", "5",
+		"overflow", "FAIL", "incrby", "U63", "#1", "-1",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, &BitField{Key: "mykey", Ops: []kv.BitFieldOp{
+		{Kind: kv.BitFieldGet, Bits: 4, Offset: 0},
+		{Kind: kv.BitFieldSet, Signed: true, Bits: 8, Offset: 16, Value: -100},
+		{Kind: kv.BitFieldIncrBy, Signed: true, Bits: 64, Offset: 3, Value: 5, Overflow: kv.BitFieldSat},
+		{Kind: kv.BitFieldIncrBy, Bits: 63, Offset: 63, Value: -1, Overflow: kv.BitFieldFail},
+	}}, cmd)
+
+	cmd, err = p.Parse(&protocol.Message{Command: "BITFIELD", Args: []string{"mykey"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &BitField{Key: "mykey"}, cmd)
+}
+
+func TestBitFieldParser_ParseInvalidArguments(t *testing.T) {
+	p := NewBitFieldParser()
+	for _, tc := range []struct {
+		args []string
+		err  error
+	}{
+		{[]string{"mykey", "GET", "u64", "0"}, errBitFieldType},
+		{[]string{"mykey", "GET", "i65", "0"}, errBitFieldType},
+		{[]string{"mykey", "GET", "i0", "0"}, errBitFieldType},
+		{[]string{"mykey", "GET", "x8", "0"}, errBitFieldType},
+		{[]string{"mykey", "GET", "u8", "-1"}, errBitOffset},
+		{[]string{"mykey", "GET", "u8", "#x"}, errBitOffset},
+		{[]string{"mykey", "GET", "i64", "#67108864"}, errBitOffset},
+		{[]string{"mykey", "SET", "u8", "0", "x"}, command.ErrNotInteger},
+		{[]string{"mykey", "SET", "u8", "0"}, command.ErrSyntax},
+		{[]string{"mykey", "GET", "u8"}, command.ErrSyntax},
+		{[]string{"mykey", "OVERFLOW"}, command.ErrSyntax},
+		{[]string{"mykey", "OVERFLOW", "NONE"}, errBitFieldOverflow},
+		{[]string{"mykey", "DEL", "u8", "0"}, command.ErrSyntax},
+	} {
+		_, err := p.Parse(&protocol.Message{Command: "BITFIELD", Args: tc.args})
+		assert.Equal(t, tc.err, err, tc.args)
+	}
+}
+
+func TestBitFieldROParser_Parse(t *testing.T) {
+	p := NewBitFieldROParser()
+	cmd, err := p.Parse(&protocol.Message{Command: "BITFIELD_RO", Args: []string{"mykey", "GET", "i8", "#1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &BitFieldRO{Key: "mykey", Ops: []kv.BitFieldOp{{Kind: kv.BitFieldGet, Signed: true, Bits: 8, Offset: 8}}}, cmd)
+
+	_, err = p.Parse(&protocol.Message{Command: "BITFIELD_RO", Args: []string{"mykey", "GET", "i8", "0", "SET", "i8", "0", "1"}})
+	assert.Equal(t, errBitFieldReadOnly, err)
+}
+
+func TestBitField_Execute(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+	store := mockkv.NewMockStore(ctr)
+	storage.EXPECT().KV().Return(store).Times(2)
+	ops := []kv.BitFieldOp{
+		{Kind: kv.BitFieldIncrBy, Bits: 2, Value: 1, Overflow: kv.BitFieldFail},
+		{Kind: kv.BitFieldGet, Bits: 2},
+	}
+	three := int64(3)
+	store.EXPECT().BitField(gomock.Any(), "mykey", ops).Return([]*int64{nil, &three}, nil)
+	store.EXPECT().BitField(gomock.Any(), "mykey", ops).Return(nil, assert.AnError)
+
+	cmd := &BitField{Key: "mykey", Ops: ops}
+	resp := cmd.Execute(context.TODO(), storage)
+	assert.Equal(t, protocol.NewSuccessResponse(protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewNullBulkStringProtocolValue(),
+		protocol.NewNumberProtocolValue(3),
+	})), resp)
+	assert.Equal(t, protocol.NewErrorResponse(assert.AnError), cmd.Execute(context.TODO(), storage))
+}
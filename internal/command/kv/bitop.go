@@ -0,0 +1,73 @@
+package kv
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/kv"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errBitOpNot = errors.New("ERR BITOP NOT must be called with a single source key.")
+
+var bitOps = map[string]kv.BitOp{
+	"AND":   kv.BitOpAnd,
+	"OR":    kv.BitOpOr,
+	"XOR":   kv.BitOpXor,
+	"NOT":   kv.BitOpNot,
+	"DIFF":  kv.BitOpDiff,
+	"ANDOR": kv.BitOpAndOr,
+	"ONE":   kv.BitOpOne,
+}
+
+// BitOp stores the result of a bitwise operation on string values and replies its length.
+type BitOp struct {
+	Op          kv.BitOp
+	Destination string
+	Sources     []string
+}
+
+func (b *BitOp) DenyOOM() {}
+
+func (b *BitOp) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	length, err := storage.KV().BitOp(ctx, b.Op, b.Destination, b.Sources)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(length)
+}
+
+type BitOpParser struct{}
+
+func NewBitOpParser() *BitOpParser {
+	return &BitOpParser{}
+}
+
+// Parse accepts BITOP operation destkey key [key ...], NOT taking a single source key and DIFF
+// and ANDOR at least two.
+func (p *BitOpParser) Parse(msg *protocol.Message) (command.Command, error) {
+	args := msg.Args
+	if len(args) < 3 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 3, len(args))
+	}
+	name := strings.ToUpper(args[0])
+	op, ok := bitOps[name]
+	if !ok {
+		return nil, command.ErrSyntax
+	}
+	sources := args[2:]
+	switch {
+	case op == kv.BitOpNot && len(sources) != 1:
+		return nil, errBitOpNot
+	case (op == kv.BitOpDiff || op == kv.BitOpAndOr) && len(sources) < 2:
+		return nil, fmt.Errorf("ERR BITOP %s must be called with at least two source keys.", name)
+	}
+	return &BitOp{Op: op, Destination: args[1], Sources: sources}, nil
+}
+
+func (p *BitOpParser) Name() string {
+	return "BITOP"
+}
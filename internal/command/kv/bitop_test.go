@@ -0,0 +1,52 @@
+package kv
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage/kv"
+	mockkv "avacado/internal/storage/kv/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBitOpParser_Parse(t *testing.T) {
+	p := NewBitOpParser()
+	cmd, err := p.Parse(&protocol.Message{Command: "BITOP", Args: []string{"and", "dest", "a", "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &BitOp{Op: kv.BitOpAnd, Destination: "dest", Sources: []string{"a", "b"}}, cmd)
+
+	cmd, err = p.Parse(&protocol.Message{Command: "BITOP", Args: []string{"ONE", "dest", "a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &BitOp{Op: kv.BitOpOne, Destination: "dest", Sources: []string{"a"}}, cmd)
+}
+
+func TestBitOpParser_ParseInvalidArguments(t *testing.T) {
+	p := NewBitOpParser()
+	_, err := p.Parse(&protocol.Message{Command: "BITOP", Args: []string{"NAND", "dest", "a"}})
+	assert.Equal(t, command.ErrSyntax, err)
+	_, err = p.Parse(&protocol.Message{Command: "BITOP", Args: []string{"NOT", "dest", "a", "b"}})
+	assert.Equal(t, errBitOpNot, err)
+	_, err = p.Parse(&protocol.Message{Command: "BITOP", Args: []string{"diff", "dest", "a"}})
+	assert.EqualError(t, err, "ERR BITOP DIFF must be called with at least two source keys.")
+	_, err = p.Parse(&protocol.Message{Command: "BITOP", Args: []string{"ANDOR", "dest", "a"}})
+	assert.EqualError(t, err, "ERR BITOP ANDOR must be called with at least two source keys.")
+	_, err = p.Parse(&protocol.Message{Command: "BITOP", Args: []string{"AND", "dest"}})
+	assert.Error(t, err)
+}
+
+func TestBitOp_Execute(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+	store := mockkv.NewMockStore(ctr)
+	storage.EXPECT().KV().Return(store).Times(2)
+	store.EXPECT().BitOp(gomock.Any(), kv.BitOpXor, "dest", []string{"a", "b"}).Return(int64(6), nil)
+	store.EXPECT().BitOp(gomock.Any(), kv.BitOpXor, "dest", []string{"a", "b"}).Return(int64(0), assert.AnError)
+
+	cmd := &BitOp{Op: kv.BitOpXor, Destination: "dest", Sources: []string{"a", "b"}}
+	assert.Equal(t, protocol.NewNumberResponse(6), cmd.Execute(context.TODO(), storage))
+	assert.Equal(t, protocol.NewErrorResponse(assert.AnError), cmd.Execute(context.TODO(), storage))
+}
@@ -0,0 +1,70 @@
+package kv
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/kv"
+	"context"
+	"errors"
+	"strconv"
+)
+
+var errBitPosBit = errors.New("ERR The bit argument must be 1 or 0.")
+
+// BitPos replies the position of the first bit of a string value set or clear, within a range
+// of bytes or bits, or -1 if there is none.
+type BitPos struct {
+	Key   string
+	Bit   byte
+	Range kv.BitRange
+}
+
+func (b *BitPos) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	pos, err := storage.KV().BitPos(ctx, b.Key, b.Bit, b.Range)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(pos)
+}
+
+type BitPosParser struct{}
+
+func NewBitPosParser() *BitPosParser {
+	return &BitPosParser{}
+}
+
+// Parse accepts BITPOS key bit [start [end [BYTE|BIT]]], looking up to the end of the value
+// without an end.
+func (p *BitPosParser) Parse(msg *protocol.Message) (command.Command, error) {
+	args := msg.Args
+	if len(args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(args))
+	}
+	if len(args) > 5 {
+		return nil, command.ErrSyntax
+	}
+	bit, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return nil, command.ErrNotInteger
+	}
+	if bit != 0 && bit != 1 {
+		return nil, errBitPosBit
+	}
+	cmd := &BitPos{Key: args[0], Bit: byte(bit), Range: kv.BitRange{Start: 0, End: -1, NoEnd: true}}
+	switch len(args) {
+	case 3:
+		if cmd.Range.Start, err = strconv.ParseInt(args[2], 10, 64); err != nil {
+			return nil, command.ErrNotInteger
+		}
+	case 4, 5:
+		if cmd.Range, err = parseBitRange(args[2], args[3], args[4:]...); err != nil {
+			return nil, err
+		}
+	}
+	return cmd, nil
+}
+
+func (p *BitPosParser) Name() string {
+	return "BITPOS"
+}
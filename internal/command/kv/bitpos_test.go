@@ -0,0 +1,65 @@
+package kv
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage/kv"
+	mockkv "avacado/internal/storage/kv/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBitPosParser_Parse(t *testing.T) {
+	p := NewBitPosParser()
+	for _, tc := range []struct {
+		args []string
+		bit  byte
+		r    kv.BitRange
+	}{
+		{[]string{"mykey", "0"}, 0, kv.BitRange{Start: 0, End: -1, NoEnd: true}},
+		{[]string{"mykey", "1", "2"}, 1, kv.BitRange{Start: 2, End: -1, NoEnd: true}},
+		{[]string{"mykey", "1", "2", "-1"}, 1, kv.BitRange{Start: 2, End: -1}},
+		{[]string{"mykey", "0", "7", "15", "bit"}, 0, kv.BitRange{Start: 7, End: 15, Bits: true}},
+	} {
+		cmd, err := p.Parse(&protocol.Message{Command: "BITPOS", Args: tc.args})
+		assert.NoError(t, err)
+		assert.Equal(t, &BitPos{Key: "mykey", Bit: tc.bit, Range: tc.r}, cmd, tc.args)
+	}
+}
+
+func TestBitPosParser_ParseInvalidArguments(t *testing.T) {
+	p := NewBitPosParser()
+	for _, tc := range []struct {
+		args []string
+		err  error
+	}{
+		{[]string{"mykey", "2"}, errBitPosBit},
+		{[]string{"mykey", "x"}, command.ErrNotInteger},
+		{[]string{"mykey", "1", "x"}, command.ErrNotInteger},
+		{[]string{"mykey", "1", "0", "1", "WORD"}, command.ErrSyntax},
+		{[]string{"mykey", "1", "0", "1", "BIT", "x"}, command.ErrSyntax},
+	} {
+		_, err := p.Parse(&protocol.Message{Command: "BITPOS", Args: tc.args})
+		assert.Equal(t, tc.err, err, tc.args)
+	}
+	_, err := p.Parse(&protocol.Message{Command: "BITPOS", Args: []string{"mykey"}})
+	assert.Error(t, err)
+}
+
+func TestBitPos_Execute(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+	store := mockkv.NewMockStore(ctr)
+	storage.EXPECT().KV().Return(store).Times(2)
+	r := kv.BitRange{Start: 0, End: -1, NoEnd: true}
+	store.EXPECT().BitPos(gomock.Any(), "mykey", byte(1), r).Return(int64(12), nil)
+	store.EXPECT().BitPos(gomock.Any(), "mykey", byte(1), r).Return(int64(0), assert.AnError)
+
+	cmd := &BitPos{Key: "mykey", Bit: 1, Range: r}
+	assert.Equal(t, protocol.NewNumberResponse(12), cmd.Execute(context.TODO(), storage))
+	assert.Equal(t, protocol.NewErrorResponse(assert.AnError), cmd.Execute(context.TODO(), storage))
+}
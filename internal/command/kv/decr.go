@@ -15,6 +15,8 @@ type Decr struct {
 	Key string
 }
 
+func (d *Decr) DenyOOM() {}
+
 func (d *Decr) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	v, err := storage.KV().Decr(ctx, d.Key)
 	if err != nil {
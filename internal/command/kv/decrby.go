@@ -17,6 +17,8 @@ type DecrBy struct {
 	Decrement int64
 }
 
+func (d *DecrBy) DenyOOM() {}
+
 func (d *DecrBy) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	v, err := storage.KV().DecrBy(ctx, d.Key, d.Decrement)
 	if err != nil {
@@ -15,7 +15,7 @@ type Del struct {
 }
 
 func (d *Del) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
-	count, err := storage.KV().Del(ctx, d.Keys...)
+	count, err := storage.Keyspace().Del(ctx, d.Keys...)
 	if err != nil {
 		return protocol.NewErrorResponse(err)
 	}
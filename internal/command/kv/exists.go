@@ -15,7 +15,7 @@ type Exists struct {
 }
 
 func (e *Exists) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
-	count, err := storage.KV().Exists(ctx, e.Keys...)
+	count, err := storage.Keyspace().Exists(ctx, e.Keys...)
 	if err != nil {
 		return protocol.NewErrorResponse(err)
 	}
@@ -2,7 +2,7 @@ package kv
 
 import (
 	"avacado/internal/protocol"
-	mockkv "avacado/internal/storage/kv/mock"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
 	mocksstorage "avacado/internal/storage/mock"
 	"context"
 	"testing"
@@ -36,10 +36,10 @@ func TestExistsParser_ParseMultipleKeys(t *testing.T) {
 func TestExists_Execute(t *testing.T) {
 	ctr := gomock.NewController(t)
 	storage := mocksstorage.NewMockStorage(ctr)
-	store := mockkv.NewMockStore(ctr)
+	keyspace := mockkeyspace.NewMockKeyspace(ctr)
 
-	storage.EXPECT().KV().Return(store)
-	store.EXPECT().Exists(gomock.Any(), "key1", "key2").Return(int64(2), nil)
+	storage.EXPECT().Keyspace().Return(keyspace)
+	keyspace.EXPECT().Exists(gomock.Any(), "key1", "key2").Return(int64(2), nil)
 
 	cmd := &Exists{Keys: []string{"key1", "key2"}}
 	resp := cmd.Execute(context.TODO(), storage)
@@ -49,10 +49,10 @@ func TestExists_Execute(t *testing.T) {
 func TestExists_ExecuteHandlesError(t *testing.T) {
 	ctr := gomock.NewController(t)
 	storage := mocksstorage.NewMockStorage(ctr)
-	store := mockkv.NewMockStore(ctr)
+	keyspace := mockkeyspace.NewMockKeyspace(ctr)
 
-	storage.EXPECT().KV().Return(store)
-	store.EXPECT().Exists(gomock.Any(), "key1").Return(int64(0), assert.AnError)
+	storage.EXPECT().Keyspace().Return(keyspace)
+	keyspace.EXPECT().Exists(gomock.Any(), "key1").Return(int64(0), assert.AnError)
 
 	cmd := &Exists{Keys: []string{"key1"}}
 	resp := cmd.Execute(context.TODO(), storage)
@@ -0,0 +1,163 @@
+package expiry
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/keyspace"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expire sets the expiry of a key of any type, either relative to now (EXPIRE, PEXPIRE)
+// or as an absolute Unix time (EXPIREAT, PEXPIREAT).
+type Expire struct {
+	Name      string
+	Key       string
+	Amount    int64
+	Unit      time.Duration
+	Absolute  bool
+	Condition keyspace.ExpireCondition
+}
+
+func (e *Expire) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	at, ok := e.expireAt()
+	if !ok {
+		return protocol.NewErrorResponse(fmt.Errorf("ERR invalid expire time in '%s' command", strings.ToLower(e.Name)))
+	}
+	changed, err := storage.Keyspace().Expire(ctx, e.Key, at, e.Condition)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if changed {
+		return protocol.NewNumberResponse(1)
+	}
+	return protocol.NewNumberResponse(0)
+}
+
+// expireAt converts Amount to an absolute time, reporting false if it overflows a Unix time in milliseconds.
+func (e *Expire) expireAt() (time.Time, bool) {
+	millis := e.Amount
+	factor := int64(e.Unit / time.Millisecond)
+	if millis > math.MaxInt64/factor || millis < math.MinInt64/factor {
+		return time.Time{}, false
+	}
+	millis *= factor
+	if !e.Absolute {
+		now := time.Now().UnixMilli()
+		if millis > math.MaxInt64-now {
+			return time.Time{}, false
+		}
+		millis += now
+	}
+	return time.UnixMilli(millis), true
+}
+
+func parseExpire(name string, unit time.Duration, absolute bool, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(name, 2, len(msg.Args))
+	}
+	amount, err := strconv.ParseInt(msg.Args[1], 10, 64)
+	if err != nil {
+		return nil, command.ErrNotInteger
+	}
+	condition, err := parseExpireCondition(msg.Args[2:])
+	if err != nil {
+		return nil, err
+	}
+	return &Expire{
+		Name:      name,
+		Key:       msg.Args[0],
+		Amount:    amount,
+		Unit:      unit,
+		Absolute:  absolute,
+		Condition: condition,
+	}, nil
+}
+
+// parseExpireCondition parses the NX, XX, GT and LT flags. XX can be combined with GT or LT,
+// every other combination is rejected the way Redis does.
+func parseExpireCondition(args []string) (keyspace.ExpireCondition, error) {
+	condition := keyspace.ExpireAlways
+	for _, arg := range args {
+		switch strings.ToUpper(arg) {
+		case "NX":
+			condition |= keyspace.ExpireNX
+		case "XX":
+			condition |= keyspace.ExpireXX
+		case "GT":
+			condition |= keyspace.ExpireGT
+		case "LT":
+			condition |= keyspace.ExpireLT
+		default:
+			return 0, fmt.Errorf("ERR Unsupported option %s", arg)
+		}
+	}
+	if condition&keyspace.ExpireNX != 0 && condition != keyspace.ExpireNX {
+		return 0, fmt.Errorf("ERR NX and XX, GT or LT options at the same time are not compatible")
+	}
+	if condition&keyspace.ExpireGT != 0 && condition&keyspace.ExpireLT != 0 {
+		return 0, fmt.Errorf("ERR GT and LT options at the same time are not compatible")
+	}
+	return condition, nil
+}
+
+type ExpireParser struct{}
+
+func NewExpireParser() *ExpireParser {
+	return &ExpireParser{}
+}
+
+func (e *ExpireParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseExpire(e.Name(), time.Second, false, msg)
+}
+
+func (e *ExpireParser) Name() string {
+	return "EXPIRE"
+}
+
+type PExpireParser struct{}
+
+func NewPExpireParser() *PExpireParser {
+	return &PExpireParser{}
+}
+
+func (p *PExpireParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseExpire(p.Name(), time.Millisecond, false, msg)
+}
+
+func (p *PExpireParser) Name() string {
+	return "PEXPIRE"
+}
+
+type ExpireAtParser struct{}
+
+func NewExpireAtParser() *ExpireAtParser {
+	return &ExpireAtParser{}
+}
+
+func (e *ExpireAtParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseExpire(e.Name(), time.Second, true, msg)
+}
+
+func (e *ExpireAtParser) Name() string {
+	return "EXPIREAT"
+}
+
+type PExpireAtParser struct{}
+
+func NewPExpireAtParser() *PExpireAtParser {
+	return &PExpireAtParser{}
+}
+
+func (p *PExpireAtParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseExpire(p.Name(), time.Millisecond, true, msg)
+}
+
+func (p *PExpireAtParser) Name() string {
+	return "PEXPIREAT"
+}
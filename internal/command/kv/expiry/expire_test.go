@@ -0,0 +1,105 @@
+package expiry
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/keyspace"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExpireParser_Parse(t *testing.T) {
+	parser := NewExpireParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "EXPIRE", Args: []string{"key", "10"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Expire{
+		Name:      "EXPIRE",
+		Key:       "key",
+		Amount:    10,
+		Unit:      time.Second,
+		Condition: keyspace.ExpireAlways,
+	}, cmd)
+}
+
+func TestPExpireAtParser_Parse(t *testing.T) {
+	parser := NewPExpireAtParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "PEXPIREAT", Args: []string{"key", "1700000000000", "xx", "LT"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Expire{
+		Name:      "PEXPIREAT",
+		Key:       "key",
+		Amount:    1700000000000,
+		Unit:      time.Millisecond,
+		Absolute:  true,
+		Condition: keyspace.ExpireXX | keyspace.ExpireLT,
+	}, cmd)
+}
+
+func TestExpireParser_ParseErrors(t *testing.T) {
+	parser := NewExpireParser()
+
+	_, err := parser.Parse(&protocol.Message{Command: "EXPIRE", Args: []string{"key"}})
+	assert.Error(t, err)
+
+	_, err = parser.Parse(&protocol.Message{Command: "EXPIRE", Args: []string{"key", "ten"}})
+	assert.EqualError(t, err, "ERR value is not an integer or out of range")
+
+	_, err = parser.Parse(&protocol.Message{Command: "EXPIRE", Args: []string{"key", "10", "NX", "GT"}})
+	assert.EqualError(t, err, "ERR NX and XX, GT or LT options at the same time are not compatible")
+
+	_, err = parser.Parse(&protocol.Message{Command: "EXPIRE", Args: []string{"key", "10", "GT", "LT"}})
+	assert.EqualError(t, err, "ERR GT and LT options at the same time are not compatible")
+
+	_, err = parser.Parse(&protocol.Message{Command: "EXPIRE", Args: []string{"key", "10", "KEEP"}})
+	assert.EqualError(t, err, "ERR Unsupported option KEEP")
+}
+
+func TestExpire_ExecuteRelative(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+
+	storage.EXPECT().Keyspace().Return(ks)
+	before := time.Now()
+	ks.EXPECT().Expire(gomock.Any(), "key", gomock.Any(), keyspace.ExpireNX).
+		DoAndReturn(func(_ context.Context, _ string, at time.Time, _ keyspace.ExpireCondition) (bool, error) {
+			assert.WithinDuration(t, before.Add(10*time.Second), at, time.Second)
+			return true, nil
+		})
+
+	cmd := &Expire{Name: "EXPIRE", Key: "key", Amount: 10, Unit: time.Second, Condition: keyspace.ExpireNX}
+	response := cmd.Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(1), response)
+}
+
+func TestExpire_ExecuteAbsolute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+
+	storage.EXPECT().Keyspace().Return(ks)
+	ks.EXPECT().Expire(gomock.Any(), "key", time.UnixMilli(1700000000000), keyspace.ExpireAlways).Return(false, nil)
+
+	cmd := &Expire{Name: "EXPIREAT", Key: "key", Amount: 1700000000, Unit: time.Second, Absolute: true}
+	response := cmd.Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(0), response)
+}
+
+func TestExpire_ExecuteOverflow(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+
+	cmd := &Expire{Name: "EXPIRE", Key: "key", Amount: math.MaxInt64 / 100, Unit: time.Second}
+	response := cmd.Execute(context.Background(), storage)
+	assert.EqualError(t, response.Err, "ERR invalid expire time in 'expire' command")
+
+	cmd = &Expire{Name: "PEXPIRE", Key: "key", Amount: math.MaxInt64 - 1, Unit: time.Millisecond}
+	response = cmd.Execute(context.Background(), storage)
+	assert.EqualError(t, response.Err, "ERR invalid expire time in 'pexpire' command")
+}
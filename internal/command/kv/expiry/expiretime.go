@@ -0,0 +1,60 @@
+package expiry
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// ExpireTime returns the absolute Unix time at which a key expires, in seconds or in milliseconds
+type ExpireTime struct {
+	Key    string
+	Millis bool
+}
+
+func (e *ExpireTime) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	expireTime, err := storage.Keyspace().ExpireTime(ctx, e.Key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if expireTime < 0 || e.Millis {
+		return protocol.NewNumberResponse(expireTime)
+	}
+	return protocol.NewNumberResponse(expireTime / 1000)
+}
+
+func parseExpireTime(name string, millis bool, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 1 {
+		return nil, command.NewInvalidArgumentsCount(name, 1, len(msg.Args))
+	}
+	return &ExpireTime{Key: msg.Args[0], Millis: millis}, nil
+}
+
+type ExpireTimeParser struct{}
+
+func NewExpireTimeParser() *ExpireTimeParser {
+	return &ExpireTimeParser{}
+}
+
+func (e *ExpireTimeParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseExpireTime(e.Name(), false, msg)
+}
+
+func (e *ExpireTimeParser) Name() string {
+	return "EXPIRETIME"
+}
+
+type PExpireTimeParser struct{}
+
+func NewPExpireTimeParser() *PExpireTimeParser {
+	return &PExpireTimeParser{}
+}
+
+func (p *PExpireTimeParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseExpireTime(p.Name(), true, msg)
+}
+
+func (p *PExpireTimeParser) Name() string {
+	return "PEXPIRETIME"
+}
@@ -0,0 +1,45 @@
+package expiry
+
+import (
+	"avacado/internal/protocol"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExpireTime_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+
+	storage.EXPECT().Keyspace().Return(ks).AnyTimes()
+	ks.EXPECT().ExpireTime(gomock.Any(), "key").Return(int64(1700000000999), nil).Times(2)
+	ks.EXPECT().ExpireTime(gomock.Any(), "persistent").Return(int64(-1), nil)
+	ks.EXPECT().ExpireTime(gomock.Any(), "missing").Return(int64(-2), nil)
+
+	response := (&ExpireTime{Key: "key"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(1700000000), response)
+
+	response = (&ExpireTime{Key: "key", Millis: true}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(1700000000999), response)
+
+	response = (&ExpireTime{Key: "persistent"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(-1), response)
+
+	response = (&ExpireTime{Key: "missing"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(-2), response)
+}
+
+func TestPExpireTimeParser_Parse(t *testing.T) {
+	parser := NewPExpireTimeParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "PEXPIRETIME", Args: []string{"key"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ExpireTime{Key: "key", Millis: true}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "PEXPIRETIME", Args: []string{}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package expiry
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// Persist removes the expiry of a key, turning it back into a persistent key
+type Persist struct {
+	Key string
+}
+
+func (p *Persist) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	removed, err := storage.Keyspace().Persist(ctx, p.Key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if removed {
+		return protocol.NewNumberResponse(1)
+	}
+	return protocol.NewNumberResponse(0)
+}
+
+type PersistParser struct{}
+
+func NewPersistParser() *PersistParser {
+	return &PersistParser{}
+}
+
+func (p *PersistParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	return &Persist{Key: msg.Args[0]}, nil
+}
+
+func (p *PersistParser) Name() string {
+	return "PERSIST"
+}
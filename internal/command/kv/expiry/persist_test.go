@@ -0,0 +1,38 @@
+package expiry
+
+import (
+	"avacado/internal/protocol"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPersist_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+
+	storage.EXPECT().Keyspace().Return(ks).AnyTimes()
+	ks.EXPECT().Persist(gomock.Any(), "volatile").Return(true, nil)
+	ks.EXPECT().Persist(gomock.Any(), "persistent").Return(false, nil)
+
+	response := (&Persist{Key: "volatile"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(1), response)
+
+	response = (&Persist{Key: "persistent"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(0), response)
+}
+
+func TestPersistParser_Parse(t *testing.T) {
+	parser := NewPersistParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "PERSIST", Args: []string{"key"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &Persist{Key: "key"}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "PERSIST", Args: []string{"a", "b"}})
+	assert.Error(t, err)
+}
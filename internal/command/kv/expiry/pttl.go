@@ -13,12 +13,9 @@ type PTTL struct {
 }
 
 func (t *PTTL) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
-	ttl, err := storage.KV().GetTTL(t.Key)
+	ttl, err := storage.Keyspace().TTL(ctx, t.Key)
 	if err != nil {
-		return protocol.NewNumberResponse(-2)
-	}
-	if ttl == -1 {
-		return protocol.NewNumberResponse(-1)
+		return protocol.NewErrorResponse(err)
 	}
 	return protocol.NewNumberResponse(ttl)
 }
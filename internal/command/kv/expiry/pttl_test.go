@@ -2,23 +2,23 @@ package expiry
 
 import (
 	"avacado/internal/protocol"
-	"avacado/internal/storage/kv/memory"
-	mockkv "avacado/internal/storage/kv/mock"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
 	mocksstorage "avacado/internal/storage/mock"
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
-func TestPTTL_ExecuteHandlesError(t *testing.T) {
+func TestPTTL_ExecuteMissingKey(t *testing.T) {
 	controller := gomock.NewController(t)
 	storage := mocksstorage.NewMockStorage(controller)
-	store := mockkv.NewMockStore(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
 
-	storage.EXPECT().KV().Return(store)
-	store.EXPECT().GetTTL("key1").Return(int64(-2), memory.NewKeyNotPresentError("key1"))
+	storage.EXPECT().Keyspace().Return(ks)
+	ks.EXPECT().TTL(gomock.Any(), "key1").Return(int64(-2), nil)
 
 	pttl := &PTTL{Key: "key1"}
 	response := pttl.Execute(context.Background(), storage)
@@ -26,13 +26,26 @@ func TestPTTL_ExecuteHandlesError(t *testing.T) {
 	assert.Equal(t, protocol.NewNumberResponse(int64(-2)), response)
 }
 
+func TestPTTL_ExecuteHandlesError(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+
+	storage.EXPECT().Keyspace().Return(ks)
+	ks.EXPECT().TTL(gomock.Any(), "key1").Return(int64(0), errors.New("some error"))
+
+	pttl := &PTTL{Key: "key1"}
+	response := pttl.Execute(context.Background(), storage)
+	assert.EqualError(t, response.Err, "some error")
+}
+
 func TestPTTL_ExecuteHandlesNegativeTTL(t *testing.T) {
 	controller := gomock.NewController(t)
 	storage := mocksstorage.NewMockStorage(controller)
-	store := mockkv.NewMockStore(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
 
-	storage.EXPECT().KV().Return(store)
-	store.EXPECT().GetTTL("key1").Return(int64(-1), nil)
+	storage.EXPECT().Keyspace().Return(ks)
+	ks.EXPECT().TTL(gomock.Any(), "key1").Return(int64(-1), nil)
 
 	pttl := &PTTL{Key: "key1"}
 	response := pttl.Execute(context.Background(), storage)
@@ -43,17 +56,17 @@ func TestPTTL_ExecuteHandlesNegativeTTL(t *testing.T) {
 func TestPTTL_ExecutePositiveTTL(t *testing.T) {
 	controller := gomock.NewController(t)
 	storage := mocksstorage.NewMockStorage(controller)
-	store := mockkv.NewMockStore(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
 
-	storage.EXPECT().KV().Return(store).AnyTimes()
-	store.EXPECT().GetTTL("key1").Return(int64(1800), nil)
+	storage.EXPECT().Keyspace().Return(ks).AnyTimes()
+	ks.EXPECT().TTL(gomock.Any(), "key1").Return(int64(1800), nil)
 
 	pttl := &PTTL{Key: "key1"}
 	response := pttl.Execute(context.Background(), storage)
 	assert.NoError(t, response.Err)
 	assert.Equal(t, protocol.NewNumberResponse(int64(1800)), response)
 
-	store.EXPECT().GetTTL("key2").Return(int64(800), nil)
+	ks.EXPECT().TTL(gomock.Any(), "key2").Return(int64(800), nil)
 	pttl2 := &PTTL{Key: "key2"}
 	response2 := pttl2.Execute(context.Background(), storage)
 	assert.NoError(t, response2.Err)
@@ -13,12 +13,12 @@ type TTL struct {
 }
 
 func (t *TTL) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
-	ttl, err := storage.KV().GetTTL(t.Key)
+	ttl, err := storage.Keyspace().TTL(ctx, t.Key)
 	if err != nil {
-		return protocol.NewNumberResponse(-2)
+		return protocol.NewErrorResponse(err)
 	}
-	if ttl == -1 {
-		return protocol.NewNumberResponse(-1)
+	if ttl < 0 {
+		return protocol.NewNumberResponse(ttl)
 	}
 	ttlInSeconds := ttl / 1000
 	return protocol.NewNumberResponse(ttlInSeconds)
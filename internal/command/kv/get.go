@@ -13,7 +13,10 @@ type Get struct {
 
 func (g *Get) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	data, err := storage.KV().Get(ctx, g.key)
-	if err != nil || data == nil {
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if data == nil {
 		return protocol.NewNullBulkStringResponse()
 	}
 	return protocol.NewBulkStringResponse(data)
@@ -55,8 +55,7 @@ func TestGetCommand_ExecuteForKeyError(t *testing.T) {
 	kv.EXPECT().Get(ctx, "key1").Return(nil, fmt.Errorf("some error"))
 
 	response := command.Execute(ctx, storage)
-	assert.Nil(t, response.Err)
-	assert.True(t, response.Value.Null)
+	assert.EqualError(t, response.Err, "some error")
 }
 
 func TestGetParser_Parse(t *testing.T) {
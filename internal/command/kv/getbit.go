@@ -0,0 +1,42 @@
+package kv
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type GetBit struct {
+	Key    string
+	Offset int64
+}
+
+func (g *GetBit) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	bit, err := storage.KV().GetBit(ctx, g.Key, g.Offset)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(bit))
+}
+
+type GetBitParser struct{}
+
+func NewGetBitParser() *GetBitParser {
+	return &GetBitParser{}
+}
+
+func (p *GetBitParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	offset, err := parseBitOffset(msg.Args[1])
+	if err != nil {
+		return nil, err
+	}
+	return &GetBit{Key: msg.Args[0], Offset: offset}, nil
+}
+
+func (p *GetBitParser) Name() string {
+	return "GETBIT"
+}
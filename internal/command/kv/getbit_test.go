@@ -0,0 +1,37 @@
+package kv
+
+import (
+	"avacado/internal/protocol"
+	mockkv "avacado/internal/storage/kv/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetBitParser_Parse(t *testing.T) {
+	p := NewGetBitParser()
+	cmd, err := p.Parse(&protocol.Message{Command: "GETBIT", Args: []string{"mykey", "7"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &GetBit{Key: "mykey", Offset: 7}, cmd)
+
+	_, err = p.Parse(&protocol.Message{Command: "GETBIT", Args: []string{"mykey", "-1"}})
+	assert.Equal(t, errBitOffset, err)
+	_, err = p.Parse(&protocol.Message{Command: "GETBIT", Args: []string{"mykey"}})
+	assert.Error(t, err)
+}
+
+func TestGetBit_Execute(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+	store := mockkv.NewMockStore(ctr)
+	storage.EXPECT().KV().Return(store).Times(2)
+	store.EXPECT().GetBit(gomock.Any(), "mykey", int64(7)).Return(byte(1), nil)
+	store.EXPECT().GetBit(gomock.Any(), "mykey", int64(7)).Return(byte(0), assert.AnError)
+
+	cmd := &GetBit{Key: "mykey", Offset: 7}
+	assert.Equal(t, protocol.NewNumberResponse(1), cmd.Execute(context.TODO(), storage))
+	assert.Equal(t, protocol.NewErrorResponse(assert.AnError), cmd.Execute(context.TODO(), storage))
+}
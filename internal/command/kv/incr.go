@@ -15,6 +15,8 @@ type Incr struct {
 	Key string
 }
 
+func (i *Incr) DenyOOM() {}
+
 func (i *Incr) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	v, err := storage.KV().Incr(ctx, i.Key)
 	if err != nil {
@@ -4,11 +4,15 @@ import (
 	"avacado/internal/command"
 	"avacado/internal/protocol"
 	"avacado/internal/storage"
+	"avacado/internal/storage/keyspace"
 	"avacado/internal/storage/kv"
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Set represent a set command containing key and value as arguments
@@ -18,17 +22,23 @@ type Set struct {
 	Options *kv.SetOptions
 }
 
+func (s *Set) DenyOOM() {}
+
 func (s *Set) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	oldValue, err := storage.KV().Set(ctx, s.Key, s.Value, s.Options)
-	if err != nil {
-		return protocol.NewNullBulkStringResponse()
+	if errors.Is(err, keyspace.ErrWrongType) {
+		return protocol.NewErrorResponse(err)
 	}
+	// With GET the old value is the reply whether the NX, XX or IFEQ condition was met or not.
 	if s.Options.Get {
 		if oldValue == nil {
 			return protocol.NewNullBulkStringResponse()
 		}
 		return protocol.NewBulkStringResponse(oldValue)
 	}
+	if err != nil {
+		return protocol.NewNullBulkStringResponse()
+	}
 	return protocol.NewSimpleStringResponse("OK")
 }
 
@@ -40,43 +50,95 @@ func NewSetParser() SetParser {
 	return SetParser{}
 }
 
+// Parse follows Redis: NX, XX and IFEQ exclude each other, as do EX, PX, EXAT, PXAT and KEEPTTL.
+// Repeating the same option is allowed, the last value wins.
 func (s SetParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(s.Name(), 2, len(msg.Args))
+	}
 	cmd := &Set{Key: msg.Args[0], Value: []byte(msg.Args[1])}
 	options := kv.NewSetOptions()
+	condition, expiry := "", ""
 	for i := 2; i < len(msg.Args); i++ {
 		argName := strings.ToUpper(msg.Args[i])
-		if argName == "NX" {
-			options = options.WithNX()
-		}
-		if argName == "XX" {
-			options = options.WithXX()
-		}
-		if argName == "EX" {
-			if i+1 >= len(msg.Args) {
-				return nil, fmt.Errorf("set command: EX option requires a value")
+		switch argName {
+		case "NX", "XX", "IFEQ":
+			if condition != "" && condition != argName {
+				return nil, command.ErrSyntax
 			}
-			i++
-			exSeconds, err := strconv.ParseInt(msg.Args[i], 10, 64)
-			if err != nil {
-				return nil, fmt.Errorf("set command: EX value must be an integer: %w", err)
+			condition = argName
+			switch argName {
+			case "NX":
+				options = options.WithNX()
+			case "XX":
+				options = options.WithXX()
+			default:
+				if i+1 >= len(msg.Args) {
+					return nil, command.ErrSyntax
+				}
+				i++
+				options = options.WithIFEQ([]byte(msg.Args[i]))
 			}
-			options = options.WithEX(exSeconds)
-		}
-		if argName == "GET" {
+		case "GET":
 			options = options.WithGet()
-		}
-		if argName == "IFEQ" {
+		case "KEEPTTL", "EX", "PX", "EXAT", "PXAT":
+			if expiry != "" && expiry != argName {
+				return nil, command.ErrSyntax
+			}
+			expiry = argName
+			if argName == "KEEPTTL" {
+				options = options.WithKeepTTL()
+				continue
+			}
 			if i+1 >= len(msg.Args) {
-				return nil, fmt.Errorf("set command: IFEQ option requires a value")
+				return nil, command.ErrSyntax
 			}
 			i++
-			options = options.WithIFEQ([]byte(msg.Args[i]))
+			if err := parseSetExpiry(options, argName, msg.Args[i]); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, command.ErrSyntax
 		}
 	}
 	cmd.Options = options
 	return cmd, nil
 }
 
+// parseSetExpiry validates the value of an EX, PX, EXAT or PXAT option and records it in options.
+// The expiry must be positive and must not overflow a Unix time in milliseconds.
+func parseSetExpiry(options *kv.SetOptions, option string, arg string) error {
+	amount, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return command.ErrNotInteger
+	}
+	invalid := fmt.Errorf("ERR invalid expire time in 'set' command")
+	if amount <= 0 {
+		return invalid
+	}
+	millis, relative := amount, option == "EX" || option == "PX"
+	if option == "EX" || option == "EXAT" {
+		if amount > math.MaxInt64/1000 {
+			return invalid
+		}
+		millis = amount * 1000
+	}
+	if relative && millis > math.MaxInt64-time.Now().UnixMilli() {
+		return invalid
+	}
+	switch option {
+	case "EX":
+		options.WithEX(amount)
+	case "PX":
+		options.WithPX(amount)
+	case "EXAT":
+		options.WithEXAT(amount)
+	case "PXAT":
+		options.WithPXAT(amount)
+	}
+	return nil
+}
+
 func (s SetParser) Name() string {
 	return "SET"
 }
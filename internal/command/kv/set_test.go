@@ -71,8 +71,7 @@ func TestSetParser_WithEXOptionMissingValue(t *testing.T) {
 	}
 	parser := NewSetParser()
 	_, err := parser.Parse(msg)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "EX option requires a value")
+	assert.EqualError(t, err, "ERR syntax error")
 }
 
 func TestSetParser_WithEXOptionInvalidValue(t *testing.T) {
@@ -82,8 +81,7 @@ func TestSetParser_WithEXOptionInvalidValue(t *testing.T) {
 	}
 	parser := NewSetParser()
 	_, err := parser.Parse(msg)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "EX value must be an integer")
+	assert.EqualError(t, err, "ERR value is not an integer or out of range")
 }
 
 func TestSetParser_WithMultipleOptions(t *testing.T) {
@@ -130,9 +128,70 @@ func TestSet_ExecuteWithError(t *testing.T) {
 	kv.EXPECT().Set(ctx, "key", value, gomock.Any()).Return(nil, fmt.Errorf("some error"))
 
 	command := &Set{
-		Key:   "key",
-		Value: value,
+		Key:     "key",
+		Value:   value,
+		Options: kv2.NewSetOptions(),
 	}
 	response := command.Execute(ctx, storage)
 	assert.Equal(t, protocol.NewNullBulkStringResponse(), response)
 }
+
+func TestSet_ExecuteWithGetRepliesOldValueWhenConditionFails(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	kv := mockkv.NewMockStore(controller)
+	storage.EXPECT().KV().Return(kv)
+
+	ctx := context.Background()
+	options := kv2.NewSetOptions().WithNX().WithGet()
+	kv.EXPECT().Set(ctx, "key", []byte("value"), options).Return([]byte("old"), fmt.Errorf("key exists"))
+
+	command := &Set{Key: "key", Value: []byte("value"), Options: options}
+	response := command.Execute(ctx, storage)
+	assert.Equal(t, protocol.NewBulkStringResponse([]byte("old")), response)
+}
+
+func TestSetParser_WithExpiryOptions(t *testing.T) {
+	parser := NewSetParser()
+	tests := []struct {
+		args     []string
+		expected *kv2.SetOptions
+	}{
+		{[]string{"PX", "1500"}, kv2.NewSetOptions().WithPX(1500)},
+		{[]string{"exat", "1700000000"}, kv2.NewSetOptions().WithEXAT(1700000000)},
+		{[]string{"PXAT", "1700000000000", "GET"}, kv2.NewSetOptions().WithPXAT(1700000000000).WithGet()},
+		{[]string{"XX", "KEEPTTL"}, kv2.NewSetOptions().WithXX().WithKeepTTL()},
+		{[]string{"EX", "10", "EX", "20"}, kv2.NewSetOptions().WithEX(20)},
+	}
+	for _, tt := range tests {
+		command, err := parser.Parse(&protocol.Message{Command: "SET", Args: append([]string{"key", "value"}, tt.args...)})
+		assert.NoError(t, err, tt.args)
+		assert.Equal(t, tt.expected, command.(*Set).Options, tt.args)
+	}
+}
+
+func TestSetParser_RejectsInvalidOptions(t *testing.T) {
+	parser := NewSetParser()
+	tests := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"NX", "XX"}, "ERR syntax error"},
+		{[]string{"XX", "IFEQ", "v"}, "ERR syntax error"},
+		{[]string{"EX", "10", "PX", "100"}, "ERR syntax error"},
+		{[]string{"KEEPTTL", "EXAT", "1700000000"}, "ERR syntax error"},
+		{[]string{"PXAT"}, "ERR syntax error"},
+		{[]string{"UNKNOWN"}, "ERR syntax error"},
+		{[]string{"PX", "0"}, "ERR invalid expire time in 'set' command"},
+		{[]string{"EX", "-5"}, "ERR invalid expire time in 'set' command"},
+		{[]string{"EX", "9223372036854775"}, "ERR invalid expire time in 'set' command"},
+		{[]string{"PX", "1.5"}, "ERR value is not an integer or out of range"},
+	}
+	for _, tt := range tests {
+		_, err := parser.Parse(&protocol.Message{Command: "SET", Args: append([]string{"key", "value"}, tt.args...)})
+		assert.EqualError(t, err, tt.err, tt.args)
+	}
+
+	_, err := parser.Parse(&protocol.Message{Command: "SET", Args: []string{"key"}})
+	assert.Error(t, err)
+}
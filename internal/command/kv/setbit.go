@@ -0,0 +1,69 @@
+package kv
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"errors"
+	"strconv"
+)
+
+// maxBitOffset is the first bit offset out of range, a string value being at most 512MB.
+const maxBitOffset = 512 * 1024 * 1024 * 8
+
+var (
+	errBitOffset = errors.New("ERR bit offset is not an integer or out of range")
+	errBitValue  = errors.New("ERR bit is not an integer or out of range")
+)
+
+// parseBitOffset parses the offset of a bit of a string value.
+func parseBitOffset(arg string) (int64, error) {
+	offset, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || offset < 0 || offset >= maxBitOffset {
+		return 0, errBitOffset
+	}
+	return offset, nil
+}
+
+// SetBit sets or clears the bit at an offset of a string value and replies the bit it replaced.
+type SetBit struct {
+	Key    string
+	Offset int64
+	Bit    byte
+}
+
+func (s *SetBit) DenyOOM() {}
+
+func (s *SetBit) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	old, err := storage.KV().SetBit(ctx, s.Key, s.Offset, s.Bit)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(old))
+}
+
+type SetBitParser struct{}
+
+func NewSetBitParser() *SetBitParser {
+	return &SetBitParser{}
+}
+
+func (p *SetBitParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 3 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 3, len(msg.Args))
+	}
+	offset, err := parseBitOffset(msg.Args[1])
+	if err != nil {
+		return nil, err
+	}
+	bit, err := strconv.ParseInt(msg.Args[2], 10, 64)
+	if err != nil || (bit != 0 && bit != 1) {
+		return nil, errBitValue
+	}
+	return &SetBit{Key: msg.Args[0], Offset: offset, Bit: byte(bit)}, nil
+}
+
+func (p *SetBitParser) Name() string {
+	return "SETBIT"
+}
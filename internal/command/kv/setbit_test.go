@@ -0,0 +1,55 @@
+package kv
+
+import (
+	"avacado/internal/protocol"
+	mockkv "avacado/internal/storage/kv/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetBitParser_Parse(t *testing.T) {
+	p := NewSetBitParser()
+	cmd, err := p.Parse(&protocol.Message{Command: "SETBIT", Args: []string{"mykey", "7", "1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &SetBit{Key: "mykey", Offset: 7, Bit: 1}, cmd)
+
+	cmd, err = p.Parse(&protocol.Message{Command: "SETBIT", Args: []string{"mykey", "4294967295", "0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &SetBit{Key: "mykey", Offset: 4294967295, Bit: 0}, cmd)
+}
+
+func TestSetBitParser_ParseInvalidArguments(t *testing.T) {
+	p := NewSetBitParser()
+	for _, tc := range []struct {
+		args []string
+		err  error
+	}{
+		{[]string{"mykey", "-1", "1"}, errBitOffset},
+		{[]string{"mykey", "4294967296", "1"}, errBitOffset},
+		{[]string{"mykey", "x", "1"}, errBitOffset},
+		{[]string{"mykey", "0", "2"}, errBitValue},
+		{[]string{"mykey", "0", "x"}, errBitValue},
+	} {
+		_, err := p.Parse(&protocol.Message{Command: "SETBIT", Args: tc.args})
+		assert.Equal(t, tc.err, err, tc.args)
+	}
+	_, err := p.Parse(&protocol.Message{Command: "SETBIT", Args: []string{"mykey", "0"}})
+	assert.Error(t, err)
+}
+
+func TestSetBit_Execute(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+	store := mockkv.NewMockStore(ctr)
+	storage.EXPECT().KV().Return(store).Times(2)
+	store.EXPECT().SetBit(gomock.Any(), "mykey", int64(7), byte(1)).Return(byte(1), nil)
+	store.EXPECT().SetBit(gomock.Any(), "mykey", int64(7), byte(1)).Return(byte(0), assert.AnError)
+
+	cmd := &SetBit{Key: "mykey", Offset: 7, Bit: 1}
+	assert.Equal(t, protocol.NewNumberResponse(1), cmd.Execute(context.TODO(), storage))
+	assert.Equal(t, protocol.NewErrorResponse(assert.AnError), cmd.Execute(context.TODO(), storage))
+}
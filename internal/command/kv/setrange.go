@@ -14,6 +14,8 @@ type SetRange struct {
 	Value  []byte
 }
 
+func (s *SetRange) DenyOOM() {}
+
 func (s *SetRange) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	length, err := storage.KV().SetRange(ctx, s.Key, s.Offset, s.Value)
 	if err != nil {
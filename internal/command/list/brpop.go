@@ -4,10 +4,8 @@ import (
 	"avacado/internal/command"
 	"avacado/internal/protocol"
 	"avacado/internal/storage"
-	"avacado/internal/storage/lists"
 	"context"
 	"strconv"
-	"time"
 )
 
 type BRPop struct {
@@ -18,31 +16,31 @@ type BRPop struct {
 func (b *BRPop) Execute(ctx context.Context, s storage.Storage) *protocol.Response {
 	// Try immediate pop from each key in order.
 	for _, key := range b.Keys {
-		vals, _ := s.Lists().RPop(ctx, key, 1)
+		vals, err := s.Lists().RPop(ctx, key, 1)
+		if err != nil {
+			return protocol.NewErrorResponse(err)
+		}
 		if len(vals) > 0 {
 			return protocol.NewArrayResponse([]interface{}{key, vals[0]})
 		}
 	}
 
-	// No immediate data — register as a blocked client via the executor's BlockRegistry.
-	registry, ok := command.BlockRegistryFromContext(ctx)
-	if !ok {
-		return protocol.NewNullBulkStringResponse()
-	}
-	blockCh, cancelFn := registry.RegisterBlockedClient(b.Keys, lists.Right)
+	// No immediate data — block until a push to one of the keys.
+	return command.Block(ctx, b.Keys, b, b.Timeout)
+}
 
-	// Start a timeout goroutine only when a finite timeout is set.
-	if b.Timeout > 0 {
-		go func() {
-			select {
-			case <-time.After(time.Duration(b.Timeout * float64(time.Second))):
-			case <-ctx.Done():
-			}
-			cancelFn()
-		}()
-	}
+// Ready reports whether key holds a list to pop from.
+func (b *BRPop) Ready(ctx context.Context, s storage.Storage, key string) bool {
+	n, err := s.Lists().Len(ctx, key)
+	return err == nil && n > 0
+}
 
-	return &protocol.Response{BlockCh: blockCh}
+func (b *BRPop) Unblock(ctx context.Context, s storage.Storage, key string) *protocol.Response {
+	vals, err := s.Lists().RPop(ctx, key, 1)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewArrayResponse([]interface{}{key, vals[0]})
 }
 
 type BRPopParser struct{}
@@ -4,6 +4,7 @@ import (
 	"avacado/internal/command"
 	"avacado/internal/protocol"
 	"avacado/internal/storage"
+	"avacado/internal/storage/keyspace"
 	"context"
 	"errors"
 	"strconv"
@@ -15,12 +16,10 @@ type LIndex struct {
 }
 
 func (l *LIndex) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
-	val, err := storage.KV().Get(ctx, l.Key)
-	// Element represented by key is not list
-	if err == nil && val != nil {
-		return protocol.NewErrorResponse(errors.New("(error) WRONGTYPE Operation against a key holding the wrong kind of value"))
-	}
 	element, err := storage.Lists().LIndex(ctx, l.Key, l.Index)
+	if errors.Is(err, keyspace.ErrWrongType) {
+		return protocol.NewErrorResponse(err)
+	}
 	if err != nil || element == nil {
 		return protocol.NewNullBulkStringResponse()
 	}
@@ -2,7 +2,7 @@ package list
 
 import (
 	"avacado/internal/protocol"
-	mockkv "avacado/internal/storage/kv/mock"
+	"avacado/internal/storage/keyspace"
 	mocklists "avacado/internal/storage/lists/mock"
 	mocksstorage "avacado/internal/storage/mock"
 	"context"
@@ -19,11 +19,8 @@ func TestLIndexCommand_Execute(t *testing.T) {
 	ctx := context.Background()
 
 	storage := mocksstorage.NewMockStorage(controller)
-	kv := mockkv.NewMockStore(controller)
 	lists := mocklists.NewMockLists(controller)
 
-	storage.EXPECT().KV().Return(kv)
-	kv.EXPECT().Get(ctx, "mylist").Return(nil, fmt.Errorf("key not found"))
 	storage.EXPECT().Lists().Return(lists)
 	lists.EXPECT().LIndex(ctx, "mylist", 1).Return([]byte("value1"), nil)
 
@@ -38,11 +35,8 @@ func TestLIndexCommand_ExecuteNotFound(t *testing.T) {
 	ctx := context.Background()
 
 	storage := mocksstorage.NewMockStorage(controller)
-	kv := mockkv.NewMockStore(controller)
 	lists := mocklists.NewMockLists(controller)
 
-	storage.EXPECT().KV().Return(kv)
-	kv.EXPECT().Get(ctx, "mylist").Return(nil, fmt.Errorf("key not found"))
 	storage.EXPECT().Lists().Return(lists)
 	lists.EXPECT().LIndex(ctx, "mylist", 99).Return(nil, fmt.Errorf("index out of range"))
 
@@ -57,13 +51,13 @@ func TestLIndexCommand_ExecuteWrongType(t *testing.T) {
 	ctx := context.Background()
 
 	storage := mocksstorage.NewMockStorage(controller)
-	kv := mockkv.NewMockStore(controller)
+	lists := mocklists.NewMockLists(controller)
 
-	storage.EXPECT().KV().Return(kv)
-	kv.EXPECT().Get(ctx, "mykey").Return([]byte("stringvalue"), nil)
+	storage.EXPECT().Lists().Return(lists)
+	lists.EXPECT().LIndex(ctx, "mykey", 0).Return(nil, keyspace.ErrWrongType)
 
 	response := cmd.Execute(ctx, storage)
-	assert.NotNil(t, response.Err)
+	assert.ErrorIs(t, response.Err, keyspace.ErrWrongType)
 }
 
 func TestLIndexParser_Parse(t *testing.T) {
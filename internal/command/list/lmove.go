@@ -6,7 +6,6 @@ import (
 	"avacado/internal/storage"
 	"avacado/internal/storage/lists"
 	"context"
-	"fmt"
 	"strings"
 )
 
@@ -17,6 +16,10 @@ type LMove struct {
 	DestinationDirection lists.Direction
 }
 
+func (l *LMove) DenyOOM() {}
+
+func (l *LMove) WrittenKeys() []string { return []string{l.Destination} }
+
 func (l *LMove) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	element, err := storage.Lists().LMove(ctx, l.Source, l.Destination, l.SourceDirection, l.DestinationDirection)
 	if err != nil {
@@ -41,10 +44,10 @@ func (l *LMoveParser) Parse(msg *protocol.Message) (command.Command, error) {
 	srcDir := strings.ToLower(msg.Args[2])
 	dstDir := strings.ToLower(msg.Args[3])
 	if srcDir != lists.Left && srcDir != lists.Right {
-		return nil, fmt.Errorf("ERR syntax error")
+		return nil, command.ErrSyntax
 	}
 	if dstDir != lists.Left && dstDir != lists.Right {
-		return nil, fmt.Errorf("ERR syntax error")
+		return nil, command.ErrSyntax
 	}
 	return &LMove{
 		Source:               msg.Args[0],
@@ -12,7 +12,9 @@ type LPush struct {
 	Values [][]byte
 }
 
-func (l *LPush) PushedKey() string { return l.Key }
+func (l *LPush) WrittenKeys() []string { return []string{l.Key} }
+
+func (l *LPush) DenyOOM() {}
 
 func (l *LPush) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	size, err := storage.Lists().LPush(ctx, l.Key, l.Values...)
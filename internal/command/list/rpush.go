@@ -12,7 +12,9 @@ type RPush struct {
 	Values [][]byte
 }
 
-func (r *RPush) PushedKey() string { return r.Key }
+func (r *RPush) WrittenKeys() []string { return []string{r.Key} }
+
+func (r *RPush) DenyOOM() {}
 
 func (r *RPush) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
 	size, err := storage.Lists().RPush(ctx, r.Key, r.Values...)
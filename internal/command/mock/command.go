@@ -44,18 +44,185 @@ func (m *MockBlockRegistry) EXPECT() *MockBlockRegistryMockRecorder {
 }
 
 // RegisterBlockedClient mocks base method.
-func (m *MockBlockRegistry) RegisterBlockedClient(keys []string, direction string) (<-chan *protocol.Response, context.CancelFunc) {
+func (m *MockBlockRegistry) RegisterBlockedClient(ctx context.Context, keys []string, unblocker command.Unblocker) (<-chan *protocol.Response, context.CancelFunc) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterBlockedClient", keys, direction)
+	ret := m.ctrl.Call(m, "RegisterBlockedClient", ctx, keys, unblocker)
 	ret0, _ := ret[0].(<-chan *protocol.Response)
 	ret1, _ := ret[1].(context.CancelFunc)
 	return ret0, ret1
 }
 
 // RegisterBlockedClient indicates an expected call of RegisterBlockedClient.
-func (mr *MockBlockRegistryMockRecorder) RegisterBlockedClient(keys, direction any) *gomock.Call {
+func (mr *MockBlockRegistryMockRecorder) RegisterBlockedClient(ctx, keys, unblocker any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBlockedClient", reflect.TypeOf((*MockBlockRegistry)(nil).RegisterBlockedClient), keys, direction)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBlockedClient", reflect.TypeOf((*MockBlockRegistry)(nil).RegisterBlockedClient), ctx, keys, unblocker)
+}
+
+// MockUnblocker is a mock of Unblocker interface.
+type MockUnblocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnblockerMockRecorder
+	isgomock struct{}
+}
+
+// MockUnblockerMockRecorder is the mock recorder for MockUnblocker.
+type MockUnblockerMockRecorder struct {
+	mock *MockUnblocker
+}
+
+// NewMockUnblocker creates a new mock instance.
+func NewMockUnblocker(ctrl *gomock.Controller) *MockUnblocker {
+	mock := &MockUnblocker{ctrl: ctrl}
+	mock.recorder = &MockUnblockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnblocker) EXPECT() *MockUnblockerMockRecorder {
+	return m.recorder
+}
+
+// Ready mocks base method.
+func (m *MockUnblocker) Ready(ctx context.Context, arg1 storage.Storage, key string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", ctx, arg1, key)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockUnblockerMockRecorder) Ready(ctx, arg1, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockUnblocker)(nil).Ready), ctx, arg1, key)
+}
+
+// Unblock mocks base method.
+func (m *MockUnblocker) Unblock(ctx context.Context, arg1 storage.Storage, key string) *protocol.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unblock", ctx, arg1, key)
+	ret0, _ := ret[0].(*protocol.Response)
+	return ret0
+}
+
+// Unblock indicates an expected call of Unblock.
+func (mr *MockUnblockerMockRecorder) Unblock(ctx, arg1, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unblock", reflect.TypeOf((*MockUnblocker)(nil).Unblock), ctx, arg1, key)
+}
+
+// MockKeyWriter is a mock of KeyWriter interface.
+type MockKeyWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyWriterMockRecorder is the mock recorder for MockKeyWriter.
+type MockKeyWriterMockRecorder struct {
+	mock *MockKeyWriter
+}
+
+// NewMockKeyWriter creates a new mock instance.
+func NewMockKeyWriter(ctrl *gomock.Controller) *MockKeyWriter {
+	mock := &MockKeyWriter{ctrl: ctrl}
+	mock.recorder = &MockKeyWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyWriter) EXPECT() *MockKeyWriterMockRecorder {
+	return m.recorder
+}
+
+// WrittenKeys mocks base method.
+func (m *MockKeyWriter) WrittenKeys() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WrittenKeys")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// WrittenKeys indicates an expected call of WrittenKeys.
+func (mr *MockKeyWriterMockRecorder) WrittenKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WrittenKeys", reflect.TypeOf((*MockKeyWriter)(nil).WrittenKeys))
+}
+
+// MockDBKeyWriter is a mock of DBKeyWriter interface.
+type MockDBKeyWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDBKeyWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockDBKeyWriterMockRecorder is the mock recorder for MockDBKeyWriter.
+type MockDBKeyWriterMockRecorder struct {
+	mock *MockDBKeyWriter
+}
+
+// NewMockDBKeyWriter creates a new mock instance.
+func NewMockDBKeyWriter(ctrl *gomock.Controller) *MockDBKeyWriter {
+	mock := &MockDBKeyWriter{ctrl: ctrl}
+	mock.recorder = &MockDBKeyWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDBKeyWriter) EXPECT() *MockDBKeyWriterMockRecorder {
+	return m.recorder
+}
+
+// WrittenDBKeys mocks base method.
+func (m *MockDBKeyWriter) WrittenDBKeys() []command.DBKey {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WrittenDBKeys")
+	ret0, _ := ret[0].([]command.DBKey)
+	return ret0
+}
+
+// WrittenDBKeys indicates an expected call of WrittenDBKeys.
+func (mr *MockDBKeyWriterMockRecorder) WrittenDBKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WrittenDBKeys", reflect.TypeOf((*MockDBKeyWriter)(nil).WrittenDBKeys))
+}
+
+// MockDBSwapper is a mock of DBSwapper interface.
+type MockDBSwapper struct {
+	ctrl     *gomock.Controller
+	recorder *MockDBSwapperMockRecorder
+	isgomock struct{}
+}
+
+// MockDBSwapperMockRecorder is the mock recorder for MockDBSwapper.
+type MockDBSwapperMockRecorder struct {
+	mock *MockDBSwapper
+}
+
+// NewMockDBSwapper creates a new mock instance.
+func NewMockDBSwapper(ctrl *gomock.Controller) *MockDBSwapper {
+	mock := &MockDBSwapper{ctrl: ctrl}
+	mock.recorder = &MockDBSwapperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDBSwapper) EXPECT() *MockDBSwapperMockRecorder {
+	return m.recorder
+}
+
+// SwappedDBs mocks base method.
+func (m *MockDBSwapper) SwappedDBs() (int, int) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwappedDBs")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	return ret0, ret1
+}
+
+// SwappedDBs indicates an expected call of SwappedDBs.
+func (mr *MockDBSwapperMockRecorder) SwappedDBs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwappedDBs", reflect.TypeOf((*MockDBSwapper)(nil).SwappedDBs))
 }
 
 // MockCommand is a mock of Command interface.
@@ -96,6 +263,42 @@ func (mr *MockCommandMockRecorder) Execute(ctx, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockCommand)(nil).Execute), ctx, arg1)
 }
 
+// MockDenyOOM is a mock of DenyOOM interface.
+type MockDenyOOM struct {
+	ctrl     *gomock.Controller
+	recorder *MockDenyOOMMockRecorder
+	isgomock struct{}
+}
+
+// MockDenyOOMMockRecorder is the mock recorder for MockDenyOOM.
+type MockDenyOOMMockRecorder struct {
+	mock *MockDenyOOM
+}
+
+// NewMockDenyOOM creates a new mock instance.
+func NewMockDenyOOM(ctrl *gomock.Controller) *MockDenyOOM {
+	mock := &MockDenyOOM{ctrl: ctrl}
+	mock.recorder = &MockDenyOOMMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDenyOOM) EXPECT() *MockDenyOOMMockRecorder {
+	return m.recorder
+}
+
+// DenyOOM mocks base method.
+func (m *MockDenyOOM) DenyOOM() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DenyOOM")
+}
+
+// DenyOOM indicates an expected call of DenyOOM.
+func (mr *MockDenyOOMMockRecorder) DenyOOM() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyOOM", reflect.TypeOf((*MockDenyOOM)(nil).DenyOOM))
+}
+
 // MockParser is a mock of Parser interface.
 type MockParser struct {
 	ctrl     *gomock.Controller
@@ -4,10 +4,17 @@ import (
 	"avacado/internal/command"
 	"avacado/internal/command/connection"
 	"avacado/internal/command/connection/client"
+	"avacado/internal/command/generic"
+	"avacado/internal/command/geo"
 	"avacado/internal/command/hashmap"
+	"avacado/internal/command/hyperloglog"
 	"avacado/internal/command/kv"
 	"avacado/internal/command/kv/expiry"
 	"avacado/internal/command/list"
+	"avacado/internal/command/server"
+	"avacado/internal/command/set"
+	"avacado/internal/command/stream"
+	"avacado/internal/command/zset"
 	"avacado/internal/protocol"
 	"strings"
 )
@@ -26,9 +33,33 @@ func SetupDefaultParserRegistry() *DefaultParserRegistry {
 	registry.Register(kv.NewGetParser())
 	registry.Register(expiry.NewTTLParser())
 	registry.Register(expiry.NewPTTLParser())
+	registry.Register(expiry.NewExpireParser())
+	registry.Register(expiry.NewPExpireParser())
+	registry.Register(expiry.NewExpireAtParser())
+	registry.Register(expiry.NewPExpireAtParser())
+	registry.Register(expiry.NewExpireTimeParser())
+	registry.Register(expiry.NewPExpireTimeParser())
+	registry.Register(expiry.NewPersistParser())
 	registry.Register(connection.NewHelloParser())
 	registry.Register(connection.NewPingParser())
+	registry.Register(connection.NewSelectParser())
 	registry.Register(client.NewClientParser())
+	registry.Register(server.NewInfoParser())
+	registry.Register(server.NewDBSizeParser())
+	registry.Register(server.NewSwapDBParser())
+	registry.Register(server.NewFlushDBParser())
+	registry.Register(server.NewFlushAllParser())
+	registry.Register(server.NewMemoryParser())
+	registry.Register(server.NewDebugParser())
+	registry.Register(generic.NewTypeParser())
+	registry.Register(generic.NewKeysParser())
+	registry.Register(generic.NewScanParser())
+	registry.Register(generic.NewRandomKeyParser())
+	registry.Register(generic.NewRenameParser())
+	registry.Register(generic.NewRenameNXParser())
+	registry.Register(generic.NewCopyParser())
+	registry.Register(generic.NewMoveParser())
+	registry.Register(generic.NewObjectParser())
 	registry.Register(kv.NewIncrParser())
 	registry.Register(kv.NewDecrParser())
 	registry.Register(kv.NewDecrByParser())
@@ -37,6 +68,13 @@ func SetupDefaultParserRegistry() *DefaultParserRegistry {
 	registry.Register(kv.NewAppendParser())
 	registry.Register(kv.NewStrlenParser())
 	registry.Register(kv.NewSetRangeParser())
+	registry.Register(kv.NewSetBitParser())
+	registry.Register(kv.NewGetBitParser())
+	registry.Register(kv.NewBitCountParser())
+	registry.Register(kv.NewBitPosParser())
+	registry.Register(kv.NewBitOpParser())
+	registry.Register(kv.NewBitFieldParser())
+	registry.Register(kv.NewBitFieldROParser())
 	getRangeParser := kv.NewGetRangeParser()
 	registry.Register(getRangeParser)
 	registry.RegisterAlias("SUBSTR", getRangeParser)
@@ -59,6 +97,87 @@ func SetupDefaultParserRegistry() *DefaultParserRegistry {
 	registry.Register(hashmap.NewHExistsParser())
 	registry.Register(hashmap.NewHIncrByParser())
 	registry.Register(hashmap.NewHMGetParser())
+	registry.Register(hashmap.NewHExpireParser())
+	registry.Register(hashmap.NewHPExpireParser())
+	registry.Register(hashmap.NewHExpireAtParser())
+	registry.Register(hashmap.NewHPExpireAtParser())
+	registry.Register(hashmap.NewHTTLParser())
+	registry.Register(hashmap.NewHPTTLParser())
+	registry.Register(hashmap.NewHExpireTimeParser())
+	registry.Register(hashmap.NewHPExpireTimeParser())
+	registry.Register(hashmap.NewHPersistParser())
+	registry.Register(hashmap.NewHGetDelParser())
+	registry.Register(hashmap.NewHGetExParser())
+	registry.Register(hashmap.NewHSetExParser())
+
+	registry.Register(set.NewSAddParser())
+	registry.Register(set.NewSRemParser())
+	registry.Register(set.NewSMembersParser())
+	registry.Register(set.NewSIsMemberParser())
+	registry.Register(set.NewSMIsMemberParser())
+	registry.Register(set.NewSCardParser())
+	registry.Register(set.NewSPopParser())
+	registry.Register(set.NewSRandMemberParser())
+	registry.Register(set.NewSMoveParser())
+	registry.Register(set.NewSInterParser())
+	registry.Register(set.NewSInterCardParser())
+	registry.Register(set.NewSInterStoreParser())
+	registry.Register(set.NewSUnionParser())
+	registry.Register(set.NewSUnionStoreParser())
+	registry.Register(set.NewSDiffParser())
+	registry.Register(set.NewSDiffStoreParser())
+	registry.Register(zset.NewZAddParser())
+	registry.Register(zset.NewZIncrByParser())
+	registry.Register(zset.NewZCardParser())
+	registry.Register(zset.NewZScoreParser())
+	registry.Register(zset.NewZMScoreParser())
+	registry.Register(zset.NewZRankParser())
+	registry.Register(zset.NewZRevRankParser())
+	registry.Register(zset.NewZRemParser())
+	registry.Register(zset.NewZRangeParser())
+	registry.Register(zset.NewZRangeStoreParser())
+	registry.Register(zset.NewZCountParser())
+	registry.Register(zset.NewZLexCountParser())
+	registry.Register(zset.NewZRemRangeByRankParser())
+	registry.Register(zset.NewZRemRangeByScoreParser())
+	registry.Register(zset.NewZRemRangeByLexParser())
+	registry.Register(zset.NewZInterParser())
+	registry.Register(zset.NewZInterStoreParser())
+	registry.Register(zset.NewZInterCardParser())
+	registry.Register(zset.NewZUnionParser())
+	registry.Register(zset.NewZUnionStoreParser())
+	registry.Register(zset.NewZDiffParser())
+	registry.Register(zset.NewZDiffStoreParser())
+	registry.Register(zset.NewZPopMinParser())
+	registry.Register(zset.NewZPopMaxParser())
+	registry.Register(zset.NewZMPopParser())
+	registry.Register(zset.NewBZPopMinParser())
+	registry.Register(zset.NewBZPopMaxParser())
+	registry.Register(zset.NewBZMPopParser())
+	registry.Register(stream.NewXAddParser())
+	registry.Register(stream.NewXRangeParser())
+	registry.Register(stream.NewXRevRangeParser())
+	registry.Register(stream.NewXLenParser())
+	registry.Register(stream.NewXTrimParser())
+	registry.Register(stream.NewXDelParser())
+	registry.Register(stream.NewXInfoParser())
+	registry.Register(stream.NewXReadParser())
+	registry.Register(stream.NewXGroupParser())
+	registry.Register(stream.NewXReadGroupParser())
+	registry.Register(stream.NewXAckParser())
+	registry.Register(stream.NewXPendingParser())
+	registry.Register(stream.NewXClaimParser())
+	registry.Register(stream.NewXAutoClaimParser())
+	registry.Register(hyperloglog.NewPFAddParser())
+	registry.Register(hyperloglog.NewPFCountParser())
+	registry.Register(hyperloglog.NewPFMergeParser())
+	registry.Register(hyperloglog.NewPFDebugParser())
+	registry.Register(geo.NewGeoAddParser())
+	registry.Register(geo.NewGeoPosParser())
+	registry.Register(geo.NewGeoDistParser())
+	registry.Register(geo.NewGeoHashParser())
+	registry.Register(geo.NewGeoSearchParser())
+	registry.Register(geo.NewGeoSearchStoreParser())
 
 	return registry
 }
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+// DBSize returns the number of keys in the keyspace
+type DBSize struct{}
+
+func (d *DBSize) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	size, err := storage.Keyspace().DBSize(ctx)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(size)
+}
+
+type DBSizeParser struct{}
+
+func NewDBSizeParser() *DBSizeParser {
+	return &DBSizeParser{}
+}
+
+func (d *DBSizeParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 0 {
+		return nil, command.NewInvalidArgumentsCount(d.Name(), 0, len(msg.Args))
+	}
+	return &DBSize{}, nil
+}
+
+func (d *DBSizeParser) Name() string {
+	return "DBSIZE"
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"avacado/internal/protocol"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDBSize_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks)
+	ks.EXPECT().DBSize(gomock.Any()).Return(int64(3), nil)
+
+	response := (&DBSize{}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(3), response)
+}
+
+func TestDBSizeParser_Parse(t *testing.T) {
+	parser := NewDBSizeParser()
+	_, err := parser.Parse(&protocol.Message{Command: "DBSIZE", Args: []string{}})
+	assert.NoError(t, err)
+
+	_, err = parser.Parse(&protocol.Message{Command: "DBSIZE", Args: []string{"extra"}})
+	assert.Error(t, err)
+}
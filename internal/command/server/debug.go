@@ -0,0 +1,72 @@
+package server
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/keyspace"
+	"context"
+	"fmt"
+	"strings"
+)
+
+var debugHelp = []string{
+	"DEBUG <subcommand> [<arg> [value] [opt] ...]. Subcommands are:",
+	"OBJECT <key>",
+	"    Show low level info about the <key> and associated value.",
+	"HELP",
+	"    Print this help.",
+}
+
+// DebugObject reports low level information about the value of a key as a single line of
+// name:value pairs, with ql_nodes giving the number of quick list nodes of a list. Values are
+// never shared between keys, so refcount is always 1.
+type DebugObject struct {
+	Key string
+}
+
+func (d *DebugObject) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	object, ok, err := storage.Keyspace().Object(ctx, d.Key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if !ok {
+		return protocol.NewErrorResponse(keyspace.ErrNoSuchKey)
+	}
+	info := fmt.Sprintf("refcount:1 encoding:%s lru_seconds_idle:%d", object.Encoding, int64(object.Idle.Seconds()))
+	if object.QuicklistNodes > 0 {
+		info += fmt.Sprintf(" ql_nodes:%d", object.QuicklistNodes)
+	}
+	return protocol.NewSimpleStringResponse(info)
+}
+
+// DebugHelp lists the DEBUG subcommands.
+type DebugHelp struct{}
+
+func (d *DebugHelp) Execute(_ context.Context, _ storage.Storage) *protocol.Response {
+	return protocol.NewArrayResponse(debugHelp)
+}
+
+type DebugParser struct{}
+
+func NewDebugParser() *DebugParser {
+	return &DebugParser{}
+}
+
+func (p *DebugParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) == 0 {
+		return nil, fmt.Errorf("ERR wrong number of arguments for 'debug' command")
+	}
+	subcommand := strings.ToUpper(msg.Args[0])
+	switch {
+	case subcommand == "OBJECT" && len(msg.Args) == 2:
+		return &DebugObject{Key: msg.Args[1]}, nil
+	case subcommand == "HELP" && len(msg.Args) == 1:
+		return &DebugHelp{}, nil
+	}
+	return nil, command.NewUnknownSubcommandError(p.Name(), msg.Args[0])
+}
+
+func (p *DebugParser) Name() string {
+	return "DEBUG"
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/keyspace"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDebugObject_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks).AnyTimes()
+	ks.EXPECT().Object(gomock.Any(), "list").
+		Return(keyspace.Object{Encoding: "quicklist", Idle: 90 * time.Second, QuicklistNodes: 3}, true, nil)
+	ks.EXPECT().Object(gomock.Any(), "string").Return(keyspace.Object{Encoding: "int"}, true, nil)
+	ks.EXPECT().Object(gomock.Any(), "missing").Return(keyspace.Object{}, false, nil)
+
+	response := (&DebugObject{Key: "list"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewSimpleStringResponse("refcount:1 encoding:quicklist lru_seconds_idle:90 ql_nodes:3"), response)
+
+	response = (&DebugObject{Key: "string"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewSimpleStringResponse("refcount:1 encoding:int lru_seconds_idle:0"), response)
+
+	response = (&DebugObject{Key: "missing"}).Execute(context.Background(), storage)
+	assert.EqualError(t, response.Err, "ERR no such key")
+}
+
+func TestDebugParser_Parse(t *testing.T) {
+	parser := NewDebugParser()
+
+	cmd, err := parser.Parse(&protocol.Message{Command: "DEBUG", Args: []string{"object", "key"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &DebugObject{Key: "key"}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "DEBUG", Args: []string{"HELP"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &DebugHelp{}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "DEBUG", Args: []string{}})
+	assert.EqualError(t, err, "ERR wrong number of arguments for 'debug' command")
+
+	_, err = parser.Parse(&protocol.Message{Command: "DEBUG", Args: []string{"SLEEP", "1"}})
+	assert.EqualError(t, err, "ERR unknown subcommand or wrong number of arguments for 'SLEEP'. Try DEBUG HELP.")
+	assert.Equal(t, "DEBUG", parser.Name())
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"strings"
+)
+
+// FlushDB removes every key of the selected database.
+// ASYNC and SYNC are both accepted and behave the same: the flushed tables are dropped at once
+// and reclaimed by the garbage collector, so the executor is never stalled freeing them.
+type FlushDB struct{}
+
+func (f *FlushDB) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	if err := storage.Keyspace().Flush(ctx); err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewSimpleStringResponse("OK")
+}
+
+// FlushAll removes every key of every database. Like FlushDB, it accepts ASYNC and SYNC.
+type FlushAll struct{}
+
+func (f *FlushAll) Execute(ctx context.Context, _ storage.Storage) *protocol.Response {
+	databases, ok := command.DatabasesFromContext(ctx)
+	if !ok {
+		return protocol.NewErrorResponse(command.ErrNoDatabases)
+	}
+	for i := 0; i < databases.Count(); i++ {
+		if err := databases.DB(i).Keyspace().Flush(ctx); err != nil {
+			return protocol.NewErrorResponse(err)
+		}
+	}
+	return protocol.NewSimpleStringResponse("OK")
+}
+
+// parseFlushMode validates the optional ASYNC or SYNC argument of the flush commands.
+func parseFlushMode(msg *protocol.Message) error {
+	if len(msg.Args) > 1 {
+		return command.ErrSyntax
+	}
+	if len(msg.Args) == 1 {
+		switch strings.ToUpper(msg.Args[0]) {
+		case "ASYNC", "SYNC":
+		default:
+			return command.ErrSyntax
+		}
+	}
+	return nil
+}
+
+type FlushDBParser struct{}
+
+func NewFlushDBParser() *FlushDBParser {
+	return &FlushDBParser{}
+}
+
+func (f *FlushDBParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if err := parseFlushMode(msg); err != nil {
+		return nil, err
+	}
+	return &FlushDB{}, nil
+}
+
+func (f *FlushDBParser) Name() string {
+	return "FLUSHDB"
+}
+
+type FlushAllParser struct{}
+
+func NewFlushAllParser() *FlushAllParser {
+	return &FlushAllParser{}
+}
+
+func (f *FlushAllParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if err := parseFlushMode(msg); err != nil {
+		return nil, err
+	}
+	return &FlushAll{}, nil
+}
+
+func (f *FlushAllParser) Name() string {
+	return "FLUSHALL"
+}
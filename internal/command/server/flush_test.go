@@ -0,0 +1,57 @@
+package server
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFlushDB_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks)
+	ks.EXPECT().Flush(gomock.Any()).Return(nil)
+
+	response := (&FlushDB{}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewSimpleStringResponse("OK"), response)
+}
+
+func TestFlushAll_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	databases := mocksstorage.NewMockDatabases(controller)
+	databases.EXPECT().Count().Return(3).AnyTimes()
+	for i := 0; i < 3; i++ {
+		db := mocksstorage.NewMockStorage(controller)
+		ks := mockkeyspace.NewMockKeyspace(controller)
+		databases.EXPECT().DB(i).Return(db)
+		db.EXPECT().Keyspace().Return(ks)
+		ks.EXPECT().Flush(gomock.Any()).Return(nil)
+	}
+	ctx := command.ContextWithDatabases(context.Background(), databases)
+
+	response := (&FlushAll{}).Execute(ctx, mocksstorage.NewMockStorage(controller))
+	assert.Equal(t, protocol.NewSimpleStringResponse("OK"), response)
+}
+
+func TestFlushParsers_Parse(t *testing.T) {
+	for _, parser := range []command.Parser{NewFlushDBParser(), NewFlushAllParser()} {
+		_, err := parser.Parse(&protocol.Message{Command: parser.Name(), Args: []string{}})
+		assert.NoError(t, err)
+		_, err = parser.Parse(&protocol.Message{Command: parser.Name(), Args: []string{"async"}})
+		assert.NoError(t, err)
+		_, err = parser.Parse(&protocol.Message{Command: parser.Name(), Args: []string{"SYNC"}})
+		assert.NoError(t, err)
+
+		_, err = parser.Parse(&protocol.Message{Command: parser.Name(), Args: []string{"LATER"}})
+		assert.EqualError(t, err, "ERR syntax error")
+		_, err = parser.Parse(&protocol.Message{Command: parser.Name(), Args: []string{"SYNC", "ASYNC"}})
+		assert.EqualError(t, err, "ERR syntax error")
+	}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// infoField is a single "name:value" line of the INFO reply.
+type infoField struct {
+	name  string
+	value string
+}
+
+// infoSection is a titled group of fields of the INFO reply.
+type infoSection struct {
+	name   string
+	fields func(storage storage.Storage) []infoField
+}
+
+// infoSections lists every section in the order INFO reports them.
+var infoSections = []infoSection{
+	{name: "memory", fields: memoryFields},
+	{name: "stats", fields: statsFields},
+}
+
+func memoryFields(storage storage.Storage) []infoField {
+	stats := storage.Keyspace().Stats()
+	return []infoField{
+		{name: "used_memory", value: fmt.Sprintf("%d", stats.UsedMemory)},
+		{name: "used_memory_human", value: bytesToHuman(stats.UsedMemory)},
+		{name: "used_memory_peak", value: fmt.Sprintf("%d", stats.PeakUsedMemory)},
+		{name: "used_memory_peak_human", value: bytesToHuman(stats.PeakUsedMemory)},
+	}
+}
+
+func statsFields(storage storage.Storage) []infoField {
+	stats := storage.Keyspace().Stats()
+	return []infoField{
+		{name: "expired_keys", value: fmt.Sprintf("%d", stats.ExpiredKeys)},
+		{name: "expired_subkeys", value: fmt.Sprintf("%d", stats.ExpiredSubkeys)},
+		{name: "expired_stale_perc", value: fmt.Sprintf("%.2f", stats.ExpiredStalePerc)},
+		{name: "expired_time_cap_reached_count", value: fmt.Sprintf("%d", stats.ExpiredTimeCapReachedCount)},
+		{name: "expire_cycle_cpu_milliseconds", value: fmt.Sprintf("%d", stats.ExpireCycleTime.Milliseconds())},
+		{name: "evicted_keys", value: fmt.Sprintf("%d", stats.EvictedKeys)},
+	}
+}
+
+// Info reports server information and statistics. Without arguments, or with
+// "all", "default" or "everything", every section is included.
+type Info struct {
+	Sections []string
+}
+
+func (i *Info) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	var builder strings.Builder
+	for _, section := range infoSections {
+		if !i.includes(section.name) {
+			continue
+		}
+		if builder.Len() > 0 {
+			builder.WriteString("\r\n")
+		}
+		builder.WriteString("# " + strings.ToUpper(section.name[:1]) + section.name[1:] + "\r\n")
+		for _, field := range section.fields(storage) {
+			builder.WriteString(field.name + ":" + field.value + "\r\n")
+		}
+	}
+	return protocol.NewBulkStringResponse([]byte(builder.String()))
+}
+
+func (i *Info) includes(name string) bool {
+	if len(i.Sections) == 0 {
+		return true
+	}
+	for _, section := range i.Sections {
+		switch strings.ToLower(section) {
+		case name, "all", "default", "everything":
+			return true
+		}
+	}
+	return false
+}
+
+type InfoParser struct{}
+
+func NewInfoParser() *InfoParser {
+	return &InfoParser{}
+}
+
+func (p *InfoParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return &Info{Sections: msg.Args}, nil
+}
+
+func (p *InfoParser) Name() string {
+	return "INFO"
+}
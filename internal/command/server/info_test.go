@@ -0,0 +1,61 @@
+package server
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/keyspace"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInfo_ExecuteStats(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+	ks := mockkeyspace.NewMockKeyspace(ctr)
+
+	storage.EXPECT().Keyspace().Return(ks)
+	ks.EXPECT().Stats().Return(keyspace.Stats{
+		ExpiredKeys:                42,
+		ExpiredSubkeys:             5,
+		ExpiredStalePerc:           12.5,
+		ExpiredTimeCapReachedCount: 3,
+		ExpireCycleTime:            1500 * time.Millisecond,
+		EvictedKeys:                7,
+	})
+
+	cmd := &Info{Sections: []string{"stats"}}
+	resp := cmd.Execute(context.Background(), storage)
+
+	assert.Nil(t, resp.Err)
+	assert.Equal(t, "# Stats\r\n"+
+		"expired_keys:42\r\n"+
+		"expired_subkeys:5\r\n"+
+		"expired_stale_perc:12.50\r\n"+
+		"expired_time_cap_reached_count:3\r\n"+
+		"expire_cycle_cpu_milliseconds:1500\r\n"+
+		"evicted_keys:7\r\n", string(resp.Value.Bytes))
+}
+
+func TestInfo_ExecuteUnknownSection(t *testing.T) {
+	ctr := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(ctr)
+
+	cmd := &Info{Sections: []string{"unknown"}}
+	resp := cmd.Execute(context.Background(), storage)
+
+	assert.Nil(t, resp.Err)
+	assert.Equal(t, "", string(resp.Value.Bytes))
+}
+
+func TestInfoParser_Parse(t *testing.T) {
+	parser := NewInfoParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "INFO", Args: []string{"stats"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"stats"}, cmd.(*Info).Sections)
+	assert.Equal(t, "INFO", parser.Name())
+}
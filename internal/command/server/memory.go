@@ -0,0 +1,197 @@
+package server
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/keyspace"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// doctorMinUsedMemory is the memory under which MEMORY DOCTOR has nothing meaningful to say.
+	doctorMinUsedMemory = 5 << 20
+	// doctorPeakRatio is how much the peak may exceed the memory used before MEMORY DOCTOR reports it.
+	doctorPeakRatio = 1.5
+)
+
+var memoryHelp = []string{
+	"MEMORY <subcommand> [<arg> [value] [opt] ...]. Subcommands are:",
+	"DOCTOR",
+	"    Return memory problems reports.",
+	"STATS",
+	"    Return information about the memory usage of the server.",
+	"USAGE <key> [SAMPLES <count>]",
+	"    Return memory in bytes used by <key> and its value.",
+	"HELP",
+	"    Print this help.",
+}
+
+// MemoryUsage reports the bytes used by a key and its value. Samples is accepted for
+// compatibility only: sizes are maintained as values change, so nothing needs sampling.
+type MemoryUsage struct {
+	Key     string
+	Samples int64
+}
+
+func (m *MemoryUsage) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	size, ok, err := storage.Keyspace().MemoryUsage(ctx, m.Key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if !ok {
+		return protocol.NewNullBulkStringResponse()
+	}
+	return protocol.NewNumberResponse(size)
+}
+
+// MemoryStats reports the memory used by the server and by each non-empty database.
+type MemoryStats struct{}
+
+func (m *MemoryStats) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	databases, ok := command.DatabasesFromContext(ctx)
+	if !ok {
+		return protocol.NewErrorResponse(command.ErrNoDatabases)
+	}
+	stats := storage.Keyspace().Stats()
+	reply := []protocol.MapEntry{
+		numberEntry("peak.allocated", stats.PeakUsedMemory),
+		numberEntry("total.allocated", stats.UsedMemory),
+	}
+	var keys, overhead int64
+	for i := 0; i < databases.Count(); i++ {
+		db := databases.DB(i).Keyspace().MemoryStats(ctx)
+		if db.Keys == 0 {
+			continue
+		}
+		keys += db.Keys
+		overhead += db.Overhead
+		reply = append(reply, protocol.MapEntry{
+			Key: "db." + strconv.Itoa(i),
+			Val: protocol.NewMapProtocolValue([]protocol.MapEntry{
+				numberEntry("keys", db.Keys),
+				numberEntry("expires", db.Expires),
+				numberEntry("overhead.hashtable.main", db.Overhead),
+			}),
+		})
+	}
+	dataset := stats.UsedMemory - overhead
+	bytesPerKey := int64(0)
+	if keys > 0 {
+		bytesPerKey = stats.UsedMemory / keys
+	}
+	reply = append(reply,
+		numberEntry("overhead.total", overhead),
+		numberEntry("keys.count", keys),
+		numberEntry("keys.bytes-per-key", bytesPerKey),
+		numberEntry("dataset.bytes", dataset),
+		percentageEntry("dataset.percentage", dataset, stats.UsedMemory),
+		percentageEntry("peak.percentage", stats.UsedMemory, stats.PeakUsedMemory),
+	)
+	return protocol.NewMapResponse(reply)
+}
+
+func numberEntry(key string, n int64) protocol.MapEntry {
+	return protocol.MapEntry{Key: key, Val: protocol.NewNumberProtocolValue(n)}
+}
+
+func percentageEntry(key string, part, total int64) protocol.MapEntry {
+	percentage := 0.0
+	if total > 0 {
+		percentage = float64(part) * 100 / float64(total)
+	}
+	return protocol.MapEntry{Key: key, Val: protocol.NewBulkStringProtocolValue([]byte(strconv.FormatFloat(percentage, 'f', -1, 64)))}
+}
+
+// MemoryDoctor reports the memory issues it can detect in plain words.
+type MemoryDoctor struct{}
+
+func (m *MemoryDoctor) Execute(_ context.Context, storage storage.Storage) *protocol.Response {
+	return protocol.NewBulkStringResponse([]byte(memoryDoctorReport(storage.Keyspace().Stats())))
+}
+
+func memoryDoctorReport(stats keyspace.Stats) string {
+	if stats.UsedMemory < doctorMinUsedMemory {
+		return "Hi Sam, this instance is empty or is using very little memory, my issues detector can't be used in these conditions. " +
+			"Please, leave for your mission on Earth and fill it with some data. " +
+			"The new Sam and I will be back to our programming as soon as I finished rebooting."
+	}
+	if float64(stats.PeakUsedMemory) > float64(stats.UsedMemory)*doctorPeakRatio {
+		return "Sam, I detected a few issues in this avacado instance memory implants:\n\n" +
+			fmt.Sprintf(" * Peak memory: In the past this instance used more than 150%% the memory that is currently using (%s against %s). "+
+				"The memory of the keys that were deleted or expired since then is not reused until the Go garbage collector returns it.\n\n",
+				bytesToHuman(stats.PeakUsedMemory), bytesToHuman(stats.UsedMemory)) +
+			"I'm here to keep you safe, Sam. I want to help you.\n"
+	}
+	return "Hi Sam, I can't find any memory issue in your instance. I can only account for what occurs on this base."
+}
+
+// bytesToHuman formats n bytes with the largest binary unit keeping a value of at least one, as Redis does.
+func bytesToHuman(n int64) string {
+	units := []string{"K", "M", "G", "T", "P"}
+	if n < 1024 {
+		return strconv.FormatInt(n, 10) + "B"
+	}
+	value := float64(n) / 1024
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%.2f%s", value, units[unit])
+}
+
+// MemoryHelp lists the MEMORY subcommands.
+type MemoryHelp struct{}
+
+func (m *MemoryHelp) Execute(_ context.Context, _ storage.Storage) *protocol.Response {
+	return protocol.NewArrayResponse(memoryHelp)
+}
+
+type MemoryParser struct{}
+
+func NewMemoryParser() *MemoryParser {
+	return &MemoryParser{}
+}
+
+func (p *MemoryParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) == 0 {
+		return nil, fmt.Errorf("ERR wrong number of arguments for 'memory' command")
+	}
+	subcommand, args := strings.ToUpper(msg.Args[0]), msg.Args[1:]
+	switch {
+	case subcommand == "USAGE" && len(args) > 0:
+		return parseMemoryUsage(args)
+	case subcommand == "STATS" && len(args) == 0:
+		return &MemoryStats{}, nil
+	case subcommand == "DOCTOR" && len(args) == 0:
+		return &MemoryDoctor{}, nil
+	case subcommand == "HELP" && len(args) == 0:
+		return &MemoryHelp{}, nil
+	}
+	return nil, command.NewUnknownSubcommandError(p.Name(), msg.Args[0])
+}
+
+func parseMemoryUsage(args []string) (command.Command, error) {
+	usage := &MemoryUsage{Key: args[0]}
+	options := args[1:]
+	for len(options) > 0 {
+		if !strings.EqualFold(options[0], "SAMPLES") || len(options) < 2 {
+			return nil, command.ErrSyntax
+		}
+		samples, err := strconv.ParseInt(options[1], 10, 64)
+		if err != nil || samples < 0 {
+			return nil, command.ErrNotInteger
+		}
+		usage.Samples = samples
+		options = options[2:]
+	}
+	return usage, nil
+}
+
+func (p *MemoryParser) Name() string {
+	return "MEMORY"
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage/keyspace"
+	mockkeyspace "avacado/internal/storage/keyspace/mock"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMemoryParser_Parse(t *testing.T) {
+	parser := NewMemoryParser()
+
+	cmd, err := parser.Parse(&protocol.Message{Command: "MEMORY", Args: []string{"usage", "key"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &MemoryUsage{Key: "key"}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "MEMORY", Args: []string{"USAGE", "key", "SAMPLES", "0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &MemoryUsage{Key: "key", Samples: 0}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "MEMORY", Args: []string{"stats"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &MemoryStats{}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "MEMORY", Args: []string{"DOCTOR"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &MemoryDoctor{}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "MEMORY", Args: []string{"help"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &MemoryHelp{}, cmd)
+	assert.Equal(t, "MEMORY", parser.Name())
+}
+
+func TestMemoryParser_ParseErrors(t *testing.T) {
+	parser := NewMemoryParser()
+
+	_, err := parser.Parse(&protocol.Message{Command: "MEMORY", Args: []string{}})
+	assert.EqualError(t, err, "ERR wrong number of arguments for 'memory' command")
+
+	_, err = parser.Parse(&protocol.Message{Command: "MEMORY", Args: []string{"purge"}})
+	assert.EqualError(t, err, "ERR unknown subcommand or wrong number of arguments for 'purge'. Try MEMORY HELP.")
+
+	_, err = parser.Parse(&protocol.Message{Command: "MEMORY", Args: []string{"USAGE"}})
+	assert.Error(t, err)
+
+	_, err = parser.Parse(&protocol.Message{Command: "MEMORY", Args: []string{"STATS", "extra"}})
+	assert.Error(t, err)
+
+	_, err = parser.Parse(&protocol.Message{Command: "MEMORY", Args: []string{"USAGE", "key", "SAMPLES"}})
+	assert.EqualError(t, err, "ERR syntax error")
+
+	_, err = parser.Parse(&protocol.Message{Command: "MEMORY", Args: []string{"USAGE", "key", "SAMPLES", "-1"}})
+	assert.EqualError(t, err, "ERR value is not an integer or out of range")
+}
+
+func TestMemoryUsage_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks).Times(2)
+	ks.EXPECT().MemoryUsage(gomock.Any(), "key").Return(int64(120), true, nil)
+	ks.EXPECT().MemoryUsage(gomock.Any(), "missing").Return(int64(0), false, nil)
+
+	response := (&MemoryUsage{Key: "key"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNumberResponse(120), response)
+
+	response = (&MemoryUsage{Key: "missing"}).Execute(context.Background(), storage)
+	assert.Equal(t, protocol.NewNullBulkStringResponse(), response)
+}
+
+func TestMemoryStats_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	storage := mocksstorage.NewMockStorage(controller)
+	ks := mockkeyspace.NewMockKeyspace(controller)
+	storage.EXPECT().Keyspace().Return(ks)
+	ks.EXPECT().Stats().Return(keyspace.Stats{UsedMemory: 1000, PeakUsedMemory: 2000})
+
+	databases := mocksstorage.NewMockDatabases(controller)
+	databases.EXPECT().Count().Return(2).AnyTimes()
+	for i, stats := range []keyspace.MemoryStats{{}, {Keys: 4, Expires: 1, Bytes: 1000, Overhead: 400}} {
+		db := mocksstorage.NewMockStorage(controller)
+		dbKeyspace := mockkeyspace.NewMockKeyspace(controller)
+		databases.EXPECT().DB(i).Return(db)
+		db.EXPECT().Keyspace().Return(dbKeyspace)
+		dbKeyspace.EXPECT().MemoryStats(gomock.Any()).Return(stats)
+	}
+	ctx := command.ContextWithDatabases(context.Background(), databases)
+
+	response := (&MemoryStats{}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []protocol.MapEntry{
+		numberEntry("peak.allocated", 2000),
+		numberEntry("total.allocated", 1000),
+		{Key: "db.1", Val: protocol.NewMapProtocolValue([]protocol.MapEntry{
+			numberEntry("keys", 4),
+			numberEntry("expires", 1),
+			numberEntry("overhead.hashtable.main", 400),
+		})},
+		numberEntry("overhead.total", 400),
+		numberEntry("keys.count", 4),
+		numberEntry("keys.bytes-per-key", 250),
+		numberEntry("dataset.bytes", 600),
+		{Key: "dataset.percentage", Val: protocol.NewBulkStringProtocolValue([]byte("60"))},
+		{Key: "peak.percentage", Val: protocol.NewBulkStringProtocolValue([]byte("50"))},
+	}, response.Value.Map)
+}
+
+func TestMemoryDoctorReport(t *testing.T) {
+	assert.Contains(t, memoryDoctorReport(keyspace.Stats{UsedMemory: 1024}), "using very little memory")
+	assert.Contains(t, memoryDoctorReport(keyspace.Stats{UsedMemory: 10 << 20, PeakUsedMemory: 11 << 20}), "can't find any memory issue")
+
+	report := memoryDoctorReport(keyspace.Stats{UsedMemory: 10 << 20, PeakUsedMemory: 40 << 20})
+	assert.Contains(t, report, "Peak memory")
+	assert.Contains(t, report, "40.00M against 10.00M")
+}
+
+func TestBytesToHuman(t *testing.T) {
+	assert.Equal(t, "0B", bytesToHuman(0))
+	assert.Equal(t, "1023B", bytesToHuman(1023))
+	assert.Equal(t, "1.50K", bytesToHuman(1536))
+	assert.Equal(t, "2.00M", bytesToHuman(2<<20))
+	assert.Equal(t, "3.00G", bytesToHuman(3<<30))
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"errors"
+	"strconv"
+)
+
+var (
+	errInvalidFirstDBIndex  = errors.New("ERR invalid first DB index")
+	errInvalidSecondDBIndex = errors.New("ERR invalid second DB index")
+)
+
+// SwapDB exchanges the content of two databases. Clients connected to one of them
+// immediately see the data of the other.
+type SwapDB struct {
+	First  int
+	Second int
+}
+
+func (s *SwapDB) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	databases, ok := command.DatabasesFromContext(ctx)
+	if !ok {
+		return protocol.NewErrorResponse(command.ErrNoDatabases)
+	}
+	if err := command.CheckDBIndex(databases, s.First); err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if err := command.CheckDBIndex(databases, s.Second); err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	databases.Swap(s.First, s.Second)
+	return protocol.NewSimpleStringResponse("OK")
+}
+
+// SwappedDBs lets the executor serve the clients blocked on keys of the swapped databases.
+func (s *SwapDB) SwappedDBs() (int, int) {
+	return s.First, s.Second
+}
+
+type SwapDBParser struct{}
+
+func NewSwapDBParser() *SwapDBParser {
+	return &SwapDBParser{}
+}
+
+func (s *SwapDBParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 2 {
+		return nil, command.NewInvalidArgumentsCount(s.Name(), 2, len(msg.Args))
+	}
+	first, err := strconv.Atoi(msg.Args[0])
+	if err != nil {
+		return nil, errInvalidFirstDBIndex
+	}
+	second, err := strconv.Atoi(msg.Args[1])
+	if err != nil {
+		return nil, errInvalidSecondDBIndex
+	}
+	return &SwapDB{First: first, Second: second}, nil
+}
+
+func (s *SwapDBParser) Name() string {
+	return "SWAPDB"
+}
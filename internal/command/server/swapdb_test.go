@@ -0,0 +1,42 @@
+package server
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSwapDB_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	databases := mocksstorage.NewMockDatabases(controller)
+	storage := mocksstorage.NewMockStorage(controller)
+	databases.EXPECT().Count().Return(16).AnyTimes()
+	databases.EXPECT().Swap(0, 5)
+	ctx := command.ContextWithDatabases(context.Background(), databases)
+
+	response := (&SwapDB{First: 0, Second: 5}).Execute(ctx, storage)
+	assert.Equal(t, protocol.NewSimpleStringResponse("OK"), response)
+
+	response = (&SwapDB{First: 0, Second: 16}).Execute(ctx, storage)
+	assert.EqualError(t, response.Err, "ERR DB index is out of range")
+}
+
+func TestSwapDBParser_Parse(t *testing.T) {
+	parser := NewSwapDBParser()
+
+	cmd, err := parser.Parse(&protocol.Message{Command: "SWAPDB", Args: []string{"1", "2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &SwapDB{First: 1, Second: 2}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SWAPDB", Args: []string{"a", "2"}})
+	assert.EqualError(t, err, "ERR invalid first DB index")
+	_, err = parser.Parse(&protocol.Message{Command: "SWAPDB", Args: []string{"1", "b"}})
+	assert.EqualError(t, err, "ERR invalid second DB index")
+	_, err = parser.Parse(&protocol.Message{Command: "SWAPDB", Args: []string{"1"}})
+	assert.Error(t, err)
+}
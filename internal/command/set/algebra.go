@@ -0,0 +1,164 @@
+package set
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type Operation int
+
+const (
+	Inter Operation = iota
+	Union
+	Diff
+)
+
+// SetOperation replies the intersection, union or difference of the sets stored at keys.
+type SetOperation struct {
+	keys      []string
+	operation Operation
+}
+
+func (s *SetOperation) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	var members []string
+	var err error
+	switch s.operation {
+	case Inter:
+		members, err = storage.Sets().SInter(ctx, s.keys)
+	case Union:
+		members, err = storage.Sets().SUnion(ctx, s.keys)
+	default:
+		members, err = storage.Sets().SDiff(ctx, s.keys)
+	}
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return membersResponse(members)
+}
+
+// SetOperationStore stores the intersection, union or difference of the sets stored at keys
+// at destination and replies its size.
+type SetOperationStore struct {
+	destination string
+	keys        []string
+	operation   Operation
+}
+
+func (s *SetOperationStore) DenyOOM() {}
+
+func (s *SetOperationStore) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	var size int
+	var err error
+	switch s.operation {
+	case Inter:
+		size, err = storage.Sets().SInterStore(ctx, s.destination, s.keys)
+	case Union:
+		size, err = storage.Sets().SUnionStore(ctx, s.destination, s.keys)
+	default:
+		size, err = storage.Sets().SDiffStore(ctx, s.destination, s.keys)
+	}
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(size))
+}
+
+func parseSetOperation(name string, operation Operation, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 {
+		return nil, command.NewInvalidArgumentsCount(name, 1, len(msg.Args))
+	}
+	return &SetOperation{keys: msg.Args, operation: operation}, nil
+}
+
+func parseSetOperationStore(name string, operation Operation, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(name, 2, len(msg.Args))
+	}
+	return &SetOperationStore{destination: msg.Args[0], keys: msg.Args[1:], operation: operation}, nil
+}
+
+type SInterParser struct{}
+
+func NewSInterParser() *SInterParser {
+	return &SInterParser{}
+}
+
+func (p *SInterParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseSetOperation(p.Name(), Inter, msg)
+}
+
+func (p *SInterParser) Name() string {
+	return "SINTER"
+}
+
+type SInterStoreParser struct{}
+
+func NewSInterStoreParser() *SInterStoreParser {
+	return &SInterStoreParser{}
+}
+
+func (p *SInterStoreParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseSetOperationStore(p.Name(), Inter, msg)
+}
+
+func (p *SInterStoreParser) Name() string {
+	return "SINTERSTORE"
+}
+
+type SUnionParser struct{}
+
+func NewSUnionParser() *SUnionParser {
+	return &SUnionParser{}
+}
+
+func (p *SUnionParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseSetOperation(p.Name(), Union, msg)
+}
+
+func (p *SUnionParser) Name() string {
+	return "SUNION"
+}
+
+type SUnionStoreParser struct{}
+
+func NewSUnionStoreParser() *SUnionStoreParser {
+	return &SUnionStoreParser{}
+}
+
+func (p *SUnionStoreParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseSetOperationStore(p.Name(), Union, msg)
+}
+
+func (p *SUnionStoreParser) Name() string {
+	return "SUNIONSTORE"
+}
+
+type SDiffParser struct{}
+
+func NewSDiffParser() *SDiffParser {
+	return &SDiffParser{}
+}
+
+func (p *SDiffParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseSetOperation(p.Name(), Diff, msg)
+}
+
+func (p *SDiffParser) Name() string {
+	return "SDIFF"
+}
+
+type SDiffStoreParser struct{}
+
+func NewSDiffStoreParser() *SDiffStoreParser {
+	return &SDiffStoreParser{}
+}
+
+func (p *SDiffStoreParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseSetOperationStore(p.Name(), Diff, msg)
+}
+
+func (p *SDiffStoreParser) Name() string {
+	return "SDIFFSTORE"
+}
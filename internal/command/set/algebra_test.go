@@ -0,0 +1,79 @@
+package set
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mocksets "avacado/internal/storage/sets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetOperation_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets).Times(3)
+	keys := []string{"set1", "set2"}
+	sets.EXPECT().SInter(ctx, keys).Return([]string{"a"}, nil)
+	sets.EXPECT().SUnion(ctx, keys).Return([]string{"a", "b", "c"}, nil)
+	sets.EXPECT().SDiff(ctx, keys).Return([]string{"b"}, nil)
+
+	for operation, expected := range map[Operation][]string{
+		Inter: {"a"},
+		Union: {"a", "b", "c"},
+		Diff:  {"b"},
+	} {
+		response := (&SetOperation{keys: keys, operation: operation}).Execute(ctx, storage)
+		assert.Nil(t, response.Err)
+		assert.Equal(t, protocol.ValueType(protocol.TypeArray), response.Value.Type)
+		assert.Equal(t, len(expected), len(response.Value.Array))
+		for i, member := range expected {
+			assert.Equal(t, []byte(member), response.Value.Array[i].Bytes)
+		}
+	}
+}
+
+func TestSetOperationStore_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets).Times(3)
+	keys := []string{"set1", "set2"}
+	sets.EXPECT().SInterStore(ctx, "dst", keys).Return(1, nil)
+	sets.EXPECT().SUnionStore(ctx, "dst", keys).Return(3, nil)
+	sets.EXPECT().SDiffStore(ctx, "dst", keys).Return(0, nil)
+
+	for operation, expected := range map[Operation]int64{Inter: 1, Union: 3, Diff: 0} {
+		response := (&SetOperationStore{destination: "dst", keys: keys, operation: operation}).Execute(ctx, storage)
+		assert.Nil(t, response.Err)
+		assert.Equal(t, expected, response.Value.Number)
+	}
+}
+
+func TestSetOperationParsers_Parse(t *testing.T) {
+	cmd, err := NewSUnionParser().Parse(&protocol.Message{Command: "SUNION", Args: []string{"set1", "set2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &SetOperation{keys: []string{"set1", "set2"}, operation: Union}, cmd)
+
+	cmd, err = NewSDiffParser().Parse(&protocol.Message{Command: "SDIFF", Args: []string{"set1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &SetOperation{keys: []string{"set1"}, operation: Diff}, cmd)
+
+	_, err = NewSInterParser().Parse(&protocol.Message{Command: "SINTER", Args: []string{}})
+	assert.Error(t, err)
+
+	cmd, err = NewSInterStoreParser().Parse(&protocol.Message{Command: "SINTERSTORE", Args: []string{"dst", "set1", "set2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &SetOperationStore{destination: "dst", keys: []string{"set1", "set2"}, operation: Inter}, cmd)
+
+	_, err = NewSUnionStoreParser().Parse(&protocol.Message{Command: "SUNIONSTORE", Args: []string{"dst"}})
+	assert.Error(t, err)
+
+	_, err = NewSDiffStoreParser().Parse(&protocol.Message{Command: "SDIFFSTORE", Args: []string{"dst"}})
+	assert.Error(t, err)
+}
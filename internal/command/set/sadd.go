@@ -0,0 +1,40 @@
+package set
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type SAdd struct {
+	key     string
+	members []string
+}
+
+func (s *SAdd) DenyOOM() {}
+
+func (s *SAdd) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	added, err := storage.Sets().SAdd(ctx, s.key, s.members)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(added))
+}
+
+type SAddParser struct{}
+
+func NewSAddParser() *SAddParser {
+	return &SAddParser{}
+}
+
+func (p *SAddParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	return &SAdd{key: msg.Args[0], members: msg.Args[1:]}, nil
+}
+
+func (p *SAddParser) Name() string {
+	return "SADD"
+}
@@ -0,0 +1,40 @@
+package set
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mocksets "avacado/internal/storage/sets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSAddCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := SAdd{key: "myset", members: []string{"a", "b"}}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets)
+	sets.EXPECT().SAdd(ctx, "myset", []string{"a", "b"}).Return(2, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(2), response.Value.Number)
+}
+
+func TestSAddParser_Parse(t *testing.T) {
+	parser := NewSAddParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "SADD", Args: []string{"myset", "a", "b"}})
+	assert.NoError(t, err)
+	sadd := cmd.(*SAdd)
+	assert.Equal(t, "myset", sadd.key)
+	assert.Equal(t, []string{"a", "b"}, sadd.members)
+}
+
+func TestSAddParser_ParseTooFewArgs(t *testing.T) {
+	parser := NewSAddParser()
+	_, err := parser.Parse(&protocol.Message{Command: "SADD", Args: []string{"myset"}})
+	assert.Error(t, err)
+}
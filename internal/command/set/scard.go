@@ -0,0 +1,37 @@
+package set
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type SCard struct {
+	key string
+}
+
+func (s *SCard) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	size, err := storage.Sets().SCard(ctx, s.key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(size))
+}
+
+type SCardParser struct{}
+
+func NewSCardParser() *SCardParser {
+	return &SCardParser{}
+}
+
+func (p *SCardParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	return &SCard{key: msg.Args[0]}, nil
+}
+
+func (p *SCardParser) Name() string {
+	return "SCARD"
+}
@@ -0,0 +1,35 @@
+package set
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mocksets "avacado/internal/storage/sets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSCardCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := SCard{key: "myset"}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets)
+	sets.EXPECT().SCard(ctx, "myset").Return(3, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(3), response.Value.Number)
+}
+
+func TestSCardParser_Parse(t *testing.T) {
+	parser := NewSCardParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "SCARD", Args: []string{"myset"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "myset", cmd.(*SCard).key)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SCARD", Args: []string{}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,12 @@
+package set
+
+import "avacado/internal/protocol"
+
+// membersResponse replies members as an array of bulk strings.
+func membersResponse(members []string) *protocol.Response {
+	values := make([][]byte, len(members))
+	for i, member := range members {
+		values[i] = []byte(member)
+	}
+	return protocol.NewArrayResponse(values)
+}
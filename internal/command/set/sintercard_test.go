@@ -0,0 +1,62 @@
+package set
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mocksets "avacado/internal/storage/sets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSInterCardCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := SInterCard{keys: []string{"set1", "set2"}, limit: 5}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets)
+	sets.EXPECT().SInterCard(ctx, []string{"set1", "set2"}, 5).Return(2, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(2), response.Value.Number)
+}
+
+func TestSInterCardParser_Parse(t *testing.T) {
+	parser := NewSInterCardParser()
+	parse := func(args ...string) (*SInterCard, error) {
+		cmd, err := parser.Parse(&protocol.Message{Command: "SINTERCARD", Args: args})
+		if err != nil {
+			return nil, err
+		}
+		return cmd.(*SInterCard), nil
+	}
+
+	cmd, err := parse("2", "set1", "set2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"set1", "set2"}, cmd.keys)
+	assert.Equal(t, 0, cmd.limit)
+
+	cmd, err = parse("1", "set1", "limit", "3")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"set1"}, cmd.keys)
+	assert.Equal(t, 3, cmd.limit)
+
+	_, err = parse("0", "set1")
+	assert.Equal(t, command.ErrNumKeys, err)
+	_, err = parse("x", "set1")
+	assert.Equal(t, command.ErrNumKeys, err)
+	_, err = parse("3", "set1", "set2")
+	assert.Equal(t, command.ErrTooManyKeys, err)
+	_, err = parse("1", "set1", "LIMIT", "-1")
+	assert.Equal(t, command.ErrNegativeLimit, err)
+	_, err = parse("1", "set1", "LIMIT")
+	assert.Equal(t, command.ErrSyntax, err)
+	_, err = parse("1", "set1", "set2")
+	assert.Equal(t, command.ErrSyntax, err)
+	_, err = parse("1")
+	assert.Error(t, err)
+}
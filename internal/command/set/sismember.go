@@ -0,0 +1,41 @@
+package set
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type SIsMember struct {
+	key    string
+	member string
+}
+
+func (s *SIsMember) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	found, err := storage.Sets().SIsMember(ctx, s.key, s.member)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if found {
+		return protocol.NewNumberResponse(1)
+	}
+	return protocol.NewNumberResponse(0)
+}
+
+type SIsMemberParser struct{}
+
+func NewSIsMemberParser() *SIsMemberParser {
+	return &SIsMemberParser{}
+}
+
+func (p *SIsMemberParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	return &SIsMember{key: msg.Args[0], member: msg.Args[1]}, nil
+}
+
+func (p *SIsMemberParser) Name() string {
+	return "SISMEMBER"
+}
@@ -0,0 +1,42 @@
+package set
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mocksets "avacado/internal/storage/sets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSIsMemberCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets).Times(2)
+	sets.EXPECT().SIsMember(ctx, "myset", "a").Return(true, nil)
+	sets.EXPECT().SIsMember(ctx, "myset", "b").Return(false, nil)
+
+	response := (&SIsMember{key: "myset", member: "a"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(1), response.Value.Number)
+
+	response = (&SIsMember{key: "myset", member: "b"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(0), response.Value.Number)
+}
+
+func TestSIsMemberParser_Parse(t *testing.T) {
+	parser := NewSIsMemberParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "SISMEMBER", Args: []string{"myset", "a"}})
+	assert.NoError(t, err)
+	sismember := cmd.(*SIsMember)
+	assert.Equal(t, "myset", sismember.key)
+	assert.Equal(t, "a", sismember.member)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SISMEMBER", Args: []string{"myset"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,37 @@
+package set
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type SMembers struct {
+	key string
+}
+
+func (s *SMembers) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	members, err := storage.Sets().SMembers(ctx, s.key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return membersResponse(members)
+}
+
+type SMembersParser struct{}
+
+func NewSMembersParser() *SMembersParser {
+	return &SMembersParser{}
+}
+
+func (p *SMembersParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	return &SMembers{key: msg.Args[0]}, nil
+}
+
+func (p *SMembersParser) Name() string {
+	return "SMEMBERS"
+}
@@ -0,0 +1,38 @@
+package set
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mocksets "avacado/internal/storage/sets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSMembersCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := SMembers{key: "myset"}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets)
+	sets.EXPECT().SMembers(ctx, "myset").Return([]string{"a", "b"}, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, protocol.ValueType(protocol.TypeArray), response.Value.Type)
+	assert.Equal(t, 2, len(response.Value.Array))
+	assert.Equal(t, []byte("a"), response.Value.Array[0].Bytes)
+	assert.Equal(t, []byte("b"), response.Value.Array[1].Bytes)
+}
+
+func TestSMembersParser_Parse(t *testing.T) {
+	parser := NewSMembersParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "SMEMBERS", Args: []string{"myset"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "myset", cmd.(*SMembers).key)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SMEMBERS", Args: []string{"myset", "extra"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,44 @@
+package set
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type SMIsMember struct {
+	key     string
+	members []string
+}
+
+func (s *SMIsMember) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	found, err := storage.Sets().SMIsMember(ctx, s.key, s.members)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	values := make([]int64, len(found))
+	for i, f := range found {
+		if f {
+			values[i] = 1
+		}
+	}
+	return protocol.NewArrayResponse(values)
+}
+
+type SMIsMemberParser struct{}
+
+func NewSMIsMemberParser() *SMIsMemberParser {
+	return &SMIsMemberParser{}
+}
+
+func (p *SMIsMemberParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	return &SMIsMember{key: msg.Args[0], members: msg.Args[1:]}, nil
+}
+
+func (p *SMIsMemberParser) Name() string {
+	return "SMISMEMBER"
+}
@@ -0,0 +1,37 @@
+package set
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mocksets "avacado/internal/storage/sets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSMIsMemberCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := SMIsMember{key: "myset", members: []string{"a", "b"}}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets)
+	sets.EXPECT().SMIsMember(ctx, "myset", []string{"a", "b"}).Return([]bool{true, false}, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, 2, len(response.Value.Array))
+	assert.Equal(t, int64(1), response.Value.Array[0].Number)
+	assert.Equal(t, int64(0), response.Value.Array[1].Number)
+}
+
+func TestSMIsMemberParser_Parse(t *testing.T) {
+	parser := NewSMIsMemberParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "SMISMEMBER", Args: []string{"myset", "a", "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, cmd.(*SMIsMember).members)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SMISMEMBER", Args: []string{"myset"}})
+	assert.Error(t, err)
+}
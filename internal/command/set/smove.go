@@ -0,0 +1,44 @@
+package set
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type SMove struct {
+	source      string
+	destination string
+	member      string
+}
+
+func (s *SMove) DenyOOM() {}
+
+func (s *SMove) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	moved, err := storage.Sets().SMove(ctx, s.source, s.destination, s.member)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if moved {
+		return protocol.NewNumberResponse(1)
+	}
+	return protocol.NewNumberResponse(0)
+}
+
+type SMoveParser struct{}
+
+func NewSMoveParser() *SMoveParser {
+	return &SMoveParser{}
+}
+
+func (p *SMoveParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 3 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 3, len(msg.Args))
+	}
+	return &SMove{source: msg.Args[0], destination: msg.Args[1], member: msg.Args[2]}, nil
+}
+
+func (p *SMoveParser) Name() string {
+	return "SMOVE"
+}
@@ -0,0 +1,38 @@
+package set
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mocksets "avacado/internal/storage/sets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSMoveCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := SMove{source: "src", destination: "dst", member: "a"}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets)
+	sets.EXPECT().SMove(ctx, "src", "dst", "a").Return(true, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(1), response.Value.Number)
+}
+
+func TestSMoveParser_Parse(t *testing.T) {
+	parser := NewSMoveParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "SMOVE", Args: []string{"src", "dst", "a"}})
+	assert.NoError(t, err)
+	smove := cmd.(*SMove)
+	assert.Equal(t, "src", smove.source)
+	assert.Equal(t, "dst", smove.destination)
+	assert.Equal(t, "a", smove.member)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SMOVE", Args: []string{"src", "dst"}})
+	assert.Error(t, err)
+}
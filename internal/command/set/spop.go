@@ -0,0 +1,62 @@
+package set
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"errors"
+	"strconv"
+)
+
+var errNotPositive = errors.New("ERR value is out of range, must be positive")
+
+// SPop removes random members. Without a count it replies a single member, or nil if the
+// set does not exist; with a count it replies an array.
+type SPop struct {
+	key   string
+	count *int
+}
+
+func (s *SPop) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	count := 1
+	if s.count != nil {
+		count = *s.count
+	}
+	members, err := storage.Sets().SPop(ctx, s.key, count)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if s.count != nil {
+		return membersResponse(members)
+	}
+	if len(members) == 0 {
+		return protocol.NewNullBulkStringResponse()
+	}
+	return protocol.NewBulkStringResponse([]byte(members[0]))
+}
+
+type SPopParser struct{}
+
+func NewSPopParser() *SPopParser {
+	return &SPopParser{}
+}
+
+func (p *SPopParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 || len(msg.Args) > 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	cmd := &SPop{key: msg.Args[0]}
+	if len(msg.Args) == 2 {
+		count, err := strconv.Atoi(msg.Args[1])
+		if err != nil || count < 0 {
+			return nil, errNotPositive
+		}
+		cmd.count = &count
+	}
+	return cmd, nil
+}
+
+func (p *SPopParser) Name() string {
+	return "SPOP"
+}
@@ -0,0 +1,61 @@
+package set
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mocksets "avacado/internal/storage/sets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSPopCommand_ExecuteWithoutCount(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets).Times(2)
+	sets.EXPECT().SPop(ctx, "myset", 1).Return([]string{"a"}, nil)
+	sets.EXPECT().SPop(ctx, "missing", 1).Return([]string{}, nil)
+
+	response := (&SPop{key: "myset"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("a"), response.Value.Bytes)
+
+	response = (&SPop{key: "missing"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.True(t, response.Value.Null)
+}
+
+func TestSPopCommand_ExecuteWithCount(t *testing.T) {
+	controller := gomock.NewController(t)
+	count := 2
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets)
+	sets.EXPECT().SPop(ctx, "myset", 2).Return([]string{"a", "b"}, nil)
+	response := (&SPop{key: "myset", count: &count}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, protocol.ValueType(protocol.TypeArray), response.Value.Type)
+	assert.Equal(t, 2, len(response.Value.Array))
+}
+
+func TestSPopParser_Parse(t *testing.T) {
+	parser := NewSPopParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "SPOP", Args: []string{"myset"}})
+	assert.NoError(t, err)
+	assert.Nil(t, cmd.(*SPop).count)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "SPOP", Args: []string{"myset", "3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *cmd.(*SPop).count)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SPOP", Args: []string{"myset", "-1"}})
+	assert.Equal(t, errNotPositive, err)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SPOP", Args: []string{"myset", "1", "2"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package set
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"errors"
+	"math"
+	"strconv"
+)
+
+// errOutOfRange is returned for a count whose negation does not fit in an integer.
+var errOutOfRange = errors.New("ERR value is out of range")
+
+// SRandMember returns random members without removing them. Without a count it replies a
+// single member, or nil if the set does not exist. A positive count replies distinct members,
+// a negative one replies exactly -count members which may repeat.
+type SRandMember struct {
+	key   string
+	count *int
+}
+
+func (s *SRandMember) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	count := 1
+	if s.count != nil {
+		count = *s.count
+	}
+	members, err := storage.Sets().SRandMember(ctx, s.key, count)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if s.count != nil {
+		return membersResponse(members)
+	}
+	if len(members) == 0 {
+		return protocol.NewNullBulkStringResponse()
+	}
+	return protocol.NewBulkStringResponse([]byte(members[0]))
+}
+
+type SRandMemberParser struct{}
+
+func NewSRandMemberParser() *SRandMemberParser {
+	return &SRandMemberParser{}
+}
+
+func (p *SRandMemberParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 || len(msg.Args) > 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	cmd := &SRandMember{key: msg.Args[0]}
+	if len(msg.Args) == 2 {
+		count, err := strconv.Atoi(msg.Args[1])
+		if err != nil {
+			return nil, command.ErrNotInteger
+		}
+		if count == math.MinInt {
+			return nil, errOutOfRange
+		}
+		cmd.count = &count
+	}
+	return cmd, nil
+}
+
+func (p *SRandMemberParser) Name() string {
+	return "SRANDMEMBER"
+}
@@ -0,0 +1,63 @@
+package set
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mocksets "avacado/internal/storage/sets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSRandMemberCommand_ExecuteWithoutCount(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets).Times(2)
+	sets.EXPECT().SRandMember(ctx, "myset", 1).Return([]string{"a"}, nil)
+	sets.EXPECT().SRandMember(ctx, "missing", 1).Return([]string{}, nil)
+
+	response := (&SRandMember{key: "myset"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("a"), response.Value.Bytes)
+
+	response = (&SRandMember{key: "missing"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.True(t, response.Value.Null)
+}
+
+func TestSRandMemberCommand_ExecuteWithNegativeCount(t *testing.T) {
+	controller := gomock.NewController(t)
+	count := -3
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets)
+	sets.EXPECT().SRandMember(ctx, "myset", -3).Return([]string{"a", "a", "a"}, nil)
+	response := (&SRandMember{key: "myset", count: &count}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, 3, len(response.Value.Array))
+}
+
+func TestSRandMemberParser_Parse(t *testing.T) {
+	parser := NewSRandMemberParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "SRANDMEMBER", Args: []string{"myset", "-2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, -2, *cmd.(*SRandMember).count)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SRANDMEMBER", Args: []string{"myset", "two"}})
+	assert.Error(t, err)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SRANDMEMBER", Args: []string{"myset", "-9223372036854775808"}})
+	assert.EqualError(t, err, "ERR value is out of range")
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "SRANDMEMBER", Args: []string{"myset", "-9223372036854775807"}})
+	assert.NoError(t, err)
+	assert.Equal(t, -9223372036854775807, *cmd.(*SRandMember).count)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SRANDMEMBER", Args: []string{}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package set
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type SRem struct {
+	key     string
+	members []string
+}
+
+func (s *SRem) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	removed, err := storage.Sets().SRem(ctx, s.key, s.members)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(removed))
+}
+
+type SRemParser struct{}
+
+func NewSRemParser() *SRemParser {
+	return &SRemParser{}
+}
+
+func (p *SRemParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	return &SRem{key: msg.Args[0], members: msg.Args[1:]}, nil
+}
+
+func (p *SRemParser) Name() string {
+	return "SREM"
+}
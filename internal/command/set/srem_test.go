@@ -0,0 +1,37 @@
+package set
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mocksets "avacado/internal/storage/sets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSRemCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := SRem{key: "myset", members: []string{"a", "missing"}}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	sets := mocksets.NewMockSets(controller)
+	storage.EXPECT().Sets().Return(sets)
+	sets.EXPECT().SRem(ctx, "myset", []string{"a", "missing"}).Return(1, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(1), response.Value.Number)
+}
+
+func TestSRemParser_Parse(t *testing.T) {
+	parser := NewSRemParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "SREM", Args: []string{"myset", "a"}})
+	assert.NoError(t, err)
+	srem := cmd.(*SRem)
+	assert.Equal(t, "myset", srem.key)
+	assert.Equal(t, []string{"a"}, srem.members)
+
+	_, err = parser.Parse(&protocol.Message{Command: "SREM", Args: []string{"myset"}})
+	assert.Error(t, err)
+}
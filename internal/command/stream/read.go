@@ -0,0 +1,97 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	errTimeoutNotInteger = errors.New("ERR timeout is not an integer or out of range")
+	errTimeoutNegative   = errors.New("ERR timeout is negative")
+)
+
+// readArgs are the arguments XREAD and XREADGROUP share: their options, then the keys of the
+// streams to read from followed by as many IDs.
+type readArgs struct {
+	group, consumer string
+	grouped         bool
+	count           int
+	// block is set by BLOCK, whose timeout is in seconds, 0 blocking indefinitely.
+	block     bool
+	timeout   float64
+	noAck     bool
+	keys, ids []string
+}
+
+// newUnbalancedStreamsError returns the error of XREAD and XREADGROUP when the keys following
+// STREAMS are not followed by as many IDs.
+func newUnbalancedStreamsError(name string) error {
+	special := '$'
+	if name == "XREADGROUP" {
+		special = '>'
+	}
+	return fmt.Errorf("ERR Unbalanced '%s' list of streams: for each stream key an ID or '%c' must be specified.", strings.ToLower(name), special)
+}
+
+// newReadGroupOnlyError returns the error of XREAD when given an option of XREADGROUP.
+func newReadGroupOnlyError(option string) error {
+	return fmt.Errorf("ERR The %s option is only supported by XREADGROUP. You called XREAD instead.", option)
+}
+
+// parseReadArgs parses [GROUP group consumer] [COUNT count] [BLOCK milliseconds] [NOACK]
+// STREAMS key [key ...] id [id ...], GROUP and NOACK being only allowed for XREADGROUP.
+func parseReadArgs(name string, args []string) (readArgs, error) {
+	readGroup := name == "XREADGROUP"
+	var read readArgs
+	for len(args) > 0 && !strings.EqualFold(args[0], "STREAMS") {
+		switch option := strings.ToUpper(args[0]); {
+		case option == "GROUP" && len(args) > 2:
+			if !readGroup {
+				return read, newReadGroupOnlyError(option)
+			}
+			read.group, read.consumer = args[1], args[2]
+			read.grouped = true
+			args = args[3:]
+		case option == "COUNT" && len(args) > 1:
+			count, err := strconv.Atoi(args[1])
+			if err != nil {
+				return read, command.ErrNotInteger
+			}
+			read.count = max(count, 0)
+			args = args[2:]
+		case option == "BLOCK" && len(args) > 1:
+			ms, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return read, errTimeoutNotInteger
+			}
+			if ms < 0 {
+				return read, errTimeoutNegative
+			}
+			read.block, read.timeout = true, float64(ms)/1000
+			args = args[2:]
+		case option == "NOACK":
+			if !readGroup {
+				return read, newReadGroupOnlyError(option)
+			}
+			read.noAck = true
+			args = args[1:]
+		default:
+			return read, command.ErrSyntax
+		}
+	}
+	if len(args) == 0 {
+		return read, command.ErrSyntax
+	}
+	if readGroup && !read.grouped {
+		return read, errMissingGroup
+	}
+	args = args[1:]
+	if len(args) == 0 || len(args)%2 != 0 {
+		return read, newUnbalancedStreamsError(name)
+	}
+	read.keys, read.ids = args[:len(args)/2], args[len(args)/2:]
+	return read, nil
+}
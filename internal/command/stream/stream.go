@@ -0,0 +1,53 @@
+package stream
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/streams"
+)
+
+// idValue replies id as a bulk string spelled ms-seq.
+func idValue(id streams.ID) protocol.Value {
+	return protocol.NewBulkStringProtocolValue([]byte(id.String()))
+}
+
+// entryValue replies entry as its ID followed by the array of its fields and values, nil for a
+// pending entry deleted from the stream.
+func entryValue(entry streams.Entry) protocol.Value {
+	if entry.Fields == nil {
+		return protocol.NewArrayProtocolValue([]protocol.Value{idValue(entry.ID), protocol.NewNullBulkStringProtocolValue()})
+	}
+	fields := make([]protocol.Value, len(entry.Fields))
+	for i, field := range entry.Fields {
+		fields[i] = protocol.NewBulkStringProtocolValue([]byte(field))
+	}
+	return protocol.NewArrayProtocolValue([]protocol.Value{idValue(entry.ID), protocol.NewArrayProtocolValue(fields)})
+}
+
+func entriesValue(entries []streams.Entry) protocol.Value {
+	values := make([]protocol.Value, len(entries))
+	for i, entry := range entries {
+		values[i] = entryValue(entry)
+	}
+	return protocol.NewArrayProtocolValue(values)
+}
+
+func idsValue(ids []streams.ID) protocol.Value {
+	values := make([]protocol.Value, len(ids))
+	for i, id := range ids {
+		values[i] = idValue(id)
+	}
+	return protocol.NewArrayProtocolValue(values)
+}
+
+// parseIDs parses the IDs of the entries a command acts on.
+func parseIDs(args []string) ([]streams.ID, error) {
+	ids := make([]streams.ID, len(args))
+	for i, arg := range args {
+		id, err := streams.ParseID(arg, 0)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
@@ -0,0 +1,87 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/storage/streams"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	errMaxLenAndMinID     = errors.New("ERR syntax error, MAXLEN and MINID options at the same time are not compatible")
+	errLimitWithoutApprox = errors.New("ERR syntax error, LIMIT cannot be used without the special ~ option")
+	errNegativeMaxLen     = errors.New("ERR The MAXLEN argument must be >= 0.")
+	errNegativeLimit      = errors.New("ERR The LIMIT argument must be >= 0.")
+)
+
+// trimParser accumulates the MAXLEN, MINID and LIMIT arguments XADD and XTRIM share.
+type trimParser struct {
+	options streams.TrimOptions
+	limit   bool
+}
+
+// parse consumes the trimming argument args starts with, if any, and returns how many
+// arguments it took, 0 if args starts with something else.
+func (p *trimParser) parse(args []string) (int, error) {
+	if len(args) < 2 {
+		return 0, nil
+	}
+	switch option := strings.ToUpper(args[0]); option {
+	case "MAXLEN", "MINID":
+		strategy := streams.TrimMaxLen
+		if option == "MINID" {
+			strategy = streams.TrimMinID
+		}
+		if p.options.Strategy != streams.TrimNone && p.options.Strategy != strategy {
+			return 0, errMaxLenAndMinID
+		}
+		p.options.Strategy = strategy
+		taken := 1
+		if len(args) > 2 && (args[1] == "~" || args[1] == "=") {
+			p.options.Approx = args[1] == "~"
+			taken++
+		}
+		threshold := args[taken]
+		if strategy == streams.TrimMinID {
+			id, err := streams.ParseID(threshold, 0)
+			if err != nil {
+				return 0, err
+			}
+			p.options.MinID = id
+			return taken + 1, nil
+		}
+		maxLen, err := strconv.ParseInt(threshold, 10, 64)
+		if err != nil {
+			return 0, command.ErrNotInteger
+		}
+		if maxLen < 0 {
+			return 0, errNegativeMaxLen
+		}
+		p.options.MaxLen = maxLen
+		return taken + 1, nil
+	case "LIMIT":
+		limit, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return 0, command.ErrNotInteger
+		}
+		if limit < 0 {
+			return 0, errNegativeLimit
+		}
+		p.options.Limit = limit
+		p.limit = true
+		return 2, nil
+	}
+	return 0, nil
+}
+
+// finish returns the options parsed, failing if they do not fit together.
+func (p *trimParser) finish() (streams.TrimOptions, error) {
+	if p.limit && !p.options.Approx {
+		return streams.TrimOptions{}, errLimitWithoutApprox
+	}
+	if !p.limit {
+		p.options.Limit = -1
+	}
+	return p.options, nil
+}
@@ -0,0 +1,45 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+)
+
+// XAck acknowledges entries delivered to a consumer group, which are no longer pending, and
+// replies how many were pending.
+type XAck struct {
+	key, group string
+	ids        []streams.ID
+}
+
+func (x *XAck) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	acknowledged, err := storage.Streams().XAck(ctx, x.key, x.group, x.ids)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(acknowledged))
+}
+
+type XAckParser struct{}
+
+func NewXAckParser() *XAckParser {
+	return &XAckParser{}
+}
+
+func (p *XAckParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 3 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 3, len(msg.Args))
+	}
+	ids, err := parseIDs(msg.Args[2:])
+	if err != nil {
+		return nil, err
+	}
+	return &XAck{key: msg.Args[0], group: msg.Args[1], ids: ids}, nil
+}
+
+func (p *XAckParser) Name() string {
+	return "XACK"
+}
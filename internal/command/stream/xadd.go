@@ -0,0 +1,85 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+	"errors"
+	"strings"
+)
+
+var errXAddArgumentsCount = errors.New("ERR wrong number of arguments for 'xadd' command")
+
+// XAdd appends an entry to a stream, trimming it afterwards if asked to, and replies the ID of
+// the entry, or nil if NOMKSTREAM kept a missing stream from being created.
+type XAdd struct {
+	key     string
+	id      streams.AddID
+	fields  []string
+	options streams.AddOptions
+}
+
+func (x *XAdd) DenyOOM() {}
+
+func (x *XAdd) WrittenKeys() []string { return []string{x.key} }
+
+func (x *XAdd) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	id, ok, err := storage.Streams().XAdd(ctx, x.key, x.id, x.fields, x.options)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if !ok {
+		return protocol.NewNullBulkStringResponse()
+	}
+	return protocol.NewSuccessResponse(idValue(id))
+}
+
+type XAddParser struct{}
+
+func NewXAddParser() *XAddParser {
+	return &XAddParser{}
+}
+
+// Parse parses XADD key [NOMKSTREAM] [MAXLEN | MINID [= | ~] threshold [LIMIT count]]
+// * | id field value [field value ...].
+func (p *XAddParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 4 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 4, len(msg.Args))
+	}
+	cmd := &XAdd{key: msg.Args[0]}
+	var trim trimParser
+	args := msg.Args[1:]
+	for len(args) > 0 {
+		if strings.EqualFold(args[0], "NOMKSTREAM") {
+			cmd.options.NoMkStream = true
+			args = args[1:]
+			continue
+		}
+		taken, err := trim.parse(args)
+		if err != nil {
+			return nil, err
+		}
+		if taken == 0 {
+			break
+		}
+		args = args[taken:]
+	}
+	var err error
+	if cmd.options.Trim, err = trim.finish(); err != nil {
+		return nil, err
+	}
+	if len(args) < 3 || len(args)%2 == 0 {
+		return nil, errXAddArgumentsCount
+	}
+	if cmd.id, err = streams.ParseAddID(args[0]); err != nil {
+		return nil, err
+	}
+	cmd.fields = args[1:]
+	return cmd, nil
+}
+
+func (p *XAddParser) Name() string {
+	return "XADD"
+}
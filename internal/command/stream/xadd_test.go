@@ -0,0 +1,86 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/streams"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXAddCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st).Times(3)
+	auto := streams.AddID{AutoMs: true, AutoSeq: true}
+	fields := []string{"f", "v"}
+	st.EXPECT().XAdd(ctx, "stream", auto, fields, streams.AddOptions{}).Return(streams.ID{Ms: 5, Seq: 1}, true, nil)
+	st.EXPECT().XAdd(ctx, "missing", auto, fields, streams.AddOptions{NoMkStream: true}).Return(streams.ID{}, false, nil)
+	st.EXPECT().XAdd(ctx, "stream", streams.AddID{ID: streams.ID{Ms: 1}}, fields, streams.AddOptions{}).Return(streams.ID{}, false, streams.ErrIDTooSmall)
+
+	response := (&XAdd{key: "stream", id: auto, fields: fields}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("5-1"), response.Value.Bytes)
+
+	response = (&XAdd{key: "missing", id: auto, fields: fields, options: streams.AddOptions{NoMkStream: true}}).Execute(ctx, storage)
+	assert.True(t, response.Value.Null)
+
+	response = (&XAdd{key: "stream", id: streams.AddID{ID: streams.ID{Ms: 1}}, fields: fields}).Execute(ctx, storage)
+	assert.Equal(t, streams.ErrIDTooSmall, response.Err)
+}
+
+func TestXAddParser_Parse(t *testing.T) {
+	parser := NewXAddParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "XADD", Args: []string{"stream", "nomkstream", "MAXLEN", "~", "10", "LIMIT", "5", "*", "f", "v", "g", "w"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XAdd{
+		key:    "stream",
+		id:     streams.AddID{AutoMs: true, AutoSeq: true},
+		fields: []string{"f", "v", "g", "w"},
+		options: streams.AddOptions{
+			NoMkStream: true,
+			Trim:       streams.TrimOptions{Strategy: streams.TrimMaxLen, MaxLen: 10, Approx: true, Limit: 5},
+		},
+	}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "XADD", Args: []string{"stream", "MINID", "3-1", "4-*", "f", "v"}})
+	assert.NoError(t, err)
+	assert.Equal(t, streams.AddID{ID: streams.ID{Ms: 4}, AutoSeq: true}, cmd.(*XAdd).id)
+	assert.Equal(t, streams.TrimOptions{Strategy: streams.TrimMinID, MinID: streams.ID{Ms: 3, Seq: 1}, Limit: -1}, cmd.(*XAdd).options.Trim)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "XADD", Args: []string{"stream", "1-1", "MAXLEN", "5"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"MAXLEN", "5"}, cmd.(*XAdd).fields, "options stop at the ID")
+	assert.Equal(t, streams.TrimNone, cmd.(*XAdd).options.Trim.Strategy)
+}
+
+func TestXAddParser_ParseErrors(t *testing.T) {
+	parser := NewXAddParser()
+	for _, tt := range []struct {
+		args     []string
+		expected error
+	}{
+		{[]string{"stream", "*", "f", "v", "g"}, errXAddArgumentsCount},
+		{[]string{"stream", "MAXLEN", "5", "*", "f"}, errXAddArgumentsCount},
+		{[]string{"stream", "MAXLEN", "-1", "*", "f", "v"}, errNegativeMaxLen},
+		{[]string{"stream", "MAXLEN", "x", "*", "f", "v"}, command.ErrNotInteger},
+		{[]string{"stream", "MAXLEN", "5", "LIMIT", "1", "*", "f", "v"}, errLimitWithoutApprox},
+		{[]string{"stream", "MAXLEN", "~", "5", "LIMIT", "-1", "*", "f", "v"}, errNegativeLimit},
+		{[]string{"stream", "MAXLEN", "5", "MINID", "1", "*", "f", "v"}, errMaxLenAndMinID},
+		{[]string{"stream", "MINID", "x", "*", "f", "v"}, streams.ErrInvalidID},
+		{[]string{"stream", "0-0", "f", "v"}, streams.ErrIDZero},
+		{[]string{"stream", "abc", "f", "v"}, streams.ErrInvalidID},
+	} {
+		_, err := parser.Parse(&protocol.Message{Command: "XADD", Args: tt.args})
+		assert.Equal(t, tt.expected, err, tt.args)
+	}
+	_, err := parser.Parse(&protocol.Message{Command: "XADD", Args: []string{"stream", "*", "f"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultAutoClaimCount = 100
+
+var (
+	errXAutoClaimMinIdle = errors.New("ERR Invalid min-idle-time argument for XAUTOCLAIM")
+	errAutoClaimCount    = errors.New("ERR COUNT must be > 0")
+)
+
+// XAutoClaim transfers to a consumer the pending entries idle for long enough, scanning them from
+// an ID, and replies the ID to resume scanning from, the entries claimed, or only their IDs with
+// JUSTID, and the IDs of the pending entries found deleted from the stream.
+type XAutoClaim struct {
+	key, group, consumer string
+	minIdle              time.Duration
+	start                streams.ID
+	count                int
+	justID               bool
+}
+
+func (x *XAutoClaim) DenyOOM() {}
+
+func (x *XAutoClaim) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	result, err := storage.Streams().XAutoClaim(ctx, x.key, x.group, x.consumer, x.minIdle, x.start, x.count, x.justID)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue([]protocol.Value{
+		idValue(result.Next),
+		claimedValue(result.Claimed, x.justID),
+		idsValue(result.Deleted),
+	}))
+}
+
+type XAutoClaimParser struct{}
+
+func NewXAutoClaimParser() *XAutoClaimParser {
+	return &XAutoClaimParser{}
+}
+
+// Parse parses XAUTOCLAIM key group consumer min-idle-time start [COUNT count] [JUSTID].
+func (p *XAutoClaimParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 5 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 5, len(msg.Args))
+	}
+	cmd := &XAutoClaim{key: msg.Args[0], group: msg.Args[1], consumer: msg.Args[2], count: defaultAutoClaimCount}
+	var err error
+	if cmd.minIdle, err = parseMinIdle(msg.Args[3], errXAutoClaimMinIdle); err != nil {
+		return nil, err
+	}
+	if cmd.start, err = streams.ParseRangeStart(msg.Args[4]); err != nil {
+		return nil, err
+	}
+	args := msg.Args[5:]
+	for len(args) > 0 {
+		switch option := strings.ToUpper(args[0]); {
+		case option == "COUNT" && len(args) > 1:
+			count, err := strconv.Atoi(args[1])
+			if err != nil || count < 1 {
+				return nil, errAutoClaimCount
+			}
+			cmd.count = count
+			args = args[2:]
+		case option == "JUSTID":
+			cmd.justID = true
+			args = args[1:]
+		default:
+			return nil, command.ErrSyntax
+		}
+	}
+	return cmd, nil
+}
+
+func (p *XAutoClaimParser) Name() string {
+	return "XAUTOCLAIM"
+}
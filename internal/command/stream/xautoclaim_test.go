@@ -0,0 +1,60 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/streams"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXAutoClaimCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st)
+	st.EXPECT().XAutoClaim(ctx, "stream", "group", "bob", time.Second, streams.MinID, 10, true).Return(streams.AutoClaimResult{
+		Next:    streams.ID{Ms: 5},
+		Claimed: []streams.Entry{{ID: streams.ID{Ms: 1}, Fields: []string{"f", "v"}}},
+		Deleted: []streams.ID{{Ms: 2}},
+	}, nil)
+
+	response := (&XAutoClaim{key: "stream", group: "group", consumer: "bob", minIdle: time.Second, count: 10, justID: true}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewBulkStringProtocolValue([]byte("5-0")),
+		protocol.NewArrayProtocolValue([]protocol.Value{protocol.NewBulkStringProtocolValue([]byte("1-0"))}),
+		protocol.NewArrayProtocolValue([]protocol.Value{protocol.NewBulkStringProtocolValue([]byte("2-0"))}),
+	}), response.Value)
+}
+
+func TestXAutoClaimParser_Parse(t *testing.T) {
+	parser := NewXAutoClaimParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "XAUTOCLAIM", Args: []string{"stream", "group", "bob", "10", "-"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XAutoClaim{key: "stream", group: "group", consumer: "bob", minIdle: 10 * time.Millisecond, count: 100}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "XAUTOCLAIM", Args: []string{"stream", "group", "bob", "0", "(1", "count", "5", "JUSTID"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XAutoClaim{key: "stream", group: "group", consumer: "bob", start: streams.ID{Ms: 1, Seq: 1}, count: 5, justID: true}, cmd)
+
+	for _, tt := range []struct {
+		args     []string
+		expected error
+	}{
+		{[]string{"stream", "group", "bob", "x", "0"}, errXAutoClaimMinIdle},
+		{[]string{"stream", "group", "bob", "0", "x"}, streams.ErrInvalidID},
+		{[]string{"stream", "group", "bob", "0", "0", "COUNT", "0"}, errAutoClaimCount},
+		{[]string{"stream", "group", "bob", "0", "0", "COUNT"}, command.ErrSyntax},
+	} {
+		_, err := parser.Parse(&protocol.Message{Command: "XAUTOCLAIM", Args: tt.args})
+		assert.Equal(t, tt.expected, err, tt.args)
+	}
+}
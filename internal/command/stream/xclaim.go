@@ -0,0 +1,135 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errXClaimMinIdle    = errors.New("ERR Invalid min-idle-time argument for XCLAIM")
+	errXClaimIdle       = errors.New("ERR Invalid IDLE option argument for XCLAIM")
+	errXClaimTime       = errors.New("ERR Invalid TIME option argument for XCLAIM")
+	errXClaimRetryCount = errors.New("ERR Invalid RETRYCOUNT option argument for XCLAIM")
+)
+
+// XClaim transfers pending entries idle for long enough to a consumer and replies them, or only
+// their IDs with JUSTID.
+type XClaim struct {
+	key, group, consumer string
+	minIdle              time.Duration
+	ids                  []streams.ID
+	options              streams.ClaimOptions
+}
+
+func (x *XClaim) DenyOOM() {}
+
+func (x *XClaim) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	claimed, err := storage.Streams().XClaim(ctx, x.key, x.group, x.consumer, x.minIdle, x.ids, x.options)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewSuccessResponse(claimedValue(claimed, x.options.JustID))
+}
+
+// claimedValue replies the entries claimed, or only their IDs with justID.
+func claimedValue(claimed []streams.Entry, justID bool) protocol.Value {
+	if !justID {
+		return entriesValue(claimed)
+	}
+	ids := make([]streams.ID, len(claimed))
+	for i, entry := range claimed {
+		ids[i] = entry.ID
+	}
+	return idsValue(ids)
+}
+
+// parseMinIdle parses the min-idle-time of XCLAIM and XAUTOCLAIM, a negative one being taken as 0.
+func parseMinIdle(arg string, invalid error) (time.Duration, error) {
+	minIdle, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return 0, invalid
+	}
+	return time.Duration(max(minIdle, 0)) * time.Millisecond, nil
+}
+
+type XClaimParser struct{}
+
+func NewXClaimParser() *XClaimParser {
+	return &XClaimParser{}
+}
+
+// Parse parses XCLAIM key group consumer min-idle-time id [id ...] [IDLE ms]
+// [TIME unix-time-milliseconds] [RETRYCOUNT count] [FORCE] [JUSTID] [LASTID lastid].
+// The IDs run up to the first argument that is not one.
+func (p *XClaimParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 5 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 5, len(msg.Args))
+	}
+	cmd := &XClaim{key: msg.Args[0], group: msg.Args[1], consumer: msg.Args[2], options: streams.ClaimOptions{RetryCount: -1}}
+	var err error
+	if cmd.minIdle, err = parseMinIdle(msg.Args[3], errXClaimMinIdle); err != nil {
+		return nil, err
+	}
+	args := msg.Args[4:]
+	for len(args) > 0 {
+		id, err := streams.ParseID(args[0], 0)
+		if err != nil {
+			break
+		}
+		cmd.ids = append(cmd.ids, id)
+		args = args[1:]
+	}
+	deliveryTime := int64(-1)
+	for len(args) > 0 {
+		switch option := strings.ToUpper(args[0]); {
+		case option == "FORCE":
+			cmd.options.Force = true
+			args = args[1:]
+		case option == "JUSTID":
+			cmd.options.JustID = true
+			args = args[1:]
+		case option == "IDLE" && len(args) > 1:
+			idle, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return nil, errXClaimIdle
+			}
+			deliveryTime = time.Now().UnixMilli() - idle
+			args = args[2:]
+		case option == "TIME" && len(args) > 1:
+			if deliveryTime, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+				return nil, errXClaimTime
+			}
+			args = args[2:]
+		case option == "RETRYCOUNT" && len(args) > 1:
+			if cmd.options.RetryCount, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+				return nil, errXClaimRetryCount
+			}
+			args = args[2:]
+		case option == "LASTID" && len(args) > 1:
+			if cmd.options.LastID, err = streams.ParseID(args[1], 0); err != nil {
+				return nil, err
+			}
+			args = args[2:]
+		default:
+			return nil, fmt.Errorf("ERR Unrecognized XCLAIM option '%s'", args[0])
+		}
+	}
+	// A bogus delivery time is taken as now rather than refused, clients computing it from
+	// clocks that may disagree with ours.
+	if deliveryTime >= 0 {
+		cmd.options.DeliveryTime = time.UnixMilli(deliveryTime)
+	}
+	return cmd, nil
+}
+
+func (p *XClaimParser) Name() string {
+	return "XCLAIM"
+}
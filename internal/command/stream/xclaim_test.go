@@ -0,0 +1,77 @@
+package stream
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/streams"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXClaimCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	ids := []streams.ID{{Ms: 1}}
+	claimed := []streams.Entry{{ID: streams.ID{Ms: 1}, Fields: []string{"f", "v"}}}
+	storage.EXPECT().Streams().Return(st).Times(2)
+	st.EXPECT().XClaim(ctx, "stream", "group", "bob", time.Second, ids, streams.ClaimOptions{RetryCount: -1}).Return(claimed, nil)
+	st.EXPECT().XClaim(ctx, "stream", "group", "bob", time.Second, ids, streams.ClaimOptions{RetryCount: -1, JustID: true}).Return(claimed, nil)
+
+	response := (&XClaim{key: "stream", group: "group", consumer: "bob", minIdle: time.Second, ids: ids, options: streams.ClaimOptions{RetryCount: -1}}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, entriesValue(claimed), response.Value)
+
+	response = (&XClaim{key: "stream", group: "group", consumer: "bob", minIdle: time.Second, ids: ids, options: streams.ClaimOptions{RetryCount: -1, JustID: true}}).Execute(ctx, storage)
+	assert.Equal(t, protocol.NewArrayProtocolValue([]protocol.Value{protocol.NewBulkStringProtocolValue([]byte("1-0"))}), response.Value)
+}
+
+func TestXClaimParser_Parse(t *testing.T) {
+	parser := NewXClaimParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "XCLAIM", Args: []string{
+		"stream", "group", "bob", "-5", "1", "2-1", "TIME", "1000", "RETRYCOUNT", "3", "force", "JUSTID", "LASTID", "4",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XClaim{
+		key:      "stream",
+		group:    "group",
+		consumer: "bob",
+		ids:      []streams.ID{{Ms: 1}, {Ms: 2, Seq: 1}},
+		options: streams.ClaimOptions{
+			DeliveryTime: time.UnixMilli(1000),
+			RetryCount:   3,
+			Force:        true,
+			JustID:       true,
+			LastID:       streams.ID{Ms: 4},
+		},
+	}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "XCLAIM", Args: []string{"stream", "group", "bob", "10", "1", "IDLE", "60000"}})
+	assert.NoError(t, err)
+	deliveryTime := cmd.(*XClaim).options.DeliveryTime
+	assert.WithinDuration(t, time.Now().Add(-time.Minute), deliveryTime, time.Second)
+
+	cmd, _ = parser.Parse(&protocol.Message{Command: "XCLAIM", Args: []string{"stream", "group", "bob", "10", "1", "TIME", "-1"}})
+	assert.True(t, cmd.(*XClaim).options.DeliveryTime.IsZero())
+
+	for _, tt := range []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"stream", "group", "bob", "x", "1"}, errXClaimMinIdle.Error()},
+		{[]string{"stream", "group", "bob", "0", "1", "IDLE", "x"}, errXClaimIdle.Error()},
+		{[]string{"stream", "group", "bob", "0", "1", "TIME", "x"}, errXClaimTime.Error()},
+		{[]string{"stream", "group", "bob", "0", "1", "RETRYCOUNT", "x"}, errXClaimRetryCount.Error()},
+		{[]string{"stream", "group", "bob", "0", "1", "LASTID", "x"}, streams.ErrInvalidID.Error()},
+		{[]string{"stream", "group", "bob", "0", "1", "IDLE"}, "ERR Unrecognized XCLAIM option 'IDLE'"},
+	} {
+		_, err := parser.Parse(&protocol.Message{Command: "XCLAIM", Args: tt.args})
+		assert.EqualError(t, err, tt.expected, tt.args)
+	}
+}
@@ -0,0 +1,44 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+)
+
+// XDel removes entries from a stream and replies how many existed.
+type XDel struct {
+	key string
+	ids []streams.ID
+}
+
+func (x *XDel) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	deleted, err := storage.Streams().XDel(ctx, x.key, x.ids)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(deleted))
+}
+
+type XDelParser struct{}
+
+func NewXDelParser() *XDelParser {
+	return &XDelParser{}
+}
+
+func (p *XDelParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	ids, err := parseIDs(msg.Args[1:])
+	if err != nil {
+		return nil, err
+	}
+	return &XDel{key: msg.Args[0], ids: ids}, nil
+}
+
+func (p *XDelParser) Name() string {
+	return "XDEL"
+}
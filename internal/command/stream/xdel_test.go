@@ -0,0 +1,39 @@
+package stream
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/streams"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXDelCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	ids := []streams.ID{{Ms: 1}, {Ms: 2, Seq: 3}}
+	storage.EXPECT().Streams().Return(st)
+	st.EXPECT().XDel(ctx, "stream", ids).Return(1, nil)
+
+	response := (&XDel{key: "stream", ids: ids}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(1), response.Value.Number)
+}
+
+func TestXDelParser_Parse(t *testing.T) {
+	parser := NewXDelParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "XDEL", Args: []string{"stream", "1", "2-3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XDel{key: "stream", ids: []streams.ID{{Ms: 1}, {Ms: 2, Seq: 3}}}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "XDEL", Args: []string{"stream", "1", "*"}})
+	assert.Equal(t, streams.ErrInvalidID, err)
+	_, err = parser.Parse(&protocol.Message{Command: "XDEL", Args: []string{"stream"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,215 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var errEntriesRead = errors.New("ERR value for ENTRIESREAD must be positive or -1")
+
+var xgroupHelp = []string{
+	"XGROUP <subcommand> [<arg> [value] [opt] ...]. Subcommands are:",
+	"CREATE <key> <groupname> <id|$> [option]",
+	"    Create a new consumer group. Options are:",
+	"    * MKSTREAM",
+	"      Create the empty stream if it does not exist.",
+	"    * ENTRIESREAD entries_read",
+	"      Set the group's entries_read counter (internal use).",
+	"CREATECONSUMER <key> <groupname> <consumer>",
+	"    Create a new consumer in the specified group.",
+	"DELCONSUMER <key> <groupname> <consumer>",
+	"    Remove the specified consumer.",
+	"DESTROY <key> <groupname>",
+	"    Remove the specified group.",
+	"SETID <key> <groupname> <id|$> [ENTRIESREAD entries_read]",
+	"    Set the current group ID and entries_read counter.",
+	"HELP",
+	"    Print this help.",
+}
+
+// XGroupCreate creates a consumer group starting after an ID, creating the stream with MKSTREAM.
+type XGroupCreate struct {
+	key, group  string
+	start       streams.GroupStart
+	entriesRead int64
+	mkStream    bool
+}
+
+func (x *XGroupCreate) DenyOOM() {}
+
+func (x *XGroupCreate) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	err := storage.Streams().XGroupCreate(ctx, x.key, x.group, x.start, x.entriesRead, x.mkStream)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewSimpleStringResponse("OK")
+}
+
+// XGroupSetID sets the last ID delivered by a consumer group.
+type XGroupSetID struct {
+	key, group  string
+	start       streams.GroupStart
+	entriesRead int64
+}
+
+// WrittenKeys returns the stream, whose group may now have entries to deliver to blocked readers.
+func (x *XGroupSetID) WrittenKeys() []string {
+	return []string{x.key}
+}
+
+func (x *XGroupSetID) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	if err := storage.Streams().XGroupSetID(ctx, x.key, x.group, x.start, x.entriesRead); err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewSimpleStringResponse("OK")
+}
+
+// XGroupDestroy removes a consumer group along with its pending entries and replies 1, or 0 if
+// there was no such group.
+type XGroupDestroy struct {
+	key, group string
+}
+
+// WrittenKeys returns the stream, so that the readers blocked on the group destroyed are told.
+func (x *XGroupDestroy) WrittenKeys() []string {
+	return []string{x.key}
+}
+
+func (x *XGroupDestroy) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	destroyed, err := storage.Streams().XGroupDestroy(ctx, x.key, x.group)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if destroyed {
+		return protocol.NewNumberResponse(1)
+	}
+	return protocol.NewNumberResponse(0)
+}
+
+// XGroupCreateConsumer adds a consumer to a group and replies 1, or 0 if it was already there.
+type XGroupCreateConsumer struct {
+	key, group, consumer string
+}
+
+func (x *XGroupCreateConsumer) DenyOOM() {}
+
+func (x *XGroupCreateConsumer) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	created, err := storage.Streams().XGroupCreateConsumer(ctx, x.key, x.group, x.consumer)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if created {
+		return protocol.NewNumberResponse(1)
+	}
+	return protocol.NewNumberResponse(0)
+}
+
+// XGroupDelConsumer removes a consumer from a group and replies how many entries were pending
+// for it, which are no longer pending.
+type XGroupDelConsumer struct {
+	key, group, consumer string
+}
+
+func (x *XGroupDelConsumer) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	pending, err := storage.Streams().XGroupDelConsumer(ctx, x.key, x.group, x.consumer)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(pending))
+}
+
+// XGroupHelp lists the XGROUP subcommands.
+type XGroupHelp struct{}
+
+func (x *XGroupHelp) Execute(_ context.Context, _ storage.Storage) *protocol.Response {
+	return protocol.NewArrayResponse(xgroupHelp)
+}
+
+type XGroupParser struct{}
+
+func NewXGroupParser() *XGroupParser {
+	return &XGroupParser{}
+}
+
+func (p *XGroupParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) == 0 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, 0)
+	}
+	subcommand, args := strings.ToUpper(msg.Args[0]), msg.Args[1:]
+	switch {
+	case subcommand == "CREATE" && len(args) >= 3 && len(args) <= 6:
+		return p.parseCreate(msg.Args[0], args)
+	case subcommand == "SETID" && len(args) >= 3 && len(args) <= 5:
+		start, entriesRead, _, err := p.parseStart(msg.Args[0], args[2:], false)
+		if err != nil {
+			return nil, err
+		}
+		return &XGroupSetID{key: args[0], group: args[1], start: start, entriesRead: entriesRead}, nil
+	case subcommand == "DESTROY" && len(args) == 2:
+		return &XGroupDestroy{key: args[0], group: args[1]}, nil
+	case subcommand == "CREATECONSUMER" && len(args) == 3:
+		return &XGroupCreateConsumer{key: args[0], group: args[1], consumer: args[2]}, nil
+	case subcommand == "DELCONSUMER" && len(args) == 3:
+		return &XGroupDelConsumer{key: args[0], group: args[1], consumer: args[2]}, nil
+	case subcommand == "HELP" && len(args) == 0:
+		return &XGroupHelp{}, nil
+	}
+	return nil, command.NewUnknownSubcommandError(p.Name(), msg.Args[0])
+}
+
+// parseCreate parses XGROUP CREATE key group id|$ [MKSTREAM] [ENTRIESREAD entries-read].
+func (p *XGroupParser) parseCreate(subcommand string, args []string) (command.Command, error) {
+	start, entriesRead, mkStream, err := p.parseStart(subcommand, args[2:], true)
+	if err != nil {
+		return nil, err
+	}
+	return &XGroupCreate{key: args[0], group: args[1], start: start, entriesRead: entriesRead, mkStream: mkStream}, nil
+}
+
+// parseStart parses the ID a group starts after and the options following it, MKSTREAM only
+// being allowed for CREATE.
+func (p *XGroupParser) parseStart(subcommand string, args []string, create bool) (streams.GroupStart, int64, bool, error) {
+	var start streams.GroupStart
+	if args[0] == "$" {
+		start.Last = true
+	} else {
+		id, err := streams.ParseID(args[0], 0)
+		if err != nil {
+			return start, 0, false, err
+		}
+		start.ID = id
+	}
+	entriesRead := int64(streams.UnknownEntriesRead)
+	mkStream := false
+	options := args[1:]
+	for len(options) > 0 {
+		switch {
+		case create && strings.EqualFold(options[0], "MKSTREAM"):
+			mkStream = true
+			options = options[1:]
+		case strings.EqualFold(options[0], "ENTRIESREAD") && len(options) > 1:
+			n, err := strconv.ParseInt(options[1], 10, 64)
+			if err != nil {
+				return start, 0, false, command.ErrNotInteger
+			}
+			if n < 0 && n != streams.UnknownEntriesRead {
+				return start, 0, false, errEntriesRead
+			}
+			entriesRead = n
+			options = options[2:]
+		default:
+			return start, 0, false, command.NewUnknownSubcommandError(p.Name(), subcommand)
+		}
+	}
+	return start, entriesRead, mkStream, nil
+}
+
+func (p *XGroupParser) Name() string {
+	return "XGROUP"
+}
@@ -0,0 +1,73 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/streams"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXGroupCommands_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st).Times(5)
+	st.EXPECT().XGroupCreate(ctx, "stream", "group", streams.GroupStart{Last: true}, int64(-1), true).Return(streams.ErrBusyGroup)
+	st.EXPECT().XGroupSetID(ctx, "stream", "group", streams.GroupStart{ID: streams.ID{Ms: 1}}, int64(1)).Return(nil)
+	st.EXPECT().XGroupDestroy(ctx, "stream", "group").Return(true, nil)
+	st.EXPECT().XGroupCreateConsumer(ctx, "stream", "group", "alice").Return(false, nil)
+	st.EXPECT().XGroupDelConsumer(ctx, "stream", "group", "alice").Return(2, nil)
+
+	response := (&XGroupCreate{key: "stream", group: "group", start: streams.GroupStart{Last: true}, entriesRead: -1, mkStream: true}).Execute(ctx, storage)
+	assert.Equal(t, streams.ErrBusyGroup, response.Err)
+	response = (&XGroupSetID{key: "stream", group: "group", start: streams.GroupStart{ID: streams.ID{Ms: 1}}, entriesRead: 1}).Execute(ctx, storage)
+	assert.Equal(t, "OK", response.Value.Str)
+	response = (&XGroupDestroy{key: "stream", group: "group"}).Execute(ctx, storage)
+	assert.Equal(t, int64(1), response.Value.Number)
+	response = (&XGroupCreateConsumer{key: "stream", group: "group", consumer: "alice"}).Execute(ctx, storage)
+	assert.Equal(t, int64(0), response.Value.Number)
+	response = (&XGroupDelConsumer{key: "stream", group: "group", consumer: "alice"}).Execute(ctx, storage)
+	assert.Equal(t, int64(2), response.Value.Number)
+}
+
+func TestXGroupParser_Parse(t *testing.T) {
+	parser := NewXGroupParser()
+	for _, tt := range []struct {
+		args     []string
+		expected command.Command
+	}{
+		{[]string{"create", "stream", "group", "$"}, &XGroupCreate{key: "stream", group: "group", start: streams.GroupStart{Last: true}, entriesRead: -1}},
+		{[]string{"CREATE", "stream", "group", "5", "ENTRIESREAD", "3", "MKSTREAM"}, &XGroupCreate{key: "stream", group: "group", start: streams.GroupStart{ID: streams.ID{Ms: 5}}, entriesRead: 3, mkStream: true}},
+		{[]string{"SETID", "stream", "group", "1-2"}, &XGroupSetID{key: "stream", group: "group", start: streams.GroupStart{ID: streams.ID{Ms: 1, Seq: 2}}, entriesRead: -1}},
+		{[]string{"DESTROY", "stream", "group"}, &XGroupDestroy{key: "stream", group: "group"}},
+		{[]string{"CREATECONSUMER", "stream", "group", "alice"}, &XGroupCreateConsumer{key: "stream", group: "group", consumer: "alice"}},
+		{[]string{"DELCONSUMER", "stream", "group", "alice"}, &XGroupDelConsumer{key: "stream", group: "group", consumer: "alice"}},
+		{[]string{"HELP"}, &XGroupHelp{}},
+	} {
+		cmd, err := parser.Parse(&protocol.Message{Command: "XGROUP", Args: tt.args})
+		assert.NoError(t, err, tt.args)
+		assert.Equal(t, tt.expected, cmd, tt.args)
+	}
+
+	for _, tt := range []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"CREATE", "stream", "group", "x"}, streams.ErrInvalidID.Error()},
+		{[]string{"CREATE", "stream", "group", "$", "ENTRIESREAD", "-2"}, errEntriesRead.Error()},
+		{[]string{"CREATE", "stream", "group", "$", "ENTRIESREAD", "x"}, command.ErrNotInteger.Error()},
+		{[]string{"SETID", "stream", "group", "$", "MKSTREAM"}, "ERR unknown subcommand or wrong number of arguments for 'SETID'. Try XGROUP HELP."},
+		{[]string{"DESTROY", "stream"}, "ERR unknown subcommand or wrong number of arguments for 'DESTROY'. Try XGROUP HELP."},
+		{[]string{"RENAME", "stream", "group"}, "ERR unknown subcommand or wrong number of arguments for 'RENAME'. Try XGROUP HELP."},
+	} {
+		_, err := parser.Parse(&protocol.Message{Command: "XGROUP", Args: tt.args})
+		assert.EqualError(t, err, tt.expected, tt.args)
+	}
+}
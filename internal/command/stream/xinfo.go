@@ -0,0 +1,293 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultFullCount is how many entries, pending entries and consumer pending entries XINFO STREAM
+// FULL lists unless COUNT says otherwise.
+const defaultFullCount = 10
+
+var xinfoHelp = []string{
+	"XINFO <subcommand> [<arg> [value] [opt] ...]. Subcommands are:",
+	"CONSUMERS <key> <groupname>",
+	"    Show consumers of <groupname>.",
+	"GROUPS <key>",
+	"    Show the stream consumer groups.",
+	"STREAM <key> [FULL [COUNT <count>]",
+	"    Show information about the stream.",
+	"HELP",
+	"    Print this help.",
+}
+
+// XInfoStream describes a stream: its length, its radix tree, the IDs it tracks and its first
+// and last entries.
+type XInfoStream struct {
+	key string
+}
+
+func (x *XInfoStream) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	info, err := storage.Streams().XInfo(ctx, x.key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewMapResponse([]protocol.MapEntry{
+		numberEntry("length", int64(info.Length)),
+		numberEntry("radix-tree-keys", int64(info.RadixTreeKeys)),
+		numberEntry("radix-tree-nodes", int64(info.RadixTreeNodes)),
+		{Key: "last-generated-id", Val: idValue(info.LastGeneratedID)},
+		{Key: "max-deleted-entry-id", Val: idValue(info.MaxDeletedEntryID)},
+		numberEntry("entries-added", int64(info.EntriesAdded)),
+		{Key: "recorded-first-entry-id", Val: idValue(info.RecordedFirstEntryID)},
+		numberEntry("groups", int64(info.Groups)),
+		optionalEntry("first-entry", info.FirstEntry),
+		optionalEntry("last-entry", info.LastEntry),
+	})
+}
+
+// XInfoStreamFull describes a stream like XInfoStream, listing its entries and the pending
+// entries and consumers of its groups rather than summing them up. Count caps each list, 0 lists
+// them all.
+type XInfoStreamFull struct {
+	key   string
+	count int
+}
+
+func (x *XInfoStreamFull) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	st := storage.Streams()
+	info, err := st.XInfo(ctx, x.key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	count := x.count
+	if count == 0 {
+		count = math.MaxInt
+	}
+	entries, err := st.XRange(ctx, x.key, streams.MinID, streams.MaxID, count, false)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	groups, err := st.XInfoGroups(ctx, x.key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	now := time.Now()
+	groupValues := make([]protocol.Value, len(groups))
+	for i, group := range groups {
+		groupValues[i], err = x.groupValue(ctx, st, group, count, now)
+		if err != nil {
+			return protocol.NewErrorResponse(err)
+		}
+	}
+	return protocol.NewMapResponse([]protocol.MapEntry{
+		numberEntry("length", int64(info.Length)),
+		numberEntry("radix-tree-keys", int64(info.RadixTreeKeys)),
+		numberEntry("radix-tree-nodes", int64(info.RadixTreeNodes)),
+		{Key: "last-generated-id", Val: idValue(info.LastGeneratedID)},
+		{Key: "max-deleted-entry-id", Val: idValue(info.MaxDeletedEntryID)},
+		numberEntry("entries-added", int64(info.EntriesAdded)),
+		{Key: "recorded-first-entry-id", Val: idValue(info.RecordedFirstEntryID)},
+		{Key: "entries", Val: entriesValue(entries)},
+		{Key: "groups", Val: protocol.NewArrayProtocolValue(groupValues)},
+	})
+}
+
+// groupValue describes group with up to count of its pending entries and of the pending entries
+// of each of its consumers. Delivery, seen and active times are Unix times in milliseconds.
+func (x *XInfoStreamFull) groupValue(ctx context.Context, st streams.Streams, group streams.GroupInfo, count int, now time.Time) (protocol.Value, error) {
+	pending, err := st.XPendingRange(ctx, x.key, group.Name, streams.PendingRange{Start: streams.MinID, End: streams.MaxID, Count: count})
+	if err != nil {
+		return protocol.Value{}, err
+	}
+	pendingValues := make([]protocol.Value, len(pending))
+	for i, entry := range pending {
+		pendingValues[i] = protocol.NewArrayProtocolValue([]protocol.Value{
+			idValue(entry.ID),
+			protocol.NewBulkStringProtocolValue([]byte(entry.Consumer)),
+			protocol.NewNumberProtocolValue(now.Add(-entry.Idle).UnixMilli()),
+			protocol.NewNumberProtocolValue(entry.DeliveryCount),
+		})
+	}
+	consumers, err := st.XInfoConsumers(ctx, x.key, group.Name)
+	if err != nil {
+		return protocol.Value{}, err
+	}
+	consumerValues := make([]protocol.Value, len(consumers))
+	for i, consumer := range consumers {
+		selection := streams.PendingRange{Start: streams.MinID, End: streams.MaxID, Count: count, Consumer: consumer.Name}
+		consumerPending, err := st.XPendingRange(ctx, x.key, group.Name, selection)
+		if err != nil {
+			return protocol.Value{}, err
+		}
+		consumerPendingValues := make([]protocol.Value, len(consumerPending))
+		for j, entry := range consumerPending {
+			consumerPendingValues[j] = protocol.NewArrayProtocolValue([]protocol.Value{
+				idValue(entry.ID),
+				protocol.NewNumberProtocolValue(now.Add(-entry.Idle).UnixMilli()),
+				protocol.NewNumberProtocolValue(entry.DeliveryCount),
+			})
+		}
+		active := int64(-1)
+		if consumer.Inactive >= 0 {
+			active = now.Add(-consumer.Inactive).UnixMilli()
+		}
+		consumerValues[i] = protocol.NewMapProtocolValue([]protocol.MapEntry{
+			{Key: "name", Val: protocol.NewBulkStringProtocolValue([]byte(consumer.Name))},
+			numberEntry("seen-time", now.Add(-consumer.Idle).UnixMilli()),
+			numberEntry("active-time", active),
+			numberEntry("pel-count", int64(consumer.Pending)),
+			{Key: "pending", Val: protocol.NewArrayProtocolValue(consumerPendingValues)},
+		})
+	}
+	return protocol.NewMapProtocolValue([]protocol.MapEntry{
+		{Key: "name", Val: protocol.NewBulkStringProtocolValue([]byte(group.Name))},
+		{Key: "last-delivered-id", Val: idValue(group.LastDeliveredID)},
+		knownEntry("entries-read", group.EntriesRead),
+		knownEntry("lag", group.Lag),
+		numberEntry("pel-count", int64(group.Pending)),
+		{Key: "pending", Val: protocol.NewArrayProtocolValue(pendingValues)},
+		{Key: "consumers", Val: protocol.NewArrayProtocolValue(consumerValues)},
+	}), nil
+}
+
+// XInfoGroups describes the consumer groups of a stream: their consumers, pending entries, last
+// delivered ID, and how many entries they read and are lagging behind, nil when it cannot be told.
+type XInfoGroups struct {
+	key string
+}
+
+func (x *XInfoGroups) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	groups, err := storage.Streams().XInfoGroups(ctx, x.key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	values := make([]protocol.Value, len(groups))
+	for i, group := range groups {
+		values[i] = protocol.NewMapProtocolValue([]protocol.MapEntry{
+			{Key: "name", Val: protocol.NewBulkStringProtocolValue([]byte(group.Name))},
+			numberEntry("consumers", int64(group.Consumers)),
+			numberEntry("pending", int64(group.Pending)),
+			{Key: "last-delivered-id", Val: idValue(group.LastDeliveredID)},
+			knownEntry("entries-read", group.EntriesRead),
+			knownEntry("lag", group.Lag),
+		})
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue(values))
+}
+
+// XInfoConsumers describes the consumers of a group: their pending entries, and the milliseconds
+// since they last attempted an interaction and since they last read or claimed an entry, -1 if
+// they never did.
+type XInfoConsumers struct {
+	key, group string
+}
+
+func (x *XInfoConsumers) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	consumers, err := storage.Streams().XInfoConsumers(ctx, x.key, x.group)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	values := make([]protocol.Value, len(consumers))
+	for i, consumer := range consumers {
+		inactive := int64(-1)
+		if consumer.Inactive >= 0 {
+			inactive = consumer.Inactive.Milliseconds()
+		}
+		values[i] = protocol.NewMapProtocolValue([]protocol.MapEntry{
+			{Key: "name", Val: protocol.NewBulkStringProtocolValue([]byte(consumer.Name))},
+			numberEntry("pending", int64(consumer.Pending)),
+			numberEntry("idle", consumer.Idle.Milliseconds()),
+			numberEntry("inactive", inactive),
+		})
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue(values))
+}
+
+func numberEntry(key string, n int64) protocol.MapEntry {
+	return protocol.MapEntry{Key: key, Val: protocol.NewNumberProtocolValue(n)}
+}
+
+// knownEntry replies n, or nil if it is streams.UnknownEntriesRead.
+func knownEntry(key string, n int64) protocol.MapEntry {
+	if n == streams.UnknownEntriesRead {
+		return protocol.MapEntry{Key: key, Val: protocol.NewNullBulkStringProtocolValue()}
+	}
+	return numberEntry(key, n)
+}
+
+// optionalEntry replies entry, or nil if there is none.
+func optionalEntry(key string, entry *streams.Entry) protocol.MapEntry {
+	if entry == nil {
+		return protocol.MapEntry{Key: key, Val: protocol.NewNullBulkStringProtocolValue()}
+	}
+	return protocol.MapEntry{Key: key, Val: entryValue(*entry)}
+}
+
+// XInfoHelp lists the XINFO subcommands.
+type XInfoHelp struct{}
+
+func (x *XInfoHelp) Execute(_ context.Context, _ storage.Storage) *protocol.Response {
+	return protocol.NewArrayResponse(xinfoHelp)
+}
+
+type XInfoParser struct{}
+
+func NewXInfoParser() *XInfoParser {
+	return &XInfoParser{}
+}
+
+// Parse parses XINFO STREAM key [FULL [COUNT count]], XINFO GROUPS key, XINFO CONSUMERS key group
+// and XINFO HELP. Known subcommands followed by arguments they do not take are syntax errors.
+func (p *XInfoParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) == 0 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, 0)
+	}
+	switch subcommand := strings.ToUpper(msg.Args[0]); {
+	case subcommand == "HELP" && len(msg.Args) == 1:
+		return &XInfoHelp{}, nil
+	case subcommand == "STREAM" && len(msg.Args) == 2:
+		return &XInfoStream{key: msg.Args[1]}, nil
+	case subcommand == "STREAM" && len(msg.Args) > 2:
+		return parseXInfoStreamFull(msg.Args[1], msg.Args[2:])
+	case subcommand == "GROUPS" && len(msg.Args) == 2:
+		return &XInfoGroups{key: msg.Args[1]}, nil
+	case subcommand == "CONSUMERS" && len(msg.Args) == 3:
+		return &XInfoConsumers{key: msg.Args[1], group: msg.Args[2]}, nil
+	}
+	return nil, command.NewUnknownSubcommandError(p.Name(), msg.Args[0])
+}
+
+// parseXInfoStreamFull parses the FULL [COUNT count] options of XINFO STREAM. A negative count
+// stands for the default one, as in Redis.
+func parseXInfoStreamFull(key string, options []string) (command.Command, error) {
+	if !strings.EqualFold(options[0], "FULL") {
+		return nil, command.ErrSyntax
+	}
+	full := &XInfoStreamFull{key: key, count: defaultFullCount}
+	switch {
+	case len(options) == 1:
+		return full, nil
+	case len(options) != 3 || !strings.EqualFold(options[1], "COUNT"):
+		return nil, command.ErrSyntax
+	}
+	count, err := strconv.Atoi(options[2])
+	if err != nil {
+		return nil, command.ErrNotInteger
+	}
+	if count >= 0 {
+		full.count = count
+	}
+	return full, nil
+}
+
+func (p *XInfoParser) Name() string {
+	return "XINFO"
+}
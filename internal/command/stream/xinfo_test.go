@@ -0,0 +1,201 @@
+package stream
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/keyspace"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/streams"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXInfoStreamCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st).Times(2)
+	first := &streams.Entry{ID: streams.ID{Ms: 1}, Fields: []string{"f", "v"}}
+	st.EXPECT().XInfo(ctx, "stream").Return(streams.Info{
+		Length:               1,
+		RadixTreeKeys:        1,
+		RadixTreeNodes:       2,
+		LastGeneratedID:      streams.ID{Ms: 3},
+		MaxDeletedEntryID:    streams.ID{Ms: 2},
+		EntriesAdded:         3,
+		RecordedFirstEntryID: streams.ID{Ms: 1},
+		Groups:               2,
+		FirstEntry:           first,
+		LastEntry:            first,
+	}, nil)
+	st.EXPECT().XInfo(ctx, "missing").Return(streams.Info{}, keyspace.ErrNoSuchKey)
+
+	response := (&XInfoStream{key: "stream"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	reply, _ := response.Value.AsMap()
+	assert.Equal(t, []protocol.MapEntry{
+		numberEntry("length", 1),
+		numberEntry("radix-tree-keys", 1),
+		numberEntry("radix-tree-nodes", 2),
+		{Key: "last-generated-id", Val: protocol.NewBulkStringProtocolValue([]byte("3-0"))},
+		{Key: "max-deleted-entry-id", Val: protocol.NewBulkStringProtocolValue([]byte("2-0"))},
+		numberEntry("entries-added", 3),
+		{Key: "recorded-first-entry-id", Val: protocol.NewBulkStringProtocolValue([]byte("1-0"))},
+		numberEntry("groups", 2),
+		{Key: "first-entry", Val: entryValue(*first)},
+		{Key: "last-entry", Val: entryValue(*first)},
+	}, reply)
+
+	response = (&XInfoStream{key: "missing"}).Execute(ctx, storage)
+	assert.Equal(t, keyspace.ErrNoSuchKey, response.Err)
+}
+
+func TestXInfoStreamFullCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st)
+	entry := streams.Entry{ID: streams.ID{Ms: 1}, Fields: []string{"f", "v"}}
+	st.EXPECT().XInfo(ctx, "stream").Return(streams.Info{Length: 1, LastGeneratedID: streams.ID{Ms: 1}, EntriesAdded: 1, Groups: 1}, nil)
+	st.EXPECT().XRange(ctx, "stream", streams.MinID, streams.MaxID, 2, false).Return([]streams.Entry{entry}, nil)
+	st.EXPECT().XInfoGroups(ctx, "stream").Return([]streams.GroupInfo{
+		{Name: "group", Consumers: 1, Pending: 1, LastDeliveredID: streams.ID{Ms: 1}, EntriesRead: 1},
+	}, nil)
+	st.EXPECT().XPendingRange(ctx, "stream", "group", streams.PendingRange{Start: streams.MinID, End: streams.MaxID, Count: 2}).
+		Return([]streams.PendingEntry{{ID: streams.ID{Ms: 1}, Consumer: "alice", DeliveryCount: 1}}, nil)
+	st.EXPECT().XInfoConsumers(ctx, "stream", "group").Return([]streams.ConsumerInfo{{Name: "alice", Pending: 1, Inactive: -1}}, nil)
+	st.EXPECT().XPendingRange(ctx, "stream", "group", streams.PendingRange{Start: streams.MinID, End: streams.MaxID, Count: 2, Consumer: "alice"}).
+		Return([]streams.PendingEntry{{ID: streams.ID{Ms: 1}, Consumer: "alice", DeliveryCount: 1}}, nil)
+
+	before := time.Now().UnixMilli()
+	response := (&XInfoStreamFull{key: "stream", count: 2}).Execute(ctx, storage)
+	after := time.Now().UnixMilli()
+	assert.Nil(t, response.Err)
+	reply, _ := response.Value.AsMap()
+	assert.Equal(t, []string{"length", "radix-tree-keys", "radix-tree-nodes", "last-generated-id", "max-deleted-entry-id",
+		"entries-added", "recorded-first-entry-id", "entries", "groups"}, mapKeys(reply))
+	assert.Equal(t, entriesValue([]streams.Entry{entry}), reply[7].Val)
+
+	group, _ := reply[8].Val.Array[0].AsMap()
+	assert.Equal(t, []string{"name", "last-delivered-id", "entries-read", "lag", "pel-count", "pending", "consumers"}, mapKeys(group))
+	assert.Equal(t, numberEntry("pel-count", 1), group[4])
+	pending := group[5].Val.Array[0].Array
+	assert.Equal(t, "alice", string(pending[1].Bytes))
+	assert.GreaterOrEqual(t, pending[2].Number, before)
+	assert.LessOrEqual(t, pending[2].Number, after)
+	assert.Equal(t, int64(1), pending[3].Number)
+
+	consumer, _ := group[6].Val.Array[0].AsMap()
+	assert.Equal(t, []string{"name", "seen-time", "active-time", "pel-count", "pending"}, mapKeys(consumer))
+	assert.Equal(t, numberEntry("active-time", -1), consumer[2])
+	assert.Len(t, consumer[4].Val.Array[0].Array, 3)
+}
+
+func mapKeys(entries []protocol.MapEntry) []string {
+	keys := make([]string, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+func TestXInfoGroupsCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st)
+	st.EXPECT().XInfoGroups(ctx, "stream").Return([]streams.GroupInfo{
+		{Name: "group", Consumers: 1, Pending: 2, LastDeliveredID: streams.ID{Ms: 3}, EntriesRead: streams.UnknownEntriesRead, Lag: 4},
+	}, nil)
+
+	response := (&XInfoGroups{key: "stream"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewMapProtocolValue([]protocol.MapEntry{
+			{Key: "name", Val: protocol.NewBulkStringProtocolValue([]byte("group"))},
+			numberEntry("consumers", 1),
+			numberEntry("pending", 2),
+			{Key: "last-delivered-id", Val: protocol.NewBulkStringProtocolValue([]byte("3-0"))},
+			{Key: "entries-read", Val: protocol.NewNullBulkStringProtocolValue()},
+			numberEntry("lag", 4),
+		}),
+	}), response.Value)
+}
+
+func TestXInfoConsumersCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st).Times(2)
+	st.EXPECT().XInfoConsumers(ctx, "stream", "group").Return([]streams.ConsumerInfo{
+		{Name: "alice", Pending: 2, Idle: 20 * time.Millisecond, Inactive: 30 * time.Millisecond},
+		{Name: "bob", Idle: time.Second, Inactive: -1},
+	}, nil)
+	st.EXPECT().XInfoConsumers(ctx, "stream", "missing").Return(nil, streams.NewNoGroupError("stream", "missing"))
+
+	response := (&XInfoConsumers{key: "stream", group: "group"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewMapProtocolValue([]protocol.MapEntry{
+			{Key: "name", Val: protocol.NewBulkStringProtocolValue([]byte("alice"))},
+			numberEntry("pending", 2),
+			numberEntry("idle", 20),
+			numberEntry("inactive", 30),
+		}),
+		protocol.NewMapProtocolValue([]protocol.MapEntry{
+			{Key: "name", Val: protocol.NewBulkStringProtocolValue([]byte("bob"))},
+			numberEntry("pending", 0),
+			numberEntry("idle", 1000),
+			numberEntry("inactive", -1),
+		}),
+	}), response.Value)
+
+	response = (&XInfoConsumers{key: "stream", group: "missing"}).Execute(ctx, storage)
+	assert.EqualError(t, response.Err, "NOGROUP No such consumer group 'missing' for key name 'stream'")
+}
+
+func TestXInfoParser_Parse(t *testing.T) {
+	parser := NewXInfoParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "XINFO", Args: []string{"stream", "key"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XInfoStream{key: "key"}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "XINFO", Args: []string{"groups", "key"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XInfoGroups{key: "key"}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "XINFO", Args: []string{"CONSUMERS", "key", "group"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XInfoConsumers{key: "key", group: "group"}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "XINFO", Args: []string{"HELP"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XInfoHelp{}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "XINFO", Args: []string{"STREAM", "key", "full"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XInfoStreamFull{key: "key", count: 10}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "XINFO", Args: []string{"STREAM", "key", "FULL", "count", "0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XInfoStreamFull{key: "key", count: 0}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "XINFO", Args: []string{"STREAM", "key", "FULL", "COUNT", "x"}})
+	assert.EqualError(t, err, "ERR value is not an integer or out of range")
+	_, err = parser.Parse(&protocol.Message{Command: "XINFO", Args: []string{"STREAM", "key", "FULL", "COUNT"}})
+	assert.EqualError(t, err, "ERR syntax error")
+	_, err = parser.Parse(&protocol.Message{Command: "XINFO", Args: []string{"STREAM", "key", "extra"}})
+	assert.EqualError(t, err, "ERR syntax error")
+	_, err = parser.Parse(&protocol.Message{Command: "XINFO", Args: []string{"GROUPS", "key", "extra"}})
+	assert.EqualError(t, err, "ERR unknown subcommand or wrong number of arguments for 'GROUPS'. Try XINFO HELP.")
+	_, err = parser.Parse(&protocol.Message{Command: "XINFO", Args: []string{}})
+	assert.Error(t, err)
+}
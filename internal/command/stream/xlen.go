@@ -0,0 +1,37 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type XLen struct {
+	key string
+}
+
+func (x *XLen) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	length, err := storage.Streams().XLen(ctx, x.key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(length))
+}
+
+type XLenParser struct{}
+
+func NewXLenParser() *XLenParser {
+	return &XLenParser{}
+}
+
+func (p *XLenParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	return &XLen{key: msg.Args[0]}, nil
+}
+
+func (p *XLenParser) Name() string {
+	return "XLEN"
+}
@@ -0,0 +1,35 @@
+package stream
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXLenCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st)
+	st.EXPECT().XLen(ctx, "stream").Return(4, nil)
+
+	response := (&XLen{key: "stream"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(4), response.Value.Number)
+}
+
+func TestXLenParser_Parse(t *testing.T) {
+	parser := NewXLenParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "XLEN", Args: []string{"stream"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XLen{key: "stream"}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "XLEN", Args: []string{}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XPendingSummary replies the number of entries pending in a consumer group, the lowest and
+// highest of their IDs and the number of entries pending for each consumer.
+type XPendingSummary struct {
+	key, group string
+}
+
+func (x *XPendingSummary) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	summary, err := storage.Streams().XPending(ctx, x.key, x.group)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if summary.Count == 0 {
+		return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue([]protocol.Value{
+			protocol.NewNumberProtocolValue(0),
+			protocol.NewNullBulkStringProtocolValue(),
+			protocol.NewNullBulkStringProtocolValue(),
+			protocol.NewNullBulkStringProtocolValue(),
+		}))
+	}
+	consumers := make([]protocol.Value, len(summary.Consumers))
+	for i, consumer := range summary.Consumers {
+		consumers[i] = protocol.NewArrayProtocolValue([]protocol.Value{
+			protocol.NewBulkStringProtocolValue([]byte(consumer.Name)),
+			protocol.NewBulkStringProtocolValue([]byte(strconv.Itoa(consumer.Count))),
+		})
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewNumberProtocolValue(int64(summary.Count)),
+		idValue(summary.Lowest),
+		idValue(summary.Highest),
+		protocol.NewArrayProtocolValue(consumers),
+	}))
+}
+
+// XPendingRange lists the entries pending in a consumer group between two IDs, with the consumer
+// each was delivered to, the milliseconds elapsed since and the number of times it was delivered.
+type XPendingRange struct {
+	key, group string
+	selection  streams.PendingRange
+}
+
+func (x *XPendingRange) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	pending, err := storage.Streams().XPendingRange(ctx, x.key, x.group, x.selection)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	values := make([]protocol.Value, len(pending))
+	for i, entry := range pending {
+		values[i] = protocol.NewArrayProtocolValue([]protocol.Value{
+			idValue(entry.ID),
+			protocol.NewBulkStringProtocolValue([]byte(entry.Consumer)),
+			protocol.NewNumberProtocolValue(entry.Idle.Milliseconds()),
+			protocol.NewNumberProtocolValue(entry.DeliveryCount),
+		})
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue(values))
+}
+
+type XPendingParser struct{}
+
+func NewXPendingParser() *XPendingParser {
+	return &XPendingParser{}
+}
+
+// Parse parses XPENDING key group [[IDLE min-idle-time] start end count [consumer]].
+func (p *XPendingParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	key, group, args := msg.Args[0], msg.Args[1], msg.Args[2:]
+	if len(args) == 0 {
+		return &XPendingSummary{key: key, group: group}, nil
+	}
+	cmd := &XPendingRange{key: key, group: group}
+	if len(args) > 1 && strings.EqualFold(args[0], "IDLE") {
+		idle, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return nil, command.ErrNotInteger
+		}
+		cmd.selection.MinIdle = time.Duration(idle) * time.Millisecond
+		args = args[2:]
+	}
+	if len(args) < 3 || len(args) > 4 {
+		return nil, command.ErrSyntax
+	}
+	count, err := strconv.Atoi(args[2])
+	if err != nil {
+		return nil, command.ErrNotInteger
+	}
+	cmd.selection.Count = max(count, 0)
+	if cmd.selection.Start, err = streams.ParseRangeStart(args[0]); err != nil {
+		return nil, err
+	}
+	if cmd.selection.End, err = streams.ParseRangeEnd(args[1]); err != nil {
+		return nil, err
+	}
+	if len(args) == 4 {
+		cmd.selection.Consumer = args[3]
+	}
+	return cmd, nil
+}
+
+func (p *XPendingParser) Name() string {
+	return "XPENDING"
+}
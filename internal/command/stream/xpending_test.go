@@ -0,0 +1,107 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/streams"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXPendingSummaryCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st).Times(2)
+	st.EXPECT().XPending(ctx, "stream", "group").Return(streams.PendingSummary{
+		Count:     3,
+		Lowest:    streams.ID{Ms: 1},
+		Highest:   streams.ID{Ms: 3},
+		Consumers: []streams.ConsumerPending{{Name: "alice", Count: 3}},
+	}, nil)
+	st.EXPECT().XPending(ctx, "stream", "empty").Return(streams.PendingSummary{}, nil)
+
+	response := (&XPendingSummary{key: "stream", group: "group"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewNumberProtocolValue(3),
+		protocol.NewBulkStringProtocolValue([]byte("1-0")),
+		protocol.NewBulkStringProtocolValue([]byte("3-0")),
+		protocol.NewArrayProtocolValue([]protocol.Value{
+			protocol.NewArrayProtocolValue([]protocol.Value{
+				protocol.NewBulkStringProtocolValue([]byte("alice")),
+				protocol.NewBulkStringProtocolValue([]byte("3")),
+			}),
+		}),
+	}), response.Value)
+
+	response = (&XPendingSummary{key: "stream", group: "empty"}).Execute(ctx, storage)
+	assert.Equal(t, int64(0), response.Value.Array[0].Number)
+	assert.True(t, response.Value.Array[3].Null)
+}
+
+func TestXPendingRangeCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	selection := streams.PendingRange{Start: streams.MinID, End: streams.MaxID, Count: 10}
+	storage.EXPECT().Streams().Return(st)
+	st.EXPECT().XPendingRange(ctx, "stream", "group", selection).Return([]streams.PendingEntry{
+		{ID: streams.ID{Ms: 1}, Consumer: "alice", Idle: 1500 * time.Millisecond, DeliveryCount: 2},
+	}, nil)
+
+	response := (&XPendingRange{key: "stream", group: "group", selection: selection}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewArrayProtocolValue([]protocol.Value{
+			protocol.NewBulkStringProtocolValue([]byte("1-0")),
+			protocol.NewBulkStringProtocolValue([]byte("alice")),
+			protocol.NewNumberProtocolValue(1500),
+			protocol.NewNumberProtocolValue(2),
+		}),
+	}), response.Value)
+}
+
+func TestXPendingParser_Parse(t *testing.T) {
+	parser := NewXPendingParser()
+	for _, tt := range []struct {
+		args     []string
+		expected command.Command
+	}{
+		{[]string{"stream", "group"}, &XPendingSummary{key: "stream", group: "group"}},
+		{[]string{"stream", "group", "-", "+", "-1"}, &XPendingRange{key: "stream", group: "group", selection: streams.PendingRange{Start: streams.MinID, End: streams.MaxID}}},
+		{[]string{"stream", "group", "idle", "20", "(1", "5", "10", "alice"}, &XPendingRange{key: "stream", group: "group", selection: streams.PendingRange{
+			Start:    streams.ID{Ms: 1, Seq: 1},
+			End:      streams.ID{Ms: 5, Seq: streams.MaxID.Seq},
+			Count:    10,
+			MinIdle:  20 * time.Millisecond,
+			Consumer: "alice",
+		}}},
+	} {
+		cmd, err := parser.Parse(&protocol.Message{Command: "XPENDING", Args: tt.args})
+		assert.NoError(t, err, tt.args)
+		assert.Equal(t, tt.expected, cmd, tt.args)
+	}
+
+	for _, tt := range []struct {
+		args     []string
+		expected error
+	}{
+		{[]string{"stream", "group", "-"}, command.ErrSyntax},
+		{[]string{"stream", "group", "IDLE", "20", "-", "+"}, command.ErrSyntax},
+		{[]string{"stream", "group", "IDLE", "x", "-", "+", "1"}, command.ErrNotInteger},
+		{[]string{"stream", "group", "-", "+", "x"}, command.ErrNotInteger},
+		{[]string{"stream", "group", "x", "+", "1"}, streams.ErrInvalidID},
+	} {
+		_, err := parser.Parse(&protocol.Message{Command: "XPENDING", Args: tt.args})
+		assert.Equal(t, tt.expected, err, tt.args)
+	}
+}
@@ -0,0 +1,91 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+	"strconv"
+	"strings"
+)
+
+// XRange replies the entries of a stream between two IDs, from the highest with reverse.
+// A COUNT of 0 replies nil, as Redis does.
+type XRange struct {
+	key        string
+	start, end streams.ID
+	count      int
+	reverse    bool
+}
+
+func (x *XRange) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	entries, err := storage.Streams().XRange(ctx, x.key, x.start, x.end, x.count, x.reverse)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if x.count == 0 {
+		return protocol.NewNullBulkStringResponse()
+	}
+	return protocol.NewSuccessResponse(entriesValue(entries))
+}
+
+// parseRange parses XRANGE key start end [COUNT count], XREVRANGE taking end before start.
+func parseRange(name string, reverse bool, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 3 {
+		return nil, command.NewInvalidArgumentsCount(name, 3, len(msg.Args))
+	}
+	startArg, endArg := msg.Args[1], msg.Args[2]
+	if reverse {
+		startArg, endArg = endArg, startArg
+	}
+	cmd := &XRange{key: msg.Args[0], count: -1, reverse: reverse}
+	var err error
+	if cmd.start, err = streams.ParseRangeStart(startArg); err != nil {
+		return nil, err
+	}
+	if cmd.end, err = streams.ParseRangeEnd(endArg); err != nil {
+		return nil, err
+	}
+	args := msg.Args[3:]
+	for len(args) > 0 {
+		if len(args) < 2 || !strings.EqualFold(args[0], "COUNT") {
+			return nil, command.ErrSyntax
+		}
+		count, err := strconv.Atoi(args[1])
+		if err != nil {
+			return nil, command.ErrNotInteger
+		}
+		cmd.count = max(count, 0)
+		args = args[2:]
+	}
+	return cmd, nil
+}
+
+type XRangeParser struct{}
+
+func NewXRangeParser() *XRangeParser {
+	return &XRangeParser{}
+}
+
+func (p *XRangeParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseRange(p.Name(), false, msg)
+}
+
+func (p *XRangeParser) Name() string {
+	return "XRANGE"
+}
+
+type XRevRangeParser struct{}
+
+func NewXRevRangeParser() *XRevRangeParser {
+	return &XRevRangeParser{}
+}
+
+func (p *XRevRangeParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseRange(p.Name(), true, msg)
+}
+
+func (p *XRevRangeParser) Name() string {
+	return "XREVRANGE"
+}
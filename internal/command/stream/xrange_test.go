@@ -0,0 +1,67 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/streams"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXRangeCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st).Times(2)
+	st.EXPECT().XRange(ctx, "stream", streams.MinID, streams.MaxID, -1, true).
+		Return([]streams.Entry{{ID: streams.ID{Ms: 1, Seq: 2}, Fields: []string{"f", "v"}}}, nil)
+	st.EXPECT().XRange(ctx, "stream", streams.MinID, streams.MaxID, 0, false).Return(nil, nil)
+
+	response := (&XRange{key: "stream", start: streams.MinID, end: streams.MaxID, count: -1, reverse: true}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewArrayProtocolValue([]protocol.Value{
+			protocol.NewBulkStringProtocolValue([]byte("1-2")),
+			protocol.NewArrayProtocolValue([]protocol.Value{
+				protocol.NewBulkStringProtocolValue([]byte("f")),
+				protocol.NewBulkStringProtocolValue([]byte("v")),
+			}),
+		}),
+	}), response.Value)
+
+	response = (&XRange{key: "stream", start: streams.MinID, end: streams.MaxID}).Execute(ctx, storage)
+	assert.True(t, response.Value.Null)
+}
+
+func TestXRangeParser_Parse(t *testing.T) {
+	cmd, err := NewXRangeParser().Parse(&protocol.Message{Command: "XRANGE", Args: []string{"stream", "(1-1", "5", "count", "-3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XRange{key: "stream", start: streams.ID{Ms: 1, Seq: 2}, end: streams.ID{Ms: 5, Seq: math.MaxUint64}, count: 0}, cmd)
+
+	cmd, err = NewXRevRangeParser().Parse(&protocol.Message{Command: "XREVRANGE", Args: []string{"stream", "+", "-", "COUNT", "2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XRange{key: "stream", start: streams.MinID, end: streams.MaxID, count: 2, reverse: true}, cmd)
+
+	for _, tt := range []struct {
+		args     []string
+		expected error
+	}{
+		{[]string{"stream", "-", "+", "COUNT"}, command.ErrSyntax},
+		{[]string{"stream", "-", "+", "LIMIT", "1"}, command.ErrSyntax},
+		{[]string{"stream", "-", "+", "COUNT", "x"}, command.ErrNotInteger},
+		{[]string{"stream", "x", "+"}, streams.ErrInvalidID},
+		{[]string{"stream", "-", "(0-0"}, streams.ErrInvalidEndID},
+	} {
+		_, err := NewXRangeParser().Parse(&protocol.Message{Command: "XRANGE", Args: tt.args})
+		assert.Equal(t, tt.expected, err, tt.args)
+	}
+	_, err = NewXRangeParser().Parse(&protocol.Message{Command: "XRANGE", Args: []string{"stream", "-"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+	"errors"
+)
+
+var errNewIDRead = errors.New("ERR The > ID can be specified only when calling XREADGROUP using the GROUP <group> <consumer> option.")
+
+// readStream is a stream XREAD reads from, after its last ID when XREAD runs if last is set,
+// which XREAD spells $.
+type readStream struct {
+	streams.ReadStream
+	last bool
+}
+
+// XRead reads the entries of streams with an ID above the one given for each, and replies them
+// by stream, or nil if none was read. With BLOCK, it blocks until one of the streams gets entries
+// above its ID, replying only these.
+type XRead struct {
+	streams []readStream
+	count   int
+	block   bool
+	timeout float64
+}
+
+func (x *XRead) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	reads := make([]streams.ReadStream, len(x.streams))
+	keys := make([]string, len(x.streams))
+	for i, read := range x.streams {
+		reads[i], keys[i] = read.ReadStream, read.Key
+		if read.last {
+			last, err := storage.Streams().XLastID(ctx, read.Key)
+			if err != nil {
+				return protocol.NewErrorResponse(err)
+			}
+			reads[i].After = last
+		}
+	}
+	result, err := storage.Streams().XRead(ctx, reads, x.count)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if len(result) > 0 {
+		return protocol.NewMapResponse(streamEntriesValue(result))
+	}
+	if !x.block {
+		return protocol.NewNullBulkStringResponse()
+	}
+	// The blocked client waits for entries above the IDs $ stands for now.
+	return command.Block(ctx, keys, &xreadUnblocker{reads: reads, count: x.count}, x.timeout)
+}
+
+// xreadUnblocker serves a client blocked by XREAD once a stream gets entries above its ID.
+// Unlike a list pop, reading takes nothing, so every client blocked on the stream is served.
+type xreadUnblocker struct {
+	reads []streams.ReadStream
+	count int
+}
+
+// read returns the read of the stream stored at key.
+func (x *xreadUnblocker) read(key string) []streams.ReadStream {
+	for _, read := range x.reads {
+		if read.Key == key {
+			return []streams.ReadStream{read}
+		}
+	}
+	return nil
+}
+
+// Ready reports whether key holds entries above the ID the client reads after.
+func (x *xreadUnblocker) Ready(ctx context.Context, storage storage.Storage, key string) bool {
+	result, err := storage.Streams().XRead(ctx, x.read(key), 1)
+	return err == nil && len(result) > 0
+}
+
+func (x *xreadUnblocker) Unblock(ctx context.Context, storage storage.Storage, key string) *protocol.Response {
+	result, err := storage.Streams().XRead(ctx, x.read(key), x.count)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewMapResponse(streamEntriesValue(result))
+}
+
+type XReadParser struct{}
+
+func NewXReadParser() *XReadParser {
+	return &XReadParser{}
+}
+
+// Parse parses XREAD [COUNT count] [BLOCK milliseconds] STREAMS key [key ...] id [id ...].
+func (p *XReadParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 3 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 3, len(msg.Args))
+	}
+	read, err := parseReadArgs(p.Name(), msg.Args)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &XRead{streams: make([]readStream, len(read.keys)), count: read.count, block: read.block, timeout: read.timeout}
+	for i, key := range read.keys {
+		cmd.streams[i].Key = key
+		switch read.ids[i] {
+		case "$":
+			cmd.streams[i].last = true
+		case ">":
+			return nil, errNewIDRead
+		default:
+			id, err := streams.ParseID(read.ids[i], 0)
+			if err != nil {
+				return nil, err
+			}
+			cmd.streams[i].After = id
+		}
+	}
+	return cmd, nil
+}
+
+func (p *XReadParser) Name() string {
+	return "XREAD"
+}
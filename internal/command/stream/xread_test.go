@@ -0,0 +1,98 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	mockcommand "avacado/internal/command/mock"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/streams"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXReadCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st).AnyTimes()
+	cmd := &XRead{streams: []readStream{{ReadStream: streams.ReadStream{Key: "a", After: streams.ID{Ms: 1}}}, {ReadStream: streams.ReadStream{Key: "b"}, last: true}}, count: 2}
+	st.EXPECT().XLastID(ctx, "b").Return(streams.ID{Ms: 7}, nil)
+	reads := []streams.ReadStream{{Key: "a", After: streams.ID{Ms: 1}}, {Key: "b", After: streams.ID{Ms: 7}}}
+	st.EXPECT().XRead(ctx, reads, 2).
+		Return([]streams.StreamEntries{{Key: "a", Entries: []streams.Entry{{ID: streams.ID{Ms: 2}, Fields: []string{"f", "v"}}}}}, nil)
+
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	reply, _ := response.Value.AsMap()
+	assert.Len(t, reply, 1)
+	assert.Equal(t, "a", reply[0].Key)
+
+	st.EXPECT().XLastID(ctx, "b").Return(streams.ID{Ms: 7}, nil)
+	st.EXPECT().XRead(ctx, reads, 2).Return(nil, nil)
+	response = cmd.Execute(ctx, storage)
+	assert.True(t, response.Value.Null)
+	assert.Nil(t, response.BlockCh)
+}
+
+func TestXReadCommand_BlocksAfterTheLastID(t *testing.T) {
+	controller := gomock.NewController(t)
+	registry := mockcommand.NewMockBlockRegistry(controller)
+	ctx := command.ContextWithBlockRegistry(context.Background(), registry)
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st).AnyTimes()
+	cmd := &XRead{streams: []readStream{{ReadStream: streams.ReadStream{Key: "stream"}, last: true}}, block: true}
+	reads := []streams.ReadStream{{Key: "stream", After: streams.ID{Ms: 3}}}
+	unblocker := &xreadUnblocker{reads: reads}
+	st.EXPECT().XLastID(ctx, "stream").Return(streams.ID{Ms: 3}, nil)
+	st.EXPECT().XRead(ctx, reads, 0).Return(nil, nil)
+	registry.EXPECT().RegisterBlockedClient(ctx, []string{"stream"}, unblocker).Return(make(chan *protocol.Response, 1), context.CancelFunc(func() {}))
+
+	response := cmd.Execute(ctx, storage)
+	assert.NotNil(t, response.BlockCh)
+
+	st.EXPECT().XRead(ctx, reads, 1).Return(nil, nil)
+	assert.False(t, unblocker.Ready(ctx, storage, "stream"))
+	added := []streams.StreamEntries{{Key: "stream", Entries: []streams.Entry{{ID: streams.ID{Ms: 4}, Fields: []string{"f", "v"}}}}}
+	st.EXPECT().XRead(ctx, reads, 1).Return(added, nil)
+	assert.True(t, unblocker.Ready(ctx, storage, "stream"))
+	st.EXPECT().XRead(ctx, reads, 0).Return(added, nil)
+	reply, _ := unblocker.Unblock(ctx, storage, "stream").Value.AsMap()
+	assert.Equal(t, streamEntriesValue(added), reply)
+}
+
+func TestXReadParser_Parse(t *testing.T) {
+	parser := NewXReadParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "XREAD", Args: []string{"COUNT", "2", "block", "1500", "STREAMS", "a", "b", "1", "$"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XRead{
+		streams: []readStream{{ReadStream: streams.ReadStream{Key: "a", After: streams.ID{Ms: 1}}}, {ReadStream: streams.ReadStream{Key: "b"}, last: true}},
+		count:   2,
+		block:   true,
+		timeout: 1.5,
+	}, cmd)
+
+	for _, tt := range []struct {
+		args     []string
+		expected error
+	}{
+		{[]string{"STREAMS", "a", "b", "1"}, newUnbalancedStreamsError("XREAD")},
+		{[]string{"STREAMS", "a", ">"}, errNewIDRead},
+		{[]string{"STREAMS", "a", "x"}, streams.ErrInvalidID},
+		{[]string{"GROUP", "g", "alice", "STREAMS", "a", "1"}, newReadGroupOnlyError("GROUP")},
+		{[]string{"NOACK", "STREAMS", "a", "1"}, newReadGroupOnlyError("NOACK")},
+		{[]string{"BLOCK", "x", "STREAMS", "a", "1"}, errTimeoutNotInteger},
+		{[]string{"BLOCK", "-1", "STREAMS", "a", "1"}, errTimeoutNegative},
+		{[]string{"COUNT", "1", "a", "1"}, command.ErrSyntax},
+	} {
+		_, err := parser.Parse(&protocol.Message{Command: "XREAD", Args: tt.args})
+		assert.Equal(t, tt.expected, err, tt.args)
+	}
+	_, err = parser.Parse(&protocol.Message{Command: "XREAD", Args: []string{"STREAMS", "a"}})
+	assert.Error(t, err)
+}
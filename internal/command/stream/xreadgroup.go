@@ -0,0 +1,125 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+	"errors"
+)
+
+var (
+	errMissingGroup    = errors.New("ERR Missing GROUP option for XREADGROUP")
+	errLastIDReadGroup = errors.New("ERR The $ ID is meaningless in the context of XREADGROUP: you want to read the history of this consumer by specifying a proper ID, or use the > ID to get new messages. The $ ID would just return an empty result set.")
+	errGroupDestroyed  = errors.New("NOGROUP the consumer group this client was blocked on no longer exists")
+)
+
+// XReadGroup reads entries from streams as a consumer of a group: with the ID >, the entries
+// never delivered to the group, which become pending for the consumer unless NOACK is given,
+// and otherwise the entries pending for the consumer after the ID. It replies the entries read by
+// stream, or nil if none was. With BLOCK, it blocks until one of the streams gets entries never
+// delivered to the group, or its group is destroyed.
+type XReadGroup struct {
+	group, consumer string
+	streams         []streams.ReadGroupStream
+	count           int
+	noAck           bool
+	block           bool
+	timeout         float64
+}
+
+func (x *XReadGroup) DenyOOM() {}
+
+func (x *XReadGroup) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	result, err := storage.Streams().XReadGroup(ctx, x.group, x.consumer, x.streams, x.count, x.noAck)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if len(result) > 0 {
+		return protocol.NewMapResponse(streamEntriesValue(result))
+	}
+	if !x.block {
+		return protocol.NewNullBulkStringResponse()
+	}
+	// Nothing was read, so every stream is read with >.
+	keys := make([]string, len(x.streams))
+	for i, read := range x.streams {
+		keys[i] = read.Key
+	}
+	return command.Block(ctx, keys, x, x.timeout)
+}
+
+// Ready reports whether key holds entries never delivered to the group, or the group is gone.
+func (x *XReadGroup) Ready(ctx context.Context, storage storage.Storage, key string) bool {
+	ready, err := storage.Streams().XReadGroupReady(ctx, key, x.group)
+	return ready || err != nil
+}
+
+func (x *XReadGroup) Unblock(ctx context.Context, storage storage.Storage, key string) *protocol.Response {
+	reads := []streams.ReadGroupStream{{Key: key, New: true}}
+	result, err := storage.Streams().XReadGroup(ctx, x.group, x.consumer, reads, x.count, x.noAck)
+	if errors.Is(err, streams.ErrNoGroup) {
+		return protocol.NewErrorResponse(errGroupDestroyed)
+	}
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewMapResponse(streamEntriesValue(result))
+}
+
+// streamEntriesValue replies the entries read from each stream keyed by the stream.
+func streamEntriesValue(result []streams.StreamEntries) []protocol.MapEntry {
+	entries := make([]protocol.MapEntry, len(result))
+	for i, read := range result {
+		entries[i] = protocol.MapEntry{Key: read.Key, Val: entriesValue(read.Entries)}
+	}
+	return entries
+}
+
+type XReadGroupParser struct{}
+
+func NewXReadGroupParser() *XReadGroupParser {
+	return &XReadGroupParser{}
+}
+
+// Parse parses XREADGROUP GROUP group consumer [COUNT count] [BLOCK milliseconds] [NOACK]
+// STREAMS key [key ...] id [id ...].
+func (p *XReadGroupParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 6 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 6, len(msg.Args))
+	}
+	read, err := parseReadArgs(p.Name(), msg.Args)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &XReadGroup{
+		group:    read.group,
+		consumer: read.consumer,
+		streams:  make([]streams.ReadGroupStream, len(read.keys)),
+		count:    read.count,
+		noAck:    read.noAck,
+		block:    read.block,
+		timeout:  read.timeout,
+	}
+	for i, key := range read.keys {
+		cmd.streams[i].Key = key
+		switch read.ids[i] {
+		case ">":
+			cmd.streams[i].New = true
+		case "$":
+			return nil, errLastIDReadGroup
+		default:
+			id, err := streams.ParseID(read.ids[i], 0)
+			if err != nil {
+				return nil, err
+			}
+			cmd.streams[i].After = id
+		}
+	}
+	return cmd, nil
+}
+
+func (p *XReadGroupParser) Name() string {
+	return "XREADGROUP"
+}
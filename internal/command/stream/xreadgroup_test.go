@@ -0,0 +1,115 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	mockcommand "avacado/internal/command/mock"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/streams"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXReadGroupCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st).Times(2)
+	reads := []streams.ReadGroupStream{{Key: "stream", New: true}}
+	deleted := streams.Entry{ID: streams.ID{Ms: 1}}
+	st.EXPECT().XReadGroup(ctx, "group", "alice", reads, 2, true).
+		Return([]streams.StreamEntries{{Key: "stream", Entries: []streams.Entry{deleted}}}, nil)
+	st.EXPECT().XReadGroup(ctx, "group", "alice", reads, 0, false).Return(nil, nil)
+
+	response := (&XReadGroup{group: "group", consumer: "alice", streams: reads, count: 2, noAck: true}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	reply, _ := response.Value.AsMap()
+	assert.Equal(t, []protocol.MapEntry{{Key: "stream", Val: protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewArrayProtocolValue([]protocol.Value{
+			protocol.NewBulkStringProtocolValue([]byte("1-0")),
+			protocol.NewNullBulkStringProtocolValue(),
+		}),
+	})}}, reply)
+
+	response = (&XReadGroup{group: "group", consumer: "alice", streams: reads}).Execute(ctx, storage)
+	assert.True(t, response.Value.Null)
+}
+
+func TestXReadGroupCommand_BlocksWithoutNewEntries(t *testing.T) {
+	controller := gomock.NewController(t)
+	registry := mockcommand.NewMockBlockRegistry(controller)
+	ctx := command.ContextWithBlockRegistry(context.Background(), registry)
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st).AnyTimes()
+	reads := []streams.ReadGroupStream{{Key: "stream", New: true}}
+	cmd := &XReadGroup{group: "group", consumer: "alice", streams: reads, count: 1, block: true}
+	st.EXPECT().XReadGroup(ctx, "group", "alice", reads, 1, false).Return(nil, nil)
+	registry.EXPECT().RegisterBlockedClient(ctx, []string{"stream"}, cmd).Return(make(chan *protocol.Response, 1), context.CancelFunc(func() {}))
+
+	response := cmd.Execute(ctx, storage)
+	assert.NotNil(t, response.BlockCh)
+
+	st.EXPECT().XReadGroupReady(ctx, "stream", "group").Return(false, nil)
+	assert.False(t, cmd.Ready(ctx, storage, "stream"))
+	st.EXPECT().XReadGroupReady(ctx, "stream", "group").Return(true, nil)
+	assert.True(t, cmd.Ready(ctx, storage, "stream"))
+	read := []streams.StreamEntries{{Key: "stream", Entries: []streams.Entry{{ID: streams.ID{Ms: 1}, Fields: []string{"f", "v"}}}}}
+	st.EXPECT().XReadGroup(ctx, "group", "alice", reads, 1, false).Return(read, nil)
+	reply, _ := cmd.Unblock(ctx, storage, "stream").Value.AsMap()
+	assert.Equal(t, streamEntriesValue(read), reply)
+}
+
+func TestXReadGroupCommand_UnblocksOnceTheGroupIsDestroyed(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	storage.EXPECT().Streams().Return(st).AnyTimes()
+	reads := []streams.ReadGroupStream{{Key: "stream", New: true}}
+	cmd := &XReadGroup{group: "group", consumer: "alice", streams: reads, block: true}
+	destroyed := streams.NewNoReadGroupError("stream", "group")
+	st.EXPECT().XReadGroupReady(ctx, "stream", "group").Return(false, destroyed)
+	st.EXPECT().XReadGroup(ctx, "group", "alice", reads, 0, false).Return(nil, destroyed)
+
+	assert.True(t, cmd.Ready(ctx, storage, "stream"))
+	assert.Equal(t, errGroupDestroyed, cmd.Unblock(ctx, storage, "stream").Err)
+}
+
+func TestXReadGroupParser_Parse(t *testing.T) {
+	parser := NewXReadGroupParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "XREADGROUP", Args: []string{"COUNT", "-1", "group", "g", "alice", "NOACK", "streams", "a", "b", ">", "1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XReadGroup{
+		group:    "g",
+		consumer: "alice",
+		streams:  []streams.ReadGroupStream{{Key: "a", New: true}, {Key: "b", After: streams.ID{Ms: 1}}},
+		noAck:    true,
+	}, cmd)
+	cmd, err = parser.Parse(&protocol.Message{Command: "XREADGROUP", Args: []string{"GROUP", "g", "alice", "BLOCK", "250", "STREAMS", "a", ">"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XReadGroup{group: "g", consumer: "alice", streams: []streams.ReadGroupStream{{Key: "a", New: true}}, block: true, timeout: 0.25}, cmd)
+
+	for _, tt := range []struct {
+		args     []string
+		expected error
+	}{
+		{[]string{"COUNT", "1", "NOACK", "STREAMS", "a", ">"}, errMissingGroup},
+		{[]string{"GROUP", "g", "alice", "STREAMS", "a", "b", ">"}, newUnbalancedStreamsError("XREADGROUP")},
+		{[]string{"GROUP", "g", "alice", "STREAMS", "a", "$"}, errLastIDReadGroup},
+		{[]string{"GROUP", "g", "alice", "STREAMS", "a", "x"}, streams.ErrInvalidID},
+		{[]string{"GROUP", "g", "alice", "COUNT", "x", "STREAMS", "a", ">"}, command.ErrNotInteger},
+		{[]string{"GROUP", "g", "alice", "BLOCK", "0", "a", ">"}, command.ErrSyntax},
+		{[]string{"GROUP", "g", "alice", "BLOCK", "-5", "STREAMS", "a", ">"}, errTimeoutNegative},
+	} {
+		_, err := parser.Parse(&protocol.Message{Command: "XREADGROUP", Args: tt.args})
+		assert.Equal(t, tt.expected, err, tt.args)
+	}
+	_, err = parser.Parse(&protocol.Message{Command: "XREADGROUP", Args: []string{"GROUP", "g", "alice", "STREAMS", "a"}})
+	assert.Error(t, err)
+}
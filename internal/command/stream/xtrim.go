@@ -0,0 +1,62 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/streams"
+	"context"
+	"errors"
+)
+
+var errTrimStrategy = errors.New("ERR syntax error, XTRIM must be called with a trimming strategy")
+
+// XTrim evicts the oldest entries of a stream and replies how many were evicted.
+type XTrim struct {
+	key     string
+	options streams.TrimOptions
+}
+
+func (x *XTrim) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	evicted, err := storage.Streams().XTrim(ctx, x.key, x.options)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(evicted))
+}
+
+type XTrimParser struct{}
+
+func NewXTrimParser() *XTrimParser {
+	return &XTrimParser{}
+}
+
+// Parse parses XTRIM key MAXLEN | MINID [= | ~] threshold [LIMIT count].
+func (p *XTrimParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 3 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 3, len(msg.Args))
+	}
+	var trim trimParser
+	for args := msg.Args[1:]; len(args) > 0; {
+		taken, err := trim.parse(args)
+		if err != nil {
+			return nil, err
+		}
+		if taken == 0 {
+			return nil, command.ErrSyntax
+		}
+		args = args[taken:]
+	}
+	options, err := trim.finish()
+	if err != nil {
+		return nil, err
+	}
+	if options.Strategy == streams.TrimNone {
+		return nil, errTrimStrategy
+	}
+	return &XTrim{key: msg.Args[0], options: options}, nil
+}
+
+func (p *XTrimParser) Name() string {
+	return "XTRIM"
+}
@@ -0,0 +1,54 @@
+package stream
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/streams"
+	mockstreams "avacado/internal/storage/streams/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestXTrimCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	st := mockstreams.NewMockStreams(controller)
+	options := streams.TrimOptions{Strategy: streams.TrimMaxLen, MaxLen: 2, Limit: -1}
+	storage.EXPECT().Streams().Return(st)
+	st.EXPECT().XTrim(ctx, "stream", options).Return(3, nil)
+
+	response := (&XTrim{key: "stream", options: options}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(3), response.Value.Number)
+}
+
+func TestXTrimParser_Parse(t *testing.T) {
+	parser := NewXTrimParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "XTRIM", Args: []string{"stream", "minid", "=", "5"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &XTrim{key: "stream", options: streams.TrimOptions{Strategy: streams.TrimMinID, MinID: streams.ID{Ms: 5}, Limit: -1}}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "XTRIM", Args: []string{"stream", "MAXLEN", "~", "5", "LIMIT", "0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, streams.TrimOptions{Strategy: streams.TrimMaxLen, MaxLen: 5, Approx: true}, cmd.(*XTrim).options)
+
+	for _, tt := range []struct {
+		args     []string
+		expected error
+	}{
+		{[]string{"stream", "LIMIT", "5"}, errLimitWithoutApprox},
+		{[]string{"stream", "MAXLEN", "~", "5", "LIMIT"}, command.ErrSyntax},
+		{[]string{"stream", "MAXLEN", "5", "extra"}, command.ErrSyntax},
+		{[]string{"stream", "SOMETHING", "5"}, command.ErrSyntax},
+	} {
+		_, err := parser.Parse(&protocol.Message{Command: "XTRIM", Args: tt.args})
+		assert.Equal(t, tt.expected, err, tt.args)
+	}
+	_, err = parser.Parse(&protocol.Message{Command: "XTRIM", Args: []string{"stream", "MAXLEN"}})
+	assert.Error(t, err)
+}
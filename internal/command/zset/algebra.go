@@ -0,0 +1,234 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/zsets"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errWeightNotFloat = errors.New("ERR weight value is not a float")
+
+type Operation int
+
+const (
+	Inter Operation = iota
+	Union
+	Diff
+)
+
+// ZSetOperation replies the intersection, union or difference of the sorted sets stored at
+// keys, each member followed by its score if withScores is set.
+type ZSetOperation struct {
+	keys       []string
+	operation  Operation
+	options    zsets.CombineOptions
+	withScores bool
+}
+
+func (z *ZSetOperation) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	var members []zsets.ScoredMember
+	var err error
+	switch z.operation {
+	case Inter:
+		members, err = storage.ZSets().ZInter(ctx, z.keys, z.options)
+	case Union:
+		members, err = storage.ZSets().ZUnion(ctx, z.keys, z.options)
+	default:
+		members, err = storage.ZSets().ZDiff(ctx, z.keys)
+	}
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return scoredMembersResponse(members, z.withScores)
+}
+
+// ZSetOperationStore stores the intersection, union or difference of the sorted sets stored
+// at keys at destination and replies its size.
+type ZSetOperationStore struct {
+	destination string
+	keys        []string
+	operation   Operation
+	options     zsets.CombineOptions
+}
+
+func (z *ZSetOperationStore) DenyOOM() {}
+
+func (z *ZSetOperationStore) WrittenKeys() []string { return []string{z.destination} }
+
+func (z *ZSetOperationStore) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	var size int
+	var err error
+	switch z.operation {
+	case Inter:
+		size, err = storage.ZSets().ZInterStore(ctx, z.destination, z.keys, z.options)
+	case Union:
+		size, err = storage.ZSets().ZUnionStore(ctx, z.destination, z.keys, z.options)
+	default:
+		size, err = storage.ZSets().ZDiffStore(ctx, z.destination, z.keys)
+	}
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(size))
+}
+
+// parseOperationArgs parses numkeys key [key ...] [WEIGHTS weight [weight ...]]
+// [AGGREGATE SUM | MIN | MAX] [WITHSCORES]. The difference takes neither WEIGHTS nor
+// AGGREGATE, and storing commands do not take WITHSCORES.
+func parseOperationArgs(name string, operation Operation, store bool, args []string) ([]string, zsets.CombineOptions, bool, error) {
+	var options zsets.CombineOptions
+	numKeys, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, options, false, command.ErrNotInteger
+	}
+	if numKeys < 1 {
+		return nil, options, false, fmt.Errorf("ERR at least 1 input key is needed for '%s' command", strings.ToLower(name))
+	}
+	if numKeys > len(args)-1 {
+		return nil, options, false, command.ErrSyntax
+	}
+	keys := args[1 : numKeys+1]
+	withScores := false
+	for rest := args[numKeys+1:]; len(rest) > 0; {
+		switch option := strings.ToUpper(rest[0]); {
+		case option == "WEIGHTS" && operation != Diff && len(rest) > numKeys:
+			options.Weights = make([]float64, numKeys)
+			for i := range options.Weights {
+				if options.Weights[i], err = zsets.ParseScore(rest[i+1]); err != nil {
+					return nil, options, false, errWeightNotFloat
+				}
+			}
+			rest = rest[numKeys+1:]
+		case option == "AGGREGATE" && operation != Diff && len(rest) > 1:
+			switch strings.ToUpper(rest[1]) {
+			case "SUM":
+				options.Aggregate = zsets.AggregateSum
+			case "MIN":
+				options.Aggregate = zsets.AggregateMin
+			case "MAX":
+				options.Aggregate = zsets.AggregateMax
+			default:
+				return nil, options, false, command.ErrSyntax
+			}
+			rest = rest[2:]
+		case option == "WITHSCORES" && !store:
+			withScores = true
+			rest = rest[1:]
+		default:
+			return nil, options, false, command.ErrSyntax
+		}
+	}
+	return keys, options, withScores, nil
+}
+
+func parseOperation(name string, operation Operation, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(name, 2, len(msg.Args))
+	}
+	keys, options, withScores, err := parseOperationArgs(name, operation, false, msg.Args)
+	if err != nil {
+		return nil, err
+	}
+	return &ZSetOperation{keys: keys, operation: operation, options: options, withScores: withScores}, nil
+}
+
+func parseOperationStore(name string, operation Operation, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 3 {
+		return nil, command.NewInvalidArgumentsCount(name, 3, len(msg.Args))
+	}
+	keys, options, _, err := parseOperationArgs(name, operation, true, msg.Args[1:])
+	if err != nil {
+		return nil, err
+	}
+	return &ZSetOperationStore{destination: msg.Args[0], keys: keys, operation: operation, options: options}, nil
+}
+
+type ZInterParser struct{}
+
+func NewZInterParser() *ZInterParser {
+	return &ZInterParser{}
+}
+
+func (p *ZInterParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseOperation(p.Name(), Inter, msg)
+}
+
+func (p *ZInterParser) Name() string {
+	return "ZINTER"
+}
+
+type ZInterStoreParser struct{}
+
+func NewZInterStoreParser() *ZInterStoreParser {
+	return &ZInterStoreParser{}
+}
+
+func (p *ZInterStoreParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseOperationStore(p.Name(), Inter, msg)
+}
+
+func (p *ZInterStoreParser) Name() string {
+	return "ZINTERSTORE"
+}
+
+type ZUnionParser struct{}
+
+func NewZUnionParser() *ZUnionParser {
+	return &ZUnionParser{}
+}
+
+func (p *ZUnionParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseOperation(p.Name(), Union, msg)
+}
+
+func (p *ZUnionParser) Name() string {
+	return "ZUNION"
+}
+
+type ZUnionStoreParser struct{}
+
+func NewZUnionStoreParser() *ZUnionStoreParser {
+	return &ZUnionStoreParser{}
+}
+
+func (p *ZUnionStoreParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseOperationStore(p.Name(), Union, msg)
+}
+
+func (p *ZUnionStoreParser) Name() string {
+	return "ZUNIONSTORE"
+}
+
+type ZDiffParser struct{}
+
+func NewZDiffParser() *ZDiffParser {
+	return &ZDiffParser{}
+}
+
+func (p *ZDiffParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseOperation(p.Name(), Diff, msg)
+}
+
+func (p *ZDiffParser) Name() string {
+	return "ZDIFF"
+}
+
+type ZDiffStoreParser struct{}
+
+func NewZDiffStoreParser() *ZDiffStoreParser {
+	return &ZDiffStoreParser{}
+}
+
+func (p *ZDiffStoreParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseOperationStore(p.Name(), Diff, msg)
+}
+
+func (p *ZDiffStoreParser) Name() string {
+	return "ZDIFFSTORE"
+}
@@ -0,0 +1,100 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/zsets"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZSetOperationCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	options := zsets.CombineOptions{Aggregate: zsets.AggregateMax}
+	result := []zsets.ScoredMember{{Member: "a", Score: 1}, {Member: "b", Score: 2.5}}
+	storage.EXPECT().ZSets().Return(zs).Times(3)
+	zs.EXPECT().ZInter(ctx, []string{"z1", "z2"}, options).Return(result, nil)
+	zs.EXPECT().ZUnion(ctx, []string{"z1", "z2"}, options).Return(result, nil)
+	zs.EXPECT().ZDiff(ctx, []string{"z1", "z2"}).Return(result[:1], nil)
+
+	response := (&ZSetOperation{keys: []string{"z1", "z2"}, operation: Inter, options: options}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Len(t, response.Value.Array, 2)
+
+	response = (&ZSetOperation{keys: []string{"z1", "z2"}, operation: Union, options: options, withScores: true}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Len(t, response.Value.Array, 4)
+	assert.Equal(t, []byte("2.5"), response.Value.Array[3].Bytes)
+
+	response = (&ZSetOperation{keys: []string{"z1", "z2"}, operation: Diff}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("a"), response.Value.Array[0].Bytes)
+}
+
+func TestZSetOperationStoreCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	options := zsets.CombineOptions{Weights: []float64{1, 2}}
+	storage.EXPECT().ZSets().Return(zs).Times(3)
+	zs.EXPECT().ZInterStore(ctx, "dst", []string{"z1", "z2"}, options).Return(1, nil)
+	zs.EXPECT().ZUnionStore(ctx, "dst", []string{"z1", "z2"}, options).Return(3, nil)
+	zs.EXPECT().ZDiffStore(ctx, "dst", []string{"z1", "z2"}).Return(2, nil)
+
+	for operation, expected := range map[Operation]int64{Inter: 1, Union: 3, Diff: 2} {
+		response := (&ZSetOperationStore{destination: "dst", keys: []string{"z1", "z2"}, operation: operation, options: options}).Execute(ctx, storage)
+		assert.Nil(t, response.Err)
+		assert.Equal(t, expected, response.Value.Number)
+	}
+}
+
+func TestZSetOperationParsers_Parse(t *testing.T) {
+	cmd, err := NewZUnionParser().Parse(&protocol.Message{Command: "ZUNION", Args: []string{"2", "z1", "z2", "WEIGHTS", "2", "-inf", "AGGREGATE", "min", "withscores"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZSetOperation{
+		keys:       []string{"z1", "z2"},
+		operation:  Union,
+		options:    zsets.CombineOptions{Weights: []float64{2, math.Inf(-1)}, Aggregate: zsets.AggregateMin},
+		withScores: true,
+	}, cmd)
+
+	cmd, err = NewZInterStoreParser().Parse(&protocol.Message{Command: "ZINTERSTORE", Args: []string{"dst", "1", "z1", "AGGREGATE", "MAX"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZSetOperationStore{destination: "dst", keys: []string{"z1"}, operation: Inter, options: zsets.CombineOptions{Aggregate: zsets.AggregateMax}}, cmd)
+
+	cmd, err = NewZDiffStoreParser().Parse(&protocol.Message{Command: "ZDIFFSTORE", Args: []string{"dst", "2", "z1", "z2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZSetOperationStore{destination: "dst", keys: []string{"z1", "z2"}, operation: Diff}, cmd)
+
+	tests := []struct {
+		parser   command.Parser
+		args     []string
+		expected string
+	}{
+		{NewZUnionParser(), []string{"a", "z1"}, "ERR value is not an integer or out of range"},
+		{NewZUnionParser(), []string{"0", "z1"}, "ERR at least 1 input key is needed for 'zunion' command"},
+		{NewZInterStoreParser(), []string{"dst", "0", "z1"}, "ERR at least 1 input key is needed for 'zinterstore' command"},
+		{NewZUnionParser(), []string{"3", "z1", "z2"}, "ERR syntax error"},
+		{NewZUnionParser(), []string{"2", "z1", "z2", "WEIGHTS", "1"}, "ERR syntax error"},
+		{NewZUnionParser(), []string{"2", "z1", "z2", "WEIGHTS", "1", "x"}, "ERR weight value is not a float"},
+		{NewZInterParser(), []string{"1", "z1", "AGGREGATE", "avg"}, "ERR syntax error"},
+		{NewZDiffParser(), []string{"1", "z1", "WEIGHTS", "1"}, "ERR syntax error"},
+		{NewZUnionStoreParser(), []string{"dst", "1", "z1", "WITHSCORES"}, "ERR syntax error"},
+	}
+	for _, tt := range tests {
+		_, err = tt.parser.Parse(&protocol.Message{Args: tt.args})
+		assert.EqualError(t, err, tt.expected, tt.args)
+	}
+	_, err = NewZUnionStoreParser().Parse(&protocol.Message{Command: "ZUNIONSTORE", Args: []string{"dst", "1"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,166 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/zsets"
+	"context"
+	"errors"
+	"math"
+	"strconv"
+)
+
+var (
+	errTimeoutNotFloat = errors.New("ERR timeout is not a float or out of range")
+	errTimeoutNegative = errors.New("ERR timeout is negative")
+)
+
+// BZPop pops the member with the lowest score, or the highest if highest is set, from the first
+// of keys holding a sorted set, and replies the key, the member and its score. Without any, it
+// blocks until one of keys gets members or timeout seconds passed, unless timeout is 0.
+type BZPop struct {
+	keys    []string
+	highest bool
+	timeout float64
+}
+
+func (b *BZPop) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	for _, key := range b.keys {
+		members, err := storage.ZSets().ZPop(ctx, key, 1, b.highest)
+		if err != nil {
+			return protocol.NewErrorResponse(err)
+		}
+		if len(members) > 0 {
+			return bzpopResponse(key, members[0])
+		}
+	}
+	return command.Block(ctx, b.keys, b, b.timeout)
+}
+
+func (b *BZPop) Ready(ctx context.Context, storage storage.Storage, key string) bool {
+	return zsetReady(ctx, storage, key)
+}
+
+func (b *BZPop) Unblock(ctx context.Context, storage storage.Storage, key string) *protocol.Response {
+	members, _ := storage.ZSets().ZPop(ctx, key, 1, b.highest)
+	return bzpopResponse(key, members[0])
+}
+
+// BZMPop is the blocking ZMPOP: without members in any of keys, it blocks until one of them
+// gets some or timeout seconds passed, unless timeout is 0.
+type BZMPop struct {
+	ZMPop
+	timeout float64
+}
+
+func (b *BZMPop) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	key, members, err := b.pop(ctx, storage)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if len(members) > 0 {
+		return mpopResponse(key, members)
+	}
+	return command.Block(ctx, b.keys, b, b.timeout)
+}
+
+func (b *BZMPop) Ready(ctx context.Context, storage storage.Storage, key string) bool {
+	return zsetReady(ctx, storage, key)
+}
+
+func (b *BZMPop) Unblock(ctx context.Context, storage storage.Storage, key string) *protocol.Response {
+	members, _ := storage.ZSets().ZPop(ctx, key, b.count, b.highest)
+	return mpopResponse(key, members)
+}
+
+// bzpopResponse replies key followed by the member popped from it and its score.
+func bzpopResponse(key string, member zsets.ScoredMember) *protocol.Response {
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewBulkStringProtocolValue([]byte(key)),
+		protocol.NewBulkStringProtocolValue([]byte(member.Member)),
+		scoreValue(member.Score),
+	}))
+}
+
+// zsetReady reports whether key holds a sorted set to pop from.
+func zsetReady(ctx context.Context, storage storage.Storage, key string) bool {
+	n, err := storage.ZSets().ZCard(ctx, key)
+	return err == nil && n > 0
+}
+
+func parseTimeout(timeout string) (float64, error) {
+	seconds, err := strconv.ParseFloat(timeout, 64)
+	if err != nil || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0, errTimeoutNotFloat
+	}
+	if seconds < 0 {
+		return 0, errTimeoutNegative
+	}
+	return seconds, nil
+}
+
+func parseBPop(name string, highest bool, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(name, 2, len(msg.Args))
+	}
+	timeout, err := parseTimeout(msg.Args[len(msg.Args)-1])
+	if err != nil {
+		return nil, err
+	}
+	return &BZPop{keys: msg.Args[:len(msg.Args)-1], highest: highest, timeout: timeout}, nil
+}
+
+type BZPopMinParser struct{}
+
+func NewBZPopMinParser() *BZPopMinParser {
+	return &BZPopMinParser{}
+}
+
+func (p *BZPopMinParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseBPop(p.Name(), false, msg)
+}
+
+func (p *BZPopMinParser) Name() string {
+	return "BZPOPMIN"
+}
+
+type BZPopMaxParser struct{}
+
+func NewBZPopMaxParser() *BZPopMaxParser {
+	return &BZPopMaxParser{}
+}
+
+func (p *BZPopMaxParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseBPop(p.Name(), true, msg)
+}
+
+func (p *BZPopMaxParser) Name() string {
+	return "BZPOPMAX"
+}
+
+type BZMPopParser struct{}
+
+func NewBZMPopParser() *BZMPopParser {
+	return &BZMPopParser{}
+}
+
+// Parse parses BZMPOP timeout numkeys key [key ...] MIN | MAX [COUNT count].
+func (p *BZMPopParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 4 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 4, len(msg.Args))
+	}
+	timeout, err := parseTimeout(msg.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	mpop, err := parseMPop(msg.Args[1:])
+	if err != nil {
+		return nil, err
+	}
+	return &BZMPop{ZMPop: *mpop, timeout: timeout}, nil
+}
+
+func (p *BZMPopParser) Name() string {
+	return "BZMPOP"
+}
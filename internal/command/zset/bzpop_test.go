@@ -0,0 +1,86 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	mockcommand "avacado/internal/command/mock"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/zsets"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBZPopCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs).AnyTimes()
+	zs.EXPECT().ZPop(ctx, "empty", 1, false).Return(nil, nil)
+	zs.EXPECT().ZPop(ctx, "zset", 1, false).Return([]zsets.ScoredMember{{Member: "a", Score: 1}}, nil)
+
+	response := (&BZPop{keys: []string{"empty", "zset"}}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("zset"), response.Value.Array[0].Bytes)
+	assert.Equal(t, []byte("a"), response.Value.Array[1].Bytes)
+	assert.Equal(t, []byte("1"), response.Value.Array[2].Bytes)
+}
+
+func TestBZPopCommand_BlocksWithoutMembers(t *testing.T) {
+	controller := gomock.NewController(t)
+	registry := mockcommand.NewMockBlockRegistry(controller)
+	ctx := command.ContextWithBlockRegistry(context.Background(), registry)
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs).AnyTimes()
+	cmd := &BZPop{keys: []string{"zset"}, highest: true}
+	blockCh := make(chan *protocol.Response, 1)
+	zs.EXPECT().ZPop(ctx, "zset", 1, true).Return(nil, nil)
+	registry.EXPECT().RegisterBlockedClient(ctx, []string{"zset"}, cmd).Return(blockCh, context.CancelFunc(func() {}))
+
+	response := cmd.Execute(ctx, storage)
+	assert.NotNil(t, response.BlockCh)
+
+	zs.EXPECT().ZCard(ctx, "zset").Return(1, nil)
+	assert.True(t, cmd.Ready(ctx, storage, "zset"))
+	zs.EXPECT().ZPop(ctx, "zset", 1, true).Return([]zsets.ScoredMember{{Member: "b", Score: 2}}, nil)
+	response = cmd.Unblock(ctx, storage, "zset")
+	assert.Equal(t, []byte("b"), response.Value.Array[1].Bytes)
+}
+
+func TestBZMPopCommand_Unblock(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs).AnyTimes()
+	cmd := &BZMPop{ZMPop: ZMPop{keys: []string{"zset"}, count: 2}}
+	zs.EXPECT().ZCard(ctx, "zset").Return(0, nil)
+	zs.EXPECT().ZPop(ctx, "zset", 2, false).Return([]zsets.ScoredMember{{Member: "a", Score: 1}, {Member: "b", Score: 2}}, nil)
+
+	assert.False(t, cmd.Ready(ctx, storage, "zset"))
+	response := cmd.Unblock(ctx, storage, "zset")
+	assert.Equal(t, []byte("zset"), response.Value.Array[0].Bytes)
+	assert.Len(t, response.Value.Array[1].Array, 2)
+}
+
+func TestBZPopParsers_Parse(t *testing.T) {
+	cmd, err := NewBZPopMinParser().Parse(&protocol.Message{Command: "BZPOPMIN", Args: []string{"z1", "z2", "0.5"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &BZPop{keys: []string{"z1", "z2"}, timeout: 0.5}, cmd)
+
+	cmd, err = NewBZMPopParser().Parse(&protocol.Message{Command: "BZMPOP", Args: []string{"1", "1", "z1", "MAX"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &BZMPop{ZMPop: ZMPop{keys: []string{"z1"}, highest: true, count: 1}, timeout: 1}, cmd)
+
+	_, err = NewBZPopMaxParser().Parse(&protocol.Message{Command: "BZPOPMAX", Args: []string{"z1", "x"}})
+	assert.Equal(t, errTimeoutNotFloat, err)
+	_, err = NewBZPopMaxParser().Parse(&protocol.Message{Command: "BZPOPMAX", Args: []string{"z1", "-1"}})
+	assert.Equal(t, errTimeoutNegative, err)
+	_, err = NewBZMPopParser().Parse(&protocol.Message{Command: "BZMPOP", Args: []string{"0", "0", "z1", "MIN"}})
+	assert.Equal(t, command.ErrNumKeys, err)
+}
@@ -0,0 +1,174 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/zsets"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	errNegativeCount = errors.New("ERR value is out of range, must be positive")
+	errCount         = errors.New("ERR count should be greater than 0")
+)
+
+// ZPop pops up to count members with the lowest scores, or the highest if highest is set, and
+// replies each followed by its score.
+type ZPop struct {
+	key     string
+	count   int
+	highest bool
+}
+
+func (z *ZPop) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	members, err := storage.ZSets().ZPop(ctx, z.key, z.count, z.highest)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return scoredMembersResponse(members, true)
+}
+
+func parsePop(name string, highest bool, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 1 || len(msg.Args) > 2 {
+		return nil, command.NewInvalidArgumentsCount(name, 1, len(msg.Args))
+	}
+	cmd := &ZPop{key: msg.Args[0], count: 1, highest: highest}
+	if len(msg.Args) == 2 {
+		count, err := strconv.Atoi(msg.Args[1])
+		if err != nil {
+			return nil, command.ErrNotInteger
+		}
+		if count < 0 {
+			return nil, errNegativeCount
+		}
+		cmd.count = count
+	}
+	return cmd, nil
+}
+
+// ZMPop pops up to count members with the lowest scores, or the highest if highest is set,
+// from the first of keys holding a sorted set, and replies the key along with the members and
+// their scores, or nil if every key is empty.
+type ZMPop struct {
+	keys    []string
+	highest bool
+	count   int
+}
+
+func (z *ZMPop) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	key, members, err := z.pop(ctx, storage)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if len(members) == 0 {
+		return protocol.NewNullBulkStringResponse()
+	}
+	return mpopResponse(key, members)
+}
+
+// pop pops from the first of keys holding a sorted set and returns the key along with the
+// members popped, none if every key is empty.
+func (z *ZMPop) pop(ctx context.Context, storage storage.Storage) (string, []zsets.ScoredMember, error) {
+	for _, key := range z.keys {
+		members, err := storage.ZSets().ZPop(ctx, key, z.count, z.highest)
+		if err != nil || len(members) > 0 {
+			return key, members, err
+		}
+	}
+	return "", nil, nil
+}
+
+// mpopResponse replies key followed by the pairs of member and score popped from it.
+func mpopResponse(key string, members []zsets.ScoredMember) *protocol.Response {
+	pairs := make([]protocol.Value, len(members))
+	for i, member := range members {
+		pairs[i] = protocol.NewArrayProtocolValue([]protocol.Value{
+			protocol.NewBulkStringProtocolValue([]byte(member.Member)),
+			scoreValue(member.Score),
+		})
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewBulkStringProtocolValue([]byte(key)),
+		protocol.NewArrayProtocolValue(pairs),
+	}))
+}
+
+// parseMPop parses numkeys key [key ...] MIN | MAX [COUNT count].
+func parseMPop(args []string) (*ZMPop, error) {
+	numKeys, err := strconv.Atoi(args[0])
+	if err != nil || numKeys <= 0 {
+		return nil, command.ErrNumKeys
+	}
+	if numKeys+1 >= len(args) {
+		return nil, command.ErrSyntax
+	}
+	cmd := &ZMPop{keys: args[1 : numKeys+1], count: 1}
+	switch strings.ToUpper(args[numKeys+1]) {
+	case "MIN":
+	case "MAX":
+		cmd.highest = true
+	default:
+		return nil, command.ErrSyntax
+	}
+	options := args[numKeys+2:]
+	if len(options) == 0 {
+		return cmd, nil
+	}
+	if len(options) != 2 || !strings.EqualFold(options[0], "COUNT") {
+		return nil, command.ErrSyntax
+	}
+	if cmd.count, err = strconv.Atoi(options[1]); err != nil || cmd.count <= 0 {
+		return nil, errCount
+	}
+	return cmd, nil
+}
+
+type ZPopMinParser struct{}
+
+func NewZPopMinParser() *ZPopMinParser {
+	return &ZPopMinParser{}
+}
+
+func (p *ZPopMinParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parsePop(p.Name(), false, msg)
+}
+
+func (p *ZPopMinParser) Name() string {
+	return "ZPOPMIN"
+}
+
+type ZPopMaxParser struct{}
+
+func NewZPopMaxParser() *ZPopMaxParser {
+	return &ZPopMaxParser{}
+}
+
+func (p *ZPopMaxParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parsePop(p.Name(), true, msg)
+}
+
+func (p *ZPopMaxParser) Name() string {
+	return "ZPOPMAX"
+}
+
+type ZMPopParser struct{}
+
+func NewZMPopParser() *ZMPopParser {
+	return &ZMPopParser{}
+}
+
+// Parse parses ZMPOP numkeys key [key ...] MIN | MAX [COUNT count].
+func (p *ZMPopParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 3 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 3, len(msg.Args))
+	}
+	return parseMPop(msg.Args)
+}
+
+func (p *ZMPopParser) Name() string {
+	return "ZMPOP"
+}
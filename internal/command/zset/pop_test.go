@@ -0,0 +1,91 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/zsets"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZPopCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs)
+	zs.EXPECT().ZPop(ctx, "zset", 2, true).Return([]zsets.ScoredMember{{Member: "c", Score: 3}, {Member: "b", Score: 2}}, nil)
+
+	response := (&ZPop{key: "zset", count: 2, highest: true}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Len(t, response.Value.Array, 4)
+	assert.Equal(t, []byte("c"), response.Value.Array[0].Bytes)
+	assert.Equal(t, []byte("3"), response.Value.Array[1].Bytes)
+}
+
+func TestZMPopCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs).Times(4)
+	zs.EXPECT().ZPop(ctx, "empty", 2, false).Return(nil, nil).Times(2)
+	zs.EXPECT().ZPop(ctx, "zset", 2, false).Return([]zsets.ScoredMember{{Member: "a", Score: 1.5}}, nil)
+	zs.EXPECT().ZPop(ctx, "missing", 2, false).Return(nil, nil)
+
+	response := (&ZMPop{keys: []string{"empty", "zset"}, count: 2}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("zset"), response.Value.Array[0].Bytes)
+	pair := response.Value.Array[1].Array[0]
+	assert.Equal(t, []byte("a"), pair.Array[0].Bytes)
+	assert.Equal(t, []byte("1.5"), pair.Array[1].Bytes)
+
+	response = (&ZMPop{keys: []string{"empty", "missing"}, count: 2}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.True(t, response.Value.Null)
+}
+
+func TestZPopParsers_Parse(t *testing.T) {
+	cmd, err := NewZPopMinParser().Parse(&protocol.Message{Command: "ZPOPMIN", Args: []string{"zset"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZPop{key: "zset", count: 1}, cmd)
+
+	cmd, err = NewZPopMaxParser().Parse(&protocol.Message{Command: "ZPOPMAX", Args: []string{"zset", "3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZPop{key: "zset", count: 3, highest: true}, cmd)
+
+	_, err = NewZPopMinParser().Parse(&protocol.Message{Command: "ZPOPMIN", Args: []string{"zset", "-1"}})
+	assert.EqualError(t, err, "ERR value is out of range, must be positive")
+	_, err = NewZPopMinParser().Parse(&protocol.Message{Command: "ZPOPMIN", Args: []string{"zset", "a"}})
+	assert.Equal(t, command.ErrNotInteger, err)
+	_, err = NewZPopMinParser().Parse(&protocol.Message{Command: "ZPOPMIN", Args: []string{}})
+	assert.Error(t, err)
+}
+
+func TestZMPopParser_Parse(t *testing.T) {
+	parser := NewZMPopParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "ZMPOP", Args: []string{"2", "z1", "z2", "max", "count", "5"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZMPop{keys: []string{"z1", "z2"}, highest: true, count: 5}, cmd)
+
+	tests := []struct {
+		args     []string
+		expected error
+	}{
+		{[]string{"0", "z1", "MIN"}, command.ErrNumKeys},
+		{[]string{"2", "z1", "MIN"}, command.ErrSyntax},
+		{[]string{"1", "z1", "LOW"}, command.ErrSyntax},
+		{[]string{"1", "z1", "MIN", "COUNT"}, command.ErrSyntax},
+		{[]string{"1", "z1", "MIN", "COUNT", "0"}, errCount},
+		{[]string{"1", "z1", "MIN", "COUNT", "1", "COUNT", "2"}, command.ErrSyntax},
+	}
+	for _, tt := range tests {
+		_, err = parser.Parse(&protocol.Message{Command: "ZMPOP", Args: tt.args})
+		assert.Equal(t, tt.expected, err, tt.args)
+	}
+}
@@ -0,0 +1,108 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/zsets"
+	"context"
+	"errors"
+	"strings"
+)
+
+var (
+	errXXAndNX        = errors.New("ERR XX and NX options at the same time are not compatible")
+	errGTLTAndNX      = errors.New("ERR GT, LT, and/or NX options at the same time are not compatible")
+	errIncrSinglePair = errors.New("ERR INCR option supports a single increment-element pair")
+)
+
+// ZAdd adds members or updates their score. With incr, its single member has its score
+// incremented instead and the new score is replied, or nil if the options prevented it.
+type ZAdd struct {
+	key     string
+	members []zsets.ScoredMember
+	options zsets.AddOptions
+	incr    bool
+}
+
+func (z *ZAdd) DenyOOM() {}
+
+func (z *ZAdd) WrittenKeys() []string { return []string{z.key} }
+
+func (z *ZAdd) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	if z.incr {
+		score, ok, err := storage.ZSets().ZIncrBy(ctx, z.key, z.members[0].Member, z.members[0].Score, z.options)
+		if err != nil {
+			return protocol.NewErrorResponse(err)
+		}
+		if !ok {
+			return protocol.NewNullBulkStringResponse()
+		}
+		return protocol.NewSuccessResponse(scoreValue(score))
+	}
+	n, err := storage.ZSets().ZAdd(ctx, z.key, z.members, z.options)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(n))
+}
+
+type ZAddParser struct{}
+
+func NewZAddParser() *ZAddParser {
+	return &ZAddParser{}
+}
+
+// Parse parses ZADD key [NX | XX] [GT | LT] [CH] [INCR] score member [score member ...].
+func (p *ZAddParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 3 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 3, len(msg.Args))
+	}
+	cmd := &ZAdd{key: msg.Args[0]}
+	args := msg.Args[1:]
+flags:
+	for len(args) > 0 {
+		switch strings.ToUpper(args[0]) {
+		case "NX":
+			cmd.options.NX = true
+		case "XX":
+			cmd.options.XX = true
+		case "GT":
+			cmd.options.GT = true
+		case "LT":
+			cmd.options.LT = true
+		case "CH":
+			cmd.options.CH = true
+		case "INCR":
+			cmd.incr = true
+		default:
+			break flags
+		}
+		args = args[1:]
+	}
+	if len(args) == 0 || len(args)%2 != 0 {
+		return nil, command.ErrSyntax
+	}
+	if cmd.options.NX && cmd.options.XX {
+		return nil, errXXAndNX
+	}
+	if (cmd.options.GT && cmd.options.NX) || (cmd.options.LT && cmd.options.NX) || (cmd.options.GT && cmd.options.LT) {
+		return nil, errGTLTAndNX
+	}
+	if cmd.incr && len(args) > 2 {
+		return nil, errIncrSinglePair
+	}
+	cmd.members = make([]zsets.ScoredMember, 0, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		score, err := zsets.ParseScore(args[i])
+		if err != nil {
+			return nil, err
+		}
+		cmd.members = append(cmd.members, zsets.ScoredMember{Member: args[i+1], Score: score})
+	}
+	return cmd, nil
+}
+
+func (p *ZAddParser) Name() string {
+	return "ZADD"
+}
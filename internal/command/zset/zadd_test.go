@@ -0,0 +1,79 @@
+package zset
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/zsets"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZAddCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	members := []zsets.ScoredMember{{Member: "a", Score: 1}, {Member: "b", Score: 2}}
+	cmd := ZAdd{key: "zset", members: members, options: zsets.AddOptions{CH: true}}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs)
+	zs.EXPECT().ZAdd(ctx, "zset", members, zsets.AddOptions{CH: true}).Return(2, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(2), response.Value.Number)
+}
+
+func TestZAddCommand_ExecuteIncr(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs).Times(2)
+	zs.EXPECT().ZIncrBy(ctx, "zset", "a", 1.5, zsets.AddOptions{}).Return(2.5, true, nil)
+	zs.EXPECT().ZIncrBy(ctx, "zset", "a", 1.5, zsets.AddOptions{NX: true}).Return(0.0, false, nil)
+
+	cmd := ZAdd{key: "zset", members: []zsets.ScoredMember{{Member: "a", Score: 1.5}}, incr: true}
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("2.5"), response.Value.Bytes)
+
+	cmd.options.NX = true
+	response = cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.True(t, response.Value.Null)
+}
+
+func TestZAddParser_Parse(t *testing.T) {
+	parser := NewZAddParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "ZADD", Args: []string{"zset", "xx", "GT", "ch", "1", "a", "-inf", "b"}})
+	assert.NoError(t, err)
+	zadd := cmd.(*ZAdd)
+	assert.Equal(t, "zset", zadd.key)
+	assert.Equal(t, zsets.AddOptions{XX: true, GT: true, CH: true}, zadd.options)
+	assert.False(t, zadd.incr)
+	assert.Equal(t, "b", zadd.members[1].Member)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "ZADD", Args: []string{"zset", "INCR", "2", "a"}})
+	assert.NoError(t, err)
+	assert.True(t, cmd.(*ZAdd).incr)
+}
+
+func TestZAddParser_ParseErrors(t *testing.T) {
+	parser := NewZAddParser()
+	for expected, args := range map[error][]string{
+		errXXAndNX:        {"zset", "NX", "XX", "1", "a"},
+		errGTLTAndNX:      {"zset", "GT", "LT", "1", "a"},
+		errIncrSinglePair: {"zset", "INCR", "1", "a", "2", "b"},
+		zsets.ErrNotFloat: {"zset", "nan", "a"},
+	} {
+		_, err := parser.Parse(&protocol.Message{Command: "ZADD", Args: args})
+		assert.Equal(t, expected, err, args)
+	}
+	_, err := parser.Parse(&protocol.Message{Command: "ZADD", Args: []string{"zset", "1", "a", "2"}})
+	assert.EqualError(t, err, "ERR syntax error")
+	_, err = parser.Parse(&protocol.Message{Command: "ZADD", Args: []string{"zset", "NX", "CH"}})
+	assert.Error(t, err)
+}
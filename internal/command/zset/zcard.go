@@ -0,0 +1,37 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type ZCard struct {
+	key string
+}
+
+func (z *ZCard) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	size, err := storage.ZSets().ZCard(ctx, z.key)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(size))
+}
+
+type ZCardParser struct{}
+
+func NewZCardParser() *ZCardParser {
+	return &ZCardParser{}
+}
+
+func (p *ZCardParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 1 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 1, len(msg.Args))
+	}
+	return &ZCard{key: msg.Args[0]}, nil
+}
+
+func (p *ZCardParser) Name() string {
+	return "ZCARD"
+}
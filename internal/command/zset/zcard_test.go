@@ -0,0 +1,35 @@
+package zset
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZCardCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := ZCard{key: "zset"}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs)
+	zs.EXPECT().ZCard(ctx, "zset").Return(3, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(3), response.Value.Number)
+}
+
+func TestZCardParser_Parse(t *testing.T) {
+	parser := NewZCardParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "ZCARD", Args: []string{"zset"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "zset", cmd.(*ZCard).key)
+
+	_, err = parser.Parse(&protocol.Message{Command: "ZCARD", Args: []string{}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,62 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/zsets"
+	"context"
+)
+
+// ZCount replies the number of members in a score or lex range.
+type ZCount struct {
+	key  string
+	spec zsets.RangeSpec
+}
+
+func (z *ZCount) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	count, err := storage.ZSets().ZCount(ctx, z.key, z.spec)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(count))
+}
+
+func parseCount(name string, by zsets.RangeBy, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 3 {
+		return nil, command.NewInvalidArgumentsCount(name, 3, len(msg.Args))
+	}
+	spec := zsets.RangeSpec{By: by}
+	if err := parseBounds(&spec, msg.Args[1], msg.Args[2]); err != nil {
+		return nil, err
+	}
+	return &ZCount{key: msg.Args[0], spec: spec}, nil
+}
+
+type ZCountParser struct{}
+
+func NewZCountParser() *ZCountParser {
+	return &ZCountParser{}
+}
+
+func (p *ZCountParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseCount(p.Name(), zsets.ByScore, msg)
+}
+
+func (p *ZCountParser) Name() string {
+	return "ZCOUNT"
+}
+
+type ZLexCountParser struct{}
+
+func NewZLexCountParser() *ZLexCountParser {
+	return &ZLexCountParser{}
+}
+
+func (p *ZLexCountParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseCount(p.Name(), zsets.ByLex, msg)
+}
+
+func (p *ZLexCountParser) Name() string {
+	return "ZLEXCOUNT"
+}
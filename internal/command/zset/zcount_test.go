@@ -0,0 +1,44 @@
+package zset
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/zsets"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZCountCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	spec := zsets.RangeSpec{By: zsets.ByScore, Score: zsets.ScoreRange{Min: 1, Max: 2}}
+	storage.EXPECT().ZSets().Return(zs)
+	zs.EXPECT().ZCount(ctx, "zset", spec).Return(3, nil)
+
+	response := (&ZCount{key: "zset", spec: spec}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(3), response.Value.Number)
+}
+
+func TestZCountParsers_Parse(t *testing.T) {
+	cmd, err := NewZCountParser().Parse(&protocol.Message{Command: "ZCOUNT", Args: []string{"zset", "(1", "2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZCount{key: "zset", spec: zsets.RangeSpec{By: zsets.ByScore, Score: zsets.ScoreRange{Min: 1, Max: 2, MinExclusive: true}}}, cmd)
+
+	cmd, err = NewZLexCountParser().Parse(&protocol.Message{Command: "ZLEXCOUNT", Args: []string{"zset", "-", "(b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, zsets.LexRange{Min: zsets.LexBound{Infinity: -1}, Max: zsets.LexBound{Value: "b", Exclusive: true}}, cmd.(*ZCount).spec.Lex)
+
+	_, err = NewZCountParser().Parse(&protocol.Message{Command: "ZCOUNT", Args: []string{"zset", "a", "2"}})
+	assert.EqualError(t, err, "ERR min or max is not a float")
+	_, err = NewZLexCountParser().Parse(&protocol.Message{Command: "ZLEXCOUNT", Args: []string{"zset", "a", "+"}})
+	assert.EqualError(t, err, "ERR min or max not valid string range item")
+	_, err = NewZCountParser().Parse(&protocol.Message{Command: "ZCOUNT", Args: []string{"zset", "1"}})
+	assert.Error(t, err)
+}
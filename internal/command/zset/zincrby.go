@@ -0,0 +1,48 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/zsets"
+	"context"
+)
+
+type ZIncrBy struct {
+	key       string
+	increment float64
+	member    string
+}
+
+func (z *ZIncrBy) DenyOOM() {}
+
+func (z *ZIncrBy) WrittenKeys() []string { return []string{z.key} }
+
+func (z *ZIncrBy) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	score, _, err := storage.ZSets().ZIncrBy(ctx, z.key, z.member, z.increment, zsets.AddOptions{})
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewSuccessResponse(scoreValue(score))
+}
+
+type ZIncrByParser struct{}
+
+func NewZIncrByParser() *ZIncrByParser {
+	return &ZIncrByParser{}
+}
+
+func (p *ZIncrByParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 3 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 3, len(msg.Args))
+	}
+	increment, err := zsets.ParseScore(msg.Args[1])
+	if err != nil {
+		return nil, err
+	}
+	return &ZIncrBy{key: msg.Args[0], increment: increment, member: msg.Args[2]}, nil
+}
+
+func (p *ZIncrByParser) Name() string {
+	return "ZINCRBY"
+}
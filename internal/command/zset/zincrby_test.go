@@ -0,0 +1,50 @@
+package zset
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/zsets"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZIncrByCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := ZIncrBy{key: "zset", increment: 2, member: "a"}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs)
+	zs.EXPECT().ZIncrBy(ctx, "zset", "a", 2.0, zsets.AddOptions{}).Return(3.5, true, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("3.5"), response.Value.Bytes)
+}
+
+func TestZIncrByCommand_ExecuteNaN(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := ZIncrBy{key: "zset", increment: 2, member: "a"}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs)
+	zs.EXPECT().ZIncrBy(ctx, "zset", "a", 2.0, zsets.AddOptions{}).Return(0.0, false, zsets.ErrNotANumber)
+	response := cmd.Execute(ctx, storage)
+	assert.Equal(t, zsets.ErrNotANumber, response.Err)
+}
+
+func TestZIncrByParser_Parse(t *testing.T) {
+	parser := NewZIncrByParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "ZINCRBY", Args: []string{"zset", "-1.5", "a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZIncrBy{key: "zset", increment: -1.5, member: "a"}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "ZINCRBY", Args: []string{"zset", "x", "a"}})
+	assert.Equal(t, zsets.ErrNotFloat, err)
+	_, err = parser.Parse(&protocol.Message{Command: "ZINCRBY", Args: []string{"zset", "1"}})
+	assert.Error(t, err)
+}
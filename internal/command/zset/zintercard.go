@@ -0,0 +1,63 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"strconv"
+	"strings"
+)
+
+// ZInterCard replies the size of the intersection of the sorted sets stored at keys, counting
+// no further than limit unless limit is 0.
+type ZInterCard struct {
+	keys  []string
+	limit int
+}
+
+func (z *ZInterCard) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	size, err := storage.ZSets().ZInterCard(ctx, z.keys, z.limit)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(size))
+}
+
+type ZInterCardParser struct{}
+
+func NewZInterCardParser() *ZInterCardParser {
+	return &ZInterCardParser{}
+}
+
+// Parse parses ZINTERCARD numkeys key [key ...] [LIMIT limit].
+func (p *ZInterCardParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	numKeys, err := strconv.Atoi(msg.Args[0])
+	if err != nil || numKeys <= 0 {
+		return nil, command.ErrNumKeys
+	}
+	if numKeys > len(msg.Args)-1 {
+		return nil, command.ErrTooManyKeys
+	}
+	cmd := &ZInterCard{keys: msg.Args[1 : numKeys+1]}
+	options := msg.Args[numKeys+1:]
+	for len(options) > 0 {
+		if !strings.EqualFold(options[0], "LIMIT") || len(options) < 2 {
+			return nil, command.ErrSyntax
+		}
+		limit, err := strconv.Atoi(options[1])
+		if err != nil || limit < 0 {
+			return nil, command.ErrNegativeLimit
+		}
+		cmd.limit = limit
+		options = options[2:]
+	}
+	return cmd, nil
+}
+
+func (p *ZInterCardParser) Name() string {
+	return "ZINTERCARD"
+}
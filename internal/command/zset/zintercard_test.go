@@ -0,0 +1,42 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZInterCardCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs)
+	zs.EXPECT().ZInterCard(ctx, []string{"z1", "z2"}, 5).Return(2, nil)
+
+	response := (&ZInterCard{keys: []string{"z1", "z2"}, limit: 5}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(2), response.Value.Number)
+}
+
+func TestZInterCardParser_Parse(t *testing.T) {
+	parser := NewZInterCardParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "ZINTERCARD", Args: []string{"2", "z1", "z2", "limit", "3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZInterCard{keys: []string{"z1", "z2"}, limit: 3}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "ZINTERCARD", Args: []string{"0", "z1"}})
+	assert.Equal(t, command.ErrNumKeys, err)
+	_, err = parser.Parse(&protocol.Message{Command: "ZINTERCARD", Args: []string{"3", "z1", "z2"}})
+	assert.Equal(t, command.ErrTooManyKeys, err)
+	_, err = parser.Parse(&protocol.Message{Command: "ZINTERCARD", Args: []string{"1", "z1", "LIMIT", "-1"}})
+	assert.Equal(t, command.ErrNegativeLimit, err)
+	_, err = parser.Parse(&protocol.Message{Command: "ZINTERCARD", Args: []string{"1", "z1", "WITHSCORES"}})
+	assert.Equal(t, command.ErrSyntax, err)
+}
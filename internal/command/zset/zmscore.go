@@ -0,0 +1,46 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type ZMScore struct {
+	key     string
+	members []string
+}
+
+func (z *ZMScore) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	scores, err := storage.ZSets().ZMScore(ctx, z.key, z.members)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	values := make([]protocol.Value, len(scores))
+	for i, score := range scores {
+		if score == nil {
+			values[i] = protocol.NewNullBulkStringProtocolValue()
+		} else {
+			values[i] = scoreValue(*score)
+		}
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue(values))
+}
+
+type ZMScoreParser struct{}
+
+func NewZMScoreParser() *ZMScoreParser {
+	return &ZMScoreParser{}
+}
+
+func (p *ZMScoreParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	return &ZMScore{key: msg.Args[0], members: msg.Args[1:]}, nil
+}
+
+func (p *ZMScoreParser) Name() string {
+	return "ZMSCORE"
+}
@@ -0,0 +1,38 @@
+package zset
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZMScoreCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := ZMScore{key: "zset", members: []string{"a", "missing"}}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs)
+	score := -2.5
+	zs.EXPECT().ZMScore(ctx, "zset", []string{"a", "missing"}).Return([]*float64{&score, nil}, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, 2, len(response.Value.Array))
+	assert.Equal(t, []byte("-2.5"), response.Value.Array[0].Bytes)
+	assert.True(t, response.Value.Array[1].Null)
+}
+
+func TestZMScoreParser_Parse(t *testing.T) {
+	parser := NewZMScoreParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "ZMSCORE", Args: []string{"zset", "a", "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZMScore{key: "zset", members: []string{"a", "b"}}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "ZMSCORE", Args: []string{"zset"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/zsets"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	errLimitWithoutBy    = errors.New("ERR syntax error, LIMIT is only supported in combination with either BYSCORE or BYLEX")
+	errWithScoresWithLex = errors.New("ERR syntax error, WITHSCORES not supported in combination with BYLEX")
+)
+
+// ZRange replies the members spec selects, each followed by its score if withScores is set.
+type ZRange struct {
+	key        string
+	spec       zsets.RangeSpec
+	withScores bool
+}
+
+func (z *ZRange) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	members, err := storage.ZSets().ZRange(ctx, z.key, z.spec)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return scoredMembersResponse(members, z.withScores)
+}
+
+// ZRangeStore stores the members spec selects at destination and replies how many there are.
+type ZRangeStore struct {
+	destination string
+	key         string
+	spec        zsets.RangeSpec
+}
+
+func (z *ZRangeStore) DenyOOM() {}
+
+func (z *ZRangeStore) WrittenKeys() []string { return []string{z.destination} }
+
+func (z *ZRangeStore) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	stored, err := storage.ZSets().ZRangeStore(ctx, z.destination, z.key, z.spec)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(stored))
+}
+
+// parseRangeSpec parses start stop [BYSCORE | BYLEX] [REV] [LIMIT offset count] [WITHSCORES],
+// the arguments ZRANGE and ZRANGESTORE share, and reports whether WITHSCORES was given.
+// With REV, a score or lex range is given from max to min.
+func parseRangeSpec(args []string) (zsets.RangeSpec, bool, error) {
+	spec := zsets.RangeSpec{Count: -1}
+	withScores, limit := false, false
+	for i := 2; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "BYSCORE":
+			if spec.By == zsets.ByLex {
+				return spec, false, command.ErrSyntax
+			}
+			spec.By = zsets.ByScore
+		case "BYLEX":
+			if spec.By == zsets.ByScore {
+				return spec, false, command.ErrSyntax
+			}
+			spec.By = zsets.ByLex
+		case "REV":
+			spec.Reverse = true
+		case "WITHSCORES":
+			withScores = true
+		case "LIMIT":
+			if i+2 >= len(args) {
+				return spec, false, command.ErrSyntax
+			}
+			offset, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return spec, false, command.ErrNotInteger
+			}
+			count, err := strconv.Atoi(args[i+2])
+			if err != nil {
+				return spec, false, command.ErrNotInteger
+			}
+			spec.Offset, spec.Count, limit = offset, count, true
+			i += 2
+		default:
+			return spec, false, command.ErrSyntax
+		}
+	}
+	if limit && spec.By == zsets.ByRank {
+		return spec, false, errLimitWithoutBy
+	}
+	if withScores && spec.By == zsets.ByLex {
+		return spec, false, errWithScoresWithLex
+	}
+	min, max := args[0], args[1]
+	if spec.Reverse && spec.By != zsets.ByRank {
+		min, max = max, min
+	}
+	return spec, withScores, parseBounds(&spec, min, max)
+}
+
+// parseBounds parses the min and max of a score or lex range, or the start and stop of a rank
+// range, into spec.
+func parseBounds(spec *zsets.RangeSpec, min, max string) error {
+	var err error
+	switch spec.By {
+	case zsets.ByScore:
+		spec.Score, err = zsets.ParseScoreRange(min, max)
+	case zsets.ByLex:
+		spec.Lex, err = zsets.ParseLexRange(min, max)
+	default:
+		spec.Start, spec.Stop, err = parseRanks(min, max)
+	}
+	return err
+}
+
+func parseRanks(start, stop string) (int, int, error) {
+	from, err := strconv.Atoi(start)
+	if err != nil {
+		return 0, 0, command.ErrNotInteger
+	}
+	to, err := strconv.Atoi(stop)
+	if err != nil {
+		return 0, 0, command.ErrNotInteger
+	}
+	return from, to, nil
+}
+
+type ZRangeParser struct{}
+
+func NewZRangeParser() *ZRangeParser {
+	return &ZRangeParser{}
+}
+
+// Parse parses ZRANGE key start stop [BYSCORE | BYLEX] [REV] [LIMIT offset count] [WITHSCORES].
+func (p *ZRangeParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 3 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 3, len(msg.Args))
+	}
+	spec, withScores, err := parseRangeSpec(msg.Args[1:])
+	if err != nil {
+		return nil, err
+	}
+	return &ZRange{key: msg.Args[0], spec: spec, withScores: withScores}, nil
+}
+
+func (p *ZRangeParser) Name() string {
+	return "ZRANGE"
+}
+
+type ZRangeStoreParser struct{}
+
+func NewZRangeStoreParser() *ZRangeStoreParser {
+	return &ZRangeStoreParser{}
+}
+
+// Parse parses ZRANGESTORE destination key start stop [BYSCORE | BYLEX] [REV] [LIMIT offset count].
+func (p *ZRangeStoreParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 4 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 4, len(msg.Args))
+	}
+	spec, withScores, err := parseRangeSpec(msg.Args[2:])
+	if err != nil {
+		return nil, err
+	}
+	if withScores {
+		return nil, command.ErrSyntax
+	}
+	return &ZRangeStore{destination: msg.Args[0], key: msg.Args[1], spec: spec}, nil
+}
+
+func (p *ZRangeStoreParser) Name() string {
+	return "ZRANGESTORE"
+}
@@ -0,0 +1,103 @@
+package zset
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/zsets"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZRangeCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	spec := zsets.RangeSpec{Start: 0, Stop: -1}
+	storage.EXPECT().ZSets().Return(zs).Times(2)
+	zs.EXPECT().ZRange(ctx, "zset", spec).Return([]zsets.ScoredMember{{Member: "a", Score: 1.5}, {Member: "b", Score: 2}}, nil).Times(2)
+
+	response := (&ZRange{key: "zset", spec: spec}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Len(t, response.Value.Array, 2)
+	assert.Equal(t, []byte("b"), response.Value.Array[1].Bytes)
+
+	response = (&ZRange{key: "zset", spec: spec, withScores: true}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Len(t, response.Value.Array, 4)
+	assert.Equal(t, []byte("1.5"), response.Value.Array[1].Bytes)
+	assert.Equal(t, []byte("2"), response.Value.Array[3].Bytes)
+}
+
+func TestZRangeStoreCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	spec := zsets.RangeSpec{Start: 0, Stop: 1}
+	storage.EXPECT().ZSets().Return(zs)
+	zs.EXPECT().ZRangeStore(ctx, "destination", "zset", spec).Return(2, nil)
+
+	response := (&ZRangeStore{destination: "destination", key: "zset", spec: spec}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(2), response.Value.Number)
+}
+
+func TestZRangeParser_Parse(t *testing.T) {
+	parser := NewZRangeParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "ZRANGE", Args: []string{"zset", "1", "-1", "withscores"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZRange{key: "zset", spec: zsets.RangeSpec{Start: 1, Stop: -1, Count: -1}, withScores: true}, cmd)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "ZRANGE", Args: []string{"zset", "+inf", "(1", "BYSCORE", "REV", "LIMIT", "1", "2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, zsets.RangeSpec{
+		By:      zsets.ByScore,
+		Score:   zsets.ScoreRange{Min: 1, Max: math.Inf(1), MinExclusive: true},
+		Reverse: true,
+		Offset:  1,
+		Count:   2,
+	}, cmd.(*ZRange).spec)
+
+	cmd, err = parser.Parse(&protocol.Message{Command: "ZRANGE", Args: []string{"zset", "[a", "+", "bylex"}})
+	assert.NoError(t, err)
+	assert.Equal(t, zsets.LexRange{Min: zsets.LexBound{Value: "a"}, Max: zsets.LexBound{Infinity: 1}}, cmd.(*ZRange).spec.Lex)
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"zset", "a", "1"}, "ERR value is not an integer or out of range"},
+		{[]string{"zset", "0", "1", "LIMIT", "0", "1"}, "ERR syntax error, LIMIT is only supported in combination with either BYSCORE or BYLEX"},
+		{[]string{"zset", "-", "+", "BYLEX", "WITHSCORES"}, "ERR syntax error, WITHSCORES not supported in combination with BYLEX"},
+		{[]string{"zset", "0", "1", "BYSCORE", "BYLEX"}, "ERR syntax error"},
+		{[]string{"zset", "0", "1", "BYSCORE", "LIMIT", "0"}, "ERR syntax error"},
+		{[]string{"zset", "0", "1", "BYSCORE", "LIMIT", "a", "1"}, "ERR value is not an integer or out of range"},
+		{[]string{"zset", "0", "1", "REVERSE"}, "ERR syntax error"},
+		{[]string{"zset", "a", "1", "BYSCORE"}, "ERR min or max is not a float"},
+		{[]string{"zset", "a", "+", "BYLEX"}, "ERR min or max not valid string range item"},
+	}
+	for _, tt := range tests {
+		_, err = parser.Parse(&protocol.Message{Command: "ZRANGE", Args: tt.args})
+		assert.EqualError(t, err, tt.expected, tt.args)
+	}
+	_, err = parser.Parse(&protocol.Message{Command: "ZRANGE", Args: []string{"zset", "0"}})
+	assert.Error(t, err)
+}
+
+func TestZRangeStoreParser_Parse(t *testing.T) {
+	parser := NewZRangeStoreParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "ZRANGESTORE", Args: []string{"destination", "zset", "0", "1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZRangeStore{destination: "destination", key: "zset", spec: zsets.RangeSpec{Start: 0, Stop: 1, Count: -1}}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "ZRANGESTORE", Args: []string{"destination", "zset", "0", "1", "WITHSCORES"}})
+	assert.EqualError(t, err, "ERR syntax error")
+	_, err = parser.Parse(&protocol.Message{Command: "ZRANGESTORE", Args: []string{"destination", "zset", "0"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,77 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+	"strings"
+)
+
+// ZRank replies the rank of member by ascending score, or descending if reverse is set, and its
+// score along with it if withScore is set.
+type ZRank struct {
+	key       string
+	member    string
+	reverse   bool
+	withScore bool
+}
+
+func (z *ZRank) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	rank, score, found, err := storage.ZSets().ZRank(ctx, z.key, z.member, z.reverse)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if !found {
+		return protocol.NewNullBulkStringResponse()
+	}
+	if !z.withScore {
+		return protocol.NewNumberResponse(int64(rank))
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue([]protocol.Value{
+		protocol.NewNumberProtocolValue(int64(rank)),
+		scoreValue(score),
+	}))
+}
+
+func parseRank(name string, reverse bool, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 || len(msg.Args) > 3 {
+		return nil, command.NewInvalidArgumentsCount(name, 2, len(msg.Args))
+	}
+	cmd := &ZRank{key: msg.Args[0], member: msg.Args[1], reverse: reverse}
+	if len(msg.Args) == 3 {
+		if !strings.EqualFold(msg.Args[2], "WITHSCORE") {
+			return nil, command.ErrSyntax
+		}
+		cmd.withScore = true
+	}
+	return cmd, nil
+}
+
+type ZRankParser struct{}
+
+func NewZRankParser() *ZRankParser {
+	return &ZRankParser{}
+}
+
+func (p *ZRankParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseRank(p.Name(), false, msg)
+}
+
+func (p *ZRankParser) Name() string {
+	return "ZRANK"
+}
+
+type ZRevRankParser struct{}
+
+func NewZRevRankParser() *ZRevRankParser {
+	return &ZRevRankParser{}
+}
+
+func (p *ZRevRankParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseRank(p.Name(), true, msg)
+}
+
+func (p *ZRevRankParser) Name() string {
+	return "ZREVRANK"
+}
@@ -0,0 +1,50 @@
+package zset
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZRankCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs).Times(3)
+	zs.EXPECT().ZRank(ctx, "zset", "a", true).Return(2, 1.5, true, nil).Times(2)
+	zs.EXPECT().ZRank(ctx, "zset", "missing", false).Return(0, 0.0, false, nil)
+
+	response := (&ZRank{key: "zset", member: "a", reverse: true}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(2), response.Value.Number)
+
+	response = (&ZRank{key: "zset", member: "a", reverse: true, withScore: true}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(2), response.Value.Array[0].Number)
+	assert.Equal(t, []byte("1.5"), response.Value.Array[1].Bytes)
+
+	response = (&ZRank{key: "zset", member: "missing"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.True(t, response.Value.Null)
+}
+
+func TestZRankParsers_Parse(t *testing.T) {
+	cmd, err := NewZRankParser().Parse(&protocol.Message{Command: "ZRANK", Args: []string{"zset", "a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZRank{key: "zset", member: "a"}, cmd)
+
+	cmd, err = NewZRevRankParser().Parse(&protocol.Message{Command: "ZREVRANK", Args: []string{"zset", "a", "withscore"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZRank{key: "zset", member: "a", reverse: true, withScore: true}, cmd)
+
+	_, err = NewZRankParser().Parse(&protocol.Message{Command: "ZRANK", Args: []string{"zset", "a", "WITHSCORES"}})
+	assert.EqualError(t, err, "ERR syntax error")
+	_, err = NewZRankParser().Parse(&protocol.Message{Command: "ZRANK", Args: []string{"zset"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type ZRem struct {
+	key     string
+	members []string
+}
+
+func (z *ZRem) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	removed, err := storage.ZSets().ZRem(ctx, z.key, z.members)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(removed))
+}
+
+type ZRemParser struct{}
+
+func NewZRemParser() *ZRemParser {
+	return &ZRemParser{}
+}
+
+func (p *ZRemParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) < 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	return &ZRem{key: msg.Args[0], members: msg.Args[1:]}, nil
+}
+
+func (p *ZRemParser) Name() string {
+	return "ZREM"
+}
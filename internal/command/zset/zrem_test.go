@@ -0,0 +1,35 @@
+package zset
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZRemCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	cmd := ZRem{key: "zset", members: []string{"a", "b"}}
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs)
+	zs.EXPECT().ZRem(ctx, "zset", []string{"a", "b"}).Return(1, nil)
+	response := cmd.Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(1), response.Value.Number)
+}
+
+func TestZRemParser_Parse(t *testing.T) {
+	parser := NewZRemParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "ZREM", Args: []string{"zset", "a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZRem{key: "zset", members: []string{"a"}}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "ZREM", Args: []string{"zset"}})
+	assert.Error(t, err)
+}
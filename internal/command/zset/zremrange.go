@@ -0,0 +1,76 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"avacado/internal/storage/zsets"
+	"context"
+)
+
+// ZRemRange removes the members in a rank, score or lex range and replies how many were removed.
+type ZRemRange struct {
+	key  string
+	spec zsets.RangeSpec
+}
+
+func (z *ZRemRange) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	removed, err := storage.ZSets().ZRemRange(ctx, z.key, z.spec)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	return protocol.NewNumberResponse(int64(removed))
+}
+
+func parseRemRange(name string, by zsets.RangeBy, msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 3 {
+		return nil, command.NewInvalidArgumentsCount(name, 3, len(msg.Args))
+	}
+	spec := zsets.RangeSpec{By: by}
+	if err := parseBounds(&spec, msg.Args[1], msg.Args[2]); err != nil {
+		return nil, err
+	}
+	return &ZRemRange{key: msg.Args[0], spec: spec}, nil
+}
+
+type ZRemRangeByRankParser struct{}
+
+func NewZRemRangeByRankParser() *ZRemRangeByRankParser {
+	return &ZRemRangeByRankParser{}
+}
+
+func (p *ZRemRangeByRankParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseRemRange(p.Name(), zsets.ByRank, msg)
+}
+
+func (p *ZRemRangeByRankParser) Name() string {
+	return "ZREMRANGEBYRANK"
+}
+
+type ZRemRangeByScoreParser struct{}
+
+func NewZRemRangeByScoreParser() *ZRemRangeByScoreParser {
+	return &ZRemRangeByScoreParser{}
+}
+
+func (p *ZRemRangeByScoreParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseRemRange(p.Name(), zsets.ByScore, msg)
+}
+
+func (p *ZRemRangeByScoreParser) Name() string {
+	return "ZREMRANGEBYSCORE"
+}
+
+type ZRemRangeByLexParser struct{}
+
+func NewZRemRangeByLexParser() *ZRemRangeByLexParser {
+	return &ZRemRangeByLexParser{}
+}
+
+func (p *ZRemRangeByLexParser) Parse(msg *protocol.Message) (command.Command, error) {
+	return parseRemRange(p.Name(), zsets.ByLex, msg)
+}
+
+func (p *ZRemRangeByLexParser) Name() string {
+	return "ZREMRANGEBYLEX"
+}
@@ -0,0 +1,46 @@
+package zset
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	"avacado/internal/storage/zsets"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZRemRangeCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	spec := zsets.RangeSpec{Start: 0, Stop: 1}
+	storage.EXPECT().ZSets().Return(zs)
+	zs.EXPECT().ZRemRange(ctx, "zset", spec).Return(2, nil)
+
+	response := (&ZRemRange{key: "zset", spec: spec}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, int64(2), response.Value.Number)
+}
+
+func TestZRemRangeParsers_Parse(t *testing.T) {
+	cmd, err := NewZRemRangeByRankParser().Parse(&protocol.Message{Command: "ZREMRANGEBYRANK", Args: []string{"zset", "0", "-2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZRemRange{key: "zset", spec: zsets.RangeSpec{Start: 0, Stop: -2}}, cmd)
+
+	cmd, err = NewZRemRangeByScoreParser().Parse(&protocol.Message{Command: "ZREMRANGEBYSCORE", Args: []string{"zset", "-inf", "(5"}})
+	assert.NoError(t, err)
+	assert.Equal(t, zsets.ByScore, cmd.(*ZRemRange).spec.By)
+
+	cmd, err = NewZRemRangeByLexParser().Parse(&protocol.Message{Command: "ZREMRANGEBYLEX", Args: []string{"zset", "[a", "[c"}})
+	assert.NoError(t, err)
+	assert.Equal(t, zsets.ByLex, cmd.(*ZRemRange).spec.By)
+
+	_, err = NewZRemRangeByRankParser().Parse(&protocol.Message{Command: "ZREMRANGEBYRANK", Args: []string{"zset", "0", "a"}})
+	assert.EqualError(t, err, "ERR value is not an integer or out of range")
+	_, err = NewZRemRangeByLexParser().Parse(&protocol.Message{Command: "ZREMRANGEBYLEX", Args: []string{"zset", "a"}})
+	assert.Error(t, err)
+}
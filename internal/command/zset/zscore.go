@@ -0,0 +1,41 @@
+package zset
+
+import (
+	"avacado/internal/command"
+	"avacado/internal/protocol"
+	"avacado/internal/storage"
+	"context"
+)
+
+type ZScore struct {
+	key    string
+	member string
+}
+
+func (z *ZScore) Execute(ctx context.Context, storage storage.Storage) *protocol.Response {
+	score, found, err := storage.ZSets().ZScore(ctx, z.key, z.member)
+	if err != nil {
+		return protocol.NewErrorResponse(err)
+	}
+	if !found {
+		return protocol.NewNullBulkStringResponse()
+	}
+	return protocol.NewSuccessResponse(scoreValue(score))
+}
+
+type ZScoreParser struct{}
+
+func NewZScoreParser() *ZScoreParser {
+	return &ZScoreParser{}
+}
+
+func (p *ZScoreParser) Parse(msg *protocol.Message) (command.Command, error) {
+	if len(msg.Args) != 2 {
+		return nil, command.NewInvalidArgumentsCount(p.Name(), 2, len(msg.Args))
+	}
+	return &ZScore{key: msg.Args[0], member: msg.Args[1]}, nil
+}
+
+func (p *ZScoreParser) Name() string {
+	return "ZSCORE"
+}
@@ -0,0 +1,40 @@
+package zset
+
+import (
+	"avacado/internal/protocol"
+	mocksstorage "avacado/internal/storage/mock"
+	mockzsets "avacado/internal/storage/zsets/mock"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZScoreCommand_Execute(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+	storage := mocksstorage.NewMockStorage(controller)
+	zs := mockzsets.NewMockZSets(controller)
+	storage.EXPECT().ZSets().Return(zs).Times(2)
+	zs.EXPECT().ZScore(ctx, "zset", "a").Return(1e21, true, nil)
+	zs.EXPECT().ZScore(ctx, "zset", "missing").Return(0.0, false, nil)
+
+	response := (&ZScore{key: "zset", member: "a"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.Equal(t, []byte("1e+21"), response.Value.Bytes)
+
+	response = (&ZScore{key: "zset", member: "missing"}).Execute(ctx, storage)
+	assert.Nil(t, response.Err)
+	assert.True(t, response.Value.Null)
+}
+
+func TestZScoreParser_Parse(t *testing.T) {
+	parser := NewZScoreParser()
+	cmd, err := parser.Parse(&protocol.Message{Command: "ZSCORE", Args: []string{"zset", "a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZScore{key: "zset", member: "a"}, cmd)
+
+	_, err = parser.Parse(&protocol.Message{Command: "ZSCORE", Args: []string{"zset"}})
+	assert.Error(t, err)
+}
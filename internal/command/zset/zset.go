@@ -0,0 +1,23 @@
+package zset
+
+import (
+	"avacado/internal/protocol"
+	"avacado/internal/storage/zsets"
+)
+
+// scoreValue replies score as a bulk string formatted the way Redis does.
+func scoreValue(score float64) protocol.Value {
+	return protocol.NewBulkStringProtocolValue([]byte(zsets.FormatScore(score)))
+}
+
+// scoredMembersResponse replies members in order, each followed by its score if withScores is set.
+func scoredMembersResponse(members []zsets.ScoredMember, withScores bool) *protocol.Response {
+	values := make([]protocol.Value, 0, len(members)*2)
+	for _, member := range members {
+		values = append(values, protocol.NewBulkStringProtocolValue([]byte(member.Member)))
+		if withScores {
+			values = append(values, scoreValue(member.Score))
+		}
+	}
+	return protocol.NewSuccessResponse(protocol.NewArrayProtocolValue(values))
+}
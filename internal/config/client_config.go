@@ -8,6 +8,8 @@ const ClientConfigKey ContextKey = "clientConfig"
 
 type ClientConfig struct {
 	ProtocolVersion int
+	// DB is the index of the database the client selected with SELECT
+	DB int
 }
 
 func DefaultClientConfig() *ClientConfig {
@@ -19,3 +21,11 @@ func IsProto3(ctx context.Context) bool {
 	config := cc.(*ClientConfig)
 	return config.ProtocolVersion == 3
 }
+
+// SelectedDB returns the index of the database selected by the client, 0 when ctx carries no client config.
+func SelectedDB(ctx context.Context) int {
+	if config, ok := ctx.Value(ClientConfigKey).(*ClientConfig); ok {
+		return config.DB
+	}
+	return 0
+}
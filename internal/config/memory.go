@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// memoryUnits are the suffixes accepted by ParseMemory, as in redis.conf: k, m and g are powers
+// of 1000 while kb, mb and gb are powers of 1024.
+var memoryUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"kb", 1 << 10}, {"mb", 1 << 20}, {"gb", 1 << 30},
+	{"k", 1000}, {"m", 1000 * 1000}, {"g", 1000 * 1000 * 1000},
+	{"b", 1},
+}
+
+// ParseMemory parses an amount of memory such as "100mb" into bytes. A bare number is in bytes.
+func ParseMemory(s string) (int64, error) {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	multiplier := int64(1)
+	for _, unit := range memoryUnits {
+		if number, found := strings.CutSuffix(lower, unit.suffix); found {
+			lower, multiplier = number, unit.multiplier
+			break
+		}
+	}
+	n, err := strconv.ParseInt(lower, 10, 64)
+	if err != nil || n < 0 || n > (1<<63-1)/multiplier {
+		return 0, fmt.Errorf("invalid memory amount %q", s)
+	}
+	return n * multiplier, nil
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMemory(t *testing.T) {
+	for input, expected := range map[string]int64{
+		"0":     0,
+		"1024":  1024,
+		"100b":  100,
+		"2k":    2000,
+		"2kb":   2048,
+		"3MB":   3 << 20,
+		"1m":    1000 * 1000,
+		"1gb":   1 << 30,
+		" 5g ":  5 * 1000 * 1000 * 1000,
+		"512mb": 512 << 20,
+	} {
+		actual, err := ParseMemory(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, actual, input)
+	}
+
+	for _, input := range []string{"", "mb", "ten", "-1", "1tb", "99999999999gb"} {
+		_, err := ParseMemory(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -2,19 +2,47 @@ package executor
 
 import (
 	"avacado/internal/command"
+	"avacado/internal/config"
 	"avacado/internal/protocol"
 	"avacado/internal/storage"
-	"avacado/internal/storage/lists"
 	"context"
+	"slices"
 	"sync/atomic"
+	"time"
 )
 
-// blockedClient represents a BLPOP/BRPOP client waiting for data on one or more keys.
+const (
+	// serverHz is how many times per second the executor runs its periodic background tasks.
+	serverHz = 10
+	// activeExpireCyclePercent is the share of each tick the active expire cycle may use.
+	activeExpireCyclePercent = 25
+)
+
+// activeExpirer is implemented by databases able to reclaim expired keys that are never accessed again.
+type activeExpirer interface {
+	ActiveExpireCycle(timeLimit time.Duration)
+}
+
+// evictor is implemented by databases bounded by maxmemory. PerformEvictions reports whether
+// the memory used fits in maxmemory once it is done evicting.
+type evictor interface {
+	PerformEvictions() bool
+}
+
+// blockedKey is a key a client is blocked on, within the database the client selected.
+type blockedKey struct {
+	db  int
+	key string
+}
+
+// blockedClient represents a client of a blocking command such as BLPOP or BZPOPMIN waiting
+// for data on one or more keys, served by its unblocker once one of them has some.
 // The executor owns all blocked clients; they are only accessed from the executor goroutine
 // except for cancelled (atomic) which is written by the timeout goroutine.
 type blockedClient struct {
+	db        int
 	keys      []string
-	direction string
+	unblocker command.Unblocker
 	resultCh  chan *protocol.Response
 	cancelled atomic.Bool
 }
@@ -26,44 +54,84 @@ type commandRequest struct {
 }
 
 // Executor serialises command execution through a single goroutine so storage
-// needs no internal locking. It also manages the blocked-client queue for
-// BLPOP/BRPOP: when a push arrives the executor delivers to any waiting client.
+// needs no internal locking. Each command runs against the database selected by its client.
+// It also manages the blocked-client queue of the blocking commands: when a command writes
+// to a key clients are blocked on, the executor serves those it can.
+// Before each command it evicts keys if maxmemory is exceeded, refusing the commands adding data
+// when that is not enough. Between commands it runs periodic background tasks such as the active expire cycle.
 type Executor struct {
 	queue          chan commandRequest
-	store          storage.Storage
-	blockedClients map[string][]*blockedClient // key → waiting clients (FIFO)
+	databases      storage.Databases
+	blockedClients map[blockedKey][]*blockedClient // key → waiting clients (FIFO)
+	// cancelledClients are the clients that timed out or disconnected, to forget about.
+	cancelledClients chan *blockedClient
 }
 
-func New(store storage.Storage) *Executor {
+func New(databases storage.Databases) *Executor {
 	return &Executor{
-		queue:          make(chan commandRequest, 1024),
-		store:          store,
-		blockedClients: make(map[string][]*blockedClient),
+		queue:            make(chan commandRequest, 1024),
+		databases:        databases,
+		blockedClients:   make(map[blockedKey][]*blockedClient),
+		cancelledClients: make(chan *blockedClient, 1024),
 	}
 }
 
 // Run processes commands one at a time. Call as a goroutine; exits when ctx is cancelled.
 func (e *Executor) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Second / serverHz)
+	defer ticker.Stop()
 	for {
 		select {
 		case req := <-e.queue:
-			execCtx := command.ContextWithBlockRegistry(req.ctx, e)
-			resp := req.cmd.Execute(execCtx, e.store)
-			// After a push command, check if any blocked BLPOP/BRPOP can be served.
-			if pusher, ok := req.cmd.(interface{ PushedKey() string }); ok {
-				if key := pusher.PushedKey(); key != "" {
-					e.tryUnblockClient(key)
-				}
-			}
-			req.respCh <- resp
+			req.respCh <- e.execute(req)
+		case client := <-e.cancelledClients:
+			e.removeBlockedClient(client)
+		case <-ticker.C:
+			e.cron()
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// execute runs a single command against the database selected by its client.
+func (e *Executor) execute(req commandRequest) *protocol.Response {
+	if evictor, ok := e.databases.(evictor); ok && !evictor.PerformEvictions() {
+		if _, denyOOM := req.cmd.(command.DenyOOM); denyOOM {
+			return protocol.NewErrorResponse(command.ErrOOM)
+		}
+	}
+	execCtx := command.ContextWithDatabases(command.ContextWithBlockRegistry(req.ctx, e), e.databases)
+	db := config.SelectedDB(req.ctx)
+	resp := req.cmd.Execute(execCtx, e.databases.DB(db))
+	// After a command writing keys, check if any client blocked on them can be served.
+	if writer, ok := req.cmd.(command.KeyWriter); ok {
+		for _, key := range writer.WrittenKeys() {
+			e.tryUnblockClient(blockedKey{db: db, key: key})
+		}
+	}
+	if writer, ok := req.cmd.(command.DBKeyWriter); ok {
+		for _, key := range writer.WrittenDBKeys() {
+			e.tryUnblockClient(blockedKey{db: key.DB, key: key.Key})
+		}
+	}
+	// After SWAPDB, the keys clients are blocked on may now hold data.
+	if swapper, ok := req.cmd.(command.DBSwapper); ok {
+		first, second := swapper.SwappedDBs()
+		e.tryUnblockClientsOf(first, second)
+	}
+	return resp
+}
+
+// cron runs the periodic background tasks, serverHz times per second.
+func (e *Executor) cron() {
+	if expirer, ok := e.databases.(activeExpirer); ok {
+		expirer.ActiveExpireCycle(time.Second / serverHz * activeExpireCyclePercent / 100)
+	}
+}
+
 // Submit enqueues cmd and blocks until the executor returns a response.
-// For blocking commands (BLPOP/BRPOP with no immediate data), the returned
+// For blocking commands (BLPOP, BZPOPMIN... with no immediate data), the returned
 // Response has a non-nil BlockCh; the caller must wait on that channel.
 func (e *Executor) Submit(ctx context.Context, cmd command.Command) *protocol.Response {
 	respCh := make(chan *protocol.Response, 1)
@@ -77,78 +145,102 @@ func (e *Executor) SubmitAsync(cmd command.Command) {
 	select {
 	case e.queue <- commandRequest{cmd: cmd, ctx: context.Background(), respCh: respCh}:
 	default:
-		// Queue full — skip (e.g. a TTL cleanup tick); will retry on next tick.
+		// Queue full — drop the command.
 	}
 }
 
 // RegisterBlockedClient implements command.BlockRegistry. It is called from
 // within Execute(), which runs inside the executor goroutine, so no locking
-// is needed for the blocked-clients map.
-func (e *Executor) RegisterBlockedClient(keys []string, direction string) (<-chan *protocol.Response, context.CancelFunc) {
+// is needed for the blocked-clients map. The client blocks on keys of the database it selected.
+func (e *Executor) RegisterBlockedClient(ctx context.Context, keys []string, unblocker command.Unblocker) (<-chan *protocol.Response, context.CancelFunc) {
 	resultCh := make(chan *protocol.Response, 1)
-	client := &blockedClient{keys: keys, direction: direction, resultCh: resultCh}
+	client := &blockedClient{db: config.SelectedDB(ctx), keys: keys, unblocker: unblocker, resultCh: resultCh}
 	for _, key := range keys {
-		e.blockedClients[key] = append(e.blockedClients[key], client)
+		bk := blockedKey{db: client.db, key: key}
+		e.blockedClients[bk] = append(e.blockedClients[bk], client)
 	}
 	// The cancel function is safe to call from any goroutine: it uses CAS to
 	// ensure only one caller (timeout goroutine OR executor) delivers to resultCh.
 	cancelFn := func() {
 		if client.cancelled.CompareAndSwap(false, true) {
 			client.resultCh <- protocol.NewNullBulkStringResponse()
+			select {
+			case e.cancelledClients <- client:
+			default:
+				// Queue full — the client is pruned by the next write to its keys instead.
+			}
 		}
 	}
 	return resultCh, cancelFn
 }
 
-// tryUnblockClient is called by the executor after a successful push to key.
-// It finds the first non-cancelled waiting client, pops one element for it,
-// and removes it from the blocked-clients map.
-func (e *Executor) tryUnblockClient(key string) {
-	clients := e.blockedClients[key]
-	if len(clients) == 0 {
-		return
+// tryUnblockClientsOf tries to serve every client blocked on a key of the given databases.
+func (e *Executor) tryUnblockClientsOf(dbs ...int) {
+	var keys []blockedKey
+	for bk := range e.blockedClients {
+		for _, db := range dbs {
+			if bk.db == db {
+				keys = append(keys, bk)
+			}
+		}
 	}
+	for _, bk := range keys {
+		e.tryUnblockClient(bk)
+	}
+}
 
-	for _, client := range clients {
+// tryUnblockClient is called by the executor after a command wrote to key.
+// It serves the waiting clients in the order they blocked, as long as key holds data they can
+// take, removing each from the blocked-clients map, then prunes the cancelled ones.
+func (e *Executor) tryUnblockClient(bk blockedKey) {
+	store, key := e.databases.DB(bk.db), bk.key
+	ctx := context.Background()
+	// Serving a client edits the map entry, so walk a copy of it.
+	for _, client := range slices.Clone(e.blockedClients[bk]) {
+		// A write that failed (e.g. WRONGTYPE) or data of another type leaves nothing to take;
+		// keep the client waiting.
+		if client.cancelled.Load() || !client.unblocker.Ready(ctx, store, key) {
+			continue
+		}
 		// CAS(false→true): if we win, we are responsible for delivering.
 		// If already true, the timeout goroutine already cancelled this client.
 		if client.cancelled.CompareAndSwap(false, true) {
-			var elements [][]byte
-			if client.direction == lists.Left {
-				elements, _ = e.store.Lists().LPop(context.Background(), key, 1)
-			} else {
-				elements, _ = e.store.Lists().RPop(context.Background(), key, 1)
-			}
-			if len(elements) > 0 {
-				client.resultCh <- protocol.NewArrayResponse([]any{key, elements[0]})
-			} else {
-				client.resultCh <- protocol.NewNullBulkStringResponse()
-			}
-			e.removeBlockedClient(key, client)
-			return
+			client.resultCh <- client.unblocker.Unblock(ctx, store, key)
+			e.removeBlockedClient(client)
 		}
 	}
 
-	// All clients in the list are cancelled — prune them.
-	kept := clients[:0]
-	for _, c := range clients {
+	kept := e.blockedClients[bk][:0]
+	for _, c := range e.blockedClients[bk] {
 		if !c.cancelled.Load() {
 			kept = append(kept, c)
 		}
 	}
-	e.blockedClients[key] = kept
+	if len(kept) == 0 {
+		delete(e.blockedClients, bk)
+		return
+	}
+	e.blockedClients[bk] = kept
 }
 
 // removeBlockedClient removes target from the blocked-clients map for all of its watched keys.
-func (e *Executor) removeBlockedClient(_ string, target *blockedClient) {
+func (e *Executor) removeBlockedClient(target *blockedClient) {
 	for _, key := range target.keys {
-		others := e.blockedClients[key]
+		bk := blockedKey{db: target.db, key: key}
+		others, found := e.blockedClients[bk]
+		if !found {
+			continue
+		}
 		kept := others[:0]
 		for _, c := range others {
 			if c != target {
 				kept = append(kept, c)
 			}
 		}
-		e.blockedClients[key] = kept
+		if len(kept) == 0 {
+			delete(e.blockedClients, bk)
+			continue
+		}
+		e.blockedClients[bk] = kept
 	}
 }
@@ -0,0 +1,24 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutor_ForgetsCancelledClients(t *testing.T) {
+	e := New(nil)
+	ctx := context.Background()
+	resultCh, cancel := e.RegisterBlockedClient(ctx, []string{"a", "b"}, nil)
+	_, _ = e.RegisterBlockedClient(ctx, []string{"b"}, nil)
+
+	cancel()
+	cancel()
+	assert.True(t, (<-resultCh).Value.Null)
+	e.removeBlockedClient(<-e.cancelledClients)
+	assert.Len(t, e.cancelledClients, 0)
+
+	assert.NotContains(t, e.blockedClients, blockedKey{key: "a"})
+	assert.Len(t, e.blockedClients[blockedKey{key: "b"}], 1)
+}
@@ -36,16 +36,32 @@ type Connection interface {
 	io.Closer
 }
 
+// parsed is the outcome of reading the next message of a connection.
+type parsed struct {
+	message *protocol.Message
+	err     error
+}
+
 func (s *Server) Serve(conn Connection, logger *slog.Logger) error {
 	clientConfig := config.DefaultClientConfig()
-	ctx := context.WithValue(context.Background(), config.ClientConfigKey, clientConfig)
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), config.ClientConfigKey, clientConfig))
+	defer cancel()
 	defer func() {
 		logger.Info("closing connection")
 		_ = conn.Close()
 	}()
 	parser := s.protocol.CreateParser(conn)
+	// next holds the message read while the client was blocked, if any.
+	var next chan parsed
 	for {
-		message, err := parser.Parse()
+		var message *protocol.Message
+		var err error
+		if next != nil {
+			result := <-next
+			message, err, next = result.message, result.err, nil
+		} else {
+			message, err = parser.Parse()
+		}
 		if err == io.EOF {
 			return nil
 		}
@@ -61,20 +77,15 @@ func (s *Server) Serve(conn Connection, logger *slog.Logger) error {
 			continue
 		}
 		response := s.executor.Submit(ctx, cmd)
-		if response.Err != nil {
-			_, _ = conn.Write(s.protocol.SerializeError(response.Err))
-			continue
-		}
 
 		// Blocking commands (BLPOP/BRPOP) return a non-nil BlockCh when no data
 		// is immediately available. Wait for the result on that channel.
 		if response.BlockCh != nil {
-			select {
-			case finalResp := <-response.BlockCh:
-				response = finalResp
-			case <-ctx.Done():
-				return nil
-			}
+			response, next = awaitBlocked(cancel, parser, response.BlockCh)
+		}
+		if response.Err != nil {
+			_, _ = conn.Write(s.protocol.SerializeError(response.Err))
+			continue
 		}
 
 		bytes, err := s.protocol.Serialize(response)
@@ -85,3 +96,24 @@ func (s *Server) Serve(conn Connection, logger *slog.Logger) error {
 		_, _ = conn.Write(bytes)
 	}
 }
+
+// awaitBlocked waits for the reply of a blocked client. Meanwhile it reads the next message of
+// the connection, so that a disconnection cancels the client context, which unblocks the client.
+// The message read is returned along with the reply, to be served next.
+func awaitBlocked(cancel context.CancelFunc, parser protocol.Parser, blockCh <-chan *protocol.Response) (*protocol.Response, chan parsed) {
+	next := make(chan parsed, 1)
+	go func() {
+		message, err := parser.Parse()
+		next <- parsed{message: message, err: err}
+	}()
+	select {
+	case reply := <-blockCh:
+		return reply, next
+	case result := <-next:
+		if result.err != nil {
+			cancel()
+		}
+		next <- result
+		return <-blockCh, next
+	}
+}
@@ -19,11 +19,11 @@ import (
 func TestServer_HandlesErrorOnProtocolMessageParsing(t *testing.T) {
 	controller := gomock.NewController(t)
 	proto := mockprotocol.NewMockProtocol(controller)
-	store := mocksstorage.NewMockStorage(controller)
+	databases := mocksstorage.NewMockDatabases(controller)
 	registry := mockcommand.NewMockParserRegistry(controller)
 	parser := mockprotocol.NewMockParser(controller)
 
-	exec := executor.New(store)
+	exec := executor.New(databases)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	go exec.Run(ctx)
@@ -48,11 +48,11 @@ func TestServer_HandlesErrorOnProtocolMessageParsing(t *testing.T) {
 func TestServer_HandlesErrorOnCommandParsing(t *testing.T) {
 	controller := gomock.NewController(t)
 	proto := mockprotocol.NewMockProtocol(controller)
-	store := mocksstorage.NewMockStorage(controller)
+	databases := mocksstorage.NewMockDatabases(controller)
 	registry := mockcommand.NewMockParserRegistry(controller)
 	parser := mockprotocol.NewMockParser(controller)
 
-	exec := executor.New(store)
+	exec := executor.New(databases)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	go exec.Run(ctx)
@@ -79,13 +79,13 @@ func TestServer_HandlesErrorOnCommandParsing(t *testing.T) {
 func TestServer_HandlesCommandExecutionError(t *testing.T) {
 	controller := gomock.NewController(t)
 	proto := mockprotocol.NewMockProtocol(controller)
-	store := mocksstorage.NewMockStorage(controller)
+	databases := mocksstorage.NewMockDatabases(controller)
 	registry := mockcommand.NewMockParserRegistry(controller)
 	cmd := mockcommand.NewMockCommand(controller)
 	parser := mockprotocol.NewMockParser(controller)
 	resp := protocol2.NewErrorResponse(fmt.Errorf("command Execution fail"))
 
-	exec := executor.New(store)
+	exec := executor.New(databases)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	go exec.Run(ctx)
@@ -102,7 +102,9 @@ func TestServer_HandlesCommandExecutionError(t *testing.T) {
 	proto.EXPECT().CreateParser(connection).Return(parser)
 	parser.EXPECT().Parse().Return(msg, nil)
 	registry.EXPECT().Parse(gomock.Any()).Return(cmd, nil)
-	cmd.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(resp)
+	store := mocksstorage.NewMockStorage(controller)
+	databases.EXPECT().DB(0).Return(store)
+	cmd.EXPECT().Execute(gomock.Any(), store).Return(resp)
 	proto.EXPECT().SerializeError(gomock.Any()).Return([]byte("-command Execution fail\r\n"))
 	parser.EXPECT().Parse().Return(nil, io.EOF)
 
@@ -114,12 +116,12 @@ func TestServer_HandlesCommandExecutionSuccess(t *testing.T) {
 	controller := gomock.NewController(t)
 	proto := mockprotocol.NewMockProtocol(controller)
 	registry := mockcommand.NewMockParserRegistry(controller)
-	store := mocksstorage.NewMockStorage(controller)
+	databases := mocksstorage.NewMockDatabases(controller)
 	cmd := mockcommand.NewMockCommand(controller)
 	resp := protocol2.NewSuccessResponse(protocol2.NewStringProtocolValue("OK"))
 	parser := mockprotocol.NewMockParser(controller)
 
-	exec := executor.New(store)
+	exec := executor.New(databases)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	go exec.Run(ctx)
@@ -136,7 +138,9 @@ func TestServer_HandlesCommandExecutionSuccess(t *testing.T) {
 	proto.EXPECT().CreateParser(connection).Return(parser)
 	parser.EXPECT().Parse().Return(msg, nil)
 	registry.EXPECT().Parse(gomock.Any()).Return(cmd, nil)
-	cmd.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(resp)
+	store := mocksstorage.NewMockStorage(controller)
+	databases.EXPECT().DB(0).Return(store)
+	cmd.EXPECT().Execute(gomock.Any(), store).Return(resp)
 	proto.EXPECT().Serialize(gomock.Any()).Return([]byte("-command success\r\n"), nil)
 	parser.EXPECT().Parse().Return(nil, io.EOF)
 
@@ -0,0 +1,366 @@
+// Package dict implements the hash table behind the keyspace and hash-encoded values.
+//
+// It follows Redis's dict: two bucket tables so a resize is spread over many operations by
+// incremental rehashing instead of stalling the executor, and a reverse-binary cursor for Scan
+// which returns every entry present for the whole iteration at least once, even across resizes.
+package dict
+
+import (
+	"hash/maphash"
+	"math/bits"
+	"math/rand/v2"
+)
+
+const (
+	// initialSize is the number of buckets of the first table.
+	initialSize = 4
+	// minFill is the inverse of the load factor under which the table is shrunk.
+	minFill = 8
+	// rehashEmptyVisits bounds, per migrated bucket, how many empty buckets a rehash step may skip.
+	rehashEmptyVisits = 10
+	// sampleStepsPerKey bounds how many buckets Sample visits per requested key.
+	sampleStepsPerKey = 10
+)
+
+type entry[V any] struct {
+	key   string
+	value V
+	next  *entry[V]
+}
+
+type table[V any] struct {
+	buckets []*entry[V]
+	used    int
+}
+
+func (t *table[V]) size() uint64 {
+	return uint64(len(t.buckets))
+}
+
+func (t *table[V]) mask() uint64 {
+	if len(t.buckets) == 0 {
+		return 0
+	}
+	return uint64(len(t.buckets)) - 1
+}
+
+// Dict is a hash table with string keys. While rehashing, entries move from tables[0] to tables[1]
+// one bucket at a time, starting at rehashIdx.
+// Dict is not safe for concurrent use, and must not be modified from inside the callbacks it calls.
+type Dict[V any] struct {
+	tables    [2]table[V]
+	rehashIdx int
+	seed      maphash.Seed
+}
+
+func New[V any]() *Dict[V] {
+	return &Dict[V]{rehashIdx: -1, seed: maphash.MakeSeed()}
+}
+
+// Len returns the number of entries.
+func (d *Dict[V]) Len() int {
+	return d.tables[0].used + d.tables[1].used
+}
+
+func (d *Dict[V]) isRehashing() bool {
+	return d.rehashIdx != -1
+}
+
+func (d *Dict[V]) hash(key string) uint64 {
+	return maphash.String(d.seed, key)
+}
+
+// Get returns the value stored at key.
+func (d *Dict[V]) Get(key string) (V, bool) {
+	if e := d.find(key); e != nil {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (d *Dict[V]) find(key string) *entry[V] {
+	if d.Len() == 0 {
+		return nil
+	}
+	d.rehashStep()
+	h := d.hash(key)
+	for i := range d.tables {
+		t := &d.tables[i]
+		if t.size() == 0 {
+			continue
+		}
+		for e := t.buckets[h&t.mask()]; e != nil; e = e.next {
+			if e.key == key {
+				return e
+			}
+		}
+		if !d.isRehashing() {
+			break
+		}
+	}
+	return nil
+}
+
+// Set stores value at key and reports whether key was added rather than updated.
+func (d *Dict[V]) Set(key string, value V) bool {
+	if e := d.find(key); e != nil {
+		e.value = value
+		return false
+	}
+	d.expandIfNeeded()
+	// New entries go to the table being rehashed into, so tables[0] only ever shrinks.
+	t := &d.tables[0]
+	if d.isRehashing() {
+		t = &d.tables[1]
+	}
+	idx := d.hash(key) & t.mask()
+	t.buckets[idx] = &entry[V]{key: key, value: value, next: t.buckets[idx]}
+	t.used++
+	return true
+}
+
+// Delete removes key and returns the value it held.
+func (d *Dict[V]) Delete(key string) (V, bool) {
+	var zero V
+	if d.Len() == 0 {
+		return zero, false
+	}
+	d.rehashStep()
+	h := d.hash(key)
+	for i := range d.tables {
+		t := &d.tables[i]
+		if t.size() == 0 {
+			continue
+		}
+		idx := h & t.mask()
+		var prev *entry[V]
+		for e := t.buckets[idx]; e != nil; e = e.next {
+			if e.key != key {
+				prev = e
+				continue
+			}
+			if prev == nil {
+				t.buckets[idx] = e.next
+			} else {
+				prev.next = e.next
+			}
+			t.used--
+			d.shrinkIfNeeded()
+			return e.value, true
+		}
+		if !d.isRehashing() {
+			break
+		}
+	}
+	return zero, false
+}
+
+// Range calls fn for every entry until fn returns false.
+func (d *Dict[V]) Range(fn func(key string, value V) bool) {
+	for i := range d.tables {
+		for _, e := range d.tables[i].buckets {
+			for ; e != nil; e = e.next {
+				if !fn(e.key, e.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Scan calls fn for the entries of the bucket designated by cursor and returns the cursor
+// of the next bucket, 0 once every bucket was visited. Start with cursor 0.
+//
+// The cursor is incremented on its reversed bits, so when the table grows or shrinks between
+// two calls the buckets already visited map to buckets that stay behind the cursor. Entries
+// present for the whole scan are returned at least once; some may be returned more than once.
+func (d *Dict[V]) Scan(cursor uint64, fn func(key string, value V)) uint64 {
+	if d.Len() == 0 {
+		return 0
+	}
+	emit := func(t *table[V], idx uint64) {
+		for e := t.buckets[idx]; e != nil; e = e.next {
+			fn(e.key, e.value)
+		}
+	}
+	if !d.isRehashing() {
+		t := &d.tables[0]
+		emit(t, cursor&t.mask())
+		return nextCursor(cursor, t.mask())
+	}
+
+	small, large := &d.tables[0], &d.tables[1]
+	if small.size() > large.size() {
+		small, large = large, small
+	}
+	smallMask, largeMask := small.mask(), large.mask()
+	emit(small, cursor&smallMask)
+	// Visit every bucket of the larger table that expands the bucket of the smaller one.
+	for {
+		emit(large, cursor&largeMask)
+		cursor = nextCursor(cursor, largeMask)
+		if cursor&(smallMask^largeMask) == 0 {
+			break
+		}
+	}
+	return cursor
+}
+
+// nextCursor increments the bits of cursor covered by mask, starting from the most significant one.
+func nextCursor(cursor, mask uint64) uint64 {
+	cursor |= ^mask
+	cursor = bits.Reverse64(cursor)
+	cursor++
+	return bits.Reverse64(cursor)
+}
+
+// RandomKey returns an entry picked at random, and false if the dict is empty.
+func (d *Dict[V]) RandomKey() (string, V, bool) {
+	var zero V
+	if d.Len() == 0 {
+		return "", zero, false
+	}
+	d.rehashStep()
+	var head *entry[V]
+	for head == nil {
+		if d.isRehashing() {
+			// Buckets of tables[0] below rehashIdx are empty, skip them.
+			size0 := d.tables[0].size()
+			h := uint64(d.rehashIdx) + rand.Uint64N(size0+d.tables[1].size()-uint64(d.rehashIdx))
+			if h >= size0 {
+				head = d.tables[1].buckets[h-size0]
+			} else {
+				head = d.tables[0].buckets[h]
+			}
+		} else {
+			head = d.tables[0].buckets[rand.Uint64()&d.tables[0].mask()]
+		}
+	}
+	// Pick uniformly within the chain.
+	length := 0
+	for e := head; e != nil; e = e.next {
+		length++
+	}
+	e := head
+	for n := rand.IntN(length); n > 0; n-- {
+		e = e.next
+	}
+	return e.key, e.value, true
+}
+
+// Sample calls fn for up to count distinct entries, read from consecutive buckets starting at a
+// random one. It is cheaper than count calls to RandomKey but the entries are less evenly
+// distributed, which is fine for the sampling done by expiry and eviction.
+func (d *Dict[V]) Sample(count int, fn func(key string, value V)) {
+	count = min(count, d.Len())
+	if count == 0 {
+		return
+	}
+	for i := 0; i < count && d.isRehashing(); i++ {
+		d.rehashStep()
+	}
+	tables := 1
+	if d.isRehashing() {
+		tables = 2
+	}
+	maxMask := d.tables[0].mask()
+	if tables == 2 {
+		maxMask = max(maxMask, d.tables[1].mask())
+	}
+	idx := rand.Uint64() & maxMask
+	sampled := 0
+	// Never walk more than all the buckets once, so no entry is seen twice.
+	steps := min(uint64(count*sampleStepsPerKey), maxMask+1)
+	for ; sampled < count && steps > 0; steps-- {
+		for j := 0; j < tables; j++ {
+			t := &d.tables[j]
+			if j == 0 && tables == 2 && idx < uint64(d.rehashIdx) {
+				// Already migrated, the bucket is empty.
+				continue
+			}
+			if idx >= t.size() {
+				continue
+			}
+			for e := t.buckets[idx]; e != nil && sampled < count; e = e.next {
+				fn(e.key, e.value)
+				sampled++
+			}
+		}
+		idx = (idx + 1) & maxMask
+	}
+}
+
+// expandIfNeeded starts growing the table once it holds as many entries as buckets.
+func (d *Dict[V]) expandIfNeeded() {
+	if d.isRehashing() {
+		return
+	}
+	t := &d.tables[0]
+	if t.size() == 0 {
+		t.buckets = make([]*entry[V], initialSize)
+		return
+	}
+	if uint64(t.used) >= t.size() {
+		d.resize(t.used + 1)
+	}
+}
+
+// shrinkIfNeeded starts shrinking the table once less than one bucket in minFill is used.
+func (d *Dict[V]) shrinkIfNeeded() {
+	if d.isRehashing() {
+		return
+	}
+	t := &d.tables[0]
+	if t.size() > initialSize && uint64(t.used*minFill) <= t.size() {
+		d.resize(max(t.used, initialSize))
+	}
+}
+
+// resize starts rehashing into a table of the smallest power of two holding size entries.
+func (d *Dict[V]) resize(size int) {
+	buckets := uint64(initialSize)
+	for buckets < uint64(size) {
+		buckets <<= 1
+	}
+	if buckets == d.tables[0].size() {
+		return
+	}
+	d.tables[1] = table[V]{buckets: make([]*entry[V], buckets)}
+	d.rehashIdx = 0
+}
+
+// rehashStep migrates one bucket of tables[0], skipping a bounded number of empty buckets.
+func (d *Dict[V]) rehashStep() {
+	if !d.isRehashing() {
+		return
+	}
+	from, to := &d.tables[0], &d.tables[1]
+	emptyVisits := rehashEmptyVisits
+	for from.used > 0 && from.buckets[d.rehashIdx] == nil {
+		d.rehashIdx++
+		emptyVisits--
+		if emptyVisits == 0 {
+			return
+		}
+	}
+	if from.used > 0 {
+		e := from.buckets[d.rehashIdx]
+		for e != nil {
+			next := e.next
+			idx := d.hash(e.key) & to.mask()
+			e.next = to.buckets[idx]
+			to.buckets[idx] = e
+			from.used--
+			to.used++
+			e = next
+		}
+		from.buckets[d.rehashIdx] = nil
+		d.rehashIdx++
+	}
+	if from.used == 0 {
+		d.tables[0] = d.tables[1]
+		d.tables[1] = table[V]{}
+		d.rehashIdx = -1
+	}
+}
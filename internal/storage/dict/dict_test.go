@@ -0,0 +1,164 @@
+package dict
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func populated(n int) *Dict[int] {
+	d := New[int]()
+	for i := 0; i < n; i++ {
+		d.Set("key:"+strconv.Itoa(i), i)
+	}
+	return d
+}
+
+func TestDict_SetGetDelete(t *testing.T) {
+	d := New[string]()
+
+	_, ok := d.Get("missing")
+	assert.False(t, ok)
+
+	assert.True(t, d.Set("a", "1"))
+	assert.False(t, d.Set("a", "2"))
+	assert.True(t, d.Set("b", "3"))
+	assert.Equal(t, 2, d.Len())
+
+	v, ok := d.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "2", v)
+
+	v, ok = d.Delete("a")
+	assert.True(t, ok)
+	assert.Equal(t, "2", v)
+	_, ok = d.Delete("a")
+	assert.False(t, ok)
+	assert.Equal(t, 1, d.Len())
+}
+
+func TestDict_GrowsAndShrinksIncrementally(t *testing.T) {
+	d := populated(1000)
+	assert.Equal(t, 1000, d.Len())
+	for i := 0; i < 1000; i++ {
+		v, ok := d.Get("key:" + strconv.Itoa(i))
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+	assert.GreaterOrEqual(t, d.tables[0].size()+d.tables[1].size(), uint64(1024))
+
+	for i := 0; i < 990; i++ {
+		_, ok := d.Delete("key:" + strconv.Itoa(i))
+		assert.True(t, ok)
+	}
+	for d.isRehashing() {
+		d.rehashStep()
+	}
+	assert.Equal(t, 10, d.Len())
+	assert.Less(t, d.tables[0].size(), uint64(1024))
+	for i := 990; i < 1000; i++ {
+		_, ok := d.Get("key:" + strconv.Itoa(i))
+		assert.True(t, ok)
+	}
+}
+
+func TestDict_Range(t *testing.T) {
+	d := populated(100)
+	seen := make(map[string]int)
+	d.Range(func(key string, value int) bool {
+		seen[key] = value
+		return true
+	})
+	assert.Len(t, seen, 100)
+
+	visited := 0
+	d.Range(func(string, int) bool {
+		visited++
+		return visited < 5
+	})
+	assert.Equal(t, 5, visited)
+}
+
+func scanAll(d *Dict[int], between func()) map[string]int {
+	seen := make(map[string]int)
+	cursor := uint64(0)
+	for {
+		cursor = d.Scan(cursor, func(key string, _ int) {
+			seen[key]++
+		})
+		if cursor == 0 {
+			return seen
+		}
+		between()
+	}
+}
+
+func TestDict_ScanVisitsEveryKey(t *testing.T) {
+	d := populated(500)
+	seen := scanAll(d, func() {})
+	assert.Len(t, seen, 500)
+	for key, count := range seen {
+		assert.Equal(t, 1, count, key)
+	}
+
+	assert.Equal(t, uint64(0), New[int]().Scan(0, func(string, int) {}))
+}
+
+func TestDict_ScanGuaranteeWhileGrowing(t *testing.T) {
+	d := populated(100)
+	added := 0
+	seen := scanAll(d, func() {
+		for i := 0; i < 20 && added < 2000; i++ {
+			d.Set("new:"+strconv.Itoa(added), added)
+			added++
+		}
+	})
+	for i := 0; i < 100; i++ {
+		assert.Contains(t, seen, "key:"+strconv.Itoa(i))
+	}
+}
+
+func TestDict_ScanGuaranteeWhileShrinking(t *testing.T) {
+	d := populated(2000)
+	next := 0
+	seen := scanAll(d, func() {
+		for i := 0; i < 50 && next < 1900; i++ {
+			d.Delete("key:" + strconv.Itoa(next))
+			next++
+		}
+	})
+	for i := 1900; i < 2000; i++ {
+		assert.Contains(t, seen, "key:"+strconv.Itoa(i))
+	}
+}
+
+func TestDict_RandomKey(t *testing.T) {
+	_, _, ok := New[int]().RandomKey()
+	assert.False(t, ok)
+
+	d := populated(50)
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		key, value, ok := d.RandomKey()
+		assert.True(t, ok)
+		assert.Equal(t, "key:"+strconv.Itoa(value), key)
+		seen[key] = true
+	}
+	assert.Greater(t, len(seen), 25)
+}
+
+func TestDict_Sample(t *testing.T) {
+	d := populated(200)
+	seen := make(map[string]bool)
+	d.Sample(20, func(key string, _ int) {
+		assert.False(t, seen[key], "sampled twice")
+		seen[key] = true
+	})
+	assert.Len(t, seen, 20)
+
+	small := populated(3)
+	count := 0
+	small.Sample(20, func(string, int) { count++ })
+	assert.Equal(t, 3, count)
+}
@@ -0,0 +1,155 @@
+// Package geo implements the geohash scores Redis gives the members of a sorted set holding
+// locations, bit for bit, along with the distances and the search areas its GEO commands use.
+package geo
+
+import "math"
+
+const (
+	// steps is the number of bits of each coordinate in a score, 52 bits interleaved.
+	steps = 26
+
+	LongitudeMin = -180
+	LongitudeMax = 180
+	// LatitudeMin and LatitudeMax are the limits of the Web Mercator projection.
+	LatitudeMin = -85.05112878
+	LatitudeMax = 85.05112878
+
+	// EarthRadius is the earth radius in meters Redis computes distances with.
+	EarthRadius = 6372797.560856
+
+	mercatorMax = 20037726.37
+)
+
+const alphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// coordRange is the range of a coordinate a geohash divides.
+type coordRange struct {
+	min, max float64
+}
+
+var (
+	longitudeRange = coordRange{LongitudeMin, LongitudeMax}
+	latitudeRange  = coordRange{LatitudeMin, LatitudeMax}
+	// standardLatitudeRange is the range of latitudes of the standard geohash strings.
+	standardLatitudeRange = coordRange{-90, 90}
+)
+
+// hash is a geohash of step bits per coordinate, latitude bits being the even ones.
+type hash struct {
+	bits uint64
+	step uint
+}
+
+// isZero reports whether h is an excluded search area.
+func (h hash) isZero() bool {
+	return h.bits == 0 && h.step == 0
+}
+
+// ValidCoordinates reports whether a location can be encoded.
+func ValidCoordinates(longitude, latitude float64) bool {
+	return longitude >= LongitudeMin && longitude <= LongitudeMax &&
+		latitude >= LatitudeMin && latitude <= LatitudeMax
+}
+
+// Encode returns the score of a location, a 52 bits geohash. The location is expected to be valid.
+func Encode(longitude, latitude float64) float64 {
+	h, _ := encode(longitudeRange, latitudeRange, longitude, latitude, steps)
+	return float64(h.bits)
+}
+
+// Decode returns the location at the center of the area the score of a member designates.
+func Decode(score float64) (float64, float64) {
+	minLon, maxLon, minLat, maxLat := decode(longitudeRange, latitudeRange, hash{bits: uint64(score), step: steps})
+	longitude := min(max((minLon+maxLon)/2, LongitudeMin), LongitudeMax)
+	latitude := min(max((minLat+maxLat)/2, LatitudeMin), LatitudeMax)
+	return longitude, latitude
+}
+
+// String returns the standard 11 characters geohash of the location a score designates, which
+// uses latitudes from -90 to 90 rather than the limits of the scores. As a score has 52 bits
+// only, the last character always stands for zero bits.
+func String(score float64) string {
+	longitude, latitude := Decode(score)
+	h, _ := encode(longitudeRange, standardLatitudeRange, longitude, latitude, steps)
+	buf := make([]byte, 11)
+	for i := range buf {
+		idx := uint64(0)
+		if i < 10 {
+			idx = (h.bits >> (52 - (i+1)*5)) & 0x1f
+		}
+		buf[i] = alphabet[idx]
+	}
+	return string(buf)
+}
+
+func encode(lonRange, latRange coordRange, longitude, latitude float64, step uint) (hash, bool) {
+	if !ValidCoordinates(longitude, latitude) ||
+		latitude < latRange.min || latitude > latRange.max ||
+		longitude < lonRange.min || longitude > lonRange.max {
+		return hash{}, false
+	}
+	latOffset := (latitude - latRange.min) / (latRange.max - latRange.min)
+	lonOffset := (longitude - lonRange.min) / (lonRange.max - lonRange.min)
+	latOffset *= float64(uint64(1) << step)
+	lonOffset *= float64(uint64(1) << step)
+	return hash{bits: interleave(uint32(latOffset), uint32(lonOffset)), step: step}, true
+}
+
+// decode returns the bounds of the area h designates.
+func decode(lonRange, latRange coordRange, h hash) (minLon, maxLon, minLat, maxLat float64) {
+	lat, lon := deinterleave(h.bits)
+	cells := float64(uint64(1) << h.step)
+	latScale := latRange.max - latRange.min
+	lonScale := lonRange.max - lonRange.min
+	minLat = latRange.min + (float64(lat)/cells)*latScale
+	maxLat = latRange.min + ((float64(lat)+1)/cells)*latScale
+	minLon = lonRange.min + (float64(lon)/cells)*lonScale
+	maxLon = lonRange.min + ((float64(lon)+1)/cells)*lonScale
+	return minLon, maxLon, minLat, maxLat
+}
+
+// interleave spreads the bits of x over the even bits of the result and those of y over the odd ones.
+func interleave(x, y uint32) uint64 {
+	var bits uint64
+	for i := 0; i < 32; i++ {
+		bits |= uint64(x>>i&1) << (2 * i)
+		bits |= uint64(y>>i&1) << (2*i + 1)
+	}
+	return bits
+}
+
+// deinterleave is the inverse of interleave.
+func deinterleave(bits uint64) (uint32, uint32) {
+	var x, y uint32
+	for i := 0; i < 32; i++ {
+		x |= uint32(bits>>(2*i)&1) << i
+		y |= uint32(bits>>(2*i+1)&1) << i
+	}
+	return x, y
+}
+
+func degToRad(deg float64) float64 {
+	return deg * (math.Pi / 180)
+}
+
+func radToDeg(rad float64) float64 {
+	return rad / (math.Pi / 180)
+}
+
+// latitudeDistance returns the distance in meters between two latitudes along a meridian.
+func latitudeDistance(lat1, lat2 float64) float64 {
+	return EarthRadius * math.Abs(degToRad(lat2)-degToRad(lat1))
+}
+
+// Distance returns the distance in meters between two locations by the haversine formula.
+func Distance(lon1, lat1, lon2, lat2 float64) float64 {
+	v := math.Sin((degToRad(lon2) - degToRad(lon1)) / 2)
+	// Along a meridian the distance is cheaper to compute.
+	if v == 0 {
+		return latitudeDistance(lat1, lat2)
+	}
+	lat1r, lat2r := degToRad(lat1), degToRad(lat2)
+	u := math.Sin((lat2r - lat1r) / 2)
+	a := u*u + math.Cos(lat1r)*math.Cos(lat2r)*v*v
+	return 2 * EarthRadius * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,49 @@
+package geo
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Palermo and Catania are the locations of the examples of the Redis documentation.
+const (
+	palermoScore = 3479099956230698
+	cataniaScore = 3479447370796909
+)
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, float64(palermoScore), Encode(13.361389, 38.115556))
+	assert.Equal(t, float64(cataniaScore), Encode(15.087269, 37.502669))
+}
+
+func TestDecode(t *testing.T) {
+	longitude, latitude := Decode(palermoScore)
+	assert.Equal(t, "13.36138933897018433", strconv.FormatFloat(longitude, 'f', 17, 64))
+	assert.Equal(t, "38.11555639549629859", strconv.FormatFloat(latitude, 'f', 17, 64))
+
+	longitude, latitude = Decode(Encode(LongitudeMax, LatitudeMin))
+	assert.LessOrEqual(t, longitude, float64(LongitudeMax))
+	assert.GreaterOrEqual(t, latitude, LatitudeMin)
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "sqc8b49rny0", String(palermoScore))
+	assert.Equal(t, "sqdtr74hyu0", String(cataniaScore))
+}
+
+func TestValidCoordinates(t *testing.T) {
+	assert.True(t, ValidCoordinates(-180, 85.05112878))
+	assert.False(t, ValidCoordinates(180.1, 0))
+	assert.False(t, ValidCoordinates(0, -85.06))
+}
+
+func TestDistance(t *testing.T) {
+	palermoLon, palermoLat := Decode(palermoScore)
+	cataniaLon, cataniaLat := Decode(cataniaScore)
+	distance := Distance(palermoLon, palermoLat, cataniaLon, cataniaLat)
+	assert.Equal(t, "166274.1516", strconv.FormatFloat(distance, 'f', 4, 64))
+	assert.Equal(t, distance, Distance(cataniaLon, cataniaLat, palermoLon, palermoLat))
+	assert.InDelta(t, EarthRadius*0.0174533, Distance(10, 1, 10, 2), 0.1)
+}
@@ -0,0 +1,198 @@
+package geo
+
+import "math"
+
+// Shape is the area a search covers around its center, a circle of Radius meters or, when Box is
+// set, a box of Width by Height meters.
+type Shape struct {
+	Longitude, Latitude float64
+	Box                 bool
+	Radius              float64
+	Width, Height       float64
+}
+
+// Contains reports whether the location is inside the shape and returns its distance in meters
+// to the center.
+func (s Shape) Contains(longitude, latitude float64) (float64, bool) {
+	if !s.Box {
+		distance := Distance(s.Longitude, s.Latitude, longitude, latitude)
+		return distance, distance <= s.Radius
+	}
+	// The latitude condition is the cheaper to check.
+	if latitudeDistance(latitude, s.Latitude) > s.Height/2 {
+		return 0, false
+	}
+	if Distance(longitude, latitude, s.Longitude, latitude) > s.Width/2 {
+		return 0, false
+	}
+	return Distance(s.Longitude, s.Latitude, longitude, latitude), true
+}
+
+// ScoreRange is the range of scores from Min included to Max excluded of the members in a
+// geohash area.
+type ScoreRange struct {
+	Min, Max float64
+}
+
+// SearchRanges returns the score ranges of the areas to scan for the members inside the shape: the
+// area of the center and its neighbors, those too far being left out, in the order Redis scans them.
+func (s Shape) SearchRanges() []ScoreRange {
+	minLon, minLat, maxLon, maxLat := s.boundingBox()
+	radius := s.Radius
+	if s.Box {
+		// The distance from the center to a corner.
+		radius = math.Sqrt((s.Width/2)*(s.Width/2) + (s.Height/2)*(s.Height/2))
+	}
+	step := estimateSteps(radius, s.Latitude)
+	center, _ := encode(longitudeRange, latitudeRange, s.Longitude, s.Latitude, step)
+	n := neighbors(center)
+
+	// Near the edges of the center area, a neighbor may still be too close to cover the search
+	// area, so the step is decreased to larger areas.
+	_, _, _, northMax := decode(longitudeRange, latitudeRange, n[north])
+	_, _, southMin, _ := decode(longitudeRange, latitudeRange, n[south])
+	_, eastMax, _, _ := decode(longitudeRange, latitudeRange, n[east])
+	westMin, _, _, _ := decode(longitudeRange, latitudeRange, n[west])
+	if step > 1 && (northMax < maxLat || southMin > minLat || eastMax < maxLon || westMin > minLon) {
+		step--
+		center, _ = encode(longitudeRange, latitudeRange, s.Longitude, s.Latitude, step)
+		n = neighbors(center)
+	}
+
+	if step >= 2 {
+		areaMinLon, areaMaxLon, areaMinLat, areaMaxLat := decode(longitudeRange, latitudeRange, center)
+		if areaMinLat < minLat {
+			n[south], n[southWest], n[southEast] = hash{}, hash{}, hash{}
+		}
+		if areaMaxLat > maxLat {
+			n[north], n[northEast], n[northWest] = hash{}, hash{}, hash{}
+		}
+		if areaMinLon < minLon {
+			n[west], n[southWest], n[northWest] = hash{}, hash{}, hash{}
+		}
+		if areaMaxLon > maxLon {
+			n[east], n[southEast], n[northEast] = hash{}, hash{}, hash{}
+		}
+	}
+
+	areas := append([]hash{center}, n[:]...)
+	var ranges []ScoreRange
+	last := 0
+	for i, area := range areas {
+		if area.isZero() {
+			continue
+		}
+		// With a huge radius neighbors can be the same area. Like Redis, this only looks at the
+		// area scanned last, and not at all when it is the center one.
+		if last != 0 && area == areas[last] {
+			continue
+		}
+		ranges = append(ranges, ScoreRange{Min: align(area), Max: align(hash{bits: area.bits + 1, step: area.step})})
+		last = i
+	}
+	return ranges
+}
+
+// boundingBox returns the longitudes and latitudes bounding the shape.
+func (s Shape) boundingBox() (minLon, minLat, maxLon, maxLat float64) {
+	height, width := s.Radius, s.Radius
+	if s.Box {
+		height, width = s.Height/2, s.Width/2
+	}
+	latDelta := radToDeg(height / EarthRadius)
+	lonDeltaTop := radToDeg(width / EarthRadius / math.Cos(degToRad(s.Latitude+latDelta)))
+	lonDeltaBottom := radToDeg(width / EarthRadius / math.Cos(degToRad(s.Latitude-latDelta)))
+	// The widest latitude is the one nearest the equator.
+	lonDelta := lonDeltaTop
+	if s.Latitude < 0 {
+		lonDelta = lonDeltaBottom
+	}
+	return s.Longitude - lonDelta, s.Latitude - latDelta, s.Longitude + lonDelta, s.Latitude + latDelta
+}
+
+// estimateSteps returns the step of the areas whose size covers radius meters at latitude.
+func estimateSteps(radius, latitude float64) uint {
+	if radius == 0 {
+		return steps
+	}
+	step := 1
+	for radius < mercatorMax {
+		radius *= 2
+		step++
+	}
+	// Make sure the radius is covered in most cases.
+	step -= 2
+	// Areas are narrower towards the poles.
+	if latitude > 66 || latitude < -66 {
+		step--
+		if latitude > 80 || latitude < -80 {
+			step--
+		}
+	}
+	return uint(min(max(step, 1), steps))
+}
+
+// align returns the score of the first member in the area h designates.
+func align(h hash) float64 {
+	return float64(h.bits << (52 - h.step*2))
+}
+
+const (
+	north = iota
+	south
+	east
+	west
+	northEast
+	northWest
+	southEast
+	southWest
+)
+
+// neighbors returns the 8 areas around h, in the order of the constants above.
+func neighbors(h hash) [8]hash {
+	moves := [8][2]int{
+		north: {0, 1}, south: {0, -1}, east: {1, 0}, west: {-1, 0},
+		northEast: {1, 1}, northWest: {-1, 1}, southEast: {1, -1}, southWest: {-1, -1},
+	}
+	var n [8]hash
+	for i, move := range moves {
+		n[i] = moveX(moveY(h, move[1]), move[0])
+	}
+	return n
+}
+
+// moveX returns the area d areas east of h, wrapping around the longitudes.
+func moveX(h hash, d int) hash {
+	if d == 0 {
+		return h
+	}
+	x := h.bits & 0xaaaaaaaaaaaaaaaa
+	y := h.bits & 0x5555555555555555
+	zz := uint64(0x5555555555555555) >> (64 - h.step*2)
+	if d > 0 {
+		x += zz + 1
+	} else {
+		x |= zz
+		x -= zz + 1
+	}
+	x &= 0xaaaaaaaaaaaaaaaa >> (64 - h.step*2)
+	return hash{bits: x | y, step: h.step}
+}
+
+// moveY returns the area d areas north of h.
+func moveY(h hash, d int) hash {
+	if d == 0 {
+		return h
+	}
+	x := h.bits & 0xaaaaaaaaaaaaaaaa
+	y := h.bits & 0x5555555555555555
+	zz := uint64(0xaaaaaaaaaaaaaaaa) >> (64 - h.step*2)
+	if d > 0 {
+		y += zz + 1
+	} else {
+		y |= zz
+		y -= zz + 1
+	}
+	y &= 0x5555555555555555 >> (64 - h.step*2)
+	return hash{bits: x | y, step: h.step}
+}
@@ -0,0 +1,84 @@
+package geo
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// inRanges reports whether score lies in one of ranges.
+func inRanges(ranges []ScoreRange, score float64) bool {
+	for _, r := range ranges {
+		if score >= r.Min && score < r.Max {
+			return true
+		}
+	}
+	return false
+}
+
+func TestShape_Contains(t *testing.T) {
+	cataniaLon, cataniaLat := Decode(cataniaScore)
+	circle := Shape{Longitude: 15, Latitude: 37, Radius: 100000}
+	distance, ok := circle.Contains(cataniaLon, cataniaLat)
+	assert.True(t, ok)
+	assert.Equal(t, "56.4413", strconv.FormatFloat(distance/1000, 'f', 4, 64))
+	_, ok = circle.Contains(Decode(palermoScore))
+	assert.False(t, ok)
+
+	box := Shape{Longitude: 15, Latitude: 37, Box: true, Width: 400000, Height: 400000}
+	_, ok = box.Contains(Decode(palermoScore))
+	assert.True(t, ok)
+	_, ok = box.Contains(12.758489, 38.788135)
+	assert.True(t, ok)
+	_, ok = box.Contains(15, 38.9)
+	assert.False(t, ok)
+	_, ok = box.Contains(12.6, 37)
+	assert.False(t, ok)
+}
+
+func TestShape_SearchRanges(t *testing.T) {
+	shape := Shape{Longitude: 15, Latitude: 37, Radius: 200000}
+	ranges := shape.SearchRanges()
+	assert.LessOrEqual(t, len(ranges), 9)
+	assert.True(t, inRanges(ranges, palermoScore))
+	assert.True(t, inRanges(ranges, cataniaScore))
+	assert.False(t, inRanges(ranges, Encode(-74, 40.7)))
+
+	for _, r := range ranges {
+		assert.Less(t, r.Min, r.Max)
+	}
+}
+
+func TestShape_SearchRangesCoverShape(t *testing.T) {
+	for _, shape := range []Shape{
+		{Longitude: 179.99, Latitude: 0, Radius: 50000},
+		{Longitude: 0, Latitude: 84, Radius: 300000},
+		{Longitude: -73.99, Latitude: -40.7, Box: true, Width: 20000, Height: 5000},
+		{Longitude: 2.35, Latitude: 48.85, Radius: 0},
+	} {
+		ranges := shape.SearchRanges()
+		// Locations along the edges of the shape must be in the areas scanned.
+		minLon, minLat, maxLon, maxLat := shape.boundingBox()
+		for _, location := range [][2]float64{
+			{shape.Longitude, shape.Latitude},
+			{shape.Longitude, minLat}, {shape.Longitude, maxLat},
+			{minLon, shape.Latitude}, {maxLon, shape.Latitude},
+		} {
+			if !ValidCoordinates(location[0], location[1]) {
+				continue
+			}
+			if _, ok := shape.Contains(location[0], location[1]); !ok {
+				continue
+			}
+			assert.True(t, inRanges(ranges, Encode(location[0], location[1])), "%v %v", shape, location)
+		}
+	}
+}
+
+func TestEstimateSteps(t *testing.T) {
+	assert.Equal(t, uint(steps), estimateSteps(0, 0))
+	assert.Equal(t, uint(1), estimateSteps(mercatorMax, 0))
+	assert.Greater(t, estimateSteps(1000, 0), estimateSteps(100000, 0))
+	assert.Equal(t, estimateSteps(1000, 0)-2, estimateSteps(1000, 85))
+}
@@ -0,0 +1,87 @@
+// Package glob implements the glob-style patterns used by KEYS and the MATCH option of the SCAN family.
+package glob
+
+// Match reports whether str matches pattern, following Redis's stringmatchlen.
+// '*' matches any sequence of bytes, '?' exactly one byte, "[abc]" one of the listed bytes,
+// "[^abc]" any byte but them, "[a-z]" a range, and a backslash makes the next byte literal.
+// Unlike path.Match, '/' is an ordinary byte and a malformed pattern never fails, it just matches less.
+func Match(pattern, str string) bool {
+	p, s := 0, 0
+	for p < len(pattern) && s < len(str) {
+		switch pattern[p] {
+		case '*':
+			for p+1 < len(pattern) && pattern[p+1] == '*' {
+				p++
+			}
+			if p+1 == len(pattern) {
+				return true
+			}
+			for ; s < len(str); s++ {
+				if Match(pattern[p+1:], str[s:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			s++
+		case '[':
+			p++
+			not := p < len(pattern) && pattern[p] == '^'
+			if not {
+				p++
+			}
+			matched := false
+			for {
+				if p >= len(pattern) {
+					// An unterminated class ends with the pattern.
+					p--
+					break
+				}
+				if pattern[p] == '\\' && p+1 < len(pattern) {
+					p++
+					if pattern[p] == str[s] {
+						matched = true
+					}
+				} else if pattern[p] == ']' {
+					break
+				} else if p+2 < len(pattern) && pattern[p+1] == '-' {
+					start, end := pattern[p], pattern[p+2]
+					if start > end {
+						start, end = end, start
+					}
+					p += 2
+					if str[s] >= start && str[s] <= end {
+						matched = true
+					}
+				} else if pattern[p] == str[s] {
+					matched = true
+				}
+				p++
+			}
+			if not {
+				matched = !matched
+			}
+			if !matched {
+				return false
+			}
+			s++
+		case '\\':
+			if p+1 < len(pattern) {
+				p++
+			}
+			fallthrough
+		default:
+			if pattern[p] != str[s] {
+				return false
+			}
+			s++
+		}
+		p++
+	}
+	if s == len(str) {
+		for p < len(pattern) && pattern[p] == '*' {
+			p++
+		}
+	}
+	return p == len(pattern) && s == len(str)
+}
@@ -0,0 +1,45 @@
+package glob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		str     string
+		matches bool
+	}{
+		{"*", "", true},
+		{"*", "anything", true},
+		{"", "", true},
+		{"", "a", false},
+		{"user:*", "user:1000", true},
+		{"user:*", "users", false},
+		{"*:name", "user:1:name", true},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "axxbyy", false},
+		{"h?llo", "hello", true},
+		{"h?llo", "hllo", false},
+		{"h[ae]llo", "hallo", true},
+		{"h[ae]llo", "hillo", false},
+		{"h[^e]llo", "hallo", true},
+		{"h[^e]llo", "hello", false},
+		{"h[a-b]llo", "hbllo", true},
+		{"h[b-a]llo", "hallo", true},
+		{"h[a-b]llo", "hcllo", false},
+		{`h\*llo`, "h*llo", true},
+		{`h\*llo`, "hello", false},
+		{`[\]]`, "]", true},
+		{"a/*", "a/b/c", true},
+		{"key*", "key", true},
+		{"key**", "key", true},
+		{"[abc", "a", true},
+		{"ab[", "ab", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.matches, Match(tt.pattern, tt.str), "%q ~ %q", tt.pattern, tt.str)
+	}
+}
@@ -1,14 +1,53 @@
 package hashmaps
 
-import "context"
+import (
+	"avacado/internal/storage/keyspace"
+	"context"
+	"time"
+)
+
+// Replies given for each field by the commands reading or changing the expiry of fields.
+const (
+	// FieldMissing is replied for a field that does not exist
+	FieldMissing int64 = -2
+	// FieldNoExpiry is replied for a field without expiry by HTTL-like commands and HPERSIST
+	FieldNoExpiry int64 = -1
+	// FieldConditionNotMet is replied by HEXPIRE-like commands when the condition prevents the change
+	FieldConditionNotMet int64 = 0
+	// FieldUpdated is replied when the expiry of the field was set or removed
+	FieldUpdated int64 = 1
+	// FieldDeleted is replied by HEXPIRE-like commands when an expiry in the past deleted the field
+	FieldDeleted int64 = 2
+)
+
+// FieldExpiry is how HGETEX and HSETEX change the expiry of the fields they touch. At makes them
+// expire at that time, Keep leaves their expiry alone, and the zero value removes it.
+type FieldExpiry struct {
+	At   *time.Time
+	Keep bool
+}
+
+// SetExOptions holds the options of HSETEX.
+type SetExOptions struct {
+	// FNX sets the fields only if none of them exist, FXX only if all of them do.
+	FNX, FXX bool
+	Expiry   FieldExpiry
+}
 
 //go:generate sh -c "rm -f mock/hashmaps.go && mockgen -source=hashmaps.go -destination=mock/hashmaps.go -package=mockhashmaps"
 type HashMaps interface {
-	HSet(ctx context.Context, name string, keyValues []string) int
+	HSet(ctx context.Context, name string, keyValues []string) (int, error)
 	HGet(ctx context.Context, name string, field string) ([]byte, error)
 	HGetAll(ctx context.Context, name string) (map[string]string, error)
 	HDel(ctx context.Context, key string, fields []string) (int, error)
-	HExists(ctx context.Context, key string, field string) int
+	HExists(ctx context.Context, key string, field string) (int, error)
 	HIncrBy(ctx context.Context, key string, field string, increment int64) (int64, error)
-	HMGet(ctx context.Context, key string, fields []string) []any
+	HMGet(ctx context.Context, key string, fields []string) ([]any, error)
+	HExpire(ctx context.Context, key string, at time.Time, condition keyspace.ExpireCondition, fields []string) ([]int64, error)
+	HPersist(ctx context.Context, key string, fields []string) ([]int64, error)
+	HTTL(ctx context.Context, key string, fields []string) ([]int64, error)
+	HExpireTime(ctx context.Context, key string, fields []string) ([]int64, error)
+	HGetDel(ctx context.Context, key string, fields []string) ([]any, error)
+	HGetEx(ctx context.Context, key string, fields []string, expiry FieldExpiry) ([]any, error)
+	HSetEx(ctx context.Context, key string, keyValues []string, options SetExOptions) (bool, error)
 }
@@ -1,15 +1,27 @@
 package memory
 
 import (
+	"avacado/internal/storage/dict"
 	"avacado/internal/storage/listpack"
 	"fmt"
+	"math"
 	"strconv"
+	"time"
 )
 
 const defaultMaxListPackSize = 1024 * 8
 const maxEntryCount = 128
 const maxEntrySize = 64
 
+const (
+	// hashMapOverhead approximates the bytes used by a HashMap besides its fields.
+	hashMapOverhead = 64
+	// hashFieldOverhead approximates the bytes used by each dict entry of the hash encoding besides its field and value.
+	hashFieldOverhead = 56
+	// fieldExpiryOverhead approximates the bytes used by each field expiry, the dict entry included.
+	fieldExpiryOverhead = 48
+)
+
 type encodingType = int
 
 const (
@@ -18,11 +30,22 @@ const (
 )
 
 // HashMap stores key-value pairs, internally using either listpack or hash encoding.
+// The hash encoding is a dict, so growing a large hash is spread over many operations.
+// Fields can carry an expiry whatever the encoding. Expired fields stay in the hash until
+// ExpireFields removes them, which the keyspace does before handing the hash to a command.
 // All methods are called exclusively by the executor goroutine — no locking needed.
 type HashMap struct {
 	lp       *listpack.ListPack
-	hash     map[string]string
+	hash     *dict.Dict[string]
 	encoding encodingType
+	// hashBytes is the length of every field and value held by hash.
+	hashBytes int64
+	// expires holds the Unix time in milliseconds at which each field with an expiry expires.
+	// It is nil until a field first gets one.
+	expires *dict.Dict[int64]
+	// nextExpiry is no later than the earliest time in expires, so ExpireFields can tell that
+	// no field has expired without looking at them.
+	nextExpiry int64
 }
 
 func NewHashMap() *HashMap {
@@ -32,7 +55,78 @@ func NewHashMap() *HashMap {
 	}
 }
 
+// Encoding returns "listpack" or "hashtable", the names Redis gives to the two encodings.
+// Like Redis, a listpack is reported as "listpackex" once a field was given an expiry.
+func (h *HashMap) Encoding() string {
+	if h.encoding == hashEncoding {
+		return "hashtable"
+	}
+	if h.expires != nil {
+		return "listpackex"
+	}
+	return "listpack"
+}
+
+// Clone returns a deep copy of the hash map in the same encoding.
+func (h *HashMap) Clone() any {
+	clone := &HashMap{encoding: h.encoding, hashBytes: h.hashBytes, nextExpiry: h.nextExpiry}
+	if h.encoding == hashEncoding {
+		clone.hash = dict.New[string]()
+		h.hash.Range(func(k, v string) bool {
+			clone.hash.Set(k, v)
+			return true
+		})
+	} else {
+		clone.lp = h.lp.Clone()
+	}
+	if h.expires != nil {
+		clone.expires = dict.New[int64]()
+		h.expires.Range(func(field string, at int64) bool {
+			clone.expires.Set(field, at)
+			return true
+		})
+	}
+	return clone
+}
+
+// MemoryUsage returns the approximate bytes allocated for the hash map.
+func (h *HashMap) MemoryUsage() int64 {
+	size := int64(hashMapOverhead)
+	if h.expires != nil {
+		size += int64(h.expires.Len()) * fieldExpiryOverhead
+	}
+	if h.encoding == hashEncoding {
+		return size + h.hashBytes + int64(h.hash.Len())*hashFieldOverhead
+	}
+	return size + int64(h.lp.MemoryUsage())
+}
+
+// hashSet sets key in the hash encoding, keeping hashBytes up to date.
+func (h *HashMap) hashSet(key, value string) {
+	if old, ok := h.hash.Get(key); ok {
+		h.hashBytes -= int64(len(old))
+	} else {
+		h.hashBytes += int64(len(key))
+	}
+	h.hashBytes += int64(len(value))
+	h.hash.Set(key, value)
+}
+
+// hashDelete deletes key from the hash encoding, keeping hashBytes up to date.
+func (h *HashMap) hashDelete(key string) {
+	if old, ok := h.hash.Delete(key); ok {
+		h.hashBytes -= int64(len(key) + len(old))
+	}
+}
+
+// Set sets key to value, removing the expiry key had like HSET does.
 func (h *HashMap) Set(key, value string) int {
+	h.Persist(key)
+	return h.set(key, value)
+}
+
+// set sets key to value, keeping the expiry key may have.
+func (h *HashMap) set(key, value string) int {
 	existingSize := h.size()
 
 	if existingSize >= maxEntryCount && h.encoding != hashEncoding {
@@ -41,7 +135,7 @@ func (h *HashMap) Set(key, value string) int {
 
 	switch h.encoding {
 	case hashEncoding:
-		h.hash[key] = value
+		h.hashSet(key, value)
 	case listpackEncoding:
 		h.setInListPack(key, value)
 	}
@@ -51,7 +145,7 @@ func (h *HashMap) Set(key, value string) int {
 
 func (h *HashMap) Get(key string) ([]byte, bool) {
 	if h.encoding == hashEncoding {
-		v, ok := h.hash[key]
+		v, ok := h.hash.Get(key)
 		if !ok {
 			return nil, false
 		}
@@ -94,7 +188,7 @@ func (h *HashMap) Delete(fields []string) int {
 
 	if h.encoding == hashEncoding {
 		for _, key := range fields {
-			delete(h.hash, key)
+			h.hashDelete(key)
 		}
 	} else {
 		for _, key := range fields {
@@ -105,14 +199,20 @@ func (h *HashMap) Delete(fields []string) int {
 			h.lp.DeleteFromIndex(keyIndex, 2)
 		}
 	}
+	if h.expires != nil {
+		for _, key := range fields {
+			h.expires.Delete(key)
+		}
+	}
 	return currentSize - h.size()
 }
 
+// IncrBy adds increment to the integer value of field, keeping the expiry field may have.
 func (h *HashMap) IncrBy(field string, increment int64) (int64, error) {
 	currentValue := int64(0)
 
 	if h.encoding == hashEncoding {
-		if val, exists := h.hash[field]; exists {
+		if val, exists := h.hash.Get(field); exists {
 			v, err := strconv.ParseInt(val, 10, 64)
 			if err != nil {
 				return 0, fmt.Errorf("hash value is not an integer or out of range")
@@ -140,7 +240,7 @@ func (h *HashMap) IncrBy(field string, increment int64) (int64, error) {
 	newValueStr := strconv.FormatInt(newValue, 10)
 
 	if h.encoding == hashEncoding {
-		h.hash[field] = newValueStr
+		h.hashSet(field, newValueStr)
 	} else {
 		h.setInListPack(field, newValueStr)
 	}
@@ -148,6 +248,61 @@ func (h *HashMap) IncrBy(field string, increment int64) (int64, error) {
 	return newValue, nil
 }
 
+// Expiry returns the Unix time in milliseconds at which field expires, and false if it has no expiry.
+func (h *HashMap) Expiry(field string) (int64, bool) {
+	if h.expires == nil {
+		return 0, false
+	}
+	return h.expires.Get(field)
+}
+
+// SetExpiry makes field expire at the given Unix time in milliseconds. The field must exist.
+func (h *HashMap) SetExpiry(field string, at int64) {
+	if h.expires == nil {
+		h.expires = dict.New[int64]()
+	}
+	if h.expires.Len() == 0 || at < h.nextExpiry {
+		h.nextExpiry = at
+	}
+	h.expires.Set(field, at)
+}
+
+// Persist removes the expiry of field and reports whether it had one.
+func (h *HashMap) Persist(field string) bool {
+	if h.expires == nil {
+		return false
+	}
+	_, ok := h.expires.Delete(field)
+	return ok
+}
+
+// HasVolatileFields reports whether any field carries an expiry.
+func (h *HashMap) HasVolatileFields() bool {
+	return h.expires != nil && h.expires.Len() > 0
+}
+
+// ExpireFields removes up to limit fields expired at now, every one of them if limit is negative,
+// and returns how many were removed and whether the hash is left empty.
+func (h *HashMap) ExpireFields(now time.Time, limit int) (int, bool) {
+	millis := now.UnixMilli()
+	if !h.HasVolatileFields() || h.nextExpiry >= millis {
+		return 0, h.size() == 0
+	}
+	var expired []string
+	next := int64(math.MaxInt64)
+	h.expires.Range(func(field string, at int64) bool {
+		if at < millis && (limit < 0 || len(expired) < limit) {
+			expired = append(expired, field)
+		} else {
+			next = min(next, at)
+		}
+		return true
+	})
+	h.Delete(expired)
+	h.nextExpiry = next
+	return len(expired), h.size() == 0
+}
+
 func (h *HashMap) setInListPack(key, value string) {
 	keyIndex, keyExists := h.lp.IndexOf(key, true)
 
@@ -169,7 +324,7 @@ func (h *HashMap) setInListPack(key, value string) {
 
 	if needsMigration {
 		_ = h.migrateToHashMap()
-		h.hash[key] = value
+		h.hashSet(key, value)
 	}
 }
 
@@ -197,7 +352,7 @@ func convertToBytes(value interface{}) []byte {
 
 func (h *HashMap) size() int {
 	if h.encoding == hashEncoding {
-		return len(h.hash)
+		return h.hash.Len()
 	}
 
 	return h.lp.Length() / 2
@@ -212,20 +367,21 @@ func (h *HashMap) migrateToHashMap() error {
 	}
 
 	h.encoding = hashEncoding
-	h.hash = make(map[string]string)
+	h.hash = dict.New[string]()
 	for i := 0; i < length; i += 2 {
-		h.hash[string(entries[i])] = string(entries[i+1])
+		h.hashSet(string(entries[i]), string(entries[i+1]))
 	}
 
 	h.lp = nil
 	return nil
 }
 
-func copyHashMap(source map[string]string) map[string]string {
-	destination := make(map[string]string)
-	for k, v := range source {
+func copyHashMap(source *dict.Dict[string]) map[string]string {
+	destination := make(map[string]string, source.Len())
+	source.Range(func(k, v string) bool {
 		destination[k] = v
-	}
+		return true
+	})
 	return destination
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -349,3 +350,123 @@ func Test_Delete(t *testing.T) {
 		assert.Equal(t, "val1", string(v))
 	})
 }
+
+func Test_Clone(t *testing.T) {
+	t.Run("clone of listpack encoding is independent", func(t *testing.T) {
+		hs := NewHashMap()
+		hs.Set("field", "value")
+		clone := hs.Clone().(*HashMap)
+
+		clone.Set("field", "changed")
+		value, _ := hs.Get("field")
+		assert.Equal(t, "value", string(value))
+		assert.NotNil(t, clone.lp)
+	})
+
+	t.Run("clone of hash encoding is independent", func(t *testing.T) {
+		hs := NewHashMap()
+		for i := 0; i <= maxEntryCount; i++ {
+			hs.Set(fmt.Sprintf("%d", i), "hi")
+		}
+		clone := hs.Clone().(*HashMap)
+
+		clone.Delete([]string{"0"})
+		assert.Equal(t, maxEntryCount+1, hs.Size())
+		assert.Equal(t, maxEntryCount, clone.Size())
+		assert.NotNil(t, clone.hash)
+	})
+}
+
+func Test_FieldExpiry(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Second).UnixMilli(), now.Add(time.Hour).UnixMilli()
+
+	t.Run("expired fields are removed in listpack encoding", func(t *testing.T) {
+		hs := NewHashMap()
+		hs.Set("expired", "v1")
+		hs.Set("live", "v2")
+		hs.Set("persistent", "v3")
+		assert.Equal(t, "listpack", hs.Encoding())
+		hs.SetExpiry("expired", past)
+		hs.SetExpiry("live", future)
+		assert.Equal(t, "listpackex", hs.Encoding())
+
+		expired, empty := hs.ExpireFields(now, -1)
+		assert.Equal(t, 1, expired)
+		assert.False(t, empty)
+		assert.Equal(t, map[string]string{"live": "v2", "persistent": "v3"}, hs.GetAll())
+		at, ok := hs.Expiry("live")
+		assert.True(t, ok)
+		assert.Equal(t, future, at)
+		_, ok = hs.Expiry("persistent")
+		assert.False(t, ok)
+	})
+
+	t.Run("expired fields are removed in hash encoding", func(t *testing.T) {
+		hs := NewHashMap()
+		for i := 0; i <= maxEntryCount; i++ {
+			hs.Set(fmt.Sprintf("%d", i), "hi")
+			hs.SetExpiry(fmt.Sprintf("%d", i), past)
+		}
+		assert.Equal(t, "hashtable", hs.Encoding())
+
+		expired, empty := hs.ExpireFields(now, 10)
+		assert.Equal(t, 10, expired)
+		assert.False(t, empty)
+		assert.Equal(t, maxEntryCount-9, hs.Size())
+
+		expired, empty = hs.ExpireFields(now, -1)
+		assert.Equal(t, maxEntryCount-9, expired)
+		assert.True(t, empty)
+		assert.False(t, hs.HasVolatileFields())
+	})
+
+	t.Run("set removes the expiry while increment keeps it", func(t *testing.T) {
+		hs := NewHashMap()
+		hs.Set("counter", "1")
+		hs.Set("field", "value")
+		hs.SetExpiry("counter", future)
+		hs.SetExpiry("field", future)
+
+		_, _ = hs.IncrBy("counter", 1)
+		hs.Set("field", "changed")
+
+		_, ok := hs.Expiry("counter")
+		assert.True(t, ok)
+		_, ok = hs.Expiry("field")
+		assert.False(t, ok)
+	})
+
+	t.Run("delete and persist remove the expiry", func(t *testing.T) {
+		hs := NewHashMap()
+		hs.Set("f1", "v1")
+		hs.Set("f2", "v2")
+		hs.SetExpiry("f1", future)
+		hs.SetExpiry("f2", future)
+
+		hs.Delete([]string{"f1"})
+		assert.True(t, hs.Persist("f2"))
+		assert.False(t, hs.Persist("f2"))
+		assert.False(t, hs.HasVolatileFields())
+	})
+
+	t.Run("clone keeps the expiry of fields", func(t *testing.T) {
+		hs := NewHashMap()
+		hs.Set("field", "value")
+		hs.SetExpiry("field", future)
+		clone := hs.Clone().(*HashMap)
+
+		clone.Persist("field")
+		at, ok := hs.Expiry("field")
+		assert.True(t, ok)
+		assert.Equal(t, future, at)
+	})
+
+	t.Run("memory usage accounts for expiries", func(t *testing.T) {
+		hs := NewHashMap()
+		hs.Set("field", "value")
+		before := hs.MemoryUsage()
+		hs.SetExpiry("field", future)
+		assert.Equal(t, before+fieldExpiryOverhead, hs.MemoryUsage())
+	})
+}
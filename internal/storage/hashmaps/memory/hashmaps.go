@@ -1,40 +1,70 @@
 package memory
 
 import (
+	"avacado/internal/storage/hashmaps"
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
 	"context"
 	"fmt"
+	"time"
 )
 
-// HashMaps holds all named hash maps.
+// HashMaps holds all named hash maps in the shared keyspace, a hash left without fields is removed from it.
 // All methods are called exclusively by the executor goroutine — no locking needed.
 type HashMaps struct {
-	maps map[string]*HashMap
+	keyspace *memkeyspace.Keyspace
 }
 
-func NewHashMaps() *HashMaps {
+func NewHashMaps(ks *memkeyspace.Keyspace) *HashMaps {
 	return &HashMaps{
-		maps: make(map[string]*HashMap),
+		keyspace: ks,
 	}
 }
 
-// HSet sets given fields to the specified map
-func (h *HashMaps) HSet(_ context.Context, name string, keyValues []string) int {
-	hMap, found := h.maps[name]
-	if !found {
+// lookup returns the hash map stored at name, or nil if name does not exist.
+// ErrWrongType is returned if name holds a value of another type.
+func (h *HashMaps) lookup(name string) (*HashMap, error) {
+	entry, err := h.keyspace.LookupOfType(name, keyspace.TypeHash)
+	if entry == nil {
+		return nil, err
+	}
+	return entry.Value.(*HashMap), nil
+}
+
+// lookupOrCreate returns the hash map stored at name, creating an empty one if name does not exist.
+func (h *HashMaps) lookupOrCreate(name string) (*HashMap, error) {
+	hMap, err := h.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	if hMap == nil {
 		hMap = NewHashMap()
-		h.maps[name] = hMap
+		h.keyspace.Put(name, keyspace.TypeHash, hMap)
+	}
+	return hMap, nil
+}
+
+// HSet sets given fields to the specified map
+func (h *HashMaps) HSet(_ context.Context, name string, keyValues []string) (int, error) {
+	hMap, err := h.lookupOrCreate(name)
+	if err != nil {
+		return 0, err
 	}
 	addedCount := 0
 	for i := 0; i < len(keyValues); i += 2 {
 		addedCount += hMap.Set(keyValues[i], keyValues[i+1])
 	}
-	return addedCount
+	h.keyspace.Resized(name)
+	return addedCount, nil
 }
 
 // HGet return the specified field of the map
 func (h *HashMaps) HGet(_ context.Context, name, field string) ([]byte, error) {
-	hMap, found := h.maps[name]
-	if !found {
+	hMap, err := h.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	if hMap == nil {
 		return nil, fmt.Errorf("%s does not exists", name)
 	}
 	value, valueFound := hMap.Get(field)
@@ -45,52 +75,257 @@ func (h *HashMaps) HGet(_ context.Context, name, field string) ([]byte, error) {
 }
 
 func (h *HashMaps) HGetAll(_ context.Context, name string) (map[string]string, error) {
-	hMap, found := h.maps[name]
-	if !found {
+	hMap, err := h.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	if hMap == nil {
 		return make(map[string]string), nil
 	}
 	return hMap.GetAll(), nil
 }
 
-func (h *HashMaps) HExists(_ context.Context, key string, field string) int {
-	hMap, found := h.maps[key]
-	if !found {
-		return 0
+func (h *HashMaps) HExists(_ context.Context, key string, field string) (int, error) {
+	hMap, err := h.lookup(key)
+	if hMap == nil {
+		return 0, err
 	}
 	_, exists := hMap.Get(field)
 	if exists {
-		return 1
+		return 1, nil
 	}
-	return 0
+	return 0, nil
 }
 
 func (h *HashMaps) HDel(_ context.Context, key string, fields []string) (int, error) {
-	hMap, found := h.maps[key]
-	if !found {
-		return 0, nil
+	hMap, err := h.lookup(key)
+	if hMap == nil {
+		return 0, err
+	}
+	deleted := hMap.Delete(fields)
+	if hMap.Size() == 0 {
+		h.keyspace.Remove(key)
 	}
-	return hMap.Delete(fields), nil
+	h.keyspace.Resized(key)
+	return deleted, nil
 }
 
-func (h *HashMaps) HMGet(_ context.Context, key string, fields []string) []any {
+func (h *HashMaps) HMGet(_ context.Context, key string, fields []string) ([]any, error) {
+	hMap, err := h.lookup(key)
+	if err != nil {
+		return nil, err
+	}
 	result := make([]any, len(fields))
-	hMap, found := h.maps[key]
-	if !found {
-		return result
+	if hMap == nil {
+		return result, nil
 	}
 	for i, field := range fields {
 		if value, ok := hMap.Get(field); ok {
 			result[i] = value
 		}
 	}
-	return result
+	return result, nil
 }
 
 func (h *HashMaps) HIncrBy(_ context.Context, key string, field string, increment int64) (int64, error) {
-	hMap, found := h.maps[key]
-	if !found {
+	hMap, err := h.lookupOrCreate(key)
+	if err != nil {
+		return 0, err
+	}
+	value, err := hMap.IncrBy(field, increment)
+	if hMap.Size() == 0 {
+		// A failed increment on a fresh key must not leave an empty hash behind.
+		h.keyspace.Remove(key)
+	}
+	h.keyspace.Resized(key)
+	return value, err
+}
+
+// modified accounts for the hash map at key after it was modified in place, removing key if the
+// hash map was left without fields.
+func (h *HashMaps) modified(key string, hMap *HashMap) {
+	if hMap.Size() == 0 {
+		h.keyspace.Remove(key)
+		return
+	}
+	h.keyspace.Resized(key)
+}
+
+// fieldReplies returns the reply of an expiry command for each field, FieldMissing for the fields
+// of a missing hash and the result of reply for the others.
+func fieldReplies(hMap *HashMap, fields []string, reply func(field string) int64) []int64 {
+	result := make([]int64, len(fields))
+	for i, field := range fields {
+		result[i] = hashmaps.FieldMissing
+		if hMap == nil {
+			continue
+		}
+		if _, ok := hMap.Get(field); ok {
+			result[i] = reply(field)
+		}
+	}
+	return result
+}
+
+// HExpire makes each field expire at the given time if condition allows it. An expiry that is
+// not in the future deletes the field right away.
+func (h *HashMaps) HExpire(_ context.Context, key string, at time.Time, condition keyspace.ExpireCondition, fields []string) ([]int64, error) {
+	hMap, err := h.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	at = time.UnixMilli(at.UnixMilli())
+	past := !at.After(time.Now())
+	result := fieldReplies(hMap, fields, func(field string) int64 {
+		var current *time.Time
+		if millis, ok := hMap.Expiry(field); ok {
+			expiry := time.UnixMilli(millis)
+			current = &expiry
+		}
+		switch {
+		case !condition.Holds(current, at):
+			return hashmaps.FieldConditionNotMet
+		case past:
+			hMap.Delete([]string{field})
+			return hashmaps.FieldDeleted
+		default:
+			hMap.SetExpiry(field, at.UnixMilli())
+			return hashmaps.FieldUpdated
+		}
+	})
+	if hMap != nil {
+		h.modified(key, hMap)
+	}
+	return result, nil
+}
+
+// HPersist removes the expiry of each field.
+func (h *HashMaps) HPersist(_ context.Context, key string, fields []string) ([]int64, error) {
+	hMap, err := h.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	result := fieldReplies(hMap, fields, func(field string) int64 {
+		if hMap.Persist(field) {
+			return hashmaps.FieldUpdated
+		}
+		return hashmaps.FieldNoExpiry
+	})
+	if hMap != nil {
+		h.modified(key, hMap)
+	}
+	return result, nil
+}
+
+// HTTL returns the time to live of each field in milliseconds.
+func (h *HashMaps) HTTL(ctx context.Context, key string, fields []string) ([]int64, error) {
+	result, err := h.HExpireTime(ctx, key, fields)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UnixMilli()
+	for i, at := range result {
+		if at >= 0 {
+			result[i] = max(at-now, 0)
+		}
+	}
+	return result, nil
+}
+
+// HExpireTime returns the Unix time in milliseconds at which each field expires.
+func (h *HashMaps) HExpireTime(_ context.Context, key string, fields []string) ([]int64, error) {
+	hMap, err := h.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	return fieldReplies(hMap, fields, func(field string) int64 {
+		if at, ok := hMap.Expiry(field); ok {
+			return at
+		}
+		return hashmaps.FieldNoExpiry
+	}), nil
+}
+
+// HGetDel returns the value of each field, nil for the missing ones, and deletes the fields.
+func (h *HashMaps) HGetDel(_ context.Context, key string, fields []string) ([]any, error) {
+	hMap, err := h.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]any, len(fields))
+	if hMap == nil {
+		return result, nil
+	}
+	for i, field := range fields {
+		if value, ok := hMap.Get(field); ok {
+			result[i] = value
+			hMap.Delete([]string{field})
+		}
+	}
+	h.modified(key, hMap)
+	return result, nil
+}
+
+// HGetEx returns the value of each field, nil for the missing ones, and changes the expiry of the
+// existing ones as expiry says. An expiry that is not in the future deletes the fields.
+func (h *HashMaps) HGetEx(_ context.Context, key string, fields []string, expiry hashmaps.FieldExpiry) ([]any, error) {
+	hMap, err := h.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]any, len(fields))
+	if hMap == nil {
+		return result, nil
+	}
+	for i, field := range fields {
+		if value, ok := hMap.Get(field); ok {
+			result[i] = value
+			applyFieldExpiry(hMap, field, expiry)
+		}
+	}
+	h.modified(key, hMap)
+	return result, nil
+}
+
+// HSetEx sets the given fields and changes their expiry as options say, unless FNX or FXX prevent
+// it. It reports whether the fields were set.
+func (h *HashMaps) HSetEx(_ context.Context, key string, keyValues []string, options hashmaps.SetExOptions) (bool, error) {
+	hMap, err := h.lookup(key)
+	if err != nil {
+		return false, err
+	}
+	if options.FNX || options.FXX {
+		for i := 0; i < len(keyValues); i += 2 {
+			exists := false
+			if hMap != nil {
+				_, exists = hMap.Get(keyValues[i])
+			}
+			if (options.FNX && exists) || (options.FXX && !exists) {
+				return false, nil
+			}
+		}
+	}
+	if hMap == nil {
 		hMap = NewHashMap()
-		h.maps[key] = hMap
+		h.keyspace.Put(key, keyspace.TypeHash, hMap)
+	}
+	for i := 0; i < len(keyValues); i += 2 {
+		hMap.set(keyValues[i], keyValues[i+1])
+		applyFieldExpiry(hMap, keyValues[i], options.Expiry)
+	}
+	h.modified(key, hMap)
+	return true, nil
+}
+
+// applyFieldExpiry changes the expiry of the existing field as expiry says.
+func applyFieldExpiry(hMap *HashMap, field string, expiry hashmaps.FieldExpiry) {
+	switch {
+	case expiry.Keep:
+	case expiry.At == nil:
+		hMap.Persist(field)
+	case !expiry.At.After(time.Now()):
+		hMap.Delete([]string{field})
+	default:
+		hMap.SetExpiry(field, expiry.At.UnixMilli())
 	}
-	return hMap.IncrBy(field, increment)
 }
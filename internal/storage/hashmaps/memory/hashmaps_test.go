@@ -1,25 +1,38 @@
 package memory
 
 import (
+	"avacado/internal/storage/hashmaps"
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// mapAt returns the hash map stored at name, failing loudly if name is not a hash
+func mapAt(maps *HashMaps, name string) *HashMap {
+	hMap, err := maps.lookup(name)
+	if err != nil {
+		panic(err)
+	}
+	return hMap
+}
+
 func TestHashMaps_HSet(t *testing.T) {
-	maps := NewHashMaps()
-	maps.HSet(context.Background(), "map1", []string{"key1", "V1", "key2", "V2"})
+	maps := NewHashMaps(memkeyspace.NewKeyspace())
+	_, _ = maps.HSet(context.Background(), "map1", []string{"key1", "V1", "key2", "V2"})
 
-	assert.Equal(t, 1, len(maps.maps))
-	assert.Equal(t, 2, maps.maps["map1"].Size())
+	assert.Equal(t, 1, maps.keyspace.Len())
+	assert.Equal(t, 2, mapAt(maps, "map1").Size())
 }
 
 func TestHashMaps_HGet(t *testing.T) {
-	maps := NewHashMaps()
+	maps := NewHashMaps(memkeyspace.NewKeyspace())
 	ctx := context.Background()
-	maps.HSet(ctx, "map1", []string{"key1", "V1", "key2", "V2"})
+	_, _ = maps.HSet(ctx, "map1", []string{"key1", "V1", "key2", "V2"})
 
 	_, err := maps.HGet(ctx, "non-existing-map", "key1")
 	assert.Error(t, err)
@@ -36,7 +49,7 @@ func TestHashMaps_HGetAll(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("returns empty map for non-existing map name", func(t *testing.T) {
-		maps := NewHashMaps()
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
 		result, err := maps.HGetAll(ctx, "non-existing-map")
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -44,12 +57,12 @@ func TestHashMaps_HGetAll(t *testing.T) {
 	})
 
 	t.Run("returns all key-value pairs - listpack encoding", func(t *testing.T) {
-		maps := NewHashMaps()
-		maps.HSet(ctx, "map1", []string{"key1", "V1", "key2", "V2", "key3", "V3"})
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
+		_, _ = maps.HSet(ctx, "map1", []string{"key1", "V1", "key2", "V2", "key3", "V3"})
 
 		// confirm still listpack-encoded
-		assert.Nil(t, maps.maps["map1"].hash)
-		assert.NotNil(t, maps.maps["map1"].lp)
+		assert.Nil(t, mapAt(maps, "map1").hash)
+		assert.NotNil(t, mapAt(maps, "map1").lp)
 
 		result, err := maps.HGetAll(ctx, "map1")
 		assert.NoError(t, err)
@@ -61,16 +74,16 @@ func TestHashMaps_HGetAll(t *testing.T) {
 	})
 
 	t.Run("returns all key-value pairs - hash encoding", func(t *testing.T) {
-		maps := NewHashMaps()
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
 		kvs := make([]string, 0, (maxEntryCount+1)*2)
 		for i := 0; i <= maxEntryCount; i++ {
 			kvs = append(kvs, fmt.Sprintf("key%d", i), fmt.Sprintf("val%d", i))
 		}
-		maps.HSet(ctx, "map1", kvs)
+		_, _ = maps.HSet(ctx, "map1", kvs)
 
 		// confirm migrated to hash encoding
-		assert.NotNil(t, maps.maps["map1"].hash)
-		assert.Nil(t, maps.maps["map1"].lp)
+		assert.NotNil(t, mapAt(maps, "map1").hash)
+		assert.Nil(t, mapAt(maps, "map1").lp)
 
 		result, err := maps.HGetAll(ctx, "map1")
 		assert.NoError(t, err)
@@ -85,35 +98,35 @@ func TestHashMaps_HExists(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("returns 0 for non-existing key", func(t *testing.T) {
-		maps := NewHashMaps()
-		result := maps.HExists(ctx, "non-existing", "field1")
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
+		result, _ := maps.HExists(ctx, "non-existing", "field1")
 		assert.Equal(t, 0, result)
 	})
 
 	t.Run("returns 0 for existing key but missing field", func(t *testing.T) {
-		maps := NewHashMaps()
-		maps.HSet(ctx, "map1", []string{"key1", "V1"})
-		result := maps.HExists(ctx, "map1", "missing")
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
+		_, _ = maps.HSet(ctx, "map1", []string{"key1", "V1"})
+		result, _ := maps.HExists(ctx, "map1", "missing")
 		assert.Equal(t, 0, result)
 	})
 
 	t.Run("returns 1 for existing field - listpack encoding", func(t *testing.T) {
-		maps := NewHashMaps()
-		maps.HSet(ctx, "map1", []string{"key1", "V1", "key2", "V2"})
-		assert.Nil(t, maps.maps["map1"].hash)
-		result := maps.HExists(ctx, "map1", "key1")
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
+		_, _ = maps.HSet(ctx, "map1", []string{"key1", "V1", "key2", "V2"})
+		assert.Nil(t, mapAt(maps, "map1").hash)
+		result, _ := maps.HExists(ctx, "map1", "key1")
 		assert.Equal(t, 1, result)
 	})
 
 	t.Run("returns 1 for existing field - hash encoding", func(t *testing.T) {
-		maps := NewHashMaps()
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
 		kvs := make([]string, 0, (maxEntryCount+1)*2)
 		for i := 0; i <= maxEntryCount; i++ {
 			kvs = append(kvs, fmt.Sprintf("key%d", i), fmt.Sprintf("val%d", i))
 		}
-		maps.HSet(ctx, "map1", kvs)
-		assert.NotNil(t, maps.maps["map1"].hash)
-		result := maps.HExists(ctx, "map1", "key0")
+		_, _ = maps.HSet(ctx, "map1", kvs)
+		assert.NotNil(t, mapAt(maps, "map1").hash)
+		result, _ := maps.HExists(ctx, "map1", "key0")
 		assert.Equal(t, 1, result)
 	})
 }
@@ -122,18 +135,18 @@ func TestHashMaps_HDel(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("returns 0 for non-existing map", func(t *testing.T) {
-		maps := NewHashMaps()
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
 		deleted, err := maps.HDel(ctx, "non-existing-map", []string{"key1"})
 		assert.NoError(t, err)
 		assert.Equal(t, 0, deleted)
 	})
 
 	t.Run("deletes existing fields - listpack encoding", func(t *testing.T) {
-		maps := NewHashMaps()
-		maps.HSet(ctx, "map1", []string{"key1", "V1", "key2", "V2", "key3", "V3"})
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
+		_, _ = maps.HSet(ctx, "map1", []string{"key1", "V1", "key2", "V2", "key3", "V3"})
 
-		assert.Nil(t, maps.maps["map1"].hash)
-		assert.NotNil(t, maps.maps["map1"].lp)
+		assert.Nil(t, mapAt(maps, "map1").hash)
+		assert.NotNil(t, mapAt(maps, "map1").lp)
 
 		deleted, err := maps.HDel(ctx, "map1", []string{"key1", "key3"})
 		assert.NoError(t, err)
@@ -149,8 +162,8 @@ func TestHashMaps_HDel(t *testing.T) {
 	})
 
 	t.Run("returns 0 for non-existing fields - listpack encoding", func(t *testing.T) {
-		maps := NewHashMaps()
-		maps.HSet(ctx, "map1", []string{"key1", "V1"})
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
+		_, _ = maps.HSet(ctx, "map1", []string{"key1", "V1"})
 
 		deleted, err := maps.HDel(ctx, "map1", []string{"missing"})
 		assert.NoError(t, err)
@@ -158,15 +171,15 @@ func TestHashMaps_HDel(t *testing.T) {
 	})
 
 	t.Run("deletes existing fields - hash encoding", func(t *testing.T) {
-		maps := NewHashMaps()
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
 		kvs := make([]string, 0, (maxEntryCount+1)*2)
 		for i := 0; i <= maxEntryCount; i++ {
 			kvs = append(kvs, fmt.Sprintf("key%d", i), fmt.Sprintf("val%d", i))
 		}
-		maps.HSet(ctx, "map1", kvs)
+		_, _ = maps.HSet(ctx, "map1", kvs)
 
-		assert.NotNil(t, maps.maps["map1"].hash)
-		assert.Nil(t, maps.maps["map1"].lp)
+		assert.NotNil(t, mapAt(maps, "map1").hash)
+		assert.Nil(t, mapAt(maps, "map1").lp)
 
 		deleted, err := maps.HDel(ctx, "map1", []string{"key0", "key1"})
 		assert.NoError(t, err)
@@ -182,14 +195,14 @@ func TestHashMaps_HDel(t *testing.T) {
 	})
 
 	t.Run("returns 0 for non-existing fields - hash encoding", func(t *testing.T) {
-		maps := NewHashMaps()
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
 		kvs := make([]string, 0, (maxEntryCount+1)*2)
 		for i := 0; i <= maxEntryCount; i++ {
 			kvs = append(kvs, fmt.Sprintf("key%d", i), fmt.Sprintf("val%d", i))
 		}
-		maps.HSet(ctx, "map1", kvs)
+		_, _ = maps.HSet(ctx, "map1", kvs)
 
-		assert.NotNil(t, maps.maps["map1"].hash)
+		assert.NotNil(t, mapAt(maps, "map1").hash)
 
 		deleted, err := maps.HDel(ctx, "map1", []string{"missing"})
 		assert.NoError(t, err)
@@ -197,8 +210,8 @@ func TestHashMaps_HDel(t *testing.T) {
 	})
 
 	t.Run("deletes mix of existing and non-existing fields", func(t *testing.T) {
-		maps := NewHashMaps()
-		maps.HSet(ctx, "map1", []string{"key1", "V1", "key2", "V2"})
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
+		_, _ = maps.HSet(ctx, "map1", []string{"key1", "V1", "key2", "V2"})
 
 		deleted, err := maps.HDel(ctx, "map1", []string{"key1", "missing"})
 		assert.NoError(t, err)
@@ -211,3 +224,201 @@ func TestHashMaps_HDel(t *testing.T) {
 		assert.Equal(t, "V2", string(value))
 	})
 }
+
+func TestHashMaps_WrongType(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	maps := NewHashMaps(ks)
+	ctx := context.Background()
+	ks.Put("str", keyspace.TypeString, struct{}{})
+
+	_, err := maps.HSet(ctx, "str", []string{"f", "v"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = maps.HGet(ctx, "str", "f")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = maps.HGetAll(ctx, "str")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = maps.HExists(ctx, "str", "f")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = maps.HDel(ctx, "str", []string{"f"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = maps.HMGet(ctx, "str", []string{"f"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = maps.HIncrBy(ctx, "str", "f", 1)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = maps.HExpire(ctx, "str", time.Now(), keyspace.ExpireAlways, []string{"f"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = maps.HTTL(ctx, "str", []string{"f"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = maps.HGetEx(ctx, "str", []string{"f"}, hashmaps.FieldExpiry{Keep: true})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = maps.HSetEx(ctx, "str", []string{"f", "v"}, hashmaps.SetExOptions{})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+}
+
+func TestHashMaps_RemovesEmptyHash(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	maps := NewHashMaps(ks)
+	ctx := context.Background()
+
+	_, _ = maps.HSet(ctx, "map1", []string{"key1", "V1", "key2", "V2"})
+	_, _ = maps.HDel(ctx, "map1", []string{"key1", "key2"})
+	assert.Equal(t, 0, ks.Len())
+
+	_, err := maps.HIncrBy(ctx, "map2", "field", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ks.Len())
+}
+
+func TestHashMaps_HExpire(t *testing.T) {
+	ctx := context.Background()
+	later, muchLater := time.Now().Add(time.Hour), time.Now().Add(2*time.Hour)
+
+	t.Run("replies missing for every field of a missing hash", func(t *testing.T) {
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
+		result, err := maps.HExpire(ctx, "missing", later, keyspace.ExpireAlways, []string{"f1", "f2"})
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{hashmaps.FieldMissing, hashmaps.FieldMissing}, result)
+	})
+
+	t.Run("honours the condition", func(t *testing.T) {
+		maps := NewHashMaps(memkeyspace.NewKeyspace())
+		_, _ = maps.HSet(ctx, "map1", []string{"f1", "v1", "f2", "v2"})
+
+		result, _ := maps.HExpire(ctx, "map1", later, keyspace.ExpireXX, []string{"f1", "missing"})
+		assert.Equal(t, []int64{hashmaps.FieldConditionNotMet, hashmaps.FieldMissing}, result)
+		result, _ = maps.HExpire(ctx, "map1", later, keyspace.ExpireNX, []string{"f1"})
+		assert.Equal(t, []int64{hashmaps.FieldUpdated}, result)
+		result, _ = maps.HExpire(ctx, "map1", muchLater, keyspace.ExpireLT, []string{"f1", "f2"})
+		assert.Equal(t, []int64{hashmaps.FieldConditionNotMet, hashmaps.FieldUpdated}, result)
+		result, _ = maps.HExpire(ctx, "map1", muchLater, keyspace.ExpireGT, []string{"f1"})
+		assert.Equal(t, []int64{hashmaps.FieldUpdated}, result)
+
+		times, _ := maps.HExpireTime(ctx, "map1", []string{"f1", "f2", "missing"})
+		assert.Equal(t, []int64{muchLater.UnixMilli(), muchLater.UnixMilli(), hashmaps.FieldMissing}, times)
+	})
+
+	t.Run("an expiry in the past deletes the fields and the emptied hash", func(t *testing.T) {
+		ks := memkeyspace.NewKeyspace()
+		maps := NewHashMaps(ks)
+		_, _ = maps.HSet(ctx, "map1", []string{"f1", "v1", "f2", "v2"})
+
+		result, _ := maps.HExpire(ctx, "map1", time.Now().Add(-time.Second), keyspace.ExpireAlways, []string{"f1"})
+		assert.Equal(t, []int64{hashmaps.FieldDeleted}, result)
+		assert.Equal(t, 1, mapAt(maps, "map1").Size())
+
+		_, _ = maps.HExpire(ctx, "map1", time.Now(), keyspace.ExpireAlways, []string{"f2"})
+		assert.Equal(t, 0, ks.Len())
+	})
+}
+
+func TestHashMaps_HTTLAndHPersist(t *testing.T) {
+	maps := NewHashMaps(memkeyspace.NewKeyspace())
+	ctx := context.Background()
+	_, _ = maps.HSet(ctx, "map1", []string{"f1", "v1", "f2", "v2"})
+	_, _ = maps.HExpire(ctx, "map1", time.Now().Add(time.Minute), keyspace.ExpireAlways, []string{"f1"})
+
+	ttls, err := maps.HTTL(ctx, "map1", []string{"f1", "f2", "missing"})
+	assert.NoError(t, err)
+	assert.InDelta(t, time.Minute.Milliseconds(), ttls[0], 1000)
+	assert.Equal(t, []int64{hashmaps.FieldNoExpiry, hashmaps.FieldMissing}, ttls[1:])
+
+	result, err := maps.HPersist(ctx, "map1", []string{"f1", "f2", "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{hashmaps.FieldUpdated, hashmaps.FieldNoExpiry, hashmaps.FieldMissing}, result)
+	ttls, _ = maps.HTTL(ctx, "map1", []string{"f1"})
+	assert.Equal(t, []int64{hashmaps.FieldNoExpiry}, ttls)
+}
+
+func TestHashMaps_ExpiredFieldsAreReclaimedLazily(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	maps := NewHashMaps(ks)
+	ctx := context.Background()
+	_, _ = maps.HSet(ctx, "map1", []string{"f1", "v1", "f2", "v2"})
+	_, _ = maps.HExpire(ctx, "map1", time.Now().Add(10*time.Millisecond), keyspace.ExpireAlways, []string{"f1"})
+	_, _ = maps.HSet(ctx, "map2", []string{"f1", "v1"})
+	_, _ = maps.HExpire(ctx, "map2", time.Now().Add(10*time.Millisecond), keyspace.ExpireAlways, []string{"f1"})
+	time.Sleep(20 * time.Millisecond)
+
+	all, err := maps.HGetAll(ctx, "map1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"f2": "v2"}, all)
+	exists, _ := maps.HExists(ctx, "map2", "f1")
+	assert.Equal(t, 0, exists)
+	assert.Equal(t, 1, ks.Len())
+	assert.Equal(t, int64(2), ks.Stats().ExpiredSubkeys)
+}
+
+func TestHashMaps_HGetDel(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	maps := NewHashMaps(ks)
+	ctx := context.Background()
+	_, _ = maps.HSet(ctx, "map1", []string{"f1", "v1", "f2", "v2"})
+
+	values, err := maps.HGetDel(ctx, "map1", []string{"f1", "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{[]byte("v1"), nil}, values)
+	assert.Equal(t, 1, mapAt(maps, "map1").Size())
+
+	values, _ = maps.HGetDel(ctx, "map1", []string{"f2"})
+	assert.Equal(t, []any{[]byte("v2")}, values)
+	assert.Equal(t, 0, ks.Len())
+
+	values, _ = maps.HGetDel(ctx, "missing", []string{"f1"})
+	assert.Equal(t, []any{nil}, values)
+}
+
+func TestHashMaps_HGetEx(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	maps := NewHashMaps(ks)
+	ctx := context.Background()
+	_, _ = maps.HSet(ctx, "map1", []string{"f1", "v1", "f2", "v2"})
+	later := time.Now().Add(time.Hour)
+
+	values, err := maps.HGetEx(ctx, "map1", []string{"f1", "missing"}, hashmaps.FieldExpiry{At: &later})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{[]byte("v1"), nil}, values)
+	times, _ := maps.HExpireTime(ctx, "map1", []string{"f1", "missing"})
+	assert.Equal(t, []int64{later.UnixMilli(), hashmaps.FieldMissing}, times)
+
+	_, _ = maps.HGetEx(ctx, "map1", []string{"f1"}, hashmaps.FieldExpiry{Keep: true})
+	times, _ = maps.HExpireTime(ctx, "map1", []string{"f1"})
+	assert.Equal(t, []int64{later.UnixMilli()}, times)
+
+	_, _ = maps.HGetEx(ctx, "map1", []string{"f1"}, hashmaps.FieldExpiry{})
+	times, _ = maps.HExpireTime(ctx, "map1", []string{"f1"})
+	assert.Equal(t, []int64{hashmaps.FieldNoExpiry}, times)
+
+	past := time.Now().Add(-time.Second)
+	values, _ = maps.HGetEx(ctx, "map1", []string{"f1", "f2"}, hashmaps.FieldExpiry{At: &past})
+	assert.Equal(t, []any{[]byte("v1"), []byte("v2")}, values)
+	assert.Equal(t, 0, ks.Len())
+}
+
+func TestHashMaps_HSetEx(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	maps := NewHashMaps(ks)
+	ctx := context.Background()
+	later := time.Now().Add(time.Hour)
+
+	set, err := maps.HSetEx(ctx, "map1", []string{"f1", "v1"}, hashmaps.SetExOptions{FXX: true})
+	assert.NoError(t, err)
+	assert.False(t, set)
+	assert.Equal(t, 0, ks.Len())
+
+	set, _ = maps.HSetEx(ctx, "map1", []string{"f1", "v1", "f2", "v2"}, hashmaps.SetExOptions{FNX: true, Expiry: hashmaps.FieldExpiry{At: &later}})
+	assert.True(t, set)
+	times, _ := maps.HExpireTime(ctx, "map1", []string{"f1", "f2"})
+	assert.Equal(t, []int64{later.UnixMilli(), later.UnixMilli()}, times)
+
+	set, _ = maps.HSetEx(ctx, "map1", []string{"f2", "v2", "f3", "v3"}, hashmaps.SetExOptions{FNX: true})
+	assert.False(t, set)
+
+	set, _ = maps.HSetEx(ctx, "map1", []string{"f1", "changed"}, hashmaps.SetExOptions{FXX: true, Expiry: hashmaps.FieldExpiry{Keep: true}})
+	assert.True(t, set)
+	set, _ = maps.HSetEx(ctx, "map1", []string{"f2", "changed"}, hashmaps.SetExOptions{})
+	assert.True(t, set)
+	times, _ = maps.HExpireTime(ctx, "map1", []string{"f1", "f2"})
+	assert.Equal(t, []int64{later.UnixMilli(), hashmaps.FieldNoExpiry}, times)
+	all, _ := maps.HGetAll(ctx, "map1")
+	assert.Equal(t, map[string]string{"f1": "changed", "f2": "changed"}, all)
+}
@@ -10,8 +10,11 @@
 package mockhashmaps
 
 import (
+	hashmaps "avacado/internal/storage/hashmaps"
+	keyspace "avacado/internal/storage/keyspace"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -56,11 +59,12 @@ func (mr *MockHashMapsMockRecorder) HDel(ctx, key, fields any) *gomock.Call {
 }
 
 // HExists mocks base method.
-func (m *MockHashMaps) HExists(ctx context.Context, key, field string) int {
+func (m *MockHashMaps) HExists(ctx context.Context, key, field string) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HExists", ctx, key, field)
 	ret0, _ := ret[0].(int)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // HExists indicates an expected call of HExists.
@@ -69,6 +73,36 @@ func (mr *MockHashMapsMockRecorder) HExists(ctx, key, field any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HExists", reflect.TypeOf((*MockHashMaps)(nil).HExists), ctx, key, field)
 }
 
+// HExpire mocks base method.
+func (m *MockHashMaps) HExpire(ctx context.Context, key string, at time.Time, condition keyspace.ExpireCondition, fields []string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HExpire", ctx, key, at, condition, fields)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HExpire indicates an expected call of HExpire.
+func (mr *MockHashMapsMockRecorder) HExpire(ctx, key, at, condition, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HExpire", reflect.TypeOf((*MockHashMaps)(nil).HExpire), ctx, key, at, condition, fields)
+}
+
+// HExpireTime mocks base method.
+func (m *MockHashMaps) HExpireTime(ctx context.Context, key string, fields []string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HExpireTime", ctx, key, fields)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HExpireTime indicates an expected call of HExpireTime.
+func (mr *MockHashMapsMockRecorder) HExpireTime(ctx, key, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HExpireTime", reflect.TypeOf((*MockHashMaps)(nil).HExpireTime), ctx, key, fields)
+}
+
 // HGet mocks base method.
 func (m *MockHashMaps) HGet(ctx context.Context, name, field string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +133,36 @@ func (mr *MockHashMapsMockRecorder) HGetAll(ctx, name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HGetAll", reflect.TypeOf((*MockHashMaps)(nil).HGetAll), ctx, name)
 }
 
+// HGetDel mocks base method.
+func (m *MockHashMaps) HGetDel(ctx context.Context, key string, fields []string) ([]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HGetDel", ctx, key, fields)
+	ret0, _ := ret[0].([]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HGetDel indicates an expected call of HGetDel.
+func (mr *MockHashMapsMockRecorder) HGetDel(ctx, key, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HGetDel", reflect.TypeOf((*MockHashMaps)(nil).HGetDel), ctx, key, fields)
+}
+
+// HGetEx mocks base method.
+func (m *MockHashMaps) HGetEx(ctx context.Context, key string, fields []string, expiry hashmaps.FieldExpiry) ([]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HGetEx", ctx, key, fields, expiry)
+	ret0, _ := ret[0].([]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HGetEx indicates an expected call of HGetEx.
+func (mr *MockHashMapsMockRecorder) HGetEx(ctx, key, fields, expiry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HGetEx", reflect.TypeOf((*MockHashMaps)(nil).HGetEx), ctx, key, fields, expiry)
+}
+
 // HIncrBy mocks base method.
 func (m *MockHashMaps) HIncrBy(ctx context.Context, key, field string, increment int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -115,11 +179,12 @@ func (mr *MockHashMapsMockRecorder) HIncrBy(ctx, key, field, increment any) *gom
 }
 
 // HMGet mocks base method.
-func (m *MockHashMaps) HMGet(ctx context.Context, key string, fields []string) []any {
+func (m *MockHashMaps) HMGet(ctx context.Context, key string, fields []string) ([]any, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HMGet", ctx, key, fields)
 	ret0, _ := ret[0].([]any)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // HMGet indicates an expected call of HMGet.
@@ -128,12 +193,28 @@ func (mr *MockHashMapsMockRecorder) HMGet(ctx, key, fields any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HMGet", reflect.TypeOf((*MockHashMaps)(nil).HMGet), ctx, key, fields)
 }
 
+// HPersist mocks base method.
+func (m *MockHashMaps) HPersist(ctx context.Context, key string, fields []string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HPersist", ctx, key, fields)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HPersist indicates an expected call of HPersist.
+func (mr *MockHashMapsMockRecorder) HPersist(ctx, key, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HPersist", reflect.TypeOf((*MockHashMaps)(nil).HPersist), ctx, key, fields)
+}
+
 // HSet mocks base method.
-func (m *MockHashMaps) HSet(ctx context.Context, name string, keyValues []string) int {
+func (m *MockHashMaps) HSet(ctx context.Context, name string, keyValues []string) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HSet", ctx, name, keyValues)
 	ret0, _ := ret[0].(int)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // HSet indicates an expected call of HSet.
@@ -141,3 +222,33 @@ func (mr *MockHashMapsMockRecorder) HSet(ctx, name, keyValues any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HSet", reflect.TypeOf((*MockHashMaps)(nil).HSet), ctx, name, keyValues)
 }
+
+// HSetEx mocks base method.
+func (m *MockHashMaps) HSetEx(ctx context.Context, key string, keyValues []string, options hashmaps.SetExOptions) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HSetEx", ctx, key, keyValues, options)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HSetEx indicates an expected call of HSetEx.
+func (mr *MockHashMapsMockRecorder) HSetEx(ctx, key, keyValues, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HSetEx", reflect.TypeOf((*MockHashMaps)(nil).HSetEx), ctx, key, keyValues, options)
+}
+
+// HTTL mocks base method.
+func (m *MockHashMaps) HTTL(ctx context.Context, key string, fields []string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HTTL", ctx, key, fields)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HTTL indicates an expected call of HTTL.
+func (mr *MockHashMapsMockRecorder) HTTL(ctx, key, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HTTL", reflect.TypeOf((*MockHashMaps)(nil).HTTL), ctx, key, fields)
+}
@@ -0,0 +1,334 @@
+// Package hyperloglog implements the HyperLogLog sketches of Redis, byte for byte, so that the
+// string value holding one can be exchanged with Redis.
+package hyperloglog
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	// precision is the number of bits of the hash of an element addressing its register.
+	precision = 14
+	// Registers is the number of registers of a sketch.
+	Registers = 1 << precision
+	// q is the number of bits of the hash left to count the run of zeros of.
+	q            = 64 - precision
+	registerBits = 6
+	registerMax  = 1<<registerBits - 1
+
+	// headerSize is the size of the header: the HYLL magic, the encoding, 3 unused bytes and the
+	// cached cardinality, little endian, whose most significant bit is set once it is stale.
+	headerSize = 16
+	denseSize  = headerSize + (Registers*registerBits+7)/8
+
+	encodingDense  = 0
+	encodingSparse = 1
+
+	alphaInf = 0.721347520444481703680
+	hashSeed = 0xadc83b19
+)
+
+const magic = "HYLL"
+
+// DefaultSparseMaxBytes is the size a sparse sketch is promoted to dense past, Redis's default
+// hll-sparse-max-bytes.
+const DefaultSparseMaxBytes = 3000
+
+var (
+	// ErrNotHLL is returned when a string value is not a sketch.
+	ErrNotHLL = errors.New("WRONGTYPE Key is not a valid HyperLogLog string value.")
+	// ErrCorrupted is returned when the registers of a sketch cannot be decoded.
+	ErrCorrupted = errors.New("INVALIDOBJ Corrupted HLL object detected")
+)
+
+// HLL is a HyperLogLog sketch estimating how many distinct elements were added to it, from
+// Registers registers each holding the longest run of zeros seen in the hashes of the elements
+// addressing it. A sketch starts sparse, its registers run-length encoded, and is promoted to dense,
+// 6 bits per register, once it grows too large or a register no longer fits the sparse encoding.
+type HLL struct {
+	data []byte
+}
+
+// New returns an empty sparse sketch.
+func New() *HLL {
+	data := make([]byte, headerSize+2)
+	copy(data, magic)
+	data[4] = encodingSparse
+	setXZero(data[headerSize:], Registers)
+	return &HLL{data: data}
+}
+
+// Parse returns the sketch held in data, which it works on, or ErrNotHLL if data holds none.
+func Parse(data []byte) (*HLL, error) {
+	if len(data) < headerSize || string(data[:4]) != magic || data[4] > encodingSparse {
+		return nil, ErrNotHLL
+	}
+	if data[4] == encodingDense && len(data) != denseSize {
+		return nil, ErrNotHLL
+	}
+	return &HLL{data: data}, nil
+}
+
+// Bytes returns the bytes of the sketch.
+func (h *HLL) Bytes() []byte {
+	return h.data
+}
+
+// Dense reports whether the sketch is dense.
+func (h *HLL) Dense() bool {
+	return h.data[4] == encodingDense
+}
+
+// Encoding returns "dense" or "sparse".
+func (h *HLL) Encoding() string {
+	if h.Dense() {
+		return "dense"
+	}
+	return "sparse"
+}
+
+// Add adds element to the sketch and reports whether a register was updated, promoting a sparse
+// sketch to dense rather than growing it past sparseMaxBytes.
+func (h *HLL) Add(element []byte, sparseMaxBytes int) (bool, error) {
+	index, count := patternLength(element)
+	updated, err := h.set(index, count, sparseMaxBytes)
+	if updated {
+		h.invalidateCache()
+	}
+	return updated, err
+}
+
+// set raises the register at index to count, reporting whether it was lower.
+func (h *HLL) set(index int, count uint8, sparseMaxBytes int) (bool, error) {
+	if h.Dense() {
+		return denseSet(h.data[headerSize:], index, count), nil
+	}
+	return h.sparseSet(index, count, sparseMaxBytes)
+}
+
+// Count returns the estimated cardinality, cached in the header until a register changes, and
+// reports whether the cache was refreshed.
+func (h *HLL) Count() (uint64, bool, error) {
+	if h.data[15]&0x80 == 0 {
+		return binary.LittleEndian.Uint64(h.data[8:headerSize]), false, nil
+	}
+	var histogram [64]int
+	if h.Dense() {
+		denseHistogram(h.data[headerSize:], &histogram)
+	} else if err := sparseHistogram(h.data[headerSize:], &histogram); err != nil {
+		return 0, false, err
+	}
+	card := estimate(&histogram)
+	binary.LittleEndian.PutUint64(h.data[8:headerSize], card)
+	return card, true, nil
+}
+
+func (h *HLL) invalidateCache() {
+	h.data[15] |= 0x80
+}
+
+// ToDense converts a sparse sketch to dense, and reports whether it was sparse.
+func (h *HLL) ToDense() (bool, error) {
+	if h.Dense() {
+		return false, nil
+	}
+	dense := make([]byte, denseSize)
+	copy(dense, h.data[:headerSize])
+	dense[4] = encodingDense
+	registers := dense[headerSize:]
+	err := eachRun(h.data[headerSize:], func(r run) {
+		for i := r.start; i < r.start+r.length && r.value > 0; i++ {
+			setRegister(registers, i, r.value)
+		}
+	})
+	if err != nil {
+		return false, err
+	}
+	h.data = dense
+	return true, nil
+}
+
+// MergeInto raises each of max, which holds Registers registers, to the matching register of the
+// sketch.
+func (h *HLL) MergeInto(max []uint8) error {
+	if h.Dense() {
+		registers := h.data[headerSize:]
+		for i := range Registers {
+			max[i] = maxRegister(max[i], getRegister(registers, i))
+		}
+		return nil
+	}
+	return eachRun(h.data[headerSize:], func(r run) {
+		for i := r.start; i < r.start+r.length; i++ {
+			max[i] = maxRegister(max[i], r.value)
+		}
+	})
+}
+
+// Raise raises the registers of the sketch to the matching ones of max, as PFMERGE does.
+func (h *HLL) Raise(max []uint8, sparseMaxBytes int) error {
+	for i, count := range max {
+		if count == 0 {
+			continue
+		}
+		if _, err := h.set(i, count, sparseMaxBytes); err != nil {
+			return err
+		}
+	}
+	h.invalidateCache()
+	return nil
+}
+
+// Registers returns the value of every register, the sketch being dense.
+func (h *HLL) Registers() []uint8 {
+	values := make([]uint8, Registers)
+	registers := h.data[headerSize:]
+	for i := range values {
+		values[i] = getRegister(registers, i)
+	}
+	return values
+}
+
+// Estimate returns the cardinality estimated from Registers registers, as PFCOUNT does for the
+// union of several sketches.
+func Estimate(registers []uint8) uint64 {
+	var histogram [64]int
+	for _, value := range registers {
+		histogram[value]++
+	}
+	return estimate(&histogram)
+}
+
+// getRegister returns the register at index of dense registers.
+func getRegister(registers []byte, index int) uint8 {
+	at, shift := index*registerBits/8, uint(index*registerBits&7)
+	value := uint(registers[at]) >> shift
+	// The last register starts 2 bits into the last byte, so it does not reach the next one.
+	if at+1 < len(registers) {
+		value |= uint(registers[at+1]) << (8 - shift)
+	}
+	return uint8(value & registerMax)
+}
+
+// setRegister sets the register at index of dense registers to value.
+func setRegister(registers []byte, index int, value uint8) {
+	at, shift := index*registerBits/8, uint(index*registerBits&7)
+	v := uint(value)
+	registers[at] &^= byte(registerMax << shift)
+	registers[at] |= byte(v << shift)
+	if at+1 < len(registers) {
+		registers[at+1] &^= byte(registerMax >> (8 - shift))
+		registers[at+1] |= byte(v >> (8 - shift))
+	}
+}
+
+// denseSet raises the register at index of dense registers to count, reporting whether it was lower.
+func denseSet(registers []byte, index int, count uint8) bool {
+	if getRegister(registers, index) >= count {
+		return false
+	}
+	setRegister(registers, index, count)
+	return true
+}
+
+func denseHistogram(registers []byte, histogram *[64]int) {
+	for i := range Registers {
+		histogram[getRegister(registers, i)]++
+	}
+}
+
+func maxRegister(a, b uint8) uint8 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// patternLength returns the register element addresses and the length of the run of zeros,
+// plus one, that follows in its hash.
+func patternLength(element []byte) (int, uint8) {
+	hash := murmurHash64A(element, hashSeed)
+	index := int(hash & (Registers - 1))
+	hash >>= precision
+	// The bit set past the q bits left makes the count at most q+1.
+	hash |= 1 << q
+	count := uint8(1)
+	for hash&1 == 0 {
+		count++
+		hash >>= 1
+	}
+	return index, count
+}
+
+// estimate returns the cardinality estimated from the histogram of the register values, following
+// "New cardinality estimation algorithms for HyperLogLog sketches", Otmar Ertl, arXiv:1702.01284.
+func estimate(histogram *[64]int) uint64 {
+	m := float64(Registers)
+	z := m * tau((m-float64(histogram[q+1]))/m)
+	for j := q; j >= 1; j-- {
+		z += float64(histogram[j])
+		z *= 0.5
+	}
+	z += m * sigma(float64(histogram[0])/m)
+	return uint64(math.Round(alphaInf * m * m / z))
+}
+
+func sigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y, z := 1.0, x
+	for {
+		x *= x
+		previous := z
+		z += x * y
+		y += y
+		if previous == z {
+			return z
+		}
+	}
+}
+
+func tau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		previous := z
+		y *= 0.5
+		z -= math.Pow(1-x, 2) * y
+		if previous == z {
+			return z / 3
+		}
+	}
+}
+
+// murmurHash64A is MurmurHash64A as Redis computes it, reading the element little endian.
+func murmurHash64A(key []byte, seed uint64) uint64 {
+	const m = 0xc6a4a7935bd1e995
+	const r = 47
+	h := seed ^ (uint64(len(key)) * m)
+	for len(key) >= 8 {
+		k := binary.LittleEndian.Uint64(key)
+		k *= m
+		k ^= k >> r
+		k *= m
+		h ^= k
+		h *= m
+		key = key[8:]
+	}
+	if len(key) > 0 {
+		for i := len(key) - 1; i >= 0; i-- {
+			h ^= uint64(key[i]) << (8 * i)
+		}
+		h *= m
+	}
+	h ^= h >> r
+	h *= m
+	h ^= h >> r
+	return h
+}
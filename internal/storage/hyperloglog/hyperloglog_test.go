@@ -0,0 +1,153 @@
+package hyperloglog
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	h := New()
+	assert.Equal(t, []byte("HYLL\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x7f\xff"), h.Bytes())
+	assert.Equal(t, "sparse", h.Encoding())
+	decoded, err := h.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, "Z:16384", decoded)
+	card, refreshed, err := h.Count()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), card)
+	assert.False(t, refreshed)
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse(New().Bytes())
+	assert.NoError(t, err)
+	for _, data := range [][]byte{
+		[]byte("HYLL"),
+		[]byte("HYLX\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x7f\xff"),
+		[]byte("HYLL\x02\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x7f\xff"),
+		[]byte("HYLL\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x7f\xff"),
+	} {
+		_, err := Parse(data)
+		assert.Equal(t, ErrNotHLL, err, data)
+	}
+}
+
+func TestHLL_Add(t *testing.T) {
+	h := New()
+	updated, err := h.Add([]byte("a"), DefaultSparseMaxBytes)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	updated, _ = h.Add([]byte("a"), DefaultSparseMaxBytes)
+	assert.False(t, updated)
+
+	index, count := patternLength([]byte("a"))
+	decoded, _ := h.Decode()
+	assert.Equal(t, "Z:"+strconv.Itoa(index)+" v:"+strconv.Itoa(int(count))+",1 Z:"+strconv.Itoa(Registers-index-1), decoded)
+	card, refreshed, err := h.Count()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), card)
+	assert.True(t, refreshed)
+	_, refreshed, _ = h.Count()
+	assert.False(t, refreshed)
+}
+
+func TestHLL_SparseMergesAdjacentValues(t *testing.T) {
+	h := New()
+	for i := 0; i < 4; i++ {
+		_, err := h.set(i, 3, DefaultSparseMaxBytes)
+		require.NoError(t, err)
+	}
+	decoded, _ := h.Decode()
+	assert.Equal(t, "v:3,4 Z:16380", decoded)
+	_, _ = h.set(4, 3, DefaultSparseMaxBytes)
+	_, _ = h.set(1, 5, DefaultSparseMaxBytes)
+	decoded, _ = h.Decode()
+	assert.Equal(t, "v:3,1 v:5,1 v:3,3 Z:16379", decoded)
+}
+
+func TestHLL_SparseAndDenseAgree(t *testing.T) {
+	sparse, dense := New(), New()
+	_, err := dense.ToDense()
+	require.NoError(t, err)
+	for i := 0; i < 2000; i++ {
+		element := []byte(strconv.Itoa(i))
+		_, err := sparse.Add(element, 1<<20)
+		require.NoError(t, err)
+		_, _ = dense.Add(element, 1<<20)
+	}
+	assert.False(t, sparse.Dense())
+	sparseCard, _, err := sparse.Count()
+	assert.NoError(t, err)
+	denseCard, _, _ := dense.Count()
+	assert.Equal(t, denseCard, sparseCard)
+	assert.InDelta(t, 2000, sparseCard, 2000*0.02)
+
+	converted, err := sparse.ToDense()
+	assert.NoError(t, err)
+	assert.True(t, converted)
+	assert.Equal(t, dense.Registers(), sparse.Registers())
+}
+
+func TestHLL_PromotedToDense(t *testing.T) {
+	h := New()
+	for i := 0; !h.Dense(); i++ {
+		_, err := h.Add([]byte(strconv.Itoa(i)), DefaultSparseMaxBytes)
+		require.NoError(t, err)
+		if !h.Dense() {
+			assert.LessOrEqual(t, len(h.Bytes()), DefaultSparseMaxBytes)
+		}
+	}
+	assert.Len(t, h.Bytes(), denseSize)
+
+	_, _ = h.set(7, valMaxValue+1, DefaultSparseMaxBytes)
+	assert.Equal(t, uint8(valMaxValue+1), h.Registers()[7])
+	small := New()
+	_, _ = small.set(7, valMaxValue+1, DefaultSparseMaxBytes)
+	assert.True(t, small.Dense())
+	assert.Equal(t, uint8(valMaxValue+1), small.Registers()[7])
+}
+
+func TestHLL_Registers(t *testing.T) {
+	h := New()
+	_, _ = h.ToDense()
+	for _, i := range []int{0, 1, 2, 3, Registers - 2, Registers - 1} {
+		_, _ = h.set(i, registerMax, 0)
+		assert.Equal(t, uint8(registerMax), h.Registers()[i], i)
+	}
+	registers := h.Registers()
+	assert.Equal(t, uint8(0), registers[4])
+	assert.Equal(t, uint8(0), registers[Registers-3])
+}
+
+func TestHLL_MergeAndEstimate(t *testing.T) {
+	first, second := New(), New()
+	for i := 0; i < 100; i++ {
+		_, _ = first.Add([]byte(strconv.Itoa(i)), DefaultSparseMaxBytes)
+		_, _ = second.Add([]byte(strconv.Itoa(i+50)), DefaultSparseMaxBytes)
+	}
+	_, _ = second.ToDense()
+	max := make([]uint8, Registers)
+	assert.NoError(t, first.MergeInto(max))
+	assert.NoError(t, second.MergeInto(max))
+	assert.InDelta(t, 150, Estimate(max), 3)
+
+	merged := New()
+	assert.NoError(t, merged.Raise(max, DefaultSparseMaxBytes))
+	card, _, _ := merged.Count()
+	assert.Equal(t, Estimate(max), card)
+}
+
+func TestHLL_Corrupted(t *testing.T) {
+	h, err := Parse([]byte("HYLL\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x80\x7f"))
+	require.NoError(t, err)
+	_, _, err = h.Count()
+	assert.Equal(t, ErrCorrupted, err)
+	_, err = h.Add([]byte("a"), DefaultSparseMaxBytes)
+	assert.Equal(t, ErrCorrupted, err)
+	_, err = h.ToDense()
+	assert.Equal(t, ErrCorrupted, err)
+	assert.Equal(t, ErrCorrupted, h.MergeInto(make([]uint8, Registers)))
+}
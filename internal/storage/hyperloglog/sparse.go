@@ -0,0 +1,250 @@
+package hyperloglog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// The sparse encoding run-length encodes the registers with three opcodes:
+//   - ZERO, 00xxxxxx: 1 to 64 registers set to 0, xxxxxx being the count minus 1.
+//   - XZERO, 01xxxxxx yyyyyyyy: 1 to 16384 registers set to 0, the 14 bits x and y being the count
+//     minus 1.
+//   - VAL, 1vvvvvxx: 1 to 4 registers set to 1 to 32, vvvvv being the value minus 1 and xx the
+//     count minus 1.
+const (
+	xzeroBit        = 0x40
+	valBit          = 0x80
+	valMaxValue     = 32
+	valMaxLength    = 4
+	zeroMaxLength   = 64
+	sparseMergeScan = 5
+)
+
+func isZero(op byte) bool {
+	return op&0xc0 == 0
+}
+
+func isXZero(op byte) bool {
+	return op&0xc0 == xzeroBit
+}
+
+func isVal(op byte) bool {
+	return op&valBit != 0
+}
+
+func zeroLength(op byte) int {
+	return int(op&0x3f) + 1
+}
+
+func xzeroLength(op, next byte) int {
+	return (int(op&0x3f)<<8 | int(next)) + 1
+}
+
+func valValue(op byte) uint8 {
+	return (op>>2)&0x1f + 1
+}
+
+func valLength(op byte) int {
+	return int(op&0x3) + 1
+}
+
+func valOp(value uint8, length int) byte {
+	return (value-1)<<2 | byte(length-1) | valBit
+}
+
+func setXZero(p []byte, length int) {
+	length--
+	p[0] = byte(length>>8) | xzeroBit
+	p[1] = byte(length)
+}
+
+// appendZeros appends the opcode of a run of length registers set to 0.
+func appendZeros(seq []byte, length int) []byte {
+	if length > zeroMaxLength {
+		seq = append(seq, 0, 0)
+		setXZero(seq[len(seq)-2:], length)
+		return seq
+	}
+	return append(seq, byte(length-1))
+}
+
+// run is the registers an opcode sets to value.
+type run struct {
+	start, length int
+	value         uint8
+	xzero         bool
+}
+
+// eachRun calls fn with the run of every opcode of sparse, and returns ErrCorrupted unless they
+// cover exactly all the registers.
+func eachRun(sparse []byte, fn func(r run)) error {
+	index := 0
+	for p := 0; p < len(sparse); {
+		r := run{start: index}
+		switch op := sparse[p]; {
+		case isZero(op):
+			r.length = zeroLength(op)
+			p++
+		case isXZero(op):
+			if p+1 == len(sparse) {
+				return ErrCorrupted
+			}
+			r.length, r.xzero = xzeroLength(op, sparse[p+1]), true
+			p += 2
+		default:
+			r.length, r.value = valLength(op), valValue(op)
+			p++
+		}
+		index += r.length
+		if index > Registers {
+			return ErrCorrupted
+		}
+		fn(r)
+	}
+	if index != Registers {
+		return ErrCorrupted
+	}
+	return nil
+}
+
+func sparseHistogram(sparse []byte, histogram *[64]int) error {
+	return eachRun(sparse, func(r run) {
+		histogram[r.value] += r.length
+	})
+}
+
+// Decode describes the opcodes of a sparse sketch, as PFDEBUG DECODE does.
+func (h *HLL) Decode() (string, error) {
+	var opcodes []string
+	err := eachRun(h.data[headerSize:], func(r run) {
+		switch {
+		case r.xzero:
+			opcodes = append(opcodes, fmt.Sprintf("Z:%d", r.length))
+		case r.value == 0:
+			opcodes = append(opcodes, fmt.Sprintf("z:%d", r.length))
+		default:
+			opcodes = append(opcodes, fmt.Sprintf("v:%d,%d", r.value, r.length))
+		}
+	})
+	return strings.Join(opcodes, " "), err
+}
+
+// sparseSet raises the register at index to count, reporting whether it was lower. Like Redis, it
+// edits the opcodes in place rather than encoding the registers anew, which keeps the bytes the
+// same as Redis's. The sketch is promoted to dense when count does not fit a VAL opcode or the
+// edit would grow it past sparseMaxBytes.
+func (h *HLL) sparseSet(index int, count uint8, sparseMaxBytes int) (bool, error) {
+	if count > valMaxValue {
+		return h.promote(index, count)
+	}
+	sparse := h.data[headerSize:]
+
+	// Locate the opcode covering the register, first being the first register it covers.
+	p, prev, first, span := 0, -1, 0, 0
+	for p < len(sparse) {
+		length := 1
+		switch op := sparse[p]; {
+		case isZero(op):
+			span = zeroLength(op)
+		case isVal(op):
+			span = valLength(op)
+		default:
+			if p+1 == len(sparse) {
+				return false, ErrCorrupted
+			}
+			span, length = xzeroLength(op, sparse[p+1]), 2
+		}
+		if index <= first+span-1 {
+			break
+		}
+		prev = p
+		p += length
+		first += span
+	}
+	if span == 0 || p >= len(sparse) {
+		return false, ErrCorrupted
+	}
+
+	op := sparse[p]
+	switch {
+	case isVal(op) && valValue(op) >= count:
+		return false, nil
+	case isVal(op) && span == 1, isZero(op) && span == 1:
+		sparse[p] = valOp(count, 1)
+		h.mergeValues(prev)
+		return true, nil
+	}
+
+	// Split the opcode in up to 3 around the register: an XZERO split in the middle being the
+	// worst case, 5 bytes.
+	seq := make([]byte, 0, 5)
+	last := first + span - 1
+	if isVal(op) {
+		value := valValue(op)
+		if index != first {
+			seq = append(seq, valOp(value, index-first))
+		}
+		seq = append(seq, valOp(count, 1))
+		if index != last {
+			seq = append(seq, valOp(value, last-index))
+		}
+	} else {
+		if index != first {
+			seq = appendZeros(seq, index-first)
+		}
+		seq = append(seq, valOp(count, 1))
+		if index != last {
+			seq = appendZeros(seq, last-index)
+		}
+	}
+	length := 1
+	if isXZero(op) {
+		length = 2
+	}
+	if len(seq) > length && len(h.data)+len(seq)-length > sparseMaxBytes {
+		return h.promote(index, count)
+	}
+	h.data = slices.Replace(h.data, headerSize+p, headerSize+p+length, seq...)
+	h.mergeValues(prev)
+	return true, nil
+}
+
+// mergeValues merges the adjacent VAL opcodes of the same value that fit a single one, scanning
+// a few opcodes from prev, the opcode before the one edited, as Redis does.
+func (h *HLL) mergeValues(prev int) {
+	sparse := h.data[headerSize:]
+	end := len(sparse)
+	p := max(prev, 0)
+	for scan := sparseMergeScan; p < end && scan > 0; scan-- {
+		switch {
+		case isXZero(sparse[p]):
+			p += 2
+			continue
+		case isZero(sparse[p]):
+			p++
+			continue
+		}
+		if p+1 < end && isVal(sparse[p+1]) && valValue(sparse[p]) == valValue(sparse[p+1]) {
+			if length := valLength(sparse[p]) + valLength(sparse[p+1]); length <= valMaxLength {
+				sparse[p+1] = valOp(valValue(sparse[p]), length)
+				copy(sparse[p:], sparse[p+1:end])
+				end--
+				// Try to merge the opcode merged with the one on its right too.
+				continue
+			}
+		}
+		p++
+	}
+	h.data = h.data[:headerSize+end]
+}
+
+// promote converts the sketch to dense to set the register at index to count, which requires
+// an update.
+func (h *HLL) promote(index int, count uint8) (bool, error) {
+	if _, err := h.ToDense(); err != nil {
+		return false, err
+	}
+	denseSet(h.data[headerSize:], index, count)
+	return true, nil
+}
@@ -0,0 +1,185 @@
+package keyspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Type identifies the kind of value held by a key. Its string form is the
+// reply of the TYPE command.
+type Type string
+
+const (
+	TypeString Type = "string"
+	TypeList   Type = "list"
+	TypeHash   Type = "hash"
+	TypeSet    Type = "set"
+	TypeZSet   Type = "zset"
+	TypeStream Type = "stream"
+	// TypeNone is reported for keys that do not exist
+	TypeNone Type = "none"
+)
+
+var (
+	// ErrWrongType is returned when a command operates on a key holding a value of another type.
+	ErrWrongType = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	// ErrNoSuchKey is returned when a command requires a key that does not exist.
+	ErrNoSuchKey = errors.New("ERR no such key")
+	// ErrSameObject is returned when a key is copied onto itself.
+	ErrSameObject = errors.New("ERR source and destination objects are the same")
+)
+
+// ExpireCondition restricts when EXPIRE-like commands may change the expiry of a key.
+// Conditions are flags and can be combined, e.g. ExpireXX|ExpireLT.
+// For GT and LT a key without expiry is treated as having an infinite TTL.
+type ExpireCondition int
+
+// ExpireAlways sets the expiry unconditionally
+const ExpireAlways ExpireCondition = 0
+
+const (
+	// ExpireNX sets the expiry only when the key has none
+	ExpireNX ExpireCondition = 1 << iota
+	// ExpireXX sets the expiry only when the key already has one
+	ExpireXX
+	// ExpireGT sets the expiry only when it is later than the current one
+	ExpireGT
+	// ExpireLT sets the expiry only when it is earlier than the current one
+	ExpireLT
+)
+
+// Holds reports whether the condition allows an expiry to change from current, nil for none, to at.
+func (c ExpireCondition) Holds(current *time.Time, at time.Time) bool {
+	if c&ExpireNX != 0 && current != nil {
+		return false
+	}
+	if c&ExpireXX != 0 && current == nil {
+		return false
+	}
+	if c&ExpireGT != 0 && (current == nil || !at.After(*current)) {
+		return false
+	}
+	if c&ExpireLT != 0 && current != nil && !at.Before(*current) {
+		return false
+	}
+	return true
+}
+
+// EvictionPolicy selects the keys removed once the memory used exceeds maxmemory.
+type EvictionPolicy string
+
+const (
+	// NoEviction removes nothing, commands adding data are refused instead
+	NoEviction EvictionPolicy = "noeviction"
+	// AllKeysLRU removes the least recently used keys
+	AllKeysLRU EvictionPolicy = "allkeys-lru"
+	// AllKeysLFU removes the least frequently used keys
+	AllKeysLFU EvictionPolicy = "allkeys-lfu"
+	// AllKeysRandom removes random keys
+	AllKeysRandom EvictionPolicy = "allkeys-random"
+	// VolatileLRU removes the least recently used keys among those with an expiry
+	VolatileLRU EvictionPolicy = "volatile-lru"
+	// VolatileLFU removes the least frequently used keys among those with an expiry
+	VolatileLFU EvictionPolicy = "volatile-lfu"
+	// VolatileRandom removes random keys among those with an expiry
+	VolatileRandom EvictionPolicy = "volatile-random"
+	// VolatileTTL removes the keys closest to expiring
+	VolatileTTL EvictionPolicy = "volatile-ttl"
+)
+
+var evictionPolicies = []EvictionPolicy{
+	NoEviction, AllKeysLRU, AllKeysLFU, AllKeysRandom, VolatileLRU, VolatileLFU, VolatileRandom, VolatileTTL,
+}
+
+// ParseEvictionPolicy returns the eviction policy named name, ignoring case.
+func ParseEvictionPolicy(name string) (EvictionPolicy, error) {
+	for _, policy := range evictionPolicies {
+		if strings.EqualFold(name, string(policy)) {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("invalid maxmemory-policy %q", name)
+}
+
+// Volatile reports whether the policy only removes keys with an expiry.
+func (p EvictionPolicy) Volatile() bool {
+	return strings.HasPrefix(string(p), "volatile-")
+}
+
+// ScanOptions filter the keys returned by Scan. Zero values disable the filter.
+type ScanOptions struct {
+	Match string // Glob-style pattern keys must match
+	Count int    // Hint of how many keys to visit in one call
+	Type  Type   // Type of value keys must hold
+}
+
+// Stats are the counters of the expiration, eviction and memory accounting machinery, reported by INFO.
+type Stats struct {
+	// ExpiredKeys is the number of keys removed because their TTL elapsed, lazily or actively.
+	ExpiredKeys int64
+	// ExpiredSubkeys is the number of hash fields removed because their TTL elapsed, lazily or actively.
+	ExpiredSubkeys int64
+	// ExpiredStalePerc is a running estimate of the percentage of volatile keys already expired.
+	ExpiredStalePerc float64
+	// ExpiredTimeCapReachedCount is the number of active expire cycles stopped by their time limit.
+	ExpiredTimeCapReachedCount int64
+	// ExpireCycleTime is the total time spent in active expire cycles.
+	ExpireCycleTime time.Duration
+	// EvictedKeys is the number of keys removed to stay under maxmemory.
+	EvictedKeys int64
+	// UsedMemory is the approximate number of bytes used by the keys of every database.
+	UsedMemory int64
+	// PeakUsedMemory is the highest UsedMemory ever reached.
+	PeakUsedMemory int64
+}
+
+// Object describes how a key is stored and used, reported by OBJECT and DEBUG OBJECT.
+type Object struct {
+	// Encoding names the internal representation of the value, such as "int" or "listpack".
+	Encoding string
+	// Idle is how long the key was not accessed.
+	Idle time.Duration
+	// Frequency is the logarithmic access counter used by LFU eviction, between 0 and 255.
+	Frequency int64
+	// QuicklistNodes is the number of nodes of a list stored as a quick list, 0 for other values.
+	QuicklistNodes int64
+}
+
+// MemoryStats describe the memory used by the keys of a single database, reported by MEMORY STATS.
+type MemoryStats struct {
+	// Keys is the number of keys, counting expired keys that were not reclaimed yet.
+	Keys int64
+	// Expires is the number of keys with an expiry.
+	Expires int64
+	// Bytes is the memory accounted for the keys, overhead included.
+	Bytes int64
+	// Overhead is the part of Bytes spent on the keyspace bookkeeping rather than on keys and values.
+	Overhead int64
+}
+
+// Keyspace represent the operations that work on keys regardless of the type of value they hold
+//
+//go:generate sh -c "rm -f mock/keyspace.go && mockgen -source=keyspace.go -destination=mock/keyspace.go -package=mockkeyspace"
+type Keyspace interface {
+	Del(ctx context.Context, keys ...string) (int64, error)
+	Exists(ctx context.Context, keys ...string) (int64, error)
+	Expire(ctx context.Context, key string, at time.Time, condition ExpireCondition) (bool, error)
+	Persist(ctx context.Context, key string) (bool, error)
+	TTL(ctx context.Context, key string) (int64, error)
+	ExpireTime(ctx context.Context, key string) (int64, error)
+	Type(ctx context.Context, key string) (Type, error)
+	Keys(ctx context.Context, pattern string) ([]string, error)
+	Scan(ctx context.Context, cursor uint64, options ScanOptions) (uint64, []string, error)
+	DBSize(ctx context.Context) (int64, error)
+	RandomKey(ctx context.Context) (string, bool, error)
+	Rename(ctx context.Context, key, newKey string, nx bool) (bool, error)
+	Copy(ctx context.Context, source, destination string, replace bool) (bool, error)
+	Flush(ctx context.Context) error
+	Stats() Stats
+	MemoryUsage(ctx context.Context, key string) (int64, bool, error)
+	MemoryStats(ctx context.Context) MemoryStats
+	Object(ctx context.Context, key string) (Object, bool, error)
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// lfuInitValue is the frequency of new keys, so they are not evicted before having a chance to be used.
+	lfuInitValue = 5
+	// lfuLogFactor slows down the growth of the frequency counter, Redis's lfu-log-factor.
+	lfuLogFactor = 10
+	// lfuDecayMinutes is how many minutes without access decrement the counter by one, Redis's lfu-decay-time.
+	lfuDecayMinutes = 1
+)
+
+// access records how recently and how frequently an entry is used, for LRU and LFU eviction.
+// Like Redis, the frequency is a logarithmic counter saturating at 255 which decays as time passes.
+type access struct {
+	// accessed is the Unix time in milliseconds of the last access.
+	accessed int64
+	// frequency is the logarithmic access counter.
+	frequency uint8
+	// frequencyTime is the Unix time in minutes at which frequency was last updated.
+	frequencyTime int64
+}
+
+func newAccess(now time.Time) access {
+	return access{accessed: now.UnixMilli(), frequency: lfuInitValue, frequencyTime: now.Unix() / 60}
+}
+
+// touch records an access at now.
+func (a *access) touch(now time.Time) {
+	a.accessed = now.UnixMilli()
+	a.frequency = lfuLogIncr(a.decayedFrequency(now))
+	a.frequencyTime = now.Unix() / 60
+}
+
+// idle returns how long the entry was not accessed.
+func (a *access) idle(now time.Time) time.Duration {
+	return time.Duration(max(now.UnixMilli()-a.accessed, 0)) * time.Millisecond
+}
+
+// decayedFrequency returns the frequency counter decremented once per lfuDecayMinutes since its last update.
+func (a *access) decayedFrequency(now time.Time) uint8 {
+	periods := (now.Unix()/60 - a.frequencyTime) / lfuDecayMinutes
+	if periods >= int64(a.frequency) {
+		return 0
+	}
+	return a.frequency - uint8(max(periods, 0))
+}
+
+// lfuLogIncr increments counter with a probability shrinking as it grows, so 255 stands for
+// about a million accesses.
+func lfuLogIncr(counter uint8) uint8 {
+	if counter == 255 {
+		return counter
+	}
+	base := max(float64(counter)-lfuInitValue, 0)
+	if rand.Float64() < 1.0/(base*lfuLogFactor+1) {
+		counter++
+	}
+	return counter
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"avacado/internal/storage/dict"
+	"avacado/internal/storage/keyspace"
+	"math"
+	"time"
+)
+
+const (
+	// evictionSamples is how many keys of each keyspace are sampled to refill the pool, Redis's maxmemory-samples.
+	evictionSamples = 5
+	// evictionPoolSize is how many of the best candidates seen so far are kept between samples.
+	evictionPoolSize = 16
+)
+
+// evictionCandidate is a sampled key, the higher its score the better it is to evict.
+type evictionCandidate struct {
+	score    uint64
+	keyspace *Keyspace
+	key      string
+	entry    *Entry
+}
+
+// Evictor removes keys until the memory used by a set of keyspaces fits in maxMemory.
+// Like Redis it approximates LRU, LFU and TTL orders by sampling: each round samples a few keys
+// of every keyspace into a pool of the best candidates seen so far and evicts the best one,
+// so keys that were sampled earlier still compete with the new samples.
+type Evictor struct {
+	maxMemory int64
+	policy    keyspace.EvictionPolicy
+	// pool is sorted by ascending score.
+	pool []evictionCandidate
+	// nextRandom is the keyspace the random policies evict from next.
+	nextRandom int
+}
+
+// NewEvictor creates an evictor keeping memory under maxMemory bytes, 0 meaning no limit.
+func NewEvictor(maxMemory int64, policy keyspace.EvictionPolicy) *Evictor {
+	return &Evictor{maxMemory: maxMemory, policy: policy}
+}
+
+// PerformEvictions evicts keys of keyspaces, which must share their stats, until the memory they
+// use fits in maxMemory. It reports whether it does, false meaning no key could be evicted.
+func (e *Evictor) PerformEvictions(keyspaces []*Keyspace) bool {
+	if e.maxMemory == 0 || len(keyspaces) == 0 {
+		return true
+	}
+	stats := keyspaces[0].stats
+	for stats.UsedMemory > e.maxMemory {
+		if e.policy == keyspace.NoEviction {
+			return false
+		}
+		ks, key, ok := e.candidate(keyspaces)
+		if !ok {
+			return false
+		}
+		ks.Remove(key)
+		stats.EvictedKeys++
+	}
+	return true
+}
+
+// candidate returns the next key to evict according to the policy.
+func (e *Evictor) candidate(keyspaces []*Keyspace) (*Keyspace, string, bool) {
+	switch e.policy {
+	case keyspace.AllKeysRandom, keyspace.VolatileRandom:
+		return e.randomCandidate(keyspaces)
+	default:
+		return e.pooledCandidate(keyspaces)
+	}
+}
+
+func (e *Evictor) randomCandidate(keyspaces []*Keyspace) (*Keyspace, string, bool) {
+	for range keyspaces {
+		ks := keyspaces[e.nextRandom%len(keyspaces)]
+		e.nextRandom++
+		if key, _, ok := e.evictable(ks).RandomKey(); ok {
+			return ks, key, true
+		}
+	}
+	return nil, "", false
+}
+
+func (e *Evictor) pooledCandidate(keyspaces []*Keyspace) (*Keyspace, string, bool) {
+	now := time.Now()
+	for {
+		sampled := 0
+		for _, ks := range keyspaces {
+			e.evictable(ks).Sample(evictionSamples, func(key string, entry *Entry) {
+				sampled++
+				e.offer(evictionCandidate{score: e.score(entry, now), keyspace: ks, key: key, entry: entry})
+			})
+		}
+		if sampled == 0 {
+			return nil, "", false
+		}
+		// Take the best candidate still holding the sampled entry, earlier ones may be gone by now.
+		for len(e.pool) > 0 {
+			best := e.pool[len(e.pool)-1]
+			e.pool = e.pool[:len(e.pool)-1]
+			if entry, ok := best.keyspace.entries.Get(best.key); ok && entry == best.entry {
+				return best.keyspace, best.key, true
+			}
+		}
+	}
+}
+
+// evictable returns the keys of ks the policy may evict.
+func (e *Evictor) evictable(ks *Keyspace) *dict.Dict[*Entry] {
+	if e.policy.Volatile() {
+		return ks.volatile
+	}
+	return ks.entries
+}
+
+// score ranks entry for eviction: the idle time for LRU, the inverse of the frequency for LFU
+// and the inverse of the expiry time for TTL.
+func (e *Evictor) score(entry *Entry, now time.Time) uint64 {
+	switch e.policy {
+	case keyspace.AllKeysLFU, keyspace.VolatileLFU:
+		return uint64(255 - entry.decayedFrequency(now))
+	case keyspace.VolatileTTL:
+		return math.MaxUint64 - uint64(entry.expiry.UnixMilli())
+	default:
+		return uint64(entry.idle(now))
+	}
+}
+
+// offer inserts candidate in the pool unless the pool is full of better candidates.
+func (e *Evictor) offer(candidate evictionCandidate) {
+	if len(e.pool) == evictionPoolSize {
+		if candidate.score <= e.pool[0].score {
+			return
+		}
+		copy(e.pool, e.pool[1:])
+		e.pool = e.pool[:len(e.pool)-1]
+	}
+	i := len(e.pool)
+	for i > 0 && e.pool[i-1].score > candidate.score {
+		i--
+	}
+	e.pool = append(e.pool, evictionCandidate{})
+	copy(e.pool[i+1:], e.pool[i:])
+	e.pool[i] = candidate
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccess_TouchAndDecay(t *testing.T) {
+	now := time.Now()
+	a := newAccess(now.Add(-time.Minute))
+	assert.GreaterOrEqual(t, a.idle(now), time.Minute)
+
+	a.touch(now)
+	assert.Equal(t, time.Duration(0), a.idle(now))
+	assert.GreaterOrEqual(t, a.frequency, uint8(lfuInitValue))
+
+	frequency := a.frequency
+	assert.Equal(t, frequency-3, a.decayedFrequency(now.Add(3*time.Minute)))
+	assert.Equal(t, uint8(0), a.decayedFrequency(now.Add(time.Hour)))
+}
+
+func TestLfuLogIncr(t *testing.T) {
+	counter := uint8(0)
+	for i := 0; i < 1000; i++ {
+		counter = lfuLogIncr(counter)
+	}
+	assert.Greater(t, counter, uint8(lfuInitValue))
+	assert.Less(t, counter, uint8(255))
+	assert.Equal(t, uint8(255), lfuLogIncr(255))
+}
+
+// fill stores count keys of size bytes in ks, the access of key i being i minutes old.
+func fill(ks *Keyspace, prefix string, count int, size int64) {
+	now := time.Now()
+	for i := 0; i < count; i++ {
+		entry := ks.Put(fmt.Sprintf("%s%d", prefix, i), keyspace.TypeString, sizedValue(size))
+		entry.access = newAccess(now.Add(-time.Duration(i) * time.Minute))
+	}
+}
+
+func TestEvictor_NoLimit(t *testing.T) {
+	keyspaces := NewKeyspaces(1)
+	fill(keyspaces[0], "key", 10, 1000)
+
+	assert.True(t, NewEvictor(0, keyspace.NoEviction).PerformEvictions(keyspaces))
+	assert.Equal(t, 10, keyspaces[0].Len())
+}
+
+func TestEvictor_NoEviction(t *testing.T) {
+	keyspaces := NewKeyspaces(1)
+	fill(keyspaces[0], "key", 10, 1000)
+
+	assert.False(t, NewEvictor(1000, keyspace.NoEviction).PerformEvictions(keyspaces))
+	assert.Equal(t, 10, keyspaces[0].Len())
+}
+
+func TestEvictor_EvictsUntilUnderTheLimit(t *testing.T) {
+	for _, policy := range []keyspace.EvictionPolicy{keyspace.AllKeysLRU, keyspace.AllKeysLFU, keyspace.AllKeysRandom} {
+		keyspaces := NewKeyspaces(2)
+		fill(keyspaces[0], "first", 50, 1000)
+		fill(keyspaces[1], "second", 50, 1000)
+		limit := keyspaces[0].UsedMemory()
+
+		assert.True(t, NewEvictor(limit, policy).PerformEvictions(keyspaces), policy)
+		assert.LessOrEqual(t, keyspaces[0].UsedMemory()+keyspaces[1].UsedMemory(), limit, policy)
+		assert.Equal(t, int64(100-keyspaces[0].Len()-keyspaces[1].Len()), keyspaces[0].Stats().EvictedKeys, policy)
+	}
+}
+
+func TestEvictor_AllKeysLRUPrefersIdleKeys(t *testing.T) {
+	keyspaces := NewKeyspaces(1)
+	fill(keyspaces[0], "key", 100, 1000)
+	limit := keyspaces[0].UsedMemory() / 2
+
+	assert.True(t, NewEvictor(limit, keyspace.AllKeysLRU).PerformEvictions(keyspaces))
+	// Sampling only approximates LRU, but the most recently used keys survive.
+	for i := 0; i < 10; i++ {
+		_, ok := keyspaces[0].entries.Get(fmt.Sprintf("key%d", i))
+		assert.True(t, ok, i)
+	}
+}
+
+func TestEvictor_AllKeysLFUPrefersRarelyUsedKeys(t *testing.T) {
+	keyspaces := NewKeyspaces(1)
+	fill(keyspaces[0], "key", 100, 1000)
+	for i := 0; i < 10; i++ {
+		entry, _ := keyspaces[0].entries.Get(fmt.Sprintf("key%d", i))
+		entry.frequency = 200
+	}
+	limit := keyspaces[0].UsedMemory() / 2
+
+	assert.True(t, NewEvictor(limit, keyspace.AllKeysLFU).PerformEvictions(keyspaces))
+	for i := 0; i < 10; i++ {
+		_, ok := keyspaces[0].entries.Get(fmt.Sprintf("key%d", i))
+		assert.True(t, ok, i)
+	}
+}
+
+func TestEvictor_VolatilePoliciesOnlyEvictKeysWithAnExpiry(t *testing.T) {
+	policies := []keyspace.EvictionPolicy{keyspace.VolatileLRU, keyspace.VolatileLFU, keyspace.VolatileRandom, keyspace.VolatileTTL}
+	for _, policy := range policies {
+		keyspaces := NewKeyspaces(1)
+		ks := keyspaces[0]
+		fill(ks, "persistent", 10, 1000)
+		fill(ks, "volatile", 10, 1000)
+		for i := 0; i < 10; i++ {
+			ks.SetExpiry(fmt.Sprintf("volatile%d", i), time.Now().Add(time.Duration(i+1)*time.Hour))
+		}
+		limit := ks.UsedMemory() - 1
+
+		assert.True(t, NewEvictor(limit, policy).PerformEvictions(keyspaces), policy)
+		assert.Equal(t, 19, ks.Len(), policy)
+		assert.Equal(t, 9, ks.volatile.Len(), policy)
+
+		// Evicting every volatile key is not enough.
+		assert.False(t, NewEvictor(1000, policy).PerformEvictions(keyspaces), policy)
+		assert.Equal(t, 10, ks.Len(), policy)
+	}
+}
+
+func TestEvictor_VolatileTTLPrefersKeysCloseToExpiring(t *testing.T) {
+	keyspaces := NewKeyspaces(1)
+	ks := keyspaces[0]
+	// Few enough keys for a single sample to see all of them.
+	fill(ks, "key", evictionSamples, 1000)
+	for i := 0; i < evictionSamples; i++ {
+		ks.SetExpiry(fmt.Sprintf("key%d", i), time.Now().Add(time.Duration(i+1)*time.Hour))
+	}
+	limit := ks.UsedMemory() - 1
+
+	assert.True(t, NewEvictor(limit, keyspace.VolatileTTL).PerformEvictions(keyspaces))
+	_, ok := ks.entries.Get("key0")
+	assert.False(t, ok)
+}
@@ -0,0 +1,133 @@
+package memory
+
+import "time"
+
+const (
+	// activeExpireKeysPerLoop is the number of volatile keys sampled per iteration of the cycle.
+	activeExpireKeysPerLoop = 20
+	// activeExpireAcceptableStale is the percentage of expired keys in a sample below which
+	// the cycle stops, as more iterations would mostly find live keys.
+	activeExpireAcceptableStale = 10
+	// activeExpireTimeCheckInterval is how many iterations run between two checks of the time limit.
+	activeExpireTimeCheckInterval = 16
+	// activeExpireBucketsPerKey bounds, per key to sample, how many buckets a sample may visit.
+	activeExpireBucketsPerKey = 20
+	// activeExpireFieldsPerKey bounds how many expired fields are removed from a single key per
+	// sample, so a huge hash cannot stall the cycle on its own.
+	activeExpireFieldsPerKey = 50
+)
+
+// ActiveExpireCycle runs the active expire cycle over this keyspace alone.
+func (k *Keyspace) ActiveExpireCycle(timeLimit time.Duration) {
+	ActiveExpireCycle([]*Keyspace{k}, 0, timeLimit)
+}
+
+// ActiveExpireCycle reclaims expired keys that are never read again, following Redis's
+// activeExpireCycle: for each keyspace it samples keys with an expiry, deletes the expired ones
+// and repeats while more than activeExpireAcceptableStale percent of the sample had expired.
+// Keys whose fields carry an expiry are then sampled the same way to reclaim their expired fields.
+// The keyspaces must share their stats. The cycle starts with keyspaces[first] and stops once it
+// has run for timeLimit so the executor is never stalled. It returns the index of the keyspace
+// the next cycle should start with, so a keyspace interrupted by the time limit is resumed first.
+func ActiveExpireCycle(keyspaces []*Keyspace, first int, timeLimit time.Duration) int {
+	start := time.Now()
+	stats := keyspaces[0].stats
+	var totalSampled, totalExpired int64
+	timeLimitReached := false
+
+	current := first
+	for visited := 0; visited < len(keyspaces) && !timeLimitReached; visited++ {
+		k := keyspaces[current]
+		var sampled, expired int64
+		sampled, expired, timeLimitReached = sampleWhileStale(k.expireSample, start, timeLimit)
+		totalSampled += sampled
+		totalExpired += expired
+		if !timeLimitReached {
+			_, _, timeLimitReached = sampleWhileStale(k.expireFieldsSample, start, timeLimit)
+		}
+		if !timeLimitReached {
+			current = (current + 1) % len(keyspaces)
+		}
+	}
+
+	elapsed := time.Since(start)
+	stats.ExpireCycleTime += elapsed
+	if timeLimitReached {
+		stats.ExpiredTimeCapReachedCount++
+	}
+	// Like Redis, smooth the stale estimate over cycles instead of reporting the last sample only.
+	stalePerc := 0.0
+	if totalSampled > 0 {
+		stalePerc = float64(totalExpired) * 100 / float64(totalSampled)
+	}
+	stats.ExpiredStalePerc = stalePerc*0.05 + stats.ExpiredStalePerc*0.95
+	return current
+}
+
+// sampleWhileStale takes samples until one finds few stale keys or the cycle started at start
+// runs out of time. It returns the keys sampled and found stale overall, and whether the time
+// limit was reached.
+func sampleWhileStale(sample func(count int) (sampled, stale int64), start time.Time, timeLimit time.Duration) (sampled, stale int64, timeLimitReached bool) {
+	for iteration := 1; ; iteration++ {
+		n, s := sample(activeExpireKeysPerLoop)
+		sampled += n
+		stale += s
+
+		if iteration%activeExpireTimeCheckInterval == 0 && time.Since(start) > timeLimit {
+			return sampled, stale, true
+		}
+		if n == 0 || s*100/n <= activeExpireAcceptableStale {
+			return sampled, stale, false
+		}
+	}
+}
+
+// expireSample checks up to count keys with an expiry and removes the expired ones. Like Redis,
+// it resumes scanning the volatile keys where the previous sample stopped rather than sampling
+// at random, so every key is eventually checked even once deletions left the table sparse.
+func (k *Keyspace) expireSample(count int) (sampled, expired int64) {
+	now := time.Now()
+	var expiredKeys []string
+	for buckets := 0; sampled < int64(count) && buckets < count*activeExpireBucketsPerKey; buckets++ {
+		k.expireCursor = k.volatile.Scan(k.expireCursor, func(key string, entry *Entry) {
+			sampled++
+			if entry.isExpiredAt(now) {
+				expiredKeys = append(expiredKeys, key)
+			}
+		})
+		if k.expireCursor == 0 {
+			// A whole pass is done, going on would check the same keys again.
+			break
+		}
+	}
+	for _, key := range expiredKeys {
+		k.Remove(key)
+	}
+	expired = int64(len(expiredKeys))
+	k.stats.ExpiredKeys += expired
+	return sampled, expired
+}
+
+// expireFieldsSample checks up to count keys holding fields with an expiry, resuming the scan of
+// volatileFields where the previous sample stopped, and reclaims their expired fields. It returns
+// how many keys were checked and how many had expired fields.
+func (k *Keyspace) expireFieldsSample(count int) (sampled, stale int64) {
+	now := time.Now()
+	var keys []string
+	var entries []*Entry
+	for buckets := 0; len(keys) < count && buckets < count*activeExpireBucketsPerKey; buckets++ {
+		k.fieldExpireCursor = k.volatileFields.Scan(k.fieldExpireCursor, func(key string, entry *Entry) {
+			keys = append(keys, key)
+			entries = append(entries, entry)
+		})
+		if k.fieldExpireCursor == 0 {
+			break
+		}
+	}
+	for i, key := range keys {
+		if expired, _ := k.expireFields(key, entries[i], now, activeExpireFieldsPerKey); expired > 0 {
+			stale++
+		}
+	}
+	return int64(len(keys)), stale
+}
@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyspace_ActiveExpireCycleReclaimsExpiredKeys(t *testing.T) {
+	ks := NewKeyspace()
+	pastTime := time.Now().Add(-time.Second)
+	futureTime := time.Now().Add(time.Hour)
+	for i := 0; i < 100; i++ {
+		ks.Put(fmt.Sprintf("expired%d", i), keyspace.TypeString, "value")
+		ks.SetExpiry(fmt.Sprintf("expired%d", i), pastTime)
+	}
+	ks.Put("volatile", keyspace.TypeString, "value")
+	ks.SetExpiry("volatile", futureTime)
+	ks.Put("persistent", keyspace.TypeString, "value")
+
+	ks.ActiveExpireCycle(time.Second)
+
+	assert.Equal(t, 2, ks.Len())
+	assert.Equal(t, int64(100), ks.Stats().ExpiredKeys)
+	assert.Greater(t, ks.Stats().ExpiredStalePerc, 0.0)
+	_, ok := ks.Lookup("volatile")
+	assert.True(t, ok)
+}
+
+func TestKeyspace_ActiveExpireCycleStopsWhenFewKeysAreStale(t *testing.T) {
+	ks := NewKeyspace()
+	futureTime := time.Now().Add(time.Hour)
+	for i := 0; i < 100; i++ {
+		ks.Put(fmt.Sprintf("volatile%d", i), keyspace.TypeString, "value")
+		ks.SetExpiry(fmt.Sprintf("volatile%d", i), futureTime)
+	}
+	ks.Put("expired", keyspace.TypeString, "value")
+	ks.SetExpiry("expired", time.Now().Add(-time.Second))
+
+	ks.ActiveExpireCycle(time.Second)
+
+	assert.LessOrEqual(t, ks.Stats().ExpiredKeys, int64(1))
+	assert.GreaterOrEqual(t, ks.Len(), 100)
+}
+
+func TestKeyspace_ActiveExpireCycleWithoutVolatileKeys(t *testing.T) {
+	ks := NewKeyspace()
+	ks.Put("persistent", keyspace.TypeString, "value")
+
+	ks.ActiveExpireCycle(time.Second)
+
+	assert.Equal(t, 1, ks.Len())
+	assert.Equal(t, int64(0), ks.Stats().ExpiredKeys)
+	assert.Equal(t, 0.0, ks.Stats().ExpiredStalePerc)
+}
+
+func TestKeyspace_LazyExpirationIsCounted(t *testing.T) {
+	ks := NewKeyspace()
+	ks.Put("expired", keyspace.TypeString, "value")
+	ks.SetExpiry("expired", time.Now().Add(-time.Second))
+
+	_, ok := ks.Lookup("expired")
+
+	assert.False(t, ok)
+	assert.Equal(t, int64(1), ks.Stats().ExpiredKeys)
+}
+
+func TestActiveExpireCycle_CoversEveryKeyspaceAndSharesStats(t *testing.T) {
+	keyspaces := NewKeyspaces(3)
+	pastTime := time.Now().Add(-time.Second)
+	for i, ks := range keyspaces {
+		key := fmt.Sprintf("expired%d", i)
+		ks.Put(key, keyspace.TypeString, "value")
+		ks.SetExpiry(key, pastTime)
+	}
+
+	next := ActiveExpireCycle(keyspaces, 1, time.Second)
+
+	assert.Equal(t, 1, next)
+	for _, ks := range keyspaces {
+		assert.Equal(t, 0, ks.Len())
+		assert.Equal(t, int64(3), ks.Stats().ExpiredKeys)
+	}
+}
+
+// expiringFields is a value whose fields expire at the given times.
+type expiringFields map[string]time.Time
+
+func (f expiringFields) HasVolatileFields() bool {
+	return len(f) > 0
+}
+
+func (f expiringFields) ExpireFields(now time.Time, limit int) (int, bool) {
+	expired := 0
+	for field, at := range f {
+		if now.After(at) && (limit < 0 || expired < limit) {
+			delete(f, field)
+			expired++
+		}
+	}
+	return expired, len(f) == 0
+}
+
+func TestKeyspace_LookupReclaimsExpiredFields(t *testing.T) {
+	ks := NewKeyspace()
+	pastTime := time.Now().Add(-time.Second)
+	fields := expiringFields{"expired": pastTime, "live": time.Now().Add(time.Hour)}
+	ks.Put("hash", keyspace.TypeHash, fields)
+	ks.Put("emptied", keyspace.TypeHash, expiringFields{"expired": pastTime})
+
+	_, ok := ks.Lookup("hash")
+	assert.True(t, ok)
+	assert.Equal(t, expiringFields{"live": fields["live"]}, fields)
+
+	_, ok = ks.Lookup("emptied")
+	assert.False(t, ok)
+	assert.Equal(t, 1, ks.Len())
+	assert.Equal(t, int64(2), ks.Stats().ExpiredSubkeys)
+	assert.Equal(t, int64(0), ks.Stats().ExpiredKeys)
+}
+
+func TestKeyspace_ActiveExpireCycleReclaimsExpiredFields(t *testing.T) {
+	ks := NewKeyspace()
+	pastTime := time.Now().Add(-time.Second)
+	for i := 0; i < 100; i++ {
+		ks.Put(fmt.Sprintf("hash%d", i), keyspace.TypeHash, expiringFields{"expired": pastTime})
+	}
+	fields := expiringFields{"live": time.Now().Add(time.Hour)}
+	ks.Put("live", keyspace.TypeHash, fields)
+	ks.Put("persistent", keyspace.TypeHash, expiringFields{})
+
+	ks.ActiveExpireCycle(time.Second)
+
+	assert.Equal(t, 2, ks.Len())
+	assert.Equal(t, int64(100), ks.Stats().ExpiredSubkeys)
+	assert.Equal(t, 1, ks.volatileFields.Len())
+
+	delete(fields, "live")
+	ks.Resized("live")
+	assert.Equal(t, 0, ks.volatileFields.Len())
+}
+
+func TestKeyspace_VolatileFieldsFollowTheKey(t *testing.T) {
+	ks := NewKeyspace()
+	ks.Put("hash", keyspace.TypeHash, expiringFields{"field": time.Now().Add(time.Hour)})
+
+	_, _ = ks.Rename(context.Background(), "hash", "renamed", false)
+	_, ok := ks.volatileFields.Get("renamed")
+	assert.True(t, ok)
+	assert.Equal(t, 1, ks.volatileFields.Len())
+
+	ks.Put("renamed", keyspace.TypeString, "value")
+	assert.Equal(t, 0, ks.volatileFields.Len())
+}
@@ -0,0 +1,566 @@
+package memory
+
+import (
+	"avacado/internal/storage/dict"
+	"avacado/internal/storage/glob"
+	"avacado/internal/storage/keyspace"
+	"context"
+	"math"
+	"time"
+)
+
+// entryOverhead approximates the bytes used by an Entry and the dict entry holding it.
+const entryOverhead = 112
+
+// Entry is a key of the keyspace together with the value it holds.
+// Value is owned by the typed store matching Type, which is the only one allowed to cast it.
+type Entry struct {
+	Type   keyspace.Type
+	Value  any
+	expiry *time.Time
+	// size is the memory accounted for the entry, its key included.
+	size int64
+	// volatileFields tells whether the entry is tracked as holding fields with an expiry.
+	volatileFields bool
+	access
+}
+
+// Expiry returns the time at which the entry expires, nil if it never does.
+func (e *Entry) Expiry() *time.Time {
+	return e.expiry
+}
+
+func (e *Entry) isExpiredAt(now time.Time) bool {
+	if e.expiry == nil {
+		return false
+	}
+	return now.After(*e.expiry)
+}
+
+// Cloner is implemented by the values stored in the keyspace so COPY can duplicate them.
+// The clone must share no mutable memory with the original.
+type Cloner interface {
+	Clone() any
+}
+
+// Sizer is implemented by the values stored in the keyspace so the memory they use can be
+// accounted against maxmemory. The size approximates the bytes allocated for the value.
+type Sizer interface {
+	MemoryUsage() int64
+}
+
+// Encoder is implemented by the values stored in the keyspace to name their internal
+// representation, reported by OBJECT ENCODING.
+type Encoder interface {
+	Encoding() string
+}
+
+// NodeCounter is implemented by the values stored as a list of nodes, such as quick lists, to
+// report their number of nodes.
+type NodeCounter interface {
+	Nodes() int
+}
+
+// FieldExpirer is implemented by the values whose fields can expire on their own, such as hashes
+// with field TTLs. The keyspace reclaims their expired fields when the key is looked up and during
+// the active expire cycle, and removes the key once no field is left.
+type FieldExpirer interface {
+	// HasVolatileFields reports whether any field carries an expiry.
+	HasVolatileFields() bool
+	// ExpireFields removes up to limit fields expired at now, every one of them if limit is
+	// negative. It returns how many were removed and whether no field is left.
+	ExpireFields(now time.Time, limit int) (expired int, empty bool)
+}
+
+func sizeOf(key string, entry *Entry) int64 {
+	size := int64(len(key)) + entryOverhead
+	if sizer, ok := entry.Value.(Sizer); ok {
+		size += sizer.MemoryUsage()
+	}
+	return size
+}
+
+// Keyspace is the single dictionary of keys shared by every typed store, so a key
+// can only ever hold one value of one type.
+// Keys carrying an expiry are also tracked in volatile, which the active expire cycle scans, and
+// keys holding fields with an expiry in volatileFields.
+// used is the memory accounted for every entry, kept up to date as entries are stored, removed
+// and resized. The keyspaces of the logical databases share their stats, which are server wide.
+// All methods are called exclusively by the executor goroutine — no locking needed.
+type Keyspace struct {
+	entries  *dict.Dict[*Entry]
+	volatile *dict.Dict[*Entry]
+	// expireCursor is where the active expire cycle resumes scanning volatile.
+	expireCursor   uint64
+	volatileFields *dict.Dict[*Entry]
+	// fieldExpireCursor is where the active expire cycle resumes scanning volatileFields.
+	fieldExpireCursor uint64
+	used              int64
+	stats             *keyspace.Stats
+}
+
+func NewKeyspace() *Keyspace {
+	return newKeyspace(&keyspace.Stats{})
+}
+
+// NewKeyspaces creates the keyspaces of count logical databases, sharing their expire stats.
+func NewKeyspaces(count int) []*Keyspace {
+	stats := &keyspace.Stats{}
+	keyspaces := make([]*Keyspace, count)
+	for i := range keyspaces {
+		keyspaces[i] = newKeyspace(stats)
+	}
+	return keyspaces
+}
+
+func newKeyspace(stats *keyspace.Stats) *Keyspace {
+	return &Keyspace{
+		entries:        dict.New[*Entry](),
+		volatile:       dict.New[*Entry](),
+		volatileFields: dict.New[*Entry](),
+		stats:          stats,
+	}
+}
+
+// Lookup returns the entry stored at key and records the access for LRU and LFU eviction.
+// Expired entries are removed lazily and reported as missing.
+func (k *Keyspace) Lookup(key string) (*Entry, bool) {
+	now := time.Now()
+	entry, ok := k.find(key, now)
+	if ok {
+		entry.touch(now)
+	}
+	return entry, ok
+}
+
+// find is Lookup without recording an access, for the commands that only inspect a key.
+// The expired fields of the value are reclaimed too, a value left without fields being missing.
+func (k *Keyspace) find(key string, now time.Time) (*Entry, bool) {
+	entry, ok := k.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if entry.isExpiredAt(now) {
+		k.Remove(key)
+		k.stats.ExpiredKeys++
+		return nil, false
+	}
+	if entry.volatileFields {
+		if _, removed := k.expireFields(key, entry, now, -1); removed {
+			return nil, false
+		}
+	}
+	return entry, true
+}
+
+// expireFields removes up to limit fields of the value of entry expired at now, every one of them
+// if limit is negative, and removes key once no field is left. It returns how many fields were
+// removed and whether key was.
+func (k *Keyspace) expireFields(key string, entry *Entry, now time.Time, limit int) (int, bool) {
+	expired, empty := entry.Value.(FieldExpirer).ExpireFields(now, limit)
+	k.stats.ExpiredSubkeys += int64(expired)
+	if empty {
+		k.Remove(key)
+		return expired, true
+	}
+	if expired > 0 {
+		k.Resized(key)
+	}
+	return expired, false
+}
+
+// LookupOfType returns the entry stored at key if it holds a value of the given type.
+// A missing key yields a nil entry and no error, a key of another type yields ErrWrongType.
+func (k *Keyspace) LookupOfType(key string, t keyspace.Type) (*Entry, error) {
+	entry, ok := k.Lookup(key)
+	if !ok {
+		return nil, nil
+	}
+	if entry.Type != t {
+		return nil, keyspace.ErrWrongType
+	}
+	return entry, nil
+}
+
+// Put stores value at key, replacing whatever the key held before along with its expiry.
+func (k *Keyspace) Put(key string, t keyspace.Type, value any) *Entry {
+	entry := &Entry{Type: t, Value: value, access: newAccess(time.Now())}
+	k.place(key, entry)
+	return entry
+}
+
+// place stores entry at key, keeping volatile in line with the expiry of entry and
+// accounting for the memory of entry in place of the one it replaces.
+func (k *Keyspace) place(key string, entry *Entry) {
+	if old, ok := k.entries.Get(key); ok {
+		k.account(-old.size)
+		if old.volatileFields {
+			k.volatileFields.Delete(key)
+		}
+	}
+	entry.size = sizeOf(key, entry)
+	k.account(entry.size)
+	k.entries.Set(key, entry)
+	if entry.expiry != nil {
+		k.volatile.Set(key, entry)
+	} else {
+		k.volatile.Delete(key)
+	}
+	entry.volatileFields = false
+	k.trackFields(key, entry)
+}
+
+// trackFields keeps volatileFields in line with whether the value of entry has fields with an expiry.
+func (k *Keyspace) trackFields(key string, entry *Entry) {
+	expirer, ok := entry.Value.(FieldExpirer)
+	volatile := ok && expirer.HasVolatileFields()
+	if volatile == entry.volatileFields {
+		return
+	}
+	entry.volatileFields = volatile
+	if volatile {
+		k.volatileFields.Set(key, entry)
+	} else {
+		k.volatileFields.Delete(key)
+	}
+}
+
+// SetExpiry makes the live key expire at the given time. It reports whether key exists.
+func (k *Keyspace) SetExpiry(key string, at time.Time) bool {
+	entry, ok := k.Lookup(key)
+	if !ok {
+		return false
+	}
+	entry.expiry = &at
+	k.volatile.Set(key, entry)
+	return true
+}
+
+// Remove deletes key from the keyspace.
+func (k *Keyspace) Remove(key string) {
+	if entry, ok := k.entries.Delete(key); ok {
+		k.account(-entry.size)
+		if entry.volatileFields {
+			k.volatileFields.Delete(key)
+		}
+	}
+	k.volatile.Delete(key)
+}
+
+// Resized accounts again for the memory of the value stored at key, and tracks whether it now
+// has fields with an expiry. Stores call it after modifying a value in place; nothing happens if
+// key was removed meanwhile.
+func (k *Keyspace) Resized(key string) {
+	entry, ok := k.entries.Get(key)
+	if !ok {
+		return
+	}
+	size := sizeOf(key, entry)
+	k.account(size - entry.size)
+	entry.size = size
+	k.trackFields(key, entry)
+}
+
+// account adds delta bytes to the memory used by the keyspace and by the whole server.
+func (k *Keyspace) account(delta int64) {
+	k.used += delta
+	k.stats.UsedMemory += delta
+	k.stats.PeakUsedMemory = max(k.stats.PeakUsedMemory, k.stats.UsedMemory)
+}
+
+// UsedMemory returns the memory accounted for every entry of the keyspace, in bytes.
+func (k *Keyspace) UsedMemory() int64 {
+	return k.used
+}
+
+// MemoryUsage returns the bytes accounted for key and its value, and false if key does not exist.
+// Sizes are kept up to date as values change, so unlike Redis no sampling is needed.
+func (k *Keyspace) MemoryUsage(_ context.Context, key string) (int64, bool, error) {
+	entry, ok := k.find(key, time.Now())
+	if !ok {
+		return 0, false, nil
+	}
+	return entry.size, true, nil
+}
+
+// Object returns the encoding, idle time and access frequency of key without recording an
+// access, and false if key does not exist. Both LRU and LFU information are tracked whatever
+// the eviction policy, so unlike Redis both are always reported.
+func (k *Keyspace) Object(_ context.Context, key string) (keyspace.Object, bool, error) {
+	now := time.Now()
+	entry, ok := k.find(key, now)
+	if !ok {
+		return keyspace.Object{}, false, nil
+	}
+	object := keyspace.Object{Idle: entry.idle(now), Frequency: int64(entry.decayedFrequency(now))}
+	if encoder, ok := entry.Value.(Encoder); ok {
+		object.Encoding = encoder.Encoding()
+	}
+	if counter, ok := entry.Value.(NodeCounter); ok {
+		object.QuicklistNodes = int64(counter.Nodes())
+	}
+	return object, true, nil
+}
+
+// MemoryStats returns the memory used by the keyspace.
+func (k *Keyspace) MemoryStats(_ context.Context) keyspace.MemoryStats {
+	keys := int64(k.entries.Len())
+	return keyspace.MemoryStats{
+		Keys:     keys,
+		Expires:  int64(k.volatile.Len()),
+		Bytes:    k.used,
+		Overhead: keys * entryOverhead,
+	}
+}
+
+// Len returns the number of keys in the keyspace, counting expired keys that were not reclaimed yet.
+func (k *Keyspace) Len() int {
+	return k.entries.Len()
+}
+
+// Del removes the given keys whatever their type and returns the number of keys removed.
+func (k *Keyspace) Del(_ context.Context, keys ...string) (int64, error) {
+	var deletedCount int64
+	for _, key := range keys {
+		if _, ok := k.Lookup(key); ok {
+			k.Remove(key)
+			deletedCount++
+		}
+	}
+	return deletedCount, nil
+}
+
+// Exists returns how many of the given keys exist. Keys mentioned multiple times are counted multiple times.
+func (k *Keyspace) Exists(_ context.Context, keys ...string) (int64, error) {
+	var existsCount int64
+	now := time.Now()
+	for _, key := range keys {
+		if _, ok := k.find(key, now); ok {
+			existsCount++
+		}
+	}
+	return existsCount, nil
+}
+
+// Expire makes key expire at the given time if condition allows it, and reports whether the expiry was changed.
+// An expiry in the past deletes the key right away.
+func (k *Keyspace) Expire(_ context.Context, key string, at time.Time, condition keyspace.ExpireCondition) (bool, error) {
+	entry, ok := k.Lookup(key)
+	if !ok {
+		return false, nil
+	}
+	if !condition.Holds(entry.expiry, at) {
+		return false, nil
+	}
+	if !at.After(time.Now()) {
+		k.Remove(key)
+		return true, nil
+	}
+	k.SetExpiry(key, at)
+	return true, nil
+}
+
+// Persist removes the expiry of key and reports whether key had one.
+func (k *Keyspace) Persist(_ context.Context, key string) (bool, error) {
+	entry, ok := k.Lookup(key)
+	if !ok || entry.expiry == nil {
+		return false, nil
+	}
+	entry.expiry = nil
+	k.volatile.Delete(key)
+	return true, nil
+}
+
+// TTL returns the time to live of key in milliseconds, -1 if key has no expiry and -2 if key does not exist.
+func (k *Keyspace) TTL(_ context.Context, key string) (int64, error) {
+	entry, ok := k.find(key, time.Now())
+	if !ok {
+		return -2, nil
+	}
+	if entry.expiry == nil {
+		return -1, nil
+	}
+	return max(entry.expiry.UnixMilli()-time.Now().UnixMilli(), 0), nil
+}
+
+// ExpireTime returns the Unix time in milliseconds at which key expires,
+// -1 if key has no expiry and -2 if key does not exist.
+func (k *Keyspace) ExpireTime(_ context.Context, key string) (int64, error) {
+	entry, ok := k.find(key, time.Now())
+	if !ok {
+		return -2, nil
+	}
+	if entry.expiry == nil {
+		return -1, nil
+	}
+	return entry.expiry.UnixMilli(), nil
+}
+
+// Stats returns the counters of keys reclaimed lazily, by the active expire cycle and by eviction.
+func (k *Keyspace) Stats() keyspace.Stats {
+	return *k.stats
+}
+
+// Type returns the type of the value stored at key, TypeNone if key does not exist.
+func (k *Keyspace) Type(_ context.Context, key string) (keyspace.Type, error) {
+	entry, ok := k.find(key, time.Now())
+	if !ok {
+		return keyspace.TypeNone, nil
+	}
+	return entry.Type, nil
+}
+
+// Keys returns every live key matching the glob-style pattern.
+func (k *Keyspace) Keys(_ context.Context, pattern string) ([]string, error) {
+	now := time.Now()
+	keys := make([]string, 0)
+	k.entries.Range(func(key string, entry *Entry) bool {
+		if !entry.isExpiredAt(now) && glob.Match(pattern, key) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	return keys, nil
+}
+
+// Scan visits buckets of the keyspace from cursor until about options.Count keys were seen, and
+// returns the live ones passing the filters of options along with the cursor to resume from,
+// 0 once the iteration is complete. Like Redis, a sparse keyspace stops the call after
+// 10*Count buckets so it stays short even when few keys are found, and at least one bucket is
+// always visited whatever the count.
+func (k *Keyspace) Scan(_ context.Context, cursor uint64, options keyspace.ScanOptions) (uint64, []string, error) {
+	now := time.Now()
+	keys := make([]string, 0)
+	visited := 0
+	maxIterations := math.MaxInt
+	if options.Count < math.MaxInt/10 {
+		maxIterations = options.Count * 10
+	}
+	for {
+		cursor = k.entries.Scan(cursor, func(key string, entry *Entry) {
+			visited++
+			if scanAccepts(key, entry, now, options) {
+				keys = append(keys, key)
+			}
+		})
+		maxIterations--
+		if cursor == 0 || visited >= options.Count || maxIterations <= 0 {
+			break
+		}
+	}
+	return cursor, keys, nil
+}
+
+func scanAccepts(key string, entry *Entry, now time.Time, options keyspace.ScanOptions) bool {
+	if entry.isExpiredAt(now) {
+		return false
+	}
+	if options.Type != "" && entry.Type != options.Type {
+		return false
+	}
+	return options.Match == "" || glob.Match(options.Match, key)
+}
+
+// DBSize returns the number of live keys. Only keys with an expiry have to be checked,
+// so the cost is bounded by the volatile keys rather than the whole keyspace.
+func (k *Keyspace) DBSize(_ context.Context) (int64, error) {
+	now := time.Now()
+	size := int64(k.entries.Len())
+	k.volatile.Range(func(_ string, entry *Entry) bool {
+		if entry.isExpiredAt(now) {
+			size--
+		}
+		return true
+	})
+	return size, nil
+}
+
+// RandomKey returns a live key picked at random, and false if the keyspace is empty.
+// Expired keys met on the way are reclaimed.
+func (k *Keyspace) RandomKey(_ context.Context) (string, bool, error) {
+	for {
+		key, entry, ok := k.entries.RandomKey()
+		if !ok {
+			return "", false, nil
+		}
+		if !entry.isExpiredAt(time.Now()) {
+			return key, true, nil
+		}
+		k.Remove(key)
+		k.stats.ExpiredKeys++
+	}
+}
+
+// Rename moves the value and expiry of key to newKey, overwriting newKey unless nx is set.
+// It reports whether key was renamed and returns ErrNoSuchKey if key does not exist.
+func (k *Keyspace) Rename(_ context.Context, key, newKey string, nx bool) (bool, error) {
+	entry, ok := k.Lookup(key)
+	if !ok {
+		return false, keyspace.ErrNoSuchKey
+	}
+	if nx {
+		if _, exists := k.find(newKey, time.Now()); exists {
+			return false, nil
+		}
+	}
+	if key == newKey {
+		return true, nil
+	}
+	k.Remove(key)
+	k.place(newKey, entry)
+	return true, nil
+}
+
+// Copy stores a deep copy of the value and expiry of source at destination, overwriting
+// destination only if replace is set. It reports whether the copy was made.
+func (k *Keyspace) Copy(_ context.Context, source, destination string, replace bool) (bool, error) {
+	return k.CopyTo(source, k, destination, replace)
+}
+
+// CopyTo is Copy with the destination key living in the keyspace to, which may be another database.
+func (k *Keyspace) CopyTo(source string, to *Keyspace, destination string, replace bool) (bool, error) {
+	if k == to && source == destination {
+		return false, keyspace.ErrSameObject
+	}
+	entry, ok := k.Lookup(source)
+	if !ok {
+		return false, nil
+	}
+	if _, exists := to.find(destination, time.Now()); exists && !replace {
+		return false, nil
+	}
+	clone := &Entry{Type: entry.Type, Value: entry.Value.(Cloner).Clone(), access: newAccess(time.Now())}
+	if entry.expiry != nil {
+		at := *entry.expiry
+		clone.expiry = &at
+	}
+	to.place(destination, clone)
+	return true, nil
+}
+
+// MoveTo moves key with its value and expiry to the keyspace to, unless to already holds key.
+// It reports whether key was moved.
+func (k *Keyspace) MoveTo(key string, to *Keyspace) bool {
+	entry, ok := k.Lookup(key)
+	if !ok {
+		return false
+	}
+	if _, exists := to.find(key, time.Now()); exists {
+		return false
+	}
+	k.Remove(key)
+	to.place(key, entry)
+	return true
+}
+
+// Flush removes every key. The old tables are left to the garbage collector, so flushing
+// never stalls the executor however many keys there were.
+func (k *Keyspace) Flush(_ context.Context) error {
+	k.entries = dict.New[*Entry]()
+	k.volatile = dict.New[*Entry]()
+	k.expireCursor = 0
+	k.volatileFields = dict.New[*Entry]()
+	k.fieldExpireCursor = 0
+	k.account(-k.used)
+	return nil
+}
@@ -0,0 +1,543 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyspace_LookupOfType(t *testing.T) {
+	ks := NewKeyspace()
+	ks.Put("str", keyspace.TypeString, "value")
+
+	entry, err := ks.LookupOfType("missing", keyspace.TypeString)
+	assert.NoError(t, err)
+	assert.Nil(t, entry)
+
+	entry, err = ks.LookupOfType("str", keyspace.TypeString)
+	assert.NoError(t, err)
+	assert.Equal(t, "value", entry.Value)
+
+	entry, err = ks.LookupOfType("str", keyspace.TypeList)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	assert.Nil(t, entry)
+}
+
+func TestKeyspace_LookupRemovesExpiredKey(t *testing.T) {
+	ks := NewKeyspace()
+	pastTime := time.Now().Add(-2 * time.Second)
+	ks.Put("expiring", keyspace.TypeHash, "value")
+	ks.SetExpiry("expiring", pastTime)
+
+	_, ok := ks.Lookup("expiring")
+	assert.False(t, ok)
+	assert.Equal(t, 0, ks.Len())
+}
+
+func TestKeyspace_PutReplacesValueOfAnyType(t *testing.T) {
+	ks := NewKeyspace()
+	futureTime := time.Now().Add(10 * time.Second)
+	ks.Put("key", keyspace.TypeList, "list")
+	ks.SetExpiry("key", futureTime)
+
+	ks.Put("key", keyspace.TypeString, "string")
+
+	entry, ok := ks.Lookup("key")
+	assert.True(t, ok)
+	assert.Equal(t, keyspace.TypeString, entry.Type)
+	assert.Nil(t, entry.Expiry())
+}
+
+func TestKeyspace_Del(t *testing.T) {
+	ks := NewKeyspace()
+	ctx := context.Background()
+
+	count, err := ks.Del(ctx, "nonexistent")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	ks.Put("key1", keyspace.TypeString, "value1")
+	ks.Put("key2", keyspace.TypeList, "value2")
+	ks.Put("key3", keyspace.TypeHash, "value3")
+
+	count, err = ks.Del(ctx, "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	_, ok := ks.Lookup("key1")
+	assert.False(t, ok)
+
+	count, err = ks.Del(ctx, "key2", "key3")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, 0, ks.Len())
+
+	ks.Put("key4", keyspace.TypeString, "value4")
+	count, err = ks.Del(ctx, "key4", "nonexistent", "alsonothere")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	pastTime := time.Now().Add(-2 * time.Second)
+	ks.Put("expiring", keyspace.TypeString, "value")
+	ks.SetExpiry("expiring", pastTime)
+	count, err = ks.Del(ctx, "expiring")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestKeyspace_Exists(t *testing.T) {
+	ks := NewKeyspace()
+	ctx := context.Background()
+
+	count, err := ks.Exists(ctx, "nonexistent")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	ks.Put("key1", keyspace.TypeString, "value1")
+	ks.Put("key2", keyspace.TypeList, "value2")
+	ks.Put("key3", keyspace.TypeHash, "value3")
+
+	count, err = ks.Exists(ctx, "key1", "key2", "key3")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = ks.Exists(ctx, "key1", "nonexistent", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	pastTime := time.Now().Add(-2 * time.Second)
+	ks.Put("expiring", keyspace.TypeString, "value")
+	ks.SetExpiry("expiring", pastTime)
+	count, err = ks.Exists(ctx, "expiring")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	count, err = ks.Exists(ctx, "key1", "key1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestKeyspace_Expire(t *testing.T) {
+	ks := NewKeyspace()
+	ctx := context.Background()
+	soon := time.Now().Add(10 * time.Second)
+	later := time.Now().Add(20 * time.Second)
+
+	changed, err := ks.Expire(ctx, "missing", soon, keyspace.ExpireAlways)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	ks.Put("key", keyspace.TypeList, "list")
+	changed, _ = ks.Expire(ctx, "key", soon, keyspace.ExpireXX)
+	assert.False(t, changed)
+	changed, _ = ks.Expire(ctx, "key", soon, keyspace.ExpireGT)
+	assert.False(t, changed, "a persistent key has an infinite TTL")
+	changed, _ = ks.Expire(ctx, "key", later, keyspace.ExpireLT)
+	assert.True(t, changed)
+	changed, _ = ks.Expire(ctx, "key", soon, keyspace.ExpireNX)
+	assert.False(t, changed)
+	changed, _ = ks.Expire(ctx, "key", later.Add(time.Second), keyspace.ExpireXX|keyspace.ExpireLT)
+	assert.False(t, changed)
+	changed, _ = ks.Expire(ctx, "key", soon, keyspace.ExpireXX|keyspace.ExpireLT)
+	assert.True(t, changed)
+
+	expireTime, _ := ks.ExpireTime(ctx, "key")
+	assert.Equal(t, soon.UnixMilli(), expireTime)
+}
+
+func TestKeyspace_ExpireInThePastDeletesKey(t *testing.T) {
+	ks := NewKeyspace()
+	ctx := context.Background()
+	ks.Put("key", keyspace.TypeHash, "hash")
+
+	changed, err := ks.Expire(ctx, "key", time.Now().Add(-time.Second), keyspace.ExpireAlways)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 0, ks.Len())
+}
+
+func TestKeyspace_PersistAndTTL(t *testing.T) {
+	ks := NewKeyspace()
+	ctx := context.Background()
+
+	ttl, _ := ks.TTL(ctx, "missing")
+	assert.Equal(t, int64(-2), ttl)
+	expireTime, _ := ks.ExpireTime(ctx, "missing")
+	assert.Equal(t, int64(-2), expireTime)
+
+	ks.Put("key", keyspace.TypeString, "value")
+	ttl, _ = ks.TTL(ctx, "key")
+	assert.Equal(t, int64(-1), ttl)
+	persisted, _ := ks.Persist(ctx, "key")
+	assert.False(t, persisted)
+
+	ks.SetExpiry("key", time.Now().Add(10*time.Second))
+	ttl, _ = ks.TTL(ctx, "key")
+	assert.InDelta(t, 10000, ttl, 100)
+
+	persisted, _ = ks.Persist(ctx, "key")
+	assert.True(t, persisted)
+	ttl, _ = ks.TTL(ctx, "key")
+	assert.Equal(t, int64(-1), ttl)
+	assert.Equal(t, 0, ks.volatile.Len())
+}
+
+func newPopulatedKeyspace() *Keyspace {
+	ks := NewKeyspace()
+	ks.Put("user:1", keyspace.TypeString, "a")
+	ks.Put("user:2", keyspace.TypeHash, "b")
+	ks.Put("queue", keyspace.TypeList, "c")
+	ks.Put("user:expired", keyspace.TypeString, "d")
+	ks.SetExpiry("user:expired", time.Now().Add(-time.Second))
+	return ks
+}
+
+func TestKeyspace_Type(t *testing.T) {
+	ks := newPopulatedKeyspace()
+	ctx := context.Background()
+
+	keyType, _ := ks.Type(ctx, "user:2")
+	assert.Equal(t, keyspace.TypeHash, keyType)
+	keyType, _ = ks.Type(ctx, "user:expired")
+	assert.Equal(t, keyspace.TypeNone, keyType)
+	keyType, _ = ks.Type(ctx, "missing")
+	assert.Equal(t, keyspace.TypeNone, keyType)
+}
+
+func TestKeyspace_KeysSkipsExpiredKeys(t *testing.T) {
+	ks := newPopulatedKeyspace()
+	ctx := context.Background()
+
+	keys, err := ks.Keys(ctx, "*")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"user:1", "user:2", "queue"}, keys)
+
+	keys, _ = ks.Keys(ctx, "user:*")
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, keys)
+
+	keys, _ = ks.Keys(ctx, "nothing*")
+	assert.Empty(t, keys)
+}
+
+func TestKeyspace_ScanFilters(t *testing.T) {
+	ks := newPopulatedKeyspace()
+	ctx := context.Background()
+
+	cursor, keys, err := ks.Scan(ctx, 0, keyspace.ScanOptions{Count: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), cursor)
+	assert.ElementsMatch(t, []string{"user:1", "user:2", "queue"}, keys)
+
+	_, keys, _ = ks.Scan(ctx, 0, keyspace.ScanOptions{Match: "user:*", Count: 10})
+	assert.ElementsMatch(t, []string{"user:1", "user:2"}, keys)
+
+	_, keys, _ = ks.Scan(ctx, 0, keyspace.ScanOptions{Match: "user:*", Type: keyspace.TypeString, Count: 10})
+	assert.Equal(t, []string{"user:1"}, keys)
+}
+
+func TestKeyspace_ScanHugeCount(t *testing.T) {
+	ks := newPopulatedKeyspace()
+
+	cursor, keys, err := ks.Scan(context.Background(), 0, keyspace.ScanOptions{Count: math.MaxInt})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), cursor)
+	assert.ElementsMatch(t, []string{"user:1", "user:2", "queue"}, keys)
+}
+
+func TestKeyspace_DBSizeSkipsExpiredKeys(t *testing.T) {
+	ks := newPopulatedKeyspace()
+
+	size, err := ks.DBSize(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), size)
+}
+
+func TestKeyspace_RandomKey(t *testing.T) {
+	ks := NewKeyspace()
+	ctx := context.Background()
+
+	_, found, err := ks.RandomKey(ctx)
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	ks.Put("expired", keyspace.TypeString, "v")
+	ks.SetExpiry("expired", time.Now().Add(-time.Second))
+	_, found, _ = ks.RandomKey(ctx)
+	assert.False(t, found)
+	assert.Equal(t, 0, ks.Len())
+
+	ks.Put("live", keyspace.TypeList, "v")
+	key, found, _ := ks.RandomKey(ctx)
+	assert.True(t, found)
+	assert.Equal(t, "live", key)
+}
+
+func TestKeyspace_ScanResumesFromCursor(t *testing.T) {
+	ks := NewKeyspace()
+	ctx := context.Background()
+	for i := 0; i < 1000; i++ {
+		ks.Put(fmt.Sprintf("key:%d", i), keyspace.TypeString, "v")
+	}
+
+	seen := make(map[string]bool)
+	calls := 0
+	cursor := uint64(0)
+	for {
+		next, keys, err := ks.Scan(ctx, cursor, keyspace.ScanOptions{Count: 10})
+		assert.NoError(t, err)
+		for _, key := range keys {
+			seen[key] = true
+		}
+		calls++
+		// Keys added during the scan must not prevent it from returning the original ones.
+		ks.Put(fmt.Sprintf("added:%d", calls), keyspace.TypeString, "v")
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	assert.Greater(t, calls, 1)
+	for i := 0; i < 1000; i++ {
+		assert.True(t, seen[fmt.Sprintf("key:%d", i)])
+	}
+}
+
+type cloneableValue struct {
+	items []string
+}
+
+func (c *cloneableValue) Clone() any {
+	return &cloneableValue{items: append([]string(nil), c.items...)}
+}
+
+func TestKeyspace_RenameKeepsValueAndExpiry(t *testing.T) {
+	ks := NewKeyspace()
+	ctx := context.Background()
+	at := time.Now().Add(time.Hour)
+	ks.Put("src", keyspace.TypeList, "list")
+	ks.SetExpiry("src", at)
+	ks.Put("dst", keyspace.TypeString, "old")
+
+	_, err := ks.Rename(ctx, "missing", "dst", false)
+	assert.ErrorIs(t, err, keyspace.ErrNoSuchKey)
+
+	renamed, err := ks.Rename(ctx, "src", "dst", true)
+	assert.NoError(t, err)
+	assert.False(t, renamed)
+
+	renamed, err = ks.Rename(ctx, "src", "dst", false)
+	assert.NoError(t, err)
+	assert.True(t, renamed)
+	_, ok := ks.Lookup("src")
+	assert.False(t, ok)
+	entry, ok := ks.Lookup("dst")
+	assert.True(t, ok)
+	assert.Equal(t, keyspace.TypeList, entry.Type)
+	assert.Equal(t, at.UnixMilli(), entry.Expiry().UnixMilli())
+	assert.Equal(t, 1, ks.volatile.Len())
+
+	renamed, err = ks.Rename(ctx, "dst", "dst", false)
+	assert.NoError(t, err)
+	assert.True(t, renamed)
+	assert.Equal(t, 1, ks.Len())
+}
+
+func TestKeyspace_RenameOntoVolatileKeyDropsItsExpiry(t *testing.T) {
+	ks := NewKeyspace()
+	ctx := context.Background()
+	ks.Put("src", keyspace.TypeString, "v")
+	ks.Put("dst", keyspace.TypeString, "old")
+	ks.SetExpiry("dst", time.Now().Add(time.Hour))
+
+	_, _ = ks.Rename(ctx, "src", "dst", false)
+	entry, _ := ks.Lookup("dst")
+	assert.Nil(t, entry.Expiry())
+	assert.Equal(t, 0, ks.volatile.Len())
+}
+
+func TestKeyspace_CopyIsDeep(t *testing.T) {
+	ks := NewKeyspace()
+	ctx := context.Background()
+	original := &cloneableValue{items: []string{"a"}}
+	ks.Put("src", keyspace.TypeList, original)
+	ks.SetExpiry("src", time.Now().Add(time.Hour))
+	ks.Put("taken", keyspace.TypeString, &cloneableValue{})
+
+	_, err := ks.Copy(ctx, "src", "src", false)
+	assert.ErrorIs(t, err, keyspace.ErrSameObject)
+
+	copied, _ := ks.Copy(ctx, "missing", "dst", false)
+	assert.False(t, copied)
+	copied, _ = ks.Copy(ctx, "src", "taken", false)
+	assert.False(t, copied)
+
+	copied, err = ks.Copy(ctx, "src", "dst", false)
+	assert.NoError(t, err)
+	assert.True(t, copied)
+	copied, _ = ks.Copy(ctx, "src", "taken", true)
+	assert.True(t, copied)
+
+	entry, _ := ks.Lookup("dst")
+	entry.Value.(*cloneableValue).items[0] = "changed"
+	assert.Equal(t, []string{"a"}, original.items)
+	srcEntry, _ := ks.Lookup("src")
+	assert.Equal(t, srcEntry.Expiry().UnixMilli(), entry.Expiry().UnixMilli())
+	assert.NotSame(t, srcEntry.Expiry(), entry.Expiry())
+	assert.Equal(t, 3, ks.volatile.Len())
+}
+
+func TestKeyspace_CopyToAnotherKeyspace(t *testing.T) {
+	keyspaces := NewKeyspaces(2)
+	keyspaces[0].Put("key", keyspace.TypeString, &cloneableValue{items: []string{"a"}})
+	keyspaces[1].Put("taken", keyspace.TypeString, &cloneableValue{})
+
+	copied, err := keyspaces[0].CopyTo("key", keyspaces[1], "key", false)
+	assert.NoError(t, err)
+	assert.True(t, copied)
+	copied, _ = keyspaces[0].CopyTo("key", keyspaces[1], "taken", false)
+	assert.False(t, copied)
+
+	entry, ok := keyspaces[1].Lookup("key")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a"}, entry.Value.(*cloneableValue).items)
+	assert.Equal(t, 1, keyspaces[0].Len())
+}
+
+func TestKeyspace_MoveToKeepsExpiry(t *testing.T) {
+	keyspaces := NewKeyspaces(2)
+	at := time.Now().Add(time.Hour)
+	keyspaces[0].Put("key", keyspace.TypeString, "value")
+	keyspaces[0].SetExpiry("key", at)
+	keyspaces[0].Put("both", keyspace.TypeString, "source")
+	keyspaces[1].Put("both", keyspace.TypeString, "destination")
+
+	assert.True(t, keyspaces[0].MoveTo("key", keyspaces[1]))
+	assert.False(t, keyspaces[0].MoveTo("key", keyspaces[1]))
+	assert.False(t, keyspaces[0].MoveTo("both", keyspaces[1]))
+
+	_, ok := keyspaces[0].Lookup("key")
+	assert.False(t, ok)
+	assert.Equal(t, 0, keyspaces[0].volatile.Len())
+	entry, ok := keyspaces[1].Lookup("key")
+	assert.True(t, ok)
+	assert.Equal(t, at.UnixMilli(), entry.Expiry().UnixMilli())
+	assert.Equal(t, 1, keyspaces[1].volatile.Len())
+	both, _ := keyspaces[1].Lookup("both")
+	assert.Equal(t, "destination", both.Value)
+}
+
+func TestKeyspace_Flush(t *testing.T) {
+	ks := newPopulatedKeyspace()
+	ks.SetExpiry("user:1", time.Now().Add(time.Hour))
+
+	assert.NoError(t, ks.Flush(context.Background()))
+
+	assert.Equal(t, 0, ks.Len())
+	assert.Equal(t, 0, ks.volatile.Len())
+}
+
+type sizedValue int64
+
+func (v sizedValue) MemoryUsage() int64 {
+	return int64(v)
+}
+
+func TestKeyspace_AccountsUsedMemory(t *testing.T) {
+	ks := NewKeyspace()
+	ks.Put("key", keyspace.TypeString, sizedValue(100))
+	assert.Equal(t, int64(3+entryOverhead+100), ks.UsedMemory())
+
+	ks.Put("key", keyspace.TypeString, sizedValue(10))
+	assert.Equal(t, int64(3+entryOverhead+10), ks.UsedMemory())
+
+	entry, _ := ks.Lookup("key")
+	entry.Value = sizedValue(50)
+	ks.Resized("key")
+	assert.Equal(t, int64(3+entryOverhead+50), ks.UsedMemory())
+
+	ks.Remove("key")
+	assert.Equal(t, int64(0), ks.UsedMemory())
+	ks.Resized("key")
+	assert.Equal(t, int64(0), ks.UsedMemory())
+}
+
+func TestKeyspace_MemoryUsageAndStats(t *testing.T) {
+	keyspaces := NewKeyspaces(2)
+	first, second := keyspaces[0], keyspaces[1]
+	first.Put("key", keyspace.TypeString, sizedValue(100))
+	first.Put("volatile", keyspace.TypeString, sizedValue(10))
+	first.SetExpiry("volatile", time.Now().Add(time.Hour))
+	second.Put("other", keyspace.TypeString, sizedValue(1000))
+
+	size, ok, err := first.MemoryUsage(context.Background(), "key")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3+entryOverhead+100), size)
+	_, ok, _ = first.MemoryUsage(context.Background(), "missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, keyspace.MemoryStats{
+		Keys:     2,
+		Expires:  1,
+		Bytes:    3 + 8 + 2*entryOverhead + 110,
+		Overhead: 2 * entryOverhead,
+	}, first.MemoryStats(context.Background()))
+
+	total := first.UsedMemory() + second.UsedMemory()
+	assert.Equal(t, total, first.Stats().UsedMemory)
+	assert.Equal(t, total, first.Stats().PeakUsedMemory)
+
+	assert.NoError(t, second.Flush(context.Background()))
+	assert.Equal(t, first.UsedMemory(), first.Stats().UsedMemory)
+	assert.Equal(t, total, first.Stats().PeakUsedMemory)
+}
+
+type encodedValue string
+
+func (v encodedValue) Encoding() string {
+	return string(v)
+}
+
+func TestKeyspace_Object(t *testing.T) {
+	ks := NewKeyspace()
+	entry := ks.Put("key", keyspace.TypeString, encodedValue("int"))
+	entry.access = newAccess(time.Now().Add(-time.Minute))
+
+	object, ok, err := ks.Object(context.Background(), "key")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "int", object.Encoding)
+	assert.GreaterOrEqual(t, object.Idle, time.Minute)
+	assert.LessOrEqual(t, object.Frequency, int64(lfuInitValue))
+
+	// OBJECT does not count as an access, unlike a read.
+	object, _, _ = ks.Object(context.Background(), "key")
+	assert.GreaterOrEqual(t, object.Idle, time.Minute)
+	ks.Lookup("key")
+	object, _, _ = ks.Object(context.Background(), "key")
+	assert.Less(t, object.Idle, time.Minute)
+
+	_, ok, _ = ks.Object(context.Background(), "missing")
+	assert.False(t, ok)
+}
+
+type nodesValue int
+
+func (v nodesValue) Nodes() int {
+	return int(v)
+}
+
+func TestKeyspace_ObjectReportsNodes(t *testing.T) {
+	ks := NewKeyspace()
+	ks.Put("list", keyspace.TypeList, nodesValue(3))
+	ks.Put("string", keyspace.TypeString, encodedValue("raw"))
+
+	object, _, _ := ks.Object(context.Background(), "list")
+	assert.Equal(t, int64(3), object.QuicklistNodes)
+	object, _, _ = ks.Object(context.Background(), "string")
+	assert.Equal(t, int64(0), object.QuicklistNodes)
+}
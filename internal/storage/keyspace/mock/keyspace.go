@@ -0,0 +1,324 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: keyspace.go
+//
+// Generated by this command:
+//
+//	mockgen -source=keyspace.go -destination=mock/keyspace.go -package=mockkeyspace
+//
+
+// Package mockkeyspace is a generated GoMock package.
+package mockkeyspace
+
+import (
+	keyspace "avacado/internal/storage/keyspace"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKeyspace is a mock of Keyspace interface.
+type MockKeyspace struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyspaceMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyspaceMockRecorder is the mock recorder for MockKeyspace.
+type MockKeyspaceMockRecorder struct {
+	mock *MockKeyspace
+}
+
+// NewMockKeyspace creates a new mock instance.
+func NewMockKeyspace(ctrl *gomock.Controller) *MockKeyspace {
+	mock := &MockKeyspace{ctrl: ctrl}
+	mock.recorder = &MockKeyspaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyspace) EXPECT() *MockKeyspaceMockRecorder {
+	return m.recorder
+}
+
+// Copy mocks base method.
+func (m *MockKeyspace) Copy(ctx context.Context, source, destination string, replace bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", ctx, source, destination, replace)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Copy indicates an expected call of Copy.
+func (mr *MockKeyspaceMockRecorder) Copy(ctx, source, destination, replace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockKeyspace)(nil).Copy), ctx, source, destination, replace)
+}
+
+// DBSize mocks base method.
+func (m *MockKeyspace) DBSize(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DBSize", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DBSize indicates an expected call of DBSize.
+func (mr *MockKeyspaceMockRecorder) DBSize(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBSize", reflect.TypeOf((*MockKeyspace)(nil).DBSize), ctx)
+}
+
+// Del mocks base method.
+func (m *MockKeyspace) Del(ctx context.Context, keys ...string) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Del", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockKeyspaceMockRecorder) Del(ctx any, keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockKeyspace)(nil).Del), varargs...)
+}
+
+// Exists mocks base method.
+func (m *MockKeyspace) Exists(ctx context.Context, keys ...string) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exists", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockKeyspaceMockRecorder) Exists(ctx any, keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockKeyspace)(nil).Exists), varargs...)
+}
+
+// Expire mocks base method.
+func (m *MockKeyspace) Expire(ctx context.Context, key string, at time.Time, condition keyspace.ExpireCondition) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", ctx, key, at, condition)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockKeyspaceMockRecorder) Expire(ctx, key, at, condition any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockKeyspace)(nil).Expire), ctx, key, at, condition)
+}
+
+// ExpireTime mocks base method.
+func (m *MockKeyspace) ExpireTime(ctx context.Context, key string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireTime", ctx, key)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireTime indicates an expected call of ExpireTime.
+func (mr *MockKeyspaceMockRecorder) ExpireTime(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireTime", reflect.TypeOf((*MockKeyspace)(nil).ExpireTime), ctx, key)
+}
+
+// Flush mocks base method.
+func (m *MockKeyspace) Flush(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockKeyspaceMockRecorder) Flush(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockKeyspace)(nil).Flush), ctx)
+}
+
+// Keys mocks base method.
+func (m *MockKeyspace) Keys(ctx context.Context, pattern string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Keys", ctx, pattern)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Keys indicates an expected call of Keys.
+func (mr *MockKeyspaceMockRecorder) Keys(ctx, pattern any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockKeyspace)(nil).Keys), ctx, pattern)
+}
+
+// MemoryStats mocks base method.
+func (m *MockKeyspace) MemoryStats(ctx context.Context) keyspace.MemoryStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MemoryStats", ctx)
+	ret0, _ := ret[0].(keyspace.MemoryStats)
+	return ret0
+}
+
+// MemoryStats indicates an expected call of MemoryStats.
+func (mr *MockKeyspaceMockRecorder) MemoryStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MemoryStats", reflect.TypeOf((*MockKeyspace)(nil).MemoryStats), ctx)
+}
+
+// MemoryUsage mocks base method.
+func (m *MockKeyspace) MemoryUsage(ctx context.Context, key string) (int64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MemoryUsage", ctx, key)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MemoryUsage indicates an expected call of MemoryUsage.
+func (mr *MockKeyspaceMockRecorder) MemoryUsage(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MemoryUsage", reflect.TypeOf((*MockKeyspace)(nil).MemoryUsage), ctx, key)
+}
+
+// Object mocks base method.
+func (m *MockKeyspace) Object(ctx context.Context, key string) (keyspace.Object, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Object", ctx, key)
+	ret0, _ := ret[0].(keyspace.Object)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Object indicates an expected call of Object.
+func (mr *MockKeyspaceMockRecorder) Object(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Object", reflect.TypeOf((*MockKeyspace)(nil).Object), ctx, key)
+}
+
+// Persist mocks base method.
+func (m *MockKeyspace) Persist(ctx context.Context, key string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Persist", ctx, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Persist indicates an expected call of Persist.
+func (mr *MockKeyspaceMockRecorder) Persist(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Persist", reflect.TypeOf((*MockKeyspace)(nil).Persist), ctx, key)
+}
+
+// RandomKey mocks base method.
+func (m *MockKeyspace) RandomKey(ctx context.Context) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RandomKey", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RandomKey indicates an expected call of RandomKey.
+func (mr *MockKeyspaceMockRecorder) RandomKey(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomKey", reflect.TypeOf((*MockKeyspace)(nil).RandomKey), ctx)
+}
+
+// Rename mocks base method.
+func (m *MockKeyspace) Rename(ctx context.Context, key, newKey string, nx bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", ctx, key, newKey, nx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockKeyspaceMockRecorder) Rename(ctx, key, newKey, nx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockKeyspace)(nil).Rename), ctx, key, newKey, nx)
+}
+
+// Scan mocks base method.
+func (m *MockKeyspace) Scan(ctx context.Context, cursor uint64, options keyspace.ScanOptions) (uint64, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", ctx, cursor, options)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockKeyspaceMockRecorder) Scan(ctx, cursor, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockKeyspace)(nil).Scan), ctx, cursor, options)
+}
+
+// Stats mocks base method.
+func (m *MockKeyspace) Stats() keyspace.Stats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(keyspace.Stats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockKeyspaceMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockKeyspace)(nil).Stats))
+}
+
+// TTL mocks base method.
+func (m *MockKeyspace) TTL(ctx context.Context, key string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TTL", ctx, key)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TTL indicates an expected call of TTL.
+func (mr *MockKeyspaceMockRecorder) TTL(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TTL", reflect.TypeOf((*MockKeyspace)(nil).TTL), ctx, key)
+}
+
+// Type mocks base method.
+func (m *MockKeyspace) Type(ctx context.Context, key string) (keyspace.Type, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Type", ctx, key)
+	ret0, _ := ret[0].(keyspace.Type)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Type indicates an expected call of Type.
+func (mr *MockKeyspaceMockRecorder) Type(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Type", reflect.TypeOf((*MockKeyspace)(nil).Type), ctx, key)
+}
@@ -0,0 +1,176 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	"avacado/internal/storage/kv"
+	"context"
+	"math"
+)
+
+// BitField grows the value up front to the last field written, as Redis does, so that a SET or
+// INCRBY failing to overflow still grows it.
+func (k *KVMemoryStore) BitField(_ context.Context, key string, ops []kv.BitFieldOp) ([]*int64, error) {
+	v, err := k.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	length := int64(0)
+	for _, op := range ops {
+		if op.Kind != kv.BitFieldGet {
+			length = max(length, (op.Offset+int64(op.Bits)-1)/8+1)
+		}
+	}
+	var data []byte
+	if v != nil {
+		data = v.Bytes()
+	}
+	if length > 0 {
+		if v == nil {
+			v = &value{enc: encodingString}
+			k.keyspace.Put(key, keyspace.TypeString, v)
+		}
+		if int64(len(data)) < length {
+			data = append(data, make([]byte, length-int64(len(data)))...)
+		}
+		v.data, v.enc = data, encodingString
+		defer k.keyspace.Resized(key)
+	}
+
+	results := make([]*int64, len(ops))
+	for i, op := range ops {
+		if op.Signed {
+			results[i] = signedField(data, op)
+		} else {
+			results[i] = unsignedField(data, op)
+		}
+	}
+	return results, nil
+}
+
+// signedField performs op on a signed field of data.
+func signedField(data []byte, op kv.BitFieldOp) *int64 {
+	old := getField(data, op.Offset, op.Bits)
+	// Extend the sign bit to the bits above the field.
+	if op.Bits < 64 && old&(1<<(op.Bits-1)) != 0 {
+		old |= math.MaxUint64 << op.Bits
+	}
+	current := int64(old)
+	if op.Kind == kv.BitFieldGet {
+		return &current
+	}
+	value, result, incr := op.Value, current, int64(0)
+	if op.Kind == kv.BitFieldIncrBy {
+		value, incr = current, op.Value
+	}
+	stored, overflow := addSigned(value, incr, op.Bits, op.Overflow)
+	if overflow && op.Overflow == kv.BitFieldFail {
+		return nil
+	}
+	if op.Kind == kv.BitFieldIncrBy {
+		result = stored
+	}
+	setField(data, op.Offset, op.Bits, uint64(stored))
+	return &result
+}
+
+// unsignedField performs op on an unsigned field of data, the value set being read as unsigned.
+func unsignedField(data []byte, op kv.BitFieldOp) *int64 {
+	current := getField(data, op.Offset, op.Bits)
+	result := int64(current)
+	if op.Kind == kv.BitFieldGet {
+		return &result
+	}
+	value, incr := uint64(op.Value), int64(0)
+	if op.Kind == kv.BitFieldIncrBy {
+		value, incr = current, op.Value
+	}
+	stored, overflow := addUnsigned(value, incr, op.Bits, op.Overflow)
+	if overflow && op.Overflow == kv.BitFieldFail {
+		return nil
+	}
+	if op.Kind == kv.BitFieldIncrBy {
+		result = int64(stored)
+	}
+	setField(data, op.Offset, op.Bits, stored)
+	return &result
+}
+
+// addSigned returns value plus incr as a signed integer of bits bits, and reports whether it
+// overflowed, the value returned then being wrapped or saturated. It follows Redis, which also
+// counts a value set out of range as an overflow.
+func addSigned(value, incr int64, bits int, overflow kv.BitFieldOverflow) (int64, bool) {
+	maxValue := int64(math.MaxInt64)
+	if bits < 64 {
+		maxValue = 1<<(bits-1) - 1
+	}
+	minValue := -maxValue - 1
+	// Both may overflow, but are only used once value is known to be in range.
+	maxIncr, minIncr := maxValue-value, minValue-value
+	switch {
+	case value > maxValue || (bits != 64 && incr > maxIncr) || (value >= 0 && incr > 0 && incr > maxIncr):
+		if overflow == kv.BitFieldSat {
+			return maxValue, true
+		}
+	case value < minValue || (bits != 64 && incr < minIncr) || (value < 0 && incr < 0 && incr < minIncr):
+		if overflow == kv.BitFieldSat {
+			return minValue, true
+		}
+	default:
+		return value + incr, false
+	}
+	wrapped := uint64(value) + uint64(incr)
+	if bits < 64 {
+		mask := uint64(math.MaxUint64) << bits
+		if wrapped&(1<<(bits-1)) != 0 {
+			wrapped |= mask
+		} else {
+			wrapped &^= mask
+		}
+	}
+	return int64(wrapped), true
+}
+
+// addUnsigned is addSigned for an unsigned integer of at most 63 bits.
+func addUnsigned(value uint64, incr int64, bits int, overflow kv.BitFieldOverflow) (uint64, bool) {
+	maxValue := uint64(1)<<bits - 1
+	maxIncr, minIncr := int64(maxValue-value), -int64(value)
+	switch {
+	case value > maxValue || (incr > 0 && incr > maxIncr):
+		if overflow == kv.BitFieldSat {
+			return maxValue, true
+		}
+	case incr < 0 && incr < minIncr:
+		if overflow == kv.BitFieldSat {
+			return 0, true
+		}
+	default:
+		return value + uint64(incr), false
+	}
+	return (value + uint64(incr)) & maxValue, true
+}
+
+// getField returns the bits bits of data from offset, most significant first, those past the end
+// of data being clear.
+func getField(data []byte, offset int64, bits int) uint64 {
+	var field uint64
+	for pos := offset; pos < offset+int64(bits); pos++ {
+		field <<= 1
+		if pos/8 < int64(len(data)) {
+			field |= uint64(data[pos/8]>>(7-pos%8)) & 1
+		}
+	}
+	return field
+}
+
+// setField sets the bits bits of data from offset to the lowest bits of field.
+func setField(data []byte, offset int64, bits int, field uint64) {
+	for i := 0; i < bits; i++ {
+		pos := offset + int64(i)
+		mask := byte(0x80) >> (pos % 8)
+		if field>>(bits-1-i)&1 == 1 {
+			data[pos/8] |= mask
+		} else {
+			data[pos/8] &^= mask
+		}
+	}
+}
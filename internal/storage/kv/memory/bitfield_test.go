@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
+	"avacado/internal/storage/kv"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func results(values ...any) []*int64 {
+	out := make([]*int64, len(values))
+	for i, v := range values {
+		if v != nil {
+			n := int64(v.(int))
+			out[i] = &n
+		}
+	}
+	return out
+}
+
+func TestKVMemoryStore_BitField(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+
+	got, err := store.BitField(ctx, "bitfield", []kv.BitFieldOp{
+		{Kind: kv.BitFieldIncrBy, Signed: true, Bits: 5, Offset: 100, Value: 1},
+		{Kind: kv.BitFieldGet, Bits: 4, Offset: 0},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, results(1, 0), got)
+	length, _ := store.Len(ctx, "bitfield")
+	assert.Equal(t, int64(14), length)
+
+	got, _ = store.BitField(ctx, "bitfield", []kv.BitFieldOp{
+		{Kind: kv.BitFieldSet, Signed: true, Bits: 8, Offset: 0, Value: -100},
+		{Kind: kv.BitFieldGet, Bits: 8, Offset: 0},
+		{Kind: kv.BitFieldGet, Signed: true, Bits: 8, Offset: 0},
+		{Kind: kv.BitFieldGet, Bits: 4, Offset: 4},
+	})
+	assert.Equal(t, results(0, 156, -100, 12), got)
+	data, _ := store.Get(ctx, "bitfield")
+	assert.Equal(t, byte(156), data[0])
+}
+
+func TestKVMemoryStore_BitFieldGetDoesNotCreate(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	store := NewKVMemoryStore(ks, DefaultConfig())
+
+	got, err := store.BitField(context.Background(), "missing", []kv.BitFieldOp{
+		{Kind: kv.BitFieldGet, Signed: true, Bits: 64, Offset: 1000},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, results(0), got)
+	entry, _ := ks.Lookup("missing")
+	assert.Nil(t, entry)
+}
+
+func TestKVMemoryStore_BitFieldOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		op   kv.BitFieldOp
+		// initial is set first, as the same kind of field.
+		initial int64
+		want    []*int64
+		stored  int64
+	}{
+		{"unsigned incr wraps", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Bits: 2, Value: 1}, 3, results(0), 0},
+		{"unsigned incr saturates", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Bits: 2, Value: 1, Overflow: kv.BitFieldSat}, 3, results(3), 3},
+		{"unsigned incr fails", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Bits: 2, Value: 1, Overflow: kv.BitFieldFail}, 3, results(nil), 3},
+		{"unsigned decr wraps", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Bits: 8, Value: -3}, 1, results(254), 254},
+		{"unsigned decr saturates", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Bits: 8, Value: -3, Overflow: kv.BitFieldSat}, 1, results(0), 0},
+		{"unsigned set wraps", kv.BitFieldOp{Kind: kv.BitFieldSet, Bits: 8, Value: 257}, 7, results(7), 1},
+		{"unsigned set negative saturates", kv.BitFieldOp{Kind: kv.BitFieldSet, Bits: 8, Value: -1, Overflow: kv.BitFieldSat}, 7, results(7), 255},
+		{"unsigned set fails", kv.BitFieldOp{Kind: kv.BitFieldSet, Bits: 8, Value: 256, Overflow: kv.BitFieldFail}, 7, results(nil), 7},
+		{"unsigned 63 bits", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Bits: 63, Value: 1}, math.MaxInt64, results(0), 0},
+		{"signed incr wraps", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Signed: true, Bits: 8, Value: 1}, 127, results(-128), -128},
+		{"signed incr saturates", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Signed: true, Bits: 8, Value: 10, Overflow: kv.BitFieldSat}, 127, results(127), 127},
+		{"signed decr saturates", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Signed: true, Bits: 8, Value: -10, Overflow: kv.BitFieldSat}, -120, results(-128), -128},
+		{"signed decr fails", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Signed: true, Bits: 8, Value: -10, Overflow: kv.BitFieldFail}, -120, results(nil), -120},
+		{"signed set wraps", kv.BitFieldOp{Kind: kv.BitFieldSet, Signed: true, Bits: 4, Value: 9}, 1, results(1), -7},
+		{"signed 64 bits wraps", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Signed: true, Bits: 64, Value: 1}, math.MaxInt64, results(math.MinInt64), math.MinInt64},
+		{"signed 64 bits saturates", kv.BitFieldOp{Kind: kv.BitFieldIncrBy, Signed: true, Bits: 64, Value: math.MinInt64, Overflow: kv.BitFieldSat}, -1, results(math.MinInt64), math.MinInt64},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+			ctx := context.Background()
+			get := kv.BitFieldOp{Kind: kv.BitFieldGet, Signed: tc.op.Signed, Bits: tc.op.Bits, Offset: 3}
+			set := get
+			set.Kind, set.Value = kv.BitFieldSet, tc.initial
+			tc.op.Offset = 3
+			_, err := store.BitField(ctx, "bitfield", []kv.BitFieldOp{set})
+			require.NoError(t, err)
+
+			got, err := store.BitField(ctx, "bitfield", []kv.BitFieldOp{tc.op, get})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got[:1])
+			assert.Equal(t, tc.stored, *got[1])
+		})
+	}
+}
+
+func TestKVMemoryStore_BitFieldFailStillGrows(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+
+	got, err := store.BitField(ctx, "bitfield", []kv.BitFieldOp{
+		{Kind: kv.BitFieldSet, Bits: 8, Offset: 16, Value: 300, Overflow: kv.BitFieldFail},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, results(nil), got)
+	data, _ := store.Get(ctx, "bitfield")
+	assert.Equal(t, []byte{0, 0, 0}, data)
+}
+
+func TestKVMemoryStore_BitFieldWrongType(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	store := NewKVMemoryStore(ks, DefaultConfig())
+	ks.Put("list", keyspace.TypeList, struct{}{})
+
+	_, err := store.BitField(context.Background(), "list", []kv.BitFieldOp{{Kind: kv.BitFieldGet, Bits: 8}})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+}
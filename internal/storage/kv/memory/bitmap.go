@@ -0,0 +1,209 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	"avacado/internal/storage/kv"
+	"context"
+	"math/bits"
+)
+
+// The bits of a value are numbered from the most significant bit of its first byte, as Redis does.
+
+func (k *KVMemoryStore) SetBit(_ context.Context, key string, offset int64, bit byte) (byte, error) {
+	v, err := k.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	if v == nil {
+		v = &value{data: []byte{}, enc: encodingString}
+		k.keyspace.Put(key, keyspace.TypeString, v)
+	}
+	data := v.Bytes()
+	at := int(offset / 8)
+	if at >= len(data) {
+		data = append(data, make([]byte, at+1-len(data))...)
+	}
+	mask := byte(0x80) >> (offset % 8)
+	old := byte(0)
+	if data[at]&mask != 0 {
+		old = 1
+	}
+	if bit == 1 {
+		data[at] |= mask
+	} else {
+		data[at] &^= mask
+	}
+	v.data = data
+	v.enc = encodingString
+	k.keyspace.Resized(key)
+	return old, nil
+}
+
+func (k *KVMemoryStore) GetBit(_ context.Context, key string, offset int64) (byte, error) {
+	v, err := k.lookup(key)
+	if v == nil {
+		return 0, err
+	}
+	data := v.Bytes()
+	if offset/8 >= int64(len(data)) {
+		return 0, nil
+	}
+	return data[offset/8] >> (7 - offset%8) & 1, nil
+}
+
+func (k *KVMemoryStore) BitCount(_ context.Context, key string, r kv.BitRange) (int64, error) {
+	v, err := k.lookup(key)
+	if v == nil {
+		return 0, err
+	}
+	data := v.Bytes()
+	first, last := bitSpan(data, r)
+	if first > last {
+		return 0, nil
+	}
+	count := 0
+	firstByte, lastByte := first/8, last/8
+	for i := firstByte; i <= lastByte; i++ {
+		b := data[i]
+		if i == firstByte {
+			b &= 0xff >> (first % 8)
+		}
+		if i == lastByte {
+			b &= 0xff << (7 - last%8)
+		}
+		count += bits.OnesCount8(b)
+	}
+	return int64(count), nil
+}
+
+// BitPos counts a missing key as an empty value followed by clear bits, and so does a value given
+// no end to look in.
+func (k *KVMemoryStore) BitPos(_ context.Context, key string, bit byte, r kv.BitRange) (int64, error) {
+	v, err := k.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	if v == nil {
+		if bit == 1 {
+			return -1, nil
+		}
+		return 0, nil
+	}
+	data := v.Bytes()
+	first, last := bitSpan(data, r)
+	if first > last {
+		return -1, nil
+	}
+	// The bytes whose bits all differ from bit are skipped whole.
+	skip := byte(0)
+	if bit == 0 {
+		skip = 0xff
+	}
+	for i := first; i <= last; {
+		if i%8 == 0 && i+7 <= last && data[i/8] == skip {
+			i += 8
+			continue
+		}
+		if data[i/8]>>(7-i%8)&1 == bit {
+			return i, nil
+		}
+		i++
+	}
+	if bit == 0 && r.NoEnd {
+		return last + 1, nil
+	}
+	return -1, nil
+}
+
+// bitSpan returns the first and the last bit of data r selects, after clamping it to data, the
+// last being before the first when it selects none.
+func bitSpan(data []byte, r kv.BitRange) (int64, int64) {
+	length := int64(len(data))
+	if r.Bits {
+		length *= 8
+	}
+	start, end := r.Start, r.End
+	if start < 0 {
+		start += length
+	}
+	if end < 0 {
+		end += length
+	}
+	start, end = max(start, 0), min(max(end, 0), length-1)
+	if r.Bits {
+		return start, end
+	}
+	return start * 8, end*8 + 7
+}
+
+// BitOp overwrites destination, whatever it held, with a value that has no expiry.
+func (k *KVMemoryStore) BitOp(_ context.Context, op kv.BitOp, destination string, sources []string) (int64, error) {
+	values := make([][]byte, len(sources))
+	length := 0
+	for i, source := range sources {
+		v, err := k.lookup(source)
+		if err != nil {
+			return 0, err
+		}
+		if v != nil {
+			values[i] = v.Bytes()
+		}
+		length = max(length, len(values[i]))
+	}
+	if length == 0 {
+		k.keyspace.Remove(destination)
+		return 0, nil
+	}
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = bitOp(op, values, i)
+	}
+	k.keyspace.Put(destination, keyspace.TypeString, &value{data: result, enc: encodingString})
+	return int64(length), nil
+}
+
+// bitOp returns the byte at index of the result of op on values.
+func bitOp(op kv.BitOp, values [][]byte, index int) byte {
+	first := byteAt(values[0], index)
+	switch op {
+	case kv.BitOpNot:
+		return ^first
+	case kv.BitOpDiff, kv.BitOpAndOr:
+		others := byte(0)
+		for _, v := range values[1:] {
+			others |= byteAt(v, index)
+		}
+		if op == kv.BitOpDiff {
+			return first &^ others
+		}
+		return first & others
+	case kv.BitOpOne:
+		// once holds the bits set in a single value so far, more those set in several.
+		once, more := byte(0), byte(0)
+		for _, v := range values {
+			b := byteAt(v, index)
+			more |= once & b
+			once = (once ^ b) &^ more
+		}
+		return once
+	}
+	result := first
+	for _, v := range values[1:] {
+		switch b := byteAt(v, index); op {
+		case kv.BitOpAnd:
+			result &= b
+		case kv.BitOpOr:
+			result |= b
+		case kv.BitOpXor:
+			result ^= b
+		}
+	}
+	return result
+}
+
+func byteAt(data []byte, index int) byte {
+	if index < len(data) {
+		return data[index]
+	}
+	return 0
+}
@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
+	"avacado/internal/storage/kv"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKVMemoryStore_SetBitAndGetBit(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+
+	old, err := store.SetBit(ctx, "bitmap", 7, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0), old)
+	data, _ := store.Get(ctx, "bitmap")
+	assert.Equal(t, []byte{0x01}, data)
+
+	old, _ = store.SetBit(ctx, "bitmap", 7, 0)
+	assert.Equal(t, byte(1), old)
+	_, _ = store.SetBit(ctx, "bitmap", 17, 1)
+	data, _ = store.Get(ctx, "bitmap")
+	assert.Equal(t, []byte{0x00, 0x00, 0x40}, data)
+
+	bit, err := store.GetBit(ctx, "bitmap", 17)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(1), bit)
+	bit, _ = store.GetBit(ctx, "bitmap", 16)
+	assert.Equal(t, byte(0), bit)
+	bit, _ = store.GetBit(ctx, "bitmap", 1000)
+	assert.Equal(t, byte(0), bit)
+	bit, _ = store.GetBit(ctx, "missing", 0)
+	assert.Equal(t, byte(0), bit)
+}
+
+func TestKVMemoryStore_SetBitOnInteger(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = store.Set(ctx, "number", []byte("1"), kv.NewSetOptions())
+
+	// "1" is 0x31: setting its last but one bit makes it "3".
+	old, err := store.SetBit(ctx, "number", 6, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0), old)
+	data, _ := store.Get(ctx, "number")
+	assert.Equal(t, []byte("3"), data)
+	_, err = store.Incr(ctx, "number")
+	assert.NoError(t, err)
+	data, _ = store.Get(ctx, "number")
+	assert.Equal(t, []byte("4"), data)
+}
+
+func TestKVMemoryStore_BitCount(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = store.Set(ctx, "bitmap", []byte("foobar"), kv.NewSetOptions())
+
+	for _, tc := range []struct {
+		r     kv.BitRange
+		count int64
+	}{
+		{kv.BitRange{Start: 0, End: -1}, 26},
+		{kv.BitRange{Start: 0, End: 0}, 4},
+		{kv.BitRange{Start: 1, End: 1}, 6},
+		{kv.BitRange{Start: -2, End: -1}, 7},
+		{kv.BitRange{Start: 5, End: 30, Bits: true}, 17},
+		{kv.BitRange{Start: 2, End: 1}, 0},
+		{kv.BitRange{Start: -100, End: 100}, 26},
+	} {
+		count, err := store.BitCount(ctx, "bitmap", tc.r)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.count, count, tc.r)
+	}
+	count, err := store.BitCount(ctx, "missing", kv.BitRange{Start: 0, End: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestKVMemoryStore_BitPos(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = store.Set(ctx, "ones", []byte{0xff, 0xf0, 0x00}, kv.NewSetOptions())
+	_, _ = store.Set(ctx, "full", []byte{0xff, 0xff}, kv.NewSetOptions())
+
+	for _, tc := range []struct {
+		key string
+		bit byte
+		r   kv.BitRange
+		pos int64
+	}{
+		{"ones", 0, kv.BitRange{Start: 0, End: -1, NoEnd: true}, 12},
+		{"ones", 1, kv.BitRange{Start: 2, End: -1, NoEnd: true}, -1},
+		{"ones", 1, kv.BitRange{Start: 1, End: -1, NoEnd: true}, 8},
+		{"ones", 1, kv.BitRange{Start: 7, End: 15, Bits: true}, 7},
+		{"ones", 0, kv.BitRange{Start: 7, End: 11, Bits: true}, -1},
+		{"full", 0, kv.BitRange{Start: 0, End: -1, NoEnd: true}, 16},
+		{"full", 0, kv.BitRange{Start: 0, End: -1}, -1},
+		{"full", 0, kv.BitRange{Start: 5, End: -1, NoEnd: true}, -1},
+		{"missing", 0, kv.BitRange{Start: 0, End: -1, NoEnd: true}, 0},
+		{"missing", 1, kv.BitRange{Start: 0, End: -1, NoEnd: true}, -1},
+	} {
+		pos, err := store.BitPos(ctx, tc.key, tc.bit, tc.r)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.pos, pos, tc)
+	}
+}
+
+func TestKVMemoryStore_BitOp(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = store.Set(ctx, "a", []byte{0b1100, 0xff}, kv.NewSetOptions())
+	_, _ = store.Set(ctx, "b", []byte{0b1010}, kv.NewSetOptions())
+	_, _ = store.Set(ctx, "c", []byte{0b0110}, kv.NewSetOptions())
+
+	for _, tc := range []struct {
+		op      kv.BitOp
+		sources []string
+		result  []byte
+	}{
+		{kv.BitOpAnd, []string{"a", "b"}, []byte{0b1000, 0}},
+		{kv.BitOpOr, []string{"a", "b"}, []byte{0b1110, 0xff}},
+		{kv.BitOpXor, []string{"a", "b"}, []byte{0b0110, 0xff}},
+		{kv.BitOpNot, []string{"b"}, []byte{0xf5}},
+		{kv.BitOpDiff, []string{"a", "b", "c"}, []byte{0, 0xff}},
+		{kv.BitOpAndOr, []string{"a", "b", "c"}, []byte{0b1100, 0}},
+		{kv.BitOpOne, []string{"a", "b", "c"}, []byte{0, 0xff}},
+		{kv.BitOpOne, []string{"b", "c"}, []byte{0b1100}},
+		{kv.BitOpAnd, []string{"a", "missing"}, []byte{0, 0}},
+	} {
+		length, err := store.BitOp(ctx, tc.op, "dest", tc.sources)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(tc.result)), length)
+		data, _ := store.Get(ctx, "dest")
+		assert.Equal(t, tc.result, data, tc)
+	}
+
+	length, err := store.BitOp(ctx, kv.BitOpOr, "dest", []string{"missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), length)
+	data, _ := store.Get(ctx, "dest")
+	assert.Nil(t, data)
+}
+
+func TestKVMemoryStore_BitmapWrongType(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	store := NewKVMemoryStore(ks, DefaultConfig())
+	ctx := context.Background()
+	ks.Put("list", keyspace.TypeList, struct{}{})
+
+	_, err := store.SetBit(ctx, "list", 0, 1)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.GetBit(ctx, "list", 0)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.BitCount(ctx, "list", kv.BitRange{Start: 0, End: -1})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.BitPos(ctx, "list", 1, kv.BitRange{Start: 0, End: -1})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.BitOp(ctx, kv.BitOpAnd, "dest", []string{"missing", "list"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.BitOp(ctx, kv.BitOpAnd, "list", []string{"missing"})
+	assert.NoError(t, err)
+	entry, _ := ks.Lookup("list")
+	assert.Nil(t, entry)
+}
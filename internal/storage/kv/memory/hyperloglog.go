@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"avacado/internal/storage/hyperloglog"
+	"avacado/internal/storage/keyspace"
+	"bytes"
+	"context"
+)
+
+// lookupHLL returns the string value stored at key and a copy of the HyperLogLog it holds, or nil
+// if key does not exist. The copy is edited then stored back, as replies may still refer to the
+// bytes of the value. hyperloglog.ErrNotHLL is returned if the value holds no HyperLogLog.
+func (k *KVMemoryStore) lookupHLL(key string) (*value, *hyperloglog.HLL, error) {
+	v, err := k.lookup(key)
+	if v == nil {
+		return nil, nil, err
+	}
+	if v.enc != encodingString {
+		return nil, nil, hyperloglog.ErrNotHLL
+	}
+	hll, err := hyperloglog.Parse(bytes.Clone(v.data))
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, hll, nil
+}
+
+// storeHLL stores hll at key, into v unless key did not exist.
+func (k *KVMemoryStore) storeHLL(key string, v *value, hll *hyperloglog.HLL) {
+	if v == nil {
+		k.keyspace.Put(key, keyspace.TypeString, &value{data: hll.Bytes(), enc: encodingString})
+		return
+	}
+	v.data = hll.Bytes()
+	k.keyspace.Resized(key)
+}
+
+func (k *KVMemoryStore) PFAdd(_ context.Context, key string, elements []string) (bool, error) {
+	v, hll, err := k.lookupHLL(key)
+	if err != nil {
+		return false, err
+	}
+	updated := v == nil
+	if v == nil {
+		hll = hyperloglog.New()
+	}
+	for _, element := range elements {
+		added, err := hll.Add([]byte(element), k.config.HLLSparseMaxBytes)
+		if err != nil {
+			return false, err
+		}
+		updated = updated || added
+	}
+	if updated {
+		k.storeHLL(key, v, hll)
+	}
+	return updated, nil
+}
+
+// PFCount returns the cardinality cached by a single HyperLogLog, caching it first if it is stale.
+// The union of several is estimated anew every time.
+func (k *KVMemoryStore) PFCount(_ context.Context, keys []string) (uint64, error) {
+	if len(keys) == 1 {
+		v, hll, err := k.lookupHLL(keys[0])
+		if v == nil {
+			return 0, err
+		}
+		card, refreshed, err := hll.Count()
+		if err != nil {
+			return 0, err
+		}
+		if refreshed {
+			k.storeHLL(keys[0], v, hll)
+		}
+		return card, nil
+	}
+	registers, _, err := k.mergeHLLs(keys)
+	if err != nil {
+		return 0, err
+	}
+	return hyperloglog.Estimate(registers), nil
+}
+
+// PFMerge stores the union at destination, dense if any of the HyperLogLogs merged is.
+func (k *KVMemoryStore) PFMerge(_ context.Context, destination string, sources []string) error {
+	registers, dense, err := k.mergeHLLs(append([]string{destination}, sources...))
+	if err != nil {
+		return err
+	}
+	v, hll, _ := k.lookupHLL(destination)
+	if v == nil {
+		hll = hyperloglog.New()
+	}
+	if dense {
+		if _, err := hll.ToDense(); err != nil {
+			return err
+		}
+	}
+	if err := hll.Raise(registers, k.config.HLLSparseMaxBytes); err != nil {
+		return err
+	}
+	k.storeHLL(destination, v, hll)
+	return nil
+}
+
+// mergeHLLs returns the registers of the union of the HyperLogLogs stored at keys, the missing
+// keys counting as empty ones, and reports whether any of them is dense.
+func (k *KVMemoryStore) mergeHLLs(keys []string) ([]uint8, bool, error) {
+	registers := make([]uint8, hyperloglog.Registers)
+	dense := false
+	for _, key := range keys {
+		v, hll, err := k.lookupHLL(key)
+		if err != nil {
+			return nil, false, err
+		}
+		if v == nil {
+			continue
+		}
+		dense = dense || hll.Dense()
+		if err := hll.MergeInto(registers); err != nil {
+			return nil, false, err
+		}
+	}
+	return registers, dense, nil
+}
+
+func (k *KVMemoryStore) PFDebug(_ context.Context, key string, toDense bool) (*hyperloglog.HLL, bool, error) {
+	v, hll, err := k.lookupHLL(key)
+	if v == nil {
+		return nil, false, err
+	}
+	if !toDense {
+		return hll, false, nil
+	}
+	converted, err := hll.ToDense()
+	if err != nil {
+		return nil, false, err
+	}
+	if converted {
+		k.storeHLL(key, v, hll)
+	}
+	return hll, converted, nil
+}
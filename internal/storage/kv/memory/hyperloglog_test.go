@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"avacado/internal/storage/hyperloglog"
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
+	"avacado/internal/storage/kv"
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKVMemoryStore_PFAddAndPFCount(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+
+	updated, err := store.PFAdd(ctx, "hll", nil)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	updated, _ = store.PFAdd(ctx, "hll", []string{"a", "b", "c"})
+	assert.True(t, updated)
+	updated, _ = store.PFAdd(ctx, "hll", []string{"a", "b"})
+	assert.False(t, updated)
+
+	card, err := store.PFCount(ctx, []string{"hll"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), card)
+	data, _ := store.Get(ctx, "hll")
+	assert.Equal(t, "HYLL", string(data[:4]))
+	assert.Equal(t, byte(3), data[8], "the cardinality is cached")
+
+	card, err = store.PFCount(ctx, []string{"missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), card)
+}
+
+func TestKVMemoryStore_PFCountOfSeveralKeys(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = store.PFAdd(ctx, "first", []string{"a", "b", "c"})
+	_, _ = store.PFAdd(ctx, "second", []string{"c", "d"})
+
+	card, err := store.PFCount(ctx, []string{"first", "second", "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), card)
+}
+
+func TestKVMemoryStore_PFMerge(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = store.PFAdd(ctx, "first", []string{"a", "b"})
+	_, _ = store.PFAdd(ctx, "second", []string{"b", "c"})
+
+	assert.NoError(t, store.PFMerge(ctx, "merged", []string{"first", "second"}))
+	card, _ := store.PFCount(ctx, []string{"merged"})
+	assert.Equal(t, uint64(3), card)
+	hll, _, _ := store.PFDebug(ctx, "merged", false)
+	assert.False(t, hll.Dense())
+
+	_, converted, err := store.PFDebug(ctx, "second", true)
+	assert.NoError(t, err)
+	assert.True(t, converted)
+	assert.NoError(t, store.PFMerge(ctx, "first", []string{"second"}))
+	hll, _, _ = store.PFDebug(ctx, "first", false)
+	assert.True(t, hll.Dense())
+	card, _ = store.PFCount(ctx, []string{"first"})
+	assert.Equal(t, uint64(3), card)
+}
+
+func TestKVMemoryStore_PFAddPromotesToDense(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), Config{HLLSparseMaxBytes: 100})
+	ctx := context.Background()
+	elements := make([]string, 100)
+	for i := range elements {
+		elements[i] = strconv.Itoa(i)
+	}
+
+	_, err := store.PFAdd(ctx, "hll", elements)
+	require.NoError(t, err)
+	hll, converted, err := store.PFDebug(ctx, "hll", true)
+	assert.NoError(t, err)
+	assert.False(t, converted)
+	assert.True(t, hll.Dense())
+}
+
+func TestKVMemoryStore_PFDebugMissingKey(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+
+	hll, converted, err := store.PFDebug(context.Background(), "missing", true)
+	assert.NoError(t, err)
+	assert.Nil(t, hll)
+	assert.False(t, converted)
+}
+
+func TestKVMemoryStore_HyperLogLogWrongType(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	store := NewKVMemoryStore(ks, DefaultConfig())
+	ctx := context.Background()
+	ks.Put("list", keyspace.TypeList, struct{}{})
+	_, _ = store.Set(ctx, "string", []byte("value"), kv.NewSetOptions())
+	_, _ = store.Set(ctx, "integer", []byte("12"), kv.NewSetOptions())
+
+	_, err := store.PFAdd(ctx, "list", []string{"a"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.PFAdd(ctx, "string", []string{"a"})
+	assert.Equal(t, hyperloglog.ErrNotHLL, err)
+	_, err = store.PFCount(ctx, []string{"integer"})
+	assert.Equal(t, hyperloglog.ErrNotHLL, err)
+	_, err = store.PFCount(ctx, []string{"missing", "string"})
+	assert.Equal(t, hyperloglog.ErrNotHLL, err)
+	err = store.PFMerge(ctx, "string", nil)
+	assert.Equal(t, hyperloglog.ErrNotHLL, err)
+	_, _, err = store.PFDebug(ctx, "string", false)
+	assert.Equal(t, hyperloglog.ErrNotHLL, err)
+}
@@ -1,6 +1,9 @@
 package memory
 
 import (
+	"avacado/internal/storage/hyperloglog"
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
 	"avacado/internal/storage/kv"
 	"bytes"
 	"context"
@@ -17,10 +20,12 @@ const (
 	encodingInteger          = 1
 )
 
+// valueOverhead approximates the bytes used by a value besides its data.
+const valueOverhead = 32
+
 type value struct {
-	data   []byte
-	enc    encoding
-	expiry *time.Time
+	data []byte
+	enc  encoding
 }
 
 func encodeNumber(n int64) []byte {
@@ -35,12 +40,33 @@ func newIntegerValue(n int64) *value {
 	return &value{data: encodeNumber(n), enc: encodingInteger}
 }
 
-func newValue(data []byte, expiry *time.Time) *value {
-	// Try to parse as integer for optimized storage
-	if n, err := strconv.ParseInt(string(data), 10, 64); err == nil {
-		return &value{data: encodeNumber(n), enc: encodingInteger, expiry: expiry}
+func newValue(data []byte) *value {
+	// Store as an integer only when formatting it back gives the same bytes, so that values
+	// such as "007" or "+5" are returned as they were set.
+	if n, err := strconv.ParseInt(string(data), 10, 64); err == nil && strconv.FormatInt(n, 10) == string(data) {
+		return &value{data: encodeNumber(n), enc: encodingInteger}
 	}
-	return &value{data: data, enc: encodingString, expiry: expiry}
+	return &value{data: data, enc: encodingString}
+}
+
+// Clone returns a copy of the value that does not share its bytes.
+func (v *value) Clone() any {
+	data := make([]byte, len(v.data))
+	copy(data, v.data)
+	return &value{data: data, enc: v.enc}
+}
+
+// MemoryUsage returns the bytes allocated for the value.
+func (v *value) MemoryUsage() int64 {
+	return int64(cap(v.data)) + valueOverhead
+}
+
+// Encoding returns "int" for values stored as a binary integer and "raw" otherwise.
+func (v *value) Encoding() string {
+	if v.enc == encodingInteger {
+		return "int"
+	}
+	return "raw"
 }
 
 func (v *value) AsInt64() (int64, error) {
@@ -69,29 +95,47 @@ func (v *value) Bytes() []byte {
 	return []byte(strconv.FormatInt(n, 10))
 }
 
-func (v *value) isExpired() bool {
-	if v.expiry == nil {
-		return false
-	}
-	return time.Now().After(*v.expiry)
+// Config holds the size a sparse HyperLogLog is promoted to dense past, Redis's hll-sparse-max-bytes.
+type Config struct {
+	HLLSparseMaxBytes int
+}
+
+// DefaultConfig returns the threshold Redis uses by default.
+func DefaultConfig() Config {
+	return Config{HLLSparseMaxBytes: hyperloglog.DefaultSparseMaxBytes}
 }
 
-// KVMemoryStore is an in-memory key-value store.
+// KVMemoryStore is an in-memory key-value store keeping its string values in the shared keyspace.
 // All methods are called exclusively by the executor goroutine — no locking needed.
 type KVMemoryStore struct {
-	store map[string]*value
+	keyspace *memkeyspace.Keyspace
+	config   *Config
 }
 
-func NewKVMemoryStore() *KVMemoryStore {
+func NewKVMemoryStore(ks *memkeyspace.Keyspace, config Config) *KVMemoryStore {
 	return &KVMemoryStore{
-		store: make(map[string]*value),
+		keyspace: ks,
+		config:   &config,
+	}
+}
+
+// lookup returns the string value stored at key, or nil if key does not exist.
+// ErrWrongType is returned if key holds a value of another type.
+func (k *KVMemoryStore) lookup(key string) (*value, error) {
+	entry, err := k.keyspace.LookupOfType(key, keyspace.TypeString)
+	if entry == nil {
+		return nil, err
 	}
+	return entry.Value.(*value), nil
 }
 
 func (k *KVMemoryStore) Incr(ctx context.Context, key string) (int64, error) {
-	v, ok := k.store[key]
-	if !ok || v.isExpired() {
-		k.store[key] = newValue([]byte("1"), nil)
+	v, err := k.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	if v == nil {
+		k.keyspace.Put(key, keyspace.TypeString, newValue([]byte("1")))
 		return 1, nil
 	}
 
@@ -100,7 +144,8 @@ func (k *KVMemoryStore) Incr(ctx context.Context, key string) (int64, error) {
 		return 0, NewExpectsValidNumberError()
 	}
 
-	v.data = encodeNumber(oldValue + 1)
+	v.data, v.enc = encodeNumber(oldValue+1), encodingInteger
+	k.keyspace.Resized(key)
 	return oldValue + 1, nil
 }
 
@@ -109,10 +154,13 @@ func (k *KVMemoryStore) Decr(ctx context.Context, key string) (int64, error) {
 }
 
 func (k *KVMemoryStore) DecrBy(ctx context.Context, key string, decrement int64) (int64, error) {
-	v, ok := k.store[key]
-	if !ok || v.isExpired() {
+	v, err := k.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	if v == nil {
 		nv := 0 - decrement
-		k.store[key] = newIntegerValue(nv)
+		k.keyspace.Put(key, keyspace.TypeString, newIntegerValue(nv))
 		return nv, nil
 	}
 
@@ -122,118 +170,90 @@ func (k *KVMemoryStore) DecrBy(ctx context.Context, key string, decrement int64)
 	}
 
 	nv := oldValue - decrement
-	v.data = encodeNumber(nv)
+	v.data, v.enc = encodeNumber(nv), encodingInteger
+	k.keyspace.Resized(key)
 	return nv, nil
 }
 
-func (k *KVMemoryStore) Del(ctx context.Context, keys ...string) (int64, error) {
-	var deletedCount int64
-	for _, key := range keys {
-		v, ok := k.store[key]
-		if ok && !v.isExpired() {
-			delete(k.store, key)
-			deletedCount++
-		}
-	}
-	return deletedCount, nil
-}
-
-func (k *KVMemoryStore) Exists(ctx context.Context, keys ...string) (int64, error) {
-	var existsCount int64
-	for _, key := range keys {
-		v, ok := k.store[key]
-		if ok && !v.isExpired() {
-			existsCount++
-		}
-	}
-	return existsCount, nil
-}
-
 func (k *KVMemoryStore) Append(_ context.Context, key string, data []byte) (int64, error) {
-	existing, ok := k.store[key]
-	if !ok || existing.isExpired() {
-		k.store[key] = &value{data: data, enc: encodingString}
+	existing, err := k.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	if existing == nil {
+		k.keyspace.Put(key, keyspace.TypeString, &value{data: data, enc: encodingString})
 		return int64(len(data)), nil
 	}
 	current := existing.Bytes()
 	appended := append(current, data...)
-	k.store[key] = &value{data: appended, enc: encodingString, expiry: existing.expiry}
+	existing.data = appended
+	existing.enc = encodingString
+	k.keyspace.Resized(key)
 	return int64(len(appended)), nil
 }
 
-func (k *KVMemoryStore) Set(_ context.Context, key string, data []byte, options *kv.SetOptions) ([]byte, error) {
-	oldValue, keyAlreadyExists := k.store[key]
-
-	// Check if key is expired
-	if keyAlreadyExists && oldValue.isExpired() {
-		keyAlreadyExists = false
-		oldValue = nil
+// Set stores data at key, overwriting any value of any type already held by key.
+// When options.Get is set the key must hold a string, as its old value is returned.
+// Set stores data at key according to options. When an NX, XX or IFEQ condition is not met
+// nothing is stored and the error is returned along with the old value, as SET ... GET still
+// replies with it.
+func (k *KVMemoryStore) Set(ctx context.Context, key string, data []byte, options *kv.SetOptions) ([]byte, error) {
+	oldEntry, keyAlreadyExists := k.keyspace.Lookup(key)
+	var oldValue *value
+	if keyAlreadyExists && oldEntry.Type == keyspace.TypeString {
+		oldValue = oldEntry.Value.(*value)
 	}
 
+	if keyAlreadyExists && oldValue == nil && (options.Get || options.IFEQ != nil) {
+		return nil, keyspace.ErrWrongType
+	}
+	var old []byte
+	if oldValue != nil && options.Get {
+		old = oldValue.Bytes()
+	}
 	if keyAlreadyExists && options.NX {
-		return nil, NewKeyAlreadyExistsError(key)
+		return old, NewKeyAlreadyExistsError(key)
 	}
 	if !keyAlreadyExists && options.XX {
-		return nil, NewKeyNotPresentError(key)
+		return old, NewKeyNotPresentError(key)
 	}
 
 	// Check IFEQ condition
 	if options.IFEQ != nil {
 		if !keyAlreadyExists {
-			return nil, NewValueMismatchError()
+			return old, NewValueMismatchError()
 		}
 		if !bytes.Equal(oldValue.Bytes(), options.IFEQ) {
-			return nil, NewValueMismatchError()
+			return old, NewValueMismatchError()
 		}
 	}
 
-	var expiry *time.Time
-	if options.EX != 0 {
-		expiryTime := time.Now().Add(time.Duration(options.EX) * time.Second)
-		expiry = &expiryTime
+	var oldExpiry *time.Time
+	if keyAlreadyExists {
+		oldExpiry = oldEntry.Expiry()
 	}
-
-	k.store[key] = newValue(data, expiry)
-	var d []byte
-	if keyAlreadyExists && options.Get {
-		d = oldValue.Bytes()
+	k.keyspace.Put(key, keyspace.TypeString, newValue(data))
+	if at, ok := options.ExpireAt(time.Now()); ok {
+		// Expire also deletes the key right away when at is already in the past.
+		_, _ = k.keyspace.Expire(ctx, key, at, keyspace.ExpireAlways)
+	} else if options.KeepTTL && oldExpiry != nil {
+		k.keyspace.SetExpiry(key, *oldExpiry)
 	}
-	return d, nil
+	return old, nil
 }
 
 func (k *KVMemoryStore) Get(_ context.Context, key string) ([]byte, error) {
-	v, ok := k.store[key]
-	if !ok {
-		return nil, nil
-	}
-	if v.isExpired() {
-		delete(k.store, key)
-		return nil, nil
+	v, err := k.lookup(key)
+	if v == nil {
+		return nil, err
 	}
 	return v.Bytes(), nil
 }
 
-// GetTTL returns the time to live for key in milliseconds
-func (k *KVMemoryStore) GetTTL(key string) (int64, error) {
-	v, ok := k.store[key]
-	if !ok {
-		return -2, NewKeyNotPresentError(key)
-	}
-	if v.expiry == nil {
-		return -1, nil
-	}
-	now := time.Now()
-	return v.expiry.UnixMilli() - now.UnixMilli(), nil
-}
-
 func (k *KVMemoryStore) Len(_ context.Context, key string) (int64, error) {
-	v, ok := k.store[key]
-	if !ok {
-		return 0, nil
-	}
-	if v.isExpired() {
-		delete(k.store, key)
-		return 0, nil
+	v, err := k.lookup(key)
+	if v == nil {
+		return 0, err
 	}
 	return v.Len(), nil
 }
@@ -242,12 +262,11 @@ func (k *KVMemoryStore) Len(_ context.Context, key string) (int64, error) {
 // following Redis's GETRANGE semantics: negative offsets count from the end of the string,
 // out-of-range offsets are clamped, and a missing key or empty/invalid range yields an empty slice.
 func (k *KVMemoryStore) GetRange(_ context.Context, key string, start, end int64) ([]byte, error) {
-	v, ok := k.store[key]
-	if !ok {
-		return []byte{}, nil
+	v, err := k.lookup(key)
+	if err != nil {
+		return nil, err
 	}
-	if v.isExpired() {
-		delete(k.store, key)
+	if v == nil {
 		return []byte{}, nil
 	}
 
@@ -294,14 +313,18 @@ func (k *KVMemoryStore) SetRange(
 	_ context.Context,
 	key string,
 	start int,
-	value []byte,
+	data []byte,
 ) (int, error) {
-	v, ok := k.store[key]
-	if !ok || v.isExpired() {
-		v = newValue([]byte{}, nil)
-		k.store[key] = v
+	v, err := k.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	if v == nil {
+		v = newValue([]byte{})
+		k.keyspace.Put(key, keyspace.TypeString, v)
 	}
-	setRange(v, start, value)
+	setRange(v, start, data)
+	k.keyspace.Resized(key)
 	return len(v.Bytes()), nil
 }
 
@@ -1,6 +1,8 @@
 package memory
 
 import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
 	"avacado/internal/storage/kv"
 	"context"
 	"testing"
@@ -9,8 +11,13 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// expireAt overrides the expiry of a live key, e.g. to move it into the past
+func expireAt(store *KVMemoryStore, key string, at time.Time) {
+	store.keyspace.SetExpiry(key, at)
+}
+
 func TestKVMemoryStore_GetAndSet(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	v, err := store.Get(context.Background(), "key1")
 
 	assert.NoError(t, err)
@@ -28,7 +35,7 @@ func TestKVMemoryStore_GetAndSet(t *testing.T) {
 }
 
 func TestKVMemoryStore_SetExistingKeyWithNXOptionEnabled(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	options := kv.NewSetOptions()
 	options.WithNX()
 
@@ -40,7 +47,7 @@ func TestKVMemoryStore_SetExistingKeyWithNXOptionEnabled(t *testing.T) {
 }
 
 func TestKVMemoryStore_SetExistingKeyWithNXOptionDisabled(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	option := kv.NewSetOptions()
 
 	_, err := store.Set(context.Background(), "key1", []byte("value1"), option)
@@ -54,7 +61,7 @@ func TestKVMemoryStore_SetExistingKeyWithNXOptionDisabled(t *testing.T) {
 }
 
 func TestKVMemoryStore_SetWithXXEnabled(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	optionWithXX := kv.NewSetOptions()
 	optionWithXX.WithXX()
 
@@ -72,7 +79,7 @@ func TestKVMemoryStore_SetWithXXEnabled(t *testing.T) {
 }
 
 func TestKVMemoryStore_Expiry(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	options := kv.NewSetOptions()
 	options.WithEX(1)
 
@@ -90,7 +97,7 @@ func TestKVMemoryStore_Expiry(t *testing.T) {
 // TestKVMemoryStore_LazyExpirationImmediateCleanup verifies that lazy expiration
 // immediately removes expired keys on GET
 func TestKVMemoryStore_LazyExpirationImmediateCleanup(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	options := kv.NewSetOptions().WithEX(1)
 
 	_, err := store.Set(context.Background(), "key1", []byte("value1"), options)
@@ -108,7 +115,7 @@ func TestKVMemoryStore_LazyExpirationImmediateCleanup(t *testing.T) {
 }
 
 func TestKVMemoryStore_Incr(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	val, err := store.Incr(ctx, "counter")
@@ -124,7 +131,7 @@ func TestKVMemoryStore_Incr(t *testing.T) {
 	_, err = store.Set(ctx, "counter2", []byte("20"), kv.NewSetOptions().WithEX(1))
 	assert.NoError(t, err)
 	pastTime := time.Now().Add(-2 * time.Second)
-	store.store["counter2"].expiry = &pastTime
+	expireAt(store, "counter2", pastTime)
 
 	v, err := store.Incr(ctx, "counter2")
 	assert.NoError(t, err)
@@ -138,7 +145,7 @@ func TestKVMemoryStore_Incr(t *testing.T) {
 }
 
 func TestKVMemoryStore_Decr(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	val, err := store.Decr(ctx, "counter")
@@ -154,7 +161,7 @@ func TestKVMemoryStore_Decr(t *testing.T) {
 	_, err = store.Set(ctx, "counter2", []byte("20"), kv.NewSetOptions().WithEX(1))
 	assert.NoError(t, err)
 	pastTime := time.Now().Add(-2 * time.Second)
-	store.store["counter2"].expiry = &pastTime
+	expireAt(store, "counter2", pastTime)
 
 	v, err := store.Decr(ctx, "counter2")
 	assert.NoError(t, err)
@@ -168,7 +175,7 @@ func TestKVMemoryStore_Decr(t *testing.T) {
 }
 
 func TestKVMemoryStore_DecrBy(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	val, err := store.DecrBy(ctx, "counter", 5)
@@ -192,7 +199,7 @@ func TestKVMemoryStore_DecrBy(t *testing.T) {
 	_, err = store.Set(ctx, "counter2", []byte("50"), kv.NewSetOptions().WithEX(1))
 	assert.NoError(t, err)
 	pastTime := time.Now().Add(-2 * time.Second)
-	store.store["counter2"].expiry = &pastTime
+	expireAt(store, "counter2", pastTime)
 
 	v, err := store.DecrBy(ctx, "counter2", 15)
 	assert.NoError(t, err)
@@ -205,103 +212,8 @@ func TestKVMemoryStore_DecrBy(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestKVMemoryStore_Del(t *testing.T) {
-	store := NewKVMemoryStore()
-	ctx := context.Background()
-
-	count, err := store.Del(ctx, "nonexistent")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(0), count)
-
-	_, err = store.Set(ctx, "key1", []byte("value1"), kv.NewSetOptions())
-	assert.NoError(t, err)
-	_, err = store.Set(ctx, "key2", []byte("value2"), kv.NewSetOptions())
-	assert.NoError(t, err)
-	_, err = store.Set(ctx, "key3", []byte("value3"), kv.NewSetOptions())
-	assert.NoError(t, err)
-
-	count, err = store.Del(ctx, "key1")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(1), count)
-
-	val, err := store.Get(ctx, "key1")
-	assert.NoError(t, err)
-	assert.Nil(t, val)
-
-	count, err = store.Del(ctx, "key2", "key3")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(2), count)
-
-	val, err = store.Get(ctx, "key2")
-	assert.NoError(t, err)
-	assert.Nil(t, val)
-	val, err = store.Get(ctx, "key3")
-	assert.NoError(t, err)
-	assert.Nil(t, val)
-
-	_, err = store.Set(ctx, "key4", []byte("value4"), kv.NewSetOptions())
-	assert.NoError(t, err)
-	count, err = store.Del(ctx, "key4", "nonexistent", "alsonothere")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(1), count)
-
-	_, err = store.Set(ctx, "expiring", []byte("value"), kv.NewSetOptions().WithEX(1))
-	assert.NoError(t, err)
-	pastTime := time.Now().Add(-2 * time.Second)
-	store.store["expiring"].expiry = &pastTime
-
-	count, err = store.Del(ctx, "expiring")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(0), count)
-}
-
-func TestKVMemoryStore_Exists(t *testing.T) {
-	store := NewKVMemoryStore()
-	ctx := context.Background()
-
-	count, err := store.Exists(ctx, "nonexistent")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(0), count)
-
-	_, err = store.Set(ctx, "key1", []byte("value1"), kv.NewSetOptions())
-	assert.NoError(t, err)
-	count, err = store.Exists(ctx, "key1")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(1), count)
-
-	_, err = store.Set(ctx, "key2", []byte("value2"), kv.NewSetOptions())
-	assert.NoError(t, err)
-	_, err = store.Set(ctx, "key3", []byte("value3"), kv.NewSetOptions())
-	assert.NoError(t, err)
-
-	count, err = store.Exists(ctx, "key1", "key2", "key3")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(3), count)
-
-	count, err = store.Exists(ctx, "key1", "nonexistent", "key2")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(2), count)
-
-	_, err = store.Set(ctx, "expiring", []byte("value"), kv.NewSetOptions().WithEX(1))
-	assert.NoError(t, err)
-	pastTime := time.Now().Add(-2 * time.Second)
-	store.store["expiring"].expiry = &pastTime
-
-	count, err = store.Exists(ctx, "expiring")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(0), count)
-
-	count, err = store.Exists(ctx, "key1", "key1", "key1")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(3), count)
-
-	count, err = store.Exists(ctx, "key1", "nonexistent", "key1")
-	assert.NoError(t, err)
-	assert.Equal(t, int64(2), count)
-}
-
 func TestKVMemoryStore_NumberEncoding(t *testing.T) {
-	v := newValue([]byte("123"), nil)
+	v := newValue([]byte("123"))
 	n, err := v.AsInt64()
 	assert.NoError(t, err)
 	assert.Equal(t, n, int64(123))
@@ -312,8 +224,23 @@ func TestKVMemoryStore_NumberEncoding(t *testing.T) {
 	assert.Equal(t, n, int64(80))
 }
 
+func TestKVMemoryStore_NonCanonicalIntegersStayRaw(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+
+	for _, data := range []string{"007", "+5", "-0"} {
+		_, err := store.Set(ctx, data, []byte(data), kv.NewSetOptions())
+		assert.NoError(t, err)
+		val, err := store.Get(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(data), val)
+		assert.Equal(t, "raw", newValue([]byte(data)).Encoding(), data)
+	}
+	assert.Equal(t, "int", newValue([]byte("-5")).Encoding())
+}
+
 func TestKVMemoryStore_SetWithIFEQMatchingValue(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	_, err := store.Set(ctx, "key1", []byte("oldvalue"), kv.NewSetOptions())
@@ -329,7 +256,7 @@ func TestKVMemoryStore_SetWithIFEQMatchingValue(t *testing.T) {
 }
 
 func TestKVMemoryStore_SetWithIFEQNonMatchingValue(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	_, err := store.Set(ctx, "key1", []byte("oldvalue"), kv.NewSetOptions())
@@ -345,7 +272,7 @@ func TestKVMemoryStore_SetWithIFEQNonMatchingValue(t *testing.T) {
 }
 
 func TestKVMemoryStore_SetWithIFEQNonExistentKey(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	options := kv.NewSetOptions().WithIFEQ([]byte("somevalue"))
@@ -358,14 +285,14 @@ func TestKVMemoryStore_SetWithIFEQNonExistentKey(t *testing.T) {
 }
 
 func TestKVMemoryStore_SetWithIFEQExpiredKey(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	_, err := store.Set(ctx, "key1", []byte("oldvalue"), kv.NewSetOptions().WithEX(1))
 	assert.NoError(t, err)
 
 	pastTime := time.Now().Add(-2 * time.Second)
-	store.store["key1"].expiry = &pastTime
+	expireAt(store, "key1", pastTime)
 
 	options := kv.NewSetOptions().WithIFEQ([]byte("oldvalue"))
 	_, err = store.Set(ctx, "key1", []byte("newvalue"), options)
@@ -377,7 +304,7 @@ func TestKVMemoryStore_SetWithIFEQExpiredKey(t *testing.T) {
 }
 
 func TestKVMemoryStore_Append(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	// Append to absent key creates it
@@ -404,23 +331,27 @@ func TestKVMemoryStore_Append(t *testing.T) {
 
 	// TTL is preserved after append
 	futureTime := time.Now().Add(10 * time.Second)
-	store.store["ttlkey"] = &value{data: []byte("hi"), enc: encodingString, expiry: &futureTime}
+	store.keyspace.Put("ttlkey", keyspace.TypeString, &value{data: []byte("hi"), enc: encodingString})
+	store.keyspace.SetExpiry("ttlkey", futureTime)
 	n, err = store.Append(ctx, "ttlkey", []byte("!"))
 	assert.NoError(t, err)
 	assert.Equal(t, int64(3), n)
-	assert.NotNil(t, store.store["ttlkey"].expiry)
+	entry, _ := store.keyspace.Lookup("ttlkey")
+	assert.NotNil(t, entry.Expiry())
 
 	// Append to expired key treats it as new (no TTL)
 	pastTime := time.Now().Add(-2 * time.Second)
-	store.store["expired"] = &value{data: []byte("old"), enc: encodingString, expiry: &pastTime}
+	store.keyspace.Put("expired", keyspace.TypeString, &value{data: []byte("old"), enc: encodingString})
+	store.keyspace.SetExpiry("expired", pastTime)
 	n, err = store.Append(ctx, "expired", []byte("new"))
 	assert.NoError(t, err)
 	assert.Equal(t, int64(3), n)
-	assert.Nil(t, store.store["expired"].expiry)
+	entry, _ = store.keyspace.Lookup("expired")
+	assert.Nil(t, entry.Expiry())
 }
 
 func TestKVMemoryStore_Len(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	// Len of absent key is 0
@@ -442,16 +373,16 @@ func TestKVMemoryStore_Len(t *testing.T) {
 
 	// Len of expired key is 0 and key is removed
 	pastTime := time.Now().Add(-2 * time.Second)
-	store.store["expired"] = &value{data: []byte("old"), enc: encodingString, expiry: &pastTime}
+	store.keyspace.Put("expired", keyspace.TypeString, &value{data: []byte("old"), enc: encodingString})
+	store.keyspace.SetExpiry("expired", pastTime)
 	n, err = store.Len(ctx, "expired")
 	assert.NoError(t, err)
 	assert.Equal(t, int64(0), n)
-	_, stillPresent := store.store["expired"]
-	assert.False(t, stillPresent)
+	assert.Equal(t, 2, store.keyspace.Len())
 }
 
 func TestKVMemoryStore_SetWithIFEQAndGet(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	_, err := store.Set(ctx, "key1", []byte("oldvalue"), kv.NewSetOptions())
@@ -468,7 +399,7 @@ func TestKVMemoryStore_SetWithIFEQAndGet(t *testing.T) {
 }
 
 func TestKVMemoryStore_GetRange(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	// GetRange of non-existent key returns empty
@@ -518,16 +449,16 @@ func TestKVMemoryStore_GetRange(t *testing.T) {
 
 	// Expired key is treated as non-existent and evicted
 	pastTime := time.Now().Add(-2 * time.Second)
-	store.store["expired"] = &value{data: []byte("old"), enc: encodingString, expiry: &pastTime}
+	store.keyspace.Put("expired", keyspace.TypeString, &value{data: []byte("old"), enc: encodingString})
+	store.keyspace.SetExpiry("expired", pastTime)
 	v, err = store.GetRange(ctx, "expired", 0, -1)
 	assert.NoError(t, err)
 	assert.Equal(t, []byte{}, v)
-	_, stillPresent := store.store["expired"]
-	assert.False(t, stillPresent)
+	assert.Equal(t, 2, store.keyspace.Len())
 }
 
 func TestKVMemoryStore_SetRange(t *testing.T) {
-	store := NewKVMemoryStore()
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
 	ctx := context.Background()
 
 	// Non-existing key is treated as empty string.
@@ -589,7 +520,7 @@ func TestKVMemoryStore_SetRange(t *testing.T) {
 	_, err = store.Set(ctx, "expired", []byte("oldvalue"), kv.NewSetOptions().WithEX(1))
 	assert.NoError(t, err)
 	pastTime := time.Now().Add(-2 * time.Second)
-	store.store["expired"].expiry = &pastTime
+	expireAt(store, "expired", pastTime)
 
 	n, err = store.SetRange(ctx, "expired", 0, []byte("Hello"))
 	assert.NoError(t, err)
@@ -598,3 +529,92 @@ func TestKVMemoryStore_SetRange(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []byte("Hello"), v)
 }
+
+func TestKVMemoryStore_WrongType(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	store := NewKVMemoryStore(ks, DefaultConfig())
+	ctx := context.Background()
+	ks.Put("list", keyspace.TypeList, struct{}{})
+
+	_, err := store.Get(ctx, "list")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.Incr(ctx, "list")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.DecrBy(ctx, "list", 2)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.Append(ctx, "list", []byte("x"))
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.Len(ctx, "list")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.GetRange(ctx, "list", 0, -1)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.SetRange(ctx, "list", 0, []byte("x"))
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.Set(ctx, "list", []byte("x"), kv.NewSetOptions().WithGet())
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+
+	// A plain SET replaces the value whatever its type
+	_, err = store.Set(ctx, "list", []byte("x"), kv.NewSetOptions())
+	assert.NoError(t, err)
+	v, err := store.Get(ctx, "list")
+	assert.NoError(t, err)
+	assert.Equal(t, "x", string(v))
+}
+
+func TestKVMemoryStore_SetWithExpiryOptions(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+
+	_, err := store.Set(ctx, "px", []byte("v"), kv.NewSetOptions().WithPX(1500))
+	assert.NoError(t, err)
+	ttl, _ := store.keyspace.TTL(ctx, "px")
+	assert.InDelta(t, 1500, ttl, 50)
+
+	at := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	_, err = store.Set(ctx, "pxat", []byte("v"), kv.NewSetOptions().WithPXAT(at.UnixMilli()))
+	assert.NoError(t, err)
+	expireTime, _ := store.keyspace.ExpireTime(ctx, "pxat")
+	assert.Equal(t, at.UnixMilli(), expireTime)
+
+	_, err = store.Set(ctx, "exat", []byte("v"), kv.NewSetOptions().WithEXAT(at.Unix()))
+	assert.NoError(t, err)
+	expireTime, _ = store.keyspace.ExpireTime(ctx, "exat")
+	assert.Equal(t, at.Unix()*1000, expireTime)
+
+	_, err = store.Set(ctx, "past", []byte("v"), kv.NewSetOptions().WithPXAT(time.Now().Add(-time.Second).UnixMilli()))
+	assert.NoError(t, err)
+	_, ok := store.keyspace.Lookup("past")
+	assert.False(t, ok)
+}
+
+func TestKVMemoryStore_SetWithKeepTTL(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	at := time.Now().Add(time.Hour)
+
+	_, _ = store.Set(ctx, "key", []byte("v1"), kv.NewSetOptions())
+	expireAt(store, "key", at)
+
+	_, err := store.Set(ctx, "key", []byte("v2"), kv.NewSetOptions().WithKeepTTL())
+	assert.NoError(t, err)
+	expireTime, _ := store.keyspace.ExpireTime(ctx, "key")
+	assert.Equal(t, at.UnixMilli(), expireTime)
+
+	_, err = store.Set(ctx, "key", []byte("v3"), kv.NewSetOptions())
+	assert.NoError(t, err)
+	expireTime, _ = store.keyspace.ExpireTime(ctx, "key")
+	assert.Equal(t, int64(-1), expireTime)
+}
+
+func TestKVMemoryStore_SetReturnsOldValueWhenConditionFails(t *testing.T) {
+	store := NewKVMemoryStore(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = store.Set(ctx, "key", []byte("old"), kv.NewSetOptions())
+
+	oldVal, err := store.Set(ctx, "key", []byte("new"), kv.NewSetOptions().WithNX().WithGet())
+	assert.Error(t, err)
+	assert.Equal(t, []byte("old"), oldVal)
+
+	val, _ := store.Get(ctx, "key")
+	assert.Equal(t, []byte("old"), val)
+}
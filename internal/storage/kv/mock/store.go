@@ -10,6 +10,7 @@
 package mockkv
 
 import (
+	hyperloglog "avacado/internal/storage/hyperloglog"
 	kv "avacado/internal/storage/kv"
 	context "context"
 	reflect "reflect"
@@ -56,74 +57,94 @@ func (mr *MockStoreMockRecorder) Append(ctx, key, value any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockStore)(nil).Append), ctx, key, value)
 }
 
-// Decr mocks base method.
-func (m *MockStore) Decr(ctx context.Context, key string) (int64, error) {
+// BitCount mocks base method.
+func (m *MockStore) BitCount(ctx context.Context, key string, r kv.BitRange) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Decr", ctx, key)
+	ret := m.ctrl.Call(m, "BitCount", ctx, key, r)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Decr indicates an expected call of Decr.
-func (mr *MockStoreMockRecorder) Decr(ctx, key any) *gomock.Call {
+// BitCount indicates an expected call of BitCount.
+func (mr *MockStoreMockRecorder) BitCount(ctx, key, r any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decr", reflect.TypeOf((*MockStore)(nil).Decr), ctx, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitCount", reflect.TypeOf((*MockStore)(nil).BitCount), ctx, key, r)
 }
 
-// DecrBy mocks base method.
-func (m *MockStore) DecrBy(ctx context.Context, key string, decrement int64) (int64, error) {
+// BitField mocks base method.
+func (m *MockStore) BitField(ctx context.Context, key string, ops []kv.BitFieldOp) ([]*int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DecrBy", ctx, key, decrement)
+	ret := m.ctrl.Call(m, "BitField", ctx, key, ops)
+	ret0, _ := ret[0].([]*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitField indicates an expected call of BitField.
+func (mr *MockStoreMockRecorder) BitField(ctx, key, ops any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitField", reflect.TypeOf((*MockStore)(nil).BitField), ctx, key, ops)
+}
+
+// BitOp mocks base method.
+func (m *MockStore) BitOp(ctx context.Context, op kv.BitOp, destination string, sources []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitOp", ctx, op, destination, sources)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DecrBy indicates an expected call of DecrBy.
-func (mr *MockStoreMockRecorder) DecrBy(ctx, key, decrement any) *gomock.Call {
+// BitOp indicates an expected call of BitOp.
+func (mr *MockStoreMockRecorder) BitOp(ctx, op, destination, sources any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrBy", reflect.TypeOf((*MockStore)(nil).DecrBy), ctx, key, decrement)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitOp", reflect.TypeOf((*MockStore)(nil).BitOp), ctx, op, destination, sources)
+}
+
+// BitPos mocks base method.
+func (m *MockStore) BitPos(ctx context.Context, key string, bit byte, r kv.BitRange) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitPos", ctx, key, bit, r)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitPos indicates an expected call of BitPos.
+func (mr *MockStoreMockRecorder) BitPos(ctx, key, bit, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitPos", reflect.TypeOf((*MockStore)(nil).BitPos), ctx, key, bit, r)
 }
 
-// Del mocks base method.
-func (m *MockStore) Del(ctx context.Context, keys ...string) (int64, error) {
+// Decr mocks base method.
+func (m *MockStore) Decr(ctx context.Context, key string) (int64, error) {
 	m.ctrl.T.Helper()
-	varargs := []any{ctx}
-	for _, a := range keys {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "Del", varargs...)
+	ret := m.ctrl.Call(m, "Decr", ctx, key)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Del indicates an expected call of Del.
-func (mr *MockStoreMockRecorder) Del(ctx any, keys ...any) *gomock.Call {
+// Decr indicates an expected call of Decr.
+func (mr *MockStoreMockRecorder) Decr(ctx, key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{ctx}, keys...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockStore)(nil).Del), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decr", reflect.TypeOf((*MockStore)(nil).Decr), ctx, key)
 }
 
-// Exists mocks base method.
-func (m *MockStore) Exists(ctx context.Context, keys ...string) (int64, error) {
+// DecrBy mocks base method.
+func (m *MockStore) DecrBy(ctx context.Context, key string, decrement int64) (int64, error) {
 	m.ctrl.T.Helper()
-	varargs := []any{ctx}
-	for _, a := range keys {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "Exists", varargs...)
+	ret := m.ctrl.Call(m, "DecrBy", ctx, key, decrement)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Exists indicates an expected call of Exists.
-func (mr *MockStoreMockRecorder) Exists(ctx any, keys ...any) *gomock.Call {
+// DecrBy indicates an expected call of DecrBy.
+func (mr *MockStoreMockRecorder) DecrBy(ctx, key, decrement any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{ctx}, keys...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockStore)(nil).Exists), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrBy", reflect.TypeOf((*MockStore)(nil).DecrBy), ctx, key, decrement)
 }
 
 // Get mocks base method.
@@ -141,34 +162,34 @@ func (mr *MockStoreMockRecorder) Get(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), ctx, key)
 }
 
-// GetRange mocks base method.
-func (m *MockStore) GetRange(ctx context.Context, key string, start, end int64) ([]byte, error) {
+// GetBit mocks base method.
+func (m *MockStore) GetBit(ctx context.Context, key string, offset int64) (byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRange", ctx, key, start, end)
-	ret0, _ := ret[0].([]byte)
+	ret := m.ctrl.Call(m, "GetBit", ctx, key, offset)
+	ret0, _ := ret[0].(byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRange indicates an expected call of GetRange.
-func (mr *MockStoreMockRecorder) GetRange(ctx, key, start, end any) *gomock.Call {
+// GetBit indicates an expected call of GetBit.
+func (mr *MockStoreMockRecorder) GetBit(ctx, key, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRange", reflect.TypeOf((*MockStore)(nil).GetRange), ctx, key, start, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBit", reflect.TypeOf((*MockStore)(nil).GetBit), ctx, key, offset)
 }
 
-// GetTTL mocks base method.
-func (m *MockStore) GetTTL(key string) (int64, error) {
+// GetRange mocks base method.
+func (m *MockStore) GetRange(ctx context.Context, key string, start, end int64) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTTL", key)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "GetRange", ctx, key, start, end)
+	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTTL indicates an expected call of GetTTL.
-func (mr *MockStoreMockRecorder) GetTTL(key any) *gomock.Call {
+// GetRange indicates an expected call of GetRange.
+func (mr *MockStoreMockRecorder) GetRange(ctx, key, start, end any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTTL", reflect.TypeOf((*MockStore)(nil).GetTTL), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRange", reflect.TypeOf((*MockStore)(nil).GetRange), ctx, key, start, end)
 }
 
 // Incr mocks base method.
@@ -201,6 +222,66 @@ func (mr *MockStoreMockRecorder) Len(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Len", reflect.TypeOf((*MockStore)(nil).Len), ctx, key)
 }
 
+// PFAdd mocks base method.
+func (m *MockStore) PFAdd(ctx context.Context, key string, elements []string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PFAdd", ctx, key, elements)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PFAdd indicates an expected call of PFAdd.
+func (mr *MockStoreMockRecorder) PFAdd(ctx, key, elements any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PFAdd", reflect.TypeOf((*MockStore)(nil).PFAdd), ctx, key, elements)
+}
+
+// PFCount mocks base method.
+func (m *MockStore) PFCount(ctx context.Context, keys []string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PFCount", ctx, keys)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PFCount indicates an expected call of PFCount.
+func (mr *MockStoreMockRecorder) PFCount(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PFCount", reflect.TypeOf((*MockStore)(nil).PFCount), ctx, keys)
+}
+
+// PFDebug mocks base method.
+func (m *MockStore) PFDebug(ctx context.Context, key string, toDense bool) (*hyperloglog.HLL, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PFDebug", ctx, key, toDense)
+	ret0, _ := ret[0].(*hyperloglog.HLL)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PFDebug indicates an expected call of PFDebug.
+func (mr *MockStoreMockRecorder) PFDebug(ctx, key, toDense any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PFDebug", reflect.TypeOf((*MockStore)(nil).PFDebug), ctx, key, toDense)
+}
+
+// PFMerge mocks base method.
+func (m *MockStore) PFMerge(ctx context.Context, destination string, sources []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PFMerge", ctx, destination, sources)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PFMerge indicates an expected call of PFMerge.
+func (mr *MockStoreMockRecorder) PFMerge(ctx, destination, sources any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PFMerge", reflect.TypeOf((*MockStore)(nil).PFMerge), ctx, destination, sources)
+}
+
 // Set mocks base method.
 func (m *MockStore) Set(ctx context.Context, key string, value []byte, options *kv.SetOptions) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -216,6 +297,21 @@ func (mr *MockStoreMockRecorder) Set(ctx, key, value, options any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockStore)(nil).Set), ctx, key, value, options)
 }
 
+// SetBit mocks base method.
+func (m *MockStore) SetBit(ctx context.Context, key string, offset int64, bit byte) (byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBit", ctx, key, offset, bit)
+	ret0, _ := ret[0].(byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBit indicates an expected call of SetBit.
+func (mr *MockStoreMockRecorder) SetBit(ctx, key, offset, bit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBit", reflect.TypeOf((*MockStore)(nil).SetBit), ctx, key, offset, bit)
+}
+
 // SetRange mocks base method.
 func (m *MockStore) SetRange(ctx context.Context, key string, start int, value []byte) (int, error) {
 	m.ctrl.T.Helper()
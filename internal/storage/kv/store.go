@@ -1,6 +1,10 @@
 package kv
 
-import "context"
+import (
+	"avacado/internal/storage/hyperloglog"
+	"context"
+	"time"
+)
 
 type ErrorType string
 
@@ -9,14 +13,18 @@ const (
 	KeyNotPresentErrorType    ErrorType = "KEY_NOT_PRESENT"
 )
 
-// SetOptions represent options supported by set command
+// SetOptions represent options supported by set command.
+// At most one of EX, PX, EXAT, PXAT and KeepTTL is expected to be set.
 type SetOptions struct {
-	NX   bool
-	XX   bool
-	EX   int64
-	PX   int64
-	Get  bool
-	IFEQ []byte // Set value only if current value equals this
+	NX      bool
+	XX      bool
+	EX      int64 // Expire after this many seconds
+	PX      int64 // Expire after this many milliseconds
+	EXAT    int64 // Expire at this Unix time in seconds
+	PXAT    int64 // Expire at this Unix time in milliseconds
+	KeepTTL bool  // Retain the expiry the key had before
+	Get     bool
+	IFEQ    []byte // Set value only if current value equals this
 }
 
 func NewSetOptions() *SetOptions {
@@ -41,6 +49,46 @@ func (s *SetOptions) WithEX(time int64) *SetOptions {
 	return s
 }
 
+// WithPX set px option set value expiry time in milliseconds
+func (s *SetOptions) WithPX(time int64) *SetOptions {
+	s.PX = time
+	return s
+}
+
+// WithEXAT set exat option set value expiry as a Unix time in seconds
+func (s *SetOptions) WithEXAT(timestamp int64) *SetOptions {
+	s.EXAT = timestamp
+	return s
+}
+
+// WithPXAT set pxat option set value expiry as a Unix time in milliseconds
+func (s *SetOptions) WithPXAT(timestamp int64) *SetOptions {
+	s.PXAT = timestamp
+	return s
+}
+
+// WithKeepTTL set keepttl option which retain the expiry of the key being overwritten
+func (s *SetOptions) WithKeepTTL() *SetOptions {
+	s.KeepTTL = true
+	return s
+}
+
+// ExpireAt returns the absolute expiry requested by EX, PX, EXAT or PXAT relative to now,
+// and false if none of them is set.
+func (s *SetOptions) ExpireAt(now time.Time) (time.Time, bool) {
+	switch {
+	case s.EX != 0:
+		return time.UnixMilli(now.UnixMilli() + s.EX*1000), true
+	case s.PX != 0:
+		return time.UnixMilli(now.UnixMilli() + s.PX), true
+	case s.EXAT != 0:
+		return time.UnixMilli(s.EXAT * 1000), true
+	case s.PXAT != 0:
+		return time.UnixMilli(s.PXAT), true
+	}
+	return time.Time{}, false
+}
+
 // WithGet set get option which return old value of the key if it exists
 func (s *SetOptions) WithGet() *SetOptions {
 	s.Get = true
@@ -53,18 +101,104 @@ func (s *SetOptions) WithIFEQ(value []byte) *SetOptions {
 	return s
 }
 
+// BitRange selects the bytes of a string value from Start to End included, or its bits when Bits
+// is set, negative offsets counting from the end of the value.
+type BitRange struct {
+	Start int64
+	End   int64
+	Bits  bool
+	// NoEnd is set when the range was given no end, BITPOS then looking for a clear bit past the
+	// end of the value, as if it were padded with zeros.
+	NoEnd bool
+}
+
+// BitOp is the bitwise operation BITOP combines its source values with.
+type BitOp int
+
+const (
+	BitOpAnd BitOp = iota
+	BitOpOr
+	BitOpXor
+	BitOpNot
+	// BitOpDiff keeps the bits of the first source set in none of the others.
+	BitOpDiff
+	// BitOpAndOr keeps the bits of the first source set in at least one of the others.
+	BitOpAndOr
+	// BitOpOne keeps the bits set in exactly one source.
+	BitOpOne
+)
+
+// BitFieldOpKind is the operation a BITFIELD subcommand performs on its field.
+type BitFieldOpKind int
+
+const (
+	BitFieldGet BitFieldOpKind = iota
+	BitFieldSet
+	BitFieldIncrBy
+)
+
+// BitFieldOverflow is how SET and INCRBY of BITFIELD handle a value the field cannot hold.
+type BitFieldOverflow int
+
+const (
+	// BitFieldWrap keeps the lowest bits of the value, wrapping around as integers do.
+	BitFieldWrap BitFieldOverflow = iota
+	// BitFieldSat saturates the field to its minimum or maximum.
+	BitFieldSat
+	// BitFieldFail leaves the field unchanged.
+	BitFieldFail
+)
+
+// BitFieldOp is a GET, SET or INCRBY of BITFIELD on the integer of Bits bits starting at bit
+// Offset, Value being the value set or the increment.
+type BitFieldOp struct {
+	Kind     BitFieldOpKind
+	Signed   bool
+	Bits     int
+	Offset   int64
+	Value    int64
+	Overflow BitFieldOverflow
+}
+
 //go:generate sh -c "rm -f mock/store.go && mockgen -source=store.go -destination=mock/store.go -package=mockkv"
 type Store interface {
 	Set(ctx context.Context, key string, value []byte, options *SetOptions) ([]byte, error)
 	Get(ctx context.Context, key string) ([]byte, error)
-	GetTTL(key string) (int64, error)
 	Incr(ctx context.Context, key string) (int64, error)
 	Decr(ctx context.Context, key string) (int64, error)
 	DecrBy(ctx context.Context, key string, decrement int64) (int64, error)
-	Del(ctx context.Context, keys ...string) (int64, error)
-	Exists(ctx context.Context, keys ...string) (int64, error)
 	Append(ctx context.Context, key string, value []byte) (int64, error)
 	Len(ctx context.Context, key string) (int64, error)
 	GetRange(ctx context.Context, key string, start, end int64) ([]byte, error)
 	SetRange(ctx context.Context, key string, start int, value []byte) (int, error)
+
+	// SetBit sets the bit at offset of the value stored at key to bit, growing the value with clear
+	// bits as needed, and returns the bit it replaced.
+	SetBit(ctx context.Context, key string, offset int64, bit byte) (byte, error)
+	// GetBit returns the bit at offset of the value stored at key, 0 past its end.
+	GetBit(ctx context.Context, key string, offset int64) (byte, error)
+	// BitCount returns how many bits of the value stored at key within r are set.
+	BitCount(ctx context.Context, key string, r BitRange) (int64, error)
+	// BitPos returns the position of the first bit of the value stored at key within r that is
+	// set to bit, or -1 if there is none.
+	BitPos(ctx context.Context, key string, bit byte, r BitRange) (int64, error)
+	// BitOp stores the result of op on the values stored at sources at destination, the shorter
+	// values being padded with clear bits, and returns its length. An empty result deletes
+	// destination instead.
+	BitOp(ctx context.Context, op BitOp, destination string, sources []string) (int64, error)
+	// BitField performs ops in order on the value stored at key and returns the result of each:
+	// the value read by GET, the one replaced by SET and the one INCRBY stores, or nil where
+	// BitFieldFail left the field unchanged. The value is grown to hold every field written.
+	BitField(ctx context.Context, key string, ops []BitFieldOp) ([]*int64, error)
+
+	// PFAdd adds elements to the HyperLogLog stored at key, creating it if needed, and reports
+	// whether it was created or a register was updated.
+	PFAdd(ctx context.Context, key string, elements []string) (bool, error)
+	// PFCount returns the cardinality estimated by the union of the HyperLogLogs stored at keys.
+	PFCount(ctx context.Context, keys []string) (uint64, error)
+	// PFMerge stores the union of the HyperLogLogs stored at destination and sources at destination.
+	PFMerge(ctx context.Context, destination string, sources []string) error
+	// PFDebug returns a copy of the HyperLogLog stored at key, or nil if key does not exist,
+	// converting it to dense first if toDense is set, and reports whether it was converted.
+	PFDebug(ctx context.Context, key string, toDense bool) (*hyperloglog.HLL, bool, error)
 }
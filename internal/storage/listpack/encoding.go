@@ -172,11 +172,21 @@ func lpDecodeBackLen(buf []byte, offset int) (uint64, int, error) {
 	return entryLen, bytesRead, nil
 }
 
+// asInteger returns the integer element is the canonical decimal form of, so that decoding it
+// gives element back: "7" is an integer while "07" and "+7" are strings.
+func asInteger(element []byte) (int, bool) {
+	v, err := strconv.Atoi(string(element))
+	if err != nil || strconv.Itoa(v) != string(element) {
+		return 0, false
+	}
+	return v, true
+}
+
 // encodedSize returns the exact number of bytes that encode() will write for element,
 // including header, data, and backlen fields. Mirrors encode()'s type-selection logic
 // and is used for pre-flight capacity checks before writing.
 func encodedSize(element []byte) int {
-	if v, err := strconv.Atoi(string(element)); err == nil {
+	if v, ok := asInteger(element); ok {
 		switch {
 		case v >= 0 && v <= 127:
 			return 2 // 1-byte enc + 1-byte backlen
@@ -209,7 +219,7 @@ func encode(buf []byte, offset int, element []byte) (int, error) {
 		return offset, fmt.Errorf("listpack overflow: need %d bytes at offset %d, buffer size %d", needed, offset, len(buf))
 	}
 
-	if v, err := strconv.Atoi(string(element)); err == nil {
+	if v, ok := asInteger(element); ok {
 		if v >= 0 && v <= 127 {
 			return encode7BitInt(buf, offset, v), nil
 		} else if v >= -4096 && v <= 4095 {
@@ -530,6 +530,8 @@ func TestEncodedSize(t *testing.T) {
 		{"6-bit string hello", []byte("hello"), 7},                 // 1 + 5 + 1
 		{"6-bit string max (63 bytes)", newStringOfLength(63), 65}, // 1 + 63 + 1
 		{"12-bit string (64 bytes)", newStringOfLength(64), 67},    // 2 + 64 + 1
+		{"leading zero is a string", []byte("07"), 4},
+		{"plus sign is a string", []byte("+7"), 4},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -31,6 +31,13 @@ func NewListPack(maxSize int, elements ...[]byte) *ListPack {
 	return lp
 }
 
+// Clone returns a deep copy of the list pack, sharing no memory with lp.
+func (lp *ListPack) Clone() *ListPack {
+	data := make([]byte, len(lp.data))
+	copy(data, lp.data)
+	return &ListPack{data: data, maxSize: lp.maxSize}
+}
+
 func (lp *ListPack) Length() int {
 	return int(binary.BigEndian.Uint16(lp.data[4:6]))
 }
@@ -44,6 +51,11 @@ func (lp *ListPack) ByteSize() int {
 	return int(binary.BigEndian.Uint32(lp.data[:4]))
 }
 
+// MemoryUsage returns the bytes allocated for the list pack, its maximum size being reserved up front.
+func (lp *ListPack) MemoryUsage() int {
+	return cap(lp.data)
+}
+
 func (lp *ListPack) Push(value []byte) (int, error) {
 	return lp._push(value)
 }
@@ -204,6 +216,15 @@ func IsLargerThanListPackSize(value []byte, maxListPackSize int) bool {
 	return encodedSize(value)+7 > maxListPackSize
 }
 
+// SizeOf returns the bytes taken by a listpack holding exactly elements.
+func SizeOf(elements ...[]byte) int {
+	size := 6 + 1
+	for _, element := range elements {
+		size += encodedSize(element)
+	}
+	return size
+}
+
 func NewPlainListPack(element []byte) *ListPack {
 	size := 6 + encodedSize(element) + 1
 	return NewListPack(size, element)
@@ -258,6 +279,7 @@ func (lp *ListPack) Traverse(cb func(interface{}, int, int, int) (bool, error))
 // InsertAt insert the given element at the given index.
 // negative index will result in error.
 // Any index greater than or equal to length of listpack will be clamped to listpack length
+// An element that does not fit in the remaining space results in error, leaving the listpack untouched.
 func (lp *ListPack) InsertAt(i int, bytes []byte) error {
 	if i < 0 {
 		return errors.New("negative index not supported")
@@ -285,6 +307,9 @@ func (lp *ListPack) InsertAt(i int, bytes []byte) error {
 		}
 	}
 	size := encodedSize(bytes)
+	if int(oldSize)+size > lp.maxSize {
+		return errListPackNotEnoughSize
+	}
 	encoded := make([]byte, size)
 	_, err = encode(encoded, 0, bytes)
 	if err != nil {
@@ -383,7 +408,7 @@ func (lp *ListPack) DeleteFromIndex(startingIndex int, count int) {
 	})
 
 	copy(lp.data[start:], lp.data[end+1:bytesSize])
-	bytesSize = bytesSize - (end - start)
+	bytesSize = bytesSize - (end - start + 1)
 	removedCount := endIndex - startingIndex + 1
 	binary.BigEndian.PutUint32(lp.data[:4], uint32(bytesSize))
 	binary.BigEndian.PutUint16(lp.data[4:6], uint16(size-removedCount))
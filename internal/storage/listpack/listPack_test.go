@@ -2,6 +2,7 @@ package listpack
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -272,6 +273,13 @@ func TestListPack_InsertAt(t *testing.T) {
 		v, _ := lp.AtIndex(0)
 		assert.Equal(t, "Hello World", string(v))
 	})
+	t.Run("Insert without enough space", func(t *testing.T) {
+		lp := NewListPack(20, []byte("first"))
+
+		err := lp.InsertAt(0, []byte("a value too long"))
+		assert.ErrorIs(t, err, errListPackNotEnoughSize)
+		assertContainsExactly(t, []string{"first"}, lp)
+	})
 }
 
 func TestListPack_ReplaceAt(t *testing.T) {
@@ -456,6 +464,19 @@ func TestListPack_DeleteFromIndex(t *testing.T) {
 		assertContainsExactly(t, []string{"First", "Second"}, lp)
 	})
 
+	t.Run("push after delete", func(t *testing.T) {
+		lp := NewListPack(1024, []byte("Hello"), []byte("World"))
+		size := lp.ByteSize()
+		lp.DeleteFromIndex(0, 1)
+		_, _ = lp.Push([]byte("Hello"))
+		assert.Equal(t, size, lp.ByteSize())
+		assertContainsExactly(t, []string{"World", "Hello"}, lp)
+	})
+}
+
+func TestListPack_NonCanonicalIntegersRoundTrip(t *testing.T) {
+	lp := NewListPack(1024, []byte("007"), []byte("+7"), []byte("-0"), []byte("7"))
+	assertContainsExactly(t, []string{"007", "+7", "-0", "7"}, lp)
 }
 
 func assertContainsExactly(t *testing.T, expected []string, lp *ListPack) {
@@ -465,3 +486,24 @@ func assertContainsExactly(t *testing.T, expected []string, lp *ListPack) {
 		assert.Equal(t, expectedElem, string(actual), fmt.Sprintf("Failed at index %d", i))
 	}
 }
+
+func TestListPack_CloneSharesNoMemory(t *testing.T) {
+	lp := NewListPack(256, []byte("a"), []byte("b"))
+	clone := lp.Clone()
+
+	_, _ = clone.Push([]byte("c"))
+	_ = lp.ReplaceAt(0, []byte("z"))
+
+	original, _ := lp.LRange(0, 10)
+	copied, _ := clone.LRange(0, 10)
+	assert.Equal(t, [][]byte{[]byte("z"), []byte("b")}, original)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte("c")}, copied)
+}
+
+func TestListPack_SizeOfFitsExactly(t *testing.T) {
+	elements := [][]byte{[]byte("1"), []byte("-5000"), []byte("field"), []byte(strings.Repeat("x", 100))}
+	lp := NewEmptyListPack(SizeOf(elements...))
+	_, err := lp.PushAllOrNone(elements...)
+	assert.NoError(t, err)
+	assert.Equal(t, SizeOf(elements...), lp.ByteSize())
+}
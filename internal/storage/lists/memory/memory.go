@@ -1,78 +1,114 @@
 package memory
 
 import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
 	"avacado/internal/storage/lists"
 	"context"
 )
 
 // ListMemoryStore represent an in-memory implementation of Lists interface.
-// It's a key value store where each value is a quicklist.
+// Each list is a quicklist stored in the shared keyspace, a list left empty by a pop is removed from it.
 // All methods are called exclusively by the executor goroutine — no locking needed.
 type ListMemoryStore struct {
-	lists           map[string]*quickList
+	keyspace        *memkeyspace.Keyspace
 	maxListPackSize int
 }
 
-// NewListMemoryStore creates a ListMemoryStore with given maxListPackSize
-func NewListMemoryStore(maxListPackSize int) *ListMemoryStore {
+// NewListMemoryStore creates a ListMemoryStore on the given keyspace with given maxListPackSize
+func NewListMemoryStore(ks *memkeyspace.Keyspace, maxListPackSize int) *ListMemoryStore {
 	return &ListMemoryStore{
-		lists:           make(map[string]*quickList),
+		keyspace:        ks,
 		maxListPackSize: maxListPackSize,
 	}
 }
 
+// lookup returns the quicklist stored at key, or nil if key does not exist.
+// ErrWrongType is returned if key holds a value of another type.
+func (l *ListMemoryStore) lookup(key string) (*quickList, error) {
+	entry, err := l.keyspace.LookupOfType(key, keyspace.TypeList)
+	if entry == nil {
+		return nil, err
+	}
+	return entry.Value.(*quickList), nil
+}
+
+// lookupOrCreate returns the quicklist stored at key, creating an empty one if key does not exist.
+func (l *ListMemoryStore) lookupOrCreate(key string) (*quickList, error) {
+	list, err := l.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = newQuickList(l.maxListPackSize)
+		l.keyspace.Put(key, keyspace.TypeList, list)
+	}
+	return list, nil
+}
+
+// removeIfEmpty deletes key once its list has no element left, as Redis never keeps empty lists.
+func (l *ListMemoryStore) removeIfEmpty(key string, list *quickList) {
+	if list.length() == 0 {
+		l.keyspace.Remove(key)
+	}
+}
+
 // LPush add the given values at the head of quicklist specified by the given key.
 // If key is not present a new quicklist entry is created first.
 func (l *ListMemoryStore) LPush(ctx context.Context, key string, values ...[]byte) (int, error) {
-	list, ok := l.lists[key]
-	if !ok {
-		list = newQuickList(l.maxListPackSize)
-		l.lists[key] = list
+	list, err := l.lookupOrCreate(key)
+	if err != nil {
+		return 0, err
 	}
 	length := list.lPush(values)
+	l.keyspace.Resized(key)
 	return length, nil
 }
 
 // RPush add the given values at the end of quicklist specified by the given key.
 // If key is not present a new quicklist entry is created first.
 func (l *ListMemoryStore) RPush(ctx context.Context, key string, values ...[]byte) (int, error) {
-	list, ok := l.lists[key]
-	if !ok {
-		list = newQuickList(l.maxListPackSize)
-		l.lists[key] = list
+	list, err := l.lookupOrCreate(key)
+	if err != nil {
+		return 0, err
 	}
 	length := list.rPush(values)
+	l.keyspace.Resized(key)
 	return length, nil
 }
 
 // LPop remove the given number of values from the head of quicklist specified by the given key.
 // If key is not present a nil slice is returned.
 func (l *ListMemoryStore) LPop(ctx context.Context, key string, count int) ([][]byte, error) {
-	list, ok := l.lists[key]
-	if !ok {
-		return nil, nil
+	list, err := l.lookup(key)
+	if list == nil {
+		return nil, err
 	}
 	elements, _ := list.lPop(count)
+	l.removeIfEmpty(key, list)
+	l.keyspace.Resized(key)
 	return elements, nil
 }
 
 // RPop remove the given number of values from the end of quicklist specified by the given key.
 // If key is not present a nil slice is returned.
 func (l *ListMemoryStore) RPop(ctx context.Context, key string, count int) ([][]byte, error) {
-	list, ok := l.lists[key]
-	if !ok {
-		return nil, nil
+	list, err := l.lookup(key)
+	if list == nil {
+		return nil, err
 	}
 	elements, _ := list.rPop(count)
+	l.removeIfEmpty(key, list)
+	l.keyspace.Resized(key)
 	return elements, nil
 }
 
 // LIndex finds an element in ListPack from left side.
 // Returns nil if there is no element at the given index.
 func (l *ListMemoryStore) LIndex(ctx context.Context, key string, index int) ([]byte, error) {
-	list, found := l.lists[key]
-	if !found {
-		return nil, nil
+	list, err := l.lookup(key)
+	if list == nil {
+		return nil, err
 	}
 	element, _ := list.atIndex(index)
 	return element, nil
@@ -80,16 +116,19 @@ func (l *ListMemoryStore) LIndex(ctx context.Context, key string, index int) ([]
 
 // Len returns the count of elements in the list at the given key.
 func (l *ListMemoryStore) Len(ctx context.Context, key string) (int, error) {
-	list, ok := l.lists[key]
-	if !ok {
-		return 0, nil
+	list, err := l.lookup(key)
+	if list == nil {
+		return 0, err
 	}
 	return list.length(), nil
 }
 
 func (l *ListMemoryStore) LRange(ctx context.Context, key string, start, end int64) ([][]byte, error) {
-	ql, ok := l.lists[key]
-	if !ok {
+	ql, err := l.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if ql == nil {
 		return [][]byte{}, nil
 	}
 	return ql.lRange(start, end), nil
@@ -100,9 +139,13 @@ func (l *ListMemoryStore) LMove(
 	source, destination string,
 	sourceDirection, destinationDirection lists.Direction,
 ) ([]byte, error) {
-	sList, ok := l.lists[source]
-	if !ok {
-		return nil, nil
+	sList, err := l.lookup(source)
+	if sList == nil {
+		return nil, err
+	}
+	// Check the destination type before popping so a WRONGTYPE leaves the source untouched.
+	if _, err := l.lookup(destination); err != nil {
+		return nil, err
 	}
 	var poppedElements [][]byte
 	if sourceDirection == lists.Left {
@@ -114,17 +157,16 @@ func (l *ListMemoryStore) LMove(
 	if len(poppedElements) == 0 {
 		return nil, nil
 	}
+	l.removeIfEmpty(source, sList)
+	l.keyspace.Resized(source)
 
-	dList, ok := l.lists[destination]
-	if !ok {
-		dList = newQuickList(l.maxListPackSize)
-		l.lists[destination] = dList
-	}
+	dList, _ := l.lookupOrCreate(destination)
 	if destinationDirection == lists.Left {
 		dList.lPush(poppedElements)
 	} else {
 		dList.rPush(poppedElements)
 	}
+	l.keyspace.Resized(destination)
 
 	return poppedElements[0], nil
 }
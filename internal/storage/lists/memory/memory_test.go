@@ -1,6 +1,8 @@
 package memory
 
 import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
 	"avacado/internal/storage/lists"
 	"context"
 	"testing"
@@ -9,7 +11,7 @@ import (
 )
 
 func TestListMemoryStore_RPush(t *testing.T) {
-	store := NewListMemoryStore(1024)
+	store := NewListMemoryStore(memkeyspace.NewKeyspace(), 1024)
 
 	size, err := store.RPush(context.Background(), "Foo", []byte("Hello"), []byte("World"))
 	assert.NoError(t, err)
@@ -26,7 +28,7 @@ func TestListMemoryStore_RPush(t *testing.T) {
 
 func TestListMemoryStore_RPop(t *testing.T) {
 	t.Run("Pop from existing list", func(t *testing.T) {
-		store := NewListMemoryStore(1024)
+		store := NewListMemoryStore(memkeyspace.NewKeyspace(), 1024)
 
 		_, _ = store.RPush(context.Background(), "Foo", []byte("Hello"), []byte("World"))
 		elements, err := store.RPop(context.Background(), "Foo", 3)
@@ -37,7 +39,7 @@ func TestListMemoryStore_RPop(t *testing.T) {
 	})
 
 	t.Run("Pop from a non existing list", func(t *testing.T) {
-		store := NewListMemoryStore(1024)
+		store := NewListMemoryStore(memkeyspace.NewKeyspace(), 1024)
 
 		elements, err := store.RPop(context.Background(), "non-existing-key", 12)
 		assert.NoError(t, err)
@@ -47,7 +49,7 @@ func TestListMemoryStore_RPop(t *testing.T) {
 
 func TestListMemoryStore_Len(t *testing.T) {
 	t.Run("Len of existing list", func(t *testing.T) {
-		store := NewListMemoryStore(1024)
+		store := NewListMemoryStore(memkeyspace.NewKeyspace(), 1024)
 
 		_, _ = store.RPush(context.Background(), "Foo", []byte("Hello"), []byte("World"))
 		l, err := store.Len(context.Background(), "Foo")
@@ -56,12 +58,12 @@ func TestListMemoryStore_Len(t *testing.T) {
 	})
 
 	t.Run("Len of non existing list", func(t *testing.T) {
-		NewListMemoryStore(1024)
+		NewListMemoryStore(memkeyspace.NewKeyspace(), 1024)
 	})
 }
 
 func setupListStoreForLMove() *ListMemoryStore {
-	store := NewListMemoryStore(20)
+	store := NewListMemoryStore(memkeyspace.NewKeyspace(), 20)
 
 	_, _ = store.RPush(
 		context.Background(),
@@ -175,3 +177,50 @@ func TestListMemoryStore_LMove(t *testing.T) {
 		assert.Nil(t, element)
 	})
 }
+
+func TestListMemoryStore_WrongType(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	store := NewListMemoryStore(ks, 1024)
+	ctx := context.Background()
+	ks.Put("str", keyspace.TypeString, struct{}{})
+
+	_, err := store.LPush(ctx, "str", []byte("a"))
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.RPush(ctx, "str", []byte("a"))
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.LPop(ctx, "str", 1)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.RPop(ctx, "str", 1)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.Len(ctx, "str")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.LIndex(ctx, "str", 0)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = store.LRange(ctx, "str", 0, -1)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+
+	// LMOVE into a key of another type leaves the source untouched
+	_, _ = store.RPush(ctx, "src", []byte("a"))
+	_, err = store.LMove(ctx, "src", "str", lists.Left, lists.Right)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	length, _ := store.Len(ctx, "src")
+	assert.Equal(t, 1, length)
+}
+
+func TestListMemoryStore_RemovesEmptyList(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	store := NewListMemoryStore(ks, 1024)
+	ctx := context.Background()
+
+	_, _ = store.RPush(ctx, "Foo", []byte("a"), []byte("b"))
+	_, _ = store.LPop(ctx, "Foo", 1)
+	assert.Equal(t, 1, ks.Len())
+
+	_, _ = store.RPop(ctx, "Foo", 1)
+	assert.Equal(t, 0, ks.Len())
+
+	_, _ = store.RPush(ctx, "src", []byte("a"))
+	_, _ = store.LMove(ctx, "src", "dst", lists.Left, lists.Right)
+	_, srcExists := ks.Lookup("src")
+	assert.False(t, srcExists)
+}
@@ -6,6 +6,13 @@ import (
 
 const defaultMaxListPackSize = 1024 * 8
 
+const (
+	// quickListOverhead approximates the bytes used by a quickList besides its list packs.
+	quickListOverhead = 64
+	// listPackNodeOverhead approximates the bytes used by each list pack besides its data.
+	listPackNodeOverhead = 40
+)
+
 // quickList represents a quick list data structure used for storing lists in memory.
 // All methods are called exclusively by the executor goroutine — no locking needed.
 type quickList struct {
@@ -22,6 +29,35 @@ func newQuickList(maxListPackSize int) *quickList {
 	}
 }
 
+// Encoding returns "quicklist", lists being always stored as a quick list.
+func (ql *quickList) Encoding() string {
+	return "quicklist"
+}
+
+// Nodes returns the number of list packs of the quick list, reported as ql_nodes by DEBUG OBJECT.
+func (ql *quickList) Nodes() int {
+	return len(ql.lps)
+}
+
+// Clone returns a deep copy of the quick list, every list pack included.
+func (ql *quickList) Clone() any {
+	lps := make([]*listpack.ListPack, len(ql.lps))
+	for i, lp := range ql.lps {
+		lps[i] = lp.Clone()
+	}
+	return &quickList{lps: lps, maxListPackSize: ql.maxListPackSize, size: ql.size}
+}
+
+// MemoryUsage returns the bytes allocated for the quick list. List packs reserve their
+// maximum size up front, so the usage grows by steps of a whole node.
+func (ql *quickList) MemoryUsage() int64 {
+	size := int64(quickListOverhead)
+	for _, lp := range ql.lps {
+		size += int64(lp.MemoryUsage()) + listPackNodeOverhead
+	}
+	return size
+}
+
 func (ql *quickList) length() int {
 	return ql.size
 }
@@ -37,7 +73,7 @@ func (ql *quickList) lPush(elements [][]byte) int {
 				ql.lps = append([]*listpack.ListPack{lp}, ql.lps...)
 			}
 			ql.size++
-			return ql.size
+			continue
 		}
 		_, err := ql.lps[0].LPush(element)
 		if err != nil {
@@ -63,7 +99,7 @@ func (ql *quickList) rPush(elements [][]byte) int {
 				ql.lps = append(ql.lps, lp)
 			}
 			ql.size++
-			return ql.size
+			continue
 		}
 		_, err := ql.lps[len(ql.lps)-1].Push(element)
 		if err != nil {
@@ -119,3 +119,34 @@ func TestQuickList_LRange(t *testing.T) {
 		assert.Equal(t, elements[2:], ql.lRange(2, -1))
 	})
 }
+
+func TestQuickList_CloneIsIndependent(t *testing.T) {
+	ql := newQuickList(32)
+	ql.rPush([][]byte{[]byte("one"), []byte("two"), []byte("three"), []byte("four"), []byte("five")})
+	clone := ql.Clone().(*quickList)
+
+	clone.lPop(2)
+	ql.rPush([][]byte{[]byte("six")})
+
+	assert.Equal(t, 6, ql.length())
+	assert.Equal(t, 3, clone.length())
+	assert.Equal(t, [][]byte{[]byte("three"), []byte("four"), []byte("five")}, clone.lRange(0, -1))
+}
+
+func TestQuickList_Nodes(t *testing.T) {
+	ql := newQuickList(20)
+	assert.Equal(t, 1, ql.Nodes())
+
+	ql.rPush([][]byte{[]byte("one"), []byte("two"), []byte("three"), []byte("four"), []byte("five")})
+	assert.Greater(t, ql.Nodes(), 1)
+}
+
+func TestQuickList_PushKeepsElementsAfterALargeOne(t *testing.T) {
+	ql := newQuickList(20)
+	large := []byte("an element larger than a list pack")
+	ql.rPush([][]byte{large, []byte("tail")})
+	ql.lPush([][]byte{large, []byte("head")})
+
+	assert.Equal(t, 4, ql.length())
+	assert.Equal(t, [][]byte{[]byte("head"), large, large, []byte("tail")}, ql.lRange(0, -1))
+}
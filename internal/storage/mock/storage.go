@@ -10,9 +10,15 @@
 package mocksstorage
 
 import (
+	storage "avacado/internal/storage"
 	hashmaps "avacado/internal/storage/hashmaps"
+	keyspace "avacado/internal/storage/keyspace"
 	kv "avacado/internal/storage/kv"
 	lists "avacado/internal/storage/lists"
+	sets "avacado/internal/storage/sets"
+	streams "avacado/internal/storage/streams"
+	zsets "avacado/internal/storage/zsets"
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -56,6 +62,20 @@ func (mr *MockStorageMockRecorder) KV() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KV", reflect.TypeOf((*MockStorage)(nil).KV))
 }
 
+// Keyspace mocks base method.
+func (m *MockStorage) Keyspace() keyspace.Keyspace {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Keyspace")
+	ret0, _ := ret[0].(keyspace.Keyspace)
+	return ret0
+}
+
+// Keyspace indicates an expected call of Keyspace.
+func (mr *MockStorageMockRecorder) Keyspace() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keyspace", reflect.TypeOf((*MockStorage)(nil).Keyspace))
+}
+
 // Lists mocks base method.
 func (m *MockStorage) Lists() lists.Lists {
 	m.ctrl.T.Helper()
@@ -83,3 +103,139 @@ func (mr *MockStorageMockRecorder) Maps() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Maps", reflect.TypeOf((*MockStorage)(nil).Maps))
 }
+
+// Sets mocks base method.
+func (m *MockStorage) Sets() sets.Sets {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sets")
+	ret0, _ := ret[0].(sets.Sets)
+	return ret0
+}
+
+// Sets indicates an expected call of Sets.
+func (mr *MockStorageMockRecorder) Sets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sets", reflect.TypeOf((*MockStorage)(nil).Sets))
+}
+
+// Streams mocks base method.
+func (m *MockStorage) Streams() streams.Streams {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Streams")
+	ret0, _ := ret[0].(streams.Streams)
+	return ret0
+}
+
+// Streams indicates an expected call of Streams.
+func (mr *MockStorageMockRecorder) Streams() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Streams", reflect.TypeOf((*MockStorage)(nil).Streams))
+}
+
+// ZSets mocks base method.
+func (m *MockStorage) ZSets() zsets.ZSets {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZSets")
+	ret0, _ := ret[0].(zsets.ZSets)
+	return ret0
+}
+
+// ZSets indicates an expected call of ZSets.
+func (mr *MockStorageMockRecorder) ZSets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZSets", reflect.TypeOf((*MockStorage)(nil).ZSets))
+}
+
+// MockDatabases is a mock of Databases interface.
+type MockDatabases struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatabasesMockRecorder
+	isgomock struct{}
+}
+
+// MockDatabasesMockRecorder is the mock recorder for MockDatabases.
+type MockDatabasesMockRecorder struct {
+	mock *MockDatabases
+}
+
+// NewMockDatabases creates a new mock instance.
+func NewMockDatabases(ctrl *gomock.Controller) *MockDatabases {
+	mock := &MockDatabases{ctrl: ctrl}
+	mock.recorder = &MockDatabasesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDatabases) EXPECT() *MockDatabasesMockRecorder {
+	return m.recorder
+}
+
+// Copy mocks base method.
+func (m *MockDatabases) Copy(ctx context.Context, source string, from int, destination string, to int, replace bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", ctx, source, from, destination, to, replace)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Copy indicates an expected call of Copy.
+func (mr *MockDatabasesMockRecorder) Copy(ctx, source, from, destination, to, replace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockDatabases)(nil).Copy), ctx, source, from, destination, to, replace)
+}
+
+// Count mocks base method.
+func (m *MockDatabases) Count() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockDatabasesMockRecorder) Count() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockDatabases)(nil).Count))
+}
+
+// DB mocks base method.
+func (m *MockDatabases) DB(index int) storage.Storage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DB", index)
+	ret0, _ := ret[0].(storage.Storage)
+	return ret0
+}
+
+// DB indicates an expected call of DB.
+func (mr *MockDatabasesMockRecorder) DB(index any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*MockDatabases)(nil).DB), index)
+}
+
+// Move mocks base method.
+func (m *MockDatabases) Move(ctx context.Context, key string, from, to int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, key, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockDatabasesMockRecorder) Move(ctx, key, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockDatabases)(nil).Move), ctx, key, from, to)
+}
+
+// Swap mocks base method.
+func (m *MockDatabases) Swap(first, second int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Swap", first, second)
+}
+
+// Swap indicates an expected call of Swap.
+func (mr *MockDatabasesMockRecorder) Swap(first, second any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Swap", reflect.TypeOf((*MockDatabases)(nil).Swap), first, second)
+}
@@ -0,0 +1,242 @@
+// Package rax implements the radix tree Redis calls rax, which streams use to index their
+// listpack nodes by the ID of their first entry.
+//
+// Keys sharing a prefix share the nodes spelling it, and a node holding no value with a single
+// child is merged into it, so the tree stays shallow for keys as long as stream IDs. Keys are
+// visited in lexicographic byte order, which for big-endian IDs is the order of the IDs.
+package rax
+
+import (
+	"slices"
+	"strings"
+)
+
+type node[V any] struct {
+	// prefix is the part of the key spelled between the parent and this node.
+	prefix string
+	// children are ordered by the first byte of their prefix, which no two of them share.
+	children []*node[V]
+	value    V
+	hasValue bool
+}
+
+// childIndex returns the index of the child whose prefix starts with b, or where it would be
+// inserted.
+func (n *node[V]) childIndex(b byte) (int, bool) {
+	return slices.BinarySearchFunc(n.children, b, func(child *node[V], b byte) int {
+		return int(child.prefix[0]) - int(b)
+	})
+}
+
+// Tree maps keys to values, visited in key order.
+// All methods are called exclusively by the executor goroutine — no locking needed.
+type Tree[V any] struct {
+	root  *node[V]
+	len   int
+	nodes int
+}
+
+func New[V any]() *Tree[V] {
+	return &Tree[V]{root: &node[V]{}, nodes: 1}
+}
+
+// Len returns the number of keys.
+func (t *Tree[V]) Len() int {
+	return t.len
+}
+
+// Nodes returns the number of nodes, the root included.
+func (t *Tree[V]) Nodes() int {
+	return t.nodes
+}
+
+func (t *Tree[V]) Get(key string) (V, bool) {
+	n := t.root
+	for key != "" {
+		i, found := n.childIndex(key[0])
+		if !found || !strings.HasPrefix(key, n.children[i].prefix) {
+			var zero V
+			return zero, false
+		}
+		key = key[len(n.children[i].prefix):]
+		n = n.children[i]
+	}
+	return n.value, n.hasValue
+}
+
+// Set maps key to value and reports whether key was added rather than updated.
+func (t *Tree[V]) Set(key string, value V) bool {
+	n := t.root
+	for key != "" {
+		i, found := n.childIndex(key[0])
+		if !found {
+			n.children = slices.Insert(n.children, i, &node[V]{prefix: key, value: value, hasValue: true})
+			t.len++
+			t.nodes++
+			return true
+		}
+		child := n.children[i]
+		common := commonPrefixLength(key, child.prefix)
+		if common < len(child.prefix) {
+			// key leaves the prefix of child midway: split child where they part.
+			split := &node[V]{prefix: child.prefix[:common], children: []*node[V]{child}}
+			child.prefix = child.prefix[common:]
+			n.children[i] = split
+			t.nodes++
+			child = split
+		}
+		key = key[common:]
+		n = child
+	}
+	added := !n.hasValue
+	n.value, n.hasValue = value, true
+	if added {
+		t.len++
+	}
+	return added
+}
+
+// Delete removes key and returns the value it mapped to.
+func (t *Tree[V]) Delete(key string) (V, bool) {
+	var zero V
+	var path []*node[V]
+	n := t.root
+	for key != "" {
+		i, found := n.childIndex(key[0])
+		if !found || !strings.HasPrefix(key, n.children[i].prefix) {
+			return zero, false
+		}
+		path = append(path, n)
+		key = key[len(n.children[i].prefix):]
+		n = n.children[i]
+	}
+	if !n.hasValue {
+		return zero, false
+	}
+	value := n.value
+	n.value, n.hasValue = zero, false
+	t.len--
+	t.compact(path, n)
+	return value, true
+}
+
+// compact removes n if it has neither value nor children, or merges it into its only child,
+// path being the ancestors of n.
+func (t *Tree[V]) compact(path []*node[V], n *node[V]) {
+	if n == t.root || n.hasValue {
+		return
+	}
+	parent := path[len(path)-1]
+	i, _ := parent.childIndex(n.prefix[0])
+	switch len(n.children) {
+	case 0:
+		parent.children = slices.Delete(parent.children, i, i+1)
+		t.nodes--
+		t.compact(path[:len(path)-1], parent)
+	case 1:
+		child := n.children[0]
+		child.prefix = n.prefix + child.prefix
+		parent.children[i] = child
+		t.nodes--
+	}
+}
+
+// Ascend calls fn for every key not below from, in ascending order, until fn returns false.
+func (t *Tree[V]) Ascend(from string, fn func(key string, value V) bool) {
+	t.root.ascend("", from, fn)
+}
+
+func (n *node[V]) ascend(path, from string, fn func(string, V) bool) bool {
+	path += n.prefix
+	// Every key under n starts with path, so comparing path with from may settle them all.
+	if from != "" {
+		m := min(len(path), len(from))
+		if c := strings.Compare(path[:m], from[:m]); c < 0 {
+			return true
+		} else if c > 0 || len(path) >= len(from) {
+			from = ""
+		}
+	}
+	// With from left, path is a strict prefix of it, so below it.
+	if n.hasValue && from == "" && !fn(path, n.value) {
+		return false
+	}
+	for _, child := range n.children {
+		if !child.ascend(path, from, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Descend calls fn for every key not above to, in descending order, until fn returns false.
+func (t *Tree[V]) Descend(to string, fn func(key string, value V) bool) {
+	t.root.descend("", to, true, fn)
+}
+
+// DescendAll calls fn for every key in descending order, until fn returns false.
+func (t *Tree[V]) DescendAll(fn func(key string, value V) bool) {
+	t.root.descend("", "", false, fn)
+}
+
+func (n *node[V]) descend(path, to string, bounded bool, fn func(string, V) bool) bool {
+	path += n.prefix
+	if bounded {
+		m := min(len(path), len(to))
+		if c := strings.Compare(path[:m], to[:m]); c > 0 || (c == 0 && len(path) > len(to)) {
+			return true
+		} else if c < 0 {
+			bounded = false
+		}
+	}
+	for i := len(n.children) - 1; i >= 0; i-- {
+		if !n.children[i].descend(path, to, bounded, fn) {
+			return false
+		}
+	}
+	return !n.hasValue || fn(path, n.value)
+}
+
+// First returns the lowest key and its value.
+func (t *Tree[V]) First() (string, V, bool) {
+	var key string
+	var value V
+	found := false
+	t.Ascend("", func(k string, v V) bool {
+		key, value, found = k, v, true
+		return false
+	})
+	return key, value, found
+}
+
+// Last returns the highest key and its value.
+func (t *Tree[V]) Last() (string, V, bool) {
+	var key string
+	var value V
+	found := false
+	t.DescendAll(func(k string, v V) bool {
+		key, value, found = k, v, true
+		return false
+	})
+	return key, value, found
+}
+
+// Floor returns the highest key not above key, and its value.
+func (t *Tree[V]) Floor(key string) (string, V, bool) {
+	var floor string
+	var value V
+	found := false
+	t.Descend(key, func(k string, v V) bool {
+		floor, value, found = k, v, true
+		return false
+	})
+	return floor, value, found
+}
+
+func commonPrefixLength(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,135 @@
+package rax
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func keys[V any](t *Tree[V]) []string {
+	var keys []string
+	t.Ascend("", func(key string, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+func TestTree_SetGetDelete(t *testing.T) {
+	tree := New[int]()
+	for i, key := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "rom", ""} {
+		assert.True(t, tree.Set(key, i), key)
+	}
+	assert.False(t, tree.Set("ruber", 42))
+	assert.Equal(t, 9, tree.Len())
+
+	value, found := tree.Get("ruber")
+	assert.True(t, found)
+	assert.Equal(t, 42, value)
+	_, found = tree.Get("rub")
+	assert.False(t, found)
+	_, found = tree.Get("romanes")
+	assert.False(t, found)
+	value, found = tree.Get("")
+	assert.True(t, found)
+	assert.Equal(t, 8, value)
+
+	assert.Equal(t, []string{"", "rom", "romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"}, keys(tree))
+
+	_, found = tree.Delete("rub")
+	assert.False(t, found)
+	value, found = tree.Delete("romane")
+	assert.True(t, found)
+	assert.Equal(t, 0, value)
+	_, found = tree.Get("romane")
+	assert.False(t, found)
+	_, found = tree.Get("romanus")
+	assert.True(t, found)
+	assert.Equal(t, 8, tree.Len())
+}
+
+func TestTree_DeleteCompactsNodes(t *testing.T) {
+	tree := New[int]()
+	tree.Set("test", 1)
+	assert.Equal(t, 2, tree.Nodes())
+	tree.Set("team", 2)
+	tree.Set("toast", 3)
+	// root, "t", "e", "st", "am", "oast"
+	assert.Equal(t, 6, tree.Nodes())
+
+	tree.Delete("team")
+	// root, "t", "est", "oast"
+	assert.Equal(t, 4, tree.Nodes())
+	tree.Delete("toast")
+	tree.Delete("test")
+	assert.Equal(t, 1, tree.Nodes())
+	assert.Equal(t, 0, tree.Len())
+	assert.Empty(t, keys(tree))
+}
+
+func TestTree_SeeksInOrder(t *testing.T) {
+	tree := New[int]()
+	for _, key := range []string{"b", "ba", "bb", "c", "ca"} {
+		tree.Set(key, 0)
+	}
+
+	var ascended []string
+	tree.Ascend("ba", func(key string, _ int) bool {
+		ascended = append(ascended, key)
+		return len(ascended) < 3
+	})
+	assert.Equal(t, []string{"ba", "bb", "c"}, ascended)
+
+	var descended []string
+	tree.Descend("bz", func(key string, _ int) bool {
+		descended = append(descended, key)
+		return true
+	})
+	assert.Equal(t, []string{"bb", "ba", "b"}, descended)
+
+	key, _, found := tree.Floor("b0")
+	assert.True(t, found)
+	assert.Equal(t, "b", key)
+	_, _, found = tree.Floor("a")
+	assert.False(t, found)
+	key, _, _ = tree.First()
+	assert.Equal(t, "b", key)
+	key, _, _ = tree.Last()
+	assert.Equal(t, "ca", key)
+}
+
+func TestTree_OrdersBigEndianKeysAtScale(t *testing.T) {
+	tree := New[uint64]()
+	expected := make([]string, 0, 2000)
+	seen := make(map[uint64]bool)
+	for len(expected) < 2000 {
+		n := rand.Uint64N(1 << 20)
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		key := binary.BigEndian.AppendUint64(nil, n)
+		tree.Set(string(key), n)
+		expected = append(expected, string(key))
+	}
+	slices.Sort(expected)
+	assert.Equal(t, expected, keys(tree))
+
+	for i, key := range expected {
+		if i%2 == 0 {
+			_, found := tree.Delete(key)
+			assert.True(t, found, strconv.Itoa(i))
+		}
+	}
+	var remaining []string
+	for i, key := range expected {
+		if i%2 == 1 {
+			remaining = append(remaining, key)
+		}
+	}
+	assert.Equal(t, remaining, keys(tree))
+}
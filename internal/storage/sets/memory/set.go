@@ -0,0 +1,306 @@
+package memory
+
+import (
+	"avacado/internal/storage/dict"
+	"avacado/internal/storage/listpack"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+)
+
+const defaultMaxListPackSize = 1024 * 8
+
+const (
+	// setOverhead approximates the bytes used by a Set besides its members.
+	setOverhead = 64
+	// setMemberOverhead approximates the bytes used by each dict entry of the hashtable encoding besides its member.
+	setMemberOverhead = 48
+	// intsetMemberSize is the bytes used by each member of the intset encoding.
+	intsetMemberSize = 8
+)
+
+type encodingType = int
+
+const (
+	intsetEncoding    encodingType = 0
+	listpackEncoding  encodingType = 1
+	hashtableEncoding encodingType = 2
+)
+
+// Config holds the thresholds at which a set converts to a larger encoding, Redis's
+// set-max-intset-entries, set-max-listpack-entries and set-max-listpack-value.
+type Config struct {
+	// MaxIntsetEntries is the most members a set of integers holds as an intset.
+	MaxIntsetEntries int
+	// MaxListpackEntries is the most members a set holds as a listpack.
+	MaxListpackEntries int
+	// MaxListpackValue is the longest member, in bytes, a set holds as a listpack.
+	MaxListpackValue int
+}
+
+// DefaultConfig returns the thresholds Redis uses by default.
+func DefaultConfig() Config {
+	return Config{MaxIntsetEntries: 512, MaxListpackEntries: 128, MaxListpackValue: 64}
+}
+
+// Set stores distinct members in the most compact encoding that fits them: a sorted slice of
+// integers while every member is an integer, then a listpack while the set is small, and a dict
+// beyond. Like Redis, a set never converts back to a smaller encoding.
+// All methods are called exclusively by the executor goroutine — no locking needed.
+type Set struct {
+	config   *Config
+	encoding encodingType
+	intset   []int64
+	lp       *listpack.ListPack
+	hash     *dict.Dict[struct{}]
+	// hashBytes is the length of every member held by hash.
+	hashBytes int64
+}
+
+// newSet creates an empty set in the encoding fitting sizeHint members like first.
+func newSet(config *Config, first string, sizeHint int) *Set {
+	s := &Set{config: config}
+	if _, ok := asInteger(first); ok && sizeHint <= config.MaxIntsetEntries {
+		s.encoding = intsetEncoding
+	} else if len(first) <= config.MaxListpackValue && sizeHint <= config.MaxListpackEntries {
+		s.encoding = listpackEncoding
+		s.lp = listpack.NewEmptyListPack(defaultMaxListPackSize)
+	} else {
+		s.encoding = hashtableEncoding
+		s.hash = dict.New[struct{}]()
+	}
+	return s
+}
+
+// asInteger returns the integer member is the canonical decimal form of, the members an intset holds.
+func asInteger(member string) (int64, bool) {
+	n, err := strconv.ParseInt(member, 10, 64)
+	if err != nil || strconv.FormatInt(n, 10) != member {
+		return 0, false
+	}
+	return n, true
+}
+
+// Encoding returns "intset", "listpack" or "hashtable", the names Redis gives to the three encodings.
+func (s *Set) Encoding() string {
+	switch s.encoding {
+	case intsetEncoding:
+		return "intset"
+	case listpackEncoding:
+		return "listpack"
+	default:
+		return "hashtable"
+	}
+}
+
+// Clone returns a deep copy of the set in the same encoding.
+func (s *Set) Clone() any {
+	clone := &Set{config: s.config, encoding: s.encoding, hashBytes: s.hashBytes}
+	switch s.encoding {
+	case intsetEncoding:
+		clone.intset = slices.Clone(s.intset)
+	case listpackEncoding:
+		clone.lp = s.lp.Clone()
+	default:
+		clone.hash = dict.New[struct{}]()
+		s.hash.Range(func(member string, _ struct{}) bool {
+			clone.hash.Set(member, struct{}{})
+			return true
+		})
+	}
+	return clone
+}
+
+// MemoryUsage returns the approximate bytes allocated for the set.
+func (s *Set) MemoryUsage() int64 {
+	switch s.encoding {
+	case intsetEncoding:
+		return setOverhead + int64(cap(s.intset))*intsetMemberSize
+	case listpackEncoding:
+		return setOverhead + int64(s.lp.MemoryUsage())
+	default:
+		return setOverhead + s.hashBytes + int64(s.hash.Len())*setMemberOverhead
+	}
+}
+
+// Len returns the number of members.
+func (s *Set) Len() int {
+	switch s.encoding {
+	case intsetEncoding:
+		return len(s.intset)
+	case listpackEncoding:
+		return s.lp.Length()
+	default:
+		return s.hash.Len()
+	}
+}
+
+// Contains reports whether member belongs to the set.
+func (s *Set) Contains(member string) bool {
+	switch s.encoding {
+	case intsetEncoding:
+		n, ok := asInteger(member)
+		if !ok {
+			return false
+		}
+		_, found := slices.BinarySearch(s.intset, n)
+		return found
+	case listpackEncoding:
+		_, found := s.lp.IndexOf(member, false)
+		return found
+	default:
+		_, found := s.hash.Get(member)
+		return found
+	}
+}
+
+// Add adds member to the set, converting it to a larger encoding first if member does not fit,
+// and reports whether member was added rather than already present.
+func (s *Set) Add(member string) bool {
+	if s.Contains(member) {
+		return false
+	}
+	switch s.encoding {
+	case intsetEncoding:
+		n, ok := asInteger(member)
+		if ok {
+			i, _ := slices.BinarySearch(s.intset, n)
+			s.intset = slices.Insert(s.intset, i, n)
+			if len(s.intset) > s.config.MaxIntsetEntries {
+				s.convert(s.largerEncoding(len(s.intset), 0))
+			}
+			return true
+		}
+		s.convert(s.largerEncoding(len(s.intset)+1, len(member)))
+		return s.Add(member)
+	case listpackEncoding:
+		if s.lp.Length() >= s.config.MaxListpackEntries || len(member) > s.config.MaxListpackValue {
+			s.convert(hashtableEncoding)
+			return s.Add(member)
+		}
+		if _, err := s.lp.Push([]byte(member)); err != nil {
+			s.convert(hashtableEncoding)
+			return s.Add(member)
+		}
+	default:
+		s.hash.Set(member, struct{}{})
+		s.hashBytes += int64(len(member))
+	}
+	return true
+}
+
+// largerEncoding returns the encoding an intset converts to so it can hold size members,
+// the longest of which is memberLength bytes long.
+func (s *Set) largerEncoding(size, memberLength int) encodingType {
+	if size <= s.config.MaxListpackEntries && memberLength <= s.config.MaxListpackValue {
+		return listpackEncoding
+	}
+	return hashtableEncoding
+}
+
+// Remove removes member from the set and reports whether it was present.
+func (s *Set) Remove(member string) bool {
+	switch s.encoding {
+	case intsetEncoding:
+		n, ok := asInteger(member)
+		if !ok {
+			return false
+		}
+		i, found := slices.BinarySearch(s.intset, n)
+		if found {
+			s.intset = slices.Delete(s.intset, i, i+1)
+		}
+		return found
+	case listpackEncoding:
+		i, found := s.lp.IndexOf(member, false)
+		if found {
+			s.lp.DeleteFromIndex(i, 1)
+		}
+		return found
+	default:
+		if _, found := s.hash.Delete(member); found {
+			s.hashBytes -= int64(len(member))
+			return true
+		}
+		return false
+	}
+}
+
+// Members returns every member: in ascending order for an intset, in insertion order for a
+// listpack and in no particular order for a hashtable.
+func (s *Set) Members() []string {
+	members := make([]string, 0, s.Len())
+	switch s.encoding {
+	case intsetEncoding:
+		for _, n := range s.intset {
+			members = append(members, strconv.FormatInt(n, 10))
+		}
+	case listpackEncoding:
+		entries, _ := s.lp.LRange(0, int64(s.lp.Length()))
+		for _, entry := range entries {
+			members = append(members, string(entry))
+		}
+	default:
+		s.hash.Range(func(member string, _ struct{}) bool {
+			members = append(members, member)
+			return true
+		})
+	}
+	return members
+}
+
+// RandomMember returns a member picked at random. The set must not be empty.
+func (s *Set) RandomMember() string {
+	switch s.encoding {
+	case intsetEncoding:
+		return strconv.FormatInt(s.intset[rand.IntN(len(s.intset))], 10)
+	case listpackEncoding:
+		member, _ := s.lp.AtIndex(rand.IntN(s.lp.Length()))
+		return string(member)
+	default:
+		member, _, _ := s.hash.RandomKey()
+		return member
+	}
+}
+
+// RandomMembers returns count distinct members picked at random, every member if count is not
+// smaller than the set.
+func (s *Set) RandomMembers(count int) []string {
+	if count >= s.Len() {
+		return s.Members()
+	}
+	// Like Redis, shuffle every member when most of them are wanted, as picking them one by
+	// one would mostly pick members already seen.
+	if count*3 > s.Len() {
+		members := s.Members()
+		rand.Shuffle(len(members), func(i, j int) {
+			members[i], members[j] = members[j], members[i]
+		})
+		return members[:count]
+	}
+	seen := make(map[string]bool, count)
+	members := make([]string, 0, count)
+	for len(members) < count {
+		member := s.RandomMember()
+		if !seen[member] {
+			seen[member] = true
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+// convert moves every member to the given larger encoding.
+func (s *Set) convert(encoding encodingType) {
+	members := s.Members()
+	s.encoding = encoding
+	s.intset, s.lp, s.hash, s.hashBytes = nil, nil, nil, 0
+	if encoding == listpackEncoding {
+		s.lp = listpack.NewEmptyListPack(defaultMaxListPackSize)
+	} else {
+		s.hash = dict.New[struct{}]()
+	}
+	for _, member := range members {
+		s.Add(member)
+	}
+}
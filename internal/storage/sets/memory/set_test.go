@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSet(config Config, members ...string) *Set {
+	s := newSet(&config, members[0], len(members))
+	for _, member := range members {
+		s.Add(member)
+	}
+	return s
+}
+
+func TestSet_CreatedInTheEncodingFittingItsMembers(t *testing.T) {
+	config := DefaultConfig()
+	assert.Equal(t, "intset", newSet(&config, "12", 1).Encoding())
+	assert.Equal(t, "listpack", newSet(&config, "012", 1).Encoding())
+	assert.Equal(t, "hashtable", newSet(&config, "12", 600).Encoding())
+	assert.Equal(t, "hashtable", newSet(&config, strings.Repeat("x", 65), 1).Encoding())
+	assert.Equal(t, "hashtable", newSet(&config, "a", 200).Encoding())
+}
+
+func TestSet_IntsetKeepsMembersSorted(t *testing.T) {
+	s := newTestSet(DefaultConfig(), "3", "-1", "2", "3")
+
+	assert.Equal(t, "intset", s.Encoding())
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, []string{"-1", "2", "3"}, s.Members())
+	assert.True(t, s.Contains("2"))
+	assert.False(t, s.Contains("02"))
+	assert.False(t, s.Contains("a"))
+}
+
+func TestSet_ConvertsToLargerEncodings(t *testing.T) {
+	config := Config{MaxIntsetEntries: 4, MaxListpackEntries: 6, MaxListpackValue: 8}
+
+	s := newTestSet(config, "1", "2")
+	s.Add("member")
+	assert.Equal(t, "listpack", s.Encoding())
+	assert.ElementsMatch(t, []string{"1", "2", "member"}, s.Members())
+
+	s = newTestSet(config, "1", "2", "3", "4")
+	s.Add("5")
+	assert.Equal(t, "listpack", s.Encoding())
+
+	s = newTestSet(config, "1", "2", "3")
+	s.Add("a member too long for a listpack")
+	assert.Equal(t, "hashtable", s.Encoding())
+
+	s = newTestSet(config, "a", "b", "c", "d", "e", "f")
+	assert.Equal(t, "listpack", s.Encoding())
+	s.Add("g")
+	assert.Equal(t, "hashtable", s.Encoding())
+	assert.Equal(t, 7, s.Len())
+
+	// A set never converts back to a smaller encoding.
+	for _, member := range []string{"a", "b", "c", "d", "e", "f"} {
+		s.Remove(member)
+	}
+	assert.Equal(t, "hashtable", s.Encoding())
+	assert.Equal(t, []string{"g"}, s.Members())
+}
+
+func TestSet_AddRemoveInEveryEncoding(t *testing.T) {
+	for _, s := range []*Set{
+		newTestSet(DefaultConfig(), "1", "2"),
+		newTestSet(DefaultConfig(), "1", "b"),
+		newTestSet(Config{}, "1", "b"),
+	} {
+		encoding := s.Encoding()
+		assert.False(t, s.Add("1"), encoding)
+		assert.True(t, s.Remove("1"), encoding)
+		assert.False(t, s.Remove("1"), encoding)
+		assert.False(t, s.Contains("1"), encoding)
+		assert.True(t, s.Add("1"), encoding)
+		assert.True(t, s.Contains("1"), encoding)
+		assert.Equal(t, 2, s.Len(), encoding)
+	}
+}
+
+func TestSet_RandomMembersAreDistinct(t *testing.T) {
+	members := make([]string, 100)
+	for i := range members {
+		members[i] = "m" + strconv.Itoa(i)
+	}
+	s := newTestSet(DefaultConfig(), members...)
+
+	for _, count := range []int{0, 5, 40, 99, 100, 150} {
+		random := s.RandomMembers(count)
+		assert.Len(t, random, min(count, 100), count)
+		seen := make(map[string]bool)
+		for _, member := range random {
+			assert.False(t, seen[member], count)
+			assert.True(t, s.Contains(member), count)
+			seen[member] = true
+		}
+	}
+}
+
+func TestSet_CloneIsIndependent(t *testing.T) {
+	for _, s := range []*Set{
+		newTestSet(DefaultConfig(), "1", "2"),
+		newTestSet(DefaultConfig(), "1", "b"),
+		newTestSet(Config{}, "1", "b"),
+	} {
+		encoding := s.Encoding()
+		clone := s.Clone().(*Set)
+		clone.Add("3")
+		s.Remove("1")
+
+		assert.Equal(t, encoding, clone.Encoding())
+		assert.True(t, clone.Contains("1"), encoding)
+		assert.False(t, s.Contains("3"), encoding)
+		assert.Equal(t, 3, clone.Len(), encoding)
+		assert.Equal(t, 1, s.Len(), encoding)
+	}
+}
+
+func TestSet_MemoryUsageGrowsInHashtable(t *testing.T) {
+	s := newTestSet(Config{}, "a")
+	before := s.MemoryUsage()
+	s.Add(strings.Repeat("x", 100))
+	assert.Equal(t, before+100+setMemberOverhead, s.MemoryUsage())
+}
@@ -0,0 +1,353 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
+	"context"
+	"slices"
+)
+
+// Sets holds all named sets in the shared keyspace, a set left without members is removed from it.
+// All methods are called exclusively by the executor goroutine — no locking needed.
+type Sets struct {
+	keyspace *memkeyspace.Keyspace
+	config   *Config
+}
+
+func NewSets(ks *memkeyspace.Keyspace, config Config) *Sets {
+	return &Sets{
+		keyspace: ks,
+		config:   &config,
+	}
+}
+
+// lookup returns the set stored at key, or nil if key does not exist.
+// ErrWrongType is returned if key holds a value of another type.
+func (s *Sets) lookup(key string) (*Set, error) {
+	entry, err := s.keyspace.LookupOfType(key, keyspace.TypeSet)
+	if entry == nil {
+		return nil, err
+	}
+	return entry.Value.(*Set), nil
+}
+
+// lookupOrCreate returns the set stored at key, creating an empty one fit for members if key does not exist.
+func (s *Sets) lookupOrCreate(key string, members []string) (*Set, error) {
+	set, err := s.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		set = newSet(s.config, members[0], len(members))
+		s.keyspace.Put(key, keyspace.TypeSet, set)
+	}
+	return set, nil
+}
+
+// removeIfEmpty removes key once its set has no member left, and accounts for its new size otherwise.
+func (s *Sets) removeIfEmpty(key string, set *Set) {
+	if set.Len() == 0 {
+		s.keyspace.Remove(key)
+		return
+	}
+	s.keyspace.Resized(key)
+}
+
+// SAdd adds members to the set stored at key and returns how many were not already present.
+func (s *Sets) SAdd(_ context.Context, key string, members []string) (int, error) {
+	set, err := s.lookupOrCreate(key, members)
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, member := range members {
+		if set.Add(member) {
+			added++
+		}
+	}
+	s.keyspace.Resized(key)
+	return added, nil
+}
+
+// SRem removes members from the set stored at key and returns how many were present.
+func (s *Sets) SRem(_ context.Context, key string, members []string) (int, error) {
+	set, err := s.lookup(key)
+	if set == nil {
+		return 0, err
+	}
+	removed := 0
+	for _, member := range members {
+		if set.Remove(member) {
+			removed++
+		}
+	}
+	s.removeIfEmpty(key, set)
+	return removed, nil
+}
+
+func (s *Sets) SMembers(_ context.Context, key string) ([]string, error) {
+	set, err := s.lookup(key)
+	if set == nil {
+		return []string{}, err
+	}
+	return set.Members(), nil
+}
+
+func (s *Sets) SIsMember(_ context.Context, key string, member string) (bool, error) {
+	set, err := s.lookup(key)
+	if set == nil {
+		return false, err
+	}
+	return set.Contains(member), nil
+}
+
+func (s *Sets) SMIsMember(_ context.Context, key string, members []string) ([]bool, error) {
+	set, err := s.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]bool, len(members))
+	if set == nil {
+		return result, nil
+	}
+	for i, member := range members {
+		result[i] = set.Contains(member)
+	}
+	return result, nil
+}
+
+func (s *Sets) SCard(_ context.Context, key string) (int, error) {
+	set, err := s.lookup(key)
+	if set == nil {
+		return 0, err
+	}
+	return set.Len(), nil
+}
+
+// SPop removes up to count random members from the set stored at key and returns them.
+// The key is removed along with its last member.
+func (s *Sets) SPop(_ context.Context, key string, count int) ([]string, error) {
+	set, err := s.lookup(key)
+	if set == nil {
+		return []string{}, err
+	}
+	if count >= set.Len() {
+		members := set.Members()
+		s.keyspace.Remove(key)
+		return members, nil
+	}
+	members := make([]string, 0, count)
+	for len(members) < count {
+		member := set.RandomMember()
+		set.Remove(member)
+		members = append(members, member)
+	}
+	s.keyspace.Resized(key)
+	return members, nil
+}
+
+func (s *Sets) SRandMember(_ context.Context, key string, count int) ([]string, error) {
+	set, err := s.lookup(key)
+	if set == nil {
+		return []string{}, err
+	}
+	if count >= 0 {
+		return set.RandomMembers(count), nil
+	}
+	// The reply grows as members are drawn rather than being allocated up front for a count
+	// that may be huge.
+	var members []string
+	for i := 0; i > count; i-- {
+		members = append(members, set.RandomMember())
+	}
+	return members, nil
+}
+
+// SMove moves member from the set stored at source to the one stored at destination, creating
+// it if needed, and reports whether member was moved. Both keys must hold sets or not exist.
+func (s *Sets) SMove(_ context.Context, source, destination string, member string) (bool, error) {
+	sourceSet, err := s.lookup(source)
+	if err != nil {
+		return false, err
+	}
+	destinationSet, err := s.lookup(destination)
+	if err != nil {
+		return false, err
+	}
+	if sourceSet == nil {
+		return false, nil
+	}
+	if source == destination {
+		return sourceSet.Contains(member), nil
+	}
+	if !sourceSet.Remove(member) {
+		return false, nil
+	}
+	s.removeIfEmpty(source, sourceSet)
+	if destinationSet == nil {
+		destinationSet = newSet(s.config, member, 1)
+		s.keyspace.Put(destination, keyspace.TypeSet, destinationSet)
+	}
+	destinationSet.Add(member)
+	s.keyspace.Resized(destination)
+	return true, nil
+}
+
+// lookupAll returns the sets stored at keys, nil for every key that does not exist.
+// ErrWrongType is returned if any key holds a value of another type.
+func (s *Sets) lookupAll(keys []string) ([]*Set, error) {
+	sets := make([]*Set, len(keys))
+	for i, key := range keys {
+		set, err := s.lookup(key)
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+	return sets, nil
+}
+
+// inter returns the members common to the sets stored at keys, at most limit of them unless limit is 0.
+func (s *Sets) inter(keys []string, limit int) ([]string, error) {
+	sets, err := s.lookupAll(keys)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(sets, nil) {
+		return []string{}, nil
+	}
+	// Like Redis, walk the smallest set and probe the others from the smallest up, so most
+	// members that are not common are ruled out early.
+	slices.SortFunc(sets, func(a, b *Set) int { return a.Len() - b.Len() })
+	members := make([]string, 0)
+	for _, member := range sets[0].Members() {
+		common := true
+		for _, set := range sets[1:] {
+			if !set.Contains(member) {
+				common = false
+				break
+			}
+		}
+		if !common {
+			continue
+		}
+		members = append(members, member)
+		if len(members) == limit {
+			break
+		}
+	}
+	return members, nil
+}
+
+// union returns a new set holding the members of every set stored at keys, or nil if they are all empty.
+func (s *Sets) union(keys []string) (*Set, error) {
+	sets, err := s.lookupAll(keys)
+	if err != nil {
+		return nil, err
+	}
+	var largest *Set
+	for _, set := range sets {
+		if set != nil && (largest == nil || set.Len() > largest.Len()) {
+			largest = set
+		}
+	}
+	if largest == nil {
+		return nil, nil
+	}
+	// The union is at least as large as the largest set, a fair hint for its encoding.
+	result := newSet(s.config, largest.RandomMember(), largest.Len())
+	for _, set := range sets {
+		if set == nil {
+			continue
+		}
+		for _, member := range set.Members() {
+			result.Add(member)
+		}
+	}
+	return result, nil
+}
+
+// diff returns the members of the set stored at the first key that none of the others hold.
+func (s *Sets) diff(keys []string) ([]string, error) {
+	sets, err := s.lookupAll(keys)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]string, 0)
+	if sets[0] == nil {
+		return members, nil
+	}
+	for _, member := range sets[0].Members() {
+		if !slices.ContainsFunc(sets[1:], func(set *Set) bool { return set != nil && set.Contains(member) }) {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// fromMembers returns a new set holding members in the encoding fitting their number, or nil if there are none.
+func (s *Sets) fromMembers(members []string) *Set {
+	if len(members) == 0 {
+		return nil
+	}
+	set := newSet(s.config, members[0], len(members))
+	for _, member := range members {
+		set.Add(member)
+	}
+	return set
+}
+
+// store replaces destination with set, removing it instead if set is nil, and returns the size of set.
+func (s *Sets) store(destination string, set *Set) int {
+	if set == nil {
+		s.keyspace.Remove(destination)
+		return 0
+	}
+	s.keyspace.Put(destination, keyspace.TypeSet, set)
+	return set.Len()
+}
+
+func (s *Sets) SInter(_ context.Context, keys []string) ([]string, error) {
+	return s.inter(keys, 0)
+}
+
+func (s *Sets) SInterCard(_ context.Context, keys []string, limit int) (int, error) {
+	members, err := s.inter(keys, limit)
+	return len(members), err
+}
+
+func (s *Sets) SUnion(_ context.Context, keys []string) ([]string, error) {
+	set, err := s.union(keys)
+	if set == nil {
+		return []string{}, err
+	}
+	return set.Members(), nil
+}
+
+func (s *Sets) SDiff(_ context.Context, keys []string) ([]string, error) {
+	return s.diff(keys)
+}
+
+func (s *Sets) SInterStore(_ context.Context, destination string, keys []string) (int, error) {
+	members, err := s.inter(keys, 0)
+	if err != nil {
+		return 0, err
+	}
+	return s.store(destination, s.fromMembers(members)), nil
+}
+
+func (s *Sets) SUnionStore(_ context.Context, destination string, keys []string) (int, error) {
+	set, err := s.union(keys)
+	if err != nil {
+		return 0, err
+	}
+	return s.store(destination, set), nil
+}
+
+func (s *Sets) SDiffStore(_ context.Context, destination string, keys []string) (int, error) {
+	members, err := s.diff(keys)
+	if err != nil {
+		return 0, err
+	}
+	return s.store(destination, s.fromMembers(members)), nil
+}
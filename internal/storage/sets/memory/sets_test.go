@@ -0,0 +1,301 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setAt returns the set stored at key, failing loudly if key is not a set
+func setAt(sets *Sets, key string) *Set {
+	set, err := sets.lookup(key)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+func TestSets_SAdd(t *testing.T) {
+	sets := NewSets(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+
+	added, err := sets.SAdd(ctx, "set1", []string{"1", "2", "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, added)
+	assert.Equal(t, "intset", setAt(sets, "set1").Encoding())
+
+	added, err = sets.SAdd(ctx, "set1", []string{"2", "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, added)
+	assert.Equal(t, "listpack", setAt(sets, "set1").Encoding())
+
+	members, _ := sets.SMembers(ctx, "set1")
+	assert.ElementsMatch(t, []string{"1", "2", "a"}, members)
+}
+
+func TestSets_Queries(t *testing.T) {
+	sets := NewSets(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = sets.SAdd(ctx, "set1", []string{"a", "b"})
+
+	card, _ := sets.SCard(ctx, "set1")
+	assert.Equal(t, 2, card)
+	card, _ = sets.SCard(ctx, "missing")
+	assert.Equal(t, 0, card)
+
+	isMember, _ := sets.SIsMember(ctx, "set1", "a")
+	assert.True(t, isMember)
+	isMember, _ = sets.SIsMember(ctx, "missing", "a")
+	assert.False(t, isMember)
+
+	areMembers, _ := sets.SMIsMember(ctx, "set1", []string{"a", "c", "b"})
+	assert.Equal(t, []bool{true, false, true}, areMembers)
+	areMembers, _ = sets.SMIsMember(ctx, "missing", []string{"a"})
+	assert.Equal(t, []bool{false}, areMembers)
+
+	members, err := sets.SMembers(ctx, "missing")
+	assert.NoError(t, err)
+	assert.NotNil(t, members)
+	assert.Empty(t, members)
+}
+
+func TestSets_SPop(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	sets := NewSets(ks, DefaultConfig())
+	ctx := context.Background()
+	_, _ = sets.SAdd(ctx, "set1", []string{"a", "b", "c"})
+
+	popped, err := sets.SPop(ctx, "set1", 2)
+	assert.NoError(t, err)
+	assert.Len(t, popped, 2)
+	for _, member := range popped {
+		isMember, _ := sets.SIsMember(ctx, "set1", member)
+		assert.False(t, isMember)
+	}
+	card, _ := sets.SCard(ctx, "set1")
+	assert.Equal(t, 1, card)
+
+	popped, _ = sets.SPop(ctx, "set1", 5)
+	assert.Len(t, popped, 1)
+	assert.Equal(t, 0, ks.Len())
+
+	popped, err = sets.SPop(ctx, "missing", 1)
+	assert.NoError(t, err)
+	assert.Empty(t, popped)
+}
+
+func TestSets_SRandMember(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	sets := NewSets(ks, DefaultConfig())
+	ctx := context.Background()
+	_, _ = sets.SAdd(ctx, "set1", []string{"a", "b"})
+
+	members, _ := sets.SRandMember(ctx, "set1", 5)
+	assert.ElementsMatch(t, []string{"a", "b"}, members)
+
+	members, _ = sets.SRandMember(ctx, "set1", -5)
+	assert.Len(t, members, 5)
+	for _, member := range members {
+		assert.Contains(t, []string{"a", "b"}, member)
+	}
+
+	card, _ := sets.SCard(ctx, "set1")
+	assert.Equal(t, 2, card)
+}
+
+func TestSets_SRandMemberLargeNegativeCount(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	sets := NewSets(ks, DefaultConfig())
+	ctx := context.Background()
+	_, _ = sets.SAdd(ctx, "set1", []string{"a"})
+
+	members, err := sets.SRandMember(ctx, "missing", math.MinInt)
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+
+	members, err = sets.SRandMember(ctx, "set1", -100000)
+	assert.NoError(t, err)
+	assert.Len(t, members, 100000)
+}
+
+func TestSets_SMove(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	sets := NewSets(ks, DefaultConfig())
+	ctx := context.Background()
+	_, _ = sets.SAdd(ctx, "src", []string{"a", "b"})
+
+	moved, err := sets.SMove(ctx, "src", "dst", "a")
+	assert.NoError(t, err)
+	assert.True(t, moved)
+	members, _ := sets.SMembers(ctx, "dst")
+	assert.Equal(t, []string{"a"}, members)
+
+	moved, _ = sets.SMove(ctx, "src", "dst", "missing")
+	assert.False(t, moved)
+
+	moved, _ = sets.SMove(ctx, "src", "src", "b")
+	assert.True(t, moved)
+
+	moved, _ = sets.SMove(ctx, "src", "dst", "b")
+	assert.True(t, moved)
+	assert.Equal(t, 1, ks.Len())
+	card, _ := sets.SCard(ctx, "dst")
+	assert.Equal(t, 2, card)
+
+	moved, err = sets.SMove(ctx, "missing", "dst", "a")
+	assert.NoError(t, err)
+	assert.False(t, moved)
+}
+
+func TestSets_WrongType(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	sets := NewSets(ks, DefaultConfig())
+	ctx := context.Background()
+	ks.Put("str", keyspace.TypeString, struct{}{})
+	_, _ = sets.SAdd(ctx, "set1", []string{"a"})
+
+	_, err := sets.SAdd(ctx, "str", []string{"a"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SRem(ctx, "str", []string{"a"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SMembers(ctx, "str")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SIsMember(ctx, "str", "a")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SMIsMember(ctx, "str", []string{"a"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SCard(ctx, "str")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SPop(ctx, "str", 1)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SRandMember(ctx, "str", 1)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SMove(ctx, "str", "set1", "a")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SMove(ctx, "set1", "str", "a")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+
+	isMember, _ := sets.SIsMember(ctx, "set1", "a")
+	assert.True(t, isMember)
+}
+
+func TestSets_RemovesEmptySet(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	sets := NewSets(ks, DefaultConfig())
+	ctx := context.Background()
+
+	_, _ = sets.SAdd(ctx, "set1", []string{"a", "b"})
+	removed, _ := sets.SRem(ctx, "set1", []string{"a", "b", "c"})
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, 0, ks.Len())
+}
+
+func TestSets_SInter(t *testing.T) {
+	sets := NewSets(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = sets.SAdd(ctx, "set1", []string{"a", "b", "c", "d"})
+	_, _ = sets.SAdd(ctx, "set2", []string{"b", "c", "e"})
+	_, _ = sets.SAdd(ctx, "set3", []string{"c", "b", "f"})
+
+	members, err := sets.SInter(ctx, []string{"set1", "set2", "set3"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"b", "c"}, members)
+
+	members, err = sets.SInter(ctx, []string{"set1", "missing"})
+	assert.NoError(t, err)
+	assert.NotNil(t, members)
+	assert.Empty(t, members)
+
+	card, _ := sets.SInterCard(ctx, []string{"set1", "set2"}, 0)
+	assert.Equal(t, 2, card)
+	card, _ = sets.SInterCard(ctx, []string{"set1", "set2"}, 1)
+	assert.Equal(t, 1, card)
+}
+
+func TestSets_SUnionAndSDiff(t *testing.T) {
+	sets := NewSets(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = sets.SAdd(ctx, "set1", []string{"a", "b", "c"})
+	_, _ = sets.SAdd(ctx, "set2", []string{"1", "c"})
+
+	members, err := sets.SUnion(ctx, []string{"set1", "missing", "set2"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "c", "1"}, members)
+
+	members, _ = sets.SUnion(ctx, []string{"missing"})
+	assert.Empty(t, members)
+
+	members, err = sets.SDiff(ctx, []string{"set1", "missing", "set2"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b"}, members)
+
+	members, _ = sets.SDiff(ctx, []string{"set1", "set1"})
+	assert.Empty(t, members)
+
+	members, _ = sets.SDiff(ctx, []string{"missing", "set1"})
+	assert.Empty(t, members)
+}
+
+func TestSets_StoreVariants(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	sets := NewSets(ks, DefaultConfig())
+	ctx := context.Background()
+	_, _ = sets.SAdd(ctx, "set1", []string{"1", "2", "a"})
+	_, _ = sets.SAdd(ctx, "set2", []string{"1", "2", "b"})
+	ks.Put("dst", keyspace.TypeString, struct{}{})
+	ks.SetExpiry("dst", time.Now().Add(time.Hour))
+
+	n, err := sets.SInterStore(ctx, "dst", []string{"set1", "set2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	// The result takes the encoding fitting it, not the one of the sets it came from.
+	assert.Equal(t, "intset", setAt(sets, "dst").Encoding())
+	ttl, _ := ks.TTL(ctx, "dst")
+	assert.Equal(t, int64(-1), ttl)
+
+	n, err = sets.SUnionStore(ctx, "dst", []string{"set1", "set2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, "listpack", setAt(sets, "dst").Encoding())
+
+	n, err = sets.SDiffStore(ctx, "set1", []string{"set1", "set2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	members, _ := sets.SMembers(ctx, "set1")
+	assert.Equal(t, []string{"a"}, members)
+
+	n, err = sets.SInterStore(ctx, "dst", []string{"set1", "set2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	exists, _ := ks.Exists(ctx, "dst")
+	assert.Equal(t, int64(0), exists)
+}
+
+func TestSets_AlgebraWrongType(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	sets := NewSets(ks, DefaultConfig())
+	ctx := context.Background()
+	ks.Put("str", keyspace.TypeString, struct{}{})
+	_, _ = sets.SAdd(ctx, "set1", []string{"a"})
+
+	// A key of another type is an error even where a missing key would short-circuit the result.
+	_, err := sets.SInter(ctx, []string{"missing", "str"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SInterCard(ctx, []string{"set1", "str"}, 0)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SUnion(ctx, []string{"set1", "str"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SDiff(ctx, []string{"missing", "str"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = sets.SUnionStore(ctx, "dst", []string{"set1", "str"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+
+	n, err := sets.SUnionStore(ctx, "str", []string{"set1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
@@ -0,0 +1,281 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sets.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sets.go -destination=mock/sets.go -package=mocksets
+//
+
+// Package mocksets is a generated GoMock package.
+package mocksets
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSets is a mock of Sets interface.
+type MockSets struct {
+	ctrl     *gomock.Controller
+	recorder *MockSetsMockRecorder
+	isgomock struct{}
+}
+
+// MockSetsMockRecorder is the mock recorder for MockSets.
+type MockSetsMockRecorder struct {
+	mock *MockSets
+}
+
+// NewMockSets creates a new mock instance.
+func NewMockSets(ctrl *gomock.Controller) *MockSets {
+	mock := &MockSets{ctrl: ctrl}
+	mock.recorder = &MockSetsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSets) EXPECT() *MockSetsMockRecorder {
+	return m.recorder
+}
+
+// SAdd mocks base method.
+func (m *MockSets) SAdd(ctx context.Context, key string, members []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SAdd", ctx, key, members)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SAdd indicates an expected call of SAdd.
+func (mr *MockSetsMockRecorder) SAdd(ctx, key, members any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SAdd", reflect.TypeOf((*MockSets)(nil).SAdd), ctx, key, members)
+}
+
+// SCard mocks base method.
+func (m *MockSets) SCard(ctx context.Context, key string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SCard", ctx, key)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SCard indicates an expected call of SCard.
+func (mr *MockSetsMockRecorder) SCard(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SCard", reflect.TypeOf((*MockSets)(nil).SCard), ctx, key)
+}
+
+// SDiff mocks base method.
+func (m *MockSets) SDiff(ctx context.Context, keys []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SDiff", ctx, keys)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SDiff indicates an expected call of SDiff.
+func (mr *MockSetsMockRecorder) SDiff(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SDiff", reflect.TypeOf((*MockSets)(nil).SDiff), ctx, keys)
+}
+
+// SDiffStore mocks base method.
+func (m *MockSets) SDiffStore(ctx context.Context, destination string, keys []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SDiffStore", ctx, destination, keys)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SDiffStore indicates an expected call of SDiffStore.
+func (mr *MockSetsMockRecorder) SDiffStore(ctx, destination, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SDiffStore", reflect.TypeOf((*MockSets)(nil).SDiffStore), ctx, destination, keys)
+}
+
+// SInter mocks base method.
+func (m *MockSets) SInter(ctx context.Context, keys []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SInter", ctx, keys)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SInter indicates an expected call of SInter.
+func (mr *MockSetsMockRecorder) SInter(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SInter", reflect.TypeOf((*MockSets)(nil).SInter), ctx, keys)
+}
+
+// SInterCard mocks base method.
+func (m *MockSets) SInterCard(ctx context.Context, keys []string, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SInterCard", ctx, keys, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SInterCard indicates an expected call of SInterCard.
+func (mr *MockSetsMockRecorder) SInterCard(ctx, keys, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SInterCard", reflect.TypeOf((*MockSets)(nil).SInterCard), ctx, keys, limit)
+}
+
+// SInterStore mocks base method.
+func (m *MockSets) SInterStore(ctx context.Context, destination string, keys []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SInterStore", ctx, destination, keys)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SInterStore indicates an expected call of SInterStore.
+func (mr *MockSetsMockRecorder) SInterStore(ctx, destination, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SInterStore", reflect.TypeOf((*MockSets)(nil).SInterStore), ctx, destination, keys)
+}
+
+// SIsMember mocks base method.
+func (m *MockSets) SIsMember(ctx context.Context, key, member string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SIsMember", ctx, key, member)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SIsMember indicates an expected call of SIsMember.
+func (mr *MockSetsMockRecorder) SIsMember(ctx, key, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SIsMember", reflect.TypeOf((*MockSets)(nil).SIsMember), ctx, key, member)
+}
+
+// SMIsMember mocks base method.
+func (m *MockSets) SMIsMember(ctx context.Context, key string, members []string) ([]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SMIsMember", ctx, key, members)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SMIsMember indicates an expected call of SMIsMember.
+func (mr *MockSetsMockRecorder) SMIsMember(ctx, key, members any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMIsMember", reflect.TypeOf((*MockSets)(nil).SMIsMember), ctx, key, members)
+}
+
+// SMembers mocks base method.
+func (m *MockSets) SMembers(ctx context.Context, key string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SMembers", ctx, key)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SMembers indicates an expected call of SMembers.
+func (mr *MockSetsMockRecorder) SMembers(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMembers", reflect.TypeOf((*MockSets)(nil).SMembers), ctx, key)
+}
+
+// SMove mocks base method.
+func (m *MockSets) SMove(ctx context.Context, source, destination, member string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SMove", ctx, source, destination, member)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SMove indicates an expected call of SMove.
+func (mr *MockSetsMockRecorder) SMove(ctx, source, destination, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMove", reflect.TypeOf((*MockSets)(nil).SMove), ctx, source, destination, member)
+}
+
+// SPop mocks base method.
+func (m *MockSets) SPop(ctx context.Context, key string, count int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SPop", ctx, key, count)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SPop indicates an expected call of SPop.
+func (mr *MockSetsMockRecorder) SPop(ctx, key, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SPop", reflect.TypeOf((*MockSets)(nil).SPop), ctx, key, count)
+}
+
+// SRandMember mocks base method.
+func (m *MockSets) SRandMember(ctx context.Context, key string, count int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SRandMember", ctx, key, count)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SRandMember indicates an expected call of SRandMember.
+func (mr *MockSetsMockRecorder) SRandMember(ctx, key, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SRandMember", reflect.TypeOf((*MockSets)(nil).SRandMember), ctx, key, count)
+}
+
+// SRem mocks base method.
+func (m *MockSets) SRem(ctx context.Context, key string, members []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SRem", ctx, key, members)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SRem indicates an expected call of SRem.
+func (mr *MockSetsMockRecorder) SRem(ctx, key, members any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SRem", reflect.TypeOf((*MockSets)(nil).SRem), ctx, key, members)
+}
+
+// SUnion mocks base method.
+func (m *MockSets) SUnion(ctx context.Context, keys []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SUnion", ctx, keys)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SUnion indicates an expected call of SUnion.
+func (mr *MockSetsMockRecorder) SUnion(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SUnion", reflect.TypeOf((*MockSets)(nil).SUnion), ctx, keys)
+}
+
+// SUnionStore mocks base method.
+func (m *MockSets) SUnionStore(ctx context.Context, destination string, keys []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SUnionStore", ctx, destination, keys)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SUnionStore indicates an expected call of SUnionStore.
+func (mr *MockSetsMockRecorder) SUnionStore(ctx, destination, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SUnionStore", reflect.TypeOf((*MockSets)(nil).SUnionStore), ctx, destination, keys)
+}
@@ -0,0 +1,29 @@
+package sets
+
+import "context"
+
+//go:generate sh -c "rm -f mock/sets.go && mockgen -source=sets.go -destination=mock/sets.go -package=mocksets"
+type Sets interface {
+	SAdd(ctx context.Context, key string, members []string) (int, error)
+	SRem(ctx context.Context, key string, members []string) (int, error)
+	SMembers(ctx context.Context, key string) ([]string, error)
+	SIsMember(ctx context.Context, key string, member string) (bool, error)
+	SMIsMember(ctx context.Context, key string, members []string) ([]bool, error)
+	SCard(ctx context.Context, key string) (int, error)
+	// SPop removes and returns up to count random members.
+	SPop(ctx context.Context, key string, count int) ([]string, error)
+	// SRandMember returns up to count distinct random members, or exactly -count members
+	// possibly repeated when count is negative.
+	SRandMember(ctx context.Context, key string, count int) ([]string, error)
+	SMove(ctx context.Context, source, destination string, member string) (bool, error)
+	SInter(ctx context.Context, keys []string) ([]string, error)
+	// SInterCard returns the size of the intersection, counting no further than limit unless limit is 0.
+	SInterCard(ctx context.Context, keys []string, limit int) (int, error)
+	SUnion(ctx context.Context, keys []string) ([]string, error)
+	SDiff(ctx context.Context, keys []string) ([]string, error)
+	// SInterStore, SUnionStore and SDiffStore replace destination with the result, whatever it
+	// held, or remove it if the result is empty, and return the size of the result.
+	SInterStore(ctx context.Context, destination string, keys []string) (int, error)
+	SUnionStore(ctx context.Context, destination string, keys []string) (int, error)
+	SDiffStore(ctx context.Context, destination string, keys []string) (int, error)
+}
@@ -3,25 +3,62 @@ package storage
 import (
 	"avacado/internal/storage/hashmaps"
 	memhash "avacado/internal/storage/hashmaps/memory"
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
 	"avacado/internal/storage/kv"
 	"avacado/internal/storage/kv/memory"
 	"avacado/internal/storage/lists"
 	memlist "avacado/internal/storage/lists/memory"
+	"avacado/internal/storage/sets"
+	memset "avacado/internal/storage/sets/memory"
+	"avacado/internal/storage/streams"
+	memstream "avacado/internal/storage/streams/memory"
+	"avacado/internal/storage/zsets"
+	memzset "avacado/internal/storage/zsets/memory"
+	"context"
 	"os"
 	"strconv"
+	"time"
 )
 
 //go:generate sh -c "rm -f mock/storage.go && mockgen -source=storage.go -destination=mock/storage.go -package=mocksstorage"
 type Storage interface {
+	Keyspace() keyspace.Keyspace
 	KV() kv.Store
 	Lists() lists.Lists
 	Maps() hashmaps.HashMaps
+	Sets() sets.Sets
+	ZSets() zsets.ZSets
+	Streams() streams.Streams
 }
 
+// Databases are the logical databases of the server, addressed by their index.
+// Indexes are expected to be validated against Count by the caller.
+type Databases interface {
+	Count() int
+	DB(index int) Storage
+	// Swap exchanges the content of two databases, so clients see the data of the other one.
+	Swap(first, second int)
+	// Move moves key with its expiry from one database to another unless the destination holds key.
+	Move(ctx context.Context, key string, from, to int) (bool, error)
+	// Copy is Keyspace.Copy with the destination key living in another database.
+	Copy(ctx context.Context, source string, from int, destination string, to int, replace bool) (bool, error)
+}
+
+// DefaultStorage is the in-memory storage. All typed stores share a single keyspace,
+// so a key holds exactly one value of one type.
 type DefaultStorage struct {
-	kv    *memory.KVMemoryStore
-	lists *memlist.ListMemoryStore
-	maps  *memhash.HashMaps
+	keyspace *memkeyspace.Keyspace
+	kv       *memory.KVMemoryStore
+	lists    *memlist.ListMemoryStore
+	maps     *memhash.HashMaps
+	sets     *memset.Sets
+	zsets    *memzset.ZSets
+	streams  *memstream.Streams
+}
+
+func (d DefaultStorage) Keyspace() keyspace.Keyspace {
+	return d.keyspace
 }
 
 func (d DefaultStorage) KV() kv.Store {
@@ -36,16 +73,118 @@ func (d DefaultStorage) Maps() hashmaps.HashMaps {
 	return d.maps
 }
 
+func (d DefaultStorage) Sets() sets.Sets {
+	return d.sets
+}
+
+func (d DefaultStorage) ZSets() zsets.ZSets {
+	return d.zsets
+}
+
+func (d DefaultStorage) Streams() streams.Streams {
+	return d.streams
+}
+
 const defaultMaxListPackSize = 8192
 
-func NewDefaultStorage() DefaultStorage {
-	maxListPackSize := defaultMaxListPackSize
+// DefaultDatabaseCount is the number of logical databases unless configured otherwise.
+const DefaultDatabaseCount = 16
+
+// Config configures the in-memory databases.
+type Config struct {
+	// Databases is the number of logical databases.
+	Databases int
+	// MaxMemory is how many bytes the databases may use before keys are evicted, 0 meaning no limit.
+	MaxMemory int64
+	// MaxMemoryPolicy selects the keys evicted once MaxMemory is exceeded.
+	MaxMemoryPolicy keyspace.EvictionPolicy
+	// KV is the size at which HyperLogLogs convert to the dense encoding.
+	KV memory.Config
+	// Sets are the thresholds at which sets convert to a larger encoding.
+	Sets memset.Config
+	// ZSets are the thresholds at which sorted sets convert to a skiplist.
+	ZSets memzset.Config
+	// Streams are the sizes of the nodes stream entries are packed in.
+	Streams memstream.Config
+}
+
+// DefaultConfig returns the configuration of unbounded databases, DefaultDatabaseCount of them.
+func DefaultConfig() Config {
+	return Config{Databases: DefaultDatabaseCount, MaxMemoryPolicy: keyspace.NoEviction, KV: memory.DefaultConfig(), Sets: memset.DefaultConfig(), ZSets: memzset.DefaultConfig(), Streams: memstream.DefaultConfig()}
+}
+
+func newDefaultStorage(ks *memkeyspace.Keyspace, maxListPackSize int, config Config) DefaultStorage {
+	return DefaultStorage{
+		keyspace: ks,
+		kv:       memory.NewKVMemoryStore(ks, config.KV),
+		lists:    memlist.NewListMemoryStore(ks, maxListPackSize),
+		maps:     memhash.NewHashMaps(ks),
+		sets:     memset.NewSets(ks, config.Sets),
+		zsets:    memzset.NewZSets(ks, config.ZSets),
+		streams:  memstream.NewStreams(ks, config.Streams),
+	}
+}
+
+func maxListPackSize() int {
 	if v, err := strconv.Atoi(os.Getenv("MAX_LIST_PACK_SIZE")); err == nil && v > 0 {
-		maxListPackSize = v
+		return v
 	}
-	return DefaultStorage{
-		kv:    memory.NewKVMemoryStore(),
-		lists: memlist.NewListMemoryStore(maxListPackSize),
-		maps:  memhash.NewHashMaps(),
+	return defaultMaxListPackSize
+}
+
+// DefaultDatabases are the in-memory logical databases.
+type DefaultDatabases struct {
+	dbs []DefaultStorage
+	// keyspaces are the keyspaces of every database, in creation order whatever the swaps since.
+	keyspaces []*memkeyspace.Keyspace
+	evictor   *memkeyspace.Evictor
+	// nextExpireDB is the index in keyspaces the next active expire cycle starts with.
+	nextExpireDB int
+}
+
+// NewDefaultDatabases creates the empty databases described by config.
+func NewDefaultDatabases(config Config) *DefaultDatabases {
+	size := maxListPackSize()
+	keyspaces := memkeyspace.NewKeyspaces(config.Databases)
+	dbs := make([]DefaultStorage, config.Databases)
+	for i, ks := range keyspaces {
+		dbs[i] = newDefaultStorage(ks, size, config)
+	}
+	return &DefaultDatabases{
+		dbs:       dbs,
+		keyspaces: keyspaces,
+		evictor:   memkeyspace.NewEvictor(config.MaxMemory, config.MaxMemoryPolicy),
 	}
 }
+
+func (d *DefaultDatabases) Count() int {
+	return len(d.dbs)
+}
+
+func (d *DefaultDatabases) DB(index int) Storage {
+	return d.dbs[index]
+}
+
+func (d *DefaultDatabases) Swap(first, second int) {
+	d.dbs[first], d.dbs[second] = d.dbs[second], d.dbs[first]
+}
+
+func (d *DefaultDatabases) Move(_ context.Context, key string, from, to int) (bool, error) {
+	return d.dbs[from].keyspace.MoveTo(key, d.dbs[to].keyspace), nil
+}
+
+func (d *DefaultDatabases) Copy(_ context.Context, source string, from int, destination string, to int, replace bool) (bool, error) {
+	return d.dbs[from].keyspace.CopyTo(source, d.dbs[to].keyspace, destination, replace)
+}
+
+// ActiveExpireCycle reclaims expired keys of every database for at most timeLimit.
+// It is run periodically by the executor.
+func (d *DefaultDatabases) ActiveExpireCycle(timeLimit time.Duration) {
+	d.nextExpireDB = memkeyspace.ActiveExpireCycle(d.keyspaces, d.nextExpireDB, timeLimit)
+}
+
+// PerformEvictions evicts keys following the maxmemory policy until the databases fit in maxmemory,
+// and reports whether they do. It is run by the executor before every command.
+func (d *DefaultDatabases) PerformEvictions() bool {
+	return d.evictor.PerformEvictions(d.keyspaces)
+}
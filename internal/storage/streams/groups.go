@@ -0,0 +1,131 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrGroupKeyMissing is returned by XGROUP when the stream does not exist.
+	ErrGroupKeyMissing = errors.New("ERR The XGROUP subcommand requires the key to exist. Note that for CREATE you may want to use the MKSTREAM option to create an empty stream automatically.")
+	ErrBusyGroup       = errors.New("BUSYGROUP Consumer Group name already exists")
+	// ErrNoGroup is wrapped by the errors returned when a consumer group is missing.
+	ErrNoGroup = errors.New("NOGROUP")
+)
+
+// NewNoGroupError returns the error of XGROUP and XINFO when the stream at key has no consumer
+// group named group.
+func NewNoGroupError(key, group string) error {
+	return fmt.Errorf("%w No such consumer group '%s' for key name '%s'", ErrNoGroup, group, key)
+}
+
+// NewNoKeyOrGroupError returns the error of commands acting on the pending entries of a group when
+// there is no stream at key or it has no consumer group named group.
+func NewNoKeyOrGroupError(key, group string) error {
+	return fmt.Errorf("%w No such key '%s' or consumer group '%s'", ErrNoGroup, key, group)
+}
+
+// NewNoReadGroupError is NewNoKeyOrGroupError for XREADGROUP.
+func NewNoReadGroupError(key, group string) error {
+	return fmt.Errorf("%w No such key '%s' or consumer group '%s' in XREADGROUP with GROUP option", ErrNoGroup, key, group)
+}
+
+// UnknownEntriesRead is the count of entries read by a group when it cannot be told, as after
+// reading past deleted entries.
+const UnknownEntriesRead = -1
+
+// GroupStart is the last ID a consumer group is considered to have delivered: ID, or the last
+// ID of the stream if Last is set, which XGROUP spells $.
+type GroupStart struct {
+	ID   ID
+	Last bool
+}
+
+// ReadGroupStream is a stream XREADGROUP reads from. Unless New is set, which XREADGROUP spells >,
+// the entries read are the ones pending for the consumer with an ID above After.
+type ReadGroupStream struct {
+	Key   string
+	After ID
+	New   bool
+}
+
+// StreamEntries are the entries read from the stream stored at Key.
+type StreamEntries struct {
+	Key     string
+	Entries []Entry
+}
+
+// PendingSummary sums up the entries pending in a consumer group.
+type PendingSummary struct {
+	Count           int
+	Lowest, Highest ID
+	// Consumers are the consumers with pending entries, ordered by name.
+	Consumers []ConsumerPending
+}
+
+type ConsumerPending struct {
+	Name  string
+	Count int
+}
+
+// PendingRange selects the pending entries XPENDING lists in its extended form.
+type PendingRange struct {
+	Start, End ID
+	Count      int
+	MinIdle    time.Duration
+	// Consumer, unless empty, only selects the entries delivered to it.
+	Consumer string
+}
+
+// PendingEntry is an entry delivered to a consumer and not acknowledged yet.
+type PendingEntry struct {
+	ID            ID
+	Consumer      string
+	Idle          time.Duration
+	DeliveryCount int64
+}
+
+// ClaimOptions are the options of XCLAIM.
+type ClaimOptions struct {
+	// DeliveryTime is the time claimed entries are considered delivered at, now if zero.
+	DeliveryTime time.Time
+	// RetryCount is the delivery count of claimed entries, which is incremented instead when
+	// RetryCount is negative, unless JustID is set.
+	RetryCount int64
+	// Force claims entries pending for no consumer as long as the stream holds them.
+	Force bool
+	// JustID leaves the fields of the entries claimed out.
+	JustID bool
+	// LastID becomes the last ID delivered by the group if it is above it.
+	LastID ID
+}
+
+// AutoClaimResult is the reply of XAUTOCLAIM: the entries claimed, the IDs of the pending entries
+// deleted from the stream, which are no longer pending, and the ID to resume scanning from, 0-0
+// once every pending entry was scanned.
+type AutoClaimResult struct {
+	Next    ID
+	Claimed []Entry
+	Deleted []ID
+}
+
+// GroupInfo describes a consumer group as XINFO GROUPS does.
+type GroupInfo struct {
+	Name            string
+	Consumers       int
+	Pending         int
+	LastDeliveredID ID
+	// EntriesRead and Lag are UnknownEntriesRead when they cannot be told.
+	EntriesRead int64
+	Lag         int64
+}
+
+// ConsumerInfo describes a consumer as XINFO CONSUMERS does.
+type ConsumerInfo struct {
+	Name    string
+	Pending int
+	// Idle is the time since the consumer last attempted a read or claim, Inactive the time since
+	// it last read or claimed an entry, negative if it never did.
+	Idle, Inactive time.Duration
+}
@@ -0,0 +1,157 @@
+package streams
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidID = errors.New("ERR Invalid stream ID specified as stream command argument")
+	// ErrIDTooSmall is returned when XADD is given an ID not above the last one of the stream.
+	ErrIDTooSmall = errors.New("ERR The ID specified in XADD is equal or smaller than the target stream top item")
+	ErrIDZero     = errors.New("ERR The ID specified in XADD must be greater than 0-0")
+	// ErrIDExhausted is returned when XADD generates an ID for a stream whose last ID is the maximum one.
+	ErrIDExhausted    = errors.New("ERR The stream has exhausted the last possible ID, unable to add more items")
+	ErrInvalidStartID = errors.New("ERR invalid start ID for the interval")
+	ErrInvalidEndID   = errors.New("ERR invalid end ID for the interval")
+)
+
+// ID identifies a stream entry: the Unix time in milliseconds it was added at, and a sequence
+// number telling apart the entries added within the same millisecond.
+type ID struct {
+	Ms, Seq uint64
+}
+
+// MinID and MaxID are the lowest and highest IDs, which XRANGE spells - and +.
+var (
+	MinID = ID{}
+	MaxID = ID{Ms: math.MaxUint64, Seq: math.MaxUint64}
+)
+
+func (id ID) String() string {
+	return strconv.FormatUint(id.Ms, 10) + "-" + strconv.FormatUint(id.Seq, 10)
+}
+
+// Compare returns -1, 0 or 1 as id is below, equal to or above other.
+func (id ID) Compare(other ID) int {
+	switch {
+	case id.Ms < other.Ms || (id.Ms == other.Ms && id.Seq < other.Seq):
+		return -1
+	case id == other:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Next returns the ID following id, or false if id is MaxID.
+func (id ID) Next() (ID, bool) {
+	switch {
+	case id.Seq < math.MaxUint64:
+		return ID{Ms: id.Ms, Seq: id.Seq + 1}, true
+	case id.Ms < math.MaxUint64:
+		return ID{Ms: id.Ms + 1}, true
+	default:
+		return id, false
+	}
+}
+
+// Prev returns the ID preceding id, or false if id is MinID.
+func (id ID) Prev() (ID, bool) {
+	switch {
+	case id.Seq > 0:
+		return ID{Ms: id.Ms, Seq: id.Seq - 1}, true
+	case id.Ms > 0:
+		return ID{Ms: id.Ms - 1, Seq: math.MaxUint64}, true
+	default:
+		return id, false
+	}
+}
+
+// ParseID parses an ID spelled ms-seq, or ms alone, in which case the sequence is missingSeq.
+func ParseID(s string, missingSeq uint64) (ID, error) {
+	msPart, seqPart, hasSeq := strings.Cut(s, "-")
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return ID{}, ErrInvalidID
+	}
+	if !hasSeq {
+		return ID{Ms: ms, Seq: missingSeq}, nil
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return ID{}, ErrInvalidID
+	}
+	return ID{Ms: ms, Seq: seq}, nil
+}
+
+// AddID is the ID XADD is asked to add an entry at: * generates the whole ID, ms-* only its
+// sequence number, and any other ID is taken as is.
+type AddID struct {
+	ID ID
+	// AutoMs and AutoSeq are set when the milliseconds or the sequence number are generated,
+	// AutoMs implying AutoSeq.
+	AutoMs, AutoSeq bool
+}
+
+// ParseAddID parses the ID argument of XADD.
+func ParseAddID(s string) (AddID, error) {
+	if s == "*" {
+		return AddID{AutoMs: true, AutoSeq: true}, nil
+	}
+	if msPart, found := strings.CutSuffix(s, "-*"); found {
+		ms, err := strconv.ParseUint(msPart, 10, 64)
+		if err != nil {
+			return AddID{}, ErrInvalidID
+		}
+		return AddID{ID: ID{Ms: ms}, AutoSeq: true}, nil
+	}
+	id, err := ParseID(s, 0)
+	if err != nil {
+		return AddID{}, err
+	}
+	if id == MinID {
+		return AddID{}, ErrIDZero
+	}
+	return AddID{ID: id}, nil
+}
+
+// ParseRangeStart parses the start of an XRANGE interval: - for the lowest ID, an ID missing its
+// sequence starting at sequence 0, and a ( prefix excluding the ID.
+func ParseRangeStart(s string) (ID, error) {
+	if s == "-" {
+		return MinID, nil
+	}
+	if exclusive, found := strings.CutPrefix(s, "("); found {
+		id, err := ParseID(exclusive, 0)
+		if err != nil {
+			return ID{}, err
+		}
+		if id, ok := id.Next(); ok {
+			return id, nil
+		}
+		return ID{}, ErrInvalidStartID
+	}
+	return ParseID(s, 0)
+}
+
+// ParseRangeEnd parses the end of an XRANGE interval: + for the highest ID, an ID missing its
+// sequence ending at the last sequence of its millisecond, and a ( prefix excluding the ID.
+func ParseRangeEnd(s string) (ID, error) {
+	if s == "+" {
+		return MaxID, nil
+	}
+	if exclusive, found := strings.CutPrefix(s, "("); found {
+		id, err := ParseID(exclusive, math.MaxUint64)
+		if err != nil {
+			return ID{}, err
+		}
+		if id, ok := id.Prev(); ok {
+			return id, nil
+		}
+		return ID{}, ErrInvalidEndID
+	}
+	return ParseID(s, math.MaxUint64)
+}
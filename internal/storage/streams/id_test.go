@@ -0,0 +1,77 @@
+package streams
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestID_NextAndPrev(t *testing.T) {
+	next, ok := ID{Ms: 1, Seq: math.MaxUint64}.Next()
+	assert.True(t, ok)
+	assert.Equal(t, ID{Ms: 2}, next)
+	_, ok = MaxID.Next()
+	assert.False(t, ok)
+
+	prev, ok := ID{Ms: 2}.Prev()
+	assert.True(t, ok)
+	assert.Equal(t, ID{Ms: 1, Seq: math.MaxUint64}, prev)
+	_, ok = MinID.Prev()
+	assert.False(t, ok)
+
+	assert.Equal(t, -1, ID{Ms: 1, Seq: 5}.Compare(ID{Ms: 2}))
+	assert.Equal(t, 1, ID{Ms: 1, Seq: 5}.Compare(ID{Ms: 1, Seq: 4}))
+	assert.Equal(t, 0, ID{Ms: 1, Seq: 5}.Compare(ID{Ms: 1, Seq: 5}))
+	assert.Equal(t, "1-5", ID{Ms: 1, Seq: 5}.String())
+}
+
+func TestParseAddID(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected AddID
+		err      error
+	}{
+		{"*", AddID{AutoMs: true, AutoSeq: true}, nil},
+		{"5-*", AddID{ID: ID{Ms: 5}, AutoSeq: true}, nil},
+		{"5-3", AddID{ID: ID{Ms: 5, Seq: 3}}, nil},
+		{"5", AddID{ID: ID{Ms: 5}}, nil},
+		{"0-0", AddID{}, ErrIDZero},
+		{"0", AddID{}, ErrIDZero},
+		{"-", AddID{}, ErrInvalidID},
+		{"5-x", AddID{}, ErrInvalidID},
+		{"-1-*", AddID{}, ErrInvalidID},
+		{"18446744073709551616", AddID{}, ErrInvalidID},
+	}
+	for _, tt := range tests {
+		id, err := ParseAddID(tt.input)
+		assert.Equal(t, tt.err, err, tt.input)
+		assert.Equal(t, tt.expected, id, tt.input)
+	}
+}
+
+func TestParseRangeBounds(t *testing.T) {
+	start, err := ParseRangeStart("-")
+	assert.NoError(t, err)
+	assert.Equal(t, MinID, start)
+	start, _ = ParseRangeStart("5")
+	assert.Equal(t, ID{Ms: 5}, start)
+	start, _ = ParseRangeStart("(5-3")
+	assert.Equal(t, ID{Ms: 5, Seq: 4}, start)
+	_, err = ParseRangeStart("(18446744073709551615-18446744073709551615")
+	assert.Equal(t, ErrInvalidStartID, err)
+	_, err = ParseRangeStart("(-")
+	assert.Equal(t, ErrInvalidID, err)
+
+	end, err := ParseRangeEnd("+")
+	assert.NoError(t, err)
+	assert.Equal(t, MaxID, end)
+	end, _ = ParseRangeEnd("5")
+	assert.Equal(t, ID{Ms: 5, Seq: math.MaxUint64}, end)
+	end, _ = ParseRangeEnd("(5-0")
+	assert.Equal(t, ID{Ms: 4, Seq: math.MaxUint64}, end)
+	_, err = ParseRangeEnd("(0-0")
+	assert.Equal(t, ErrInvalidEndID, err)
+	_, err = ParseRangeEnd("abc")
+	assert.Equal(t, ErrInvalidID, err)
+}
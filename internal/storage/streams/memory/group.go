@@ -0,0 +1,438 @@
+package memory
+
+import (
+	"avacado/internal/storage/rax"
+	"avacado/internal/storage/streams"
+	"time"
+)
+
+const (
+	// groupOverhead, consumerOverhead and pendingOverhead approximate the bytes used by each
+	// consumer group, consumer and pending entry.
+	groupOverhead    = 96
+	consumerOverhead = 64
+	pendingOverhead  = 64
+)
+
+// pendingEntry is an entry delivered to a consumer and not acknowledged yet, Redis's NACK.
+type pendingEntry struct {
+	consumer      *consumer
+	deliveryTime  time.Time
+	deliveryCount int64
+}
+
+type consumer struct {
+	name string
+	// seenTime is when the consumer last attempted a read or claim, activeTime when it last
+	// read or claimed an entry, zero if it never did.
+	seenTime, activeTime time.Time
+	// pending are the entries pending for the consumer, keyed as nodes are.
+	pending *rax.Tree[*pendingEntry]
+}
+
+func newConsumer(name string, now time.Time) *consumer {
+	return &consumer{name: name, seenTime: now, pending: rax.New[*pendingEntry]()}
+}
+
+// consumerGroup tracks the entries delivered to its consumers: the last ID delivered, and the
+// entries pending acknowledgement, each shared by the group and the consumer it was delivered to.
+type consumerGroup struct {
+	lastID streams.ID
+	// entriesRead is the number of entries added to the stream up to lastID, or
+	// streams.UnknownEntriesRead when deleted entries keep it from being told.
+	entriesRead int64
+	pending     *rax.Tree[*pendingEntry]
+	consumers   *rax.Tree[*consumer]
+}
+
+func newConsumerGroup(lastID streams.ID, entriesRead int64) *consumerGroup {
+	return &consumerGroup{
+		lastID:      lastID,
+		entriesRead: entriesRead,
+		pending:     rax.New[*pendingEntry](),
+		consumers:   rax.New[*consumer](),
+	}
+}
+
+// consumer returns the consumer named name, creating it if needed, seen at now.
+func (g *consumerGroup) consumer(name string, now time.Time) *consumer {
+	c, found := g.consumers.Get(name)
+	if !found {
+		c = newConsumer(name, now)
+		g.consumers.Set(name, c)
+	}
+	c.seenTime = now
+	return c
+}
+
+// deliver makes the entry stored at key pending for c, whichever consumer it was pending for.
+func (g *consumerGroup) deliver(key string, c *consumer, now time.Time) {
+	p, found := g.pending.Get(key)
+	if !found {
+		p = &pendingEntry{}
+		g.pending.Set(key, p)
+	}
+	g.assign(key, p, c)
+	p.deliveryTime = now
+	p.deliveryCount = 1
+}
+
+// assign moves the pending entry p stored at key to c.
+func (g *consumerGroup) assign(key string, p *pendingEntry, c *consumer) {
+	if p.consumer == c {
+		return
+	}
+	if p.consumer != nil {
+		p.consumer.pending.Delete(key)
+	}
+	p.consumer = c
+	c.pending.Set(key, p)
+}
+
+// acknowledge removes the entry stored at key from the pending entries, and reports whether it
+// was pending.
+func (g *consumerGroup) acknowledge(key string) bool {
+	p, found := g.pending.Delete(key)
+	if found {
+		p.consumer.pending.Delete(key)
+	}
+	return found
+}
+
+// deleteConsumer removes the consumer named name along with its pending entries, and returns how
+// many were pending.
+func (g *consumerGroup) deleteConsumer(name string) int {
+	c, found := g.consumers.Delete(name)
+	if !found {
+		return 0
+	}
+	c.pending.Ascend("", func(key string, _ *pendingEntry) bool {
+		g.pending.Delete(key)
+		return true
+	})
+	return c.pending.Len()
+}
+
+func (g *consumerGroup) summary() streams.PendingSummary {
+	summary := streams.PendingSummary{Count: g.pending.Len()}
+	if summary.Count == 0 {
+		return summary
+	}
+	lowest, _, _ := g.pending.First()
+	highest, _, _ := g.pending.Last()
+	summary.Lowest, summary.Highest = keyID(lowest), keyID(highest)
+	g.consumers.Ascend("", func(name string, c *consumer) bool {
+		if c.pending.Len() > 0 {
+			summary.Consumers = append(summary.Consumers, streams.ConsumerPending{Name: name, Count: c.pending.Len()})
+		}
+		return true
+	})
+	return summary
+}
+
+func (g *consumerGroup) pendingRange(selection streams.PendingRange, now time.Time) []streams.PendingEntry {
+	if selection.Count <= 0 || selection.Start.Compare(selection.End) > 0 {
+		return nil
+	}
+	pending := g.pending
+	if selection.Consumer != "" {
+		c, found := g.consumers.Get(selection.Consumer)
+		if !found {
+			return nil
+		}
+		pending = c.pending
+	}
+	var entries []streams.PendingEntry
+	pending.Ascend(nodeKey(selection.Start), func(key string, p *pendingEntry) bool {
+		id := keyID(key)
+		if id.Compare(selection.End) > 0 {
+			return false
+		}
+		idle := idleTime(p.deliveryTime, now)
+		if idle < selection.MinIdle {
+			return true
+		}
+		entries = append(entries, streams.PendingEntry{ID: id, Consumer: p.consumer.name, Idle: idle, DeliveryCount: p.deliveryCount})
+		return len(entries) < selection.Count
+	})
+	return entries
+}
+
+func (g *consumerGroup) consumersInfo(now time.Time) []streams.ConsumerInfo {
+	infos := make([]streams.ConsumerInfo, 0, g.consumers.Len())
+	g.consumers.Ascend("", func(name string, c *consumer) bool {
+		inactive := time.Duration(-1)
+		if !c.activeTime.IsZero() {
+			inactive = idleTime(c.activeTime, now)
+		}
+		infos = append(infos, streams.ConsumerInfo{Name: name, Pending: c.pending.Len(), Idle: idleTime(c.seenTime, now), Inactive: inactive})
+		return true
+	})
+	return infos
+}
+
+func (g *consumerGroup) clone() *consumerGroup {
+	clone := newConsumerGroup(g.lastID, g.entriesRead)
+	g.consumers.Ascend("", func(name string, c *consumer) bool {
+		cloned := &consumer{name: name, seenTime: c.seenTime, activeTime: c.activeTime, pending: rax.New[*pendingEntry]()}
+		clone.consumers.Set(name, cloned)
+		c.pending.Ascend("", func(key string, p *pendingEntry) bool {
+			pending := &pendingEntry{consumer: cloned, deliveryTime: p.deliveryTime, deliveryCount: p.deliveryCount}
+			cloned.pending.Set(key, pending)
+			clone.pending.Set(key, pending)
+			return true
+		})
+		return true
+	})
+	return clone
+}
+
+func (g *consumerGroup) memoryUsage() int64 {
+	return groupOverhead + int64(g.consumers.Len())*consumerOverhead + int64(g.pending.Len())*pendingOverhead
+}
+
+// idleTime returns the time elapsed from since to now, 0 if since is ahead.
+func idleTime(since, now time.Time) time.Duration {
+	return max(now.Sub(since), 0)
+}
+
+// groupStart returns the last ID start designates.
+func (s *Stream) groupStart(start streams.GroupStart) streams.ID {
+	if start.Last {
+		return s.lastID
+	}
+	return start.ID
+}
+
+// readNew delivers to c up to count entries never delivered to g, all of them if count is not
+// positive, and returns them. Unless noAck is set, they become pending for c.
+func (s *Stream) readNew(g *consumerGroup, c *consumer, count int, noAck bool, now time.Time) []streams.Entry {
+	start, ok := g.lastID.Next()
+	if !ok {
+		return nil
+	}
+	if count <= 0 {
+		count = -1
+	}
+	entries := s.Range(start, streams.MaxID, count, false)
+	for _, entry := range entries {
+		s.advance(g, entry.ID)
+		if !noAck {
+			g.deliver(nodeKey(entry.ID), c, now)
+		}
+	}
+	if len(entries) > 0 {
+		c.activeTime = now
+	}
+	return entries
+}
+
+// readPending returns up to count entries pending for c with an ID above after, all of them if
+// count is not positive, counting them as delivered again. The ones deleted from the stream are
+// returned without fields.
+func (s *Stream) readPending(c *consumer, after streams.ID, count int, now time.Time) []streams.Entry {
+	entries := []streams.Entry{}
+	start, ok := after.Next()
+	if !ok {
+		return entries
+	}
+	c.pending.Ascend(nodeKey(start), func(key string, p *pendingEntry) bool {
+		entry, found := s.entry(keyID(key))
+		if found {
+			p.deliveryTime = now
+			p.deliveryCount++
+		}
+		entries = append(entries, entry)
+		return count <= 0 || len(entries) < count
+	})
+	return entries
+}
+
+// entry returns the live entry with id, or an entry holding id alone if there is none.
+func (s *Stream) entry(id streams.ID) (streams.Entry, bool) {
+	entries := s.Range(id, id, 1, false)
+	if len(entries) == 0 {
+		return streams.Entry{ID: id}, false
+	}
+	return entries[0], true
+}
+
+// advance moves the last ID delivered by g up to id, keeping count of the entries read as Redis does.
+func (s *Stream) advance(g *consumerGroup, id streams.ID) {
+	if g.entriesRead != streams.UnknownEntriesRead && !s.hasTombstones(id) {
+		g.entriesRead++
+	} else if s.entriesAdded > 0 {
+		g.entriesRead = s.entriesReadUpTo(id)
+	}
+	g.lastID = id
+}
+
+// hasTombstones reports whether entries may have been deleted from the stream at or after start.
+func (s *Stream) hasTombstones(start streams.ID) bool {
+	if s.length == 0 || s.maxDeletedID == streams.MinID || s.First().ID.Compare(s.maxDeletedID) > 0 {
+		return false
+	}
+	return start.Compare(s.maxDeletedID) <= 0
+}
+
+// entriesReadUpTo estimates how many entries were added to the stream up to id, as Redis's
+// streamEstimateDistanceFromFirstEverEntry does, or returns streams.UnknownEntriesRead.
+func (s *Stream) entriesReadUpTo(id streams.ID) int64 {
+	added := int64(s.entriesAdded)
+	if added == 0 {
+		return 0
+	}
+	switch id.Compare(s.lastID) {
+	case 0:
+		return added
+	case 1:
+		return streams.UnknownEntriesRead
+	}
+	if s.length == 0 {
+		return added
+	}
+	first := s.First().ID
+	if s.maxDeletedID == streams.MinID || s.maxDeletedID.Compare(first) < 0 {
+		switch id.Compare(first) {
+		case -1:
+			return added - int64(s.length)
+		case 0:
+			return added - int64(s.length) + 1
+		}
+	}
+	return streams.UnknownEntriesRead
+}
+
+// lag returns how many entries were added to the stream and not read by g yet, or
+// streams.UnknownEntriesRead.
+func (s *Stream) lag(g *consumerGroup) int64 {
+	added := int64(s.entriesAdded)
+	if added == 0 {
+		return 0
+	}
+	if g.entriesRead != streams.UnknownEntriesRead && !s.hasTombstones(g.lastID) {
+		return added - g.entriesRead
+	}
+	read := s.entriesReadUpTo(g.lastID)
+	if read == streams.UnknownEntriesRead {
+		return streams.UnknownEntriesRead
+	}
+	return added - read
+}
+
+// claim transfers to the consumer named name the pending entries among ids idle for at least
+// minIdle, as XCLAIM does, and returns them.
+func (s *Stream) claim(g *consumerGroup, name string, minIdle time.Duration, ids []streams.ID, options streams.ClaimOptions, now time.Time) []streams.Entry {
+	deliveryTime := options.DeliveryTime
+	if deliveryTime.IsZero() || deliveryTime.After(now) {
+		deliveryTime = now
+	}
+	c, found := g.consumers.Get(name)
+	if found {
+		c.seenTime = now
+	}
+	claimed := []streams.Entry{}
+	for _, id := range ids {
+		key := nodeKey(id)
+		p, pending := g.pending.Get(key)
+		entry, exists := s.entry(id)
+		if !exists {
+			// An entry deleted from the stream cannot be processed anymore.
+			g.acknowledge(key)
+			continue
+		}
+		if !pending {
+			if !options.Force {
+				continue
+			}
+			p = &pendingEntry{}
+			g.pending.Set(key, p)
+		} else if idleTime(p.deliveryTime, now) < minIdle {
+			continue
+		}
+		c = g.consumer(name, now)
+		g.assign(key, p, c)
+		p.deliveryTime = deliveryTime
+		if options.RetryCount >= 0 {
+			p.deliveryCount = options.RetryCount
+		} else if !options.JustID {
+			p.deliveryCount++
+		}
+		c.activeTime = now
+		if options.JustID {
+			entry.Fields = nil
+		}
+		claimed = append(claimed, entry)
+	}
+	if options.LastID.Compare(g.lastID) > 0 {
+		g.lastID = options.LastID
+	}
+	return claimed
+}
+
+// autoClaimAttempts is how many pending entries XAUTOCLAIM scans for each entry it may claim.
+const autoClaimAttempts = 10
+
+// autoClaim claims up to count pending entries idle for at least minIdle, scanning them from
+// start, as XAUTOCLAIM does.
+func (s *Stream) autoClaim(g *consumerGroup, name string, minIdle time.Duration, start streams.ID, count int, justID bool, now time.Time) streams.AutoClaimResult {
+	attempts := count * autoClaimAttempts
+	var keys []string
+	var scanned []*pendingEntry
+	g.pending.Ascend(nodeKey(start), func(key string, p *pendingEntry) bool {
+		keys = append(keys, key)
+		scanned = append(scanned, p)
+		return len(keys) <= attempts
+	})
+	c, found := g.consumers.Get(name)
+	if found {
+		c.seenTime = now
+	}
+	result := streams.AutoClaimResult{Claimed: []streams.Entry{}, Deleted: []streams.ID{}}
+	i := 0
+	for ; i < len(keys) && i < attempts && count > 0; i++ {
+		key, p := keys[i], scanned[i]
+		entry, exists := s.entry(keyID(key))
+		if !exists {
+			g.acknowledge(key)
+			result.Deleted = append(result.Deleted, entry.ID)
+			count--
+			continue
+		}
+		if idleTime(p.deliveryTime, now) < minIdle {
+			continue
+		}
+		c = g.consumer(name, now)
+		g.assign(key, p, c)
+		p.deliveryTime = now
+		if !justID {
+			p.deliveryCount++
+		}
+		c.activeTime = now
+		if justID {
+			entry.Fields = nil
+		}
+		result.Claimed = append(result.Claimed, entry)
+		count--
+	}
+	if i < len(keys) {
+		result.Next = keyID(keys[i])
+	}
+	return result
+}
+
+func (s *Stream) groupsInfo() []streams.GroupInfo {
+	infos := make([]streams.GroupInfo, 0, s.groups.Len())
+	s.groups.Ascend("", func(name string, g *consumerGroup) bool {
+		infos = append(infos, streams.GroupInfo{
+			Name:            name,
+			Consumers:       g.consumers.Len(),
+			Pending:         g.pending.Len(),
+			LastDeliveredID: g.lastID,
+			EntriesRead:     g.entriesRead,
+			Lag:             s.lag(g),
+		})
+		return true
+	})
+	return infos
+}
@@ -0,0 +1,311 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
+	"avacado/internal/storage/streams"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGroupStreams returns streams holding n entries at i-0 for each i in [1, n] at "stream", read
+// by the group "group" from its start.
+func newGroupStreams(t *testing.T, n int) *Streams {
+	s := NewStreams(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	for i := 1; i <= n; i++ {
+		_, _, err := s.XAdd(ctx, "stream", streams.AddID{ID: id(uint64(i), 0)}, []string{"f", "v"}, streams.AddOptions{})
+		require.NoError(t, err)
+	}
+	require.NoError(t, s.XGroupCreate(ctx, "stream", "group", streams.GroupStart{}, streams.UnknownEntriesRead, false))
+	return s
+}
+
+func readNew(t *testing.T, s *Streams, consumer string, count int) []streams.ID {
+	result, err := s.XReadGroup(context.Background(), "group", consumer, []streams.ReadGroupStream{{Key: "stream", New: true}}, count, false)
+	require.NoError(t, err)
+	if len(result) == 0 {
+		return nil
+	}
+	return ids(result[0].Entries)
+}
+
+func TestStreams_XGroupCreate(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	s := NewStreams(ks, DefaultConfig())
+	ctx := context.Background()
+
+	err := s.XGroupCreate(ctx, "stream", "group", streams.GroupStart{Last: true}, streams.UnknownEntriesRead, false)
+	assert.Equal(t, streams.ErrGroupKeyMissing, err)
+	assert.NoError(t, s.XGroupCreate(ctx, "stream", "group", streams.GroupStart{Last: true}, streams.UnknownEntriesRead, true))
+	err = s.XGroupCreate(ctx, "stream", "group", streams.GroupStart{}, streams.UnknownEntriesRead, true)
+	assert.Equal(t, streams.ErrBusyGroup, err)
+
+	info, err := s.XInfo(ctx, "stream")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, info.Length)
+	assert.Equal(t, 1, info.Groups)
+}
+
+func TestStreams_XReadGroupDeliversNewEntriesOnce(t *testing.T) {
+	s := newGroupStreams(t, 3)
+
+	assert.Equal(t, []streams.ID{id(1, 0), id(2, 0)}, readNew(t, s, "alice", 2))
+	assert.Equal(t, []streams.ID{id(3, 0)}, readNew(t, s, "bob", 0))
+	assert.Nil(t, readNew(t, s, "alice", 0))
+
+	summary, err := s.XPending(context.Background(), "stream", "group")
+	assert.NoError(t, err)
+	assert.Equal(t, streams.PendingSummary{
+		Count:     3,
+		Lowest:    id(1, 0),
+		Highest:   id(3, 0),
+		Consumers: []streams.ConsumerPending{{Name: "alice", Count: 2}, {Name: "bob", Count: 1}},
+	}, summary)
+}
+
+func TestStreams_XReadGroupHistory(t *testing.T) {
+	s := newGroupStreams(t, 3)
+	ctx := context.Background()
+	readNew(t, s, "alice", 0)
+	_, _ = s.XDel(ctx, "stream", []streams.ID{id(2, 0)})
+
+	history := []streams.ReadGroupStream{{Key: "stream", After: id(1, 0)}}
+	result, err := s.XReadGroup(ctx, "group", "alice", history, 0, false)
+	assert.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, []streams.Entry{{ID: id(2, 0)}, {ID: id(3, 0), Fields: []string{"f", "v"}}}, result[0].Entries)
+
+	pending, err := s.XPendingRange(ctx, "stream", "group", streams.PendingRange{Start: streams.MinID, End: streams.MaxID, Count: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 1, 2}, []int64{pending[0].DeliveryCount, pending[1].DeliveryCount, pending[2].DeliveryCount})
+
+	result, _ = s.XReadGroup(ctx, "group", "bob", history, 0, false)
+	assert.Equal(t, []streams.StreamEntries{{Key: "stream", Entries: []streams.Entry{}}}, result)
+}
+
+func TestStreams_XReadGroupNoAck(t *testing.T) {
+	s := newGroupStreams(t, 2)
+	ctx := context.Background()
+
+	result, err := s.XReadGroup(ctx, "group", "alice", []streams.ReadGroupStream{{Key: "stream", New: true}}, 0, true)
+	assert.NoError(t, err)
+	assert.Len(t, result[0].Entries, 2)
+	summary, _ := s.XPending(ctx, "stream", "group")
+	assert.Equal(t, 0, summary.Count)
+}
+
+func TestStreams_XReadGroupChecksEveryGroupFirst(t *testing.T) {
+	s := newGroupStreams(t, 2)
+	ctx := context.Background()
+
+	reads := []streams.ReadGroupStream{{Key: "stream", New: true}, {Key: "missing", New: true}}
+	_, err := s.XReadGroup(ctx, "group", "alice", reads, 0, false)
+	assert.Equal(t, streams.NewNoReadGroupError("missing", "group"), err)
+	summary, _ := s.XPending(ctx, "stream", "group")
+	assert.Equal(t, 0, summary.Count)
+}
+
+func TestStreams_XReadGroupReady(t *testing.T) {
+	s := newGroupStreams(t, 2)
+	ctx := context.Background()
+
+	ready, err := s.XReadGroupReady(ctx, "stream", "group")
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	readNew(t, s, "alice", 0)
+	ready, _ = s.XReadGroupReady(ctx, "stream", "group")
+	assert.False(t, ready)
+	_, _, _ = s.XAdd(ctx, "stream", streams.AddID{ID: id(3, 0)}, []string{"f", "v"}, streams.AddOptions{})
+	ready, _ = s.XReadGroupReady(ctx, "stream", "group")
+	assert.True(t, ready)
+
+	_, err = s.XReadGroupReady(ctx, "stream", "missing")
+	assert.Equal(t, streams.NewNoReadGroupError("stream", "missing"), err)
+}
+
+func TestStreams_XAck(t *testing.T) {
+	s := newGroupStreams(t, 3)
+	ctx := context.Background()
+	readNew(t, s, "alice", 0)
+
+	acknowledged, err := s.XAck(ctx, "stream", "group", []streams.ID{id(1, 0), id(3, 0), id(3, 0), id(9, 0)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, acknowledged)
+	acknowledged, err = s.XAck(ctx, "missing", "group", []streams.ID{id(2, 0)})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, acknowledged)
+
+	consumers, _ := s.XInfoConsumers(ctx, "stream", "group")
+	assert.Equal(t, 1, consumers[0].Pending)
+}
+
+func TestStreams_XPendingRange(t *testing.T) {
+	s := newGroupStreams(t, 4)
+	ctx := context.Background()
+	readNew(t, s, "alice", 2)
+	readNew(t, s, "bob", 2)
+
+	pending, err := s.XPendingRange(ctx, "stream", "group", streams.PendingRange{Start: id(2, 0), End: streams.MaxID, Count: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []streams.ID{id(2, 0), id(3, 0)}, []streams.ID{pending[0].ID, pending[1].ID})
+	assert.Equal(t, []string{"alice", "bob"}, []string{pending[0].Consumer, pending[1].Consumer})
+
+	pending, _ = s.XPendingRange(ctx, "stream", "group", streams.PendingRange{Start: streams.MinID, End: streams.MaxID, Count: 10, Consumer: "bob"})
+	assert.Len(t, pending, 2)
+	pending, _ = s.XPendingRange(ctx, "stream", "group", streams.PendingRange{Start: streams.MinID, End: streams.MaxID, Count: 10, MinIdle: time.Hour})
+	assert.Empty(t, pending)
+
+	_, err = s.XPending(ctx, "stream", "missing")
+	assert.Equal(t, streams.NewNoKeyOrGroupError("stream", "missing"), err)
+}
+
+func TestStreams_XClaim(t *testing.T) {
+	s := newGroupStreams(t, 3)
+	ctx := context.Background()
+	readNew(t, s, "alice", 2)
+	_, _ = s.XDel(ctx, "stream", []streams.ID{id(2, 0)})
+
+	claimed, err := s.XClaim(ctx, "stream", "group", "bob", time.Hour, []streams.ID{id(1, 0)}, streams.ClaimOptions{RetryCount: -1})
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	options := streams.ClaimOptions{RetryCount: -1, LastID: id(3, 0)}
+	claimed, err = s.XClaim(ctx, "stream", "group", "bob", 0, []streams.ID{id(1, 0), id(2, 0), id(3, 0)}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []streams.Entry{{ID: id(1, 0), Fields: []string{"f", "v"}}}, claimed)
+
+	pending, _ := s.XPendingRange(ctx, "stream", "group", streams.PendingRange{Start: streams.MinID, End: streams.MaxID, Count: 10})
+	require.Len(t, pending, 1)
+	pending[0].Idle = 0
+	assert.Equal(t, streams.PendingEntry{ID: id(1, 0), Consumer: "bob", DeliveryCount: 2}, pending[0])
+	assert.Nil(t, readNew(t, s, "alice", 0))
+
+	options = streams.ClaimOptions{RetryCount: 7, Force: true, JustID: true}
+	claimed, _ = s.XClaim(ctx, "stream", "group", "carol", 0, []streams.ID{id(3, 0)}, options)
+	assert.Equal(t, []streams.Entry{{ID: id(3, 0)}}, claimed)
+	pending, _ = s.XPendingRange(ctx, "stream", "group", streams.PendingRange{Start: id(3, 0), End: id(3, 0), Count: 1})
+	require.Len(t, pending, 1)
+	assert.Equal(t, "carol", pending[0].Consumer)
+	assert.Equal(t, int64(7), pending[0].DeliveryCount)
+}
+
+func TestStreams_XAutoClaim(t *testing.T) {
+	s := newGroupStreams(t, 4)
+	ctx := context.Background()
+	readNew(t, s, "alice", 0)
+	_, _ = s.XDel(ctx, "stream", []streams.ID{id(2, 0)})
+
+	result, err := s.XAutoClaim(ctx, "stream", "group", "bob", 0, streams.MinID, 2, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []streams.ID{id(1, 0)}, ids(result.Claimed))
+	assert.Equal(t, []streams.ID{id(2, 0)}, result.Deleted)
+	assert.Equal(t, id(3, 0), result.Next)
+
+	result, _ = s.XAutoClaim(ctx, "stream", "group", "bob", 0, result.Next, 2, true)
+	assert.Equal(t, []streams.Entry{{ID: id(3, 0)}, {ID: id(4, 0)}}, result.Claimed)
+	assert.Equal(t, streams.MinID, result.Next)
+
+	summary, _ := s.XPending(ctx, "stream", "group")
+	assert.Equal(t, []streams.ConsumerPending{{Name: "bob", Count: 3}}, summary.Consumers)
+}
+
+func TestStreams_XGroupDelConsumer(t *testing.T) {
+	s := newGroupStreams(t, 3)
+	ctx := context.Background()
+	readNew(t, s, "alice", 2)
+	readNew(t, s, "bob", 1)
+
+	created, err := s.XGroupCreateConsumer(ctx, "stream", "group", "alice")
+	assert.NoError(t, err)
+	assert.False(t, created)
+	pending, err := s.XGroupDelConsumer(ctx, "stream", "group", "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pending)
+
+	summary, _ := s.XPending(ctx, "stream", "group")
+	assert.Equal(t, 1, summary.Count)
+	_, err = s.XGroupDelConsumer(ctx, "stream", "missing", "alice")
+	assert.Equal(t, streams.NewNoGroupError("stream", "missing"), err)
+}
+
+func TestStreams_XGroupSetIDAndDestroy(t *testing.T) {
+	s := newGroupStreams(t, 3)
+	ctx := context.Background()
+
+	assert.NoError(t, s.XGroupSetID(ctx, "stream", "group", streams.GroupStart{ID: id(2, 0)}, streams.UnknownEntriesRead))
+	assert.Equal(t, []streams.ID{id(3, 0)}, readNew(t, s, "alice", 0))
+
+	destroyed, err := s.XGroupDestroy(ctx, "stream", "group")
+	assert.NoError(t, err)
+	assert.True(t, destroyed)
+	destroyed, _ = s.XGroupDestroy(ctx, "stream", "group")
+	assert.False(t, destroyed)
+	_, err = s.XGroupDestroy(ctx, "missing", "group")
+	assert.Equal(t, streams.ErrGroupKeyMissing, err)
+}
+
+func TestStreams_XInfoGroupsTracksEntriesReadAndLag(t *testing.T) {
+	s := newGroupStreams(t, 4)
+	ctx := context.Background()
+
+	groups, err := s.XInfoGroups(ctx, "stream")
+	assert.NoError(t, err)
+	assert.Equal(t, []streams.GroupInfo{{Name: "group", EntriesRead: streams.UnknownEntriesRead, Lag: 4}}, groups)
+
+	readNew(t, s, "alice", 1)
+	groups, _ = s.XInfoGroups(ctx, "stream")
+	assert.Equal(t, int64(1), groups[0].EntriesRead)
+	assert.Equal(t, int64(3), groups[0].Lag)
+
+	_, _ = s.XDel(ctx, "stream", []streams.ID{id(3, 0)})
+	groups, _ = s.XInfoGroups(ctx, "stream")
+	assert.Equal(t, int64(streams.UnknownEntriesRead), groups[0].Lag)
+
+	readNew(t, s, "alice", 0)
+	groups, _ = s.XInfoGroups(ctx, "stream")
+	assert.Equal(t, streams.GroupInfo{Name: "group", Consumers: 1, Pending: 3, LastDeliveredID: id(4, 0), EntriesRead: 4}, groups[0])
+
+	_, err = s.XInfoGroups(ctx, "missing")
+	assert.Equal(t, keyspace.ErrNoSuchKey, err)
+}
+
+func TestStreams_XInfoConsumers(t *testing.T) {
+	s := newGroupStreams(t, 2)
+	ctx := context.Background()
+	_, _ = s.XGroupCreateConsumer(ctx, "stream", "group", "idle")
+	readNew(t, s, "alice", 0)
+
+	consumers, err := s.XInfoConsumers(ctx, "stream", "group")
+	assert.NoError(t, err)
+	require.Len(t, consumers, 2)
+	assert.Equal(t, "alice", consumers[0].Name)
+	assert.Equal(t, 2, consumers[0].Pending)
+	assert.GreaterOrEqual(t, consumers[0].Inactive, time.Duration(0))
+	assert.Equal(t, "idle", consumers[1].Name)
+	assert.Equal(t, time.Duration(-1), consumers[1].Inactive)
+
+	_, err = s.XInfoConsumers(ctx, "stream", "missing")
+	assert.Equal(t, streams.NewNoGroupError("stream", "missing"), err)
+}
+
+func TestStream_CloneCopiesGroups(t *testing.T) {
+	s := newGroupStreams(t, 2)
+	readNew(t, s, "alice", 0)
+	entry, _ := s.keyspace.Lookup("stream")
+	stream := entry.Value.(*Stream)
+	clone := stream.Clone().(*Stream)
+
+	g, _ := stream.groups.Get("group")
+	g.acknowledge(nodeKey(id(1, 0)))
+	cloned, _ := clone.groups.Get("group")
+	assert.Equal(t, 2, cloned.pending.Len())
+	alice, _ := cloned.consumers.Get("alice")
+	p, _ := cloned.pending.Get(nodeKey(id(1, 0)))
+	assert.Same(t, alice, p.consumer)
+	assert.Greater(t, clone.MemoryUsage(), newTestStream(DefaultConfig(), 2).MemoryUsage())
+}
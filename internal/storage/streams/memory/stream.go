@@ -0,0 +1,478 @@
+package memory
+
+import (
+	"avacado/internal/storage/listpack"
+	"avacado/internal/storage/rax"
+	"avacado/internal/storage/streams"
+	"encoding/binary"
+	"math"
+	"strconv"
+)
+
+// Config holds the size of the nodes stream entries are packed in, Redis's stream-node-max-bytes
+// and stream-node-max-entries, 0 meaning no limit.
+type Config struct {
+	NodeMaxBytes   int
+	NodeMaxEntries int
+}
+
+// DefaultConfig returns the node sizes Redis uses by default.
+func DefaultConfig() Config {
+	return Config{NodeMaxBytes: 4096, NodeMaxEntries: 100}
+}
+
+const (
+	// streamOverhead approximates the bytes used by a Stream besides its nodes.
+	streamOverhead = 64
+	// nodeOverhead approximates the bytes used by each radix tree node.
+	nodeOverhead = 48
+	// counterSlack is kept free in every node for its counters to grow into a longer encoding.
+	counterSlack = 8
+)
+
+// Flags of a node entry.
+const (
+	flagDeleted    = 1
+	flagSameFields = 2
+)
+
+// Indexes of the master entry elements.
+const (
+	countIndex        = 0
+	deletedIndex      = 1
+	masterFieldsIndex = 2
+)
+
+// Stream stores entries as Redis does: packed in listpack nodes, which a radix tree indexes by the
+// ID of their first entry, the master ID. A node opens with a master entry counting its live and
+// deleted entries and holding the fields of its first entry. The entries that follow store their
+// ID relative to the master ID, and only their values when their fields are the master ones:
+//
+//	count deleted num-master-fields master-field... 0
+//	flags ms-diff seq-diff value...
+//	flags ms-diff seq-diff num-fields field value...
+//
+// Deleting an entry only flags it, a node being freed along with its last live entry. Unlike in
+// Redis, entries do not end with their element count, nodes being only ever decoded forward.
+// All methods are called exclusively by the executor goroutine — no locking needed.
+type Stream struct {
+	config       *Config
+	nodes        *rax.Tree[*listpack.ListPack]
+	length       int
+	lastID       streams.ID
+	maxDeletedID streams.ID
+	entriesAdded uint64
+	// nodeBytes is the capacity of every node.
+	nodeBytes int64
+	groups    *rax.Tree[*consumerGroup]
+}
+
+func newStream(config *Config) *Stream {
+	return &Stream{config: config, nodes: rax.New[*listpack.ListPack](), groups: rax.New[*consumerGroup]()}
+}
+
+func (s *Stream) Encoding() string {
+	return "stream"
+}
+
+// Clone returns a deep copy of the stream, sharing no node with s.
+func (s *Stream) Clone() any {
+	clone := *s
+	clone.nodes = rax.New[*listpack.ListPack]()
+	s.nodes.Ascend("", func(key string, lp *listpack.ListPack) bool {
+		clone.nodes.Set(key, lp.Clone())
+		return true
+	})
+	clone.groups = rax.New[*consumerGroup]()
+	s.groups.Ascend("", func(name string, g *consumerGroup) bool {
+		clone.groups.Set(name, g.clone())
+		return true
+	})
+	return &clone
+}
+
+// MemoryUsage returns the approximate bytes allocated for the stream.
+func (s *Stream) MemoryUsage() int64 {
+	usage := streamOverhead + s.nodeBytes + int64(s.nodes.Nodes())*nodeOverhead
+	s.groups.Ascend("", func(_ string, g *consumerGroup) bool {
+		usage += g.memoryUsage()
+		return true
+	})
+	return usage
+}
+
+// Len returns the number of live entries.
+func (s *Stream) Len() int {
+	return s.length
+}
+
+// nextID returns the ID of an entry added at id, now being the current Unix time in milliseconds.
+func (s *Stream) nextID(id streams.AddID, now uint64) (streams.ID, error) {
+	if s.lastID == streams.MaxID {
+		return streams.ID{}, streams.ErrIDExhausted
+	}
+	switch {
+	case id.AutoMs:
+		if now > s.lastID.Ms {
+			return streams.ID{Ms: now}, nil
+		}
+		next, _ := s.lastID.Next()
+		return next, nil
+	case id.AutoSeq:
+		if id.ID.Ms > s.lastID.Ms {
+			return streams.ID{Ms: id.ID.Ms}, nil
+		}
+		if id.ID.Ms == s.lastID.Ms && s.lastID.Seq < math.MaxUint64 {
+			return streams.ID{Ms: id.ID.Ms, Seq: s.lastID.Seq + 1}, nil
+		}
+		return streams.ID{}, streams.ErrIDTooSmall
+	case id.ID.Compare(s.lastID) <= 0:
+		return streams.ID{}, streams.ErrIDTooSmall
+	default:
+		return id.ID, nil
+	}
+}
+
+// Add appends an entry, id being above the last ID of the stream.
+func (s *Stream) Add(id streams.ID, fields []string) {
+	if key, lp, found := s.nodes.Last(); !found || !s.appendTo(keyID(key), lp, id, fields) {
+		s.newNode(id, fields)
+	}
+	s.length++
+	s.lastID = id
+	s.entriesAdded++
+}
+
+// appendTo appends an entry to the node lp mastered by master, and reports whether it fit.
+func (s *Stream) appendTo(master streams.ID, lp *listpack.ListPack, id streams.ID, fields []string) bool {
+	count, deleted := counter(lp, countIndex), counter(lp, deletedIndex)
+	if s.config.NodeMaxEntries > 0 && count+deleted >= s.config.NodeMaxEntries {
+		return false
+	}
+	elements := entryElements(master, masterFields(lp), id, fields)
+	size := listpack.SizeOf(elements...) - listpack.SizeOf()
+	if (s.config.NodeMaxBytes > 0 && lp.ByteSize()+size >= s.config.NodeMaxBytes) ||
+		lp.ByteSize()+size+counterSlack > lp.MemoryUsage() ||
+		lp.Length()+len(elements) > math.MaxUint16 {
+		return false
+	}
+	_, _ = lp.PushAllOrNone(elements...)
+	_ = lp.ReplaceAt(countIndex, itoa(count+1))
+	return true
+}
+
+// newNode adds a node mastered by the entry it is created with.
+func (s *Stream) newNode(id streams.ID, fields []string) {
+	master := make([]string, 0, len(fields)/2)
+	for i := 0; i < len(fields); i += 2 {
+		master = append(master, fields[i])
+	}
+	elements := [][]byte{itoa(1), itoa(0), itoa(len(master))}
+	for _, field := range master {
+		elements = append(elements, []byte(field))
+	}
+	elements = append(elements, itoa(0))
+	elements = append(elements, entryElements(id, master, id, fields)...)
+	lp := listpack.NewEmptyListPack(max(s.config.NodeMaxBytes, listpack.SizeOf(elements...)+counterSlack))
+	_, _ = lp.PushAllOrNone(elements...)
+	s.nodes.Set(nodeKey(id), lp)
+	s.nodeBytes += int64(lp.MemoryUsage())
+}
+
+func (s *Stream) removeNode(key string, lp *listpack.ListPack) {
+	s.nodes.Delete(key)
+	s.nodeBytes -= int64(lp.MemoryUsage())
+}
+
+// entryElements returns the elements of an entry added to a node mastered by master, whose master
+// entry holds masterFields.
+func entryElements(master streams.ID, masterFields []string, id streams.ID, fields []string) [][]byte {
+	sameFields := len(fields) == 2*len(masterFields)
+	for i := 0; sameFields && i < len(masterFields); i++ {
+		sameFields = fields[2*i] == masterFields[i]
+	}
+	flags := 0
+	if sameFields {
+		flags = flagSameFields
+	}
+	// The differences wrap around for the sequence number, which may be lower than the master one.
+	elements := [][]byte{
+		itoa(flags),
+		[]byte(strconv.FormatUint(id.Ms-master.Ms, 10)),
+		[]byte(strconv.FormatUint(id.Seq-master.Seq, 10)),
+	}
+	if sameFields {
+		for i := 1; i < len(fields); i += 2 {
+			elements = append(elements, []byte(fields[i]))
+		}
+		return elements
+	}
+	elements = append(elements, itoa(len(fields)/2))
+	for _, field := range fields {
+		elements = append(elements, []byte(field))
+	}
+	return elements
+}
+
+// nodeEntry is an entry as decoded from its node.
+type nodeEntry struct {
+	streams.Entry
+	flags int
+	// flagsIndex is the index of the flags of the entry in its node.
+	flagsIndex int
+}
+
+func (e nodeEntry) deleted() bool {
+	return e.flags&flagDeleted != 0
+}
+
+// decodeNode returns the entries of the node lp mastered by master, deleted ones included.
+func decodeNode(master streams.ID, lp *listpack.ListPack) []nodeEntry {
+	elements := make([]string, 0, lp.Length())
+	_ = lp.Traverse(func(element interface{}, _, _, _ int) (bool, error) {
+		elements = append(elements, elementString(element))
+		return true, nil
+	})
+	numMasterFields, _ := strconv.Atoi(elements[masterFieldsIndex])
+	masterFields := elements[masterFieldsIndex+1 : masterFieldsIndex+1+numMasterFields]
+	var entries []nodeEntry
+	for i := masterFieldsIndex + 1 + numMasterFields + 1; i < len(elements); {
+		entry := nodeEntry{flagsIndex: i}
+		entry.flags, _ = strconv.Atoi(elements[i])
+		msDiff, _ := strconv.ParseUint(elements[i+1], 10, 64)
+		seqDiff, _ := strconv.ParseUint(elements[i+2], 10, 64)
+		entry.ID = streams.ID{Ms: master.Ms + msDiff, Seq: master.Seq + seqDiff}
+		i += 3
+		if entry.flags&flagSameFields != 0 {
+			entry.Fields = make([]string, 0, 2*numMasterFields)
+			for j, field := range masterFields {
+				entry.Fields = append(entry.Fields, field, elements[i+j])
+			}
+			i += numMasterFields
+		} else {
+			numFields, _ := strconv.Atoi(elements[i])
+			entry.Fields = append([]string(nil), elements[i+1:i+1+2*numFields]...)
+			i += 1 + 2*numFields
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// masterFields returns the fields of the master entry of lp.
+func masterFields(lp *listpack.ListPack) []string {
+	var fields []string
+	count := 0
+	_ = lp.Traverse(func(element interface{}, index, _, _ int) (bool, error) {
+		switch {
+		case index == masterFieldsIndex:
+			count, _ = strconv.Atoi(elementString(element))
+		case index > masterFieldsIndex:
+			fields = append(fields, elementString(element))
+		}
+		return index < masterFieldsIndex+count, nil
+	})
+	return fields
+}
+
+// ascend calls fn for the live entries from start on, in ascending order, until fn returns false.
+func (s *Stream) ascend(start streams.ID, fn func(nodeEntry) bool) {
+	from := nodeKey(start)
+	// The node holding start may be mastered by a lower ID.
+	if key, _, found := s.nodes.Floor(from); found {
+		from = key
+	}
+	s.nodes.Ascend(from, func(key string, lp *listpack.ListPack) bool {
+		for _, entry := range decodeNode(keyID(key), lp) {
+			if !entry.deleted() && entry.ID.Compare(start) >= 0 && !fn(entry) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// descend calls fn for the live entries up to end, in descending order, until fn returns false.
+func (s *Stream) descend(end streams.ID, fn func(nodeEntry) bool) {
+	s.nodes.Descend(nodeKey(end), func(key string, lp *listpack.ListPack) bool {
+		entries := decodeNode(keyID(key), lp)
+		for i := len(entries) - 1; i >= 0; i-- {
+			if !entries[i].deleted() && entries[i].ID.Compare(end) <= 0 && !fn(entries[i]) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Range returns up to count live entries with an ID between start and end, from the highest if
+// reverse is set. A negative count returns them all.
+func (s *Stream) Range(start, end streams.ID, count int, reverse bool) []streams.Entry {
+	var entries []streams.Entry
+	if count == 0 || start.Compare(end) > 0 {
+		return entries
+	}
+	collect := func(entry nodeEntry) bool {
+		entries = append(entries, entry.Entry)
+		return count < 0 || len(entries) < count
+	}
+	if reverse {
+		s.descend(end, func(entry nodeEntry) bool {
+			return entry.ID.Compare(start) >= 0 && collect(entry)
+		})
+	} else {
+		s.ascend(start, func(entry nodeEntry) bool {
+			return entry.ID.Compare(end) <= 0 && collect(entry)
+		})
+	}
+	return entries
+}
+
+// First returns the first live entry, or nil if the stream is empty.
+func (s *Stream) First() *streams.Entry {
+	entries := s.Range(streams.MinID, streams.MaxID, 1, false)
+	if len(entries) == 0 {
+		return nil
+	}
+	return &entries[0]
+}
+
+// Last returns the last live entry, or nil if the stream is empty.
+func (s *Stream) Last() *streams.Entry {
+	entries := s.Range(streams.MinID, streams.MaxID, 1, true)
+	if len(entries) == 0 {
+		return nil
+	}
+	return &entries[0]
+}
+
+// Delete removes the entry with id and reports whether it was live.
+func (s *Stream) Delete(id streams.ID) bool {
+	key, lp, found := s.nodes.Floor(nodeKey(id))
+	if !found {
+		return false
+	}
+	for _, entry := range decodeNode(keyID(key), lp) {
+		if entry.ID == id && !entry.deleted() {
+			s.deleteEntry(key, lp, entry)
+			if id.Compare(s.maxDeletedID) > 0 {
+				s.maxDeletedID = id
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// deleteEntry flags entry deleted in the node lp stored at key, removing the node instead if
+// entry was the last live one.
+func (s *Stream) deleteEntry(key string, lp *listpack.ListPack, entry nodeEntry) {
+	s.length--
+	count := counter(lp, countIndex) - 1
+	if count == 0 {
+		s.removeNode(key, lp)
+		return
+	}
+	_ = lp.ReplaceAt(entry.flagsIndex, itoa(entry.flags|flagDeleted))
+	_ = lp.ReplaceAt(countIndex, itoa(count))
+	_ = lp.ReplaceAt(deletedIndex, itoa(counter(lp, deletedIndex)+1))
+}
+
+// Trim evicts the oldest entries as options ask and returns how many were evicted. Whole nodes are
+// evicted first, an approximate trim stopping at the first node it cannot evict whole.
+func (s *Stream) Trim(options streams.TrimOptions) int {
+	if options.Strategy == streams.TrimNone {
+		return 0
+	}
+	limit := options.Limit
+	switch {
+	case !options.Approx:
+		limit = 0
+	case limit < 0:
+		limit = 100 * int64(s.config.NodeMaxEntries)
+	}
+	evicted := 0
+	for {
+		key, lp, found := s.nodes.First()
+		if !found {
+			return evicted
+		}
+		entries := decodeNode(keyID(key), lp)
+		live := counter(lp, countIndex)
+		var whole bool
+		if options.Strategy == streams.TrimMaxLen {
+			whole = int64(s.length-live) >= options.MaxLen
+		} else {
+			whole = entries[len(entries)-1].ID.Compare(options.MinID) < 0
+		}
+		if whole && (limit == 0 || int64(evicted+live) <= limit) {
+			s.removeNode(key, lp)
+			s.length -= live
+			evicted += live
+			continue
+		}
+		if options.Approx {
+			return evicted
+		}
+		for _, entry := range entries {
+			if entry.deleted() {
+				continue
+			}
+			if (options.Strategy == streams.TrimMaxLen && int64(s.length) <= options.MaxLen) ||
+				(options.Strategy == streams.TrimMinID && entry.ID.Compare(options.MinID) >= 0) {
+				break
+			}
+			s.deleteEntry(key, lp, entry)
+			evicted++
+		}
+		return evicted
+	}
+}
+
+// Info describes the stream as XINFO STREAM does.
+func (s *Stream) Info() streams.Info {
+	info := streams.Info{
+		Length:            s.length,
+		RadixTreeKeys:     s.nodes.Len(),
+		RadixTreeNodes:    s.nodes.Nodes(),
+		LastGeneratedID:   s.lastID,
+		MaxDeletedEntryID: s.maxDeletedID,
+		EntriesAdded:      s.entriesAdded,
+		Groups:            s.groups.Len(),
+		FirstEntry:        s.First(),
+		LastEntry:         s.Last(),
+	}
+	if info.FirstEntry != nil {
+		info.RecordedFirstEntryID = info.FirstEntry.ID
+	}
+	return info
+}
+
+// nodeKey returns the radix tree key of a node mastered by id, big-endian so that keys order as IDs.
+func nodeKey(id streams.ID) string {
+	key := binary.BigEndian.AppendUint64(make([]byte, 0, 16), id.Ms)
+	return string(binary.BigEndian.AppendUint64(key, id.Seq))
+}
+
+func keyID(key string) streams.ID {
+	return streams.ID{Ms: binary.BigEndian.Uint64([]byte(key[:8])), Seq: binary.BigEndian.Uint64([]byte(key[8:]))}
+}
+
+// counter returns the master entry counter at index of lp.
+func counter(lp *listpack.ListPack, index int) int {
+	element, _ := lp.AtIndex(index)
+	n, _ := strconv.Atoi(string(element))
+	return n
+}
+
+func itoa(n int) []byte {
+	return []byte(strconv.Itoa(n))
+}
+
+// elementString returns a listpack element as a string, whether it was stored as an integer or not.
+func elementString(element interface{}) string {
+	if n, ok := element.(int); ok {
+		return strconv.Itoa(n)
+	}
+	return string(element.([]byte))
+}
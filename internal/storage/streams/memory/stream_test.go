@@ -0,0 +1,206 @@
+package memory
+
+import (
+	"avacado/internal/storage/streams"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func id(ms, seq uint64) streams.ID {
+	return streams.ID{Ms: ms, Seq: seq}
+}
+
+// newTestStream returns a stream holding an entry {"field": "<i>"} at i-0 for each i in [1, n].
+func newTestStream(config Config, n int) *Stream {
+	s := newStream(&config)
+	for i := 1; i <= n; i++ {
+		s.Add(id(uint64(i), 0), []string{"field", strconv.Itoa(i)})
+	}
+	return s
+}
+
+func ids(entries []streams.Entry) []streams.ID {
+	var ids []streams.ID
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
+
+func TestStream_NextID(t *testing.T) {
+	s := newTestStream(DefaultConfig(), 0)
+
+	next, err := s.nextID(streams.AddID{AutoMs: true, AutoSeq: true}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, id(100, 0), next)
+	next, _ = s.nextID(streams.AddID{ID: id(0, 0), AutoSeq: true}, 100)
+	assert.Equal(t, id(0, 1), next)
+
+	s.Add(id(100, 5), []string{"f", "v"})
+	next, _ = s.nextID(streams.AddID{AutoMs: true, AutoSeq: true}, 50)
+	assert.Equal(t, id(100, 6), next, "the clock going back keeps the last milliseconds")
+	next, _ = s.nextID(streams.AddID{ID: id(100, 0), AutoSeq: true}, 0)
+	assert.Equal(t, id(100, 6), next)
+	next, _ = s.nextID(streams.AddID{ID: id(101, 0), AutoSeq: true}, 0)
+	assert.Equal(t, id(101, 0), next)
+	_, err = s.nextID(streams.AddID{ID: id(99, 0), AutoSeq: true}, 0)
+	assert.Equal(t, streams.ErrIDTooSmall, err)
+	_, err = s.nextID(streams.AddID{ID: id(100, 5)}, 0)
+	assert.Equal(t, streams.ErrIDTooSmall, err)
+
+	s.Add(id(100, math.MaxUint64), []string{"f", "v"})
+	_, err = s.nextID(streams.AddID{ID: id(100, 0), AutoSeq: true}, 0)
+	assert.Equal(t, streams.ErrIDTooSmall, err)
+	next, _ = s.nextID(streams.AddID{AutoMs: true, AutoSeq: true}, 0)
+	assert.Equal(t, id(101, 0), next)
+
+	s.Add(streams.MaxID, []string{"f", "v"})
+	_, err = s.nextID(streams.AddID{AutoMs: true, AutoSeq: true}, 0)
+	assert.Equal(t, streams.ErrIDExhausted, err)
+}
+
+func TestStream_PacksEntriesInNodes(t *testing.T) {
+	s := newTestStream(Config{NodeMaxBytes: 4096, NodeMaxEntries: 3}, 7)
+	assert.Equal(t, 7, s.Len())
+	assert.Equal(t, 3, s.nodes.Len())
+
+	s.Add(id(8, 0), []string{"other", "a", "field", "b"})
+	s.Add(id(9, 0), []string{"field", "c"})
+	entries := s.Range(id(7, 0), streams.MaxID, -1, false)
+	assert.Equal(t, []streams.Entry{
+		{ID: id(7, 0), Fields: []string{"field", "7"}},
+		{ID: id(8, 0), Fields: []string{"other", "a", "field", "b"}},
+		{ID: id(9, 0), Fields: []string{"field", "c"}},
+	}, entries)
+}
+
+func TestStream_NodesNeverExceedTheirMaxBytes(t *testing.T) {
+	s := newStream(&Config{NodeMaxBytes: 130})
+	value := strings.Repeat("x", 40)
+	for i := 1; i <= 5; i++ {
+		s.Add(id(uint64(i), 0), []string{"field", value})
+	}
+	assert.Equal(t, 3, s.nodes.Len())
+
+	large := strings.Repeat("y", 500)
+	s.Add(id(6, 0), []string{"field", large})
+	assert.Equal(t, 4, s.nodes.Len())
+	assert.Equal(t, []string{"field", large}, s.Last().Fields)
+}
+
+func TestStream_Range(t *testing.T) {
+	s := newTestStream(Config{NodeMaxBytes: 4096, NodeMaxEntries: 2}, 6)
+	tests := []struct {
+		name       string
+		start, end streams.ID
+		count      int
+		reverse    bool
+		expected   []streams.ID
+	}{
+		{"everything", streams.MinID, streams.MaxID, -1, false, []streams.ID{id(1, 0), id(2, 0), id(3, 0), id(4, 0), id(5, 0), id(6, 0)}},
+		{"within a node", id(2, 0), id(2, 0), -1, false, []streams.ID{id(2, 0)}},
+		{"across nodes", id(2, 1), id(5, 0), -1, false, []streams.ID{id(3, 0), id(4, 0), id(5, 0)}},
+		{"counted", id(2, 0), streams.MaxID, 2, false, []streams.ID{id(2, 0), id(3, 0)}},
+		{"reversed", id(2, 0), id(4, 5), -1, true, []streams.ID{id(4, 0), id(3, 0), id(2, 0)}},
+		{"reversed and counted", streams.MinID, streams.MaxID, 4, true, []streams.ID{id(6, 0), id(5, 0), id(4, 0), id(3, 0)}},
+		{"count 0", streams.MinID, streams.MaxID, 0, false, nil},
+		{"start above end", id(4, 0), id(3, 0), -1, false, nil},
+		{"past the last", id(7, 0), streams.MaxID, -1, false, nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ids(s.Range(tt.start, tt.end, tt.count, tt.reverse)), tt.name)
+	}
+}
+
+func TestStream_Delete(t *testing.T) {
+	s := newTestStream(Config{NodeMaxBytes: 4096, NodeMaxEntries: 2}, 4)
+
+	assert.True(t, s.Delete(id(2, 0)))
+	assert.False(t, s.Delete(id(2, 0)))
+	assert.False(t, s.Delete(id(9, 0)))
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, []streams.ID{id(1, 0), id(3, 0), id(4, 0)}, ids(s.Range(streams.MinID, streams.MaxID, -1, false)))
+	assert.Equal(t, []streams.ID{id(4, 0), id(3, 0), id(1, 0)}, ids(s.Range(streams.MinID, streams.MaxID, -1, true)))
+
+	assert.True(t, s.Delete(id(1, 0)))
+	assert.Equal(t, 1, s.nodes.Len(), "a node is freed with its last live entry")
+	assert.Equal(t, id(2, 0), s.maxDeletedID)
+	assert.Equal(t, id(3, 0), s.First().ID)
+
+	assert.True(t, s.Delete(id(3, 0)))
+	assert.True(t, s.Delete(id(4, 0)))
+	assert.Equal(t, 0, s.Len())
+	assert.Nil(t, s.First())
+	assert.Nil(t, s.Last())
+	assert.Equal(t, id(4, 0), s.lastID, "the last ID outlives its entry")
+}
+
+func TestStream_Trim(t *testing.T) {
+	config := Config{NodeMaxBytes: 4096, NodeMaxEntries: 3}
+	tests := []struct {
+		name      string
+		options   streams.TrimOptions
+		evicted   int
+		remaining streams.ID
+	}{
+		{"exact maxlen", streams.TrimOptions{Strategy: streams.TrimMaxLen, MaxLen: 5}, 5, id(6, 0)},
+		{"approximate maxlen evicts whole nodes", streams.TrimOptions{Strategy: streams.TrimMaxLen, MaxLen: 5, Approx: true, Limit: -1}, 3, id(4, 0)},
+		{"approximate maxlen within the first node", streams.TrimOptions{Strategy: streams.TrimMaxLen, MaxLen: 9, Approx: true, Limit: -1}, 0, id(1, 0)},
+		{"maxlen 0", streams.TrimOptions{Strategy: streams.TrimMaxLen}, 10, streams.ID{}},
+		{"maxlen above the length", streams.TrimOptions{Strategy: streams.TrimMaxLen, MaxLen: 20}, 0, id(1, 0)},
+		{"limit stops at a node too large", streams.TrimOptions{Strategy: streams.TrimMaxLen, Approx: true, Limit: 4}, 3, id(4, 0)},
+		{"limit 0 is no limit", streams.TrimOptions{Strategy: streams.TrimMaxLen, Approx: true, Limit: 0}, 10, streams.ID{}},
+		{"exact minid", streams.TrimOptions{Strategy: streams.TrimMinID, MinID: id(5, 0)}, 4, id(5, 0)},
+		{"approximate minid", streams.TrimOptions{Strategy: streams.TrimMinID, MinID: id(5, 0), Approx: true, Limit: -1}, 3, id(4, 0)},
+		{"no strategy", streams.TrimOptions{}, 0, id(1, 0)},
+	}
+	for _, tt := range tests {
+		s := newTestStream(config, 10)
+		assert.Equal(t, tt.evicted, s.Trim(tt.options), tt.name)
+		assert.Equal(t, 10-tt.evicted, s.Len(), tt.name)
+		if first := s.First(); first != nil {
+			assert.Equal(t, tt.remaining, first.ID, tt.name)
+		} else {
+			assert.Equal(t, tt.remaining, streams.ID{}, tt.name)
+		}
+	}
+}
+
+func TestStream_CloneIsIndependent(t *testing.T) {
+	s := newTestStream(DefaultConfig(), 3)
+	clone := s.Clone().(*Stream)
+	clone.Add(id(4, 0), []string{"field", "4"})
+	s.Delete(id(1, 0))
+
+	assert.Equal(t, []streams.ID{id(2, 0), id(3, 0)}, ids(s.Range(streams.MinID, streams.MaxID, -1, false)))
+	assert.Equal(t, []streams.ID{id(1, 0), id(2, 0), id(3, 0), id(4, 0)}, ids(clone.Range(streams.MinID, streams.MaxID, -1, false)))
+	assert.Equal(t, id(3, 0), s.lastID)
+}
+
+func TestStream_MemoryUsageCountsNodes(t *testing.T) {
+	s := newTestStream(Config{NodeMaxBytes: 4096, NodeMaxEntries: 2}, 2)
+	before := s.MemoryUsage()
+	s.Add(id(3, 0), []string{"field", "3"})
+	assert.Greater(t, s.MemoryUsage(), before+4096)
+	s.Trim(streams.TrimOptions{Strategy: streams.TrimMaxLen, MaxLen: 1})
+	assert.Equal(t, before, s.MemoryUsage())
+}
+
+func TestStream_Info(t *testing.T) {
+	s := newTestStream(Config{NodeMaxBytes: 4096, NodeMaxEntries: 2}, 3)
+	s.Delete(id(1, 0))
+
+	info := s.Info()
+	assert.Equal(t, 2, info.Length)
+	assert.Equal(t, 2, info.RadixTreeKeys)
+	assert.Equal(t, id(3, 0), info.LastGeneratedID)
+	assert.Equal(t, id(1, 0), info.MaxDeletedEntryID)
+	assert.Equal(t, uint64(3), info.EntriesAdded)
+	assert.Equal(t, id(2, 0), info.RecordedFirstEntryID)
+	assert.Equal(t, &streams.Entry{ID: id(2, 0), Fields: []string{"field", "2"}}, info.FirstEntry)
+	assert.Equal(t, &streams.Entry{ID: id(3, 0), Fields: []string{"field", "3"}}, info.LastEntry)
+}
@@ -0,0 +1,369 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
+	"avacado/internal/storage/streams"
+	"context"
+	"time"
+)
+
+// Streams holds all named streams in the shared keyspace. Unlike other types, a stream left
+// without entries stays in the keyspace, keeping its last ID.
+// All methods are called exclusively by the executor goroutine — no locking needed.
+type Streams struct {
+	keyspace *memkeyspace.Keyspace
+	config   *Config
+}
+
+func NewStreams(ks *memkeyspace.Keyspace, config Config) *Streams {
+	return &Streams{
+		keyspace: ks,
+		config:   &config,
+	}
+}
+
+// lookup returns the stream stored at key, or nil if key does not exist.
+// ErrWrongType is returned if key holds a value of another type.
+func (s *Streams) lookup(key string) (*Stream, error) {
+	entry, err := s.keyspace.LookupOfType(key, keyspace.TypeStream)
+	if entry == nil {
+		return nil, err
+	}
+	return entry.Value.(*Stream), nil
+}
+
+func (s *Streams) XAdd(_ context.Context, key string, id streams.AddID, fields []string, options streams.AddOptions) (streams.ID, bool, error) {
+	stream, err := s.lookup(key)
+	if err != nil {
+		return streams.ID{}, false, err
+	}
+	created := stream == nil
+	if created {
+		if options.NoMkStream {
+			return streams.ID{}, false, nil
+		}
+		stream = newStream(s.config)
+	}
+	added, err := stream.nextID(id, uint64(time.Now().UnixMilli()))
+	if err != nil {
+		return streams.ID{}, false, err
+	}
+	if created {
+		s.keyspace.Put(key, keyspace.TypeStream, stream)
+	}
+	stream.Add(added, fields)
+	stream.Trim(options.Trim)
+	s.keyspace.Resized(key)
+	return added, true, nil
+}
+
+func (s *Streams) XLen(_ context.Context, key string) (int, error) {
+	stream, err := s.lookup(key)
+	if stream == nil {
+		return 0, err
+	}
+	return stream.Len(), nil
+}
+
+func (s *Streams) XRange(_ context.Context, key string, start, end streams.ID, count int, reverse bool) ([]streams.Entry, error) {
+	stream, err := s.lookup(key)
+	if stream == nil {
+		return nil, err
+	}
+	return stream.Range(start, end, count, reverse), nil
+}
+
+func (s *Streams) XDel(_ context.Context, key string, ids []streams.ID) (int, error) {
+	stream, err := s.lookup(key)
+	if stream == nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, id := range ids {
+		if stream.Delete(id) {
+			deleted++
+		}
+	}
+	s.keyspace.Resized(key)
+	return deleted, nil
+}
+
+func (s *Streams) XTrim(_ context.Context, key string, options streams.TrimOptions) (int, error) {
+	stream, err := s.lookup(key)
+	if stream == nil {
+		return 0, err
+	}
+	evicted := stream.Trim(options)
+	s.keyspace.Resized(key)
+	return evicted, nil
+}
+
+func (s *Streams) XInfo(_ context.Context, key string) (streams.Info, error) {
+	stream, err := s.lookup(key)
+	if err != nil {
+		return streams.Info{}, err
+	}
+	if stream == nil {
+		return streams.Info{}, keyspace.ErrNoSuchKey
+	}
+	return stream.Info(), nil
+}
+
+func (s *Streams) XRead(_ context.Context, reads []streams.ReadStream, count int) ([]streams.StreamEntries, error) {
+	if count <= 0 {
+		count = -1
+	}
+	var result []streams.StreamEntries
+	for _, read := range reads {
+		stream, err := s.lookup(read.Key)
+		if err != nil {
+			return nil, err
+		}
+		start, ok := read.After.Next()
+		if stream == nil || !ok {
+			continue
+		}
+		if entries := stream.Range(start, streams.MaxID, count, false); len(entries) > 0 {
+			result = append(result, streams.StreamEntries{Key: read.Key, Entries: entries})
+		}
+	}
+	return result, nil
+}
+
+func (s *Streams) XLastID(_ context.Context, key string) (streams.ID, error) {
+	stream, err := s.lookup(key)
+	if stream == nil {
+		return streams.ID{}, err
+	}
+	return stream.lastID, nil
+}
+
+// lookupGroup returns the stream stored at key and its consumer group named name, either being
+// nil if missing.
+func (s *Streams) lookupGroup(key, name string) (*Stream, *consumerGroup, error) {
+	stream, err := s.lookup(key)
+	if stream == nil {
+		return nil, nil, err
+	}
+	group, _ := stream.groups.Get(name)
+	return stream, group, nil
+}
+
+func (s *Streams) XGroupCreate(_ context.Context, key, group string, start streams.GroupStart, entriesRead int64, mkStream bool) error {
+	stream, err := s.lookup(key)
+	if err != nil {
+		return err
+	}
+	if stream == nil {
+		if !mkStream {
+			return streams.ErrGroupKeyMissing
+		}
+		stream = newStream(s.config)
+		s.keyspace.Put(key, keyspace.TypeStream, stream)
+	}
+	if _, found := stream.groups.Get(group); found {
+		return streams.ErrBusyGroup
+	}
+	stream.groups.Set(group, newConsumerGroup(stream.groupStart(start), entriesRead))
+	s.keyspace.Resized(key)
+	return nil
+}
+
+func (s *Streams) XGroupSetID(_ context.Context, key, group string, start streams.GroupStart, entriesRead int64) error {
+	stream, cg, err := s.lookupGroup(key, group)
+	if err != nil {
+		return err
+	}
+	if stream == nil {
+		return streams.ErrGroupKeyMissing
+	}
+	if cg == nil {
+		return streams.NewNoGroupError(key, group)
+	}
+	cg.lastID = stream.groupStart(start)
+	cg.entriesRead = entriesRead
+	return nil
+}
+
+func (s *Streams) XGroupDestroy(_ context.Context, key, group string) (bool, error) {
+	stream, err := s.lookup(key)
+	if err != nil {
+		return false, err
+	}
+	if stream == nil {
+		return false, streams.ErrGroupKeyMissing
+	}
+	if _, found := stream.groups.Delete(group); !found {
+		return false, nil
+	}
+	s.keyspace.Resized(key)
+	return true, nil
+}
+
+func (s *Streams) XGroupCreateConsumer(_ context.Context, key, group, consumer string) (bool, error) {
+	stream, cg, err := s.lookupGroup(key, group)
+	if err != nil {
+		return false, err
+	}
+	if stream == nil {
+		return false, streams.ErrGroupKeyMissing
+	}
+	if cg == nil {
+		return false, streams.NewNoGroupError(key, group)
+	}
+	if _, found := cg.consumers.Get(consumer); found {
+		return false, nil
+	}
+	cg.consumers.Set(consumer, newConsumer(consumer, time.Now()))
+	s.keyspace.Resized(key)
+	return true, nil
+}
+
+func (s *Streams) XGroupDelConsumer(_ context.Context, key, group, consumer string) (int, error) {
+	stream, cg, err := s.lookupGroup(key, group)
+	if err != nil {
+		return 0, err
+	}
+	if stream == nil {
+		return 0, streams.ErrGroupKeyMissing
+	}
+	if cg == nil {
+		return 0, streams.NewNoGroupError(key, group)
+	}
+	pending := cg.deleteConsumer(consumer)
+	s.keyspace.Resized(key)
+	return pending, nil
+}
+
+func (s *Streams) XReadGroup(_ context.Context, group, consumer string, reads []streams.ReadGroupStream, count int, noAck bool) ([]streams.StreamEntries, error) {
+	// Every group must exist before anything is read.
+	streamsRead := make([]*Stream, len(reads))
+	groups := make([]*consumerGroup, len(reads))
+	for i, read := range reads {
+		stream, cg, err := s.lookupGroup(read.Key, group)
+		if err != nil {
+			return nil, err
+		}
+		if cg == nil {
+			return nil, streams.NewNoReadGroupError(read.Key, group)
+		}
+		streamsRead[i], groups[i] = stream, cg
+	}
+	now := time.Now()
+	var result []streams.StreamEntries
+	for i, read := range reads {
+		stream, cg := streamsRead[i], groups[i]
+		c := cg.consumer(consumer, now)
+		if read.New {
+			if entries := stream.readNew(cg, c, count, noAck, now); len(entries) > 0 {
+				result = append(result, streams.StreamEntries{Key: read.Key, Entries: entries})
+			}
+		} else {
+			result = append(result, streams.StreamEntries{Key: read.Key, Entries: stream.readPending(c, read.After, count, now)})
+		}
+		s.keyspace.Resized(read.Key)
+	}
+	return result, nil
+}
+
+func (s *Streams) XReadGroupReady(_ context.Context, key, group string) (bool, error) {
+	stream, cg, err := s.lookupGroup(key, group)
+	if err != nil {
+		return false, err
+	}
+	if cg == nil {
+		return false, streams.NewNoReadGroupError(key, group)
+	}
+	start, ok := cg.lastID.Next()
+	return ok && len(stream.Range(start, streams.MaxID, 1, false)) > 0, nil
+}
+
+func (s *Streams) XAck(_ context.Context, key, group string, ids []streams.ID) (int, error) {
+	_, cg, err := s.lookupGroup(key, group)
+	if cg == nil {
+		return 0, err
+	}
+	acknowledged := 0
+	for _, id := range ids {
+		if cg.acknowledge(nodeKey(id)) {
+			acknowledged++
+		}
+	}
+	s.keyspace.Resized(key)
+	return acknowledged, nil
+}
+
+// lookupPending returns the consumer group named name of the stream stored at key, commands acting
+// on its pending entries erring alike when either is missing.
+func (s *Streams) lookupPending(key, name string) (*Stream, *consumerGroup, error) {
+	stream, cg, err := s.lookupGroup(key, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cg == nil {
+		return nil, nil, streams.NewNoKeyOrGroupError(key, name)
+	}
+	return stream, cg, nil
+}
+
+func (s *Streams) XPending(_ context.Context, key, group string) (streams.PendingSummary, error) {
+	_, cg, err := s.lookupPending(key, group)
+	if err != nil {
+		return streams.PendingSummary{}, err
+	}
+	return cg.summary(), nil
+}
+
+func (s *Streams) XPendingRange(_ context.Context, key, group string, selection streams.PendingRange) ([]streams.PendingEntry, error) {
+	_, cg, err := s.lookupPending(key, group)
+	if err != nil {
+		return nil, err
+	}
+	return cg.pendingRange(selection, time.Now()), nil
+}
+
+func (s *Streams) XClaim(_ context.Context, key, group, consumer string, minIdle time.Duration, ids []streams.ID, options streams.ClaimOptions) ([]streams.Entry, error) {
+	stream, cg, err := s.lookupPending(key, group)
+	if err != nil {
+		return nil, err
+	}
+	claimed := stream.claim(cg, consumer, minIdle, ids, options, time.Now())
+	s.keyspace.Resized(key)
+	return claimed, nil
+}
+
+func (s *Streams) XAutoClaim(_ context.Context, key, group, consumer string, minIdle time.Duration, start streams.ID, count int, justID bool) (streams.AutoClaimResult, error) {
+	stream, cg, err := s.lookupPending(key, group)
+	if err != nil {
+		return streams.AutoClaimResult{}, err
+	}
+	result := stream.autoClaim(cg, consumer, minIdle, start, count, justID, time.Now())
+	s.keyspace.Resized(key)
+	return result, nil
+}
+
+func (s *Streams) XInfoGroups(_ context.Context, key string) ([]streams.GroupInfo, error) {
+	stream, err := s.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if stream == nil {
+		return nil, keyspace.ErrNoSuchKey
+	}
+	return stream.groupsInfo(), nil
+}
+
+func (s *Streams) XInfoConsumers(_ context.Context, key, group string) ([]streams.ConsumerInfo, error) {
+	stream, cg, err := s.lookupGroup(key, group)
+	if err != nil {
+		return nil, err
+	}
+	if stream == nil {
+		return nil, keyspace.ErrNoSuchKey
+	}
+	if cg == nil {
+		return nil, streams.NewNoGroupError(key, group)
+	}
+	return cg.consumersInfo(time.Now()), nil
+}
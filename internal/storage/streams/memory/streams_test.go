@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
+	"avacado/internal/storage/streams"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreams_XAdd(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	s := NewStreams(ks, DefaultConfig())
+	ctx := context.Background()
+
+	added, ok, err := s.XAdd(ctx, "stream", streams.AddID{ID: id(5, 0), AutoSeq: true}, []string{"f", "v"}, streams.AddOptions{})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, id(5, 0), added)
+
+	added, _, _ = s.XAdd(ctx, "stream", streams.AddID{AutoMs: true, AutoSeq: true}, []string{"f", "v"}, streams.AddOptions{})
+	assert.Greater(t, added.Ms, uint64(5))
+
+	_, _, err = s.XAdd(ctx, "stream", streams.AddID{ID: id(5, 1)}, []string{"f", "v"}, streams.AddOptions{})
+	assert.Equal(t, streams.ErrIDTooSmall, err)
+	length, _ := s.XLen(ctx, "stream")
+	assert.Equal(t, 2, length)
+}
+
+func TestStreams_XAddNoMkStream(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	s := NewStreams(ks, DefaultConfig())
+
+	_, ok, err := s.XAdd(context.Background(), "stream", streams.AddID{ID: id(1, 0)}, []string{"f", "v"}, streams.AddOptions{NoMkStream: true})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 0, ks.Len())
+}
+
+func TestStreams_XAddTrims(t *testing.T) {
+	s := NewStreams(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	trim := streams.AddOptions{Trim: streams.TrimOptions{Strategy: streams.TrimMaxLen, MaxLen: 2}}
+	for i := uint64(1); i <= 4; i++ {
+		_, _, _ = s.XAdd(ctx, "stream", streams.AddID{ID: id(i, 0)}, []string{"f", "v"}, trim)
+	}
+
+	entries, _ := s.XRange(ctx, "stream", streams.MinID, streams.MaxID, -1, false)
+	assert.Equal(t, []streams.ID{id(3, 0), id(4, 0)}, ids(entries))
+}
+
+func TestStreams_EmptyStreamStays(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	s := NewStreams(ks, DefaultConfig())
+	ctx := context.Background()
+	_, _, _ = s.XAdd(ctx, "stream", streams.AddID{ID: id(1, 0)}, []string{"f", "v"}, streams.AddOptions{})
+	_, _, _ = s.XAdd(ctx, "stream", streams.AddID{ID: id(2, 0)}, []string{"f", "v"}, streams.AddOptions{})
+
+	deleted, err := s.XDel(ctx, "stream", []streams.ID{id(1, 0), id(1, 0), id(3, 0)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	evicted, err := s.XTrim(ctx, "stream", streams.TrimOptions{Strategy: streams.TrimMaxLen})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, evicted)
+
+	length, _ := s.XLen(ctx, "stream")
+	assert.Equal(t, 0, length)
+	_, found := ks.Lookup("stream")
+	assert.True(t, found)
+	_, _, err = s.XAdd(ctx, "stream", streams.AddID{ID: id(2, 0)}, []string{"f", "v"}, streams.AddOptions{})
+	assert.Equal(t, streams.ErrIDTooSmall, err)
+}
+
+func TestStreams_XRead(t *testing.T) {
+	s := NewStreams(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	for i := uint64(1); i <= 3; i++ {
+		_, _, _ = s.XAdd(ctx, "stream", streams.AddID{ID: id(i, 0)}, []string{"f", "v"}, streams.AddOptions{})
+	}
+	_, _, _ = s.XAdd(ctx, "other", streams.AddID{ID: id(1, 0)}, []string{"f", "v"}, streams.AddOptions{})
+
+	reads := []streams.ReadStream{{Key: "stream", After: id(1, 0)}, {Key: "other", After: id(1, 0)}, {Key: "missing"}}
+	result, err := s.XRead(ctx, reads, 1)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "stream", result[0].Key)
+	assert.Equal(t, []streams.ID{id(2, 0)}, ids(result[0].Entries))
+	result, _ = s.XRead(ctx, reads[:1], 0)
+	assert.Equal(t, []streams.ID{id(2, 0), id(3, 0)}, ids(result[0].Entries))
+
+	last, err := s.XLastID(ctx, "stream")
+	assert.NoError(t, err)
+	assert.Equal(t, id(3, 0), last)
+	last, err = s.XLastID(ctx, "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, streams.ID{}, last)
+}
+
+func TestStreams_MissingKey(t *testing.T) {
+	s := NewStreams(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+
+	length, err := s.XLen(ctx, "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, length)
+	entries, err := s.XRange(ctx, "missing", streams.MinID, streams.MaxID, -1, false)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	deleted, _ := s.XDel(ctx, "missing", []streams.ID{id(1, 0)})
+	assert.Equal(t, 0, deleted)
+	evicted, _ := s.XTrim(ctx, "missing", streams.TrimOptions{Strategy: streams.TrimMaxLen})
+	assert.Equal(t, 0, evicted)
+	_, err = s.XInfo(ctx, "missing")
+	assert.Equal(t, keyspace.ErrNoSuchKey, err)
+}
+
+func TestStreams_WrongType(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	s := NewStreams(ks, DefaultConfig())
+	ctx := context.Background()
+	ks.Put("str", keyspace.TypeString, struct{}{})
+
+	_, _, err := s.XAdd(ctx, "str", streams.AddID{AutoMs: true, AutoSeq: true}, []string{"f", "v"}, streams.AddOptions{})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = s.XLen(ctx, "str")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = s.XRange(ctx, "str", streams.MinID, streams.MaxID, -1, false)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = s.XDel(ctx, "str", []streams.ID{id(1, 0)})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = s.XTrim(ctx, "str", streams.TrimOptions{})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = s.XInfo(ctx, "str")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = s.XRead(ctx, []streams.ReadStream{{Key: "str"}}, 0)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = s.XLastID(ctx, "str")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+}
@@ -0,0 +1,372 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: streams.go
+//
+// Generated by this command:
+//
+//	mockgen -source=streams.go -destination=mock/streams.go -package=mockstreams
+//
+
+// Package mockstreams is a generated GoMock package.
+package mockstreams
+
+import (
+	streams "avacado/internal/storage/streams"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStreams is a mock of Streams interface.
+type MockStreams struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamsMockRecorder
+	isgomock struct{}
+}
+
+// MockStreamsMockRecorder is the mock recorder for MockStreams.
+type MockStreamsMockRecorder struct {
+	mock *MockStreams
+}
+
+// NewMockStreams creates a new mock instance.
+func NewMockStreams(ctrl *gomock.Controller) *MockStreams {
+	mock := &MockStreams{ctrl: ctrl}
+	mock.recorder = &MockStreamsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreams) EXPECT() *MockStreamsMockRecorder {
+	return m.recorder
+}
+
+// XAck mocks base method.
+func (m *MockStreams) XAck(ctx context.Context, key, group string, ids []streams.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XAck", ctx, key, group, ids)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XAck indicates an expected call of XAck.
+func (mr *MockStreamsMockRecorder) XAck(ctx, key, group, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XAck", reflect.TypeOf((*MockStreams)(nil).XAck), ctx, key, group, ids)
+}
+
+// XAdd mocks base method.
+func (m *MockStreams) XAdd(ctx context.Context, key string, id streams.AddID, fields []string, options streams.AddOptions) (streams.ID, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XAdd", ctx, key, id, fields, options)
+	ret0, _ := ret[0].(streams.ID)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// XAdd indicates an expected call of XAdd.
+func (mr *MockStreamsMockRecorder) XAdd(ctx, key, id, fields, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XAdd", reflect.TypeOf((*MockStreams)(nil).XAdd), ctx, key, id, fields, options)
+}
+
+// XAutoClaim mocks base method.
+func (m *MockStreams) XAutoClaim(ctx context.Context, key, group, consumer string, minIdle time.Duration, start streams.ID, count int, justID bool) (streams.AutoClaimResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XAutoClaim", ctx, key, group, consumer, minIdle, start, count, justID)
+	ret0, _ := ret[0].(streams.AutoClaimResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XAutoClaim indicates an expected call of XAutoClaim.
+func (mr *MockStreamsMockRecorder) XAutoClaim(ctx, key, group, consumer, minIdle, start, count, justID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XAutoClaim", reflect.TypeOf((*MockStreams)(nil).XAutoClaim), ctx, key, group, consumer, minIdle, start, count, justID)
+}
+
+// XClaim mocks base method.
+func (m *MockStreams) XClaim(ctx context.Context, key, group, consumer string, minIdle time.Duration, ids []streams.ID, options streams.ClaimOptions) ([]streams.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XClaim", ctx, key, group, consumer, minIdle, ids, options)
+	ret0, _ := ret[0].([]streams.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XClaim indicates an expected call of XClaim.
+func (mr *MockStreamsMockRecorder) XClaim(ctx, key, group, consumer, minIdle, ids, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XClaim", reflect.TypeOf((*MockStreams)(nil).XClaim), ctx, key, group, consumer, minIdle, ids, options)
+}
+
+// XDel mocks base method.
+func (m *MockStreams) XDel(ctx context.Context, key string, ids []streams.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XDel", ctx, key, ids)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XDel indicates an expected call of XDel.
+func (mr *MockStreamsMockRecorder) XDel(ctx, key, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XDel", reflect.TypeOf((*MockStreams)(nil).XDel), ctx, key, ids)
+}
+
+// XGroupCreate mocks base method.
+func (m *MockStreams) XGroupCreate(ctx context.Context, key, group string, start streams.GroupStart, entriesRead int64, mkStream bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XGroupCreate", ctx, key, group, start, entriesRead, mkStream)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// XGroupCreate indicates an expected call of XGroupCreate.
+func (mr *MockStreamsMockRecorder) XGroupCreate(ctx, key, group, start, entriesRead, mkStream any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XGroupCreate", reflect.TypeOf((*MockStreams)(nil).XGroupCreate), ctx, key, group, start, entriesRead, mkStream)
+}
+
+// XGroupCreateConsumer mocks base method.
+func (m *MockStreams) XGroupCreateConsumer(ctx context.Context, key, group, consumer string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XGroupCreateConsumer", ctx, key, group, consumer)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XGroupCreateConsumer indicates an expected call of XGroupCreateConsumer.
+func (mr *MockStreamsMockRecorder) XGroupCreateConsumer(ctx, key, group, consumer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XGroupCreateConsumer", reflect.TypeOf((*MockStreams)(nil).XGroupCreateConsumer), ctx, key, group, consumer)
+}
+
+// XGroupDelConsumer mocks base method.
+func (m *MockStreams) XGroupDelConsumer(ctx context.Context, key, group, consumer string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XGroupDelConsumer", ctx, key, group, consumer)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XGroupDelConsumer indicates an expected call of XGroupDelConsumer.
+func (mr *MockStreamsMockRecorder) XGroupDelConsumer(ctx, key, group, consumer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XGroupDelConsumer", reflect.TypeOf((*MockStreams)(nil).XGroupDelConsumer), ctx, key, group, consumer)
+}
+
+// XGroupDestroy mocks base method.
+func (m *MockStreams) XGroupDestroy(ctx context.Context, key, group string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XGroupDestroy", ctx, key, group)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XGroupDestroy indicates an expected call of XGroupDestroy.
+func (mr *MockStreamsMockRecorder) XGroupDestroy(ctx, key, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XGroupDestroy", reflect.TypeOf((*MockStreams)(nil).XGroupDestroy), ctx, key, group)
+}
+
+// XGroupSetID mocks base method.
+func (m *MockStreams) XGroupSetID(ctx context.Context, key, group string, start streams.GroupStart, entriesRead int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XGroupSetID", ctx, key, group, start, entriesRead)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// XGroupSetID indicates an expected call of XGroupSetID.
+func (mr *MockStreamsMockRecorder) XGroupSetID(ctx, key, group, start, entriesRead any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XGroupSetID", reflect.TypeOf((*MockStreams)(nil).XGroupSetID), ctx, key, group, start, entriesRead)
+}
+
+// XInfo mocks base method.
+func (m *MockStreams) XInfo(ctx context.Context, key string) (streams.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XInfo", ctx, key)
+	ret0, _ := ret[0].(streams.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XInfo indicates an expected call of XInfo.
+func (mr *MockStreamsMockRecorder) XInfo(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XInfo", reflect.TypeOf((*MockStreams)(nil).XInfo), ctx, key)
+}
+
+// XInfoConsumers mocks base method.
+func (m *MockStreams) XInfoConsumers(ctx context.Context, key, group string) ([]streams.ConsumerInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XInfoConsumers", ctx, key, group)
+	ret0, _ := ret[0].([]streams.ConsumerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XInfoConsumers indicates an expected call of XInfoConsumers.
+func (mr *MockStreamsMockRecorder) XInfoConsumers(ctx, key, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XInfoConsumers", reflect.TypeOf((*MockStreams)(nil).XInfoConsumers), ctx, key, group)
+}
+
+// XInfoGroups mocks base method.
+func (m *MockStreams) XInfoGroups(ctx context.Context, key string) ([]streams.GroupInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XInfoGroups", ctx, key)
+	ret0, _ := ret[0].([]streams.GroupInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XInfoGroups indicates an expected call of XInfoGroups.
+func (mr *MockStreamsMockRecorder) XInfoGroups(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XInfoGroups", reflect.TypeOf((*MockStreams)(nil).XInfoGroups), ctx, key)
+}
+
+// XLastID mocks base method.
+func (m *MockStreams) XLastID(ctx context.Context, key string) (streams.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XLastID", ctx, key)
+	ret0, _ := ret[0].(streams.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XLastID indicates an expected call of XLastID.
+func (mr *MockStreamsMockRecorder) XLastID(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XLastID", reflect.TypeOf((*MockStreams)(nil).XLastID), ctx, key)
+}
+
+// XLen mocks base method.
+func (m *MockStreams) XLen(ctx context.Context, key string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XLen", ctx, key)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XLen indicates an expected call of XLen.
+func (mr *MockStreamsMockRecorder) XLen(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XLen", reflect.TypeOf((*MockStreams)(nil).XLen), ctx, key)
+}
+
+// XPending mocks base method.
+func (m *MockStreams) XPending(ctx context.Context, key, group string) (streams.PendingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XPending", ctx, key, group)
+	ret0, _ := ret[0].(streams.PendingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XPending indicates an expected call of XPending.
+func (mr *MockStreamsMockRecorder) XPending(ctx, key, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XPending", reflect.TypeOf((*MockStreams)(nil).XPending), ctx, key, group)
+}
+
+// XPendingRange mocks base method.
+func (m *MockStreams) XPendingRange(ctx context.Context, key, group string, selection streams.PendingRange) ([]streams.PendingEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XPendingRange", ctx, key, group, selection)
+	ret0, _ := ret[0].([]streams.PendingEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XPendingRange indicates an expected call of XPendingRange.
+func (mr *MockStreamsMockRecorder) XPendingRange(ctx, key, group, selection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XPendingRange", reflect.TypeOf((*MockStreams)(nil).XPendingRange), ctx, key, group, selection)
+}
+
+// XRange mocks base method.
+func (m *MockStreams) XRange(ctx context.Context, key string, start, end streams.ID, count int, reverse bool) ([]streams.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XRange", ctx, key, start, end, count, reverse)
+	ret0, _ := ret[0].([]streams.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XRange indicates an expected call of XRange.
+func (mr *MockStreamsMockRecorder) XRange(ctx, key, start, end, count, reverse any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XRange", reflect.TypeOf((*MockStreams)(nil).XRange), ctx, key, start, end, count, reverse)
+}
+
+// XRead mocks base method.
+func (m *MockStreams) XRead(ctx context.Context, arg1 []streams.ReadStream, count int) ([]streams.StreamEntries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XRead", ctx, arg1, count)
+	ret0, _ := ret[0].([]streams.StreamEntries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XRead indicates an expected call of XRead.
+func (mr *MockStreamsMockRecorder) XRead(ctx, arg1, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XRead", reflect.TypeOf((*MockStreams)(nil).XRead), ctx, arg1, count)
+}
+
+// XReadGroup mocks base method.
+func (m *MockStreams) XReadGroup(ctx context.Context, group, consumer string, arg3 []streams.ReadGroupStream, count int, noAck bool) ([]streams.StreamEntries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XReadGroup", ctx, group, consumer, arg3, count, noAck)
+	ret0, _ := ret[0].([]streams.StreamEntries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XReadGroup indicates an expected call of XReadGroup.
+func (mr *MockStreamsMockRecorder) XReadGroup(ctx, group, consumer, arg3, count, noAck any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XReadGroup", reflect.TypeOf((*MockStreams)(nil).XReadGroup), ctx, group, consumer, arg3, count, noAck)
+}
+
+// XReadGroupReady mocks base method.
+func (m *MockStreams) XReadGroupReady(ctx context.Context, key, group string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XReadGroupReady", ctx, key, group)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XReadGroupReady indicates an expected call of XReadGroupReady.
+func (mr *MockStreamsMockRecorder) XReadGroupReady(ctx, key, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XReadGroupReady", reflect.TypeOf((*MockStreams)(nil).XReadGroupReady), ctx, key, group)
+}
+
+// XTrim mocks base method.
+func (m *MockStreams) XTrim(ctx context.Context, key string, options streams.TrimOptions) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XTrim", ctx, key, options)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XTrim indicates an expected call of XTrim.
+func (mr *MockStreamsMockRecorder) XTrim(ctx, key, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XTrim", reflect.TypeOf((*MockStreams)(nil).XTrim), ctx, key, options)
+}
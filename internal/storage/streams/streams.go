@@ -0,0 +1,117 @@
+package streams
+
+import (
+	"context"
+	"time"
+)
+
+// Entry is a stream entry, its fields and values alternating in Fields. Fields is nil for an
+// entry pending in a consumer group that was deleted from the stream.
+type Entry struct {
+	ID     ID
+	Fields []string
+}
+
+// TrimStrategy is what a stream is trimmed by.
+type TrimStrategy int
+
+const (
+	TrimNone TrimStrategy = iota
+	// TrimMaxLen evicts the oldest entries until at most MaxLen are left.
+	TrimMaxLen
+	// TrimMinID evicts the entries with an ID below MinID.
+	TrimMinID
+)
+
+// TrimOptions are the MAXLEN and MINID arguments of XADD and XTRIM.
+type TrimOptions struct {
+	Strategy TrimStrategy
+	MaxLen   int64
+	MinID    ID
+	// Approx only evicts whole nodes, so more entries than asked may be left.
+	Approx bool
+	// Limit caps the entries an approximate trim evicts, 0 meaning no cap, and a negative Limit
+	// the default of 100 times the entries a node holds at most.
+	Limit int64
+}
+
+// AddOptions are the flags of XADD.
+type AddOptions struct {
+	// NoMkStream adds no entry rather than creating a missing stream.
+	NoMkStream bool
+	Trim       TrimOptions
+}
+
+// ReadStream is a stream XREAD reads the entries of with an ID above After from.
+type ReadStream struct {
+	Key   string
+	After ID
+}
+
+// Info describes a stream as XINFO STREAM does.
+type Info struct {
+	Length int
+	// RadixTreeKeys is the number of nodes entries are packed in, RadixTreeNodes the number of
+	// radix tree nodes indexing them.
+	RadixTreeKeys, RadixTreeNodes int
+	LastGeneratedID               ID
+	MaxDeletedEntryID             ID
+	EntriesAdded                  uint64
+	Groups                        int
+	// RecordedFirstEntryID is the ID of the first entry, 0-0 in an empty stream.
+	RecordedFirstEntryID  ID
+	FirstEntry, LastEntry *Entry
+}
+
+//go:generate sh -c "rm -f mock/streams.go && mockgen -source=streams.go -destination=mock/streams.go -package=mockstreams"
+type Streams interface {
+	// XAdd appends an entry to the stream stored at key, creating it unless options forbid,
+	// trims it as options ask, and returns the ID of the entry. It returns false instead if the
+	// stream does not exist and NoMkStream is set.
+	XAdd(ctx context.Context, key string, id AddID, fields []string, options AddOptions) (ID, bool, error)
+	XLen(ctx context.Context, key string) (int, error)
+	// XRange returns up to count entries with an ID between start and end, both included,
+	// from the highest if reverse is set. A negative count returns them all.
+	XRange(ctx context.Context, key string, start, end ID, count int, reverse bool) ([]Entry, error)
+	// XDel removes the entries with the given IDs and returns how many existed.
+	XDel(ctx context.Context, key string, ids []ID) (int, error)
+	// XTrim evicts entries as options ask and returns how many were evicted.
+	XTrim(ctx context.Context, key string, options TrimOptions) (int, error)
+	// XInfo describes the stream stored at key, keyspace.ErrNoSuchKey being returned if there is none.
+	XInfo(ctx context.Context, key string) (Info, error)
+	// XRead reads up to count entries from each stream, all of them if count is not positive, and
+	// returns the streams read from. A stream is left out when nothing was read, a missing one too.
+	XRead(ctx context.Context, streams []ReadStream, count int) ([]StreamEntries, error)
+	// XLastID returns the last ID generated in the stream stored at key, 0-0 if there is none.
+	XLastID(ctx context.Context, key string) (ID, error)
+
+	// XGroupCreate creates a consumer group, creating the stream too if it is missing and mkStream
+	// is set. entriesRead may be UnknownEntriesRead.
+	XGroupCreate(ctx context.Context, key, group string, start GroupStart, entriesRead int64, mkStream bool) error
+	XGroupSetID(ctx context.Context, key, group string, start GroupStart, entriesRead int64) error
+	XGroupDestroy(ctx context.Context, key, group string) (bool, error)
+	// XGroupCreateConsumer reports whether the consumer was created rather than already there.
+	XGroupCreateConsumer(ctx context.Context, key, group, consumer string) (bool, error)
+	// XGroupDelConsumer deletes a consumer and returns how many entries were pending for it.
+	XGroupDelConsumer(ctx context.Context, key, group, consumer string) (int, error)
+	// XReadGroup reads up to count entries from each stream for consumer, creating it if needed,
+	// and returns the streams read from. A stream read with New is left out when nothing was read.
+	// Unless noAck is set, the entries read are pending until acknowledged.
+	XReadGroup(ctx context.Context, group, consumer string, streams []ReadGroupStream, count int, noAck bool) ([]StreamEntries, error)
+	// XReadGroupReady reports whether the stream stored at key holds entries never delivered to group.
+	XReadGroupReady(ctx context.Context, key, group string) (bool, error)
+	// XAck acknowledges pending entries and returns how many were pending.
+	XAck(ctx context.Context, key, group string, ids []ID) (int, error)
+	XPending(ctx context.Context, key, group string) (PendingSummary, error)
+	XPendingRange(ctx context.Context, key, group string, selection PendingRange) ([]PendingEntry, error)
+	// XClaim transfers the pending entries among ids idle for at least minIdle to consumer,
+	// and returns the ones claimed. Pending entries deleted from the stream are acknowledged.
+	XClaim(ctx context.Context, key, group, consumer string, minIdle time.Duration, ids []ID, options ClaimOptions) ([]Entry, error)
+	// XAutoClaim claims up to count pending entries idle for at least minIdle, scanning them
+	// from start.
+	XAutoClaim(ctx context.Context, key, group, consumer string, minIdle time.Duration, start ID, count int, justID bool) (AutoClaimResult, error)
+	// XInfoGroups describes the consumer groups of a stream, ordered by name.
+	XInfoGroups(ctx context.Context, key string) ([]GroupInfo, error)
+	// XInfoConsumers describes the consumers of a group, ordered by name.
+	XInfoConsumers(ctx context.Context, key, group string) ([]ConsumerInfo, error)
+}
@@ -0,0 +1,255 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	"avacado/internal/storage/zsets"
+	"cmp"
+	"context"
+	"math"
+	"slices"
+)
+
+// operand is an input of ZUNION, ZINTER or ZDIFF: a sorted set, or a set whose members all score 1.
+type operand interface {
+	Len() int
+	Score(member string) (float64, bool)
+	Entries() []zsets.ScoredMember
+}
+
+// memberSet is the part of a set sorted set operations read. Sets belong to the sets store, so
+// they are only seen through it.
+type memberSet interface {
+	Len() int
+	Contains(member string) bool
+	Members() []string
+}
+
+type setOperand struct {
+	set memberSet
+}
+
+func (s setOperand) Len() int {
+	return s.set.Len()
+}
+
+func (s setOperand) Score(member string) (float64, bool) {
+	return 1, s.set.Contains(member)
+}
+
+func (s setOperand) Entries() []zsets.ScoredMember {
+	members := s.set.Members()
+	entries := make([]zsets.ScoredMember, len(members))
+	for i, member := range members {
+		entries[i] = zsets.ScoredMember{Member: member, Score: 1}
+	}
+	return entries
+}
+
+// lookupOperands returns the sorted set or set stored at each key, nil for a missing key.
+// ErrWrongType is returned if any key holds a value of another type.
+func (z *ZSets) lookupOperands(keys []string) ([]operand, error) {
+	operands := make([]operand, len(keys))
+	for i, key := range keys {
+		entry, found := z.keyspace.Lookup(key)
+		if !found {
+			continue
+		}
+		switch entry.Type {
+		case keyspace.TypeZSet:
+			operands[i] = entry.Value.(*ZSet)
+		case keyspace.TypeSet:
+			operands[i] = setOperand{set: entry.Value.(memberSet)}
+		default:
+			return nil, keyspace.ErrWrongType
+		}
+	}
+	return operands, nil
+}
+
+// weighted returns score multiplied by the weight of the i-th input, 0 rather than NaN as
+// Redis does when an infinite score meets a weight of 0.
+func weighted(options zsets.CombineOptions, i int, score float64) float64 {
+	if options.Weights == nil {
+		return score
+	}
+	if score = options.Weights[i] * score; math.IsNaN(score) {
+		return 0
+	}
+	return score
+}
+
+// aggregate combines the score a member got so far with the one it has in another input.
+func aggregate(aggregate zsets.Aggregate, score, other float64) float64 {
+	switch aggregate {
+	case zsets.AggregateMin:
+		return min(score, other)
+	case zsets.AggregateMax:
+		return max(score, other)
+	}
+	if score += other; math.IsNaN(score) {
+		return 0
+	}
+	return score
+}
+
+// sorted returns the members with their score, ordered by score then member.
+func sorted(scores map[string]float64) []zsets.ScoredMember {
+	members := make([]zsets.ScoredMember, 0, len(scores))
+	for member, score := range scores {
+		members = append(members, zsets.ScoredMember{Member: member, Score: score})
+	}
+	slices.SortFunc(members, compareScored)
+	return members
+}
+
+func compareScored(a, b zsets.ScoredMember) int {
+	if c := cmp.Compare(a.Score, b.Score); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Member, b.Member)
+}
+
+func (z *ZSets) union(keys []string, options zsets.CombineOptions) ([]zsets.ScoredMember, error) {
+	operands, err := z.lookupOperands(keys)
+	if err != nil {
+		return nil, err
+	}
+	scores := make(map[string]float64)
+	for i, operand := range operands {
+		if operand == nil {
+			continue
+		}
+		for _, entry := range operand.Entries() {
+			score := weighted(options, i, entry.Score)
+			if current, found := scores[entry.Member]; found {
+				score = aggregate(options.Aggregate, current, score)
+			}
+			scores[entry.Member] = score
+		}
+	}
+	return sorted(scores), nil
+}
+
+// inter returns the members common to every key, stopping once it found limit of them unless
+// limit is 0.
+func (z *ZSets) inter(keys []string, options zsets.CombineOptions, limit int) ([]zsets.ScoredMember, error) {
+	operands, err := z.lookupOperands(keys)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(operands, nil) {
+		return []zsets.ScoredMember{}, nil
+	}
+	// Walk the smallest input and probe the others from the smallest up, keeping track of the
+	// position of each input so it gets its own weight.
+	order := make([]int, len(operands))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return operands[a].Len() - operands[b].Len() })
+	scores := make(map[string]float64)
+	for _, entry := range operands[order[0]].Entries() {
+		score := weighted(options, order[0], entry.Score)
+		common := true
+		for _, i := range order[1:] {
+			other, found := operands[i].Score(entry.Member)
+			if !found {
+				common = false
+				break
+			}
+			score = aggregate(options.Aggregate, score, weighted(options, i, other))
+		}
+		if !common {
+			continue
+		}
+		scores[entry.Member] = score
+		if len(scores) == limit {
+			break
+		}
+	}
+	return sorted(scores), nil
+}
+
+func (z *ZSets) diff(keys []string) ([]zsets.ScoredMember, error) {
+	operands, err := z.lookupOperands(keys)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]zsets.ScoredMember, 0)
+	if operands[0] == nil {
+		return members, nil
+	}
+	for _, entry := range operands[0].Entries() {
+		found := false
+		for _, operand := range operands[1:] {
+			if operand == nil {
+				continue
+			}
+			if _, found = operand.Score(entry.Member); found {
+				break
+			}
+		}
+		if !found {
+			members = append(members, entry)
+		}
+	}
+	// Entries of a set come in no order.
+	slices.SortFunc(members, compareScored)
+	return members, nil
+}
+
+// store replaces destination with a sorted set of members, or removes it if there are none,
+// and returns how many members it holds.
+func (z *ZSets) store(destination string, members []zsets.ScoredMember) int {
+	if len(members) == 0 {
+		z.keyspace.Remove(destination)
+		return 0
+	}
+	zset := z.create(destination, members)
+	for _, member := range members {
+		zset.Set(member.Member, member.Score)
+	}
+	z.keyspace.Resized(destination)
+	return zset.Len()
+}
+
+func (z *ZSets) ZUnion(_ context.Context, keys []string, options zsets.CombineOptions) ([]zsets.ScoredMember, error) {
+	return z.union(keys, options)
+}
+
+func (z *ZSets) ZInter(_ context.Context, keys []string, options zsets.CombineOptions) ([]zsets.ScoredMember, error) {
+	return z.inter(keys, options, 0)
+}
+
+func (z *ZSets) ZDiff(_ context.Context, keys []string) ([]zsets.ScoredMember, error) {
+	return z.diff(keys)
+}
+
+func (z *ZSets) ZInterCard(_ context.Context, keys []string, limit int) (int, error) {
+	members, err := z.inter(keys, zsets.CombineOptions{}, limit)
+	return len(members), err
+}
+
+func (z *ZSets) ZUnionStore(_ context.Context, destination string, keys []string, options zsets.CombineOptions) (int, error) {
+	members, err := z.union(keys, options)
+	if err != nil {
+		return 0, err
+	}
+	return z.store(destination, members), nil
+}
+
+func (z *ZSets) ZInterStore(_ context.Context, destination string, keys []string, options zsets.CombineOptions) (int, error) {
+	members, err := z.inter(keys, options, 0)
+	if err != nil {
+		return 0, err
+	}
+	return z.store(destination, members), nil
+}
+
+func (z *ZSets) ZDiffStore(_ context.Context, destination string, keys []string) (int, error) {
+	members, err := z.diff(keys)
+	if err != nil {
+		return 0, err
+	}
+	return z.store(destination, members), nil
+}
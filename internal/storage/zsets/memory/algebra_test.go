@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
+	memsets "avacado/internal/storage/sets/memory"
+	"avacado/internal/storage/zsets"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAlgebraFixture(t *testing.T) (*ZSets, *memkeyspace.Keyspace) {
+	t.Helper()
+	ks := memkeyspace.NewKeyspace()
+	z := NewZSets(ks, DefaultConfig())
+	ctx := context.Background()
+	_, _ = z.ZAdd(ctx, "a", []zsets.ScoredMember{scored("x", 1), scored("y", 2), scored("z", 3)}, zsets.AddOptions{})
+	_, _ = z.ZAdd(ctx, "b", []zsets.ScoredMember{scored("y", 10), scored("z", 20), scored("w", 30)}, zsets.AddOptions{})
+	_, _ = memsets.NewSets(ks, memsets.DefaultConfig()).SAdd(ctx, "set", []string{"z", "v"})
+	return z, ks
+}
+
+func TestZSets_ZUnion(t *testing.T) {
+	z, _ := newAlgebraFixture(t)
+	ctx := context.Background()
+
+	members, err := z.ZUnion(ctx, []string{"a", "b", "missing"}, zsets.CombineOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []zsets.ScoredMember{scored("x", 1), scored("y", 12), scored("z", 23), scored("w", 30)}, members)
+
+	members, err = z.ZUnion(ctx, []string{"a", "set"}, zsets.CombineOptions{Weights: []float64{2, 5}, Aggregate: zsets.AggregateMax})
+	assert.NoError(t, err)
+	assert.Equal(t, []zsets.ScoredMember{scored("x", 2), scored("y", 4), scored("v", 5), scored("z", 6)}, members)
+}
+
+func TestZSets_ZInter(t *testing.T) {
+	z, _ := newAlgebraFixture(t)
+	ctx := context.Background()
+
+	members, err := z.ZInter(ctx, []string{"a", "b"}, zsets.CombineOptions{Aggregate: zsets.AggregateMin})
+	assert.NoError(t, err)
+	assert.Equal(t, []zsets.ScoredMember{scored("y", 2), scored("z", 3)}, members)
+
+	// The set is walked first as the smallest input, weights still follow the order of the keys.
+	members, err = z.ZInter(ctx, []string{"b", "set"}, zsets.CombineOptions{Weights: []float64{1, 100}})
+	assert.NoError(t, err)
+	assert.Equal(t, []zsets.ScoredMember{scored("z", 120)}, members)
+
+	members, err = z.ZInter(ctx, []string{"a", "missing"}, zsets.CombineOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+
+	count, err := z.ZInterCard(ctx, []string{"a", "b"}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestZSets_ZDiff(t *testing.T) {
+	z, _ := newAlgebraFixture(t)
+	ctx := context.Background()
+
+	members, err := z.ZDiff(ctx, []string{"a", "set", "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, []zsets.ScoredMember{scored("x", 1), scored("y", 2)}, members)
+
+	members, err = z.ZDiff(ctx, []string{"set", "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, []zsets.ScoredMember{scored("v", 1)}, members)
+}
+
+func TestZSets_WeightsNeverGiveNaN(t *testing.T) {
+	z := NewZSets(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = z.ZAdd(ctx, "inf", []zsets.ScoredMember{scored("a", math.Inf(1))}, zsets.AddOptions{})
+	_, _ = z.ZAdd(ctx, "-inf", []zsets.ScoredMember{scored("a", math.Inf(-1))}, zsets.AddOptions{})
+
+	members, _ := z.ZUnion(ctx, []string{"inf"}, zsets.CombineOptions{Weights: []float64{0}})
+	assert.Equal(t, []zsets.ScoredMember{scored("a", 0)}, members)
+	members, _ = z.ZInter(ctx, []string{"inf", "-inf"}, zsets.CombineOptions{})
+	assert.Equal(t, []zsets.ScoredMember{scored("a", 0)}, members)
+}
+
+func TestZSets_AlgebraStore(t *testing.T) {
+	z, ks := newAlgebraFixture(t)
+	ctx := context.Background()
+
+	size, err := z.ZUnionStore(ctx, "set", []string{"a", "set"}, zsets.CombineOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, size)
+	assert.Equal(t, []zsets.ScoredMember{scored("v", 1), scored("x", 1), scored("y", 2), scored("z", 4)}, zsetAt(z, "set").Entries())
+
+	size, err = z.ZInterStore(ctx, "inter", []string{"a", "b"}, zsets.CombineOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, size)
+
+	size, err = z.ZDiffStore(ctx, "inter", []string{"a", "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, size)
+	_, found := ks.Lookup("inter")
+	assert.False(t, found)
+}
+
+func TestZSets_AlgebraWrongType(t *testing.T) {
+	z, ks := newAlgebraFixture(t)
+	ctx := context.Background()
+	ks.Put("str", keyspace.TypeString, struct{}{})
+
+	_, err := z.ZUnion(ctx, []string{"a", "str"}, zsets.CombineOptions{})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = z.ZInter(ctx, []string{"missing", "str"}, zsets.CombineOptions{})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = z.ZDiffStore(ctx, "a", []string{"str"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	assert.Equal(t, 3, zsetAt(z, "a").Len())
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertSkiplistOrder checks that sl holds expected in order, with ranks consistent both ways.
+func assertSkiplistOrder(t *testing.T, sl *skiplist, expected []skiplistNode) {
+	t.Helper()
+	assert.Equal(t, len(expected), sl.length)
+	rank := 1
+	var previous *skiplistNode
+	for x := sl.header.level[0].forward; x != nil; x = x.level[0].forward {
+		assert.Equal(t, expected[rank-1].member, x.member)
+		assert.Equal(t, expected[rank-1].score, x.score)
+		assert.Equal(t, previous, x.backward)
+		assert.Equal(t, rank, sl.rank(x.score, x.member))
+		assert.Same(t, x, sl.byRank(rank))
+		previous = x
+		rank++
+	}
+	assert.Equal(t, previous, sl.tail)
+}
+
+func TestSkiplist_OrdersByScoreThenMember(t *testing.T) {
+	sl := newSkiplist()
+	sl.insert(2, "b")
+	sl.insert(1, "z")
+	sl.insert(2, "a")
+	sl.insert(3, "c")
+
+	assertSkiplistOrder(t, sl, []skiplistNode{{member: "z", score: 1}, {member: "a", score: 2}, {member: "b", score: 2}, {member: "c", score: 3}})
+	assert.Equal(t, 0, sl.rank(2, "missing"))
+	assert.Nil(t, sl.byRank(0))
+	assert.Nil(t, sl.byRank(5))
+}
+
+func TestSkiplist_DeleteAndUpdateScore(t *testing.T) {
+	sl := newSkiplist()
+	for i := 0; i < 10; i++ {
+		sl.insert(float64(i), strconv.Itoa(i))
+	}
+
+	assert.True(t, sl.delete(3, "3"))
+	assert.False(t, sl.delete(3, "3"))
+	assert.False(t, sl.delete(4, "5"))
+	sl.updateScore(0, "0", 20)
+	sl.updateScore(5, "5", 5.5)
+
+	assertSkiplistOrder(t, sl, []skiplistNode{
+		{member: "1", score: 1}, {member: "2", score: 2}, {member: "4", score: 4}, {member: "5", score: 5.5},
+		{member: "6", score: 6}, {member: "7", score: 7}, {member: "8", score: 8}, {member: "9", score: 9},
+		{member: "0", score: 20},
+	})
+}
+
+func TestSkiplist_RanksStayConsistentAtScale(t *testing.T) {
+	sl := newSkiplist()
+	expected := make([]skiplistNode, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		node := skiplistNode{member: "m" + strconv.Itoa(i), score: float64(rand.IntN(100))}
+		sl.insert(node.score, node.member)
+		expected = append(expected, node)
+	}
+	for i := 0; i < 300; i++ {
+		j := rand.IntN(len(expected))
+		assert.True(t, sl.delete(expected[j].score, expected[j].member))
+		expected = slices.Delete(expected, j, j+1)
+	}
+	slices.SortFunc(expected, func(a, b skiplistNode) int {
+		if a.less(b.score, b.member) {
+			return -1
+		}
+		return 1
+	})
+
+	assertSkiplistOrder(t, sl, expected)
+}
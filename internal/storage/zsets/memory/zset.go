@@ -0,0 +1,416 @@
+package memory
+
+import (
+	"avacado/internal/storage/dict"
+	"avacado/internal/storage/listpack"
+	"avacado/internal/storage/zsets"
+	"slices"
+	"strconv"
+)
+
+const defaultMaxListPackSize = 1024 * 8
+
+const (
+	// zsetOverhead approximates the bytes used by a ZSet besides its members.
+	zsetOverhead = 64
+	// zsetMemberOverhead approximates the bytes used by each member of the skiplist encoding
+	// besides the member itself: its skiplist node, levels included, and its dict entry.
+	zsetMemberOverhead = 112
+)
+
+type encodingType = int
+
+const (
+	listpackEncoding encodingType = 0
+	skiplistEncoding encodingType = 1
+)
+
+// Config holds the thresholds at which a sorted set converts to a skiplist, Redis's
+// zset-max-listpack-entries and zset-max-listpack-value.
+type Config struct {
+	// MaxListpackEntries is the most members a sorted set holds as a listpack.
+	MaxListpackEntries int
+	// MaxListpackValue is the longest member, in bytes, a sorted set holds as a listpack.
+	MaxListpackValue int
+}
+
+// DefaultConfig returns the thresholds Redis uses by default.
+func DefaultConfig() Config {
+	return Config{MaxListpackEntries: 128, MaxListpackValue: 64}
+}
+
+// ZSet stores members ordered by score then member. A small sorted set is a listpack of member
+// and score pairs kept in order; beyond, a skiplist orders the members and a dict maps each
+// member to its score. Like Redis, a sorted set never converts back to a listpack.
+// All methods are called exclusively by the executor goroutine — no locking needed.
+type ZSet struct {
+	config   *Config
+	encoding encodingType
+	lp       *listpack.ListPack
+	sl       *skiplist
+	scores   *dict.Dict[float64]
+	// memberBytes is the length of every member held by the skiplist encoding.
+	memberBytes int64
+}
+
+// newZSet creates an empty sorted set in the encoding fitting sizeHint members, the longest of
+// which is maxMemberLength bytes long.
+func newZSet(config *Config, sizeHint, maxMemberLength int) *ZSet {
+	z := &ZSet{config: config}
+	if sizeHint <= config.MaxListpackEntries && maxMemberLength <= config.MaxListpackValue {
+		z.encoding = listpackEncoding
+		z.lp = listpack.NewEmptyListPack(defaultMaxListPackSize)
+	} else {
+		z.encoding = skiplistEncoding
+		z.sl = newSkiplist()
+		z.scores = dict.New[float64]()
+	}
+	return z
+}
+
+// Encoding returns "listpack" or "skiplist", the names Redis gives to the two encodings.
+func (z *ZSet) Encoding() string {
+	if z.encoding == skiplistEncoding {
+		return "skiplist"
+	}
+	return "listpack"
+}
+
+// Clone returns a deep copy of the sorted set in the same encoding.
+func (z *ZSet) Clone() any {
+	clone := &ZSet{config: z.config, encoding: z.encoding}
+	if z.encoding == listpackEncoding {
+		clone.lp = z.lp.Clone()
+		return clone
+	}
+	clone.sl = newSkiplist()
+	clone.scores = dict.New[float64]()
+	for x := z.sl.header.level[0].forward; x != nil; x = x.level[0].forward {
+		clone.skiplistInsert(x.member, x.score)
+	}
+	return clone
+}
+
+// MemoryUsage returns the approximate bytes allocated for the sorted set.
+func (z *ZSet) MemoryUsage() int64 {
+	if z.encoding == skiplistEncoding {
+		return zsetOverhead + z.memberBytes + int64(z.sl.length)*zsetMemberOverhead
+	}
+	return zsetOverhead + int64(z.lp.MemoryUsage())
+}
+
+// Len returns the number of members.
+func (z *ZSet) Len() int {
+	if z.encoding == skiplistEncoding {
+		return z.sl.length
+	}
+	return z.lp.Length() / 2
+}
+
+// Score returns the score of member.
+func (z *ZSet) Score(member string) (float64, bool) {
+	if z.encoding == skiplistEncoding {
+		return z.scores.Get(member)
+	}
+	_, score, found := z.lpFind(member)
+	return score, found
+}
+
+// Set adds member with score, or moves it to score if it is already a member. The sorted set
+// converts to a skiplist first if a new member does not fit in the listpack.
+func (z *ZSet) Set(member string, score float64) {
+	if z.encoding == skiplistEncoding {
+		old, found := z.scores.Get(member)
+		if !found {
+			z.skiplistInsert(member, score)
+		} else if old != score {
+			z.sl.updateScore(old, member, score)
+			z.scores.Set(member, score)
+		}
+		return
+	}
+	if i, old, found := z.lpFind(member); found {
+		if old == score {
+			return
+		}
+		z.lp.DeleteFromIndex(i, 2)
+	} else if z.Len() >= z.config.MaxListpackEntries || len(member) > z.config.MaxListpackValue {
+		z.convert()
+		z.Set(member, score)
+		return
+	}
+	if !z.lpInsert(member, score) {
+		z.convert()
+		z.Set(member, score)
+	}
+}
+
+// Remove removes member and reports whether it was present.
+func (z *ZSet) Remove(member string) bool {
+	if z.encoding == skiplistEncoding {
+		score, found := z.scores.Delete(member)
+		if found {
+			z.sl.delete(score, member)
+			z.memberBytes -= int64(len(member))
+		}
+		return found
+	}
+	i, _, found := z.lpFind(member)
+	if found {
+		z.lp.DeleteFromIndex(i, 2)
+	}
+	return found
+}
+
+// Rank returns the 0-based rank of member by ascending score, or descending if reverse is set,
+// along with its score.
+func (z *ZSet) Rank(member string, reverse bool) (int, float64, bool) {
+	var rank int
+	var score float64
+	if z.encoding == skiplistEncoding {
+		var found bool
+		if score, found = z.scores.Get(member); !found {
+			return 0, 0, false
+		}
+		rank = z.sl.rank(score, member) - 1
+	} else {
+		i, s, found := z.lpFind(member)
+		if !found {
+			return 0, 0, false
+		}
+		rank, score = i/2, s
+	}
+	if reverse {
+		rank = z.Len() - 1 - rank
+	}
+	return rank, score, true
+}
+
+// Entries returns every member along with its score, ordered by score then member.
+func (z *ZSet) Entries() []zsets.ScoredMember {
+	entries := make([]zsets.ScoredMember, 0, z.Len())
+	if z.encoding == skiplistEncoding {
+		for x := z.sl.header.level[0].forward; x != nil; x = x.level[0].forward {
+			entries = append(entries, zsets.ScoredMember{Member: x.member, Score: x.score})
+		}
+		return entries
+	}
+	var member string
+	_ = z.lp.Traverse(func(element interface{}, index, _, _ int) (bool, error) {
+		if index%2 == 0 {
+			member = elementString(element)
+			return true, nil
+		}
+		entries = append(entries, zsets.ScoredMember{Member: member, Score: parseStoredScore(element)})
+		return true, nil
+	})
+	return entries
+}
+
+// Range returns the members spec selects along with their score, in the order it asks for.
+func (z *ZSet) Range(spec zsets.RangeSpec) []zsets.ScoredMember {
+	if spec.By == zsets.ByRank {
+		return z.rangeByRank(spec.Start, spec.Stop, spec.Reverse)
+	}
+	aboveMin, belowMax := rangeBounds(spec)
+	if spec.Offset < 0 {
+		return nil
+	}
+	var result []zsets.ScoredMember
+	if z.encoding == listpackEncoding {
+		entries := z.Entries()
+		if spec.Reverse {
+			slices.Reverse(entries)
+		}
+		offset := spec.Offset
+		for _, entry := range entries {
+			if !aboveMin(entry.Member, entry.Score) || !belowMax(entry.Member, entry.Score) {
+				continue
+			}
+			if offset > 0 {
+				offset--
+				continue
+			}
+			if len(result) == spec.Count {
+				break
+			}
+			result = append(result, entry)
+		}
+		return result
+	}
+	var x *skiplistNode
+	if spec.Reverse {
+		x = z.sl.lastWithin(func(n *skiplistNode) bool { return belowMax(n.member, n.score) })
+	} else {
+		x = z.sl.firstNotBefore(func(n *skiplistNode) bool { return !aboveMin(n.member, n.score) })
+	}
+	next := func(n *skiplistNode) *skiplistNode {
+		if spec.Reverse {
+			return n.backward
+		}
+		return n.level[0].forward
+	}
+	for offset := spec.Offset; x != nil && offset > 0; offset-- {
+		x = next(x)
+	}
+	for ; x != nil && len(result) != spec.Count; x = next(x) {
+		if !aboveMin(x.member, x.score) || !belowMax(x.member, x.score) {
+			break
+		}
+		result = append(result, zsets.ScoredMember{Member: x.member, Score: x.score})
+	}
+	return result
+}
+
+// Count returns the number of members in the score or lex range of spec, ignoring its limit.
+func (z *ZSet) Count(spec zsets.RangeSpec) int {
+	if spec.By == zsets.ByRank {
+		start, stop, ok := normalizeRanks(spec.Start, spec.Stop, z.Len())
+		if !ok {
+			return 0
+		}
+		return stop - start + 1
+	}
+	aboveMin, belowMax := rangeBounds(spec)
+	if z.encoding == listpackEncoding {
+		count := 0
+		for _, entry := range z.Entries() {
+			if aboveMin(entry.Member, entry.Score) && belowMax(entry.Member, entry.Score) {
+				count++
+			}
+		}
+		return count
+	}
+	first := z.sl.firstNotBefore(func(n *skiplistNode) bool { return !aboveMin(n.member, n.score) })
+	if first == nil || !belowMax(first.member, first.score) {
+		return 0
+	}
+	last := z.sl.lastWithin(func(n *skiplistNode) bool { return belowMax(n.member, n.score) })
+	return z.sl.rank(last.score, last.member) - z.sl.rank(first.score, first.member) + 1
+}
+
+// rangeByRank returns the members from rank start to stop, both included.
+func (z *ZSet) rangeByRank(start, stop int, reverse bool) []zsets.ScoredMember {
+	start, stop, ok := normalizeRanks(start, stop, z.Len())
+	if !ok {
+		return nil
+	}
+	result := make([]zsets.ScoredMember, 0, stop-start+1)
+	if z.encoding == listpackEncoding {
+		entries := z.Entries()
+		if reverse {
+			slices.Reverse(entries)
+		}
+		return append(result, entries[start:stop+1]...)
+	}
+	if reverse {
+		for x := z.sl.byRank(z.Len() - start); len(result) < cap(result); x = x.backward {
+			result = append(result, zsets.ScoredMember{Member: x.member, Score: x.score})
+		}
+		return result
+	}
+	for x := z.sl.byRank(start + 1); len(result) < cap(result); x = x.level[0].forward {
+		result = append(result, zsets.ScoredMember{Member: x.member, Score: x.score})
+	}
+	return result
+}
+
+// normalizeRanks resolves negative ranks against length and clamps them to the sorted set,
+// reporting false if no member is in range.
+func normalizeRanks(start, stop, length int) (int, int, bool) {
+	if start < 0 {
+		start += length
+	}
+	if stop < 0 {
+		stop += length
+	}
+	start = max(start, 0)
+	if start > stop || start >= length {
+		return 0, 0, false
+	}
+	return start, min(stop, length-1), true
+}
+
+// rangeBounds returns whether a member is above the min bound and whether it is below the max
+// bound of the score or lex range of spec.
+func rangeBounds(spec zsets.RangeSpec) (aboveMin, belowMax func(string, float64) bool) {
+	if spec.By == zsets.ByLex {
+		return func(member string, _ float64) bool { return spec.Lex.AboveMin(member) },
+			func(member string, _ float64) bool { return spec.Lex.BelowMax(member) }
+	}
+	return func(_ string, score float64) bool { return spec.Score.AboveMin(score) },
+		func(_ string, score float64) bool { return spec.Score.BelowMax(score) }
+}
+
+// skiplistInsert adds member, which must not be a member yet, to the skiplist encoding.
+func (z *ZSet) skiplistInsert(member string, score float64) {
+	z.sl.insert(score, member)
+	z.scores.Set(member, score)
+	z.memberBytes += int64(len(member))
+}
+
+// lpFind returns the index of member in the listpack and its score.
+func (z *ZSet) lpFind(member string) (int, float64, bool) {
+	i, found := z.lp.IndexOf(member, true)
+	if !found {
+		return 0, 0, false
+	}
+	score, _ := z.lp.AtIndex(i + 1)
+	return i, parseStoredScore(score), true
+}
+
+// lpInsert inserts member, which must not be a member yet, in order in the listpack, and reports
+// whether it fit.
+func (z *ZSet) lpInsert(member string, score float64) bool {
+	position := 0
+	var current string
+	_ = z.lp.Traverse(func(element interface{}, index, _, _ int) (bool, error) {
+		if index%2 == 0 {
+			current = elementString(element)
+			return true, nil
+		}
+		s := parseStoredScore(element)
+		if s > score || (s == score && current > member) {
+			return false, nil
+		}
+		position = index + 1
+		return true, nil
+	})
+	if err := z.lp.InsertAt(position, []byte(member)); err != nil {
+		return false
+	}
+	if err := z.lp.InsertAt(position+1, []byte(zsets.FormatScore(score))); err != nil {
+		z.lp.DeleteFromIndex(position, 1)
+		return false
+	}
+	return true
+}
+
+// convert moves every member to the skiplist encoding.
+func (z *ZSet) convert() {
+	entries := z.Entries()
+	z.encoding = skiplistEncoding
+	z.lp = nil
+	z.sl = newSkiplist()
+	z.scores = dict.New[float64]()
+	for _, entry := range entries {
+		z.skiplistInsert(entry.Member, entry.Score)
+	}
+}
+
+// elementString returns a listpack element as a string, whether it was stored as an integer or not.
+func elementString(element interface{}) string {
+	if n, ok := element.(int); ok {
+		return strconv.Itoa(n)
+	}
+	return string(element.([]byte))
+}
+
+// parseStoredScore parses a score the listpack stores, as written by FormatScore.
+func parseStoredScore(element interface{}) float64 {
+	if n, ok := element.(int); ok {
+		return float64(n)
+	}
+	score, _ := zsets.ParseScore(string(element.([]byte)))
+	return score
+}
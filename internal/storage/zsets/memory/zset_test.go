@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"avacado/internal/storage/zsets"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func scored(member string, score float64) zsets.ScoredMember {
+	return zsets.ScoredMember{Member: member, Score: score}
+}
+
+func newTestZSet(config Config, members ...zsets.ScoredMember) *ZSet {
+	z := newZSet(&config, len(members), 0)
+	for _, member := range members {
+		z.Set(member.Member, member.Score)
+	}
+	return z
+}
+
+func TestZSet_CreatedInTheEncodingFittingItsMembers(t *testing.T) {
+	config := DefaultConfig()
+	assert.Equal(t, "listpack", newZSet(&config, 1, 10).Encoding())
+	assert.Equal(t, "skiplist", newZSet(&config, 129, 10).Encoding())
+	assert.Equal(t, "skiplist", newZSet(&config, 1, 65).Encoding())
+}
+
+func TestZSet_SetAndRankInEveryEncoding(t *testing.T) {
+	for _, config := range []Config{DefaultConfig(), {}} {
+		z := newZSet(&config, 0, 0)
+		z.Set("b", 2)
+		z.Set("a", 2)
+		z.Set("c", -1.5)
+		z.Set("10", math.Inf(1))
+		z.Set("c", 3)
+
+		encoding := z.Encoding()
+		assert.Equal(t, 4, z.Len(), encoding)
+		assert.Equal(t, []zsets.ScoredMember{scored("a", 2), scored("b", 2), scored("c", 3), scored("10", math.Inf(1))}, z.Entries(), encoding)
+
+		score, found := z.Score("c")
+		assert.True(t, found, encoding)
+		assert.Equal(t, 3.0, score, encoding)
+		_, found = z.Score("missing")
+		assert.False(t, found, encoding)
+
+		rank, score, found := z.Rank("b", false)
+		assert.True(t, found, encoding)
+		assert.Equal(t, 1, rank, encoding)
+		assert.Equal(t, 2.0, score, encoding)
+		rank, _, _ = z.Rank("b", true)
+		assert.Equal(t, 2, rank, encoding)
+		_, _, found = z.Rank("missing", false)
+		assert.False(t, found, encoding)
+
+		assert.True(t, z.Remove("a"), encoding)
+		assert.False(t, z.Remove("a"), encoding)
+		assert.Equal(t, []zsets.ScoredMember{scored("b", 2), scored("c", 3), scored("10", math.Inf(1))}, z.Entries(), encoding)
+	}
+}
+
+func TestZSet_ConvertsToSkiplist(t *testing.T) {
+	config := Config{MaxListpackEntries: 3, MaxListpackValue: 8}
+
+	z := newTestZSet(config, scored("a", 1), scored("b", 2))
+	z.Set("a long member", 0)
+	assert.Equal(t, "skiplist", z.Encoding())
+	assert.Equal(t, []zsets.ScoredMember{scored("a long member", 0), scored("a", 1), scored("b", 2)}, z.Entries())
+
+	z = newTestZSet(config, scored("a", 1), scored("b", 2), scored("c", 3))
+	assert.Equal(t, "listpack", z.Encoding())
+	z.Set("c", 0)
+	assert.Equal(t, "listpack", z.Encoding())
+	z.Set("d", 4)
+	assert.Equal(t, "skiplist", z.Encoding())
+	assert.Equal(t, []zsets.ScoredMember{scored("c", 0), scored("a", 1), scored("b", 2), scored("d", 4)}, z.Entries())
+
+	// A sorted set never converts back to a listpack.
+	z.Remove("a")
+	z.Remove("b")
+	assert.Equal(t, "skiplist", z.Encoding())
+}
+
+func TestZSet_ConvertsWhenTheListpackIsFull(t *testing.T) {
+	z := newTestZSet(Config{MaxListpackEntries: 1000, MaxListpackValue: 1000})
+	member := strings.Repeat("x", 500)
+	for i := 0; i < 20; i++ {
+		z.Set(member+strconv.Itoa(i), float64(i))
+	}
+	assert.Equal(t, "skiplist", z.Encoding())
+	assert.Equal(t, 20, z.Len())
+}
+
+func TestZSet_ScoresRoundTripThroughTheListpack(t *testing.T) {
+	scores := []float64{math.Inf(-1), -1e300, -0.1, 0, 1, 3.141592653589793, 1e21, math.Inf(1)}
+	z := newTestZSet(DefaultConfig())
+	for i, score := range scores {
+		z.Set("m"+strconv.Itoa(i), score)
+	}
+	assert.Equal(t, "listpack", z.Encoding())
+	for i, entry := range z.Entries() {
+		assert.Equal(t, scores[i], entry.Score)
+	}
+}
+
+func TestZSet_CloneIsIndependent(t *testing.T) {
+	for _, config := range []Config{DefaultConfig(), {}} {
+		z := newTestZSet(config, scored("a", 1), scored("b", 2))
+		clone := z.Clone().(*ZSet)
+		clone.Set("c", 3)
+		z.Remove("a")
+
+		assert.Equal(t, z.Encoding(), clone.Encoding())
+		assert.Equal(t, []zsets.ScoredMember{scored("b", 2)}, z.Entries())
+		assert.Equal(t, []zsets.ScoredMember{scored("a", 1), scored("b", 2), scored("c", 3)}, clone.Entries())
+	}
+}
+
+func TestZSet_MemoryUsageGrowsInSkiplist(t *testing.T) {
+	z := newTestZSet(Config{}, scored("a", 0))
+	before := z.MemoryUsage()
+	z.Set(strings.Repeat("x", 100), 1)
+	assert.Equal(t, before+100+zsetMemberOverhead, z.MemoryUsage())
+}
+
+func TestZSet_RangeInEveryEncoding(t *testing.T) {
+	members := []zsets.ScoredMember{scored("a", 1), scored("b", 2), scored("c", 2), scored("d", 3), scored("e", 4)}
+	scoreRange := func(min, max string) zsets.ScoreRange {
+		r, _ := zsets.ParseScoreRange(min, max)
+		return r
+	}
+	lexRange := func(min, max string) zsets.LexRange {
+		r, _ := zsets.ParseLexRange(min, max)
+		return r
+	}
+	tests := []struct {
+		name     string
+		spec     zsets.RangeSpec
+		expected []string
+	}{
+		{"every rank", zsets.RangeSpec{Start: 0, Stop: -1}, []string{"a", "b", "c", "d", "e"}},
+		{"ranks clamped", zsets.RangeSpec{Start: -100, Stop: 1}, []string{"a", "b"}},
+		{"ranks reversed", zsets.RangeSpec{Start: 0, Stop: 1, Reverse: true}, []string{"e", "d"}},
+		{"ranks out of range", zsets.RangeSpec{Start: 5, Stop: 10}, nil},
+		{"score", zsets.RangeSpec{By: zsets.ByScore, Score: scoreRange("(1", "3"), Count: -1}, []string{"b", "c", "d"}},
+		{"score reversed", zsets.RangeSpec{By: zsets.ByScore, Score: scoreRange("2", "+inf"), Reverse: true, Count: -1}, []string{"e", "d", "c", "b"}},
+		{"score limited", zsets.RangeSpec{By: zsets.ByScore, Score: scoreRange("-inf", "+inf"), Offset: 1, Count: 2}, []string{"b", "c"}},
+		{"score reversed and limited", zsets.RangeSpec{By: zsets.ByScore, Score: scoreRange("-inf", "3"), Reverse: true, Offset: 1, Count: 2}, []string{"c", "b"}},
+		{"score offset past the range", zsets.RangeSpec{By: zsets.ByScore, Score: scoreRange("1", "2"), Offset: 3, Count: -1}, nil},
+		{"score empty range", zsets.RangeSpec{By: zsets.ByScore, Score: scoreRange("3", "(3"), Count: -1}, nil},
+		{"lex", zsets.RangeSpec{By: zsets.ByLex, Lex: lexRange("(a", "[c"), Count: -1}, []string{"b", "c"}},
+		{"lex reversed", zsets.RangeSpec{By: zsets.ByLex, Lex: lexRange("-", "(c"), Reverse: true, Count: -1}, []string{"b", "a"}},
+		{"lex limited", zsets.RangeSpec{By: zsets.ByLex, Lex: lexRange("-", "+"), Offset: 3, Count: 5}, []string{"d", "e"}},
+	}
+	for _, config := range []Config{DefaultConfig(), {}} {
+		z := newTestZSet(config, members...)
+		for _, tt := range tests {
+			var got []string
+			for _, entry := range z.Range(tt.spec) {
+				got = append(got, entry.Member)
+			}
+			assert.Equal(t, tt.expected, got, "%s in %s", tt.name, z.Encoding())
+			if tt.spec.Offset == 0 && tt.spec.Count < 0 {
+				assert.Equal(t, len(tt.expected), z.Count(tt.spec), "%s in %s", tt.name, z.Encoding())
+			}
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
+	"avacado/internal/storage/zsets"
+	"context"
+	"math"
+)
+
+// ZSets holds all named sorted sets in the shared keyspace, a sorted set left without members is
+// removed from it. All methods are called exclusively by the executor goroutine — no locking needed.
+type ZSets struct {
+	keyspace *memkeyspace.Keyspace
+	config   *Config
+}
+
+func NewZSets(ks *memkeyspace.Keyspace, config Config) *ZSets {
+	return &ZSets{
+		keyspace: ks,
+		config:   &config,
+	}
+}
+
+// lookup returns the sorted set stored at key, or nil if key does not exist.
+// ErrWrongType is returned if key holds a value of another type.
+func (z *ZSets) lookup(key string) (*ZSet, error) {
+	entry, err := z.keyspace.LookupOfType(key, keyspace.TypeZSet)
+	if entry == nil {
+		return nil, err
+	}
+	return entry.Value.(*ZSet), nil
+}
+
+// create stores an empty sorted set at key, in the encoding fitting members.
+func (z *ZSets) create(key string, members []zsets.ScoredMember) *ZSet {
+	maxMemberLength := 0
+	for _, member := range members {
+		maxMemberLength = max(maxMemberLength, len(member.Member))
+	}
+	zset := newZSet(z.config, len(members), maxMemberLength)
+	z.keyspace.Put(key, keyspace.TypeZSet, zset)
+	return zset
+}
+
+// removeIfEmpty removes key once its sorted set has no member left, and accounts for its new size otherwise.
+func (z *ZSets) removeIfEmpty(key string, zset *ZSet) {
+	if zset.Len() == 0 {
+		z.keyspace.Remove(key)
+		return
+	}
+	z.keyspace.Resized(key)
+}
+
+// allowsUpdate reports whether options let an existing member move from score to newScore.
+func allowsUpdate(options zsets.AddOptions, score, newScore float64) bool {
+	return !options.NX && (!options.GT || newScore > score) && (!options.LT || newScore < score)
+}
+
+func (z *ZSets) ZAdd(_ context.Context, key string, members []zsets.ScoredMember, options zsets.AddOptions) (int, error) {
+	zset, err := z.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	if zset == nil {
+		if options.XX {
+			return 0, nil
+		}
+		zset = z.create(key, members)
+	}
+	added, changed := 0, 0
+	for _, member := range members {
+		score, found := zset.Score(member.Member)
+		switch {
+		case !found && !options.XX:
+			zset.Set(member.Member, member.Score)
+			added++
+		case found && score != member.Score && allowsUpdate(options, score, member.Score):
+			zset.Set(member.Member, member.Score)
+			changed++
+		}
+	}
+	z.keyspace.Resized(key)
+	if options.CH {
+		return added + changed, nil
+	}
+	return added, nil
+}
+
+func (z *ZSets) ZIncrBy(_ context.Context, key string, member string, increment float64, options zsets.AddOptions) (float64, bool, error) {
+	zset, err := z.lookup(key)
+	if err != nil {
+		return 0, false, err
+	}
+	var score float64
+	found := false
+	if zset != nil {
+		score, found = zset.Score(member)
+	}
+	if (found && options.NX) || (!found && options.XX) {
+		return 0, false, nil
+	}
+	newScore := score + increment
+	if math.IsNaN(newScore) {
+		return 0, false, zsets.ErrNotANumber
+	}
+	if found && !allowsUpdate(options, score, newScore) {
+		return 0, false, nil
+	}
+	if zset == nil {
+		zset = z.create(key, []zsets.ScoredMember{{Member: member}})
+	}
+	zset.Set(member, newScore)
+	z.keyspace.Resized(key)
+	return newScore, true, nil
+}
+
+func (z *ZSets) ZCard(_ context.Context, key string) (int, error) {
+	zset, err := z.lookup(key)
+	if zset == nil {
+		return 0, err
+	}
+	return zset.Len(), nil
+}
+
+func (z *ZSets) ZScore(_ context.Context, key string, member string) (float64, bool, error) {
+	zset, err := z.lookup(key)
+	if zset == nil {
+		return 0, false, err
+	}
+	score, found := zset.Score(member)
+	return score, found, nil
+}
+
+func (z *ZSets) ZMScore(_ context.Context, key string, members []string) ([]*float64, error) {
+	zset, err := z.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	scores := make([]*float64, len(members))
+	if zset == nil {
+		return scores, nil
+	}
+	for i, member := range members {
+		if score, found := zset.Score(member); found {
+			scores[i] = &score
+		}
+	}
+	return scores, nil
+}
+
+func (z *ZSets) ZRank(_ context.Context, key string, member string, reverse bool) (int, float64, bool, error) {
+	zset, err := z.lookup(key)
+	if zset == nil {
+		return 0, 0, false, err
+	}
+	rank, score, found := zset.Rank(member, reverse)
+	return rank, score, found, nil
+}
+
+// ZRem removes members from the sorted set stored at key and returns how many were present.
+func (z *ZSets) ZRem(_ context.Context, key string, members []string) (int, error) {
+	zset, err := z.lookup(key)
+	if zset == nil {
+		return 0, err
+	}
+	removed := 0
+	for _, member := range members {
+		if zset.Remove(member) {
+			removed++
+		}
+	}
+	z.removeIfEmpty(key, zset)
+	return removed, nil
+}
+
+func (z *ZSets) ZRange(_ context.Context, key string, spec zsets.RangeSpec) ([]zsets.ScoredMember, error) {
+	zset, err := z.lookup(key)
+	if zset == nil {
+		return nil, err
+	}
+	return zset.Range(spec), nil
+}
+
+func (z *ZSets) ZRangeStore(_ context.Context, destination, key string, spec zsets.RangeSpec) (int, error) {
+	zset, err := z.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	var members []zsets.ScoredMember
+	if zset != nil {
+		members = zset.Range(spec)
+	}
+	return z.store(destination, members), nil
+}
+
+func (z *ZSets) ZCount(_ context.Context, key string, spec zsets.RangeSpec) (int, error) {
+	zset, err := z.lookup(key)
+	if zset == nil {
+		return 0, err
+	}
+	return zset.Count(spec), nil
+}
+
+func (z *ZSets) ZRemRange(_ context.Context, key string, spec zsets.RangeSpec) (int, error) {
+	zset, err := z.lookup(key)
+	if zset == nil {
+		return 0, err
+	}
+	spec.Offset, spec.Count = 0, -1
+	members := zset.Range(spec)
+	for _, member := range members {
+		zset.Remove(member.Member)
+	}
+	z.removeIfEmpty(key, zset)
+	return len(members), nil
+}
+
+func (z *ZSets) ZPop(_ context.Context, key string, count int, highest bool) ([]zsets.ScoredMember, error) {
+	zset, err := z.lookup(key)
+	if zset == nil || count <= 0 {
+		return nil, err
+	}
+	members := zset.Range(zsets.RangeSpec{Start: 0, Stop: count - 1, Reverse: highest})
+	for _, member := range members {
+		zset.Remove(member.Member)
+	}
+	z.removeIfEmpty(key, zset)
+	return members, nil
+}
@@ -0,0 +1,227 @@
+package memory
+
+import (
+	"avacado/internal/storage/keyspace"
+	memkeyspace "avacado/internal/storage/keyspace/memory"
+	"avacado/internal/storage/zsets"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// zsetAt returns the sorted set stored at key, failing loudly if key is not a sorted set
+func zsetAt(z *ZSets, key string) *ZSet {
+	zset, err := z.lookup(key)
+	if err != nil {
+		panic(err)
+	}
+	return zset
+}
+
+func TestZSets_ZAdd(t *testing.T) {
+	z := NewZSets(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+
+	added, err := z.ZAdd(ctx, "zset", []zsets.ScoredMember{scored("a", 1), scored("b", 2), scored("a", 3)}, zsets.AddOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, added)
+	assert.Equal(t, []zsets.ScoredMember{scored("b", 2), scored("a", 3)}, zsetAt(z, "zset").Entries())
+
+	added, _ = z.ZAdd(ctx, "zset", []zsets.ScoredMember{scored("a", 0), scored("c", 5)}, zsets.AddOptions{})
+	assert.Equal(t, 1, added)
+	score, _, _ := z.ZScore(ctx, "zset", "a")
+	assert.Equal(t, 0.0, score)
+}
+
+func TestZSets_ZAddOptions(t *testing.T) {
+	ctx := context.Background()
+	for _, test := range []struct {
+		name     string
+		options  zsets.AddOptions
+		expected int
+		entries  []zsets.ScoredMember
+	}{
+		{"NX only adds", zsets.AddOptions{NX: true}, 1, []zsets.ScoredMember{scored("a", 1), scored("b", 2), scored("c", 3)}},
+		{"XX only updates", zsets.AddOptions{XX: true}, 0, []zsets.ScoredMember{scored("b", 0), scored("a", 5)}},
+		{"GT updates to greater scores", zsets.AddOptions{GT: true, CH: true}, 2, []zsets.ScoredMember{scored("b", 2), scored("c", 3), scored("a", 5)}},
+		{"LT updates to lower scores", zsets.AddOptions{LT: true}, 1, []zsets.ScoredMember{scored("b", 0), scored("a", 1), scored("c", 3)}},
+		{"CH counts changes", zsets.AddOptions{CH: true}, 3, []zsets.ScoredMember{scored("b", 0), scored("c", 3), scored("a", 5)}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			z := NewZSets(memkeyspace.NewKeyspace(), DefaultConfig())
+			_, _ = z.ZAdd(ctx, "zset", []zsets.ScoredMember{scored("a", 1), scored("b", 2)}, zsets.AddOptions{})
+
+			n, err := z.ZAdd(ctx, "zset", []zsets.ScoredMember{scored("a", 5), scored("b", 0), scored("c", 3)}, test.options)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, n)
+			assert.Equal(t, test.entries, zsetAt(z, "zset").Entries())
+		})
+	}
+}
+
+func TestZSets_ZAddXXDoesNotCreateTheKey(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	z := NewZSets(ks, DefaultConfig())
+
+	n, err := z.ZAdd(context.Background(), "zset", []zsets.ScoredMember{scored("a", 1)}, zsets.AddOptions{XX: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 0, ks.Len())
+}
+
+func TestZSets_ZIncrBy(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	z := NewZSets(ks, DefaultConfig())
+	ctx := context.Background()
+
+	score, ok, err := z.ZIncrBy(ctx, "zset", "a", 2.5, zsets.AddOptions{})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2.5, score)
+
+	score, ok, _ = z.ZIncrBy(ctx, "zset", "a", -1, zsets.AddOptions{})
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, score)
+
+	_, ok, _ = z.ZIncrBy(ctx, "zset", "a", 1, zsets.AddOptions{NX: true})
+	assert.False(t, ok)
+	_, ok, _ = z.ZIncrBy(ctx, "zset", "a", -1, zsets.AddOptions{GT: true})
+	assert.False(t, ok)
+	_, ok, _ = z.ZIncrBy(ctx, "other", "a", 1, zsets.AddOptions{XX: true})
+	assert.False(t, ok)
+	assert.Equal(t, 1, ks.Len())
+
+	_, _, _ = z.ZIncrBy(ctx, "zset", "b", math.Inf(1), zsets.AddOptions{})
+	_, _, err = z.ZIncrBy(ctx, "zset", "b", math.Inf(-1), zsets.AddOptions{})
+	assert.ErrorIs(t, err, zsets.ErrNotANumber)
+	score, _, _ = z.ZScore(ctx, "zset", "b")
+	assert.Equal(t, math.Inf(1), score)
+
+	_, _, err = z.ZIncrBy(ctx, "nan", "a", math.NaN(), zsets.AddOptions{})
+	assert.ErrorIs(t, err, zsets.ErrNotANumber)
+	assert.Equal(t, 1, ks.Len())
+}
+
+func TestZSets_Queries(t *testing.T) {
+	z := NewZSets(memkeyspace.NewKeyspace(), DefaultConfig())
+	ctx := context.Background()
+	_, _ = z.ZAdd(ctx, "zset", []zsets.ScoredMember{scored("a", 1), scored("b", 2), scored("c", 3)}, zsets.AddOptions{})
+
+	card, _ := z.ZCard(ctx, "zset")
+	assert.Equal(t, 3, card)
+	card, _ = z.ZCard(ctx, "missing")
+	assert.Equal(t, 0, card)
+
+	score, found, _ := z.ZScore(ctx, "zset", "b")
+	assert.True(t, found)
+	assert.Equal(t, 2.0, score)
+	_, found, _ = z.ZScore(ctx, "missing", "b")
+	assert.False(t, found)
+
+	scores, _ := z.ZMScore(ctx, "zset", []string{"c", "missing"})
+	assert.Equal(t, 3.0, *scores[0])
+	assert.Nil(t, scores[1])
+	scores, _ = z.ZMScore(ctx, "missing", []string{"a"})
+	assert.Equal(t, []*float64{nil}, scores)
+
+	rank, score, found, _ := z.ZRank(ctx, "zset", "c", false)
+	assert.True(t, found)
+	assert.Equal(t, 2, rank)
+	assert.Equal(t, 3.0, score)
+	rank, _, _, _ = z.ZRank(ctx, "zset", "c", true)
+	assert.Equal(t, 0, rank)
+	_, _, found, _ = z.ZRank(ctx, "missing", "c", false)
+	assert.False(t, found)
+}
+
+func TestZSets_ZRangeStore(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	z := NewZSets(ks, DefaultConfig())
+	ctx := context.Background()
+	_, _ = z.ZAdd(ctx, "zset", []zsets.ScoredMember{scored("a", 1), scored("b", 2), scored("c", 3)}, zsets.AddOptions{})
+	ks.Put("destination", keyspace.TypeString, "value")
+
+	stored, err := z.ZRangeStore(ctx, "destination", "zset", zsets.RangeSpec{Start: 1, Stop: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stored)
+	assert.Equal(t, []zsets.ScoredMember{scored("b", 2), scored("c", 3)}, zsetAt(z, "destination").Entries())
+
+	stored, err = z.ZRangeStore(ctx, "destination", "missing", zsets.RangeSpec{Start: 0, Stop: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stored)
+	_, found := ks.Lookup("destination")
+	assert.False(t, found)
+}
+
+func TestZSets_ZRemRange(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	z := NewZSets(ks, DefaultConfig())
+	ctx := context.Background()
+	_, _ = z.ZAdd(ctx, "zset", []zsets.ScoredMember{scored("a", 1), scored("b", 2), scored("c", 3)}, zsets.AddOptions{})
+
+	scoreRange, _ := zsets.ParseScoreRange("(1", "2")
+	removed, err := z.ZRemRange(ctx, "zset", zsets.RangeSpec{By: zsets.ByScore, Score: scoreRange})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	count, _ := z.ZCount(ctx, "zset", zsets.RangeSpec{Start: 0, Stop: -1})
+	assert.Equal(t, 2, count)
+
+	removed, _ = z.ZRemRange(ctx, "zset", zsets.RangeSpec{Start: 0, Stop: -1})
+	assert.Equal(t, 2, removed)
+	_, found := ks.Lookup("zset")
+	assert.False(t, found)
+}
+
+func TestZSets_ZPop(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	z := NewZSets(ks, DefaultConfig())
+	ctx := context.Background()
+	_, _ = z.ZAdd(ctx, "zset", []zsets.ScoredMember{scored("a", 1), scored("b", 2), scored("c", 3)}, zsets.AddOptions{})
+
+	members, err := z.ZPop(ctx, "zset", 2, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []zsets.ScoredMember{scored("c", 3), scored("b", 2)}, members)
+
+	members, _ = z.ZPop(ctx, "zset", 0, false)
+	assert.Empty(t, members)
+
+	members, _ = z.ZPop(ctx, "zset", 5, false)
+	assert.Equal(t, []zsets.ScoredMember{scored("a", 1)}, members)
+	_, found := ks.Lookup("zset")
+	assert.False(t, found)
+}
+
+func TestZSets_WrongType(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	z := NewZSets(ks, DefaultConfig())
+	ctx := context.Background()
+	ks.Put("str", keyspace.TypeString, struct{}{})
+
+	_, err := z.ZAdd(ctx, "str", []zsets.ScoredMember{scored("a", 1)}, zsets.AddOptions{})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, _, err = z.ZIncrBy(ctx, "str", "a", 1, zsets.AddOptions{})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = z.ZCard(ctx, "str")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, _, err = z.ZScore(ctx, "str", "a")
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = z.ZMScore(ctx, "str", []string{"a"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, _, _, err = z.ZRank(ctx, "str", "a", false)
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+	_, err = z.ZRem(ctx, "str", []string{"a"})
+	assert.ErrorIs(t, err, keyspace.ErrWrongType)
+}
+
+func TestZSets_RemovesEmptySortedSet(t *testing.T) {
+	ks := memkeyspace.NewKeyspace()
+	z := NewZSets(ks, DefaultConfig())
+	ctx := context.Background()
+
+	_, _ = z.ZAdd(ctx, "zset", []zsets.ScoredMember{scored("a", 1), scored("b", 2)}, zsets.AddOptions{})
+	removed, _ := z.ZRem(ctx, "zset", []string{"a", "b", "c"})
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, 0, ks.Len())
+}
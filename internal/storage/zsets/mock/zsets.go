@@ -0,0 +1,331 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: zsets.go
+//
+// Generated by this command:
+//
+//	mockgen -source=zsets.go -destination=mock/zsets.go -package=mockzsets
+//
+
+// Package mockzsets is a generated GoMock package.
+package mockzsets
+
+import (
+	zsets "avacado/internal/storage/zsets"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockZSets is a mock of ZSets interface.
+type MockZSets struct {
+	ctrl     *gomock.Controller
+	recorder *MockZSetsMockRecorder
+	isgomock struct{}
+}
+
+// MockZSetsMockRecorder is the mock recorder for MockZSets.
+type MockZSetsMockRecorder struct {
+	mock *MockZSets
+}
+
+// NewMockZSets creates a new mock instance.
+func NewMockZSets(ctrl *gomock.Controller) *MockZSets {
+	mock := &MockZSets{ctrl: ctrl}
+	mock.recorder = &MockZSetsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockZSets) EXPECT() *MockZSetsMockRecorder {
+	return m.recorder
+}
+
+// ZAdd mocks base method.
+func (m *MockZSets) ZAdd(ctx context.Context, key string, members []zsets.ScoredMember, options zsets.AddOptions) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZAdd", ctx, key, members, options)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZAdd indicates an expected call of ZAdd.
+func (mr *MockZSetsMockRecorder) ZAdd(ctx, key, members, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZAdd", reflect.TypeOf((*MockZSets)(nil).ZAdd), ctx, key, members, options)
+}
+
+// ZCard mocks base method.
+func (m *MockZSets) ZCard(ctx context.Context, key string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZCard", ctx, key)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZCard indicates an expected call of ZCard.
+func (mr *MockZSetsMockRecorder) ZCard(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZCard", reflect.TypeOf((*MockZSets)(nil).ZCard), ctx, key)
+}
+
+// ZCount mocks base method.
+func (m *MockZSets) ZCount(ctx context.Context, key string, spec zsets.RangeSpec) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZCount", ctx, key, spec)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZCount indicates an expected call of ZCount.
+func (mr *MockZSetsMockRecorder) ZCount(ctx, key, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZCount", reflect.TypeOf((*MockZSets)(nil).ZCount), ctx, key, spec)
+}
+
+// ZDiff mocks base method.
+func (m *MockZSets) ZDiff(ctx context.Context, keys []string) ([]zsets.ScoredMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZDiff", ctx, keys)
+	ret0, _ := ret[0].([]zsets.ScoredMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZDiff indicates an expected call of ZDiff.
+func (mr *MockZSetsMockRecorder) ZDiff(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZDiff", reflect.TypeOf((*MockZSets)(nil).ZDiff), ctx, keys)
+}
+
+// ZDiffStore mocks base method.
+func (m *MockZSets) ZDiffStore(ctx context.Context, destination string, keys []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZDiffStore", ctx, destination, keys)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZDiffStore indicates an expected call of ZDiffStore.
+func (mr *MockZSetsMockRecorder) ZDiffStore(ctx, destination, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZDiffStore", reflect.TypeOf((*MockZSets)(nil).ZDiffStore), ctx, destination, keys)
+}
+
+// ZIncrBy mocks base method.
+func (m *MockZSets) ZIncrBy(ctx context.Context, key, member string, increment float64, options zsets.AddOptions) (float64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZIncrBy", ctx, key, member, increment, options)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ZIncrBy indicates an expected call of ZIncrBy.
+func (mr *MockZSetsMockRecorder) ZIncrBy(ctx, key, member, increment, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZIncrBy", reflect.TypeOf((*MockZSets)(nil).ZIncrBy), ctx, key, member, increment, options)
+}
+
+// ZInter mocks base method.
+func (m *MockZSets) ZInter(ctx context.Context, keys []string, options zsets.CombineOptions) ([]zsets.ScoredMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZInter", ctx, keys, options)
+	ret0, _ := ret[0].([]zsets.ScoredMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZInter indicates an expected call of ZInter.
+func (mr *MockZSetsMockRecorder) ZInter(ctx, keys, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZInter", reflect.TypeOf((*MockZSets)(nil).ZInter), ctx, keys, options)
+}
+
+// ZInterCard mocks base method.
+func (m *MockZSets) ZInterCard(ctx context.Context, keys []string, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZInterCard", ctx, keys, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZInterCard indicates an expected call of ZInterCard.
+func (mr *MockZSetsMockRecorder) ZInterCard(ctx, keys, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZInterCard", reflect.TypeOf((*MockZSets)(nil).ZInterCard), ctx, keys, limit)
+}
+
+// ZInterStore mocks base method.
+func (m *MockZSets) ZInterStore(ctx context.Context, destination string, keys []string, options zsets.CombineOptions) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZInterStore", ctx, destination, keys, options)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZInterStore indicates an expected call of ZInterStore.
+func (mr *MockZSetsMockRecorder) ZInterStore(ctx, destination, keys, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZInterStore", reflect.TypeOf((*MockZSets)(nil).ZInterStore), ctx, destination, keys, options)
+}
+
+// ZMScore mocks base method.
+func (m *MockZSets) ZMScore(ctx context.Context, key string, members []string) ([]*float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZMScore", ctx, key, members)
+	ret0, _ := ret[0].([]*float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZMScore indicates an expected call of ZMScore.
+func (mr *MockZSetsMockRecorder) ZMScore(ctx, key, members any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZMScore", reflect.TypeOf((*MockZSets)(nil).ZMScore), ctx, key, members)
+}
+
+// ZPop mocks base method.
+func (m *MockZSets) ZPop(ctx context.Context, key string, count int, highest bool) ([]zsets.ScoredMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZPop", ctx, key, count, highest)
+	ret0, _ := ret[0].([]zsets.ScoredMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZPop indicates an expected call of ZPop.
+func (mr *MockZSetsMockRecorder) ZPop(ctx, key, count, highest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZPop", reflect.TypeOf((*MockZSets)(nil).ZPop), ctx, key, count, highest)
+}
+
+// ZRange mocks base method.
+func (m *MockZSets) ZRange(ctx context.Context, key string, spec zsets.RangeSpec) ([]zsets.ScoredMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZRange", ctx, key, spec)
+	ret0, _ := ret[0].([]zsets.ScoredMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZRange indicates an expected call of ZRange.
+func (mr *MockZSetsMockRecorder) ZRange(ctx, key, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZRange", reflect.TypeOf((*MockZSets)(nil).ZRange), ctx, key, spec)
+}
+
+// ZRangeStore mocks base method.
+func (m *MockZSets) ZRangeStore(ctx context.Context, destination, key string, spec zsets.RangeSpec) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZRangeStore", ctx, destination, key, spec)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZRangeStore indicates an expected call of ZRangeStore.
+func (mr *MockZSetsMockRecorder) ZRangeStore(ctx, destination, key, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZRangeStore", reflect.TypeOf((*MockZSets)(nil).ZRangeStore), ctx, destination, key, spec)
+}
+
+// ZRank mocks base method.
+func (m *MockZSets) ZRank(ctx context.Context, key, member string, reverse bool) (int, float64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZRank", ctx, key, member, reverse)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ZRank indicates an expected call of ZRank.
+func (mr *MockZSetsMockRecorder) ZRank(ctx, key, member, reverse any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZRank", reflect.TypeOf((*MockZSets)(nil).ZRank), ctx, key, member, reverse)
+}
+
+// ZRem mocks base method.
+func (m *MockZSets) ZRem(ctx context.Context, key string, members []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZRem", ctx, key, members)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZRem indicates an expected call of ZRem.
+func (mr *MockZSetsMockRecorder) ZRem(ctx, key, members any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZRem", reflect.TypeOf((*MockZSets)(nil).ZRem), ctx, key, members)
+}
+
+// ZRemRange mocks base method.
+func (m *MockZSets) ZRemRange(ctx context.Context, key string, spec zsets.RangeSpec) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZRemRange", ctx, key, spec)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZRemRange indicates an expected call of ZRemRange.
+func (mr *MockZSetsMockRecorder) ZRemRange(ctx, key, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZRemRange", reflect.TypeOf((*MockZSets)(nil).ZRemRange), ctx, key, spec)
+}
+
+// ZScore mocks base method.
+func (m *MockZSets) ZScore(ctx context.Context, key, member string) (float64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZScore", ctx, key, member)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ZScore indicates an expected call of ZScore.
+func (mr *MockZSetsMockRecorder) ZScore(ctx, key, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZScore", reflect.TypeOf((*MockZSets)(nil).ZScore), ctx, key, member)
+}
+
+// ZUnion mocks base method.
+func (m *MockZSets) ZUnion(ctx context.Context, keys []string, options zsets.CombineOptions) ([]zsets.ScoredMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZUnion", ctx, keys, options)
+	ret0, _ := ret[0].([]zsets.ScoredMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZUnion indicates an expected call of ZUnion.
+func (mr *MockZSetsMockRecorder) ZUnion(ctx, keys, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZUnion", reflect.TypeOf((*MockZSets)(nil).ZUnion), ctx, keys, options)
+}
+
+// ZUnionStore mocks base method.
+func (m *MockZSets) ZUnionStore(ctx context.Context, destination string, keys []string, options zsets.CombineOptions) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZUnionStore", ctx, destination, keys, options)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ZUnionStore indicates an expected call of ZUnionStore.
+func (mr *MockZSetsMockRecorder) ZUnionStore(ctx, destination, keys, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZUnionStore", reflect.TypeOf((*MockZSets)(nil).ZUnionStore), ctx, destination, keys, options)
+}
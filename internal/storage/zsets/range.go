@@ -0,0 +1,146 @@
+package zsets
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+var (
+	// ErrNotFloatRange is returned when a bound of a score range is not a valid float.
+	ErrNotFloatRange = errors.New("ERR min or max is not a float")
+	// ErrNotLexRange is returned when a bound of a lex range is not -, + or a member prefixed by ( or [.
+	ErrNotLexRange = errors.New("ERR min or max not valid string range item")
+)
+
+// ScoreRange is a range of scores, each bound included unless marked exclusive.
+type ScoreRange struct {
+	Min, Max                   float64
+	MinExclusive, MaxExclusive bool
+}
+
+// ParseScoreRange parses the min and max bounds of a score range, a bound being excluded when
+// prefixed by (.
+func ParseScoreRange(min, max string) (ScoreRange, error) {
+	var r ScoreRange
+	var err error
+	if r.Min, r.MinExclusive, err = parseScoreBound(min); err != nil {
+		return ScoreRange{}, err
+	}
+	if r.Max, r.MaxExclusive, err = parseScoreBound(max); err != nil {
+		return ScoreRange{}, err
+	}
+	return r, nil
+}
+
+func parseScoreBound(bound string) (float64, bool, error) {
+	value, exclusive := strings.CutPrefix(bound, "(")
+	score, err := ParseScore(value)
+	if err != nil || math.IsNaN(score) {
+		return 0, false, ErrNotFloatRange
+	}
+	return score, exclusive, nil
+}
+
+// AboveMin reports whether score is not below the min bound.
+func (r ScoreRange) AboveMin(score float64) bool {
+	if r.MinExclusive {
+		return score > r.Min
+	}
+	return score >= r.Min
+}
+
+// BelowMax reports whether score is not above the max bound.
+func (r ScoreRange) BelowMax(score float64) bool {
+	if r.MaxExclusive {
+		return score < r.Max
+	}
+	return score <= r.Max
+}
+
+// LexBound is a bound of a lex range: a member, or - and + standing below and above every member.
+type LexBound struct {
+	Value     string
+	Exclusive bool
+	// Infinity is -1 for -, 1 for + and 0 for a bound holding a member.
+	Infinity int
+}
+
+// LexRange is a range of members compared byte by byte, meant for members sharing a score.
+type LexRange struct {
+	Min, Max LexBound
+}
+
+// ParseLexRange parses the min and max bounds of a lex range: - or +, or a member prefixed by
+// [ to include it or ( to exclude it.
+func ParseLexRange(min, max string) (LexRange, error) {
+	var r LexRange
+	var err error
+	if r.Min, err = parseLexBound(min); err != nil {
+		return LexRange{}, err
+	}
+	if r.Max, err = parseLexBound(max); err != nil {
+		return LexRange{}, err
+	}
+	return r, nil
+}
+
+func parseLexBound(bound string) (LexBound, error) {
+	switch {
+	case bound == "-":
+		return LexBound{Infinity: -1}, nil
+	case bound == "+":
+		return LexBound{Infinity: 1}, nil
+	case strings.HasPrefix(bound, "["):
+		return LexBound{Value: bound[1:]}, nil
+	case strings.HasPrefix(bound, "("):
+		return LexBound{Value: bound[1:], Exclusive: true}, nil
+	}
+	return LexBound{}, ErrNotLexRange
+}
+
+// AboveMin reports whether member is not below the min bound.
+func (r LexRange) AboveMin(member string) bool {
+	if r.Min.Infinity != 0 {
+		return r.Min.Infinity < 0
+	}
+	if r.Min.Exclusive {
+		return member > r.Min.Value
+	}
+	return member >= r.Min.Value
+}
+
+// BelowMax reports whether member is not above the max bound.
+func (r LexRange) BelowMax(member string) bool {
+	if r.Max.Infinity != 0 {
+		return r.Max.Infinity > 0
+	}
+	if r.Max.Exclusive {
+		return member < r.Max.Value
+	}
+	return member <= r.Max.Value
+}
+
+// RangeBy is what a RangeSpec ranges over.
+type RangeBy int
+
+const (
+	ByRank RangeBy = iota
+	ByScore
+	ByLex
+)
+
+// RangeSpec selects members the way ZRANGE does: by rank, score or lex range, in ascending
+// order or in descending order if Reverse is set.
+type RangeSpec struct {
+	By RangeBy
+	// Start and Stop are the ranks of a ByRank range, counted from the end when negative and
+	// from the highest score when Reverse is set.
+	Start, Stop int
+	Score       ScoreRange
+	Lex         LexRange
+	Reverse     bool
+	// Offset and Count are the LIMIT of a ByScore or ByLex range: Offset members in range are
+	// skipped, and at most Count are selected unless Count is negative.
+	Offset, Count int
+}
@@ -0,0 +1,51 @@
+package zsets
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScoreRange(t *testing.T) {
+	r, err := ParseScoreRange("(1", "+inf")
+	assert.NoError(t, err)
+	assert.Equal(t, ScoreRange{Min: 1, Max: math.Inf(1), MinExclusive: true}, r)
+	assert.False(t, r.AboveMin(1))
+	assert.True(t, r.AboveMin(1.5))
+	assert.True(t, r.BelowMax(math.Inf(1)))
+
+	r, err = ParseScoreRange("-inf", "(2.5")
+	assert.NoError(t, err)
+	assert.True(t, r.AboveMin(math.Inf(-1)))
+	assert.False(t, r.BelowMax(2.5))
+
+	for _, bounds := range [][2]string{{"a", "1"}, {"1", "(b"}, {"nan", "1"}, {"((1", "2"}} {
+		_, err = ParseScoreRange(bounds[0], bounds[1])
+		assert.ErrorIs(t, err, ErrNotFloatRange, bounds)
+	}
+}
+
+func TestParseLexRange(t *testing.T) {
+	r, err := ParseLexRange("[b", "(d")
+	assert.NoError(t, err)
+	assert.False(t, r.AboveMin("a"))
+	assert.True(t, r.AboveMin("b"))
+	assert.True(t, r.BelowMax("c"))
+	assert.False(t, r.BelowMax("d"))
+
+	r, err = ParseLexRange("-", "+")
+	assert.NoError(t, err)
+	assert.True(t, r.AboveMin(""))
+	assert.True(t, r.BelowMax("zzz"))
+
+	r, err = ParseLexRange("+", "-")
+	assert.NoError(t, err)
+	assert.False(t, r.AboveMin("a"))
+	assert.False(t, r.BelowMax("a"))
+
+	for _, bounds := range [][2]string{{"a", "+"}, {"-", "b"}, {"", "+"}} {
+		_, err = ParseLexRange(bounds[0], bounds[1])
+		assert.ErrorIs(t, err, ErrNotLexRange, bounds)
+	}
+}
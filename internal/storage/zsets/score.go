@@ -0,0 +1,77 @@
+package zsets
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFloat is returned when a score or an increment is not a valid float.
+var ErrNotFloat = errors.New("ERR value is not a valid float")
+
+// ParseScore parses a score the way Redis does: -inf and +inf are valid scores, while NaN
+// and finite values too large for a float are not.
+func ParseScore(s string) (float64, error) {
+	score, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(score) {
+		return 0, ErrNotFloat
+	}
+	return score, nil
+}
+
+// FormatScore formats score as Redis replies it: the shortest digits that parse back to the same
+// score, written plainly unless the exponent is large enough for the scientific notation.
+func FormatScore(score float64) string {
+	switch {
+	case math.IsInf(score, 1):
+		return "inf"
+	case math.IsInf(score, -1):
+		return "-inf"
+	case score == 0:
+		if math.Signbit(score) {
+			return "-0"
+		}
+		return "0"
+	}
+	var b strings.Builder
+	if score < 0 {
+		b.WriteByte('-')
+		score = -score
+	}
+	// Shortest digits d.ddd and exponent e, so score is digits * 10^k with k = e - (len(digits) - 1).
+	scientific := strconv.FormatFloat(score, 'e', -1, 64)
+	mantissa, exponent, _ := strings.Cut(scientific, "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	e, _ := strconv.Atoi(exponent)
+	k := e - (len(digits) - 1)
+	absE := e
+	if absE < 0 {
+		absE = -absE
+	}
+	switch {
+	case k >= 0 && absE < len(digits)+7:
+		b.WriteString(digits)
+		b.WriteString(strings.Repeat("0", k))
+	case k < 0 && (k > -7 || absE < 4):
+		if offset := len(digits) + k; offset <= 0 {
+			b.WriteString("0.")
+			b.WriteString(strings.Repeat("0", -offset))
+			b.WriteString(digits)
+		} else {
+			b.WriteString(digits[:offset])
+			b.WriteByte('.')
+			b.WriteString(digits[offset:])
+		}
+	default:
+		b.WriteString(mantissa)
+		b.WriteByte('e')
+		if e < 0 {
+			b.WriteByte('-')
+		} else {
+			b.WriteByte('+')
+		}
+		b.WriteString(strconv.Itoa(absE))
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package zsets
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScore(t *testing.T) {
+	for input, expected := range map[string]float64{
+		"1":      1,
+		"-2.5":   -2.5,
+		"1e3":    1000,
+		"inf":    math.Inf(1),
+		"+inf":   math.Inf(1),
+		"-inf":   math.Inf(-1),
+		".5":     0.5,
+		"-0":     math.Copysign(0, -1),
+		"1e-400": 0,
+	} {
+		score, err := ParseScore(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, score, input)
+	}
+	for _, input := range []string{"", "abc", "nan", "1e400", " 1", "1 ", "1.5.2"} {
+		_, err := ParseScore(input)
+		assert.Equal(t, ErrNotFloat, err, input)
+	}
+}
+
+func TestFormatScore(t *testing.T) {
+	for score, expected := range map[float64]string{
+		0:                           "0",
+		1:                           "1",
+		-1:                          "-1",
+		1.5:                         "1.5",
+		0.1:                         "0.1",
+		-0.0001:                     "-0.0001",
+		1e-7:                        "1e-7",
+		1.5e-7:                      "1.5e-7",
+		0.000123:                    "0.000123",
+		1000000:                     "1000000",
+		1e15:                        "1e+15",
+		1e7:                         "10000000",
+		1e8:                         "1e+8",
+		1e21:                        "1e+21",
+		1.2345e22:                   "1.2345e+22",
+		123456.789:                  "123456.789",
+		3.141592653589793:           "3.141592653589793",
+		math.MaxFloat64:             "1.7976931348623157e+308",
+		math.SmallestNonzeroFloat64: "5e-324",
+		math.Inf(1):                 "inf",
+		math.Inf(-1):                "-inf",
+		math.Copysign(0, -1):        "-0",
+		9007199254740993:            "9007199254740992",
+	} {
+		assert.Equal(t, expected, FormatScore(score), expected)
+	}
+}
@@ -0,0 +1,85 @@
+package zsets
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotANumber is returned when an increment would leave a score that is not a number,
+// as adding +inf to -inf does.
+var ErrNotANumber = errors.New("ERR resulting score is not a number (NaN)")
+
+// ScoredMember is a member of a sorted set along with its score.
+type ScoredMember struct {
+	Member string
+	Score  float64
+}
+
+// AddOptions are the ZADD flags deciding which members are added or updated.
+type AddOptions struct {
+	// NX only adds new members, XX only updates existing ones.
+	NX, XX bool
+	// GT only updates a member when its new score is greater, LT when it is lower.
+	GT, LT bool
+	// CH counts the members whose score changed along with the ones added.
+	CH bool
+}
+
+// Aggregate is how the scores a member has in several inputs combine.
+type Aggregate int
+
+const (
+	AggregateSum Aggregate = iota
+	AggregateMin
+	AggregateMax
+)
+
+// CombineOptions are the WEIGHTS and AGGREGATE of ZUNION and ZINTER. The scores of each input
+// are multiplied by its weight, 1 for every input if Weights is nil.
+type CombineOptions struct {
+	Weights   []float64
+	Aggregate Aggregate
+}
+
+//go:generate sh -c "rm -f mock/zsets.go && mockgen -source=zsets.go -destination=mock/zsets.go -package=mockzsets"
+type ZSets interface {
+	// ZAdd adds members or updates their score as options allow, and returns how many were
+	// added, or added and changed with CH.
+	ZAdd(ctx context.Context, key string, members []ScoredMember, options AddOptions) (int, error)
+	// ZIncrBy adds increment to the score of member, a new member starting from 0, and returns
+	// the new score. It returns false instead if options prevent the update.
+	ZIncrBy(ctx context.Context, key string, member string, increment float64, options AddOptions) (float64, bool, error)
+	ZCard(ctx context.Context, key string) (int, error)
+	ZScore(ctx context.Context, key string, member string) (float64, bool, error)
+	// ZMScore returns the score of each member, nil for the ones missing.
+	ZMScore(ctx context.Context, key string, members []string) ([]*float64, error)
+	// ZRank returns the 0-based rank of member ordered by ascending score, or descending if
+	// reverse is set, along with its score.
+	ZRank(ctx context.Context, key string, member string, reverse bool) (int, float64, bool, error)
+	ZRem(ctx context.Context, key string, members []string) (int, error)
+	// ZRange returns the members spec selects along with their score, in the order it asks for.
+	ZRange(ctx context.Context, key string, spec RangeSpec) ([]ScoredMember, error)
+	// ZRangeStore replaces destination, whatever it held, with the members spec selects, or
+	// removes it if there are none, and returns how many were stored.
+	ZRangeStore(ctx context.Context, destination, key string, spec RangeSpec) (int, error)
+	// ZCount returns the number of members spec selects, ignoring its limit.
+	ZCount(ctx context.Context, key string, spec RangeSpec) (int, error)
+	// ZRemRange removes the members spec selects and returns how many were removed.
+	ZRemRange(ctx context.Context, key string, spec RangeSpec) (int, error)
+	// ZPop removes and returns up to count members with the lowest scores, or the highest if
+	// highest is set, in the order they are popped.
+	ZPop(ctx context.Context, key string, count int, highest bool) ([]ScoredMember, error)
+	// ZUnion, ZInter and ZDiff take sets as well as sorted sets, every member of a set scoring 1.
+	// Their result is ordered by score then member.
+	ZUnion(ctx context.Context, keys []string, options CombineOptions) ([]ScoredMember, error)
+	ZInter(ctx context.Context, keys []string, options CombineOptions) ([]ScoredMember, error)
+	// ZDiff returns the members of the first key found in none of the others, with their score.
+	ZDiff(ctx context.Context, keys []string) ([]ScoredMember, error)
+	// ZInterCard returns the size of the intersection, counting no further than limit unless limit is 0.
+	ZInterCard(ctx context.Context, keys []string, limit int) (int, error)
+	// ZUnionStore, ZInterStore and ZDiffStore replace destination with the result, whatever it
+	// held, or remove it if the result is empty, and return the size of the result.
+	ZUnionStore(ctx context.Context, destination string, keys []string, options CombineOptions) (int, error)
+	ZInterStore(ctx context.Context, destination string, keys []string, options CombineOptions) (int, error)
+	ZDiffStore(ctx context.Context, destination string, keys []string) (int, error)
+}